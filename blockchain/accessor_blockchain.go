@@ -10,6 +10,8 @@ import (
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/metadata/rpccaller"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 )
 
@@ -350,6 +352,52 @@ func (blockchain *BlockChain) GetBTCHeaderChain() *btcrelaying.BlockChain {
 	return blockchain.GetConfig().BTCChain
 }
 
-func (blockchain *BlockChain) GetPortalFeederAddress() string {
-	return blockchain.GetConfig().ChainParams.PortalFeederAddress
+// GetETHHeaderSource returns the source of the Ethereum headers set in the
+// config, the ETH node of the GETH_* environment variables by default
+func (blockchain *BlockChain) GetETHHeaderSource() metadata.ETHHeaderSource {
+	if blockchain.GetConfig().ETHHeaderSource == nil {
+		return metadata.NewRPCETHHeaderSource(rpccaller.BuildRPCServerAddress(metadata.EthereumLightNodeProtocol, metadata.EthereumLightNodeHost, metadata.EthereumLightNodePort))
+	}
+	return blockchain.GetConfig().ETHHeaderSource
+}
+
+// GetEVMHeaderSource returns the source of the headers of an EVM chain bridged
+// by the decentralized bridge, Ethereum for chain id 0
+func (blockchain *BlockChain) GetEVMHeaderSource(evmChainID uint64) (metadata.ETHHeaderSource, error) {
+	if metadata.IsETHChain(evmChainID) {
+		return blockchain.GetETHHeaderSource(), nil
+	}
+	if _, err := blockchain.GetEVMContractAddressStr(evmChainID, blockchain.GetBeaconBestState().BeaconHeight); err != nil {
+		return nil, err
+	}
+	source, ok := blockchain.GetConfig().EVMHeaderSources[evmChainID]
+	if !ok {
+		return nil, fmt.Errorf("no header source for EVM chain %d", evmChainID)
+	}
+	return source, nil
+}
+
+// GetEVMContractAddressStr returns the smart contract of the bridge on an EVM
+// chain at a beacon height, Ethereum for chain id 0
+func (blockchain *BlockChain) GetEVMContractAddressStr(evmChainID uint64, beaconHeight uint64) (string, error) {
+	chainParams := blockchain.GetConfig().ChainParams
+	if metadata.IsETHChain(evmChainID) {
+		return chainParams.EthContractAddressStr, nil
+	}
+	if beaconHeight < chainParams.BCHeightBreakPointEVMBridge {
+		return "", fmt.Errorf("EVM chain %d is not bridged before beacon height %d", evmChainID, chainParams.BCHeightBreakPointEVMBridge)
+	}
+	contractAddressStr, ok := chainParams.EVMContractAddressStrs[evmChainID]
+	if !ok {
+		return "", fmt.Errorf("EVM chain %d is not bridged", evmChainID)
+	}
+	return contractAddressStr, nil
+}
+
+func (blockchain *BlockChain) GetPortalFeederAddresses() []string {
+	return blockchain.GetConfig().ChainParams.PortalFeederAddresses
+}
+
+func (blockchain *BlockChain) GetPortalFeederGovernorAddress() string {
+	return blockchain.GetConfig().ChainParams.PortalFeederGovernorAddress
 }
@@ -336,6 +336,23 @@ func (blockchain *BlockChain) GetListOutputCoinsByKeyset(keyset *incognitokey.Ke
 			return nil, err
 		}
 	}
+	return decryptOutputCoinsByKeyset(transactionStateDB, outCointsInBytes, keyset, shardID, tokenID), nil
+}
+
+// GetListOutputCoinsByKeysetFromStateDB - same as GetListOutputCoinsByKeyset but
+// read from the given transaction state db (eg. the state of a past block) without cache
+func (blockchain *BlockChain) GetListOutputCoinsByKeysetFromStateDB(transactionStateDB *statedb.StateDB, keyset *incognitokey.KeySet, shardID byte, tokenID *common.Hash) ([]*privacy.OutputCoin, error) {
+	if keyset == nil {
+		return nil, NewBlockChainError(GetListOutputCoinsByKeysetError, fmt.Errorf("invalid key set, got keyset %+v", keyset))
+	}
+	outCointsInBytes, err := statedb.GetOutcoinsByPubkey(transactionStateDB, *tokenID, keyset.PaymentAddress.Pk[:], shardID)
+	if err != nil {
+		return nil, err
+	}
+	return decryptOutputCoinsByKeyset(transactionStateDB, outCointsInBytes, keyset, shardID, tokenID), nil
+}
+
+func decryptOutputCoinsByKeyset(transactionStateDB *statedb.StateDB, outCointsInBytes [][]byte, keyset *incognitokey.KeySet, shardID byte, tokenID *common.Hash) []*privacy.OutputCoin {
 	// convert from []byte to object
 	outCoins := make([]*privacy.OutputCoin, 0)
 	for _, item := range outCointsInBytes {
@@ -354,7 +371,7 @@ func (blockchain *BlockChain) GetListOutputCoinsByKeyset(keyset *incognitokey.Ke
 			results = append(results, decryptedOut)
 		}
 	}
-	return results, nil
+	return results
 }
 
 // CreateAndSaveTxViewPointFromBlock - fetch data from block, put into txviewpoint variable and save into db
@@ -40,6 +40,7 @@ type BeaconHeader struct {
 	ConsensusType                   string      `json:"ConsensusType"`
 	Producer                        string      `json:"Producer"`
 	ProducerPubKeyStr               string      `json:"ProducerPubKeyStr"`
+	PreviousStateRootsHash          common.Hash `json:"PreviousStateRootsHash"` // hash of the state roots of the previous block, empty before the state roots hash break point
 
 	//for version 2
 	Proposer    string `json:"Proposer"`
@@ -186,6 +187,9 @@ func (beaconHeader *BeaconHeader) toString() string {
 	res += beaconHeader.AutoStakingRoot.String()
 	res += beaconHeader.ShardStateHash.String()
 	res += beaconHeader.InstructionHash.String()
+	if !beaconHeader.PreviousStateRootsHash.IsEqual(&common.Hash{}) {
+		res += beaconHeader.PreviousStateRootsHash.String()
+	}
 
 	if beaconHeader.Version == 2 {
 		res += beaconHeader.Proposer
@@ -23,6 +23,7 @@ type UpdatingInfo struct {
 	deductAmt       uint64
 	tokenID         common.Hash
 	externalTokenID []byte
+	network         string // network of a decentralized token, empty for Ethereum
 	isCentralized   bool
 }
 
@@ -71,6 +72,7 @@ func (blockchain *BlockChain) processBridgeInstructions(bridgeStateDB *statedb.S
 			bridgeStateDB,
 			updatingInfo.tokenID,
 			updatingInfo.externalTokenID,
+			updatingInfo.network,
 			updatingInfo.isCentralized,
 			updatingAmt,
 			updatingType,
@@ -123,6 +125,7 @@ func (blockchain *BlockChain) processIssuingETHReq(bridgeStateDB *statedb.StateD
 			deductAmt:       0,
 			tokenID:         issuingETHAcceptedInst.IncTokenID,
 			externalTokenID: issuingETHAcceptedInst.ExternalTokenID,
+			network:         metadata.GetEVMNetwork(issuingETHAcceptedInst.EVMChainID),
 			isCentralized:   false,
 		}
 	}
@@ -238,6 +241,7 @@ func (blockchain *BlockChain) processBurningReq(instruction []string, updatingIn
 			deductAmt:       amount,
 			tokenID:         *incTokenID,
 			externalTokenID: externalTokenID,
+			network:         metadata.GetEVMNetwork(GetBurningConfirmEVMChainID(instruction)),
 			isCentralized:   false,
 		}
 	}
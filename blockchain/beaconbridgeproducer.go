@@ -39,22 +39,22 @@ func (blockchain *BlockChain) buildBridgeInstructions(stateDB *statedb.StateDB,
 
 		case metadata.BurningRequestMeta:
 			burningConfirm := []string{}
-			burningConfirm, err = buildBurningConfirmInst(stateDB, metadata.BurningConfirmMeta, inst, beaconHeight)
+			burningConfirm, err = blockchain.buildBurningConfirmInst(stateDB, metadata.BurningConfirmMeta, inst, beaconHeight)
 			newInst = [][]string{burningConfirm}
 
 		case metadata.BurningRequestMetaV2:
 			burningConfirm := []string{}
-			burningConfirm, err = buildBurningConfirmInst(stateDB, metadata.BurningConfirmMetaV2, inst, beaconHeight)
+			burningConfirm, err = blockchain.buildBurningConfirmInst(stateDB, metadata.BurningConfirmMetaV2, inst, beaconHeight)
 			newInst = [][]string{burningConfirm}
 
 		case metadata.BurningForDepositToSCRequestMeta:
 			burningConfirm := []string{}
-			burningConfirm, err = buildBurningConfirmInst(stateDB, metadata.BurningConfirmForDepositToSCMeta, inst, beaconHeight)
+			burningConfirm, err = blockchain.buildBurningConfirmInst(stateDB, metadata.BurningConfirmForDepositToSCMeta, inst, beaconHeight)
 			newInst = [][]string{burningConfirm}
 
 		case metadata.BurningForDepositToSCRequestMetaV2:
 			burningConfirm := []string{}
-			burningConfirm, err = buildBurningConfirmInst(stateDB, metadata.BurningConfirmForDepositToSCMetaV2, inst, beaconHeight)
+			burningConfirm, err = blockchain.buildBurningConfirmInst(stateDB, metadata.BurningConfirmForDepositToSCMetaV2, inst, beaconHeight)
 			newInst = [][]string{burningConfirm}
 
 		default:
@@ -73,7 +73,7 @@ func (blockchain *BlockChain) buildBridgeInstructions(stateDB *statedb.StateDB,
 }
 
 // buildBurningConfirmInst builds on beacon an instruction confirming a tx burning bridge-token
-func buildBurningConfirmInst(
+func (blockchain *BlockChain) buildBurningConfirmInst(
 	stateDB *statedb.StateDB,
 	burningMetaType int,
 	inst []string,
@@ -89,9 +89,12 @@ func buildBurningConfirmInst(
 	md := burningReqAction.Meta
 	txID := burningReqAction.RequestedTxID // to prevent double-release token
 	shardID := byte(common.BridgeShardID)
+	if _, err := blockchain.GetEVMContractAddressStr(md.EVMChainID, height); err != nil {
+		return nil, err
+	}
 
 	// Convert to external tokenID
-	tokenID, err := findExternalTokenID(stateDB, &md.TokenID)
+	tokenID, err := findExternalTokenID(stateDB, &md.TokenID, metadata.GetEVMNetwork(md.EVMChainID))
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +109,7 @@ func buildBurningConfirmInst(
 	// Convert height to big.Int to get bytes later
 	h := big.NewInt(0).SetUint64(height)
 
-	return []string{
+	burningConfirm := []string{
 		strconv.Itoa(burningMetaType),
 		strconv.Itoa(int(shardID)),
 		base58.Base58Check{}.Encode(tokenID, 0x00),
@@ -114,12 +117,30 @@ func buildBurningConfirmInst(
 		base58.Base58Check{}.Encode(amount.Bytes(), 0x00),
 		txID.String(),
 		base58.Base58Check{}.Encode(md.TokenID[:], 0x00),
-		base58.Base58Check{}.Encode(h.Bytes(), 0x00),
-	}, nil
+	}
+	// the chain id is signed so that a burn on a chain cannot be withdrawn on another one
+	if !metadata.IsETHChain(md.EVMChainID) {
+		evmChainID := big.NewInt(0).SetUint64(md.EVMChainID)
+		burningConfirm = append(burningConfirm, base58.Base58Check{}.Encode(evmChainID.Bytes(), 0x00))
+	}
+	return append(burningConfirm, base58.Base58Check{}.Encode(h.Bytes(), 0x00)), nil
+}
+
+// GetBurningConfirmEVMChainID returns the EVM chain of a BurningConfirm
+// instruction, the instructions to Ethereum have no chain id
+func GetBurningConfirmEVMChainID(inst []string) uint64 {
+	if len(inst) < 9 {
+		return common.ETHChainID
+	}
+	evmChainID, _, err := base58.Base58Check{}.Decode(inst[7])
+	if err != nil {
+		return common.ETHChainID
+	}
+	return big.NewInt(0).SetBytes(evmChainID).Uint64()
 }
 
-// findExternalTokenID finds the external tokenID for a bridge token from database
-func findExternalTokenID(stateDB *statedb.StateDB, tokenID *common.Hash) ([]byte, error) {
+// findExternalTokenID finds the external tokenID for a bridge token of network from database
+func findExternalTokenID(stateDB *statedb.StateDB, tokenID *common.Hash, network string) ([]byte, error) {
 	allBridgeTokensBytes, err := statedb.GetAllBridgeTokens(stateDB)
 	if err != nil {
 		return nil, err
@@ -130,7 +151,7 @@ func findExternalTokenID(stateDB *statedb.StateDB, tokenID *common.Hash) ([]byte
 		return nil, errors.WithStack(err)
 	}
 	for _, token := range allBridgeTokens {
-		if token.TokenID.IsEqual(tokenID) && len(token.ExternalTokenID) > 0 {
+		if token.TokenID.IsEqual(tokenID) && len(token.ExternalTokenID) > 0 && token.Network == network {
 			return token.ExternalTokenID, nil
 		}
 	}
@@ -23,11 +23,15 @@ type BeaconChain struct {
 	ChainName   string
 	Ready       bool //when has peerstate
 
-	insertLock sync.Mutex
+	insertLock  sync.Mutex
+	statePruner *statePruner
+
+	preValidatedBlocks *lru.Cache // block hash -> committee its signatures were checked against
 }
 
 func NewBeaconChain(multiView *multiview.MultiView, blockGen *BlockGenerator, blockchain *BlockChain, chainName string) *BeaconChain {
-	return &BeaconChain{multiView: multiView, BlockGen: blockGen, Blockchain: blockchain, ChainName: chainName}
+	preValidatedBlocks, _ := lru.New(preValidatedBlockCacheSize)
+	return &BeaconChain{multiView: multiView, BlockGen: blockGen, Blockchain: blockchain, ChainName: chainName, preValidatedBlocks: preValidatedBlocks}
 }
 
 func (chain *BeaconChain) GetAllViewHash() (res []common.Hash) {
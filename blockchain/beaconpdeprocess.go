@@ -51,6 +51,18 @@ func (blockchain *BlockChain) processPDEInstructions(pdexStateDB *statedb.StateD
 			err = blockchain.processPDEFeeWithdrawal(pdexStateDB, beaconHeight, inst, currentPDEState)
 		case strconv.Itoa(metadata.PDETradingFeesDistributionMeta):
 			err = blockchain.processPDETradingFeesDistribution(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDEContributionV3Meta):
+			err = blockchain.processPDEContributionV2(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDETradeRequestV3Meta):
+			err = blockchain.processPDETradeV3(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDEWithdrawalRequestV3Meta):
+			err = blockchain.processPDEWithdrawalV3(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDETradingFeesDistributionV3Meta):
+			err = blockchain.processPDETradingFeesDistributionV3(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDELimitOrderRequestMeta):
+			err = blockchain.processPDELimitOrder(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDECancelLimitOrderRequestMeta):
+			err = blockchain.processPDECancelLimitOrder(pdexStateDB, beaconHeight, inst, currentPDEState)
 		}
 		if err != nil {
 			Logger.log.Error(err)
@@ -96,6 +108,16 @@ func hasPDEInstruction(instructions [][]string) bool {
 		case strconv.Itoa(metadata.PDETradingFeesDistributionMeta):
 			hasPDEXInstruction = true
 			break
+		case strconv.Itoa(metadata.PDEContributionV3Meta),
+			strconv.Itoa(metadata.PDETradeRequestV3Meta),
+			strconv.Itoa(metadata.PDEWithdrawalRequestV3Meta),
+			strconv.Itoa(metadata.PDETradingFeesDistributionV3Meta):
+			hasPDEXInstruction = true
+			break
+		case strconv.Itoa(metadata.PDELimitOrderRequestMeta),
+			strconv.Itoa(metadata.PDECancelLimitOrderRequestMeta):
+			hasPDEXInstruction = true
+			break
 		}
 	}
 	return hasPDEXInstruction
@@ -122,6 +144,7 @@ func (blockchain *BlockChain) processPDEContributionV2(pdexStateDB *statedb.Stat
 			TokenIDStr:            waitingContribution.TokenIDStr,
 			Amount:                waitingContribution.ContributedAmount,
 			TxReqID:               waitingContribution.TxReqID,
+			FeeTier:               waitingContribution.FeeTier,
 		}
 
 		contribStatus := metadata.PDEContributionStatus{
@@ -187,7 +210,7 @@ func (blockchain *BlockChain) processPDEContributionV2(pdexStateDB *statedb.Stat
 			Amount:                matchedContribution.ContributedAmount,
 			TxReqID:               matchedContribution.TxReqID,
 		}
-		updateWaitingContributionPairToPoolV2(
+		updateWaitingContributionPairToPool(
 			beaconHeight,
 			existingWaitingContribution,
 			incomingWaitingContribution,
@@ -231,8 +254,9 @@ func (blockchain *BlockChain) processPDEContributionV2(pdexStateDB *statedb.Stat
 				TokenIDStr:            waitingContribution.TokenIDStr,
 				Amount:                matchedNReturnedContrib.ActualWaitingContribAmount,
 				TxReqID:               waitingContribution.TxReqID,
+				FeeTier:               waitingContribution.FeeTier,
 			}
-			updateWaitingContributionPairToPoolV2(
+			updateWaitingContributionPairToPool(
 				beaconHeight,
 				existingWaitingContribution,
 				incomingWaitingContribution,
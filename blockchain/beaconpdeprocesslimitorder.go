@@ -0,0 +1,157 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func convertToRawPDELimitOrder(order metadata.PDELimitOrder) *rawdbv2.PDELimitOrder {
+	return rawdbv2.NewPDELimitOrder(
+		order.OrderID,
+		order.TraderAddressStr,
+		order.TokenIDToBuyStr,
+		order.TokenIDToSellStr,
+		order.SellAmount,
+		order.MinAcceptableAmount,
+		order.ExpiryBeaconHeight,
+		order.ShardID,
+	)
+}
+
+func deletePDELimitOrder(beaconHeight uint64, orderID common.Hash, currentPDEState *CurrentPDEState) {
+	orderKey := string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, orderID.String()))
+	order, found := currentPDEState.PDELimitOrders[orderKey]
+	if !found || order == nil {
+		Logger.log.Warnf("WARNING: could not find out resting pde limit order %s", orderID.String())
+		return
+	}
+	delete(currentPDEState.PDELimitOrders, orderKey)
+	currentPDEState.DeletedPDELimitOrders[orderKey] = order
+}
+
+func (blockchain *BlockChain) processPDELimitOrder(pdexStateDB *statedb.StateDB, beaconHeight uint64, instruction []string, currentPDEState *CurrentPDEState) error {
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	currentPDEState.initPDELimitOrders()
+	var orderID common.Hash
+	var orderStatus int
+	switch instruction[2] {
+	case common.PDELimitOrderMatchedChainStatus:
+		var matchedOrder metadata.PDEMatchedLimitOrder
+		err := json.Unmarshal([]byte(instruction[3]), &matchedOrder)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde matched limit order instruction: %+v", err)
+			return nil
+		}
+		pdePoolForPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, matchedOrder.Token1IDStr, matchedOrder.Token2IDStr))
+		pdePoolForPair, found := currentPDEState.PDEPoolPairs[pdePoolForPairKey]
+		if !found || pdePoolForPair == nil {
+			Logger.log.Errorf("WARNING: could not find out pdePoolForPair with token ids: %s & %s", matchedOrder.Token1IDStr, matchedOrder.Token2IDStr)
+			return nil
+		}
+		if matchedOrder.Token1PoolValueOperation.Operator == "+" {
+			pdePoolForPair.Token1PoolValue += matchedOrder.Token1PoolValueOperation.Value
+			pdePoolForPair.Token2PoolValue -= matchedOrder.Token2PoolValueOperation.Value
+		} else {
+			pdePoolForPair.Token1PoolValue -= matchedOrder.Token1PoolValueOperation.Value
+			pdePoolForPair.Token2PoolValue += matchedOrder.Token2PoolValueOperation.Value
+		}
+		deletePDELimitOrder(beaconHeight, matchedOrder.OrderID, currentPDEState)
+		orderID = matchedOrder.OrderID
+		orderStatus = common.PDELimitOrderMatchedStatus
+	default:
+		var order metadata.PDELimitOrder
+		err := json.Unmarshal([]byte(instruction[3]), &order)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde limit order instruction: %+v", err)
+			return nil
+		}
+		orderID = order.OrderID
+		switch instruction[2] {
+		case common.PDELimitOrderRestingChainStatus:
+			orderKey := string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, order.OrderID.String()))
+			currentPDEState.PDELimitOrders[orderKey] = convertToRawPDELimitOrder(order)
+			orderStatus = common.PDELimitOrderRestingStatus
+		case common.PDELimitOrderRefundChainStatus:
+			orderStatus = common.PDELimitOrderRefundStatus
+		case common.PDELimitOrderExpiredChainStatus:
+			deletePDELimitOrder(beaconHeight, order.OrderID, currentPDEState)
+			orderStatus = common.PDELimitOrderExpiredStatus
+		default:
+			return nil
+		}
+	}
+	err := statedb.TrackPDEStatus(
+		pdexStateDB,
+		rawdbv2.PDELimitOrderStatusPrefix,
+		orderID[:],
+		byte(orderStatus),
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while tracking pde limit order status: %+v", err)
+	}
+	return nil
+}
+
+func (blockchain *BlockChain) processPDECancelLimitOrder(pdexStateDB *statedb.StateDB, beaconHeight uint64, instruction []string, currentPDEState *CurrentPDEState) error {
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	if instruction[2] == common.PDECancelLimitOrderRejectedChainStatus {
+		contentBytes, err := base64.StdEncoding.DecodeString(instruction[3])
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while decoding content string of pde cancel limit order action: %+v", err)
+			return nil
+		}
+		var pdeCancelLimitOrderRequestAction metadata.PDECancelLimitOrderRequestAction
+		err = json.Unmarshal(contentBytes, &pdeCancelLimitOrderRequestAction)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde cancel limit order request action: %+v", err)
+			return nil
+		}
+		err = statedb.TrackPDEStatus(
+			pdexStateDB,
+			rawdbv2.PDECancelLimitOrderStatusPrefix,
+			pdeCancelLimitOrderRequestAction.TxReqID[:],
+			byte(common.PDECancelLimitOrderRejectedStatus),
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while tracking pde rejected cancel limit order status: %+v", err)
+		}
+		return nil
+	}
+
+	var cancelledOrder metadata.PDECancelledLimitOrder
+	err := json.Unmarshal([]byte(instruction[3]), &cancelledOrder)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling pde cancelled limit order instruction: %+v", err)
+		return nil
+	}
+	currentPDEState.initPDELimitOrders()
+	deletePDELimitOrder(beaconHeight, cancelledOrder.OrderID, currentPDEState)
+	err = statedb.TrackPDEStatus(
+		pdexStateDB,
+		rawdbv2.PDELimitOrderStatusPrefix,
+		cancelledOrder.OrderID[:],
+		byte(common.PDELimitOrderCancelledStatus),
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while tracking pde cancelled limit order status: %+v", err)
+	}
+	err = statedb.TrackPDEStatus(
+		pdexStateDB,
+		rawdbv2.PDECancelLimitOrderStatusPrefix,
+		cancelledOrder.CancelTxReqID[:],
+		byte(common.PDECancelLimitOrderAcceptedStatus),
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while tracking pde accepted cancel limit order status: %+v", err)
+	}
+	return nil
+}
@@ -0,0 +1,349 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// handlePDEV3Insts builds the instructions of v3 pools after the v2 ones, in
+// the order trades, trading fees, withdrawals and contributions
+func (blockchain *BlockChain) handlePDEV3Insts(
+	beaconHeight uint64,
+	currentPDEState *CurrentPDEState,
+	pdeContributionV3ActionsByShardID map[byte][][]string,
+	pdeTradeV3ActionsByShardID map[byte][][]string,
+	pdeWithdrawalV3ActionsByShardID map[byte][][]string,
+) ([][]string, error) {
+	// the requests sent before the v3 pools are activated are refunded
+	if !blockchain.IsAfterPDEV3CheckPoint(beaconHeight) {
+		return buildInstsForInactivePDEV3Actions(
+			pdeContributionV3ActionsByShardID,
+			pdeTradeV3ActionsByShardID,
+			pdeWithdrawalV3ActionsByShardID,
+		), nil
+	}
+	instructions := [][]string{}
+	if currentPDEState != nil {
+		currentPDEState.initPDEStateV3()
+	}
+
+	// handle trade
+	tradingFeesByPool := make(map[string]*metadata.PDETradingFeesDistributionV3)
+	var tradeKeys []int
+	for k := range pdeTradeV3ActionsByShardID {
+		tradeKeys = append(tradeKeys, int(k))
+	}
+	sort.Ints(tradeKeys)
+	for _, value := range tradeKeys {
+		shardID := byte(value)
+		actions := pdeTradeV3ActionsByShardID[shardID]
+		for _, action := range actions {
+			contentStr := action[1]
+			newInst, err := blockchain.buildInstructionsForPDETradeV3(contentStr, shardID, metadata.PDETradeRequestV3Meta, currentPDEState, beaconHeight, tradingFeesByPool)
+			if err != nil {
+				Logger.log.Error(err)
+				continue
+			}
+			if len(newInst) > 0 {
+				instructions = append(instructions, newInst...)
+			}
+		}
+	}
+
+	// build instruction for trading fees distribution
+	if currentPDEState != nil {
+		tradingFeesDistInst := blockchain.buildInstForTradingFeesDistV3(currentPDEState, tradingFeesByPool)
+		if len(tradingFeesDistInst) > 0 {
+			instructions = append(instructions, tradingFeesDistInst)
+		}
+	}
+
+	// handle withdrawal
+	var wrKeys []int
+	for k := range pdeWithdrawalV3ActionsByShardID {
+		wrKeys = append(wrKeys, int(k))
+	}
+	sort.Ints(wrKeys)
+	for _, value := range wrKeys {
+		shardID := byte(value)
+		actions := pdeWithdrawalV3ActionsByShardID[shardID]
+		for _, action := range actions {
+			contentStr := action[1]
+			newInst, err := blockchain.buildInstructionsForPDEWithdrawalV3(contentStr, shardID, metadata.PDEWithdrawalRequestV3Meta, currentPDEState, beaconHeight)
+			if err != nil {
+				Logger.log.Error(err)
+				continue
+			}
+			if len(newInst) > 0 {
+				instructions = append(instructions, newInst...)
+			}
+		}
+	}
+
+	// handle contribution
+	var ctKeys []int
+	for k := range pdeContributionV3ActionsByShardID {
+		ctKeys = append(ctKeys, int(k))
+	}
+	sort.Ints(ctKeys)
+	for _, value := range ctKeys {
+		shardID := byte(value)
+		actions := pdeContributionV3ActionsByShardID[shardID]
+		for _, action := range actions {
+			contentStr := action[1]
+			newInst, err := blockchain.buildInstructionsForPDEContributionV3(contentStr, shardID, metadata.PDEContributionV3Meta, currentPDEState, beaconHeight)
+			if err != nil {
+				Logger.log.Error(err)
+				continue
+			}
+			if len(newInst) > 0 {
+				instructions = append(instructions, newInst...)
+			}
+		}
+	}
+	return instructions, nil
+}
+
+// buildInstsForInactivePDEV3Actions refunds the contributions and trades and
+// rejects the withdrawals of v3 pools
+func buildInstsForInactivePDEV3Actions(
+	pdeContributionV3ActionsByShardID map[byte][][]string,
+	pdeTradeV3ActionsByShardID map[byte][][]string,
+	pdeWithdrawalV3ActionsByShardID map[byte][][]string,
+) [][]string {
+	instructions := [][]string{}
+	for _, shardID := range sortedPDEActionShardIDs(pdeTradeV3ActionsByShardID) {
+		for _, action := range pdeTradeV3ActionsByShardID[shardID] {
+			var tradeAction metadata.PDETradeRequestV3Action
+			if err := decodePDEAction(action[1], &tradeAction); err != nil {
+				Logger.log.Errorf("ERROR: an error occured while decoding pde v3 trade action: %+v", err)
+				continue
+			}
+			instructions = append(instructions, buildCrossPoolTradeRefundInst(
+				tradeAction.Meta.TraderAddressStr,
+				tradeAction.Meta.TokenIDToSellStr,
+				tradeAction.Meta.SellAmount,
+				metadata.PDETradeRequestV3Meta,
+				common.PDECrossPoolTradeSellingTokenRefundChainStatus,
+				tradeAction.ShardID,
+				tradeAction.TxReqID,
+			))
+		}
+	}
+	for _, shardID := range sortedPDEActionShardIDs(pdeWithdrawalV3ActionsByShardID) {
+		for _, action := range pdeWithdrawalV3ActionsByShardID[shardID] {
+			instructions = append(instructions, []string{
+				strconv.Itoa(metadata.PDEWithdrawalRequestV3Meta),
+				strconv.Itoa(int(shardID)),
+				common.PDEWithdrawalRejectedChainStatus,
+				action[1],
+			})
+		}
+	}
+	for _, shardID := range sortedPDEActionShardIDs(pdeContributionV3ActionsByShardID) {
+		for _, action := range pdeContributionV3ActionsByShardID[shardID] {
+			var contributionAction metadata.PDEContributionV3Action
+			if err := decodePDEAction(action[1], &contributionAction); err != nil {
+				Logger.log.Errorf("ERROR: an error occured while decoding pde v3 contribution action: %+v", err)
+				continue
+			}
+			instructions = append(instructions, buildRefundContributionInst(
+				contributionAction.Meta.PDEContributionPairID,
+				contributionAction.Meta.ContributorAddressStr,
+				contributionAction.Meta.ContributedAmount,
+				contributionAction.Meta.TokenIDStr,
+				metadata.PDEContributionV3Meta,
+				shardID,
+				contributionAction.TxReqID,
+			))
+		}
+	}
+	return instructions
+}
+
+func sortedPDEActionShardIDs(actionsByShardID map[byte][][]string) []byte {
+	var keys []int
+	for k := range actionsByShardID {
+		keys = append(keys, int(k))
+	}
+	sort.Ints(keys)
+	shardIDs := []byte{}
+	for _, k := range keys {
+		shardIDs = append(shardIDs, byte(k))
+	}
+	return shardIDs
+}
+
+func decodePDEAction(contentStr string, action interface{}) error {
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(contentBytes, action)
+}
+
+func (blockchain *BlockChain) processPDETradeV3(pdexStateDB *statedb.StateDB, beaconHeight uint64, instruction []string, currentPDEState *CurrentPDEState) error {
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	if instruction[2] == common.PDECrossPoolTradeSellingTokenRefundChainStatus {
+		var pdeRefundTrade metadata.PDERefundCrossPoolTrade
+		err := json.Unmarshal([]byte(instruction[3]), &pdeRefundTrade)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde v3 refund trade instruction: %+v", err)
+			return nil
+		}
+		err = statedb.TrackPDEStatus(
+			pdexStateDB,
+			rawdbv2.PDETradeStatusPrefix,
+			pdeRefundTrade.TxReqID[:],
+			byte(common.PDECrossPoolTradeRefundStatus),
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while tracking pde v3 refund trade status: %+v", err)
+		}
+		return nil
+	}
+	// trade accepted
+	var pdeTradeAcceptedContents []metadata.PDECrossPoolTradeAcceptedContent
+	err := json.Unmarshal([]byte(instruction[3]), &pdeTradeAcceptedContents)
+	if err != nil {
+		Logger.log.Errorf("WARNING: an error occured while unmarshaling PDETradeAcceptedContents: %+v", err)
+		return nil
+	}
+	if len(pdeTradeAcceptedContents) == 0 {
+		Logger.log.Error("WARNING: There is no pde v3 trade accepted content.")
+		return nil
+	}
+	currentPDEState.initPDEStateV3()
+	for _, pdeTradeAcceptedContent := range pdeTradeAcceptedContents {
+		pdePoolForPairKey := string(rawdbv2.BuildPDEPoolForPairKeyV3(beaconHeight, pdeTradeAcceptedContent.FeeTier, pdeTradeAcceptedContent.Token1IDStr, pdeTradeAcceptedContent.Token2IDStr))
+		pdePoolForPair, found := currentPDEState.PDEPoolPairsV3[pdePoolForPairKey]
+		if !found || pdePoolForPair == nil {
+			Logger.log.Errorf("WARNING: could not find out v3 pdePoolForPair with fee tier %d, token ids: %s & %s", pdeTradeAcceptedContent.FeeTier, pdeTradeAcceptedContent.Token1IDStr, pdeTradeAcceptedContent.Token2IDStr)
+			return nil
+		}
+		if pdeTradeAcceptedContent.Token1PoolValueOperation.Operator == "+" {
+			pdePoolForPair.Token1PoolValue += pdeTradeAcceptedContent.Token1PoolValueOperation.Value
+			pdePoolForPair.Token2PoolValue -= pdeTradeAcceptedContent.Token2PoolValueOperation.Value
+		} else {
+			pdePoolForPair.Token1PoolValue -= pdeTradeAcceptedContent.Token1PoolValueOperation.Value
+			pdePoolForPair.Token2PoolValue += pdeTradeAcceptedContent.Token2PoolValueOperation.Value
+		}
+	}
+	err = statedb.TrackPDEStatus(
+		pdexStateDB,
+		rawdbv2.PDETradeStatusPrefix,
+		pdeTradeAcceptedContents[0].RequestedTxID[:],
+		byte(common.PDECrossPoolTradeAcceptedStatus),
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while tracking pde v3 accepted trade status: %+v", err)
+	}
+	return nil
+}
+
+func (blockchain *BlockChain) processPDETradingFeesDistributionV3(pdexStateDB *statedb.StateDB, beaconHeight uint64, instruction []string, currentPDEState *CurrentPDEState) error {
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	var tradingFees []*metadata.PDETradingFeesDistributionV3
+	err := json.Unmarshal([]byte(instruction[3]), &tradingFees)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling pde v3 trading fees: %+v", err)
+		return nil
+	}
+	currentPDEState.initPDEStateV3()
+	for _, tradingFee := range tradingFees {
+		pdePoolForPairKey := string(rawdbv2.BuildPDEPoolForPairKeyV3(beaconHeight, tradingFee.FeeTier, tradingFee.Token1IDStr, tradingFee.Token2IDStr))
+		addTradingFeeToPoolV3(currentPDEState.PDEPoolPairsV3[pdePoolForPairKey], tradingFee)
+	}
+	return nil
+}
+
+func (blockchain *BlockChain) processPDEWithdrawalV3(pdexStateDB *statedb.StateDB, beaconHeight uint64, instruction []string, currentPDEState *CurrentPDEState) error {
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	if instruction[2] == common.PDEWithdrawalRejectedChainStatus {
+		contentBytes, err := base64.StdEncoding.DecodeString(instruction[3])
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while decoding content string of pde v3 withdrawal action: %+v", err)
+			return nil
+		}
+		var pdeWithdrawalRequestAction metadata.PDEWithdrawalRequestV3Action
+		err = json.Unmarshal(contentBytes, &pdeWithdrawalRequestAction)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling pde v3 withdrawal request action: %+v", err)
+			return nil
+		}
+		err = statedb.TrackPDEStatus(
+			pdexStateDB,
+			rawdbv2.PDEWithdrawalStatusPrefix,
+			pdeWithdrawalRequestAction.TxReqID[:],
+			byte(common.PDEWithdrawalRejectedStatus),
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while tracking pde v3 rejected withdrawal status: %+v", err)
+		}
+		return nil
+	}
+
+	var wdAcceptedContent metadata.PDEWithdrawalAcceptedContent
+	err := json.Unmarshal([]byte(instruction[3]), &wdAcceptedContent)
+	if err != nil {
+		Logger.log.Errorf("WARNING: an error occured while unmarshaling PDEWithdrawalAcceptedContent: %+v", err)
+		return nil
+	}
+	currentPDEState.initPDEStateV3()
+
+	// update pde pool pair
+	pdePoolForPairKey := string(rawdbv2.BuildPDEPoolForPairKeyV3(
+		beaconHeight,
+		wdAcceptedContent.FeeTier,
+		wdAcceptedContent.PairToken1IDStr,
+		wdAcceptedContent.PairToken2IDStr,
+	))
+	pdePoolForPair, found := currentPDEState.PDEPoolPairsV3[pdePoolForPairKey]
+	if !found || pdePoolForPair == nil {
+		Logger.log.Errorf("WARNING: could not find out v3 pdePoolForPair with fee tier %d, token ids: %s & %s", wdAcceptedContent.FeeTier, wdAcceptedContent.PairToken1IDStr, wdAcceptedContent.PairToken2IDStr)
+		return nil
+	}
+	if pdePoolForPair.Token1IDStr == wdAcceptedContent.WithdrawalTokenIDStr {
+		pdePoolForPair.Token1PoolValue -= wdAcceptedContent.DeductingPoolValue
+	} else {
+		pdePoolForPair.Token2PoolValue -= wdAcceptedContent.DeductingPoolValue
+	}
+
+	// update pde shares
+	pdeShareKey := string(rawdbv2.BuildPDESharesKeyV3(
+		beaconHeight,
+		wdAcceptedContent.FeeTier,
+		wdAcceptedContent.PairToken1IDStr, wdAcceptedContent.PairToken2IDStr,
+		wdAcceptedContent.WithdrawerAddressStr,
+	))
+	adjustingAmt := uint64(0)
+	currentAmt, found := currentPDEState.PDESharesV3[pdeShareKey]
+	if found && wdAcceptedContent.DeductingShares <= currentAmt {
+		adjustingAmt = currentAmt - wdAcceptedContent.DeductingShares
+	}
+	currentPDEState.PDESharesV3[pdeShareKey] = adjustingAmt
+
+	err = statedb.TrackPDEStatus(
+		pdexStateDB,
+		rawdbv2.PDEWithdrawalStatusPrefix,
+		wdAcceptedContent.TxReqID[:],
+		byte(common.PDEWithdrawalAcceptedStatus),
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while tracking pde v3 accepted withdrawal status: %+v", err)
+	}
+	return nil
+}
@@ -22,6 +22,7 @@ func buildWaitingContributionInst(
 	metaType int,
 	shardID byte,
 	txReqID common.Hash,
+	feeTier uint64,
 ) []string {
 	waitingContribution := metadata.PDEWaitingContribution{
 		PDEContributionPairID: pdeContributionPairID,
@@ -29,6 +30,7 @@ func buildWaitingContributionInst(
 		ContributedAmount:     contributedAmount,
 		TokenIDStr:            tokenIDStr,
 		TxReqID:               txReqID,
+		FeeTier:               feeTier,
 	}
 	waitingContributionBytes, _ := json.Marshal(waitingContribution)
 	return []string{
@@ -73,6 +75,7 @@ func buildMatchedContributionInst(
 	metaType int,
 	shardID byte,
 	txReqID common.Hash,
+	feeTier uint64,
 ) []string {
 	matchedContribution := metadata.PDEMatchedContribution{
 		PDEContributionPairID: pdeContributionPairID,
@@ -80,6 +83,7 @@ func buildMatchedContributionInst(
 		ContributedAmount:     contributedAmount,
 		TokenIDStr:            tokenIDStr,
 		TxReqID:               txReqID,
+		FeeTier:               feeTier,
 	}
 	matchedContributionBytes, _ := json.Marshal(matchedContribution)
 	return []string{
@@ -100,6 +104,7 @@ func buildMatchedNReturnedContributionInst(
 	shardID byte,
 	txReqID common.Hash,
 	actualWaitingContribAmount uint64,
+	feeTier uint64,
 ) []string {
 	matchedNReturnedContribution := metadata.PDEMatchedNReturnedContribution{
 		PDEContributionPairID:      pdeContributionPairID,
@@ -110,6 +115,7 @@ func buildMatchedNReturnedContributionInst(
 		ShardID:                    shardID,
 		TxReqID:                    txReqID,
 		ActualWaitingContribAmount: actualWaitingContribAmount,
+		FeeTier:                    feeTier,
 	}
 	matchedNReturnedContribBytes, _ := json.Marshal(matchedNReturnedContribution)
 	return []string{
@@ -276,10 +282,13 @@ func (blockchain *BlockChain) buildInstructionsForPDEContribution(
 			metaType,
 			shardID,
 			pdeContributionAction.TxReqID,
+			0,
 		)
 		return [][]string{inst}, nil
 	}
-	if waitingContribution.TokenIDStr == meta.TokenIDStr ||
+	// a waiting contribution with a fee tier is for a v3 pool, it can not be matched here
+	if waitingContribution.FeeTier != 0 ||
+		waitingContribution.TokenIDStr == meta.TokenIDStr ||
 		waitingContribution.ContributorAddressStr != meta.ContributorAddressStr ||
 		(isPRVRequired && waitingContribution.TokenIDStr != common.PRVIDStr && meta.TokenIDStr != common.PRVIDStr) {
 		delete(currentPDEState.WaitingPDEContributions, waitingContribPairKey)
@@ -330,6 +339,7 @@ func (blockchain *BlockChain) buildInstructionsForPDEContribution(
 			metaType,
 			shardID,
 			pdeContributionAction.TxReqID,
+			0,
 		)
 		return [][]string{matchedInst}, nil
 	}
@@ -392,6 +402,7 @@ func (blockchain *BlockChain) buildInstructionsForPDEContribution(
 		shardID,
 		pdeContributionAction.TxReqID,
 		actualWaitingContribAmt,
+		0,
 	)
 	matchedNReturnedInst2 := buildMatchedNReturnedContributionInst(
 		meta.PDEContributionPairID,
@@ -403,6 +414,7 @@ func (blockchain *BlockChain) buildInstructionsForPDEContribution(
 		shardID,
 		waitingContribution.TxReqID,
 		0,
+		0,
 	)
 	return [][]string{matchedNReturnedInst1, matchedNReturnedInst2}, nil
 }
@@ -427,7 +439,21 @@ func (blockchain *BlockChain) buildInstsForSortedTradableActions(
 	for _, tradeAction := range sortedTradableActions {
 		tradeMeta := tradeAction.Meta
 		var sequentialTrades []*tradeInfo
-		if isTradingFairContainsPRV(tradeMeta.TokenIDToSellStr, tradeMeta.TokenIDToBuyStr) { // direct trade
+		if blockchain.IsAfterPDETokenPoolsCheckPoint(beaconHeight) { // best of direct and multi-hop paths
+			path := findBestPDETradePath(beaconHeight, currentPDEState, tradeMeta.TokenIDToSellStr, tradeMeta.TokenIDToBuyStr, tradeMeta.SellAmount)
+			if len(path) == 0 {
+				untradableInsts := blockchain.buildInstsForUntradableActions([]metadata.PDECrossPoolTradeRequestAction{tradeAction})
+				tradableInsts = append(tradableInsts, untradableInsts...)
+				continue
+			}
+			for i := 0; i < len(path)-1; i++ {
+				sequentialTrades = append(sequentialTrades, &tradeInfo{
+					tokenIDToBuyStr:  path[i+1],
+					tokenIDToSellStr: path[i],
+				})
+			}
+			sequentialTrades[0].sellAmount = tradeMeta.SellAmount
+		} else if isTradingFairContainsPRV(tradeMeta.TokenIDToSellStr, tradeMeta.TokenIDToBuyStr) { // direct trade
 			sequentialTrades = []*tradeInfo{
 				&tradeInfo{
 					tokenIDToBuyStr:  tradeMeta.TokenIDToBuyStr,
@@ -800,6 +826,7 @@ func buildPDEWithdrawalAcceptedInst(
 	deductingPoolValue uint64,
 	deductingShares uint64,
 	txReqID common.Hash,
+	feeTier uint64,
 ) ([]string, error) {
 	wdAcceptedContent := metadata.PDEWithdrawalAcceptedContent{
 		WithdrawalTokenIDStr: withdrawalTokenIDStr,
@@ -810,6 +837,7 @@ func buildPDEWithdrawalAcceptedInst(
 		PairToken2IDStr:      wdMeta.WithdrawalToken2IDStr,
 		TxReqID:              txReqID,
 		ShardID:              shardID,
+		FeeTier:              feeTier,
 	}
 	wdAcceptedContentBytes, err := json.Marshal(wdAcceptedContent)
 	if err != nil {
@@ -948,6 +976,7 @@ func (blockchain *BlockChain) buildInstructionsForPDEWithdrawal(
 		deductingAmounts.PoolValue1,
 		deductingAmounts.Shares,
 		pdeWithdrawalRequestAction.TxReqID,
+		0,
 	)
 	if err != nil {
 		return [][]string{}, nil
@@ -961,6 +990,7 @@ func (blockchain *BlockChain) buildInstructionsForPDEWithdrawal(
 		deductingAmounts.PoolValue2,
 		0,
 		pdeWithdrawalRequestAction.TxReqID,
+		0,
 	)
 	if err != nil {
 		return [][]string{}, nil
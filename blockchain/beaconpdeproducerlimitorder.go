@@ -0,0 +1,307 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// handlePDELimitOrderInsts builds the instructions of limit orders after the
+// ones of trades so orders are matched against the latest pool prices, in the
+// order cancellations, new orders, expiries and matching
+func (blockchain *BlockChain) handlePDELimitOrderInsts(
+	beaconHeight uint64,
+	currentPDEState *CurrentPDEState,
+	pdeLimitOrderActionsByShardID map[byte][][]string,
+	pdeCancelLimitOrderActionsByShardID map[byte][][]string,
+) ([][]string, error) {
+	// without a pde state the orders sent before the limit orders are
+	// activated are refunded and the cancellations rejected
+	if !blockchain.IsAfterPDELimitOrderCheckPoint(beaconHeight) {
+		currentPDEState = nil
+	}
+	instructions := [][]string{}
+	if currentPDEState != nil {
+		currentPDEState.initPDELimitOrders()
+	}
+
+	// handle cancellation
+	var cancelKeys []int
+	for k := range pdeCancelLimitOrderActionsByShardID {
+		cancelKeys = append(cancelKeys, int(k))
+	}
+	sort.Ints(cancelKeys)
+	for _, value := range cancelKeys {
+		shardID := byte(value)
+		actions := pdeCancelLimitOrderActionsByShardID[shardID]
+		for _, action := range actions {
+			contentStr := action[1]
+			newInst, err := blockchain.buildInstructionsForPDECancelLimitOrder(contentStr, shardID, metadata.PDECancelLimitOrderRequestMeta, currentPDEState, beaconHeight)
+			if err != nil {
+				Logger.log.Error(err)
+				continue
+			}
+			if len(newInst) > 0 {
+				instructions = append(instructions, newInst...)
+			}
+		}
+	}
+
+	// handle new orders
+	var orderKeys []int
+	for k := range pdeLimitOrderActionsByShardID {
+		orderKeys = append(orderKeys, int(k))
+	}
+	sort.Ints(orderKeys)
+	for _, value := range orderKeys {
+		shardID := byte(value)
+		actions := pdeLimitOrderActionsByShardID[shardID]
+		for _, action := range actions {
+			contentStr := action[1]
+			newInst, err := blockchain.buildInstructionsForPDELimitOrder(contentStr, shardID, metadata.PDELimitOrderRequestMeta, currentPDEState, beaconHeight)
+			if err != nil {
+				Logger.log.Error(err)
+				continue
+			}
+			if len(newInst) > 0 {
+				instructions = append(instructions, newInst...)
+			}
+		}
+	}
+
+	if currentPDEState == nil {
+		return instructions, nil
+	}
+
+	// handle expiry
+	var restingKeys []string
+	for k := range currentPDEState.PDELimitOrders {
+		restingKeys = append(restingKeys, k)
+	}
+	sort.Strings(restingKeys)
+	for _, key := range restingKeys {
+		order := currentPDEState.PDELimitOrders[key]
+		if !isPDELimitOrderExpired(order, beaconHeight) {
+			continue
+		}
+		delete(currentPDEState.PDELimitOrders, key)
+		currentPDEState.DeletedPDELimitOrders[key] = order
+		inst, err := buildPDELimitOrderInst(metadata.PDELimitOrderRequestMeta, common.PDELimitOrderExpiredChainStatus, order.ShardID, convertToPDELimitOrderContent(order))
+		if err != nil {
+			Logger.log.Error(err)
+			continue
+		}
+		instructions = append(instructions, inst)
+	}
+
+	// handle matching
+	matchedInsts := buildInstsForMatchedPDELimitOrders(beaconHeight, currentPDEState)
+	if len(matchedInsts) > 0 {
+		instructions = append(instructions, matchedInsts...)
+	}
+	return instructions, nil
+}
+
+// isPDELimitOrderExpired checks the order against the beacon block being
+// produced on top of beaconHeight
+func isPDELimitOrderExpired(order *rawdbv2.PDELimitOrder, beaconHeight uint64) bool {
+	return order.ExpiryBeaconHeight != 0 && order.ExpiryBeaconHeight <= beaconHeight+1
+}
+
+func convertToPDELimitOrderContent(order *rawdbv2.PDELimitOrder) metadata.PDELimitOrder {
+	return metadata.PDELimitOrder{
+		OrderID:             order.OrderID,
+		TraderAddressStr:    order.TraderAddressStr,
+		TokenIDToBuyStr:     order.TokenIDToBuyStr,
+		TokenIDToSellStr:    order.TokenIDToSellStr,
+		SellAmount:          order.SellAmount,
+		MinAcceptableAmount: order.MinAcceptableAmount,
+		ExpiryBeaconHeight:  order.ExpiryBeaconHeight,
+		ShardID:             order.ShardID,
+	}
+}
+
+func buildPDELimitOrderInst(
+	metaType int,
+	status string,
+	shardID byte,
+	content interface{},
+) ([]string, error) {
+	contentBytes, err := json.Marshal(content)
+	if err != nil {
+		return []string{}, err
+	}
+	return []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		status,
+		string(contentBytes),
+	}, nil
+}
+
+func (blockchain *BlockChain) buildInstructionsForPDELimitOrder(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+) ([][]string, error) {
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of pde limit order action: %+v", err)
+		return [][]string{}, nil
+	}
+	var pdeLimitOrderRequestAction metadata.PDELimitOrderRequestAction
+	err = json.Unmarshal(contentBytes, &pdeLimitOrderRequestAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling pde limit order request action: %+v", err)
+		return [][]string{}, nil
+	}
+	meta := pdeLimitOrderRequestAction.Meta
+	order := &rawdbv2.PDELimitOrder{
+		OrderID:             pdeLimitOrderRequestAction.TxReqID,
+		TraderAddressStr:    meta.TraderAddressStr,
+		TokenIDToBuyStr:     meta.TokenIDToBuyStr,
+		TokenIDToSellStr:    meta.TokenIDToSellStr,
+		SellAmount:          meta.SellAmount,
+		MinAcceptableAmount: meta.MinAcceptableAmount,
+		ExpiryBeaconHeight:  meta.ExpiryBeaconHeight,
+		ShardID:             shardID,
+	}
+	if currentPDEState == nil || isPDELimitOrderExpired(order, beaconHeight) {
+		inst, err := buildPDELimitOrderInst(metaType, common.PDELimitOrderRefundChainStatus, shardID, convertToPDELimitOrderContent(order))
+		if err != nil {
+			return [][]string{}, err
+		}
+		return [][]string{inst}, nil
+	}
+	orderKey := string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, order.OrderID.String()))
+	currentPDEState.PDELimitOrders[orderKey] = order
+	inst, err := buildPDELimitOrderInst(metaType, common.PDELimitOrderRestingChainStatus, shardID, convertToPDELimitOrderContent(order))
+	if err != nil {
+		return [][]string{}, err
+	}
+	return [][]string{inst}, nil
+}
+
+func (blockchain *BlockChain) buildInstructionsForPDECancelLimitOrder(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+) ([][]string, error) {
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of pde cancel limit order action: %+v", err)
+		return [][]string{}, nil
+	}
+	var pdeCancelLimitOrderRequestAction metadata.PDECancelLimitOrderRequestAction
+	err = json.Unmarshal(contentBytes, &pdeCancelLimitOrderRequestAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling pde cancel limit order request action: %+v", err)
+		return [][]string{}, nil
+	}
+	rejectedInst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		common.PDECancelLimitOrderRejectedChainStatus,
+		contentStr,
+	}
+	if currentPDEState == nil {
+		return [][]string{rejectedInst}, nil
+	}
+	meta := pdeCancelLimitOrderRequestAction.Meta
+	orderKey := string(rawdbv2.BuildPDELimitOrderKey(beaconHeight, meta.OrderID))
+	order, found := currentPDEState.PDELimitOrders[orderKey]
+	if !found || order == nil || order.TraderAddressStr != meta.TraderAddressStr {
+		return [][]string{rejectedInst}, nil
+	}
+	delete(currentPDEState.PDELimitOrders, orderKey)
+	currentPDEState.DeletedPDELimitOrders[orderKey] = order
+	cancelledOrder := metadata.PDECancelledLimitOrder{
+		PDELimitOrder: convertToPDELimitOrderContent(order),
+		CancelTxReqID: pdeCancelLimitOrderRequestAction.TxReqID,
+	}
+	// the refund is paid on the shard of the order
+	inst, err := buildPDELimitOrderInst(metaType, common.PDELimitOrderCancelledChainStatus, order.ShardID, cancelledOrder)
+	if err != nil {
+		return [][]string{}, err
+	}
+	return [][]string{inst}, nil
+}
+
+// buildInstsForMatchedPDELimitOrders fills resting orders whose whole selling
+// amount gets at least the min acceptable amount on the pool of the pair. The
+// least demanding orders are matched first and each fill moves the pool price
+// seen by the next ones. Limit orders pay no trading fee.
+func buildInstsForMatchedPDELimitOrders(
+	beaconHeight uint64,
+	currentPDEState *CurrentPDEState,
+) [][]string {
+	var orderKeys []string
+	for k := range currentPDEState.PDELimitOrders {
+		orderKeys = append(orderKeys, k)
+	}
+	sort.SliceStable(orderKeys, func(i, j int) bool {
+		orderI := currentPDEState.PDELimitOrders[orderKeys[i]]
+		orderJ := currentPDEState.PDELimitOrders[orderKeys[j]]
+		// compare MinAcceptableAmount / SellAmount of both orders
+		limitI := new(big.Int).Mul(new(big.Int).SetUint64(orderI.MinAcceptableAmount), new(big.Int).SetUint64(orderJ.SellAmount))
+		limitJ := new(big.Int).Mul(new(big.Int).SetUint64(orderJ.MinAcceptableAmount), new(big.Int).SetUint64(orderI.SellAmount))
+		cmp := limitI.Cmp(limitJ)
+		if cmp == 0 {
+			return orderI.OrderID.String() < orderJ.OrderID.String()
+		}
+		return cmp < 0
+	})
+
+	insts := [][]string{}
+	for _, orderKey := range orderKeys {
+		order := currentPDEState.PDELimitOrders[orderKey]
+		if !isPoolPairExisting(beaconHeight, currentPDEState, order.TokenIDToSellStr, order.TokenIDToBuyStr) {
+			continue
+		}
+		pairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, order.TokenIDToSellStr, order.TokenIDToBuyStr))
+		pdePoolPair := currentPDEState.PDEPoolPairs[pairKey]
+		receiveAmt, newTokenPoolValueToBuy, newTokenPoolValueToSell := calcTradeValue(pdePoolPair, order.TokenIDToSellStr, order.SellAmount)
+		if receiveAmt == 0 || receiveAmt < order.MinAcceptableAmount {
+			continue
+		}
+
+		matchedOrder := metadata.PDEMatchedLimitOrder{
+			PDELimitOrder: convertToPDELimitOrderContent(order),
+			ReceiveAmount: receiveAmt,
+			Token1IDStr:   pdePoolPair.Token1IDStr,
+			Token2IDStr:   pdePoolPair.Token2IDStr,
+		}
+		// update current pde state on mem
+		if pdePoolPair.Token1IDStr == order.TokenIDToSellStr {
+			pdePoolPair.Token1PoolValue = newTokenPoolValueToSell
+			pdePoolPair.Token2PoolValue = newTokenPoolValueToBuy
+			matchedOrder.Token1PoolValueOperation = metadata.TokenPoolValueOperation{Operator: "+", Value: order.SellAmount}
+			matchedOrder.Token2PoolValueOperation = metadata.TokenPoolValueOperation{Operator: "-", Value: receiveAmt}
+		} else {
+			pdePoolPair.Token1PoolValue = newTokenPoolValueToBuy
+			pdePoolPair.Token2PoolValue = newTokenPoolValueToSell
+			matchedOrder.Token1PoolValueOperation = metadata.TokenPoolValueOperation{Operator: "-", Value: receiveAmt}
+			matchedOrder.Token2PoolValueOperation = metadata.TokenPoolValueOperation{Operator: "+", Value: order.SellAmount}
+		}
+		delete(currentPDEState.PDELimitOrders, orderKey)
+		currentPDEState.DeletedPDELimitOrders[orderKey] = order
+
+		inst, err := buildPDELimitOrderInst(metadata.PDELimitOrderRequestMeta, common.PDELimitOrderMatchedChainStatus, order.ShardID, matchedOrder)
+		if err != nil {
+			Logger.log.Error(err)
+			continue
+		}
+		insts = append(insts, inst)
+	}
+	return insts
+}
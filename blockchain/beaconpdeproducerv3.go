@@ -0,0 +1,548 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func (blockchain *BlockChain) buildInstructionsForPDEContributionV3(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+) ([][]string, error) {
+	if currentPDEState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForPDEContributionV3]: Current PDE state is null.")
+		inst := []string{
+			strconv.Itoa(metaType),
+			strconv.Itoa(int(shardID)),
+			common.PDEContributionRefundChainStatus,
+			contentStr,
+		}
+		return [][]string{inst}, nil
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of pde v3 contribution action: %+v", err)
+		return [][]string{}, nil
+	}
+	var pdeContributionAction metadata.PDEContributionV3Action
+	err = json.Unmarshal(contentBytes, &pdeContributionAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling pde v3 contribution action: %+v", err)
+		return [][]string{}, nil
+	}
+	meta := pdeContributionAction.Meta
+	waitingContribPairKey := string(rawdbv2.BuildWaitingPDEContributionKey(beaconHeight, meta.PDEContributionPairID))
+	waitingContribution, found := currentPDEState.WaitingPDEContributions[waitingContribPairKey]
+	if !found || waitingContribution == nil {
+		currentPDEState.WaitingPDEContributions[waitingContribPairKey] = &rawdbv2.PDEContribution{
+			ContributorAddressStr: meta.ContributorAddressStr,
+			TokenIDStr:            meta.TokenIDStr,
+			Amount:                meta.ContributedAmount,
+			TxReqID:               pdeContributionAction.TxReqID,
+			FeeTier:               meta.FeeTier,
+		}
+		inst := buildWaitingContributionInst(
+			meta.PDEContributionPairID,
+			meta.ContributorAddressStr,
+			meta.ContributedAmount,
+			meta.TokenIDStr,
+			metaType,
+			shardID,
+			pdeContributionAction.TxReqID,
+			meta.FeeTier,
+		)
+		return [][]string{inst}, nil
+	}
+	refundInsts := func() [][]string {
+		delete(currentPDEState.WaitingPDEContributions, waitingContribPairKey)
+		refundInst1 := buildRefundContributionInst(
+			meta.PDEContributionPairID,
+			meta.ContributorAddressStr,
+			meta.ContributedAmount,
+			meta.TokenIDStr,
+			metaType,
+			shardID,
+			pdeContributionAction.TxReqID,
+		)
+		refundInst2 := buildRefundContributionInst(
+			meta.PDEContributionPairID,
+			waitingContribution.ContributorAddressStr,
+			waitingContribution.Amount,
+			waitingContribution.TokenIDStr,
+			metaType,
+			shardID,
+			waitingContribution.TxReqID,
+		)
+		return [][]string{refundInst1, refundInst2}
+	}
+	// both sides of a pair must be contributed to the same fee tier, v3 pools are paired with PRV
+	if waitingContribution.FeeTier != meta.FeeTier ||
+		waitingContribution.TokenIDStr == meta.TokenIDStr ||
+		waitingContribution.ContributorAddressStr != meta.ContributorAddressStr ||
+		(waitingContribution.TokenIDStr != common.PRVIDStr && meta.TokenIDStr != common.PRVIDStr) {
+		return refundInsts(), nil
+	}
+
+	currentPDEState.initPDEStateV3()
+	poolPairKey := string(rawdbv2.BuildPDEPoolForPairKeyV3(beaconHeight, meta.FeeTier, waitingContribution.TokenIDStr, meta.TokenIDStr))
+	poolPair, found := currentPDEState.PDEPoolPairsV3[poolPairKey]
+	incomingWaitingContribution := &rawdbv2.PDEContribution{
+		ContributorAddressStr: meta.ContributorAddressStr,
+		TokenIDStr:            meta.TokenIDStr,
+		Amount:                meta.ContributedAmount,
+		TxReqID:               pdeContributionAction.TxReqID,
+		FeeTier:               meta.FeeTier,
+	}
+
+	if !found || poolPair == nil {
+		delete(currentPDEState.WaitingPDEContributions, waitingContribPairKey)
+		updateWaitingContributionPairToPoolV3(
+			beaconHeight,
+			waitingContribution,
+			incomingWaitingContribution,
+			currentPDEState,
+		)
+		matchedInst := buildMatchedContributionInst(
+			meta.PDEContributionPairID,
+			meta.ContributorAddressStr,
+			meta.ContributedAmount,
+			meta.TokenIDStr,
+			metaType,
+			shardID,
+			pdeContributionAction.TxReqID,
+			meta.FeeTier,
+		)
+		return [][]string{matchedInst}, nil
+	}
+
+	actualWaitingContribAmt, returnedWaitingContribAmt, actualIncomingWaitingContribAmt, returnedIncomingWaitingContribAmt := computeActualContributedAmounts(
+		waitingContribution,
+		incomingWaitingContribution,
+		poolPair,
+	)
+	if actualWaitingContribAmt == 0 || actualIncomingWaitingContribAmt == 0 {
+		return refundInsts(), nil
+	}
+
+	delete(currentPDEState.WaitingPDEContributions, waitingContribPairKey)
+	actualWaitingContrib := &rawdbv2.PDEContribution{
+		ContributorAddressStr: waitingContribution.ContributorAddressStr,
+		TokenIDStr:            waitingContribution.TokenIDStr,
+		Amount:                actualWaitingContribAmt,
+		TxReqID:               waitingContribution.TxReqID,
+		FeeTier:               meta.FeeTier,
+	}
+	actualIncomingWaitingContrib := &rawdbv2.PDEContribution{
+		ContributorAddressStr: meta.ContributorAddressStr,
+		TokenIDStr:            meta.TokenIDStr,
+		Amount:                actualIncomingWaitingContribAmt,
+		TxReqID:               pdeContributionAction.TxReqID,
+		FeeTier:               meta.FeeTier,
+	}
+	updateWaitingContributionPairToPoolV3(
+		beaconHeight,
+		actualWaitingContrib,
+		actualIncomingWaitingContrib,
+		currentPDEState,
+	)
+	matchedNReturnedInst1 := buildMatchedNReturnedContributionInst(
+		meta.PDEContributionPairID,
+		meta.ContributorAddressStr,
+		actualIncomingWaitingContribAmt,
+		returnedIncomingWaitingContribAmt,
+		meta.TokenIDStr,
+		metaType,
+		shardID,
+		pdeContributionAction.TxReqID,
+		actualWaitingContribAmt,
+		meta.FeeTier,
+	)
+	matchedNReturnedInst2 := buildMatchedNReturnedContributionInst(
+		meta.PDEContributionPairID,
+		waitingContribution.ContributorAddressStr,
+		actualWaitingContribAmt,
+		returnedWaitingContribAmt,
+		waitingContribution.TokenIDStr,
+		metaType,
+		shardID,
+		waitingContribution.TxReqID,
+		0,
+		meta.FeeTier,
+	)
+	return [][]string{matchedNReturnedInst1, matchedNReturnedInst2}, nil
+}
+
+type tradeInfoV3 struct {
+	tokenIDToBuyStr         string
+	tokenIDToSellStr        string
+	sellAmount              uint64
+	fee                     uint64
+	feeTier                 uint64
+	newTokenPoolValueToBuy  uint64
+	newTokenPoolValueToSell uint64
+	receiveAmount           uint64
+}
+
+// calcTradeValueV3 trades sellAmount on the pool of the pair at every fee
+// tier and picks the tier paying the most. It returns nil when no pool of
+// the pair can take the trade.
+func calcTradeValueV3(
+	beaconHeight uint64,
+	currentPDEState *CurrentPDEState,
+	tokenIDToBuyStr string,
+	tokenIDToSellStr string,
+	sellAmount uint64,
+) *tradeInfoV3 {
+	var bestTrade *tradeInfoV3
+	for _, feeTier := range metadata.PDEFeeTiers {
+		poolPairKey := string(rawdbv2.BuildPDEPoolForPairKeyV3(beaconHeight, feeTier, tokenIDToBuyStr, tokenIDToSellStr))
+		poolPair, found := currentPDEState.PDEPoolPairsV3[poolPairKey]
+		if !found || poolPair == nil || poolPair.Token1PoolValue == 0 || poolPair.Token2PoolValue == 0 {
+			continue
+		}
+		fee := big.NewInt(0)
+		fee.Mul(new(big.Int).SetUint64(sellAmount), new(big.Int).SetUint64(feeTier))
+		fee.Div(fee, big.NewInt(metadata.PDEFeeTierBase))
+		amtAfterFee := sellAmount - fee.Uint64()
+		receiveAmt, newTokenPoolValueToBuy, newTokenPoolValueToSell := calcTradeValue(poolPair, tokenIDToSellStr, amtAfterFee)
+		if receiveAmt == 0 {
+			continue
+		}
+		if bestTrade != nil && bestTrade.receiveAmount >= receiveAmt {
+			continue
+		}
+		bestTrade = &tradeInfoV3{
+			tokenIDToBuyStr:         tokenIDToBuyStr,
+			tokenIDToSellStr:        tokenIDToSellStr,
+			sellAmount:              sellAmount,
+			fee:                     fee.Uint64(),
+			feeTier:                 feeTier,
+			newTokenPoolValueToBuy:  newTokenPoolValueToBuy,
+			newTokenPoolValueToSell: newTokenPoolValueToSell,
+			receiveAmount:           receiveAmt,
+		}
+	}
+	return bestTrade
+}
+
+func (blockchain *BlockChain) buildInstructionsForPDETradeV3(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+	tradingFeesByPool map[string]*metadata.PDETradingFeesDistributionV3,
+) ([][]string, error) {
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of pde v3 trade action: %+v", err)
+		return [][]string{}, nil
+	}
+	var tradeAction metadata.PDETradeRequestV3Action
+	err = json.Unmarshal(contentBytes, &tradeAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling pde v3 trade action: %+v", err)
+		return [][]string{}, nil
+	}
+	tradeMeta := tradeAction.Meta
+	refundInst := buildCrossPoolTradeRefundInst(
+		tradeMeta.TraderAddressStr,
+		tradeMeta.TokenIDToSellStr,
+		tradeMeta.SellAmount,
+		metaType,
+		common.PDECrossPoolTradeSellingTokenRefundChainStatus,
+		tradeAction.ShardID,
+		tradeAction.TxReqID,
+	)
+	if currentPDEState == nil || len(currentPDEState.PDEPoolPairsV3) == 0 {
+		return [][]string{refundInst}, nil
+	}
+
+	paths := [][]string{{tradeMeta.TokenIDToSellStr, tradeMeta.TokenIDToBuyStr}}
+	if !isTradingFairContainsPRV(tradeMeta.TokenIDToSellStr, tradeMeta.TokenIDToBuyStr) {
+		paths = [][]string{
+			{tradeMeta.TokenIDToSellStr, common.PRVIDStr},
+			{common.PRVIDStr, tradeMeta.TokenIDToBuyStr},
+		}
+	}
+	sequentialTrades := []*tradeInfoV3{}
+	amt := tradeMeta.SellAmount
+	for _, path := range paths {
+		tradeInf := calcTradeValueV3(beaconHeight, currentPDEState, path[1], path[0], amt)
+		if tradeInf == nil {
+			return [][]string{refundInst}, nil
+		}
+		sequentialTrades = append(sequentialTrades, tradeInf)
+		amt = tradeInf.receiveAmount
+	}
+	if tradeMeta.MinAcceptableAmount > amt {
+		return [][]string{refundInst}, nil
+	}
+
+	tradeAcceptedContents := []metadata.PDECrossPoolTradeAcceptedContent{}
+	for _, tradeInf := range sequentialTrades {
+		// update current pde state on mem
+		pairKey := string(rawdbv2.BuildPDEPoolForPairKeyV3(beaconHeight, tradeInf.feeTier, tradeInf.tokenIDToBuyStr, tradeInf.tokenIDToSellStr))
+		pdePoolPair := currentPDEState.PDEPoolPairsV3[pairKey]
+		pdePoolPair.Token1PoolValue = tradeInf.newTokenPoolValueToBuy
+		pdePoolPair.Token2PoolValue = tradeInf.newTokenPoolValueToSell
+		if pdePoolPair.Token1IDStr == tradeInf.tokenIDToSellStr {
+			pdePoolPair.Token1PoolValue = tradeInf.newTokenPoolValueToSell
+			pdePoolPair.Token2PoolValue = tradeInf.newTokenPoolValueToBuy
+		}
+
+		pdeTradeAcceptedContent := metadata.PDECrossPoolTradeAcceptedContent{
+			TraderAddressStr: tradeMeta.TraderAddressStr,
+			TokenIDToBuyStr:  tradeInf.tokenIDToBuyStr,
+			ReceiveAmount:    tradeInf.receiveAmount,
+			Token1IDStr:      pdePoolPair.Token1IDStr,
+			Token2IDStr:      pdePoolPair.Token2IDStr,
+			ShardID:          tradeAction.ShardID,
+			RequestedTxID:    tradeAction.TxReqID,
+			AddingFee:        tradeInf.fee,
+			FeeTier:          tradeInf.feeTier,
+		}
+		pdeTradeAcceptedContent.Token1PoolValueOperation = metadata.TokenPoolValueOperation{
+			Operator: "-",
+			Value:    tradeInf.receiveAmount,
+		}
+		pdeTradeAcceptedContent.Token2PoolValueOperation = metadata.TokenPoolValueOperation{
+			Operator: "+",
+			Value:    tradeInf.sellAmount - tradeInf.fee,
+		}
+		if pdePoolPair.Token1IDStr == tradeInf.tokenIDToSellStr {
+			pdeTradeAcceptedContent.Token1PoolValueOperation = metadata.TokenPoolValueOperation{
+				Operator: "+",
+				Value:    tradeInf.sellAmount - tradeInf.fee,
+			}
+			pdeTradeAcceptedContent.Token2PoolValueOperation = metadata.TokenPoolValueOperation{
+				Operator: "-",
+				Value:    tradeInf.receiveAmount,
+			}
+		}
+		tradeAcceptedContents = append(tradeAcceptedContents, pdeTradeAcceptedContent)
+
+		if tradeInf.fee == 0 {
+			continue
+		}
+		feeKey := pairKey + "-" + tradeInf.tokenIDToSellStr
+		tradingFee, found := tradingFeesByPool[feeKey]
+		if !found {
+			tradingFee = &metadata.PDETradingFeesDistributionV3{
+				FeeTier:     tradeInf.feeTier,
+				Token1IDStr: pdePoolPair.Token1IDStr,
+				Token2IDStr: pdePoolPair.Token2IDStr,
+				TokenIDStr:  tradeInf.tokenIDToSellStr,
+			}
+			tradingFeesByPool[feeKey] = tradingFee
+		}
+		tradingFee.FeeAmount += tradeInf.fee
+	}
+
+	pdeTradeAcceptedContentsBytes, err := json.Marshal(tradeAcceptedContents)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while marshaling pdeTradeAcceptedContents: %+v", err)
+		return [][]string{}, nil
+	}
+	inst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		common.PDECrossPoolTradeAcceptedChainStatus,
+		string(pdeTradeAcceptedContentsBytes),
+	}
+	return [][]string{inst}, nil
+}
+
+// buildInstForTradingFeesDistV3 adds the trading fees collected by v3 pools
+// in this block to the pools, share holders get them by their shares on
+// withdrawal
+func (blockchain *BlockChain) buildInstForTradingFeesDistV3(
+	currentPDEState *CurrentPDEState,
+	tradingFeesByPool map[string]*metadata.PDETradingFeesDistributionV3,
+) []string {
+	var keys []string
+	for k := range tradingFeesByPool {
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return []string{}
+	}
+	sort.Strings(keys)
+	tradingFees := []*metadata.PDETradingFeesDistributionV3{}
+	for _, feeKey := range keys {
+		tradingFee := tradingFeesByPool[feeKey]
+		pairKey := strings.TrimSuffix(feeKey, "-"+tradingFee.TokenIDStr)
+		addTradingFeeToPoolV3(currentPDEState.PDEPoolPairsV3[pairKey], tradingFee)
+		tradingFees = append(tradingFees, tradingFee)
+	}
+	tradingFeesBytes, err := json.Marshal(tradingFees)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while marshaling tradingFees: %+v", err)
+		return []string{}
+	}
+	return []string{
+		strconv.Itoa(metadata.PDETradingFeesDistributionV3Meta),
+		"",
+		"",
+		string(tradingFeesBytes),
+	}
+}
+
+func addTradingFeeToPoolV3(
+	pdePoolPair *rawdbv2.PDEPoolForPair,
+	tradingFee *metadata.PDETradingFeesDistributionV3,
+) {
+	if pdePoolPair == nil {
+		return
+	}
+	if pdePoolPair.Token1IDStr == tradingFee.TokenIDStr {
+		pdePoolPair.Token1PoolValue += tradingFee.FeeAmount
+	} else if pdePoolPair.Token2IDStr == tradingFee.TokenIDStr {
+		pdePoolPair.Token2PoolValue += tradingFee.FeeAmount
+	}
+}
+
+func deductPDEAmountsV3(
+	wdMeta metadata.PDEWithdrawalRequestV3,
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+) *DeductingAmountsByWithdrawal {
+	var deductingAmounts *DeductingAmountsByWithdrawal
+	pairKey := string(rawdbv2.BuildPDEPoolForPairKeyV3(
+		beaconHeight, wdMeta.FeeTier,
+		wdMeta.WithdrawalToken1IDStr, wdMeta.WithdrawalToken2IDStr,
+	))
+	pdePoolPair, found := currentPDEState.PDEPoolPairsV3[pairKey]
+	if !found || pdePoolPair == nil {
+		return deductingAmounts
+	}
+	shareForWithdrawerKey := string(rawdbv2.BuildPDESharesKeyV3(
+		beaconHeight, wdMeta.FeeTier,
+		wdMeta.WithdrawalToken1IDStr, wdMeta.WithdrawalToken2IDStr, wdMeta.WithdrawerAddressStr,
+	))
+	currentSharesForWithdrawer, found := currentPDEState.PDESharesV3[shareForWithdrawerKey]
+	if !found || currentSharesForWithdrawer == 0 {
+		return deductingAmounts
+	}
+
+	totalSharesForPairPrefix := string(rawdbv2.BuildPDESharesKeyV3(
+		beaconHeight, wdMeta.FeeTier,
+		wdMeta.WithdrawalToken1IDStr, wdMeta.WithdrawalToken2IDStr, "",
+	))
+	totalSharesForPair := big.NewInt(0)
+	for shareKey, shareAmt := range currentPDEState.PDESharesV3 {
+		if strings.Contains(shareKey, totalSharesForPairPrefix) {
+			totalSharesForPair.Add(totalSharesForPair, new(big.Int).SetUint64(shareAmt))
+		}
+	}
+	if totalSharesForPair.Cmp(big.NewInt(0)) == 0 {
+		return deductingAmounts
+	}
+	wdSharesForWithdrawer := wdMeta.WithdrawalShareAmt
+	if wdSharesForWithdrawer > currentSharesForWithdrawer {
+		wdSharesForWithdrawer = currentSharesForWithdrawer
+	}
+
+	deductingAmounts = &DeductingAmountsByWithdrawal{}
+	deductingPoolValueToken1 := big.NewInt(0)
+	deductingPoolValueToken1.Mul(new(big.Int).SetUint64(pdePoolPair.Token1PoolValue), new(big.Int).SetUint64(wdSharesForWithdrawer))
+	deductingPoolValueToken1.Div(deductingPoolValueToken1, totalSharesForPair)
+	pdePoolPair.Token1PoolValue -= deductingPoolValueToken1.Uint64()
+	deductingAmounts.Token1IDStr = pdePoolPair.Token1IDStr
+	deductingAmounts.PoolValue1 = deductingPoolValueToken1.Uint64()
+
+	deductingPoolValueToken2 := big.NewInt(0)
+	deductingPoolValueToken2.Mul(new(big.Int).SetUint64(pdePoolPair.Token2PoolValue), new(big.Int).SetUint64(wdSharesForWithdrawer))
+	deductingPoolValueToken2.Div(deductingPoolValueToken2, totalSharesForPair)
+	pdePoolPair.Token2PoolValue -= deductingPoolValueToken2.Uint64()
+	deductingAmounts.Token2IDStr = pdePoolPair.Token2IDStr
+	deductingAmounts.PoolValue2 = deductingPoolValueToken2.Uint64()
+
+	currentPDEState.PDESharesV3[shareForWithdrawerKey] -= wdSharesForWithdrawer
+	deductingAmounts.Shares = wdSharesForWithdrawer
+	return deductingAmounts
+}
+
+func (blockchain *BlockChain) buildInstructionsForPDEWithdrawalV3(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentPDEState *CurrentPDEState,
+	beaconHeight uint64,
+) ([][]string, error) {
+	if currentPDEState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForPDEWithdrawalV3]: Current PDE state is null.")
+		return [][]string{}, nil
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of pde v3 withdrawal action: %+v", err)
+		return [][]string{}, nil
+	}
+	var pdeWithdrawalRequestAction metadata.PDEWithdrawalRequestV3Action
+	err = json.Unmarshal(contentBytes, &pdeWithdrawalRequestAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling pde v3 withdrawal request action: %+v", err)
+		return [][]string{}, nil
+	}
+	wdMetaV3 := pdeWithdrawalRequestAction.Meta
+	deductingAmounts := deductPDEAmountsV3(
+		wdMetaV3,
+		currentPDEState,
+		beaconHeight,
+	)
+	if deductingAmounts == nil {
+		inst := []string{
+			strconv.Itoa(metaType),
+			strconv.Itoa(int(shardID)),
+			common.PDEWithdrawalRejectedChainStatus,
+			contentStr,
+		}
+		return [][]string{inst}, nil
+	}
+
+	wdMeta := metadata.PDEWithdrawalRequest{
+		WithdrawerAddressStr:  wdMetaV3.WithdrawerAddressStr,
+		WithdrawalToken1IDStr: wdMetaV3.WithdrawalToken1IDStr,
+		WithdrawalToken2IDStr: wdMetaV3.WithdrawalToken2IDStr,
+		WithdrawalShareAmt:    wdMetaV3.WithdrawalShareAmt,
+	}
+	inst1, err := buildPDEWithdrawalAcceptedInst(
+		wdMeta,
+		shardID,
+		metaType,
+		deductingAmounts.Token1IDStr,
+		deductingAmounts.PoolValue1,
+		deductingAmounts.Shares,
+		pdeWithdrawalRequestAction.TxReqID,
+		wdMetaV3.FeeTier,
+	)
+	if err != nil {
+		return [][]string{}, nil
+	}
+	inst2, err := buildPDEWithdrawalAcceptedInst(
+		wdMeta,
+		shardID,
+		metaType,
+		deductingAmounts.Token2IDStr,
+		deductingAmounts.PoolValue2,
+		0,
+		pdeWithdrawalRequestAction.TxReqID,
+		wdMetaV3.FeeTier,
+	)
+	if err != nil {
+		return [][]string{}, nil
+	}
+	return [][]string{inst1, inst2}, nil
+}
@@ -0,0 +1,197 @@
+package blockchain
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func (blockchain *BlockChain) processPortalTransferObligation(
+	portalStateDB *statedb.StateDB,
+	beaconHeight uint64,
+	instructions []string,
+	currentPortalState *CurrentPortalState,
+	portalParams PortalParams,
+) error {
+	if currentPortalState == nil {
+		Logger.log.Errorf("current portal state is nil")
+		return nil
+	}
+	if len(instructions) != 4 {
+		return nil // skip the instruction
+	}
+
+	var actionData metadata.PortalTransferObligationContent
+	err := json.Unmarshal([]byte(instructions[3]), &actionData)
+	if err != nil {
+		Logger.log.Errorf("Error when unmarshaling portal transfer obligation content %v - %v", instructions[3], err)
+		return nil
+	}
+
+	reqStatus := instructions[2]
+	if reqStatus == common.PortalTransferObligationRejectedChainStatus {
+		err = trackPortalTransferObligationStatus(
+			beaconHeight,
+			portalStateDB,
+			actionData,
+			common.PortalTransferObligationRejectedStatus,
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occurred while storing transfer obligation status %v", err)
+		}
+	} else if reqStatus == common.PortalTransferObligationAcceptedChainStatus {
+		senderStateKey := statedb.GenerateCustodianStateObjectKey(actionData.SenderAddressStr).String()
+		senderState, ok := currentPortalState.CustodianPoolState[senderStateKey]
+		if !ok || senderState == nil {
+			Logger.log.Errorf("Sender %v not found", actionData.SenderAddressStr)
+			return nil
+		}
+		receiverStateKey := statedb.GenerateCustodianStateObjectKey(actionData.ReceiverAddressStr).String()
+		receiverState, ok := currentPortalState.CustodianPoolState[receiverStateKey]
+		if !ok || receiverState == nil {
+			Logger.log.Errorf("Receiver %v not found", actionData.ReceiverAddressStr)
+			return nil
+		}
+
+		err = updateCustodianStatesAfterTransferObligation(
+			senderState,
+			receiverState,
+			actionData.TokenID,
+			actionData.Amount,
+			actionData.UnlockAmount,
+			actionData.LockAmount,
+		)
+		if err != nil {
+			Logger.log.Errorf("Error updating custodian states after transfer obligation %v", err)
+			return nil
+		}
+
+		// mark the transfer id as used
+		err = statedb.TrackPortalStateStatusMultiple(
+			portalStateDB,
+			statedb.PortalTransferObligationIDPrefix(),
+			[]byte(actionData.UniqueTransferID),
+			[]byte(actionData.TxReqID.String()),
+			beaconHeight,
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occurred while storing transfer obligation id %v", err)
+			return nil
+		}
+
+		err = trackPortalTransferObligationStatus(
+			beaconHeight,
+			portalStateDB,
+			actionData,
+			common.PortalTransferObligationAcceptedStatus,
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occurred while storing transfer obligation status %v", err)
+			return nil
+		}
+	}
+	return nil
+}
+
+func trackPortalTransferObligationStatus(
+	beaconHeight uint64,
+	portalStateDB *statedb.StateDB,
+	actionData metadata.PortalTransferObligationContent,
+	status byte,
+) error {
+	transferObligationStatus := metadata.NewPortalTransferObligationStatus(status, actionData)
+	statusContentBytes, _ := json.Marshal(transferObligationStatus)
+	return statedb.TrackPortalStateStatusMultiple(
+		portalStateDB,
+		statedb.PortalTransferObligationStatusPrefix(),
+		[]byte(actionData.TxReqID.String()),
+		statusContentBytes,
+		beaconHeight,
+	)
+}
+
+func (blockchain *BlockChain) processPortalRebalanceCollateral(
+	portalStateDB *statedb.StateDB,
+	beaconHeight uint64,
+	instructions []string,
+	currentPortalState *CurrentPortalState,
+	portalParams PortalParams,
+) error {
+	if currentPortalState == nil {
+		Logger.log.Errorf("current portal state is nil")
+		return nil
+	}
+	if len(instructions) != 4 {
+		return nil // skip the instruction
+	}
+
+	var actionData metadata.PortalRebalanceCollateralContent
+	err := json.Unmarshal([]byte(instructions[3]), &actionData)
+	if err != nil {
+		Logger.log.Errorf("Error when unmarshaling portal rebalance collateral content %v - %v", instructions[3], err)
+		return nil
+	}
+
+	reqStatus := instructions[2]
+	if reqStatus == common.PortalRebalanceCollateralRejectedChainStatus {
+		err = trackPortalRebalanceCollateralStatus(
+			beaconHeight,
+			portalStateDB,
+			actionData,
+			common.PortalRebalanceCollateralRejectedStatus,
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occurred while storing rebalance collateral status %v", err)
+		}
+	} else if reqStatus == common.PortalRebalanceCollateralAcceptedChainStatus {
+		custodianStateKey := statedb.GenerateCustodianStateObjectKey(actionData.CustodianAddressStr).String()
+		custodianState, ok := currentPortalState.CustodianPoolState[custodianStateKey]
+		if !ok || custodianState == nil {
+			Logger.log.Errorf("Custodian %v not found", actionData.CustodianAddressStr)
+			return nil
+		}
+
+		err = updateCustodianStateAfterRebalanceCollateral(
+			currentPortalState,
+			custodianState,
+			actionData.FromTokenID,
+			actionData.ToTokenID,
+			actionData.Amount,
+		)
+		if err != nil {
+			Logger.log.Errorf("Error updating custodian state after rebalance collateral %v", err)
+			return nil
+		}
+
+		err = trackPortalRebalanceCollateralStatus(
+			beaconHeight,
+			portalStateDB,
+			actionData,
+			common.PortalRebalanceCollateralAcceptedStatus,
+		)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occurred while storing rebalance collateral status %v", err)
+			return nil
+		}
+	}
+	return nil
+}
+
+func trackPortalRebalanceCollateralStatus(
+	beaconHeight uint64,
+	portalStateDB *statedb.StateDB,
+	actionData metadata.PortalRebalanceCollateralContent,
+	status byte,
+) error {
+	rebalanceCollateralStatus := metadata.NewPortalRebalanceCollateralStatus(status, actionData)
+	statusContentBytes, _ := json.Marshal(rebalanceCollateralStatus)
+	return statedb.TrackPortalStateStatusMultiple(
+		portalStateDB,
+		statedb.PortalRebalanceCollateralStatusPrefix(),
+		[]byte(actionData.TxReqID.String()),
+		statusContentBytes,
+		beaconHeight,
+	)
+}
@@ -0,0 +1,322 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// beacon build new instruction from instruction received from ShardToBeaconBlock
+func buildTransferObligationInst(
+	uniqueTransferID string,
+	tokenID string,
+	senderAddressStr string,
+	receiverAddressStr string,
+	amount uint64,
+	unlockAmount uint64,
+	lockAmount uint64,
+	metaType int,
+	shardID byte,
+	txReqID common.Hash,
+	status string,
+) []string {
+	transferObligationContent := metadata.PortalTransferObligationContent{
+		UniqueTransferID:   uniqueTransferID,
+		TokenID:            tokenID,
+		SenderAddressStr:   senderAddressStr,
+		ReceiverAddressStr: receiverAddressStr,
+		Amount:             amount,
+		UnlockAmount:       unlockAmount,
+		LockAmount:         lockAmount,
+		TxReqID:            txReqID,
+		ShardID:            shardID,
+	}
+	transferObligationContentBytes, _ := json.Marshal(transferObligationContent)
+	return []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		status,
+		string(transferObligationContentBytes),
+	}
+}
+
+// beacon build new instruction from instruction received from ShardToBeaconBlock
+func buildRebalanceCollateralInst(
+	custodianAddressStr string,
+	fromTokenID string,
+	toTokenID string,
+	amount uint64,
+	metaType int,
+	shardID byte,
+	txReqID common.Hash,
+	status string,
+) []string {
+	rebalanceCollateralContent := metadata.PortalRebalanceCollateralContent{
+		CustodianAddressStr: custodianAddressStr,
+		FromTokenID:         fromTokenID,
+		ToTokenID:           toTokenID,
+		Amount:              amount,
+		TxReqID:             txReqID,
+		ShardID:             shardID,
+	}
+	rebalanceCollateralContentBytes, _ := json.Marshal(rebalanceCollateralContent)
+	return []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		status,
+		string(rebalanceCollateralContentBytes),
+	}
+}
+
+// buildInstructionsForTransferObligation builds instruction for transfer obligation action:
+// the sender has transferred amount public token to the remote address of the receiver,
+// the receiver takes over the obligation and locks the collateral backing the public token
+func (blockchain *BlockChain) buildInstructionsForTransferObligation(
+	stateDB *statedb.StateDB,
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentPortalState *CurrentPortalState,
+	beaconHeight uint64,
+	portalParams PortalParams,
+) ([][]string, error) {
+	// parse instruction
+	actionContentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occurred while decoding content string of portal transfer obligation action: %+v", err)
+		return [][]string{}, nil
+	}
+	var actionData metadata.PortalTransferObligationAction
+	err = json.Unmarshal(actionContentBytes, &actionData)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occurred while unmarshal portal transfer obligation action: %+v", err)
+		return [][]string{}, nil
+	}
+	meta := actionData.Meta
+	rejectedInst := [][]string{buildTransferObligationInst(
+		meta.UniqueTransferID,
+		meta.TokenID,
+		meta.SenderAddressStr,
+		meta.ReceiverAddressStr,
+		meta.Amount,
+		0,
+		0,
+		meta.Type,
+		shardID,
+		actionData.TxReqID,
+		common.PortalTransferObligationRejectedChainStatus,
+	)}
+
+	if currentPortalState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForTransferObligation]: Current Portal state is null.")
+		return rejectedInst, nil
+	}
+	if currentPortalState.FinalExchangeRatesState == nil {
+		Logger.log.Errorf("Final exchange rates is nil")
+		return rejectedInst, nil
+	}
+
+	// the transfer proof can be used only once
+	if currentPortalState.TransferObligationIDs[meta.UniqueTransferID] {
+		Logger.log.Errorf("Transfer id %v was accepted in this block", meta.UniqueTransferID)
+		return rejectedInst, nil
+	}
+	_, err = statedb.GetPortalStateStatusMultiple(stateDB, statedb.PortalTransferObligationIDPrefix(), []byte(meta.UniqueTransferID))
+	if err == nil {
+		Logger.log.Errorf("Transfer id %v was accepted before", meta.UniqueTransferID)
+		return rejectedInst, nil
+	}
+
+	senderStateKey := statedb.GenerateCustodianStateObjectKey(meta.SenderAddressStr).String()
+	senderState, ok := currentPortalState.CustodianPoolState[senderStateKey]
+	if !ok || senderState == nil {
+		Logger.log.Errorf("Sender %v is not a custodian", meta.SenderAddressStr)
+		return rejectedInst, nil
+	}
+	receiverStateKey := statedb.GenerateCustodianStateObjectKey(meta.ReceiverAddressStr).String()
+	receiverState, ok := currentPortalState.CustodianPoolState[receiverStateKey]
+	if !ok || receiverState == nil {
+		Logger.log.Errorf("Receiver %v is not a custodian", meta.ReceiverAddressStr)
+		return rejectedInst, nil
+	}
+	receiverRemoteAddress, ok := receiverState.GetRemoteAddresses()[meta.TokenID]
+	if !ok || receiverRemoteAddress == "" {
+		Logger.log.Errorf("Receiver has no remote address for token %v", meta.TokenID)
+		return rejectedInst, nil
+	}
+	senderRemoteAddress, ok := senderState.GetRemoteAddresses()[meta.TokenID]
+	if !ok || senderRemoteAddress == "" {
+		Logger.log.Errorf("Sender has no remote address for token %v", meta.TokenID)
+		return rejectedInst, nil
+	}
+	if senderState.GetHoldingPublicTokens()[meta.TokenID] < meta.Amount {
+		Logger.log.Errorf("Holding public token amount of sender is less than transfer amount %v", meta.Amount)
+		return rejectedInst, nil
+	}
+
+	// validate proof and memo in tx
+	adapter, err := metadata.GetPortalChainAdapter(meta.TokenID)
+	if err != nil {
+		Logger.log.Errorf("Can not get the chain adapter: %v", err)
+		return rejectedInst, nil
+	}
+	externalTx, err := adapter.ParseAndVerifyProof(blockchain, meta.TransferProof)
+	if err != nil {
+		Logger.log.Errorf("TransferProof is invalid %v\n", err)
+		return rejectedInst, nil
+	}
+	err = adapter.VerifyTransferMemo(externalTx.Memo, meta.UniqueTransferID, meta.ReceiverAddressStr)
+	if err != nil {
+		Logger.log.Errorf("Memo of transfer proof is invalid %v\n", err)
+		return rejectedInst, nil
+	}
+	// the public tokens are transferred from the sender
+	err = externalTx.VerifySender(senderRemoteAddress)
+	if err != nil {
+		Logger.log.Errorf("TransferProof is invalid %v\n", err)
+		return rejectedInst, nil
+	}
+	err = externalTx.VerifyTransfer(adapter, receiverRemoteAddress, meta.Amount)
+	if err != nil {
+		Logger.log.Errorf("TransferProof is invalid %v\n", err)
+		return rejectedInst, nil
+	}
+
+	// the sender unlocks the collateral by percentage of transfer amount, the receiver locks the minimum collateral
+	unlockAmount, err := CalUnlockCollateralAmount(currentPortalState, senderStateKey, meta.Amount, meta.TokenID)
+	if err != nil {
+		Logger.log.Errorf("Error calculating unlock amount for sender %v", err)
+		return rejectedInst, nil
+	}
+	convertExchangeRatesObj := NewConvertExchangeRatesObject(currentPortalState.FinalExchangeRatesState)
+	lockAmount, err := convertExchangeRatesObj.ExchangePToken2PRVByTokenId(meta.TokenID, up150Percent(meta.Amount, portalParams.MinPercentLockedCollateral))
+	if err != nil {
+		Logger.log.Errorf("Error calculating lock amount for receiver %v", err)
+		return rejectedInst, nil
+	}
+
+	newSenderState := cloneCustodianState(senderState)
+	newReceiverState := cloneCustodianState(receiverState)
+	err = updateCustodianStatesAfterTransferObligation(newSenderState, newReceiverState, meta.TokenID, meta.Amount, unlockAmount, lockAmount)
+	if err != nil {
+		Logger.log.Errorf("Error updating custodian states after transfer obligation %v", err)
+		return rejectedInst, nil
+	}
+	err = checkTPRatioBeforeAndAfterChangingCollateral(currentPortalState, senderState, newSenderState, currentPortalState.FinalExchangeRatesState, portalParams)
+	if err != nil {
+		Logger.log.Errorf("Sender can not transfer obligation %v", err)
+		return rejectedInst, nil
+	}
+	err = checkTPRatioBeforeAndAfterChangingCollateral(currentPortalState, receiverState, newReceiverState, currentPortalState.FinalExchangeRatesState, portalParams)
+	if err != nil {
+		Logger.log.Errorf("Receiver can not take over obligation %v", err)
+		return rejectedInst, nil
+	}
+
+	currentPortalState.CustodianPoolState[senderStateKey] = newSenderState
+	currentPortalState.CustodianPoolState[receiverStateKey] = newReceiverState
+	currentPortalState.TransferObligationIDs[meta.UniqueTransferID] = true
+
+	inst := buildTransferObligationInst(
+		meta.UniqueTransferID,
+		meta.TokenID,
+		meta.SenderAddressStr,
+		meta.ReceiverAddressStr,
+		meta.Amount,
+		unlockAmount,
+		lockAmount,
+		meta.Type,
+		shardID,
+		actionData.TxReqID,
+		common.PortalTransferObligationAcceptedChainStatus,
+	)
+	return [][]string{inst}, nil
+}
+
+// buildInstructionsForRebalanceCollateral builds instruction for rebalance collateral action
+func (blockchain *BlockChain) buildInstructionsForRebalanceCollateral(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentPortalState *CurrentPortalState,
+	beaconHeight uint64,
+	portalParams PortalParams,
+) ([][]string, error) {
+	// parse instruction
+	actionContentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occurred while decoding content string of portal rebalance collateral action: %+v", err)
+		return [][]string{}, nil
+	}
+	var actionData metadata.PortalRebalanceCollateralAction
+	err = json.Unmarshal(actionContentBytes, &actionData)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occurred while unmarshal portal rebalance collateral action: %+v", err)
+		return [][]string{}, nil
+	}
+	meta := actionData.Meta
+	rejectedInst := [][]string{buildRebalanceCollateralInst(
+		meta.CustodianAddressStr,
+		meta.FromTokenID,
+		meta.ToTokenID,
+		meta.Amount,
+		meta.Type,
+		shardID,
+		actionData.TxReqID,
+		common.PortalRebalanceCollateralRejectedChainStatus,
+	)}
+
+	if currentPortalState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForRebalanceCollateral]: Current Portal state is null.")
+		return rejectedInst, nil
+	}
+	if currentPortalState.FinalExchangeRatesState == nil {
+		Logger.log.Errorf("Final exchange rates is nil")
+		return rejectedInst, nil
+	}
+	if meta.FromTokenID == meta.ToTokenID {
+		Logger.log.Errorf("Can not rebalance collateral of token %v to itself", meta.FromTokenID)
+		return rejectedInst, nil
+	}
+
+	custodianStateKey := statedb.GenerateCustodianStateObjectKey(meta.CustodianAddressStr).String()
+	custodianState, ok := currentPortalState.CustodianPoolState[custodianStateKey]
+	if !ok || custodianState == nil {
+		Logger.log.Errorf("Custodian %v not found", meta.CustodianAddressStr)
+		return rejectedInst, nil
+	}
+	// the collateral is only moved between the tokens the custodian holds
+	if _, ok := custodianState.GetHoldingPublicTokens()[meta.ToTokenID]; !ok {
+		Logger.log.Errorf("Custodian does not hold token %v", meta.ToTokenID)
+		return rejectedInst, nil
+	}
+
+	newCustodianState := cloneCustodianState(custodianState)
+	err = updateCustodianStateAfterRebalanceCollateral(currentPortalState, newCustodianState, meta.FromTokenID, meta.ToTokenID, meta.Amount)
+	if err != nil {
+		Logger.log.Errorf("Error updating custodian state after rebalance collateral %v", err)
+		return rejectedInst, nil
+	}
+	err = checkTPRatioBeforeAndAfterChangingCollateral(currentPortalState, custodianState, newCustodianState, currentPortalState.FinalExchangeRatesState, portalParams)
+	if err != nil {
+		Logger.log.Errorf("Custodian can not rebalance collateral %v", err)
+		return rejectedInst, nil
+	}
+	currentPortalState.CustodianPoolState[custodianStateKey] = newCustodianState
+
+	inst := buildRebalanceCollateralInst(
+		meta.CustodianAddressStr,
+		meta.FromTokenID,
+		meta.ToTokenID,
+		meta.Amount,
+		meta.Type,
+		shardID,
+		actionData.TxReqID,
+		common.PortalRebalanceCollateralAcceptedChainStatus,
+	)
+	return [][]string{inst}, nil
+}
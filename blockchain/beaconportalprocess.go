@@ -5,6 +5,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"math/big"
 	"sort"
 	"strconv"
 )
@@ -38,9 +39,18 @@ func (blockchain *BlockChain) processPortalInstructions(portalStateDB *statedb.S
 		//exchange rates
 		case strconv.Itoa(metadata.PortalExchangeRatesMeta):
 			err = blockchain.processPortalExchangeRates(portalStateDB, beaconHeight, inst, currentPortalState, portalParams)
+		//exchange rates feeders
+		case strconv.Itoa(metadata.PortalUpdateFeedersMeta):
+			err = blockchain.processPortalUpdateFeeders(portalStateDB, beaconHeight, inst, currentPortalState, portalParams)
 		//custodian withdraw
 		case strconv.Itoa(metadata.PortalCustodianWithdrawRequestMeta):
 			err = blockchain.processPortalCustodianWithdrawRequest(portalStateDB, beaconHeight, inst, currentPortalState, portalParams)
+		//custodian transfer obligation
+		case strconv.Itoa(metadata.PortalTransferObligationMeta):
+			err = blockchain.processPortalTransferObligation(portalStateDB, beaconHeight, inst, currentPortalState, portalParams)
+		//custodian rebalance collateral
+		case strconv.Itoa(metadata.PortalRebalanceCollateralMeta):
+			err = blockchain.processPortalRebalanceCollateral(portalStateDB, beaconHeight, inst, currentPortalState, portalParams)
 		//liquidation exchange rates
 		case strconv.Itoa(metadata.PortalLiquidateTPExchangeRatesMeta):
 			err = blockchain.processLiquidationTopPercentileExchangeRates(portalStateDB, beaconHeight, inst, currentPortalState, portalParams)
@@ -94,7 +104,11 @@ func (blockchain *BlockChain) processPortalInstructions(portalStateDB *statedb.S
 	}
 
 	//save final exchangeRates
-	blockchain.pickExchangesRatesFinal(currentPortalState)
+	if portalParams.ExchangeRatesWindow > 0 {
+		blockchain.aggregateExchangeRatesFeeds(beaconHeight, currentPortalState, portalParams)
+	} else {
+		blockchain.pickExchangesRatesFinal(currentPortalState)
+	}
 
 	// update info of bridge portal token
 	for _, updatingInfo := range updatingInfoByTokenID {
@@ -112,6 +126,7 @@ func (blockchain *BlockChain) processPortalInstructions(portalStateDB *statedb.S
 			portalStateDB,
 			updatingInfo.tokenID,
 			updatingInfo.externalTokenID,
+			updatingInfo.network,
 			updatingInfo.isCentralized,
 			updatingAmt,
 			updatingType,
@@ -577,6 +592,9 @@ func (blockchain *BlockChain) processPortalExchangeRates(
 		}
 
 		currentPortalState.ExchangeRatesRequests[portingExchangeRatesContent.TxReqID.String()] = newExchangeRates
+		if portalParams.ExchangeRatesWindow > 0 {
+			recordExchangeRatesFeeds(currentPortalState, portingExchangeRatesContent.SenderAddress, portingExchangeRatesContent.Rates, beaconHeight)
+		}
 
 		Logger.log.Infof("Portal exchange rates, exchange rates request: total exchange rate request %v", len(currentPortalState.ExchangeRatesRequests))
 
@@ -608,114 +626,194 @@ func (blockchain *BlockChain) processPortalExchangeRates(
 
 func (blockchain *BlockChain) pickExchangesRatesFinal(currentPortalState *CurrentPortalState) {
 	//convert to slice
-	var btcExchangeRatesSlice []uint64
-	var bnbExchangeRatesSlice []uint64
-	var prvExchangeRatesSlice []uint64
+	exchangeRatesSlices := make(map[string][]uint64)
 	for _, v := range currentPortalState.ExchangeRatesRequests {
 		for _, rate := range v.Rates {
-			switch rate.PTokenID {
-			case common.PortalBTCIDStr:
-				btcExchangeRatesSlice = append(btcExchangeRatesSlice, rate.Rate)
-				break
-			case common.PortalBNBIDStr:
-				bnbExchangeRatesSlice = append(bnbExchangeRatesSlice, rate.Rate)
-				break
-			case common.PRVIDStr:
-				prvExchangeRatesSlice = append(prvExchangeRatesSlice, rate.Rate)
-				break
+			if common.IsPortalExchangeRateToken(rate.PTokenID) {
+				exchangeRatesSlices[rate.PTokenID] = append(exchangeRatesSlices[rate.PTokenID], rate.Rate)
 			}
 		}
 	}
 
-	//sort
-	sort.SliceStable(btcExchangeRatesSlice, func(i, j int) bool {
-		return btcExchangeRatesSlice[i] < btcExchangeRatesSlice[j]
-	})
-
-	sort.SliceStable(bnbExchangeRatesSlice, func(i, j int) bool {
-		return bnbExchangeRatesSlice[i] < bnbExchangeRatesSlice[j]
-	})
-
-	sort.SliceStable(prvExchangeRatesSlice, func(i, j int) bool {
-		return prvExchangeRatesSlice[i] < prvExchangeRatesSlice[j]
-	})
-
 	exchangeRatesList := make(map[string]statedb.FinalExchangeRatesDetail)
+	tokenIDs := append(append([]string{}, common.PortalSupportedIncTokenIDs...), common.PRVIDStr)
+	for _, tokenID := range tokenIDs {
+		exchangeRatesSlice := exchangeRatesSlices[tokenID]
+		sort.SliceStable(exchangeRatesSlice, func(i, j int) bool {
+			return exchangeRatesSlice[i] < exchangeRatesSlice[j]
+		})
 
-	var btcAmount uint64
-	var bnbAmount uint64
-	var prvAmount uint64
-
-	//get current value
-	if len(btcExchangeRatesSlice) > 0 {
-		btcAmount = calcMedian(btcExchangeRatesSlice)
-	}
+		//get current value
+		var amount uint64
+		if len(exchangeRatesSlice) > 0 {
+			amount = calcMedian(exchangeRatesSlice)
+		}
 
-	if len(bnbExchangeRatesSlice) > 0 {
-		bnbAmount = calcMedian(bnbExchangeRatesSlice)
+		//pick current value and pre value state
+		if exchangeRatesState := currentPortalState.FinalExchangeRatesState; exchangeRatesState != nil {
+			var amountPreState uint64
+			if value, ok := exchangeRatesState.Rates()[tokenID]; ok {
+				amountPreState = value.Amount
+			}
+			amount = choicePrice(amount, amountPreState)
+		}
 
+		//select
+		if amount > 0 {
+			exchangeRatesList[tokenID] = statedb.FinalExchangeRatesDetail{
+				Amount: amount,
+			}
+		}
 	}
 
-	if len(prvExchangeRatesSlice) > 0 {
-		prvAmount = calcMedian(prvExchangeRatesSlice)
+	if len(exchangeRatesList) > 0 {
+		currentPortalState.FinalExchangeRatesState = statedb.NewFinalExchangeRatesStateWithValue(exchangeRatesList)
 	}
+}
 
-	//todo: need refactor code, not need write this code
-	//update value when has exchange
-	if exchangeRatesState := currentPortalState.FinalExchangeRatesState; exchangeRatesState != nil {
-		var btcAmountPreState uint64
-		var bnbAmountPreState uint64
-		var prvAmountPreState uint64
-		if value, ok := exchangeRatesState.Rates()[common.PortalBTCIDStr]; ok {
-			btcAmountPreState = value.Amount
-		}
+func calcMedian(ratesList []uint64) uint64 {
+	mNumber := len(ratesList) / 2
 
-		if value, ok := exchangeRatesState.Rates()[common.PortalBNBIDStr]; ok {
-			bnbAmountPreState = value.Amount
-		}
+	if len(ratesList)%2 == 0 {
+		// same as (a + b) / 2 without overflowing
+		a, b := ratesList[mNumber-1], ratesList[mNumber]
+		return a/2 + b/2 + (a%2+b%2)/2
+	}
 
-		if value, ok := exchangeRatesState.Rates()[common.PRVIDStr]; ok {
-			prvAmountPreState = value.Amount
-		}
+	return ratesList[mNumber]
+}
 
-		//pick current value and pre value state
-		btcAmount = choicePrice(btcAmount, btcAmountPreState)
-		bnbAmount = choicePrice(bnbAmount, bnbAmountPreState)
-		prvAmount = choicePrice(prvAmount, prvAmountPreState)
+func (blockchain *BlockChain) processPortalUpdateFeeders(
+	portalStateDB *statedb.StateDB,
+	beaconHeight uint64,
+	instructions []string,
+	currentPortalState *CurrentPortalState,
+	portalParams PortalParams) error {
+	if currentPortalState == nil {
+		Logger.log.Errorf("current portal state is nil")
+		return nil
 	}
 
-	//select
-	if btcAmount > 0 {
-		exchangeRatesList[common.PortalBTCIDStr] = statedb.FinalExchangeRatesDetail{
-			Amount: btcAmount,
-		}
+	// parse instruction
+	var portalUpdateFeedersContent metadata.PortalUpdateFeedersContent
+	err := json.Unmarshal([]byte(instructions[3]), &portalUpdateFeedersContent)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occurred while unmarshaling content string of portal update feeders instruction: %+v", err)
+		return nil
 	}
 
-	if bnbAmount > 0 {
-		exchangeRatesList[common.PortalBNBIDStr] = statedb.FinalExchangeRatesDetail{
-			Amount: bnbAmount,
-		}
+	if instructions[2] == common.PortalUpdateFeedersAcceptedChainStatus {
+		currentPortalState.Feeders = portalUpdateFeedersContent.Feeders
+		Logger.log.Infof("Portal update feeders, feeders: %+v", currentPortalState.Feeders)
 	}
+	return nil
+}
 
-	if prvAmount > 0 {
-		exchangeRatesList[common.PRVIDStr] = statedb.FinalExchangeRatesDetail{
-			Amount: prvAmount,
+// recordExchangeRatesFeeds keeps the rates of a feeder as its last feeds,
+// they are aggregated at the end of the window
+func recordExchangeRatesFeeds(currentPortalState *CurrentPortalState, feeder string, rates []*metadata.ExchangeRateInfo, beaconHeight uint64) {
+	exchangeRatesState := currentPortalState.FinalExchangeRatesState
+	if exchangeRatesState == nil {
+		exchangeRatesState = statedb.NewFinalExchangeRatesState()
+	}
+	feeds := make(map[string]map[string]statedb.ExchangeRatesFeed)
+	for tokenID, tokenFeeds := range exchangeRatesState.Feeds() {
+		feeds[tokenID] = make(map[string]statedb.ExchangeRatesFeed)
+		for feederAddress, feed := range tokenFeeds {
+			feeds[tokenID][feederAddress] = feed
 		}
 	}
-
-	if len(exchangeRatesList) > 0 {
-		currentPortalState.FinalExchangeRatesState = statedb.NewFinalExchangeRatesStateWithValue(exchangeRatesList)
+	for _, rate := range rates {
+		if feeds[rate.PTokenID] == nil {
+			feeds[rate.PTokenID] = make(map[string]statedb.ExchangeRatesFeed)
+		}
+		feeds[rate.PTokenID][feeder] = statedb.ExchangeRatesFeed{Rate: rate.Rate, BeaconHeight: beaconHeight}
 	}
+	currentPortalState.FinalExchangeRatesState = statedb.NewFinalExchangeRatesStateWithFeeds(exchangeRatesState.Rates(), feeds)
 }
 
-func calcMedian(ratesList []uint64) uint64 {
-	mNumber := len(ratesList) / 2
+// aggregateExchangeRatesFeeds sets the final rate of each token to the median
+// of the fresh feeds of the current feeders at the end of each window. The
+// previous rate is kept when less than a majority of the feeders (or
+// MinExchangeRatesFeeds if higher) are left once the outliers are rejected.
+// The stale feeds and the feeds of removed feeders are dropped.
+func (blockchain *BlockChain) aggregateExchangeRatesFeeds(beaconHeight uint64, currentPortalState *CurrentPortalState, portalParams PortalParams) {
+	exchangeRatesState := currentPortalState.FinalExchangeRatesState
+	if exchangeRatesState == nil || beaconHeight%portalParams.ExchangeRatesWindow != 0 {
+		return
+	}
+	feeders := make(map[string]bool)
+	for _, feeder := range blockchain.getPortalFeeders(currentPortalState) {
+		feeders[feeder] = true
+	}
+	minFeeds := len(feeders)/2 + 1
+	if portalParams.MinExchangeRatesFeeds > minFeeds {
+		minFeeds = portalParams.MinExchangeRatesFeeds
+	}
+
+	rates := make(map[string]statedb.FinalExchangeRatesDetail)
+	for tokenID, rate := range exchangeRatesState.Rates() {
+		rates[tokenID] = rate
+	}
+	feeds := make(map[string]map[string]statedb.ExchangeRatesFeed)
+	for tokenID, tokenFeeds := range exchangeRatesState.Feeds() {
+		tokenRates := []uint64{}
+		for feeder, feed := range tokenFeeds {
+			if !feeders[feeder] {
+				continue
+			}
+			if portalParams.MaxExchangeRatesFeedAge > 0 && beaconHeight-feed.BeaconHeight > portalParams.MaxExchangeRatesFeedAge {
+				continue
+			}
+			if feeds[tokenID] == nil {
+				feeds[tokenID] = make(map[string]statedb.ExchangeRatesFeed)
+			}
+			feeds[tokenID][feeder] = feed
+			tokenRates = append(tokenRates, feed.Rate)
+		}
+		rate, ok := calcExchangeRatesMedian(tokenRates, minFeeds, portalParams.MaxPercentExchangeRatesDeviation)
+		if !ok {
+			Logger.log.Infof("Portal exchange rates, not enough feeds for token %v at beacon height %v, keep the previous rate", tokenID, beaconHeight)
+			continue
+		}
+		rates[tokenID] = statedb.FinalExchangeRatesDetail{
+			Amount: rate,
+		}
+	}
+	currentPortalState.FinalExchangeRatesState = statedb.NewFinalExchangeRatesStateWithFeeds(rates, feeds)
+}
 
-	if len(ratesList)%2 == 0 {
-		return (ratesList[mNumber-1] + ratesList[mNumber]) / 2
+// calcExchangeRatesMedian returns the median of the rates within
+// maxPercentDeviation percent of the median of all the rates, false if less
+// than minFeeds rates are left
+func calcExchangeRatesMedian(rates []uint64, minFeeds int, maxPercentDeviation uint64) (uint64, bool) {
+	if minFeeds < 1 {
+		minFeeds = 1
+	}
+	if len(rates) < minFeeds {
+		return 0, false
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i] < rates[j]
+	})
+	median := calcMedian(rates)
+	if maxPercentDeviation == 0 {
+		return median, true
 	}
 
-	return ratesList[mNumber]
+	maxDeviation := new(big.Int).Mul(new(big.Int).SetUint64(median), new(big.Int).SetUint64(maxPercentDeviation))
+	keptRates := []uint64{}
+	for _, rate := range rates {
+		deviation := new(big.Int).Sub(new(big.Int).SetUint64(rate), new(big.Int).SetUint64(median))
+		deviation.Abs(deviation).Mul(deviation, big.NewInt(100))
+		if deviation.Cmp(maxDeviation) <= 0 {
+			keptRates = append(keptRates, rate)
+		}
+	}
+	if len(keptRates) < minFeeds {
+		return 0, false
+	}
+	return calcMedian(keptRates), true
 }
 
 func choicePrice(currentPrice uint64, prePrice uint64) uint64 {
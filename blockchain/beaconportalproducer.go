@@ -3,13 +3,6 @@ package blockchain
 import (
 	"encoding/base64"
 	"encoding/json"
-	"github.com/binance-chain/go-sdk/types/msg"
-	"github.com/incognitochain/incognito-chain/relaying/bnb"
-	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
-
-	//"github.com/binance-chain/go-sdk/types/msg"
-	//"github.com/incognitochain/incognito-chain/relaying/bnb"
-	//btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
@@ -603,299 +596,67 @@ func (blockchain *BlockChain) buildInstructionsForReqPTokens(
 		return [][]string{inst}, nil
 	}
 
-	if meta.TokenID == common.PortalBTCIDStr {
-		btcChain := blockchain.config.BTCChain
-		if btcChain == nil {
-			Logger.log.Error("BTC relaying chain should not be null")
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-		// parse PortingProof in meta
-		btcTxProof, err := btcrelaying.ParseBTCProofFromB64EncodeStr(meta.PortingProof)
-		if err != nil {
-			Logger.log.Errorf("PortingProof is invalid %v\n", err)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-
-		isValid, err := btcChain.VerifyTxWithMerkleProofs(btcTxProof)
-		if !isValid || err != nil {
-			Logger.log.Errorf("Verify btcTxProof failed %v", err)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-
-		// extract attached message from txOut's OP_RETURN
-		btcAttachedMsg, err := btcrelaying.ExtractAttachedMsgFromTx(btcTxProof.BTCTx)
-		if err != nil {
-			Logger.log.Errorf("Could not extract attached message from BTC tx proof with err: %v", err)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-
-		encodedMsg := btcrelaying.HashAndEncodeBase58(meta.UniquePortingID)
-		if btcAttachedMsg != encodedMsg {
-			Logger.log.Errorf("PortingId in the btc attached message is not matched with portingID in metadata")
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-
-		// check whether amount transfer in txBNB is equal porting amount or not
-		// check receiver and amount in tx
-		// get list matching custodians in waitingPortingRequest
-		custodians := waitingPortingRequest.Custodians()
-		outputs := btcTxProof.BTCTx.TxOut
-		for _, cusDetail := range custodians {
-			remoteAddressNeedToBeTransfer := cusDetail.RemoteAddress
-			amountNeedToBeTransfer := cusDetail.Amount
-			amountNeedToBeTransferInBTC := btcrelaying.ConvertIncPBTCAmountToExternalBTCAmount(int64(amountNeedToBeTransfer))
-
-			isChecked := false
-			for _, out := range outputs {
-				addrStr, err := btcChain.ExtractPaymentAddrStrFromPkScript(out.PkScript)
-				if err != nil {
-					Logger.log.Errorf("[portal] ExtractPaymentAddrStrFromPkScript: could not extract payment address string from pkscript with err: %v\n", err)
-					continue
-				}
-				if addrStr != remoteAddressNeedToBeTransfer {
-					continue
-				}
-				if out.Value < amountNeedToBeTransferInBTC {
-					Logger.log.Errorf("BTC-TxProof is invalid - the transferred amount to %s must be equal to or greater than %d, but got %d", addrStr, amountNeedToBeTransferInBTC, out.Value)
-					inst := buildReqPTokensInst(
-						meta.UniquePortingID,
-						meta.TokenID,
-						meta.IncogAddressStr,
-						meta.PortingAmount,
-						meta.PortingProof,
-						meta.Type,
-						shardID,
-						actionData.TxReqID,
-						common.PortalReqPTokensRejectedChainStatus,
-					)
-					return [][]string{inst}, nil
-				} else {
-					isChecked = true
-					break
-				}
-			}
-			if !isChecked {
-				Logger.log.Error("BTC-TxProof is invalid")
-				inst := buildReqPTokensInst(
-					meta.UniquePortingID,
-					meta.TokenID,
-					meta.IncogAddressStr,
-					meta.PortingAmount,
-					meta.PortingProof,
-					meta.Type,
-					shardID,
-					actionData.TxReqID,
-					common.PortalReqPTokensRejectedChainStatus,
-				)
-				return [][]string{inst}, nil
-			}
-		}
-		// update holding public token for custodians
-		for _, cusDetail := range custodians {
-			custodianKey := statedb.GenerateCustodianStateObjectKey(cusDetail.IncAddress)
-			UpdateCustodianStateAfterUserRequestPToken(currentPortalState, custodianKey.String(), waitingPortingRequest.TokenID(), cusDetail.Amount)
-		}
-
+	adapter, err := metadata.GetPortalChainAdapter(meta.TokenID)
+	if err != nil {
+		Logger.log.Errorf("Can not get the chain adapter: %v", err)
 		inst := buildReqPTokensInst(
-			actionData.Meta.UniquePortingID,
-			actionData.Meta.TokenID,
-			actionData.Meta.IncogAddressStr,
-			actionData.Meta.PortingAmount,
-			actionData.Meta.PortingProof,
-			actionData.Meta.Type,
+			meta.UniquePortingID,
+			meta.TokenID,
+			meta.IncogAddressStr,
+			meta.PortingAmount,
+			meta.PortingProof,
+			meta.Type,
 			shardID,
 			actionData.TxReqID,
-			common.PortalReqPTokensAcceptedChainStatus,
+			common.PortalReqPTokensRejectedChainStatus,
 		)
-
-		// remove waiting porting request from currentPortalState
-		deleteWaitingPortingRequest(currentPortalState, keyWaitingPortingRequestStr)
 		return [][]string{inst}, nil
+	}
 
-	} else if meta.TokenID == common.PortalBNBIDStr {
-		// parse PortingProof in meta
-		txProofBNB, err := bnb.ParseBNBProofFromB64EncodeStr(meta.PortingProof)
-		if err != nil {
-			Logger.log.Errorf("PortingProof is invalid %v\n", err)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-
-		// check minimum confirmations block of bnb proof
-		latestBNBBlockHeight, err2 := blockchain.GetLatestBNBBlkHeight()
-		if err2 != nil {
-			Logger.log.Errorf("Can not get latest relaying bnb block height %v\n", err)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-
-		if latestBNBBlockHeight < txProofBNB.BlockHeight+bnb.MinConfirmationsBlock {
-			Logger.log.Errorf("Not enough min bnb confirmations block %v, latestBNBBlockHeight %v - txProofBNB.BlockHeight %v\n",
-				bnb.MinConfirmationsBlock, latestBNBBlockHeight, txProofBNB.BlockHeight)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-		dataHash, err2 := blockchain.GetBNBDataHash(txProofBNB.BlockHeight)
-		if err2 != nil {
-			Logger.log.Errorf("Error when get data hash in blockHeight %v - %v\n",
-				txProofBNB.BlockHeight, err2)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
+	// verify PortingProof in meta against the relayed headers
+	externalTx, err := adapter.ParseAndVerifyProof(blockchain, meta.PortingProof)
+	if err != nil {
+		Logger.log.Errorf("PortingProof is invalid %v\n", err)
+		inst := buildReqPTokensInst(
+			meta.UniquePortingID,
+			meta.TokenID,
+			meta.IncogAddressStr,
+			meta.PortingAmount,
+			meta.PortingProof,
+			meta.Type,
+			shardID,
+			actionData.TxReqID,
+			common.PortalReqPTokensRejectedChainStatus,
+		)
+		return [][]string{inst}, nil
+	}
 
-		isValid, err := txProofBNB.Verify(dataHash)
-		if !isValid || err != nil {
-			Logger.log.Errorf("Verify txProofBNB failed %v", err)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
+	// check memo attach portingID req
+	err = adapter.VerifyPortingMemo(externalTx.Memo, meta.UniquePortingID)
+	if err != nil {
+		Logger.log.Errorf("Memo in PortingProof is invalid %v", err)
+		inst := buildReqPTokensInst(
+			meta.UniquePortingID,
+			meta.TokenID,
+			meta.IncogAddressStr,
+			meta.PortingAmount,
+			meta.PortingProof,
+			meta.Type,
+			shardID,
+			actionData.TxReqID,
+			common.PortalReqPTokensRejectedChainStatus,
+		)
+		return [][]string{inst}, nil
+	}
 
-		// parse Tx from Data in txProofBNB
-		txBNB, err := bnb.ParseTxFromData(txProofBNB.Proof.Data)
+	// check whether amount transfer in external tx is equal porting amount or not
+	// check receiver and amount in tx
+	// get list matching custodians in waitingPortingRequest
+	custodians := waitingPortingRequest.Custodians()
+	for _, cusDetail := range custodians {
+		err = externalTx.VerifyTransfer(adapter, cusDetail.RemoteAddress, cusDetail.Amount)
 		if err != nil {
-			Logger.log.Errorf("Data in PortingProof is invalid %v", err)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-
-		// check memo attach portingID req:
-		memo := txBNB.Memo
-		memoBytes, err2 := base64.StdEncoding.DecodeString(memo)
-		if err2 != nil {
-			Logger.log.Errorf("Can not decode memo in tx bnb proof", err2)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-
-		var portingMemo PortingMemoBNB
-		err2 = json.Unmarshal(memoBytes, &portingMemo)
-		if err2 != nil {
-			Logger.log.Errorf("Can not unmarshal memo in tx bnb proof", err2)
+			Logger.log.Errorf("PortingProof is invalid - %v", err)
 			inst := buildReqPTokensInst(
 				meta.UniquePortingID,
 				meta.TokenID,
@@ -909,122 +670,29 @@ func (blockchain *BlockChain) buildInstructionsForReqPTokens(
 			)
 			return [][]string{inst}, nil
 		}
+	}
 
-		if portingMemo.PortingID != meta.UniquePortingID {
-			Logger.log.Errorf("PortingId in memoTx is not matched with portingID in metadata", err2)
-			inst := buildReqPTokensInst(
-				meta.UniquePortingID,
-				meta.TokenID,
-				meta.IncogAddressStr,
-				meta.PortingAmount,
-				meta.PortingProof,
-				meta.Type,
-				shardID,
-				actionData.TxReqID,
-				common.PortalReqPTokensRejectedChainStatus,
-			)
-			return [][]string{inst}, nil
-		}
-
-		// check whether amount transfer in txBNB is equal porting amount or not
-		// check receiver and amount in tx
-		// get list matching custodians in waitingPortingRequest
-		custodians := waitingPortingRequest.Custodians()
-		outputs := txBNB.Msgs[0].(msg.SendMsg).Outputs
-		for _, cusDetail := range custodians {
-			remoteAddressNeedToBeTransfer := cusDetail.RemoteAddress
-			amountNeedToBeTransfer := cusDetail.Amount
-			amountNeedToBeTransferInBNB := convertIncPBNBAmountToExternalBNBAmount(int64(amountNeedToBeTransfer))
-
-			isChecked := false
-			for _, out := range outputs {
-				addr, _ := bnb.GetAccAddressString(&out.Address, blockchain.config.ChainParams.BNBRelayingHeaderChainID)
-				if addr != remoteAddressNeedToBeTransfer {
-					Logger.log.Warnf("[portal] remoteAddressNeedToBeTransfer: %v - addr: %v\n", remoteAddressNeedToBeTransfer, addr)
-					continue
-				}
-
-				// calculate amount that was transferred to custodian's remote address
-				amountTransfer := int64(0)
-				for _, coin := range out.Coins {
-					if coin.Denom == bnb.DenomBNB {
-						amountTransfer += coin.Amount
-					}
-				}
-				if amountTransfer < amountNeedToBeTransferInBNB {
-					Logger.log.Errorf("TxProof-BNB is invalid - Amount transfer to %s must be equal to or greater than %d, but got %d",
-						addr, amountNeedToBeTransferInBNB, amountTransfer)
-					inst := buildReqPTokensInst(
-						meta.UniquePortingID,
-						meta.TokenID,
-						meta.IncogAddressStr,
-						meta.PortingAmount,
-						meta.PortingProof,
-						meta.Type,
-						shardID,
-						actionData.TxReqID,
-						common.PortalReqPTokensRejectedChainStatus,
-					)
-					return [][]string{inst}, nil
-				} else {
-					isChecked = true
-					break
-				}
-			}
-			if !isChecked {
-				Logger.log.Errorf("TxProof-BNB is invalid - Receiver address is invalid, expected %v",
-					remoteAddressNeedToBeTransfer)
-				inst := buildReqPTokensInst(
-					meta.UniquePortingID,
-					meta.TokenID,
-					meta.IncogAddressStr,
-					meta.PortingAmount,
-					meta.PortingProof,
-					meta.Type,
-					shardID,
-					actionData.TxReqID,
-					common.PortalReqPTokensRejectedChainStatus,
-				)
-				return [][]string{inst}, nil
-			}
-		}
-
-		// update holding public token for custodians
-		for _, cusDetail := range custodians {
-			custodianKey := statedb.GenerateCustodianStateObjectKey(cusDetail.IncAddress)
-			UpdateCustodianStateAfterUserRequestPToken(currentPortalState, custodianKey.String(), waitingPortingRequest.TokenID(), cusDetail.Amount)
-		}
+	// update holding public token for custodians
+	for _, cusDetail := range custodians {
+		custodianKey := statedb.GenerateCustodianStateObjectKey(cusDetail.IncAddress)
+		UpdateCustodianStateAfterUserRequestPToken(currentPortalState, custodianKey.String(), waitingPortingRequest.TokenID(), cusDetail.Amount)
+	}
 
-		inst := buildReqPTokensInst(
-			actionData.Meta.UniquePortingID,
-			actionData.Meta.TokenID,
-			actionData.Meta.IncogAddressStr,
-			actionData.Meta.PortingAmount,
-			actionData.Meta.PortingProof,
-			actionData.Meta.Type,
-			shardID,
-			actionData.TxReqID,
-			common.PortalReqPTokensAcceptedChainStatus,
-		)
+	inst := buildReqPTokensInst(
+		actionData.Meta.UniquePortingID,
+		actionData.Meta.TokenID,
+		actionData.Meta.IncogAddressStr,
+		actionData.Meta.PortingAmount,
+		actionData.Meta.PortingProof,
+		actionData.Meta.Type,
+		shardID,
+		actionData.TxReqID,
+		common.PortalReqPTokensAcceptedChainStatus,
+	)
 
-		// remove waiting porting request from currentPortalState
-		deleteWaitingPortingRequest(currentPortalState, keyWaitingPortingRequestStr)
-		return [][]string{inst}, nil
-	} else {
-		Logger.log.Errorf("TokenID is not supported currently on Portal")
-		inst := buildReqPTokensInst(
-			meta.UniquePortingID,
-			meta.TokenID,
-			meta.IncogAddressStr,
-			meta.PortingAmount,
-			meta.PortingProof,
-			meta.Type,
-			shardID,
-			actionData.TxReqID,
-			common.PortalReqPTokensRejectedChainStatus,
-		)
-		return [][]string{inst}, nil
-	}
+	// remove waiting porting request from currentPortalState
+	deleteWaitingPortingRequest(currentPortalState, keyWaitingPortingRequestStr)
+	return [][]string{inst}, nil
 }
 
 func (blockchain *BlockChain) buildInstructionsForExchangeRates(
@@ -1049,29 +717,32 @@ func (blockchain *BlockChain) buildInstructionsForExchangeRates(
 	}
 
 	//check key from db
+	isDuplicated := false
 	if currentPortalState.ExchangeRatesRequests != nil {
-		_, ok := currentPortalState.ExchangeRatesRequests[actionData.TxReqID.String()]
-		if ok {
-			Logger.log.Errorf("ERROR: exchange rates key is duplicated")
-
-			portalExchangeRatesContent := metadata.PortalExchangeRatesContent{
-				SenderAddress: actionData.Meta.SenderAddress,
-				Rates:         actionData.Meta.Rates,
-				TxReqID:       actionData.TxReqID,
-				LockTime:      actionData.LockTime,
-			}
-
-			portalExchangeRatesContentBytes, _ := json.Marshal(portalExchangeRatesContent)
+		_, isDuplicated = currentPortalState.ExchangeRatesRequests[actionData.TxReqID.String()]
+	}
+	// the feeders may have been updated after the shard accepted the request
+	isFeeder := blockchain.isPortalFeeder(currentPortalState, actionData.Meta.SenderAddress)
+	if isDuplicated || !isFeeder {
+		Logger.log.Errorf("ERROR: exchange rates key is duplicated or sender %v is not a feeder", actionData.Meta.SenderAddress)
+
+		portalExchangeRatesContent := metadata.PortalExchangeRatesContent{
+			SenderAddress: actionData.Meta.SenderAddress,
+			Rates:         actionData.Meta.Rates,
+			TxReqID:       actionData.TxReqID,
+			LockTime:      actionData.LockTime,
+		}
 
-			inst := []string{
-				strconv.Itoa(metaType),
-				strconv.Itoa(int(shardID)),
-				common.PortalExchangeRatesRejectedChainStatus,
-				string(portalExchangeRatesContentBytes),
-			}
+		portalExchangeRatesContentBytes, _ := json.Marshal(portalExchangeRatesContent)
 
-			return [][]string{inst}, nil
+		inst := []string{
+			strconv.Itoa(metaType),
+			strconv.Itoa(int(shardID)),
+			common.PortalExchangeRatesRejectedChainStatus,
+			string(portalExchangeRatesContentBytes),
 		}
+
+		return [][]string{inst}, nil
 	}
 
 	//success
@@ -1113,6 +784,70 @@ func (blockchain *BlockChain) buildInstructionsForExchangeRates(
 	return [][]string{inst}, nil
 }
 
+// getPortalFeeders returns the feeders of the exchange rates, the feeders of
+// the chain params until the governor updates them
+func (blockchain *BlockChain) getPortalFeeders(currentPortalState *CurrentPortalState) []string {
+	if currentPortalState.Feeders != nil {
+		return currentPortalState.Feeders
+	}
+	return blockchain.GetPortalFeederAddresses()
+}
+
+func (blockchain *BlockChain) isPortalFeeder(currentPortalState *CurrentPortalState, address string) bool {
+	for _, feeder := range blockchain.getPortalFeeders(currentPortalState) {
+		if feeder == address {
+			return true
+		}
+	}
+	return false
+}
+
+func (blockchain *BlockChain) buildInstructionsForUpdateFeeders(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentPortalState *CurrentPortalState,
+	beaconHeight uint64,
+	portalParams PortalParams,
+) ([][]string, error) {
+	actionContentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occurred while decoding content string of portal update feeders action: %+v", err)
+		return [][]string{}, nil
+	}
+
+	var actionData metadata.PortalUpdateFeedersAction
+	err = json.Unmarshal(actionContentBytes, &actionData)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occurred while unmarshal portal update feeders action: %+v", err)
+		return [][]string{}, nil
+	}
+
+	portalUpdateFeedersContent := metadata.PortalUpdateFeedersContent{
+		GovernorAddress: actionData.Meta.GovernorAddress,
+		Feeders:         actionData.Meta.Feeders,
+		TxReqID:         actionData.TxReqID,
+		ShardID:         shardID,
+	}
+	portalUpdateFeedersContentBytes, _ := json.Marshal(portalUpdateFeedersContent)
+
+	status := common.PortalUpdateFeedersAcceptedChainStatus
+	if actionData.Meta.GovernorAddress != blockchain.GetPortalFeederGovernorAddress() || len(actionData.Meta.Feeders) == 0 {
+		Logger.log.Errorf("ERROR: update feeders %v is not signed by the governor or has no feeder", actionData.TxReqID.String())
+		status = common.PortalUpdateFeedersRejectedChainStatus
+	} else {
+		currentPortalState.Feeders = actionData.Meta.Feeders
+	}
+
+	inst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		status,
+		string(portalUpdateFeedersContentBytes),
+	}
+	return [][]string{inst}, nil
+}
+
 /**
 Validation:
 	- verify each instruct belong shard
@@ -13,6 +13,7 @@ import (
 	"github.com/incognitochain/incognito-chain/blockchain/btc"
 
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
 )
@@ -78,6 +79,7 @@ func (blockchain *BlockChain) NewBlockBeacon(curView *BeaconBestState, version i
 	beaconBlock.Header.Epoch = epoch
 	beaconBlock.Header.Round = round
 	beaconBlock.Header.PreviousBlockHash = beaconBestState.BestBlockHash
+	beaconBlock.Header.PreviousStateRootsHash = blockchain.previousStateRootsHash(curView.stateRoots(), beaconBlock.Header.Height)
 	BLogger.log.Infof("Producing block: %d (epoch %d)", beaconBlock.Header.Height, beaconBlock.Header.Epoch)
 	//=====END Build Header Essential Data=====
 	//============Build body===================
@@ -101,11 +103,11 @@ func (blockchain *BlockChain) NewBlockBeacon(curView *BeaconBestState, version i
 		}
 	}
 
-	tempShardState, stakeInstructions, swapInstructions, bridgeInstructions, acceptedRewardInstructions, stopAutoStakingInstructions := blockchain.GetShardState(beaconBestState, rewardForCustodianByEpoch, portalParams)
+	tempShardState, stakeInstructions, swapInstructions, bridgeInstructions, acceptedRewardInstructions, stopAutoStakingInstructions, equivocationSlashInstructions := blockchain.GetShardState(beaconBestState, rewardForCustodianByEpoch, portalParams)
 
 	Logger.log.Infof("In NewBlockBeacon tempShardState: %+v", tempShardState)
 	tempInstruction, err := beaconBestState.GenerateInstruction(
-		beaconBlock.Header.Height, stakeInstructions, swapInstructions, stopAutoStakingInstructions,
+		beaconBlock.Header.Height, stakeInstructions, swapInstructions, stopAutoStakingInstructions, equivocationSlashInstructions,
 		beaconBestState.CandidateShardWaitingForCurrentRandom, bridgeInstructions, acceptedRewardInstructions, blockchain.config.ChainParams.Epoch,
 		blockchain.config.ChainParams.RandomTime, blockchain,
 	)
@@ -249,11 +251,14 @@ func (blockchain *BlockChain) NewBlockBeacon(curView *BeaconBestState, version i
 // 4. bridge instructions
 // 5. accepted reward instructions
 // 6. stop auto staking instructions
-func (blockchain *BlockChain) GetShardState(beaconBestState *BeaconBestState, rewardForCustodianByEpoch map[common.Hash]uint64, portalParams PortalParams) (map[byte][]ShardState, [][]string, map[byte][][]string, [][]string, [][]string, [][]string) {
+// 7. equivocation slash instructions
+func (blockchain *BlockChain) GetShardState(beaconBestState *BeaconBestState, rewardForCustodianByEpoch map[common.Hash]uint64, portalParams PortalParams) (map[byte][]ShardState, [][]string, map[byte][][]string, [][]string, [][]string, [][]string, [][]string) {
 	shardStates := make(map[byte][]ShardState)
 	validStakeInstructions := [][]string{}
 	validStakePublicKeys := []string{}
 	validStopAutoStakingInstructions := [][]string{}
+	equivocationSlashInstructions := [][]string{}
+	slashedPublicKeys := []string{}
 	validSwapInstructions := make(map[byte][][]string)
 	//Get shard to beacon block from pool
 	allShardBlocks := blockchain.GetShardBlockForBeaconProducer(beaconBestState.BestShardHeight)
@@ -272,13 +277,15 @@ func (blockchain *BlockChain) GetShardState(beaconBestState *BeaconBestState, re
 		Logger.log.Infof("Beacon Producer Got %+v Shard Block from shard %+v: ", len(shardBlocks), shardID)
 		for _, shardBlock := range shardBlocks {
 			Logger.log.Info("Add shard block in shardstate", shardID, "height", shardBlock.GetHeight(), shardBlock.Hash().String())
-			shardState, validStakeInstruction, tempValidStakePublicKeys, validSwapInstruction, bridgeInstruction, acceptedRewardInstruction, stopAutoStakingInstruction, statefulActions := blockchain.GetShardStateFromBlock(beaconBestState, beaconBestState.BeaconHeight+1, shardBlock, shardID, true, validStakePublicKeys)
+			shardState, validStakeInstruction, tempValidStakePublicKeys, validSwapInstruction, bridgeInstruction, acceptedRewardInstruction, stopAutoStakingInstruction, equivocationSlashInstruction, statefulActions := blockchain.GetShardStateFromBlock(beaconBestState, beaconBestState.BeaconHeight+1, shardBlock, shardID, true, validStakePublicKeys, slashedPublicKeys)
 			shardStates[shardID] = append(shardStates[shardID], shardState[shardID])
 			validStakeInstructions = append(validStakeInstructions, validStakeInstruction...)
 			validSwapInstructions[shardID] = append(validSwapInstructions[shardID], validSwapInstruction[shardID]...)
 			bridgeInstructions = append(bridgeInstructions, bridgeInstruction...)
 			acceptedRewardInstructions = append(acceptedRewardInstructions, acceptedRewardInstruction)
 			validStopAutoStakingInstructions = append(validStopAutoStakingInstructions, stopAutoStakingInstruction...)
+			equivocationSlashInstructions = append(equivocationSlashInstructions, equivocationSlashInstruction...)
+			slashedPublicKeys = append(slashedPublicKeys, getEquivocationSlashedPublicKeys(equivocationSlashInstruction)...)
 			validStakePublicKeys = append(validStakePublicKeys, tempValidStakePublicKeys...)
 
 			// group stateful actions by shardID
@@ -294,7 +301,7 @@ func (blockchain *BlockChain) GetShardState(beaconBestState *BeaconBestState, re
 	// build stateful instructions
 	statefulInsts := blockchain.buildStatefulInstructions(beaconBestState.featureStateDB, statefulActionsByShardID, beaconBestState.BeaconHeight+1, rewardForCustodianByEpoch, portalParams)
 	bridgeInstructions = append(bridgeInstructions, statefulInsts...)
-	return shardStates, validStakeInstructions, validSwapInstructions, bridgeInstructions, acceptedRewardInstructions, validStopAutoStakingInstructions
+	return shardStates, validStakeInstructions, validSwapInstructions, bridgeInstructions, acceptedRewardInstructions, validStopAutoStakingInstructions, equivocationSlashInstructions
 }
 
 // GetShardStateFromBlock get state (information) from shard-to-beacon block
@@ -307,6 +314,8 @@ func (blockchain *BlockChain) GetShardState(beaconBestState *BeaconBestState, re
 //	- ["stake" "pubkey1,pubkey2,..." "beacon" "txStakeHash1, txStakeHash2,..." "txStakeRewardReceiver1, txStakeRewardReceiver2,..." "flag1,flag2,..."]
 //	Stop Auto Staking instruction format:
 //	- ["stopautostaking" "pubkey1,pubkey2,..."]
+//	Equivocation Slash instruction format:
+//	- ["equivocationslash" "pubkey1,pubkey2,..."]
 //	Return Params:
 //	1. ShardState
 //	2. Stake Instruction
@@ -314,13 +323,15 @@ func (blockchain *BlockChain) GetShardState(beaconBestState *BeaconBestState, re
 //	4. Bridge Instruction
 //	5. Accepted BlockReward Instruction
 //	6. StopAutoStakingInstruction
-func (blockchain *BlockChain) GetShardStateFromBlock(curView *BeaconBestState, newBeaconHeight uint64, shardBlock *ShardBlock, shardID byte, isProducer bool, validStakePublicKeys []string) (map[byte]ShardState, [][]string, []string, map[byte][][]string, [][]string, []string, [][]string, [][]string) {
+//	7. EquivocationSlashInstruction
+func (blockchain *BlockChain) GetShardStateFromBlock(curView *BeaconBestState, newBeaconHeight uint64, shardBlock *ShardBlock, shardID byte, isProducer bool, validStakePublicKeys []string, slashedPublicKeys []string) (map[byte]ShardState, [][]string, []string, map[byte][][]string, [][]string, []string, [][]string, [][]string, [][]string) {
 	//Variable Declaration
 	shardStates := make(map[byte]ShardState)
 	stakeInstructions := [][]string{}
 	swapInstructions := make(map[byte][][]string)
 	stopAutoStakingInstructions := [][]string{}
 	stopAutoStakingInstructionsFromBlock := [][]string{}
+	equivocationEvidenceActions := [][]string{}
 	stakeInstructionFromShardBlock := [][]string{}
 	swapInstructionFromShardBlock := [][]string{}
 	bridgeInstructions := [][]string{}
@@ -375,6 +386,9 @@ func (blockchain *BlockChain) GetShardStateFromBlock(curView *BeaconBestState, n
 				}
 				stopAutoStakingInstructionsFromBlock = append(stopAutoStakingInstructionsFromBlock, instruction)
 			}
+			if isEquivocationEvidenceAction(instruction) {
+				equivocationEvidenceActions = append(equivocationEvidenceActions, instruction)
+			}
 		}
 	}
 	if len(stakeInstructionFromShardBlock) != 0 {
@@ -458,6 +472,10 @@ func (blockchain *BlockChain) GetShardStateFromBlock(curView *BeaconBestState, n
 	if len(stopAutoStakingPublicKeys) > 0 {
 		stopAutoStakingInstructions = append(stopAutoStakingInstructions, []string{StopAutoStake, strings.Join(stopAutoStakingPublicKeys, ",")})
 	}
+	equivocationSlashInstructions := [][]string{}
+	if blockchain.IsAfterEquivocationSlashCheckPoint(newBeaconHeight) {
+		equivocationSlashInstructions = curView.buildEquivocationSlashInstructions(equivocationEvidenceActions, slashedPublicKeys)
+	}
 	// Create bridge instruction
 	if len(instructions) > 0 || shardBlock.Header.Height%10 == 0 {
 		BLogger.log.Debugf("Included shardID %d, block %d, insts: %s", shardID, shardBlock.Header.Height, instructions)
@@ -482,7 +500,7 @@ func (blockchain *BlockChain) GetShardStateFromBlock(curView *BeaconBestState, n
 	// Collect stateful actions
 	statefulActions := blockchain.collectStatefulActions(instructions)
 	//Logger.log.Infof("Becon Produce: Got Shard Block %+v Shard %+v \n", shardBlock.Header.Height, shardID)
-	return shardStates, stakeInstructions, tempValidStakePublicKeys, swapInstructions, bridgeInstructions, acceptedRewardInstructions, stopAutoStakingInstructions, statefulActions
+	return shardStates, stakeInstructions, tempValidStakePublicKeys, swapInstructions, bridgeInstructions, acceptedRewardInstructions, stopAutoStakingInstructions, equivocationSlashInstructions, statefulActions
 }
 
 //  GenerateInstruction generate instruction for new beacon block
@@ -502,6 +520,7 @@ func (beaconBestState *BeaconBestState) GenerateInstruction(
 	stakeInstructions [][]string,
 	swapInstructions map[byte][][]string,
 	stopAutoStakingInstructions [][]string,
+	equivocationSlashInstructions [][]string,
 	shardCandidates []incognitokey.CommitteePublicKey,
 	bridgeInstructions [][]string,
 	acceptedRewardInstructions [][]string,
@@ -542,7 +561,7 @@ func (beaconBestState *BeaconBestState) GenerateInstruction(
 		//if err != nil {
 		//	Logger.log.Error(err)
 		//}
-		producersBlackList := make(map[string]uint8)
+		producersBlackList := getEquivocationOffenders(statedb.GetProducersBlackList(beaconBestState.slashStateDB, beaconBestState.BeaconHeight))
 		badProducersWithPunishment := blockchain.buildBadProducersWithPunishment(true, -1, beaconCommitteeStr)
 		badProducersWithPunishmentBytes, err := json.Marshal(badProducersWithPunishment)
 		if err != nil {
@@ -573,6 +592,8 @@ func (beaconBestState *BeaconBestState) GenerateInstruction(
 	instructions = append(instructions, stakeInstructions...)
 	// Stop Auto Staking
 	instructions = append(instructions, stopAutoStakingInstructions...)
+	// Equivocation Slash
+	instructions = append(instructions, equivocationSlashInstructions...)
 	// Random number for Assign Instruction
 	if newBeaconHeight%chainParamEpoch > randomTime && !beaconBestState.IsGetRandomNumber {
 		var err error
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"math"
 	"math/big"
 	"sort"
 	"strconv"
@@ -41,13 +42,21 @@ func (blockchain *BlockChain) collectStatefulActions(
 			metadata.PDEFeeWithdrawalRequestMeta,
 			metadata.PDEPRVRequiredContributionRequestMeta,
 			metadata.PDECrossPoolTradeRequestMeta,
+			metadata.PDEContributionV3Meta,
+			metadata.PDETradeRequestV3Meta,
+			metadata.PDEWithdrawalRequestV3Meta,
+			metadata.PDELimitOrderRequestMeta,
+			metadata.PDECancelLimitOrderRequestMeta,
 			metadata.PortalCustodianDepositMeta,
 			metadata.PortalUserRegisterMeta,
 			metadata.PortalUserRequestPTokenMeta,
 			metadata.PortalExchangeRatesMeta,
+			metadata.PortalUpdateFeedersMeta,
 			metadata.RelayingBNBHeaderMeta,
 			metadata.RelayingBTCHeaderMeta,
 			metadata.PortalCustodianWithdrawRequestMeta,
+			metadata.PortalTransferObligationMeta,
+			metadata.PortalRebalanceCollateralMeta,
 			metadata.PortalRedeemRequestMeta,
 			metadata.PortalRequestUnlockCollateralMeta,
 			metadata.PortalLiquidateCustodianMeta,
@@ -104,7 +113,7 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 
 	accumulatedValues := &metadata.AccumulatedValues{
 		UniqETHTxsUsed:   [][]byte{},
-		DBridgeTokenPair: map[string][]byte{},
+		DBridgeTokenPair: map[string]metadata.BridgeTokenPair{},
 		CBridgeTokens:    []*common.Hash{},
 	}
 	instructions := [][]string{}
@@ -116,14 +125,22 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 	pdeCrossPoolTradeActionsByShardID := map[byte][][]string{}
 	pdeWithdrawalActionsByShardID := map[byte][][]string{}
 	pdeFeeWithdrawalActionsByShardID := map[byte][][]string{}
+	pdeContributionV3ActionsByShardID := map[byte][][]string{}
+	pdeTradeV3ActionsByShardID := map[byte][][]string{}
+	pdeWithdrawalV3ActionsByShardID := map[byte][][]string{}
+	pdeLimitOrderActionsByShardID := map[byte][][]string{}
+	pdeCancelLimitOrderActionsByShardID := map[byte][][]string{}
 
 	// portal instructions
 	portalCustodianDepositActionsByShardID := map[byte][][]string{}
 	portalUserReqPortingActionsByShardID := map[byte][][]string{}
 	portalUserReqPTokenActionsByShardID := map[byte][][]string{}
 	portalExchangeRatesActionsByShardID := map[byte][][]string{}
+	portalUpdateFeedersActionsByShardID := map[byte][][]string{}
 	portalRedeemReqActionsByShardID := map[byte][][]string{}
 	portalCustodianWithdrawActionsByShardID := map[byte][][]string{}
+	portalTransferObligationActionsByShardID := map[byte][][]string{}
+	portalRebalanceCollateralActionsByShardID := map[byte][][]string{}
 	portalReqUnlockCollateralActionsByShardID := map[byte][][]string{}
 	portalReqWithdrawRewardActionsByShardID := map[byte][][]string{}
 	portalRedeemLiquidateExchangeRatesActionByShardID := map[byte][][]string{}
@@ -151,7 +168,7 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 				newInst, err = blockchain.buildInstructionsForIssuingReq(stateDB, contentStr, shardID, metaType, accumulatedValues)
 
 			case metadata.IssuingETHRequestMeta:
-				newInst, err = blockchain.buildInstructionsForIssuingETHReq(stateDB, contentStr, shardID, metaType, accumulatedValues)
+				newInst, err = blockchain.buildInstructionsForIssuingETHReq(stateDB, contentStr, shardID, metaType, accumulatedValues, beaconHeight)
 
 			case metadata.PDEContributionMeta:
 				pdeContributionActionsByShardID = groupPDEActionsByShardID(
@@ -189,6 +206,36 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 					action,
 					shardID,
 				)
+			case metadata.PDEContributionV3Meta:
+				pdeContributionV3ActionsByShardID = groupPDEActionsByShardID(
+					pdeContributionV3ActionsByShardID,
+					action,
+					shardID,
+				)
+			case metadata.PDETradeRequestV3Meta:
+				pdeTradeV3ActionsByShardID = groupPDEActionsByShardID(
+					pdeTradeV3ActionsByShardID,
+					action,
+					shardID,
+				)
+			case metadata.PDEWithdrawalRequestV3Meta:
+				pdeWithdrawalV3ActionsByShardID = groupPDEActionsByShardID(
+					pdeWithdrawalV3ActionsByShardID,
+					action,
+					shardID,
+				)
+			case metadata.PDELimitOrderRequestMeta:
+				pdeLimitOrderActionsByShardID = groupPDEActionsByShardID(
+					pdeLimitOrderActionsByShardID,
+					action,
+					shardID,
+				)
+			case metadata.PDECancelLimitOrderRequestMeta:
+				pdeCancelLimitOrderActionsByShardID = groupPDEActionsByShardID(
+					pdeCancelLimitOrderActionsByShardID,
+					action,
+					shardID,
+				)
 			case metadata.PortalCustodianDepositMeta:
 				{
 					portalCustodianDepositActionsByShardID = groupPortalActionsByShardID(
@@ -216,12 +263,30 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 					action,
 					shardID,
 				)
+			case metadata.PortalUpdateFeedersMeta:
+				portalUpdateFeedersActionsByShardID = groupPortalActionsByShardID(
+					portalUpdateFeedersActionsByShardID,
+					action,
+					shardID,
+				)
 			case metadata.PortalCustodianWithdrawRequestMeta:
 				portalCustodianWithdrawActionsByShardID = groupPortalActionsByShardID(
 					portalCustodianWithdrawActionsByShardID,
 					action,
 					shardID,
 				)
+			case metadata.PortalTransferObligationMeta:
+				portalTransferObligationActionsByShardID = groupPortalActionsByShardID(
+					portalTransferObligationActionsByShardID,
+					action,
+					shardID,
+				)
+			case metadata.PortalRebalanceCollateralMeta:
+				portalRebalanceCollateralActionsByShardID = groupPortalActionsByShardID(
+					portalRebalanceCollateralActionsByShardID,
+					action,
+					shardID,
+				)
 			case metadata.PortalRedeemRequestMeta:
 				portalRedeemReqActionsByShardID = groupPortalActionsByShardID(
 					portalRedeemReqActionsByShardID,
@@ -300,6 +365,33 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 		instructions = append(instructions, pdeInsts...)
 	}
 
+	pdeV3Insts, err := blockchain.handlePDEV3Insts(
+		beaconHeight-1, currentPDEState,
+		pdeContributionV3ActionsByShardID,
+		pdeTradeV3ActionsByShardID,
+		pdeWithdrawalV3ActionsByShardID,
+	)
+	if err != nil {
+		Logger.log.Error(err)
+		return instructions
+	}
+	if len(pdeV3Insts) > 0 {
+		instructions = append(instructions, pdeV3Insts...)
+	}
+
+	pdeLimitOrderInsts, err := blockchain.handlePDELimitOrderInsts(
+		beaconHeight-1, currentPDEState,
+		pdeLimitOrderActionsByShardID,
+		pdeCancelLimitOrderActionsByShardID,
+	)
+	if err != nil {
+		Logger.log.Error(err)
+		return instructions
+	}
+	if len(pdeLimitOrderInsts) > 0 {
+		instructions = append(instructions, pdeLimitOrderInsts...)
+	}
+
 	// handle portal instructions
 	portalInsts, err := blockchain.handlePortalInsts(
 		stateDB,
@@ -309,8 +401,11 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 		portalUserReqPortingActionsByShardID,
 		portalUserReqPTokenActionsByShardID,
 		portalExchangeRatesActionsByShardID,
+		portalUpdateFeedersActionsByShardID,
 		portalRedeemReqActionsByShardID,
 		portalCustodianWithdrawActionsByShardID,
+		portalTransferObligationActionsByShardID,
+		portalRebalanceCollateralActionsByShardID,
 		portalReqUnlockCollateralActionsByShardID,
 		portalRedeemLiquidateExchangeRatesActionByShardID,
 		portalLiquidationCustodianDepositActionByShardID,
@@ -359,6 +454,87 @@ func isPoolPairExisting(
 	return true
 }
 
+// findPDETradePaths lists the token paths a trade can be routed through: the
+// direct pool of the pair first, then two hops via PRV and via every other
+// token paired with both sides, in token id order
+func findPDETradePaths(
+	beaconHeight uint64,
+	currentPDEState *CurrentPDEState,
+	tokenIDToSellStr string,
+	tokenIDToBuyStr string,
+) [][]string {
+	prvIDStr := common.PRVCoinID.String()
+	paths := [][]string{}
+	if isPoolPairExisting(beaconHeight, currentPDEState, tokenIDToSellStr, tokenIDToBuyStr) {
+		paths = append(paths, []string{tokenIDToSellStr, tokenIDToBuyStr})
+	}
+	intermediateTokenIDs := map[string]bool{}
+	for _, poolPair := range currentPDEState.PDEPoolPairs {
+		if poolPair == nil {
+			continue
+		}
+		if poolPair.Token1IDStr == tokenIDToSellStr {
+			intermediateTokenIDs[poolPair.Token2IDStr] = true
+		} else if poolPair.Token2IDStr == tokenIDToSellStr {
+			intermediateTokenIDs[poolPair.Token1IDStr] = true
+		}
+	}
+	delete(intermediateTokenIDs, tokenIDToBuyStr)
+	sortedTokenIDs := []string{}
+	for tokenID := range intermediateTokenIDs {
+		if tokenID != prvIDStr {
+			sortedTokenIDs = append(sortedTokenIDs, tokenID)
+		}
+	}
+	sort.Strings(sortedTokenIDs)
+	if intermediateTokenIDs[prvIDStr] {
+		sortedTokenIDs = append([]string{prvIDStr}, sortedTokenIDs...)
+	}
+	for _, tokenID := range sortedTokenIDs {
+		if isPoolPairExisting(beaconHeight, currentPDEState, tokenIDToSellStr, tokenID) &&
+			isPoolPairExisting(beaconHeight, currentPDEState, tokenID, tokenIDToBuyStr) {
+			paths = append(paths, []string{tokenIDToSellStr, tokenID, tokenIDToBuyStr})
+		}
+	}
+	return paths
+}
+
+// findBestPDETradePath returns the path paying the most for sellAmount, the
+// earlier path wins a tie. It returns nil if no path pays anything.
+func findBestPDETradePath(
+	beaconHeight uint64,
+	currentPDEState *CurrentPDEState,
+	tokenIDToSellStr string,
+	tokenIDToBuyStr string,
+	sellAmount uint64,
+) []string {
+	bestPath, _ := findBestPDETradePathWithReceiveAmount(beaconHeight, currentPDEState, tokenIDToSellStr, tokenIDToBuyStr, sellAmount)
+	return bestPath
+}
+
+func findBestPDETradePathWithReceiveAmount(
+	beaconHeight uint64,
+	currentPDEState *CurrentPDEState,
+	tokenIDToSellStr string,
+	tokenIDToBuyStr string,
+	sellAmount uint64,
+) ([]string, uint64) {
+	var bestPath []string
+	bestReceiveAmt := uint64(0)
+	for _, path := range findPDETradePaths(beaconHeight, currentPDEState, tokenIDToSellStr, tokenIDToBuyStr) {
+		amt := sellAmount
+		for i := 0; i < len(path)-1 && amt > 0; i++ {
+			poolPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, path[i], path[i+1]))
+			amt, _, _ = calcTradeValue(currentPDEState.PDEPoolPairs[poolPairKey], path[i], amt)
+		}
+		if amt > bestReceiveAmt {
+			bestPath = path
+			bestReceiveAmt = amt
+		}
+	}
+	return bestPath, bestReceiveAmt
+}
+
 func calcTradeValue(
 	pdePoolPair *rawdbv2.PDEPoolForPair,
 	tokenIDStrToSell string,
@@ -390,6 +566,7 @@ func prepareInfoForSorting(
 	currentPDEState *CurrentPDEState,
 	beaconHeight uint64,
 	tradeAction metadata.PDECrossPoolTradeRequestAction,
+	isTokenPoolsEnabled bool,
 ) (uint64, uint64) {
 	prvIDStr := common.PRVCoinID.String()
 	tradeMeta := tradeAction.Meta
@@ -398,6 +575,19 @@ func prepareInfoForSorting(
 	if tradeMeta.TokenIDToSellStr == prvIDStr {
 		return tradingFee, sellAmount
 	}
+	// a token traded on direct pools only might not be paired with PRV, its sell
+	// amount is valued in PRV through the best path, it is sorted after the
+	// trades valued in PRV when there is no path
+	if isTokenPoolsEnabled {
+		_, sellAmount = findBestPDETradePathWithReceiveAmount(beaconHeight, currentPDEState, tradeMeta.TokenIDToSellStr, prvIDStr, sellAmount)
+		if sellAmount == 0 {
+			return tradingFee, math.MaxUint64
+		}
+		return tradingFee, sellAmount
+	}
+	if !isPoolPairExisting(beaconHeight, currentPDEState, prvIDStr, tradeMeta.TokenIDToSellStr) {
+		return tradingFee, sellAmount
+	}
 	poolPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, prvIDStr, tradeMeta.TokenIDToSellStr))
 	poolPair, _ := currentPDEState.PDEPoolPairs[poolPairKey]
 	sellAmount, _, _ = calcTradeValue(poolPair, tradeMeta.TokenIDToSellStr, sellAmount)
@@ -408,6 +598,7 @@ func categorizeNSortPDECrossPoolTradeInstsByFee(
 	beaconHeight uint64,
 	currentPDEState *CurrentPDEState,
 	pdeCrossPoolTradeActionsByShardID map[byte][][]string,
+	isTokenPoolsEnabled bool,
 ) ([]metadata.PDECrossPoolTradeRequestAction, []metadata.PDECrossPoolTradeRequestAction) {
 	prvIDStr := common.PRVCoinID.String()
 	tradableActions := []metadata.PDECrossPoolTradeRequestAction{}
@@ -434,6 +625,14 @@ func categorizeNSortPDECrossPoolTradeInstsByFee(
 				continue
 			}
 			tradeMeta := crossPoolTradeRequestAction.Meta
+			if isTokenPoolsEnabled {
+				if len(findPDETradePaths(beaconHeight, currentPDEState, tradeMeta.TokenIDToSellStr, tradeMeta.TokenIDToBuyStr)) == 0 {
+					untradableActions = append(untradableActions, crossPoolTradeRequestAction)
+					continue
+				}
+				tradableActions = append(tradableActions, crossPoolTradeRequestAction)
+				continue
+			}
 			if (isTradingFairContainsPRV(tradeMeta.TokenIDToSellStr, tradeMeta.TokenIDToBuyStr) && !isPoolPairExisting(beaconHeight, currentPDEState, tradeMeta.TokenIDToSellStr, tradeMeta.TokenIDToBuyStr)) ||
 			(!isTradingFairContainsPRV(tradeMeta.TokenIDToSellStr, tradeMeta.TokenIDToBuyStr) && (!isPoolPairExisting(beaconHeight, currentPDEState, prvIDStr, tradeMeta.TokenIDToSellStr) || !isPoolPairExisting(beaconHeight, currentPDEState, prvIDStr, tradeMeta.TokenIDToBuyStr))) {
 				untradableActions = append(untradableActions, crossPoolTradeRequestAction)
@@ -449,11 +648,13 @@ func categorizeNSortPDECrossPoolTradeInstsByFee(
 			currentPDEState,
 			beaconHeight,
 			tradableActions[i],
+			isTokenPoolsEnabled,
 		)
 		secondTradingFee, secondSellAmount := prepareInfoForSorting(
 			currentPDEState,
 			beaconHeight,
 			tradableActions[j],
+			isTokenPoolsEnabled,
 		)
 		// comparing a/b to c/d is equivalent with comparing a*d to c*b
 		firstItemProportion := big.NewInt(0)
@@ -604,10 +805,12 @@ func (blockchain *BlockChain) handlePDEInsts(
 	}
 
 	// handle cross pool trade
+	isTokenPoolsEnabled := blockchain.IsAfterPDETokenPoolsCheckPoint(beaconHeight)
 	sortedTradableActions, untradableActions := categorizeNSortPDECrossPoolTradeInstsByFee(
 		beaconHeight,
 		currentPDEState,
 		pdeCrossPoolTradeActionsByShardID,
+		isTokenPoolsEnabled,
 	)
 	tradableInsts, tradingFeeByPair := blockchain.buildInstsForSortedTradableActions(currentPDEState, beaconHeight, sortedTradableActions)
 	untradableInsts := blockchain.buildInstsForUntradableActions(untradableActions)
@@ -675,7 +878,7 @@ func (blockchain *BlockChain) handlePDEInsts(
 		actions := pdePRVRequiredContributionActionsByShardID[shardID]
 		for _, action := range actions {
 			contentStr := action[1]
-			newInst, err := blockchain.buildInstructionsForPDEContribution(contentStr, shardID, metadata.PDEPRVRequiredContributionRequestMeta, currentPDEState, beaconHeight, true)
+			newInst, err := blockchain.buildInstructionsForPDEContribution(contentStr, shardID, metadata.PDEPRVRequiredContributionRequestMeta, currentPDEState, beaconHeight, !isTokenPoolsEnabled)
 			if err != nil {
 				Logger.log.Error(err)
 				continue
@@ -711,8 +914,11 @@ func (blockchain *BlockChain) handlePortalInsts(
 	portalUserRequestPortingActionsByShardID map[byte][][]string,
 	portalUserRequestPTokenActionsByShardID map[byte][][]string,
 	portalExchangeRatesActionsByShardID map[byte][][]string,
+	portalUpdateFeedersActionsByShardID map[byte][][]string,
 	portalRedeemReqActionsByShardID map[byte][][]string,
 	portalCustodianWithdrawActionByShardID map[byte][][]string,
+	portalTransferObligationActionsByShardID map[byte][][]string,
+	portalRebalanceCollateralActionsByShardID map[byte][][]string,
 	portalReqUnlockCollateralActionsByShardID map[byte][][]string,
 	portalRedeemLiquidateExchangeRatesActionByShardID map[byte][][]string,
 	portalLiquidationCustodianDepositActionByShardID map[byte][][]string,
@@ -897,6 +1103,37 @@ func (blockchain *BlockChain) handlePortalInsts(
 		}
 	}
 
+	//handle portal update feeders, after the exchange rates which are checked against the previous feeders
+	var updateFeedersShardIDKeys []int
+	for k := range portalUpdateFeedersActionsByShardID {
+		updateFeedersShardIDKeys = append(updateFeedersShardIDKeys, int(k))
+	}
+
+	sort.Ints(updateFeedersShardIDKeys)
+	for _, value := range updateFeedersShardIDKeys {
+		shardID := byte(value)
+		actions := portalUpdateFeedersActionsByShardID[shardID]
+		for _, action := range actions {
+			contentStr := action[1]
+			newInst, err := blockchain.buildInstructionsForUpdateFeeders(
+				contentStr,
+				shardID,
+				metadata.PortalUpdateFeedersMeta,
+				currentPortalState,
+				beaconHeight,
+				portalParams,
+			)
+
+			if err != nil {
+				Logger.log.Error(err)
+				continue
+			}
+			if len(newInst) > 0 {
+				instructions = append(instructions, newInst...)
+			}
+		}
+	}
+
 	//handle portal custodian withdraw
 	var portalCustodianWithdrawShardIDKeys []int
 	for k := range portalCustodianWithdrawActionByShardID {
@@ -928,6 +1165,69 @@ func (blockchain *BlockChain) handlePortalInsts(
 		}
 	}
 
+	// handle portal custodian transfer obligation
+	var portalTransferObligationShardIDKeys []int
+	for k := range portalTransferObligationActionsByShardID {
+		portalTransferObligationShardIDKeys = append(portalTransferObligationShardIDKeys, int(k))
+	}
+
+	sort.Ints(portalTransferObligationShardIDKeys)
+	for _, value := range portalTransferObligationShardIDKeys {
+		shardID := byte(value)
+		actions := portalTransferObligationActionsByShardID[shardID]
+		for _, action := range actions {
+			contentStr := action[1]
+			newInst, err := blockchain.buildInstructionsForTransferObligation(
+				stateDB,
+				contentStr,
+				shardID,
+				metadata.PortalTransferObligationMeta,
+				currentPortalState,
+				beaconHeight,
+				portalParams,
+			)
+
+			if err != nil {
+				Logger.log.Error(err)
+				continue
+			}
+			if len(newInst) > 0 {
+				instructions = append(instructions, newInst...)
+			}
+		}
+	}
+
+	// handle portal custodian rebalance collateral
+	var portalRebalanceCollateralShardIDKeys []int
+	for k := range portalRebalanceCollateralActionsByShardID {
+		portalRebalanceCollateralShardIDKeys = append(portalRebalanceCollateralShardIDKeys, int(k))
+	}
+
+	sort.Ints(portalRebalanceCollateralShardIDKeys)
+	for _, value := range portalRebalanceCollateralShardIDKeys {
+		shardID := byte(value)
+		actions := portalRebalanceCollateralActionsByShardID[shardID]
+		for _, action := range actions {
+			contentStr := action[1]
+			newInst, err := blockchain.buildInstructionsForRebalanceCollateral(
+				contentStr,
+				shardID,
+				metadata.PortalRebalanceCollateralMeta,
+				currentPortalState,
+				beaconHeight,
+				portalParams,
+			)
+
+			if err != nil {
+				Logger.log.Error(err)
+				continue
+			}
+			if len(newInst) > 0 {
+				instructions = append(instructions, newInst...)
+			}
+		}
+	}
+
 	// handle portal req unlock collateral inst
 	var reqUnlockCollateralShardIDKeys []int
 	for k := range portalReqUnlockCollateralActionsByShardID {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"sync/atomic"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/incognitochain/incognito-chain/blockchain/btc"
@@ -35,6 +36,8 @@ type BlockChain struct {
 	IsTest bool
 
 	beaconViewCache *lru.Cache
+
+	verifiedCheckpoint atomic.Value // common.Hash of the last beacon block verified to descend from the trusted checkpoint
 }
 
 // Config is a descriptor which specifies the blockchain instance configuration.
@@ -60,6 +63,12 @@ type Config struct {
 	Server            Server
 	ConsensusEngine   ConsensusEngine
 	Highway           Highway
+	StatePrune        bool
+	StatePruneKeep    uint64
+	FastSync          bool
+	TrustedCheckpoint *TrustedCheckpoint
+	ETHHeaderSource   metadata.ETHHeaderSource
+	EVMHeaderSources  map[uint64]metadata.ETHHeaderSource // EVM chains other than Ethereum, by chain id
 }
 
 func NewBlockChain(config *Config, isTest bool) *BlockChain {
@@ -96,6 +105,9 @@ func (blockchain *BlockChain) Init(config *Config) error {
 	if err := blockchain.InitChainState(); err != nil {
 		return err
 	}
+	if err := blockchain.VerifyTrustedCheckpoint(); err != nil {
+		return err
+	}
 	blockchain.cQuitSync = make(chan struct{})
 	return nil
 }
@@ -121,6 +133,9 @@ func (blockchain *BlockChain) InitChainState() error {
 		}
 	}
 	Logger.log.Infof("Init Beacon View height %+v", blockchain.BeaconChain.GetBestView().GetHeight())
+	if blockchain.config.PubSubManager != nil {
+		blockchain.BeaconChain.multiView.SetEventHandler(newViewEventPublisher(-1, blockchain.config.PubSubManager))
+	}
 
 	//beaconHash, err := statedb.GetBeaconBlockHashByIndex(blockchain.GetBeaconBestState().GetBeaconConsensusStateDB(), 1)
 	//panic(beaconHash.String())
@@ -142,9 +157,12 @@ func (blockchain *BlockChain) InitChainState() error {
 			}
 		}
 		Logger.log.Infof("Init Shard View shardID %+v, height %+v", shardID, blockchain.ShardChain[shardID].GetFinalViewHeight())
+		if blockchain.config.PubSubManager != nil {
+			blockchain.ShardChain[shardID].multiView.SetEventHandler(newViewEventPublisher(int(shardID), blockchain.config.PubSubManager))
+		}
 	}
 
-	return nil
+	return blockchain.initStatePruners()
 }
 
 /*
@@ -715,4 +733,50 @@ func (blockchain *BlockChain) IsAfterNewZKPCheckPoint(beaconHeight uint64) bool
 	}
 
 	return beaconHeight >= blockchain.GetConfig().ChainParams.BCHeightBreakPointNewZKP
+}
+
+// IsAfterPDETokenPoolsCheckPoint returns true if pde pools between two ptokens
+// are accepted at the beacon height
+func (blockchain *BlockChain) IsAfterPDETokenPoolsCheckPoint(beaconHeight uint64) bool {
+	chainParams := blockchain.GetConfig().ChainParams
+	if chainParams == nil {
+		return false
+	}
+	return beaconHeight >= chainParams.BCHeightBreakPointPDETokenPools
+}
+
+// IsAfterPDEV3CheckPoint returns true if pde v3 pools are accepted at the beacon height
+func (blockchain *BlockChain) IsAfterPDEV3CheckPoint(beaconHeight uint64) bool {
+	chainParams := blockchain.GetConfig().ChainParams
+	if chainParams == nil {
+		return false
+	}
+	return beaconHeight >= chainParams.BCHeightBreakPointPDEV3
+}
+
+// IsAfterPDELimitOrderCheckPoint returns true if pde limit orders are accepted at the beacon height
+func (blockchain *BlockChain) IsAfterPDELimitOrderCheckPoint(beaconHeight uint64) bool {
+	chainParams := blockchain.GetConfig().ChainParams
+	if chainParams == nil {
+		return false
+	}
+	return beaconHeight >= chainParams.BCHeightBreakPointPDELimitOrder
+}
+
+// IsAfterEquivocationSlashCheckPoint returns true if equivocation evidences are accepted at the beacon height
+func (blockchain *BlockChain) IsAfterEquivocationSlashCheckPoint(beaconHeight uint64) bool {
+	chainParams := blockchain.GetConfig().ChainParams
+	if chainParams == nil {
+		return false
+	}
+	return beaconHeight >= chainParams.BCHeightBreakPointEquivocationSlash
+}
+
+// IsAfterStateRootsHashCheckPoint returns true if the block headers commit to the state roots of their previous block at the beacon height
+func (blockchain *BlockChain) IsAfterStateRootsHashCheckPoint(beaconHeight uint64) bool {
+	chainParams := blockchain.GetConfig().ChainParams
+	if chainParams == nil {
+		return false
+	}
+	return beaconHeight >= chainParams.BCHeightBreakPointStateRootsHash
 }
\ No newline at end of file
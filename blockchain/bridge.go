@@ -110,8 +110,13 @@ func decodeBurningConfirmInst(inst []string) ([]byte, error) {
 	amount, _, errAmount := base58.Base58Check{}.Decode(inst[4])
 	txID, errTx := common.Hash{}.NewHashFromStr(inst[5])
 	incTokenID, _, errIncToken := base58.Base58Check{}.Decode(inst[6])
-	height, _, errHeight := base58.Base58Check{}.Decode(inst[7])
-	if err := common.CheckError(errMeta, errShard, errToken, errAddr, errAmount, errTx, errIncToken, errHeight); err != nil {
+	// the instructions to the EVM chains other than Ethereum have the chain id before the height
+	evmChainID, errEVMChainID := []byte{}, error(nil)
+	if len(inst) > 8 {
+		evmChainID, _, errEVMChainID = base58.Base58Check{}.Decode(inst[7])
+	}
+	height, _, errHeight := base58.Base58Check{}.Decode(inst[len(inst)-1])
+	if err := common.CheckError(errMeta, errShard, errToken, errAddr, errAmount, errTx, errIncToken, errEVMChainID, errHeight); err != nil {
 		err = errors.Wrapf(err, "inst: %+v", inst)
 		BLogger.log.Error(err)
 		return nil, err
@@ -126,6 +131,9 @@ func decodeBurningConfirmInst(inst []string) ([]byte, error) {
 	flatten = append(flatten, toBytes32BigEndian(amount)...)
 	flatten = append(flatten, txID[:]...)
 	flatten = append(flatten, incTokenID...)
+	if len(inst) > 8 {
+		flatten = append(flatten, toBytes32BigEndian(evmChainID)...)
+	}
 	flatten = append(flatten, toBytes32BigEndian(height)...)
 	return flatten, nil
 }
@@ -91,7 +91,7 @@ func (blockchain *BlockChain) buildInstructionsForIssuingReq(
 	return append(instructions, returnedInst), nil
 }
 
-func (blockchain *BlockChain) buildInstructionsForIssuingETHReq(stateDB *statedb.StateDB, contentStr string, shardID byte, metaType int, ac *metadata.AccumulatedValues) ([][]string, error) {
+func (blockchain *BlockChain) buildInstructionsForIssuingETHReq(stateDB *statedb.StateDB, contentStr string, shardID byte, metaType int, ac *metadata.AccumulatedValues, beaconHeight uint64) ([][]string, error) {
 	Logger.log.Info("[Decentralized bridge token issuance] Starting...")
 	instructions := [][]string{}
 	issuingETHReqAction, err := metadata.ParseETHIssuingInstContent(contentStr)
@@ -110,7 +110,7 @@ func (blockchain *BlockChain) buildInstructionsForIssuingETHReq(stateDB *statedb
 
 	// NOTE: since TxHash from constructedReceipt is always '0x0000000000000000000000000000000000000000000000000000000000000000'
 	// so must build unique eth tx as combination of block hash and tx index.
-	uniqETHTx := append(md.BlockHash[:], []byte(strconv.Itoa(int(md.TxIndex)))...)
+	uniqETHTx := metadata.GetUniqEVMTx(md.EVMChainID, md.BlockHash, md.TxIndex)
 	isUsedInBlock := metadata.IsETHTxHashUsedInBlock(uniqETHTx, ac.UniqETHTxsUsed)
 	if isUsedInBlock {
 		Logger.log.Info("WARNING: already issued for the hash in current block: ", uniqETHTx)
@@ -126,7 +126,12 @@ func (blockchain *BlockChain) buildInstructionsForIssuingETHReq(stateDB *statedb
 		return append(instructions, rejectedInst), nil
 	}
 
-	logMap, err := metadata.PickAndParseLogMapFromReceipt(ethReceipt, blockchain.config.ChainParams.EthContractAddressStr)
+	contractAddressStr, err := blockchain.GetEVMContractAddressStr(md.EVMChainID, beaconHeight)
+	if err != nil {
+		Logger.log.Info("WARNING: an error occured while getting the bridge contract of the chain: ", err)
+		return append(instructions, rejectedInst), nil
+	}
+	logMap, err := metadata.PickAndParseLogMapFromReceipt(ethReceipt, contractAddressStr)
 	if err != nil {
 		Logger.log.Info("WARNING: an error occured while parsing log map from receipt: ", err)
 		return append(instructions, rejectedInst), nil
@@ -146,7 +151,8 @@ func (blockchain *BlockChain) buildInstructionsForIssuingETHReq(stateDB *statedb
 		return append(instructions, rejectedInst), nil
 	}
 	ethereumToken := ethereumAddr.Bytes()
-	canProcess, err := ac.CanProcessTokenPair(ethereumToken, md.IncTokenID)
+	network := metadata.GetEVMNetwork(md.EVMChainID)
+	canProcess, err := ac.CanProcessTokenPair(network, ethereumToken, md.IncTokenID)
 	if err != nil {
 		Logger.log.Info("WARNING: an error occured while checking it can process for token pair on the current block or not: ", err)
 		return append(instructions, rejectedInst), nil
@@ -156,7 +162,7 @@ func (blockchain *BlockChain) buildInstructionsForIssuingETHReq(stateDB *statedb
 		return append(instructions, rejectedInst), nil
 	}
 
-	isValid, err := statedb.CanProcessTokenPair(stateDB, ethereumToken, md.IncTokenID)
+	isValid, err := statedb.CanProcessTokenPair(stateDB, network, ethereumToken, md.IncTokenID)
 	if err != nil {
 		Logger.log.Info("WARNING: an error occured while checking it can process for token pair on the previous blocks or not: ", err)
 		return append(instructions, rejectedInst), nil
@@ -192,6 +198,7 @@ func (blockchain *BlockChain) buildInstructionsForIssuingETHReq(stateDB *statedb
 		TxReqID:         issuingETHReqAction.TxReqID,
 		UniqETHTx:       uniqETHTx,
 		ExternalTokenID: ethereumToken,
+		EVMChainID:      md.EVMChainID,
 	}
 	issuingETHAcceptedInstBytes, err := json.Marshal(issuingETHAcceptedInst)
 	if err != nil {
@@ -199,7 +206,7 @@ func (blockchain *BlockChain) buildInstructionsForIssuingETHReq(stateDB *statedb
 		return append(instructions, rejectedInst), nil
 	}
 	ac.UniqETHTxsUsed = append(ac.UniqETHTxsUsed, uniqETHTx)
-	ac.DBridgeTokenPair[md.IncTokenID.String()] = ethereumToken
+	ac.DBridgeTokenPair[md.IncTokenID.String()] = metadata.BridgeTokenPair{Network: network, ExternalTokenID: ethereumToken}
 
 	acceptedInst := buildInstruction(metaType, shardID, "accepted", base64.StdEncoding.EncodeToString(issuingETHAcceptedInstBytes))
 	return append(instructions, acceptedInst), nil
@@ -0,0 +1,270 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// TrustedCheckpoint is a finalized beacon block signed by a trusted signer.
+// A node configured with a checkpoint syncs the beacon state from it and
+// rejects any beacon chain with another block at its height, instead of
+// trusting the chain it receives from genesis.
+type TrustedCheckpoint struct {
+	Height    uint64
+	Hash      common.Hash
+	Committee []string // beacon committee signing the block after Height
+	Signer    string   // payment address of the signer
+	Signature string   // base58 schnorr signature of the signer
+}
+
+// NewTrustedCheckpoint returns the unsigned checkpoint of a beacon view.
+func NewTrustedCheckpoint(view *BeaconBestState) (*TrustedCheckpoint, error) {
+	committee, err := incognitokey.CommitteeKeyListToString(view.GetBeaconCommittee())
+	if err != nil {
+		return nil, NewBlockChainError(TrustedCheckpointError, err)
+	}
+	return &TrustedCheckpoint{
+		Height:    view.BeaconHeight,
+		Hash:      view.BestBlockHash,
+		Committee: committee,
+	}, nil
+}
+
+// LoadTrustedCheckpoint parses value as the json of a checkpoint, or reads it
+// from the file at path value.
+func LoadTrustedCheckpoint(value string) (*TrustedCheckpoint, error) {
+	data := []byte(strings.TrimSpace(value))
+	if !bytes.HasPrefix(data, []byte("{")) {
+		var err error
+		if data, err = ioutil.ReadFile(value); err != nil {
+			return nil, NewBlockChainError(TrustedCheckpointError, err)
+		}
+	}
+	cp := &TrustedCheckpoint{}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, NewBlockChainError(TrustedCheckpointError, err)
+	}
+	return cp, nil
+}
+
+func (cp *TrustedCheckpoint) signingHash() common.Hash {
+	data := append(common.Uint64ToBytes(cp.Height), cp.Hash[:]...)
+	data = append(data, []byte(strings.Join(cp.Committee, ","))...)
+	return common.HashH(data)
+}
+
+// Sign signs cp with the base58 private key of a wallet account.
+func (cp *TrustedCheckpoint) Sign(privateKey string) error {
+	keyWallet, err := wallet.Base58CheckDeserialize(privateKey)
+	if err != nil {
+		return NewBlockChainError(TrustedCheckpointError, err)
+	}
+	if err := keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey); err != nil {
+		return NewBlockChainError(TrustedCheckpointError, err)
+	}
+	// without randomness the schnorr public key is the public key of the payment address
+	sigKey := new(privacy.SchnorrPrivateKey)
+	sigKey.Set(new(privacy.Scalar).FromBytesS(keyWallet.KeySet.PrivateKey), new(privacy.Scalar).FromUint64(0))
+	hash := cp.signingHash()
+	sig, err := sigKey.Sign(hash[:])
+	if err != nil {
+		return NewBlockChainError(TrustedCheckpointError, err)
+	}
+	cp.Signer = keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
+	cp.Signature = base58.Base58Check{}.Encode(sig.Bytes(), common.ZeroByte)
+	return nil
+}
+
+// Verify checks that cp is signed by one of the payment addresses of
+// trustedSigners.
+func (cp *TrustedCheckpoint) Verify(trustedSigners []string) error {
+	signer, err := wallet.Base58CheckDeserialize(cp.Signer)
+	if err != nil {
+		return NewBlockChainError(TrustedCheckpointError, err)
+	}
+	isTrusted := false
+	for _, trustedSigner := range trustedSigners {
+		keyWallet, err := wallet.Base58CheckDeserialize(strings.TrimSpace(trustedSigner))
+		if err != nil {
+			return NewBlockChainError(TrustedCheckpointError, fmt.Errorf("invalid checkpoint signer %+v: %+v", trustedSigner, err))
+		}
+		if bytes.Equal(keyWallet.KeySet.PaymentAddress.Pk, signer.KeySet.PaymentAddress.Pk) {
+			isTrusted = true
+			break
+		}
+	}
+	if !isTrusted {
+		return NewBlockChainError(TrustedCheckpointError, fmt.Errorf("checkpoint signer %+v is not trusted", cp.Signer))
+	}
+	pk, err := new(privacy.Point).FromBytesS(signer.KeySet.PaymentAddress.Pk)
+	if err != nil {
+		return NewBlockChainError(TrustedCheckpointError, err)
+	}
+	verifyKey := new(privacy.SchnorrPublicKey)
+	verifyKey.Set(pk)
+	sigBytes, _, err := base58.Base58Check{}.Decode(cp.Signature)
+	if err != nil {
+		return NewBlockChainError(TrustedCheckpointError, err)
+	}
+	sig := new(privacy.SchnSignature)
+	if err := sig.SetBytes(sigBytes); err != nil {
+		return NewBlockChainError(TrustedCheckpointError, err)
+	}
+	hash := cp.signingHash()
+	if !verifyKey.Verify(sig, hash[:]) {
+		return NewBlockChainError(TrustedCheckpointError, errors.New("invalid checkpoint signature"))
+	}
+	return nil
+}
+
+// GetCommittee returns the beacon committee signing the block after the
+// checkpoint.
+func (cp *TrustedCheckpoint) GetCommittee() ([]incognitokey.CommitteePublicKey, error) {
+	committee, err := incognitokey.CommitteeBase58KeyListToStruct(cp.Committee)
+	if err != nil {
+		return nil, NewBlockChainError(TrustedCheckpointError, err)
+	}
+	return committee, nil
+}
+
+// GetTrustedCheckpoint returns the checkpoint of the config, nil if the
+// beacon chain is trusted from genesis.
+func (blockchain *BlockChain) GetTrustedCheckpoint() *TrustedCheckpoint {
+	return blockchain.config.TrustedCheckpoint
+}
+
+// VerifyTrustedCheckpoint checks that the finalized beacon chain in the
+// database does not conflict with the trusted checkpoint.
+func (blockchain *BlockChain) VerifyTrustedCheckpoint() error {
+	cp := blockchain.config.TrustedCheckpoint
+	if cp == nil || blockchain.BeaconChain.GetFinalViewHeight() < cp.Height {
+		return nil
+	}
+	hash, err := rawdbv2.GetFinalizedBeaconBlockHashByIndex(blockchain.GetBeaconChainDatabase(), cp.Height)
+	if err != nil {
+		return NewBlockChainError(TrustedCheckpointError, err)
+	}
+	if !hash.IsEqual(&cp.Hash) {
+		return NewBlockChainError(TrustedCheckpointError, fmt.Errorf("finalized beacon block %+v at height %+v conflicts with checkpoint block %+v", hash, cp.Height, cp.Hash))
+	}
+	return nil
+}
+
+// checkTrustedCheckpoint rejects a beacon block at the height of the trusted
+// checkpoint with another hash.
+func (blockchain *BlockChain) checkTrustedCheckpoint(height uint64, hash common.Hash) error {
+	cp := blockchain.config.TrustedCheckpoint
+	if cp == nil || height != cp.Height || hash.IsEqual(&cp.Hash) {
+		return nil
+	}
+	return NewBlockChainError(TrustedCheckpointError, fmt.Errorf("beacon block %+v at height %+v conflicts with checkpoint block %+v", hash, height, cp.Hash))
+}
+
+// BeaconCheckpointVerifier verifies the beacon blocks following the trusted
+// checkpoint chunk by chunk. Only the last verified block and the committee
+// signing the block after it are kept, the blocks of a chunk can be discarded
+// once they are verified.
+type BeaconCheckpointVerifier struct {
+	blockchain *BlockChain
+	last       *BeaconBlock
+	committee  []incognitokey.CommitteePublicKey
+}
+
+// NewBeaconCheckpointVerifier returns a verifier of the beacon blocks from the
+// trusted checkpoint.
+func (blockchain *BlockChain) NewBeaconCheckpointVerifier() (*BeaconCheckpointVerifier, error) {
+	cp := blockchain.config.TrustedCheckpoint
+	if cp == nil {
+		return nil, NewBlockChainError(TrustedCheckpointError, errors.New("no trusted checkpoint"))
+	}
+	committee, err := cp.GetCommittee()
+	if err != nil {
+		return nil, err
+	}
+	return &BeaconCheckpointVerifier{blockchain: blockchain, committee: committee}, nil
+}
+
+// GetLastBlock returns the last verified block, nil before the first chunk.
+func (verifier *BeaconCheckpointVerifier) GetLastBlock() *BeaconBlock {
+	return verifier.last
+}
+
+// Verify checks that blocks follow the last verified block, each one signed
+// by the committee resulting from the blocks before it. The first chunk must
+// start with the block of the trusted checkpoint. A chunk is verified
+// entirely or not at all, and the state checkpoint of its last block can then
+// be inserted.
+func (verifier *BeaconCheckpointVerifier) Verify(blocks []*BeaconBlock) error {
+	blockchain := verifier.blockchain
+	cp := blockchain.config.TrustedCheckpoint
+	prev, committee := verifier.last, verifier.committee
+	if prev == nil {
+		if len(blocks) == 0 || blocks[0].GetHeight() != cp.Height || !blocks[0].Hash().IsEqual(&cp.Hash) {
+			return NewInvalidBlockError(NewBlockChainError(TrustedCheckpointError, errors.New("blocks do not start with the checkpoint block")))
+		}
+		prev, blocks = blocks[0], blocks[1:]
+	}
+	var err error
+	for _, block := range blocks {
+		if block.GetHeight() != prev.GetHeight()+1 || !block.Header.PreviousBlockHash.IsEqual(prev.Hash()) {
+			return NewInvalidBlockError(NewBlockChainError(TrustedCheckpointError, fmt.Errorf("beacon block %+v at height %+v does not follow block %+v", block.Hash(), block.GetHeight(), prev.Hash())))
+		}
+		if err := VerifyBeaconBlockBody(block); err != nil {
+			return NewInvalidBlockError(err)
+		}
+		if err := blockchain.config.ConsensusEngine.ValidateProducerSig(block, common.BlsConsensus); err != nil {
+			return NewInvalidBlockError(NewBlockChainError(TrustedCheckpointError, err))
+		}
+		if err := blockchain.config.ConsensusEngine.ValidateBlockCommitteSig(block, committee); err != nil {
+			return NewInvalidBlockError(NewBlockChainError(TrustedCheckpointError, err))
+		}
+		if committee, err = ProcessBeaconCommitteeInstructions(committee, block, blockchain.config.ChainParams); err != nil {
+			return NewInvalidBlockError(err)
+		}
+		prev = block
+	}
+	verifier.last, verifier.committee = prev, committee
+	blockchain.setVerifiedCheckpoint(*prev.Hash())
+	return nil
+}
+
+// VerifyBeaconBlocksFromCheckpoint checks that blocks are the block of the
+// trusted checkpoint followed by its descendants, each one signed by the
+// committee resulting from the blocks before it. The state checkpoint of the
+// last block can then be inserted.
+func (blockchain *BlockChain) VerifyBeaconBlocksFromCheckpoint(blocks []*BeaconBlock) error {
+	verifier, err := blockchain.NewBeaconCheckpointVerifier()
+	if err != nil {
+		return err
+	}
+	return verifier.Verify(blocks)
+}
+
+func (blockchain *BlockChain) setVerifiedCheckpoint(hash common.Hash) {
+	blockchain.verifiedCheckpoint.Store(hash)
+}
+
+// isVerifiedCheckpoint reports whether hash is the block of the trusted
+// checkpoint or the last block verified to descend from it.
+func (blockchain *BlockChain) isVerifiedCheckpoint(hash common.Hash) bool {
+	cp := blockchain.config.TrustedCheckpoint
+	if cp == nil {
+		return false
+	}
+	if hash.IsEqual(&cp.Hash) {
+		return true
+	}
+	verified, ok := blockchain.verifiedCheckpoint.Load().(common.Hash)
+	return ok && hash.IsEqual(&verified)
+}
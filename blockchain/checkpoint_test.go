@@ -0,0 +1,258 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCheckpointSigner(t *testing.T, seed byte) (string, string) {
+	keyWallet, err := wallet.NewMasterKey([]byte{seed, 1, 2, 3})
+	assert.Nil(t, err)
+	return keyWallet.Base58CheckSerialize(wallet.PriKeyType), keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
+}
+
+func TestTrustedCheckpoint_SignVerify(t *testing.T) {
+	privateKey, paymentAddress := newCheckpointSigner(t, 1)
+	_, otherAddress := newCheckpointSigner(t, 2)
+	cp := &TrustedCheckpoint{Height: 100, Hash: common.HashH([]byte("block")), Committee: []string{"key1", "key2"}}
+	assert.Nil(t, cp.Sign(privateKey))
+	assert.Equal(t, paymentAddress, cp.Signer)
+	assert.Nil(t, cp.Verify([]string{otherAddress, paymentAddress}))
+
+	//the signer must be trusted
+	assert.NotNil(t, cp.Verify([]string{otherAddress}))
+	//the signature covers the height, the hash and the committee
+	tampered := *cp
+	tampered.Height = 101
+	assert.NotNil(t, tampered.Verify([]string{paymentAddress}))
+	tampered = *cp
+	tampered.Committee = []string{"key1"}
+	assert.NotNil(t, tampered.Verify([]string{paymentAddress}))
+	//the signer cannot be replaced by a trusted one
+	tampered = *cp
+	tampered.Signer = otherAddress
+	assert.NotNil(t, tampered.Verify([]string{otherAddress}))
+}
+
+func TestLoadTrustedCheckpoint(t *testing.T) {
+	privateKey, _ := newCheckpointSigner(t, 1)
+	cp := &TrustedCheckpoint{Height: 100, Hash: common.HashH([]byte("block")), Committee: []string{"key1"}}
+	assert.Nil(t, cp.Sign(privateKey))
+	data := `{"Height":100,"Hash":"` + cp.Hash.String() + `","Committee":["key1"],"Signer":"` + cp.Signer + `","Signature":"` + cp.Signature + `"}`
+
+	loaded, err := LoadTrustedCheckpoint(data)
+	assert.Nil(t, err)
+	assert.Equal(t, cp, loaded)
+
+	dir, err := ioutil.TempDir("", "checkpoint")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "checkpoint.json")
+	assert.Nil(t, ioutil.WriteFile(fileName, []byte(data), 0644))
+	loaded, err = LoadTrustedCheckpoint(fileName)
+	assert.Nil(t, err)
+	assert.Equal(t, cp, loaded)
+
+	_, err = LoadTrustedCheckpoint(filepath.Join(dir, "missing.json"))
+	assert.NotNil(t, err)
+}
+
+// checkpointEngine records the committee checking the signatures of each block
+type checkpointEngine struct {
+	ConsensusEngine
+	checkedCommittee map[uint64][]string
+}
+
+func (engine *checkpointEngine) ValidateProducerSig(block common.BlockInterface, consensusType string) error {
+	return nil
+}
+
+func (engine *checkpointEngine) ValidateBlockCommitteSig(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error {
+	if block.(*BeaconBlock).ValidationData == "forged" {
+		return errors.New("invalid committee signature")
+	}
+	engine.checkedCommittee[block.GetHeight()], _ = incognitokey.CommitteeKeyListToString(committee)
+	return nil
+}
+
+func newCheckpointBlocks(t *testing.T, from uint64, n int, instructions map[uint64][][]string) []*BeaconBlock {
+	blocks := []*BeaconBlock{}
+	prevHash := common.Hash{}
+	for height := from; height < from+uint64(n); height++ {
+		block := NewBeaconBlock()
+		block.Header.Version = 1
+		block.Header.Height = height
+		block.Header.PreviousBlockHash = prevHash
+		block.Body.Instructions = instructions[height]
+		var err error
+		block.Header.ShardStateHash, err = generateHashFromShardState(block.Body.ShardState)
+		assert.Nil(t, err)
+		instructionStrs := []string{}
+		for _, instruction := range block.Body.Instructions {
+			instructionStrs = append(instructionStrs, instruction...)
+		}
+		block.Header.InstructionHash, err = generateHashFromStringArray(instructionStrs)
+		assert.Nil(t, err)
+		blocks = append(blocks, block)
+		prevHash = *block.Hash()
+	}
+	return blocks
+}
+
+func TestBlockChain_VerifyBeaconBlocksFromCheckpoint(t *testing.T) {
+	keys := []string{}
+	for i := 0; i < 5; i++ {
+		key := incognitokey.CommitteePublicKey{IncPubKey: []byte{byte(i)}}
+		keyStr, err := key.ToBase58()
+		assert.Nil(t, err)
+		keys = append(keys, keyStr)
+	}
+	//the first key of the committee is swapped out at height 12
+	blocks := newCheckpointBlocks(t, 10, 5, map[uint64][][]string{
+		12: {{SwapAction, keys[4], keys[0], "beacon", "", "", ""}},
+	})
+	engine := &checkpointEngine{checkedCommittee: make(map[uint64][]string)}
+	bc := &BlockChain{config: Config{
+		ChainParams:       &Params{Epoch: 100},
+		ConsensusEngine:   engine,
+		TrustedCheckpoint: &TrustedCheckpoint{Height: 10, Hash: *blocks[0].Hash(), Committee: keys[:4]},
+	}}
+
+	assert.Nil(t, bc.VerifyBeaconBlocksFromCheckpoint(blocks))
+	assert.Equal(t, keys[:4], engine.checkedCommittee[12])
+	assert.Equal(t, []string{keys[4], keys[1], keys[2], keys[3]}, engine.checkedCommittee[13])
+
+	//the blocks must start with the checkpoint block
+	assert.NotNil(t, bc.VerifyBeaconBlocksFromCheckpoint(blocks[1:]))
+	assert.NotNil(t, bc.VerifyBeaconBlocksFromCheckpoint(nil))
+	//a block not following the one before it
+	assert.NotNil(t, bc.VerifyBeaconBlocksFromCheckpoint([]*BeaconBlock{blocks[0], blocks[2]}))
+	//a block not signed by the committee
+	forged := *blocks[3]
+	forged.ValidationData = "forged"
+	assert.NotNil(t, bc.VerifyBeaconBlocksFromCheckpoint([]*BeaconBlock{blocks[0], blocks[1], blocks[2], &forged}))
+	//a block with a body not matching its header
+	tampered := *blocks[1]
+	tampered.Body.Instructions = [][]string{{StakeAction}}
+	assert.NotNil(t, bc.VerifyBeaconBlocksFromCheckpoint([]*BeaconBlock{blocks[0], &tampered}))
+}
+
+func TestBlockChain_checkTrustedCheckpoint(t *testing.T) {
+	cpHash := common.HashH([]byte("checkpoint"))
+	bc := &BlockChain{}
+	assert.Nil(t, bc.checkTrustedCheckpoint(10, common.HashH([]byte("other"))))
+
+	bc.config.TrustedCheckpoint = &TrustedCheckpoint{Height: 10, Hash: cpHash}
+	assert.Nil(t, bc.checkTrustedCheckpoint(10, cpHash))
+	assert.Nil(t, bc.checkTrustedCheckpoint(11, common.HashH([]byte("other"))))
+	assert.NotNil(t, bc.checkTrustedCheckpoint(10, common.HashH([]byte("other"))))
+}
+
+func newBeaconStateCheckpoint(t *testing.T, block *BeaconBlock) *StateCheckpoint {
+	view, err := json.Marshal(&BeaconBestState{BestBlockHash: *block.Hash(), BeaconHeight: block.GetHeight()})
+	assert.Nil(t, err)
+	blockData, err := json.Marshal(block)
+	assert.Nil(t, err)
+	rootsHash, err := json.Marshal(BeaconRootHash{})
+	assert.Nil(t, err)
+	return &StateCheckpoint{View: view, Block: blockData, RootsHash: rootsHash}
+}
+
+func TestBlockChain_InsertBeaconStateCheckpoint_NotTrusted(t *testing.T) {
+	keys := []string{}
+	for i := 0; i < 4; i++ {
+		key := incognitokey.CommitteePublicKey{IncPubKey: []byte{byte(i)}}
+		keyStr, err := key.ToBase58()
+		assert.Nil(t, err)
+		keys = append(keys, keyStr)
+	}
+	blocks := newCheckpointBlocks(t, 10, 4, nil)
+	bc := &BlockChain{
+		BeaconChain: &BeaconChain{},
+		config: Config{
+			ChainParams:     &Params{Epoch: 100},
+			ConsensusEngine: &checkpointEngine{checkedCommittee: make(map[uint64][]string)},
+		},
+	}
+
+	//without trusted checkpoint a beacon state checkpoint is never inserted
+	err := bc.InsertBeaconStateCheckpoint(newBeaconStateCheckpoint(t, blocks[3]))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "no trusted checkpoint")
+
+	//a descendant of the trusted checkpoint which is not verified
+	bc.config.TrustedCheckpoint = &TrustedCheckpoint{Height: 10, Hash: *blocks[0].Hash(), Committee: keys}
+	err = bc.InsertBeaconStateCheckpoint(newBeaconStateCheckpoint(t, blocks[3]))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "not verified")
+	//a block older than the trusted checkpoint
+	older := newCheckpointBlocks(t, 9, 1, nil)[0]
+	err = bc.InsertBeaconStateCheckpoint(newBeaconStateCheckpoint(t, older))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "lower than trusted checkpoint")
+
+	//the blocks verified from the trusted checkpoint
+	assert.True(t, bc.isVerifiedCheckpoint(*blocks[0].Hash()))
+	assert.False(t, bc.isVerifiedCheckpoint(*blocks[3].Hash()))
+	assert.Nil(t, bc.VerifyBeaconBlocksFromCheckpoint(blocks))
+	assert.True(t, bc.isVerifiedCheckpoint(*blocks[3].Hash()))
+	assert.False(t, bc.isVerifiedCheckpoint(*blocks[2].Hash()))
+}
+
+func TestBeaconCheckpointVerifier_Chunks(t *testing.T) {
+	keys := []string{}
+	for i := 0; i < 5; i++ {
+		key := incognitokey.CommitteePublicKey{IncPubKey: []byte{byte(i)}}
+		keyStr, err := key.ToBase58()
+		assert.Nil(t, err)
+		keys = append(keys, keyStr)
+	}
+	//the first key of the committee is swapped out at height 12
+	blocks := newCheckpointBlocks(t, 10, 6, map[uint64][][]string{
+		12: {{SwapAction, keys[4], keys[0], "beacon", "", "", ""}},
+	})
+	engine := &checkpointEngine{checkedCommittee: make(map[uint64][]string)}
+	bc := &BlockChain{config: Config{
+		ChainParams:       &Params{Epoch: 100},
+		ConsensusEngine:   engine,
+		TrustedCheckpoint: &TrustedCheckpoint{Height: 10, Hash: *blocks[0].Hash(), Committee: keys[:4]},
+	}}
+	verifier, err := bc.NewBeaconCheckpointVerifier()
+	assert.Nil(t, err)
+
+	//the first chunk must start with the checkpoint block
+	assert.NotNil(t, verifier.Verify(blocks[1:3]))
+	assert.Nil(t, verifier.Verify(blocks[:3]))
+	assert.Equal(t, blocks[2], verifier.GetLastBlock())
+	assert.True(t, bc.isVerifiedCheckpoint(*blocks[2].Hash()))
+
+	//a chunk failing in the middle is not verified at all
+	forged := *blocks[4]
+	forged.ValidationData = "forged"
+	assert.NotNil(t, verifier.Verify([]*BeaconBlock{blocks[3], &forged}))
+	assert.Equal(t, blocks[2], verifier.GetLastBlock())
+	assert.False(t, bc.isVerifiedCheckpoint(*blocks[3].Hash()))
+	//a chunk not following the last verified block
+	assert.NotNil(t, verifier.Verify(blocks[4:]))
+
+	//the committee carries over to the next chunks
+	assert.Nil(t, verifier.Verify(blocks[3:5]))
+	assert.Nil(t, verifier.Verify(blocks[5:]))
+	assert.Equal(t, blocks[5], verifier.GetLastBlock())
+	assert.Equal(t, []string{keys[4], keys[1], keys[2], keys[3]}, engine.checkedCommittee[15])
+	assert.True(t, bc.isVerifiedCheckpoint(*blocks[5].Hash()))
+
+	//no verifier without trusted checkpoint
+	bc.config.TrustedCheckpoint = nil
+	_, err = bc.NewBeaconCheckpointVerifier()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,224 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+type signedBlockInfo struct {
+	hash     common.Hash
+	height   uint64
+	timeSlot int64
+	proposer string
+}
+
+func parseSignedBlockHeader(chainID int, header string) (*signedBlockInfo, error) {
+	if chainID == -1 {
+		beaconHeader := new(BeaconHeader)
+		if err := json.Unmarshal([]byte(header), beaconHeader); err != nil {
+			return nil, err
+		}
+		return &signedBlockInfo{
+			hash:     beaconHeader.Hash(),
+			height:   beaconHeader.Height,
+			timeSlot: common.CalculateTimeSlot(beaconHeader.ProposeTime),
+			proposer: beaconHeader.Proposer,
+		}, nil
+	}
+	shardHeader := new(ShardHeader)
+	if err := json.Unmarshal([]byte(header), shardHeader); err != nil {
+		return nil, err
+	}
+	if int(shardHeader.ShardID) != chainID {
+		return nil, fmt.Errorf("expect header of shard %+v but get shard %+v", chainID, shardHeader.ShardID)
+	}
+	return &signedBlockInfo{
+		hash:     shardHeader.Hash(),
+		height:   shardHeader.Height,
+		timeSlot: common.CalculateTimeSlot(shardHeader.ProposeTime),
+		proposer: shardHeader.Proposer,
+	}, nil
+}
+
+// verifySignedBlockEvidence checks that the offender signed the block,
+// a vote is verified with the BLS key of the offender in the committee it was signed with,
+// a proposal with the bridge key of the offender as the proposer of the block
+func verifySignedBlockEvidence(offender incognitokey.CommitteePublicKey, offenderStr string, isProposal bool, blockInfo *signedBlockInfo, evidence metadata.SignedBlockEvidence) error {
+	if isProposal {
+		if blockInfo.proposer != offenderStr {
+			return fmt.Errorf("block %+v is not proposed by %+v", blockInfo.hash.String(), offenderStr)
+		}
+		ok, err := bridgesig.Verify(offender.MiningPubKey[common.BridgeConsensus], blockInfo.hash.GetBytes(), evidence.Sig)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("invalid producer signature of block %+v", blockInfo.hash.String())
+		}
+		return nil
+	}
+	committee, err := incognitokey.CommitteeBase58KeyListToStruct(evidence.Committee)
+	if err != nil {
+		return err
+	}
+	selfIdx := -1
+	committeeBLSKeys := []blsmultisig.PublicKey{}
+	for i, member := range committee {
+		if member.GetMiningKeyBase58(common.BlsConsensus) == offender.GetMiningKeyBase58(common.BlsConsensus) {
+			selfIdx = i
+		}
+		committeeBLSKeys = append(committeeBLSKeys, member.MiningPubKey[common.BlsConsensus])
+	}
+	if selfIdx == -1 {
+		return fmt.Errorf("offender %+v not found in committee of vote for block %+v", offenderStr, blockInfo.hash.String())
+	}
+	ok, err := blsmultisig.Verify(evidence.Sig, blockInfo.hash.GetBytes(), []int{selfIdx}, committeeBLSKeys)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("invalid BLS signature of vote for block %+v", blockInfo.hash.String())
+	}
+	return nil
+}
+
+// verifyEquivocationEvidence returns nil if the offender is a committee member of the chain
+// and signed two different blocks at the same height in the same timeslot
+func (beaconBestState *BeaconBestState) verifyEquivocationEvidence(evidence metadata.EquivocationEvidence) error {
+	var chainCommittee []incognitokey.CommitteePublicKey
+	if evidence.ChainID == -1 {
+		chainCommittee = beaconBestState.BeaconCommittee
+	} else {
+		chainCommittee = beaconBestState.ShardCommittee[byte(evidence.ChainID)]
+	}
+	chainCommitteeStr, err := incognitokey.CommitteeKeyListToString(chainCommittee)
+	if err != nil {
+		return err
+	}
+	if common.IndexOfStr(evidence.Offender, chainCommitteeStr) == -1 {
+		return fmt.Errorf("offender %+v is not in committee of chain %+v", evidence.Offender, evidence.ChainID)
+	}
+	offender := incognitokey.CommitteePublicKey{}
+	if err := offender.FromString(evidence.Offender); err != nil {
+		return err
+	}
+	firstBlock, err := parseSignedBlockHeader(evidence.ChainID, evidence.FirstBlock.Header)
+	if err != nil {
+		return err
+	}
+	secondBlock, err := parseSignedBlockHeader(evidence.ChainID, evidence.SecondBlock.Header)
+	if err != nil {
+		return err
+	}
+	if firstBlock.hash == secondBlock.hash {
+		return fmt.Errorf("signed blocks are the same block %+v", firstBlock.hash.String())
+	}
+	if firstBlock.height != secondBlock.height || firstBlock.timeSlot != secondBlock.timeSlot {
+		return fmt.Errorf("signed blocks are at height %+v timeslot %+v and height %+v timeslot %+v", firstBlock.height, firstBlock.timeSlot, secondBlock.height, secondBlock.timeSlot)
+	}
+	if err := verifySignedBlockEvidence(offender, evidence.Offender, evidence.IsProposal, firstBlock, evidence.FirstBlock); err != nil {
+		return err
+	}
+	return verifySignedBlockEvidence(offender, evidence.Offender, evidence.IsProposal, secondBlock, evidence.SecondBlock)
+}
+
+// buildEquivocationSlashInstructions verifies the equivocation evidence actions of a shard block
+// and returns the instruction slashing the offenders
+//   - ["equivocationslash" "pubkey1,pubkey2,..."]
+func (beaconBestState *BeaconBestState) buildEquivocationSlashInstructions(evidenceActions [][]string, slashedPublicKeys []string) [][]string {
+	offenders := []string{}
+	for _, action := range evidenceActions {
+		contentBytes, err := base64.StdEncoding.DecodeString(action[1])
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while decoding content string of equivocation evidence action: %+v", err)
+			continue
+		}
+		var evidenceAction metadata.EquivocationEvidenceAction
+		err = json.Unmarshal(contentBytes, &evidenceAction)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling equivocation evidence action: %+v", err)
+			continue
+		}
+		evidence := evidenceAction.Meta
+		if common.IndexOfStr(evidence.Offender, offenders) > -1 || common.IndexOfStr(evidence.Offender, slashedPublicKeys) > -1 {
+			continue
+		}
+		if err := beaconBestState.verifyEquivocationEvidence(evidence); err != nil {
+			Logger.log.Errorf("Reject equivocation evidence of tx %+v: %+v", evidenceAction.TxReqID.String(), err)
+			continue
+		}
+		Logger.log.Infof("Slash %+v for signing conflicting blocks of chain %+v, evidence tx %+v", evidence.Offender, evidence.ChainID, evidenceAction.TxReqID.String())
+		offenders = append(offenders, evidence.Offender)
+	}
+	if len(offenders) == 0 {
+		return [][]string{}
+	}
+	return [][]string{{EquivocationSlash, strings.Join(offenders, ",")}}
+}
+
+func getEquivocationSlashedPublicKeys(equivocationSlashInstructions [][]string) []string {
+	slashedPublicKeys := []string{}
+	for _, instruction := range equivocationSlashInstructions {
+		slashedPublicKeys = append(slashedPublicKeys, strings.Split(instruction[1], ",")...)
+	}
+	return slashedPublicKeys
+}
+
+func isEquivocationEvidenceAction(instruction []string) bool {
+	return len(instruction) == 2 && instruction[0] == strconv.Itoa(metadata.EquivocationEvidenceMeta)
+}
+
+// processEquivocationSlash turns off auto staking of the offenders and marks their staking tx
+// as none, so they are not re-staked and get no return staking tx when swapped out. The offenders
+// are blacklisted by processForSlashing: the beacon swaps them out at the end of the epoch and
+// the shards at their next swap, see getShardProducersBlackList
+func (beaconBestState *BeaconBestState) processEquivocationSlash(instruction []string, committeeChange *committeeChange) {
+	for _, committeePublicKey := range strings.Split(instruction[1], ",") {
+		if _, ok := beaconBestState.AutoStaking.Get(committeePublicKey); !ok {
+			continue
+		}
+		beaconBestState.AutoStaking.Set(committeePublicKey, false)
+		beaconBestState.StakingTx[committeePublicKey] = common.HashH([]byte{0})
+		committeeChange.stopAutoStaking = append(committeeChange.stopAutoStaking, committeePublicKey)
+	}
+}
+
+// getShardProducersBlackList returns the producers swapped out of a shard committee by the swap
+// instruction of a shard block, the committee members slashed for equivocation among them. The
+// blacklist is read from the slash state of the beacon block the shard block refers to, so the
+// producer and the validators of the block build the same swap, the slash states are never
+// pruned. Before the activation of the equivocation slash, the blacklist is empty
+func (blockchain *BlockChain) getShardProducersBlackList(beaconHeight uint64, beaconHash common.Hash, shardID byte, committee []string) (map[string]uint8, error) {
+	if !blockchain.IsAfterEquivocationSlashCheckPoint(beaconHeight) {
+		return make(map[string]uint8), nil
+	}
+	beaconView, err := blockchain.GetBeaconViewStateDataFromBlockHash(beaconHash)
+	if err != nil {
+		return nil, err
+	}
+	if beaconView.GetBeaconSlashStateDB() == nil {
+		return nil, fmt.Errorf("no slash state for beacon block %+v", beaconHash.String())
+	}
+	return blockchain.getUpdatedProducersBlackList(beaconView.GetBeaconSlashStateDB(), false, int(shardID), committee, beaconView.BeaconHeight)
+}
+
+// getEquivocationOffenders returns the producers blacklisted for equivocation,
+// they are swapped out of the beacon committee at the end of the epoch
+func getEquivocationOffenders(producersBlackList map[string]uint8) map[string]uint8 {
+	offenders := make(map[string]uint8)
+	for producer, punishedEpoches := range producersBlackList {
+		if punishedEpoches == EquivocationPunishedEpoches {
+			offenders[producer] = punishedEpoches
+		}
+	}
+	return offenders
+}
@@ -0,0 +1,203 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+type testValidator struct {
+	blsSK     []byte
+	bridgeSK  []byte
+	publicKey string
+}
+
+func newTestValidators(num int) ([]testValidator, []incognitokey.CommitteePublicKey, []string) {
+	validators := []testValidator{}
+	committee := []incognitokey.CommitteePublicKey{}
+	committeeStr := []string{}
+	for i := 0; i < num; i++ {
+		seed := common.HashB([]byte("validator" + strconv.Itoa(i)))
+		blsSK, blsPK := blsmultisig.KeyGen(seed)
+		bridgeSK, bridgePK := bridgesig.KeyGen(seed)
+		publicKey := incognitokey.NewCommitteePublicKey()
+		publicKey.IncPubKey = seed
+		publicKey.MiningPubKey[common.BlsConsensus] = blsmultisig.PKBytes(blsPK)
+		publicKey.MiningPubKey[common.BridgeConsensus] = bridgesig.PKBytes(&bridgePK)
+		publicKeyStr, _ := publicKey.ToBase58()
+		validators = append(validators, testValidator{
+			blsSK:     blsmultisig.SKBytes(blsSK),
+			bridgeSK:  bridgesig.SKBytes(&bridgeSK),
+			publicKey: publicKeyStr,
+		})
+		committee = append(committee, *publicKey)
+		committeeStr = append(committeeStr, publicKeyStr)
+	}
+	return validators, committee, committeeStr
+}
+
+func buildEquivocationEvidenceAction(evidence *metadata.EquivocationEvidence) []string {
+	actionContent := metadata.EquivocationEvidenceAction{
+		Meta:    *evidence,
+		TxReqID: common.HashH([]byte(evidence.Offender)),
+		ShardID: 0,
+	}
+	actionContentBytes, _ := json.Marshal(actionContent)
+	return []string{strconv.Itoa(metadata.EquivocationEvidenceMeta), base64.StdEncoding.EncodeToString(actionContentBytes)}
+}
+
+func signTestVote(t *testing.T, header ShardHeader, validator testValidator, selfIdx int, committee []incognitokey.CommitteePublicKey, committeeStr []string) metadata.SignedBlockEvidence {
+	committeeBLSKeys := []blsmultisig.PublicKey{}
+	for _, member := range committee {
+		committeeBLSKeys = append(committeeBLSKeys, member.MiningPubKey[common.BlsConsensus])
+	}
+	hash := header.Hash()
+	sig, err := blsmultisig.Sign(hash.GetBytes(), validator.blsSK, selfIdx, committeeBLSKeys)
+	assert.Nil(t, err)
+	headerBytes, _ := json.Marshal(header)
+	return metadata.SignedBlockEvidence{Header: string(headerBytes), Committee: committeeStr, Sig: sig}
+}
+
+func TestEquivocationSlash(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	validators, committee, committeeStr := newTestValidators(4)
+	beaconBestState := NewBeaconBestStateWithConfig(&ChainTestParam)
+	beaconBestState.ShardCommittee[0] = committee
+	for _, publicKey := range committeeStr {
+		beaconBestState.AutoStaking.Set(publicKey, true)
+		beaconBestState.StakingTx[publicKey] = common.HashH([]byte(publicKey))
+	}
+
+	proposeTime := int64(1600000000)
+	firstHeader := ShardHeader{ShardID: 0, Height: 100, ProposeTime: proposeTime, Timestamp: proposeTime, Proposer: committeeStr[0]}
+	secondHeader := firstHeader
+	secondHeader.Timestamp++
+	laterHeader := secondHeader
+	laterHeader.ProposeTime += common.TIMESLOT
+
+	// (valid) validator 1 voted two blocks at the same height in the same timeslot
+	doubleVote, _ := metadata.NewEquivocationEvidence(0, committeeStr[1], false,
+		signTestVote(t, firstHeader, validators[1], 1, committee, committeeStr),
+		signTestVote(t, secondHeader, validators[1], 1, committee, committeeStr),
+		metadata.EquivocationEvidenceMeta)
+	// (invalid) validator 2 voted the second block in a later timeslot
+	revote, _ := metadata.NewEquivocationEvidence(0, committeeStr[2], false,
+		signTestVote(t, firstHeader, validators[2], 2, committee, committeeStr),
+		signTestVote(t, laterHeader, validators[2], 2, committee, committeeStr),
+		metadata.EquivocationEvidenceMeta)
+	// (invalid) votes of validator 3 claimed to be signed by validator 0
+	forged, _ := metadata.NewEquivocationEvidence(0, committeeStr[0], false,
+		signTestVote(t, firstHeader, validators[3], 0, committee, committeeStr),
+		signTestVote(t, secondHeader, validators[3], 0, committee, committeeStr),
+		metadata.EquivocationEvidenceMeta)
+
+	// (valid) validator 0 proposed two blocks in the same timeslot
+	firstHash := firstHeader.Hash()
+	secondHash := secondHeader.Hash()
+	firstSig, _ := bridgesig.Sign(validators[0].bridgeSK, firstHash.GetBytes())
+	secondSig, _ := bridgesig.Sign(validators[0].bridgeSK, secondHash.GetBytes())
+	firstHeaderBytes, _ := json.Marshal(firstHeader)
+	secondHeaderBytes, _ := json.Marshal(secondHeader)
+	doubleProposal, _ := metadata.NewEquivocationEvidence(0, committeeStr[0], true,
+		metadata.SignedBlockEvidence{Header: string(firstHeaderBytes), Sig: firstSig},
+		metadata.SignedBlockEvidence{Header: string(secondHeaderBytes), Sig: secondSig},
+		metadata.EquivocationEvidenceMeta)
+
+	actions := [][]string{
+		buildEquivocationEvidenceAction(doubleVote),
+		buildEquivocationEvidenceAction(revote),
+		buildEquivocationEvidenceAction(forged),
+		buildEquivocationEvidenceAction(doubleProposal),
+		// (invalid) validator 1 is already slashed
+		buildEquivocationEvidenceAction(doubleVote),
+	}
+	insts := beaconBestState.buildEquivocationSlashInstructions(actions, []string{})
+	assert.Equal(t, [][]string{{EquivocationSlash, committeeStr[1] + "," + committeeStr[0]}}, insts)
+	assert.Equal(t, []string{committeeStr[1], committeeStr[0]}, getEquivocationSlashedPublicKeys(insts))
+	assert.Equal(t, 0, len(beaconBestState.buildEquivocationSlashInstructions(actions[:1], []string{committeeStr[1]})))
+
+	committeeChange := newCommitteeChange()
+	beaconBestState.processEquivocationSlash(insts[0], committeeChange)
+	assert.Equal(t, []string{committeeStr[1], committeeStr[0]}, committeeChange.stopAutoStaking)
+	for i, publicKey := range committeeStr {
+		isAutoStaking, _ := beaconBestState.AutoStaking.Get(publicKey)
+		if i < 2 {
+			assert.False(t, isAutoStaking)
+			assert.Equal(t, common.HashH([]byte{0}), beaconBestState.StakingTx[publicKey])
+		} else {
+			assert.True(t, isAutoStaking)
+			assert.Equal(t, common.HashH([]byte(publicKey)), beaconBestState.StakingTx[publicKey])
+		}
+	}
+
+	producersBlackList := map[string]uint8{committeeStr[0]: EquivocationPunishedEpoches, committeeStr[2]: 1}
+	assert.Equal(t, map[string]uint8{committeeStr[0]: EquivocationPunishedEpoches}, getEquivocationOffenders(producersBlackList))
+}
+
+func TestEquivocationSlash_ShardSwap(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	_, _, committeeStr := newTestValidators(6)
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_statedb_")
+	assert.Nil(t, err)
+	diskBD, err := incdb.Open("leveldb", dbPath)
+	assert.Nil(t, err)
+	slashStateDB, err := statedb.NewWithPrefixTrie(common.HexToHash(common.HexEmptyRoot), statedb.NewDatabaseAccessWarper(diskBD))
+	assert.Nil(t, err)
+	bc := &BlockChain{config: Config{ChainParams: &Params{Epoch: 100}}}
+
+	// validator 1 of the shard committee is slashed in the middle of an epoch
+	beaconBlock := NewBeaconBlock()
+	beaconBlock.Header.Height = 150
+	beaconBlock.Body.Instructions = [][]string{{EquivocationSlash, committeeStr[1]}}
+	assert.Nil(t, bc.processForSlashing(slashStateDB, beaconBlock))
+	_, err = slashStateDB.Commit(true)
+	assert.Nil(t, err)
+
+	// the shard swaps it out with the blacklist of the beacon block its block refers to
+	shardCommittee := committeeStr[:4]
+	pendingValidators := committeeStr[4:]
+	producersBlackList, err := bc.getUpdatedProducersBlackList(slashStateDB, false, 0, shardCommittee, 200)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]uint8{committeeStr[1]: EquivocationPunishedEpoches}, producersBlackList)
+	_, newCommittee, swapped, _, err := SwapValidator(pendingValidators, shardCommittee, 4, 2, 1, producersBlackList, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, -1, common.IndexOfStr(committeeStr[1], newCommittee))
+	assert.True(t, common.IndexOfStr(committeeStr[1], swapped) > -1)
+}
+
+func TestEquivocationSlash_ActivationHeight(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	_, _, committeeStr := newTestValidators(4)
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_statedb_")
+	assert.Nil(t, err)
+	diskBD, err := incdb.Open("leveldb", dbPath)
+	assert.Nil(t, err)
+	slashStateDB, err := statedb.NewWithPrefixTrie(common.HexToHash(common.HexEmptyRoot), statedb.NewDatabaseAccessWarper(diskBD))
+	assert.Nil(t, err)
+	bc := &BlockChain{config: Config{ChainParams: &Params{Epoch: 100, BCHeightBreakPointEquivocationSlash: 1000}}}
+
+	// an equivocation slash instruction before the activation does not blacklist
+	beaconBlock := NewBeaconBlock()
+	beaconBlock.Header.Height = 150
+	beaconBlock.Body.Instructions = [][]string{{EquivocationSlash, committeeStr[1]}}
+	assert.Nil(t, bc.processForSlashing(slashStateDB, beaconBlock))
+	assert.Equal(t, 0, len(statedb.GetProducersBlackList(slashStateDB, 150)))
+
+	// the shards swap with an empty blacklist, without reading the beacon state
+	producersBlackList, err := bc.getShardProducersBlackList(999, common.HashH([]byte("unknown")), 0, committeeStr)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]uint8{}, producersBlackList)
+}
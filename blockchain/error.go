@@ -177,6 +177,10 @@ const (
 	GetShardBlockHeightByHashError
 	GetShardBlockByHashError
 	ResponsedTransactionFromBeaconInstructionsError
+	PruneStateError
+	InsertStateCheckpointError
+	TrustedCheckpointError
+	StateRootsHashError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -344,6 +348,10 @@ var ErrCodeMessage = map[int]struct {
 	GetListOutputCoinsByKeysetError:                   {-2000, "Get List Output Coins By Keyset Error"},
 	GetTotalLockedCollateralError:                     {-3000, "Get Total Locked Collateral Error"},
 	ResponsedTransactionFromBeaconInstructionsError:   {-3100, "Build Transaction Response From Beacon Instructions Error"},
+	PruneStateError:                                   {-3101, "Prune State Error"},
+	InsertStateCheckpointError:                        {-3102, "Insert State Checkpoint Error"},
+	TrustedCheckpointError:                            {-3103, "Trusted Checkpoint Error"},
+	StateRootsHashError:                               {-3104, "State Roots Hash Error"},
 }
 
 type BlockChainError struct {
@@ -363,3 +371,88 @@ func NewBlockChainError(key int, err error) *BlockChainError {
 		err:     errors.Wrap(err, ErrCodeMessage[key].message),
 	}
 }
+
+// InvalidBlockError is returned by the insertion or verification of a block
+// which fails validation, the peer which sent the block may be banned. Other
+// errors are failures of the node itself (eg. a database error), the block
+// may still be valid.
+type InvalidBlockError struct {
+	err error
+}
+
+func NewInvalidBlockError(err error) *InvalidBlockError {
+	return &InvalidBlockError{err: err}
+}
+
+func (e *InvalidBlockError) Error() string {
+	return e.err.Error()
+}
+
+func (e *InvalidBlockError) Unwrap() error {
+	return e.err
+}
+
+// IsInvalidBlockError reports whether err is caused by an invalid block
+func IsInvalidBlockError(err error) bool {
+	var invalidErr *InvalidBlockError
+	return errors.As(err, &invalidErr)
+}
+
+// blockValidationErrors are the errors of the block verification which only
+// depend on the block and the view it extends: a bad body, root, signature or
+// instruction. The other errors (eg. fetching a block from the database or the
+// cross shard pool) are failures of the node.
+var blockValidationErrors = []int{
+	WrongShardIDError,
+	WrongBlockHeightError,
+	WrongTimestampError,
+	WrongEpochError,
+	ProducerError,
+	TransactionRootHashError,
+	ShardTransactionRootHashError,
+	CrossShardTransactionRootHashError,
+	ShardIntructionFromTransactionAndInstructionError,
+	InstructionsHashError,
+	InstructionHashError,
+	ShardStateHashError,
+	ShardStateError,
+	WrongBlockTotalFeeError,
+	CrossShardBitMapError,
+	FlattenAndConvertStringInstError,
+	InstructionMerkleRootError,
+	BeaconBlockNotCompatibleError,
+	SwapInstructionError,
+	TransactionFromNewBlockError,
+	TransactionCreatedByMinerError,
+	ResponsedTransactionWithMetadataError,
+	CrossTransactionHashError,
+	ShardBestStateNotCompatibleError,
+	ShardBestStateBeaconHeightNotCompatibleError,
+	ShardCommitteeRootHashError,
+	ShardPendingValidatorRootHashError,
+	BeaconBestStateBestBlockNotCompatibleError,
+	BeaconBestStateBestShardHeightNotCompatibleError,
+	CandidateError,
+	RandomError,
+	BeaconCommitteeAndPendingValidatorRootError,
+	BeaconCandidateRootError,
+	ShardCandidateRootError,
+	ShardCommitteeAndPendingValidatorRootError,
+	TrustedCheckpointError,
+	StateRootsHashError,
+}
+
+// wrapBlockValidationError wraps err in an InvalidBlockError if the block
+// failed validation, and returns the other errors as they are
+func wrapBlockValidationError(err error) error {
+	var bcErr *BlockChainError
+	if IsInvalidBlockError(err) || !errors.As(err, &bcErr) {
+		return err
+	}
+	for _, key := range blockValidationErrors {
+		if bcErr.Code == ErrCodeMessage[key].Code {
+			return NewInvalidBlockError(err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,19 @@
+package blockchain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapBlockValidationError(t *testing.T) {
+	//a block with a bad root or instruction is invalid
+	assert.True(t, IsInvalidBlockError(wrapBlockValidationError(NewBlockChainError(InstructionsHashError, errors.New("bad root")))))
+	assert.True(t, IsInvalidBlockError(wrapBlockValidationError(NewBlockChainError(SwapInstructionError, errors.New("bad swap")))))
+	assert.True(t, IsInvalidBlockError(wrapBlockValidationError(NewInvalidBlockError(errors.New("bad signature")))))
+	//the failures of the node are not
+	assert.False(t, IsInvalidBlockError(wrapBlockValidationError(NewBlockChainError(CrossShardBlockError, errors.New("Unable to get required crossShard blocks from pool in time")))))
+	assert.False(t, IsInvalidBlockError(wrapBlockValidationError(NewBlockChainError(FetchPreviousBlockError, errors.New("not found")))))
+	assert.False(t, IsInvalidBlockError(wrapBlockValidationError(errors.New("database error"))))
+}
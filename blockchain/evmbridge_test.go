@@ -0,0 +1,161 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testBSCContractAddressStr = "0x1111111111111111111111111111111111111111"
+	testBSCTokenAddressStr    = "0x2222222222222222222222222222222222222222"
+	testEVMBridgeHeight       = uint64(100)
+)
+
+func newEVMBridgeTestChain(t *testing.T) (*BlockChain, *statedb.StateDB) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	BLogger.Init(common.NewBackend(nil).Logger("test", true))
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_statedb_")
+	assert.Nil(t, err)
+	diskBD, err := incdb.Open("leveldb", dbPath)
+	assert.Nil(t, err)
+	stateDB, err := statedb.NewWithPrefixTrie(common.HexToHash(common.HexEmptyRoot), statedb.NewDatabaseAccessWarper(diskBD))
+	assert.Nil(t, err)
+	bc := &BlockChain{config: Config{
+		ChainParams: &Params{
+			EthContractAddressStr:       MainETHContractAddressStr,
+			EVMContractAddressStrs:      map[uint64]string{common.BSCChainID: testBSCContractAddressStr},
+			BCHeightBreakPointEVMBridge: testEVMBridgeHeight,
+		},
+	}}
+	return bc, stateDB
+}
+
+func buildIssuingEVMReqAction(t *testing.T, evmChainID uint64, incTokenID common.Hash, amount uint64) string {
+	abiIns, err := abi.JSON(strings.NewReader(common.AbiJson))
+	assert.Nil(t, err)
+	logData, err := abiIns.Events["Deposit"].Inputs.Pack(
+		rCommon.HexToAddress(testBSCTokenAddressStr),
+		"12S5Lrs1XeQLbqN4ySyKtjAjd2d7sBP2tjFijzmp6avrrkQCNFMpkXm3FPzj2Wcu2ZNqJEmh9JriVuRErVwhuQnLmWSaggobEWsBEci",
+		big.NewInt(0).SetUint64(amount),
+	)
+	assert.Nil(t, err)
+	receipt := &types.Receipt{
+		Status: types.ReceiptStatusSuccessful,
+		Logs: []*types.Log{{
+			Address: rCommon.HexToAddress(testBSCContractAddressStr),
+			Topics:  []rCommon.Hash{},
+			Data:    logData,
+		}},
+	}
+	action := metadata.IssuingETHReqAction{
+		Meta: metadata.IssuingETHRequest{
+			BlockHash:    rCommon.HexToHash("0x01"),
+			TxIndex:      1,
+			IncTokenID:   incTokenID,
+			EVMChainID:   evmChainID,
+			MetadataBase: metadata.MetadataBase{Type: metadata.IssuingETHRequestMeta},
+		},
+		TxReqID:    common.HashH([]byte("issuing")),
+		ETHReceipt: receipt,
+	}
+	actionBytes, err := json.Marshal(action)
+	assert.Nil(t, err)
+	return base64.StdEncoding.EncodeToString(actionBytes)
+}
+
+func buildBurningEVMReqInst(t *testing.T, evmChainID uint64, incTokenID common.Hash, amount uint64) []string {
+	txID := common.HashH([]byte("burning"))
+	action := BurningReqAction{
+		Meta: metadata.BurningRequest{
+			BurningAmount: amount,
+			TokenID:       incTokenID,
+			RemoteAddress: "3333333333333333333333333333333333333333",
+			EVMChainID:    evmChainID,
+			MetadataBase:  metadata.MetadataBase{Type: metadata.BurningRequestMetaV2},
+		},
+		RequestedTxID: &txID,
+	}
+	actionBytes, err := json.Marshal(action)
+	assert.Nil(t, err)
+	return []string{strconv.Itoa(metadata.BurningRequestMetaV2), base64.StdEncoding.EncodeToString(actionBytes)}
+}
+
+func TestBlockChain_IssueAndBurnOnEVMChain(t *testing.T) {
+	bc, stateDB := newEVMBridgeTestChain(t)
+	incTokenID := common.HashH([]byte("pBSCToken"))
+	contentStr := buildIssuingEVMReqAction(t, common.BSCChainID, incTokenID, 1000)
+	newAccumulatedValues := func() *metadata.AccumulatedValues {
+		return &metadata.AccumulatedValues{
+			UniqETHTxsUsed:   [][]byte{},
+			DBridgeTokenPair: map[string]metadata.BridgeTokenPair{},
+			CBridgeTokens:    []*common.Hash{},
+		}
+	}
+
+	// the chain is not bridged before the activation height
+	insts, err := bc.buildInstructionsForIssuingETHReq(stateDB, contentStr, 0, metadata.IssuingETHRequestMeta, newAccumulatedValues(), testEVMBridgeHeight-1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(insts))
+	assert.Equal(t, "rejected", insts[0][2])
+
+	ac := newAccumulatedValues()
+	insts, err = bc.buildInstructionsForIssuingETHReq(stateDB, contentStr, 0, metadata.IssuingETHRequestMeta, ac, testEVMBridgeHeight)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(insts))
+	assert.Equal(t, "accepted", insts[0][2])
+	acceptedBytes, err := base64.StdEncoding.DecodeString(insts[0][3])
+	assert.Nil(t, err)
+	var accepted metadata.IssuingETHAcceptedInst
+	assert.Nil(t, json.Unmarshal(acceptedBytes, &accepted))
+	assert.Equal(t, common.BSCChainID, accepted.EVMChainID)
+	assert.Equal(t, uint64(1000), accepted.IssuingAmount)
+	assert.Equal(t, rCommon.HexToAddress(testBSCTokenAddressStr).Bytes(), accepted.ExternalTokenID)
+	assert.Equal(t, metadata.GetEVMNetwork(common.BSCChainID), ac.DBridgeTokenPair[incTokenID.String()].Network)
+
+	// the same deposit is not issued twice in a block
+	insts, err = bc.buildInstructionsForIssuingETHReq(stateDB, contentStr, 0, metadata.IssuingETHRequestMeta, ac, testEVMBridgeHeight)
+	assert.Nil(t, err)
+	assert.Equal(t, "rejected", insts[0][2])
+
+	// a receipt of the BSC contract is not accepted as a deposit on Ethereum
+	ethContentStr := buildIssuingEVMReqAction(t, common.ETHChainID, incTokenID, 1000)
+	insts, err = bc.buildInstructionsForIssuingETHReq(stateDB, ethContentStr, 0, metadata.IssuingETHRequestMeta, newAccumulatedValues(), testEVMBridgeHeight)
+	assert.Nil(t, err)
+	assert.Equal(t, "rejected", insts[0][2])
+
+	err = statedb.UpdateBridgeTokenInfo(stateDB, incTokenID, accepted.ExternalTokenID, metadata.GetEVMNetwork(common.BSCChainID), false, accepted.IssuingAmount, statedb.BridgePlusOperator)
+	assert.Nil(t, err)
+	_, err = stateDB.Commit(true)
+	assert.Nil(t, err)
+
+	burningInst := buildBurningEVMReqInst(t, common.BSCChainID, incTokenID, 400)
+	_, err = bc.buildBurningConfirmInst(stateDB, metadata.BurningConfirmMetaV2, burningInst, testEVMBridgeHeight-1)
+	assert.NotNil(t, err)
+
+	burningConfirm, err := bc.buildBurningConfirmInst(stateDB, metadata.BurningConfirmMetaV2, burningInst, testEVMBridgeHeight)
+	assert.Nil(t, err)
+	assert.Equal(t, 9, len(burningConfirm))
+	assert.Equal(t, common.BSCChainID, GetBurningConfirmEVMChainID(burningConfirm))
+	assert.Equal(t, strconv.Itoa(metadata.BurningConfirmMetaV2), burningConfirm[0])
+
+	// the token is not a bridge token of Ethereum
+	ethBurningInst := buildBurningEVMReqInst(t, common.ETHChainID, incTokenID, 400)
+	_, err = bc.buildBurningConfirmInst(stateDB, metadata.BurningConfirmMetaV2, ethBurningInst, testEVMBridgeHeight)
+	assert.NotNil(t, err)
+}
@@ -27,6 +27,9 @@ type TxPool interface {
 	EmptyPool() bool
 	MaybeAcceptTransactionForBlockProducing(metadata.Transaction, int64, *ShardBestState) (*metadata.TxDesc, error)
 	MaybeAcceptBatchTransactionForBlockProducing(byte, []metadata.Transaction, int64, *ShardBestState) ([]*metadata.TxDesc, error)
+	// SortTxsByFeePriority orders txs by their fee per KB in the source pool,
+	// highest first.
+	SortTxsByFeePriority(txs []metadata.Transaction)
 	//CheckTransactionFee
 	// CheckTransactionFee(tx metadata.Transaction) (uint64, error)
 	// Check tx validate by it self
@@ -0,0 +1,117 @@
+package blockchain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// A light client does not keep the beacon best state: it checks the body of a beacon block against its header,
+// and follows the beacon committee from the swap instructions, as the beacon best state does
+
+// VerifyBeaconBlockBody checks the shard states and the instructions of beaconBlock against the hashes in its header
+func VerifyBeaconBlockBody(beaconBlock *BeaconBlock) error {
+	if !verifyHashFromShardState(beaconBlock.Body.ShardState, beaconBlock.Header.ShardStateHash) {
+		return NewBlockChainError(ShardStateHashError, fmt.Errorf("Expect shard state hash to be %+v", beaconBlock.Header.ShardStateHash))
+	}
+	tempInstructionArr := []string{}
+	for _, strs := range beaconBlock.Body.Instructions {
+		tempInstructionArr = append(tempInstructionArr, strs...)
+	}
+	if hash, ok := verifyHashFromStringArray(tempInstructionArr, beaconBlock.Header.InstructionHash); !ok {
+		return NewBlockChainError(InstructionHashError, fmt.Errorf("Expect instruction hash to be %+v but get %+v", beaconBlock.Header.InstructionHash, hash))
+	}
+	return nil
+}
+
+// GetGenesisBeaconCommittee returns the beacon committee staked by the genesis block, the committee signing its next block
+func GetGenesisBeaconCommittee(genesisBlock *BeaconBlock) ([]incognitokey.CommitteePublicKey, error) {
+	committee := []incognitokey.CommitteePublicKey{}
+	for _, instruction := range genesisBlock.Body.Instructions {
+		if len(instruction) < 3 || instruction[0] != StakeAction || instruction[2] != "beacon" {
+			continue
+		}
+		candidates, err := incognitokey.CommitteeBase58KeyListToStruct(strings.Split(instruction[1], ","))
+		if err != nil {
+			return nil, NewBlockChainError(UnExpectedError, err)
+		}
+		committee = append(committee, candidates...)
+	}
+	return committee, nil
+}
+
+// ProcessBeaconCommitteeInstructions returns the beacon committee after beaconBlock, the committee of the
+// previous block being committee
+func ProcessBeaconCommitteeInstructions(committee []incognitokey.CommitteePublicKey, beaconBlock *BeaconBlock, params *Params) ([]incognitokey.CommitteePublicKey, error) {
+	newCommittee := append([]incognitokey.CommitteePublicKey{}, committee...)
+	for _, instruction := range beaconBlock.Body.Instructions {
+		if len(instruction) < 4 || instruction[0] != SwapAction || instruction[3] != "beacon" {
+			continue
+		}
+		isKeyListV2 := common.IndexOfUint64(beaconBlock.Header.Height/params.Epoch, params.EpochBreakPointSwapNewKey) > -1 || len(instruction) == 7
+		if isKeyListV2 {
+			if instruction[1] == "" && instruction[2] == "" {
+				continue
+			}
+			inPublicKeyStructs, err := incognitokey.CommitteeBase58KeyListToStruct(strings.Split(instruction[1], ","))
+			if err != nil {
+				return nil, NewBlockChainError(UnExpectedError, err)
+			}
+			if len(inPublicKeyStructs) > len(newCommittee) {
+				return nil, NewBlockChainError(ProcessSwapInstructionError, fmt.Errorf("swap %+v keys out of a beacon committee of %+v", len(inPublicKeyStructs), len(newCommittee)))
+			}
+			newCommittee = append(inPublicKeyStructs, newCommittee[len(inPublicKeyStructs):]...)
+		} else if len(instruction[1]) > 0 {
+			inPublicKeyStructs, err := incognitokey.CommitteeBase58KeyListToStruct(strings.Split(instruction[1], ","))
+			if err != nil {
+				return nil, NewBlockChainError(UnExpectedError, err)
+			}
+			newCommittee = append(newCommittee, inPublicKeyStructs...)
+		}
+	}
+	return newCommittee, nil
+}
+
+// GetMerklePathForTx returns the merkle path of the transaction at index in the transaction tree of a shard block,
+// built by BuildMerkleTreeStore
+func (merkle Merkle) GetMerklePathForTx(transactions []metadata.Transaction, index int) ([]common.Hash, error) {
+	if index < 0 || index >= len(transactions) {
+		return nil, fmt.Errorf("transaction index %v out of %v transactions", index, len(transactions))
+	}
+	merkleTree := merkle.BuildMerkleTreeStore(transactions)
+	merklePath := []common.Hash{}
+	levelSize := NextPowerOfTwo(len(transactions))
+	levelOffset := 0
+	i := index
+	for levelSize > 1 {
+		node := merkleTree[levelOffset+i]
+		sibling := merkleTree[levelOffset+(i^1)]
+		if sibling == nil {
+			//a node without right sibling is hashed with itself
+			sibling = node
+		}
+		merklePath = append(merklePath, *sibling)
+		levelOffset += levelSize
+		levelSize /= 2
+		i /= 2
+	}
+	return merklePath, nil
+}
+
+// VerifyMerklePathForTx checks that the transaction txHash at index is in the transaction tree whose root is txRoot
+func (merkle Merkle) VerifyMerklePathForTx(txHash common.Hash, index int, merklePath []common.Hash, txRoot common.Hash) bool {
+	node := &txHash
+	for _, hashPath := range merklePath {
+		sibling := hashPath
+		if index%2 == 0 {
+			node = merkle.hashMerkleBranches(node, &sibling)
+		} else {
+			node = merkle.hashMerkleBranches(&sibling, node)
+		}
+		index /= 2
+	}
+	return node.IsEqual(&txRoot)
+}
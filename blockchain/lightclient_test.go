@@ -0,0 +1,61 @@
+package blockchain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerkle_GetMerklePathForTx(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		txs := []metadata.Transaction{}
+		for i := 0; i < n; i++ {
+			txs = append(txs, &transaction.Tx{LockTime: int64(i)})
+		}
+		merkleTree := Merkle{}.BuildMerkleTreeStore(txs)
+		txRoot := *merkleTree[len(merkleTree)-1]
+		for i, tx := range txs {
+			merklePath, err := Merkle{}.GetMerklePathForTx(txs, i)
+			assert.Nil(t, err)
+			assert.True(t, Merkle{}.VerifyMerklePathForTx(*tx.Hash(), i, merklePath, txRoot), "%v txs, index %v", n, i)
+			//the path does not prove another transaction or another index
+			assert.False(t, Merkle{}.VerifyMerklePathForTx(common.HashH([]byte{byte(i)}), i, merklePath, txRoot))
+			if n > 1 {
+				assert.False(t, Merkle{}.VerifyMerklePathForTx(*tx.Hash(), (i+1)%n, merklePath, txRoot))
+			}
+		}
+		_, err := Merkle{}.GetMerklePathForTx(txs, n)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestProcessBeaconCommitteeInstructions(t *testing.T) {
+	keys := []string{}
+	for i := 0; i < 6; i++ {
+		key := incognitokey.CommitteePublicKey{IncPubKey: []byte{byte(i)}}
+		keyStr, err := key.ToBase58()
+		assert.Nil(t, err)
+		keys = append(keys, keyStr)
+	}
+	committee, err := incognitokey.CommitteeBase58KeyListToStruct(keys[:4])
+	assert.Nil(t, err)
+	params := &Params{Epoch: 10}
+	block := NewBeaconBlock()
+	block.Header.Height = 20
+	block.Body.Instructions = [][]string{
+		{SwapAction, strings.Join(keys[4:], ","), strings.Join(keys[:2], ","), "beacon", "", "", ""},
+	}
+	newCommittee, err := ProcessBeaconCommitteeInstructions(committee, block, params)
+	assert.Nil(t, err)
+	newCommitteeStr, err := incognitokey.CommitteeKeyListToString(newCommittee)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{keys[4], keys[5], keys[2], keys[3]}, newCommitteeStr)
+	//the committee of the previous block is unchanged
+	committeeStr, _ := incognitokey.CommitteeKeyListToString(committee)
+	assert.Equal(t, keys[:4], committeeStr)
+}
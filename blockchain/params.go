@@ -23,6 +23,13 @@ type PortalParams struct {
 	TP130                                uint64
 	MinPercentPortingFee                 float64
 	MinPercentRedeemFee                  float64
+
+	// exchange rates oracle, the final exchange rates are the last rates of the
+	// single feeder while ExchangeRatesWindow is 0
+	ExchangeRatesWindow              uint64 // beacon blocks between two computations of the final exchange rates from the feeds
+	MinExchangeRatesFeeds            int    // feeds needed to update the rate of a token, a majority of the feeders is needed when it is lower
+	MaxExchangeRatesFeedAge          uint64 // beacon blocks after which a feed is stale, 0 for no limit
+	MaxPercentExchangeRatesDeviation uint64 // feeds farther from the median are outliers, 0 to keep them
 }
 
 /*
@@ -64,17 +71,32 @@ type Params struct {
 	BNBRelayingHeaderChainID         string
 	BTCRelayingHeaderChainID         string
 	BTCDataFolderName                string
-	BNBFullNodeProtocol       string
-	BNBFullNodeHost           string
-	BNBFullNodePort           string
-	PortalParams              map[uint64]PortalParams
-	PortalFeederAddress       string
-	EpochBreakPointSwapNewKey []uint64
-	IsBackup                  bool
-	PreloadAddress            string
-	ReplaceStakingTxHeight    uint64
-	ETHRemoveBridgeSigEpoch   uint64
-	BCHeightBreakPointNewZKP  uint64
+	BNBFullNodeProtocol              string
+	BNBFullNodeHost                  string
+	BNBFullNodePort                  string
+	PortalParams                     map[uint64]PortalParams
+	PortalFeederAddresses            []string // feeders of the portal exchange rates until the governor updates them
+	PortalFeederGovernorAddress      string   // signer of the updates of the feeders
+	EpochBreakPointSwapNewKey        []uint64
+	IsBackup                         bool
+	PreloadAddress                   string
+	ReplaceStakingTxHeight           uint64
+	ETHRemoveBridgeSigEpoch          uint64
+	BCHeightBreakPointNewZKP         uint64
+	// pde pools between two ptokens are accepted from this beacon height
+	BCHeightBreakPointPDETokenPools uint64
+	// pde v3 pools are accepted from this beacon height
+	BCHeightBreakPointPDEV3 uint64
+	// pde limit orders are accepted from this beacon height
+	BCHeightBreakPointPDELimitOrder uint64
+	// smart contracts of the bridge on the EVM chains other than Ethereum, by chain id
+	EVMContractAddressStrs map[uint64]string
+	// the EVM chains other than Ethereum are bridged from this beacon height
+	BCHeightBreakPointEVMBridge uint64
+	// equivocation evidences are accepted and the offenders slashed from this beacon height
+	BCHeightBreakPointEquivocationSlash uint64
+	// block headers commit to the state roots of their previous block from this beacon height
+	BCHeightBreakPointStateRootsHash uint64
 }
 
 type GenesisParams struct {
@@ -160,7 +182,8 @@ func init() {
 		BNBFullNodeProtocol:            TestnetBNBFullNodeProtocol,
 		BNBFullNodeHost:                TestnetBNBFullNodeHost,
 		BNBFullNodePort:                TestnetBNBFullNodePort,
-		PortalFeederAddress:            TestnetPortalFeeder,
+		PortalFeederAddresses:          []string{TestnetPortalFeeder},
+		PortalFeederGovernorAddress:    TestnetIncognitoDAOAddress,
 		PortalParams: map[uint64]PortalParams{
 			0: {
 				TimeOutCustodianReturnPubToken:       1 * time.Hour,
@@ -176,13 +199,39 @@ func init() {
 				MinPercentPortingFee:                 0.01,
 				MinPercentRedeemFee:                  0.01,
 			},
+			2500000: { //TODO: change this value when deployed testnet
+				TimeOutCustodianReturnPubToken:       1 * time.Hour,
+				TimeOutWaitingPortingRequest:         1 * time.Hour,
+				TimeOutWaitingRedeemRequest:          10 * time.Minute,
+				MaxPercentLiquidatedCollateralAmount: 105,
+				MaxPercentCustodianRewards:           10, // todo: need to be updated before deploying
+				MinPercentCustodianRewards:           1,
+				MinLockCollateralAmountInEpoch:       5000 * 1e9, // 5000 prv
+				MinPercentLockedCollateral:           150,
+				TP120:                                120,
+				TP130:                                130,
+				MinPercentPortingFee:                 0.01,
+				MinPercentRedeemFee:                  0.01,
+				ExchangeRatesWindow:                  10,
+				MaxExchangeRatesFeedAge:              100,
+				MaxPercentExchangeRatesDeviation:     10,
+			},
+		},
+		EpochBreakPointSwapNewKey:       TestnetReplaceCommitteeEpoch,
+		ReplaceStakingTxHeight:          1,
+		IsBackup:                        false,
+		PreloadAddress:                  "",
+		BCHeightBreakPointNewZKP:        2300000, //TODO: change this value when deployed testnet
+		BCHeightBreakPointPDETokenPools: 2400000, //TODO: change this value when deployed testnet
+		BCHeightBreakPointPDEV3:         2400000, //TODO: change this value when deployed testnet
+		BCHeightBreakPointPDELimitOrder: 2400000, //TODO: change this value when deployed testnet
+		EVMContractAddressStrs: map[uint64]string{
+			common.BSCTestnetChainID: TestnetBSCContractAddressStr,
 		},
-		EpochBreakPointSwapNewKey: TestnetReplaceCommitteeEpoch,
-		ReplaceStakingTxHeight:    1,
-		IsBackup:                  false,
-		PreloadAddress:            "",
-		BCHeightBreakPointNewZKP:  2300000, //TODO: change this value when deployed testnet
-		ETHRemoveBridgeSigEpoch:   21920,
+		BCHeightBreakPointEVMBridge:         2400000, //TODO: change this value when deployed testnet
+		BCHeightBreakPointEquivocationSlash: 2400000, //TODO: change this value when deployed testnet
+		BCHeightBreakPointStateRootsHash:    2400000, //TODO: change this value when deployed testnet
+		ETHRemoveBridgeSigEpoch:             21920,
 	}
 	// END TESTNET
 
@@ -243,7 +292,8 @@ func init() {
 		BNBFullNodeProtocol:            Testnet2BNBFullNodeProtocol,
 		BNBFullNodeHost:                Testnet2BNBFullNodeHost,
 		BNBFullNodePort:                Testnet2BNBFullNodePort,
-		PortalFeederAddress:            Testnet2PortalFeeder,
+		PortalFeederAddresses:          []string{Testnet2PortalFeeder},
+		PortalFeederGovernorAddress:    Testnet2IncognitoDAOAddress,
 		PortalParams: map[uint64]PortalParams{
 			0: {
 				TimeOutCustodianReturnPubToken:       1 * time.Hour,
@@ -260,12 +310,21 @@ func init() {
 				MinPercentRedeemFee:                  0.01,
 			},
 		},
-		EpochBreakPointSwapNewKey: TestnetReplaceCommitteeEpoch,
-		ReplaceStakingTxHeight:    1,
-		IsBackup:                  false,
-		PreloadAddress:            "",
-		BCHeightBreakPointNewZKP:  260000, //TODO: change this value when deployed testnet2
-		ETHRemoveBridgeSigEpoch:   2085,
+		EpochBreakPointSwapNewKey:       TestnetReplaceCommitteeEpoch,
+		ReplaceStakingTxHeight:          1,
+		IsBackup:                        false,
+		PreloadAddress:                  "",
+		BCHeightBreakPointNewZKP:        260000, //TODO: change this value when deployed testnet2
+		BCHeightBreakPointPDETokenPools: 300000, //TODO: change this value when deployed testnet2
+		BCHeightBreakPointPDEV3:         300000, //TODO: change this value when deployed testnet2
+		BCHeightBreakPointPDELimitOrder: 300000, //TODO: change this value when deployed testnet2
+		EVMContractAddressStrs: map[uint64]string{
+			common.BSCTestnetChainID: Testnet2BSCContractAddressStr,
+		},
+		BCHeightBreakPointEVMBridge:         300000, //TODO: change this value when deployed testnet2
+		BCHeightBreakPointEquivocationSlash: 300000, //TODO: change this value when deployed testnet2
+		BCHeightBreakPointStateRootsHash:    300000, //TODO: change this value when deployed testnet2
+		ETHRemoveBridgeSigEpoch:             2085,
 	}
 	// END TESTNET-2
 
@@ -324,7 +383,8 @@ func init() {
 		BNBFullNodeProtocol:            MainnetBNBFullNodeProtocol,
 		BNBFullNodeHost:                MainnetBNBFullNodeHost,
 		BNBFullNodePort:                MainnetBNBFullNodePort,
-		PortalFeederAddress:            MainnetPortalFeeder,
+		PortalFeederAddresses:          []string{MainnetPortalFeeder},
+		PortalFeederGovernorAddress:    MainnetIncognitoDAOAddress,
 		PortalParams: map[uint64]PortalParams{
 			0: {
 				TimeOutCustodianReturnPubToken:       24 * time.Hour,
@@ -340,14 +400,40 @@ func init() {
 				MinPercentPortingFee:                 0.01,
 				MinPercentRedeemFee:                  0.01,
 			},
+			1000000000: { //TODO: change this value when deployed mainnet
+				TimeOutCustodianReturnPubToken:       24 * time.Hour,
+				TimeOutWaitingPortingRequest:         24 * time.Hour,
+				TimeOutWaitingRedeemRequest:          15 * time.Minute,
+				MaxPercentLiquidatedCollateralAmount: 120,
+				MaxPercentCustodianRewards:           20,
+				MinPercentCustodianRewards:           1,
+				MinPercentLockedCollateral:           200,
+				MinLockCollateralAmountInEpoch:       17500 * 1e9, // 17500 prv
+				TP120:                                120,
+				TP130:                                130,
+				MinPercentPortingFee:                 0.01,
+				MinPercentRedeemFee:                  0.01,
+				ExchangeRatesWindow:                  10,
+				MaxExchangeRatesFeedAge:              100,
+				MaxPercentExchangeRatesDeviation:     10,
+			},
 		},
 
-		EpochBreakPointSwapNewKey: MainnetReplaceCommitteeEpoch,
-		ReplaceStakingTxHeight:    559380,
-		IsBackup:                  false,
-		PreloadAddress:            "",
-		BCHeightBreakPointNewZKP:  737450,
-		ETHRemoveBridgeSigEpoch:   1973,
+		EpochBreakPointSwapNewKey:       MainnetReplaceCommitteeEpoch,
+		ReplaceStakingTxHeight:          559380,
+		IsBackup:                        false,
+		PreloadAddress:                  "",
+		BCHeightBreakPointNewZKP:        737450,
+		BCHeightBreakPointPDETokenPools: 1000000000, //TODO: change this value when deployed mainnet
+		BCHeightBreakPointPDEV3:         1000000000, //TODO: change this value when deployed mainnet
+		BCHeightBreakPointPDELimitOrder: 1000000000, //TODO: change this value when deployed mainnet
+		EVMContractAddressStrs: map[uint64]string{
+			common.BSCChainID: MainBSCContractAddressStr,
+		},
+		BCHeightBreakPointEVMBridge:         1000000000, //TODO: change this value when deployed mainnet
+		BCHeightBreakPointEquivocationSlash: 1000000000, //TODO: change this value when deployed mainnet
+		BCHeightBreakPointStateRootsHash:    1000000000, //TODO: change this value when deployed mainnet
+		ETHRemoveBridgeSigEpoch:             1973,
 	}
 	if IsTestNet {
 		if !IsTestNet2 {
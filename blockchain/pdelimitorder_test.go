@@ -0,0 +1,191 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func buildPDELimitOrderReqAction(
+	tokenIDToBuyStr string,
+	tokenIDToSellStr string,
+	sellAmount uint64,
+	minAcceptableAmount uint64,
+	expiryBeaconHeight uint64,
+	traderAddressStr string,
+	txReqID common.Hash,
+) []string {
+	pdeLimitOrderRequest, _ := metadata.NewPDELimitOrderRequest(
+		tokenIDToBuyStr,
+		tokenIDToSellStr,
+		sellAmount,
+		minAcceptableAmount,
+		expiryBeaconHeight,
+		traderAddressStr,
+		metadata.PDELimitOrderRequestMeta,
+	)
+	actionContent := metadata.PDELimitOrderRequestAction{
+		Meta:    *pdeLimitOrderRequest,
+		TxReqID: txReqID,
+		ShardID: 1,
+	}
+	actionContentBytes, _ := json.Marshal(actionContent)
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	return []string{strconv.Itoa(metadata.PDELimitOrderRequestMeta), actionContentBase64Str}
+}
+
+func buildPDECancelLimitOrderReqAction(
+	orderID common.Hash,
+	traderAddressStr string,
+	txReqID common.Hash,
+) []string {
+	pdeCancelLimitOrderRequest, _ := metadata.NewPDECancelLimitOrderRequest(
+		orderID.String(),
+		traderAddressStr,
+		metadata.PDECancelLimitOrderRequestMeta,
+	)
+	actionContent := metadata.PDECancelLimitOrderRequestAction{
+		Meta:    *pdeCancelLimitOrderRequest,
+		TxReqID: txReqID,
+		ShardID: 1,
+	}
+	actionContentBytes, _ := json.Marshal(actionContent)
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	return []string{strconv.Itoa(metadata.PDECancelLimitOrderRequestMeta), actionContentBase64Str}
+}
+
+func processAllNewLimitOrderInsts(
+	blockchain *BlockChain,
+	insts [][]string,
+	pdexStateDB *statedb.StateDB,
+	beaconHeight uint64,
+	currentPDEState *CurrentPDEState,
+) {
+	for _, inst := range insts {
+		var err error
+		switch inst[0] {
+		case strconv.Itoa(metadata.PDELimitOrderRequestMeta):
+			err = blockchain.processPDELimitOrder(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDECancelLimitOrderRequestMeta):
+			err = blockchain.processPDECancelLimitOrder(pdexStateDB, beaconHeight, inst, currentPDEState)
+		}
+		if err != nil {
+			fmt.Printf("An error occured while process instruction: %v\n", inst)
+		}
+	}
+}
+
+func (s *PDETestSuiteV2) TestPDELimitOrders() {
+	fmt.Println("Running testcase: TestPDELimitOrders")
+	bc := &BlockChain{config: Config{ChainParams: &Params{BCHeightBreakPointPDELimitOrder: 1000}}}
+	beaconHeight := uint64(1000)
+	poolPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, common.PRVIDStr, "tokenID1"))
+	s.currentPDEStateForProducer.PDEPoolPairs[poolPairKey] = rawdbv2.NewPDEPoolForPair(common.PRVIDStr, 1000000000000, "tokenID1", 1000000000000)
+	s.currentPDEStateForProcess.PDEPoolPairs[poolPairKey] = rawdbv2.NewPDEPoolForPair(common.PRVIDStr, 1000000000000, "tokenID1", 1000000000000)
+
+	orderA := common.HashH([]byte("orderA"))
+	orderB := common.HashH([]byte("orderB"))
+	orderC := common.HashH([]byte("orderC"))
+	orderD := common.HashH([]byte("orderD"))
+	orderActions := map[byte][][]string{
+		1: {
+			// (valid) crossed at the current price, matched in the same block
+			buildPDELimitOrderReqAction(common.PRVIDStr, "tokenID1", 1000000000, 1, 0, "trader1", orderA),
+			// (valid) rests until the price reaches its limit
+			buildPDELimitOrderReqAction(common.PRVIDStr, "tokenID1", 1000000000, 2000000000, 0, "trader1", orderB),
+			// (invalid) already expired
+			buildPDELimitOrderReqAction(common.PRVIDStr, "tokenID1", 1000000000, 1, 1001, "trader1", orderC),
+			// (valid) rests until beacon height 1002
+			buildPDELimitOrderReqAction("tokenID1", common.PRVIDStr, 1000000000, 2000000000, 1002, "trader2", orderD),
+		},
+	}
+	cancelActions := map[byte][][]string{
+		1: {
+			// (invalid) the order is not resting yet
+			buildPDECancelLimitOrderReqAction(orderB, "trader1", common.HashH([]byte("cancel1"))),
+		},
+	}
+	// the orders are refunded and the cancellations rejected before the limit orders are activated
+	newInsts, err := bc.handlePDELimitOrderInsts(beaconHeight-1, &s.currentPDEStateForProducer, orderActions, cancelActions)
+	s.Equal(nil, err)
+	s.Equal(5, len(newInsts))
+	s.Equal(common.PDECancelLimitOrderRejectedChainStatus, newInsts[0][2])
+	for _, inst := range newInsts[1:] {
+		s.Equal(common.PDELimitOrderRefundChainStatus, inst[2])
+	}
+	s.Equal(0, len(s.currentPDEStateForProducer.PDELimitOrders))
+
+	newInsts, err = bc.handlePDELimitOrderInsts(beaconHeight, &s.currentPDEStateForProducer, orderActions, cancelActions)
+	s.Equal(nil, err)
+	s.Equal(6, len(newInsts))
+	s.Equal(common.PDECancelLimitOrderRejectedChainStatus, newInsts[0][2])
+	s.Equal(common.PDELimitOrderRestingChainStatus, newInsts[1][2])
+	s.Equal(common.PDELimitOrderRestingChainStatus, newInsts[2][2])
+	s.Equal(common.PDELimitOrderRefundChainStatus, newInsts[3][2])
+	s.Equal(common.PDELimitOrderRestingChainStatus, newInsts[4][2])
+	s.Equal(common.PDELimitOrderMatchedChainStatus, newInsts[5][2])
+	var matchedOrder metadata.PDEMatchedLimitOrder
+	s.Equal(nil, json.Unmarshal([]byte(newInsts[5][3]), &matchedOrder))
+	s.Equal(orderA, matchedOrder.OrderID)
+	s.Equal(uint64(999000999), matchedOrder.ReceiveAmount)
+	s.Equal(2, len(s.currentPDEStateForProducer.PDELimitOrders))
+
+	processAllNewLimitOrderInsts(bc, newInsts, s.sdb, beaconHeight, &s.currentPDEStateForProcess)
+	s.Equal(s.currentPDEStateForProducer.PDELimitOrders, s.currentPDEStateForProcess.PDELimitOrders)
+	s.Equal(s.currentPDEStateForProducer.PDEPoolPairs, s.currentPDEStateForProcess.PDEPoolPairs)
+	status, err := statedb.GetPDEStatus(s.sdb, rawdbv2.PDELimitOrderStatusPrefix, orderA[:])
+	s.Equal(nil, err)
+	s.Equal(byte(common.PDELimitOrderMatchedStatus), status)
+	status, err = statedb.GetPDEStatus(s.sdb, rawdbv2.PDELimitOrderStatusPrefix, orderC[:])
+	s.Equal(nil, err)
+	s.Equal(byte(common.PDELimitOrderRefundStatus), status)
+
+	// resting orders are reloaded from db at the next beacon height
+	err = storePDEStateToDB(s.sdb, beaconHeight+1, &s.currentPDEStateForProcess)
+	s.Equal(nil, err)
+	_, err = s.sdb.Commit(true)
+	s.Equal(nil, err)
+	beaconHeight++
+	currentPDEState, err := InitCurrentPDEStateFromDB(s.sdb, beaconHeight)
+	s.Equal(nil, err)
+	s.Equal(2, len(currentPDEState.PDELimitOrders))
+
+	cancelActions = map[byte][][]string{
+		1: {
+			// (invalid) cancelled by another trader
+			buildPDECancelLimitOrderReqAction(orderB, "trader2", common.HashH([]byte("cancel2"))),
+			// (valid)
+			buildPDECancelLimitOrderReqAction(orderB, "trader1", common.HashH([]byte("cancel3"))),
+		},
+	}
+	newInsts, err = bc.handlePDELimitOrderInsts(beaconHeight, currentPDEState, nil, cancelActions)
+	s.Equal(nil, err)
+	s.Equal(3, len(newInsts))
+	s.Equal(common.PDECancelLimitOrderRejectedChainStatus, newInsts[0][2])
+	s.Equal(common.PDELimitOrderCancelledChainStatus, newInsts[1][2])
+	s.Equal(common.PDELimitOrderExpiredChainStatus, newInsts[2][2])
+	s.Equal(0, len(currentPDEState.PDELimitOrders))
+	s.Equal(2, len(currentPDEState.DeletedPDELimitOrders))
+
+	processState, err := InitCurrentPDEStateFromDB(s.sdb, beaconHeight)
+	s.Equal(nil, err)
+	processAllNewLimitOrderInsts(bc, newInsts, s.sdb, beaconHeight, processState)
+	s.Equal(currentPDEState.PDELimitOrders, processState.PDELimitOrders)
+	s.Equal(currentPDEState.DeletedPDELimitOrders, processState.DeletedPDELimitOrders)
+	status, err = statedb.GetPDEStatus(s.sdb, rawdbv2.PDELimitOrderStatusPrefix, orderB[:])
+	s.Equal(nil, err)
+	s.Equal(byte(common.PDELimitOrderCancelledStatus), status)
+	status, err = statedb.GetPDEStatus(s.sdb, rawdbv2.PDELimitOrderStatusPrefix, orderD[:])
+	s.Equal(nil, err)
+	s.Equal(byte(common.PDELimitOrderExpiredStatus), status)
+	cancelTxID := common.HashH([]byte("cancel2"))
+	status, err = statedb.GetPDEStatus(s.sdb, rawdbv2.PDECancelLimitOrderStatusPrefix, cancelTxID[:])
+	s.Equal(nil, err)
+	s.Equal(byte(common.PDECancelLimitOrderRejectedStatus), status)
+}
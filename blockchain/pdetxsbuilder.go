@@ -705,3 +705,68 @@ func (blockGenerator *BlockGenerator) buildPDEFeeWithdrawalTx(
 	}
 	return resTx, nil
 }
+
+func parseLimitOrderContent(
+	contentStr string,
+) (*metadata.PDEMatchedLimitOrder, error) {
+	contentBytes := []byte(contentStr)
+	// refunded, expired and cancelled orders leave the matching fields empty
+	var pdeLimitOrder metadata.PDEMatchedLimitOrder
+	err := json.Unmarshal(contentBytes, &pdeLimitOrder)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling pde limit order content: %+v", err)
+		return nil, err
+	}
+	return &pdeLimitOrder, nil
+}
+
+func (blockGenerator *BlockGenerator) buildPDELimitOrderResTx(
+	instStatus string,
+	contentStr string,
+	producerPrivateKey *privacy.PrivateKey,
+	shardID byte,
+	shardView *ShardBestState,
+	beaconView *BeaconBestState,
+) (metadata.Transaction, error) {
+	Logger.log.Info("[PDE Limit Order] Starting...")
+	if instStatus != common.PDELimitOrderMatchedChainStatus &&
+		instStatus != common.PDELimitOrderRefundChainStatus &&
+		instStatus != common.PDELimitOrderExpiredChainStatus &&
+		instStatus != common.PDELimitOrderCancelledChainStatus {
+		return nil, nil
+	}
+	limitOrderContent, err := parseLimitOrderContent(contentStr)
+	if err != nil {
+		return nil, nil
+	}
+	if shardID != limitOrderContent.ShardID {
+		return nil, nil
+	}
+	receiveAmt := limitOrderContent.SellAmount
+	receiveTokenIDStr := limitOrderContent.TokenIDToSellStr
+	if instStatus == common.PDELimitOrderMatchedChainStatus {
+		receiveAmt = limitOrderContent.ReceiveAmount
+		receiveTokenIDStr = limitOrderContent.TokenIDToBuyStr
+	}
+	meta := metadata.NewPDELimitOrderResponse(
+		instStatus,
+		limitOrderContent.OrderID,
+		metadata.PDELimitOrderResponseMeta,
+	)
+	resTx, err := buildTradeResTx(
+		limitOrderContent.TraderAddressStr,
+		receiveAmt,
+		receiveTokenIDStr,
+		producerPrivateKey,
+		shardID,
+		shardView.GetCopiedTransactionStateDB(),
+		beaconView.GetBeaconFeatureStateDB(),
+		meta,
+	)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while initializing limit order response tx: %+v", err)
+		return nil, nil
+	}
+	Logger.log.Infof("[PDE Limit Order] Create %s tx ok.", instStatus)
+	return resTx, nil
+}
@@ -18,6 +18,30 @@ type CurrentPDEState struct {
 	PDEPoolPairs                   map[string]*rawdbv2.PDEPoolForPair
 	PDEShares                      map[string]uint64
 	PDETradingFees                 map[string]uint64
+	PDEPoolPairsV3                 map[string]*rawdbv2.PDEPoolForPair
+	PDESharesV3                    map[string]uint64
+	PDELimitOrders                 map[string]*rawdbv2.PDELimitOrder
+	DeletedPDELimitOrders          map[string]*rawdbv2.PDELimitOrder
+}
+
+// initPDEStateV3 makes the maps of v3 pools which are not loaded from db
+func (s *CurrentPDEState) initPDEStateV3() {
+	if s.PDEPoolPairsV3 == nil {
+		s.PDEPoolPairsV3 = make(map[string]*rawdbv2.PDEPoolForPair)
+	}
+	if s.PDESharesV3 == nil {
+		s.PDESharesV3 = make(map[string]uint64)
+	}
+}
+
+// initPDELimitOrders makes the maps of limit orders which are not loaded from db
+func (s *CurrentPDEState) initPDELimitOrders() {
+	if s.PDELimitOrders == nil {
+		s.PDELimitOrders = make(map[string]*rawdbv2.PDELimitOrder)
+	}
+	if s.DeletedPDELimitOrders == nil {
+		s.DeletedPDELimitOrders = make(map[string]*rawdbv2.PDELimitOrder)
+	}
 }
 
 func (s *CurrentPDEState) Copy() *CurrentPDEState {
@@ -72,12 +96,28 @@ func InitCurrentPDEStateFromDB(
 	if err != nil {
 		return nil, err
 	}
+	pdePoolPairsV3, err := statedb.GetPDEPoolPairsV3(stateDB, beaconHeight)
+	if err != nil {
+		return nil, err
+	}
+	pdeSharesV3, err := statedb.GetPDESharesV3(stateDB, beaconHeight)
+	if err != nil {
+		return nil, err
+	}
+	pdeLimitOrders, err := statedb.GetPDELimitOrders(stateDB, beaconHeight)
+	if err != nil {
+		return nil, err
+	}
 	return &CurrentPDEState{
 		WaitingPDEContributions:        waitingPDEContributions,
 		PDEPoolPairs:                   pdePoolPairs,
 		PDEShares:                      pdeShares,
 		PDETradingFees:                 pdeTradingFees,
+		PDEPoolPairsV3:                 pdePoolPairsV3,
+		PDESharesV3:                    pdeSharesV3,
+		PDELimitOrders:                 pdeLimitOrders,
 		DeletedWaitingPDEContributions: make(map[string]*rawdbv2.PDEContribution),
+		DeletedPDELimitOrders:          make(map[string]*rawdbv2.PDELimitOrder),
 	}, nil
 }
 
@@ -104,6 +144,19 @@ func storePDEStateToDB(
 	if err != nil {
 		return err
 	}
+	err = statedb.StorePDEPoolPairsV3(stateDB, beaconHeight, currentPDEState.PDEPoolPairsV3)
+	if err != nil {
+		return err
+	}
+	err = statedb.StorePDESharesV3(stateDB, beaconHeight, currentPDEState.PDESharesV3)
+	if err != nil {
+		return err
+	}
+	statedb.DeletePDELimitOrders(stateDB, currentPDEState.DeletedPDELimitOrders)
+	err = statedb.StorePDELimitOrders(stateDB, beaconHeight, currentPDEState.PDELimitOrders)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -213,3 +266,105 @@ func updateWaitingContributionPairToPoolV2(
 		currentPDEState,
 	)
 }
+
+// updateWaitingContributionPairToPool adds a matched pair of contributions to
+// the v3 pool at their fee tier, or to the v2 pool when they have no fee tier
+func updateWaitingContributionPairToPool(
+	beaconHeight uint64,
+	waitingContribution1 *rawdbv2.PDEContribution,
+	waitingContribution2 *rawdbv2.PDEContribution,
+	currentPDEState *CurrentPDEState,
+) {
+	if waitingContribution1.FeeTier != 0 {
+		updateWaitingContributionPairToPoolV3(beaconHeight, waitingContribution1, waitingContribution2, currentPDEState)
+		return
+	}
+	updateWaitingContributionPairToPoolV2(beaconHeight, waitingContribution1, waitingContribution2, currentPDEState)
+}
+
+func addShareAmountUpV3(
+	beaconHeight uint64,
+	feeTier uint64,
+	token1IDStr string,
+	token2IDStr string,
+	contributedTokenIDStr string,
+	contributorAddrStr string,
+	amt uint64,
+	currentPDEState *CurrentPDEState,
+) {
+	pdeShareOnTokenPrefix := string(rawdbv2.BuildPDESharesKeyV3(beaconHeight, feeTier, token1IDStr, token2IDStr, ""))
+	totalSharesOnToken := uint64(0)
+	for key, value := range currentPDEState.PDESharesV3 {
+		if strings.Contains(key, pdeShareOnTokenPrefix) {
+			totalSharesOnToken += value
+		}
+	}
+	pdeShareKey := string(rawdbv2.BuildPDESharesKeyV3(beaconHeight, feeTier, token1IDStr, token2IDStr, contributorAddrStr))
+	if totalSharesOnToken == 0 {
+		currentPDEState.PDESharesV3[pdeShareKey] = amt
+		return
+	}
+	poolPairKey := string(rawdbv2.BuildPDEPoolForPairKeyV3(beaconHeight, feeTier, token1IDStr, token2IDStr))
+	poolPair, found := currentPDEState.PDEPoolPairsV3[poolPairKey]
+	if !found || poolPair == nil {
+		currentPDEState.PDESharesV3[pdeShareKey] = amt
+		return
+	}
+	poolValue := poolPair.Token1PoolValue
+	if poolPair.Token2IDStr == contributedTokenIDStr {
+		poolValue = poolPair.Token2PoolValue
+	}
+	if poolValue == 0 {
+		currentPDEState.PDESharesV3[pdeShareKey] = amt
+		return
+	}
+	increasingAmt := big.NewInt(0)
+	increasingAmt.Mul(new(big.Int).SetUint64(totalSharesOnToken), new(big.Int).SetUint64(amt))
+	increasingAmt.Div(increasingAmt, new(big.Int).SetUint64(poolValue))
+
+	currentShare, found := currentPDEState.PDESharesV3[pdeShareKey]
+	addedUpAmt := increasingAmt.Uint64()
+	if found {
+		addedUpAmt += currentShare
+	}
+	currentPDEState.PDESharesV3[pdeShareKey] = addedUpAmt
+}
+
+func updateWaitingContributionPairToPoolV3(
+	beaconHeight uint64,
+	waitingContribution1 *rawdbv2.PDEContribution,
+	waitingContribution2 *rawdbv2.PDEContribution,
+	currentPDEState *CurrentPDEState,
+) {
+	currentPDEState.initPDEStateV3()
+	feeTier := waitingContribution1.FeeTier
+	addShareAmountUpV3(
+		beaconHeight,
+		feeTier,
+		waitingContribution1.TokenIDStr,
+		waitingContribution2.TokenIDStr,
+		waitingContribution1.TokenIDStr,
+		waitingContribution1.ContributorAddressStr,
+		waitingContribution1.Amount,
+		currentPDEState,
+	)
+
+	waitingContributions := []*rawdbv2.PDEContribution{waitingContribution1, waitingContribution2}
+	sort.Slice(waitingContributions, func(i, j int) bool {
+		return waitingContributions[i].TokenIDStr < waitingContributions[j].TokenIDStr
+	})
+	pdePoolForPairKey := string(rawdbv2.BuildPDEPoolForPairKeyV3(beaconHeight, feeTier, waitingContributions[0].TokenIDStr, waitingContributions[1].TokenIDStr))
+	pdePoolForPair := &rawdbv2.PDEPoolForPair{
+		Token1IDStr:     waitingContributions[0].TokenIDStr,
+		Token1PoolValue: waitingContributions[0].Amount,
+		Token2IDStr:     waitingContributions[1].TokenIDStr,
+		Token2PoolValue: waitingContributions[1].Amount,
+		FeeTier:         feeTier,
+	}
+	existingPoolPair, found := currentPDEState.PDEPoolPairsV3[pdePoolForPairKey]
+	if found && existingPoolPair != nil {
+		pdePoolForPair.Token1PoolValue += existingPoolPair.Token1PoolValue
+		pdePoolForPair.Token2PoolValue += existingPoolPair.Token2PoolValue
+	}
+	currentPDEState.PDEPoolPairsV3[pdePoolForPairKey] = pdePoolForPair
+}
@@ -590,6 +590,7 @@ func (s *PDETestSuiteV2) TestSimulatedBeaconBlock1002() {
 		beaconHeight-1,
 		&s.currentPDEStateForProducer,
 		pdeTradeActionsByShardID,
+		false,
 	)
 
 	s.Equal(5, len(sortedTradableActions))
@@ -1096,6 +1097,119 @@ func (s *PDETestSuiteV2) TestSimulatedBeaconBlock1003() {
 	s.Equal(uint64(700000000000), s.currentPDEStateForProducer.PDEShares[shareKey4])
 }
 
+// test pools between two ptokens
+func (s *PDETestSuiteV2) TestPDETokenPools() {
+	fmt.Println("Running testcase: TestPDETokenPools")
+	bc := &BlockChain{config: Config{ChainParams: &Params{BCHeightBreakPointPDETokenPools: 1001}}}
+	shardID := byte(1)
+
+	// (invalid) ptoken pair before the check point
+	contribActions := map[byte][][]string{
+		shardID: {
+			buildPDEPRVRequiredContributionAction("pair-1", "contributorAddress1", 1000000000000, "tokenID1"),
+			buildPDEPRVRequiredContributionAction("pair-1", "contributorAddress1", 1000000000000, "tokenID2"),
+		},
+	}
+	newInsts, err := bc.handlePDEInsts(1000, &s.currentPDEStateForProducer, nil, contribActions, nil, nil, nil, nil)
+	s.Equal(nil, err)
+	s.Equal(3, len(newInsts))
+	s.Equal(common.PDEContributionRefundChainStatus, newInsts[1][2])
+	s.Equal(common.PDEContributionRefundChainStatus, newInsts[2][2])
+
+	// (valid) ptoken pair after the check point
+	beaconHeight := uint64(1001)
+	contribActions = map[byte][][]string{
+		shardID: {
+			buildPDEPRVRequiredContributionAction("pair-1", "contributorAddress1", 1000000000000, "tokenID1"),
+			buildPDEPRVRequiredContributionAction("pair-1", "contributorAddress1", 1000000000000, "tokenID2"),
+			buildPDEPRVRequiredContributionAction("pair-2", "contributorAddress1", 1000000000000, common.PRVIDStr),
+			buildPDEPRVRequiredContributionAction("pair-2", "contributorAddress1", 1000000000000, "tokenID1"),
+			buildPDEPRVRequiredContributionAction("pair-3", "contributorAddress1", 1000000000000, common.PRVIDStr),
+			buildPDEPRVRequiredContributionAction("pair-3", "contributorAddress1", 1000000000000, "tokenID2"),
+			buildPDEPRVRequiredContributionAction("pair-4", "contributorAddress1", 1000000000000, common.PRVIDStr),
+			buildPDEPRVRequiredContributionAction("pair-4", "contributorAddress1", 1000000000000, "tokenID3"),
+		},
+	}
+	newInsts, err = bc.handlePDEInsts(beaconHeight, &s.currentPDEStateForProducer, nil, contribActions, nil, nil, nil, nil)
+	s.Equal(nil, err)
+	s.Equal(8, len(newInsts))
+	s.Equal(common.PDEContributionMatchedChainStatus, newInsts[1][2])
+	s.Equal(4, len(s.currentPDEStateForProducer.PDEPoolPairs))
+	directPoolKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, "tokenID1", "tokenID2"))
+	s.Equal(uint64(1000000000000), s.currentPDEStateForProducer.PDEPoolPairs[directPoolKey].Token1PoolValue)
+	s.Equal(uint64(1000000000000), s.currentPDEStateForProducer.PDEPoolPairs[directPoolKey].Token2PoolValue)
+
+	s.Equal([][]string{
+		{"tokenID1", "tokenID2"},
+		{"tokenID1", common.PRVIDStr, "tokenID2"},
+	}, findPDETradePaths(beaconHeight, &s.currentPDEStateForProducer, "tokenID1", "tokenID2"))
+	s.Equal([][]string{
+		{"tokenID1", common.PRVIDStr, "tokenID3"},
+	}, findPDETradePaths(beaconHeight, &s.currentPDEStateForProducer, "tokenID1", "tokenID3"))
+
+	tradeActions := map[byte][][]string{
+		shardID: {
+			// (valid) the direct pool pays more than two hops via PRV
+			buildPDECrossPoolTradeReqAction("tokenID2", "tokenID1", 1000000000, 1, 100, "trader1"),
+			// (valid) two hops via PRV
+			buildPDECrossPoolTradeReqAction("tokenID3", "tokenID1", 1000000000, 1, 50, "trader1"),
+			// (invalid) there is no pool for the token
+			buildPDECrossPoolTradeReqAction("tokenID4", "tokenID1", 1000000000, 1, 10, "trader1"),
+		},
+	}
+	newInsts, err = bc.handlePDEInsts(beaconHeight, &s.currentPDEStateForProducer, nil, nil, nil, tradeActions, nil, nil)
+	s.Equal(nil, err)
+	s.Equal(5, len(newInsts))
+
+	directTrade := getPDECrossPoolTradeAcceptedContentFromInst(newInsts[0][3])
+	s.Equal(1, len(directTrade))
+	s.Equal("tokenID1", directTrade[0].Token1IDStr)
+	s.Equal("tokenID2", directTrade[0].Token2IDStr)
+	s.Equal(uint64(100), directTrade[0].AddingFee)
+	s.Equal(uint64(999000999), directTrade[0].ReceiveAmount)
+
+	crossPoolTrade := getPDECrossPoolTradeAcceptedContentFromInst(newInsts[1][3])
+	s.Equal(2, len(crossPoolTrade))
+	s.Equal(common.PRVIDStr, crossPoolTrade[0].TokenIDToBuyStr)
+	s.Equal("tokenID3", crossPoolTrade[1].TokenIDToBuyStr)
+
+	s.Equal(common.PDECrossPoolTradeFeeRefundChainStatus, newInsts[2][2])
+	s.Equal(common.PDECrossPoolTradeSellingTokenRefundChainStatus, newInsts[3][2])
+	s.Equal(strconv.Itoa(metadata.PDETradingFeesDistributionMeta), newInsts[4][0])
+}
+
+// test cross pool trades are sorted by their trading fees over their sell amounts in PRV
+func (s *PDETestSuiteV2) TestPDETokenPoolsSortingInPRV() {
+	fmt.Println("Running testcase: TestPDETokenPoolsSortingInPRV")
+	beaconHeight := uint64(1001)
+	addPool := func(token1IDStr string, token1PoolValue uint64, token2IDStr string, token2PoolValue uint64) {
+		poolPairKey := string(rawdbv2.BuildPDEPoolForPairKey(beaconHeight, token1IDStr, token2IDStr))
+		s.currentPDEStateForProducer.PDEPoolPairs[poolPairKey] = rawdbv2.NewPDEPoolForPair(token1IDStr, token1PoolValue, token2IDStr, token2PoolValue)
+	}
+	// a token1 is worth a tenth of a PRV, a token5 is only paired with token1
+	addPool(common.PRVIDStr, 100000000000, "tokenID1", 1000000000000)
+	addPool("tokenID1", 1000000000000, "tokenID5", 1000000000000)
+	// token6 and token7 are not paired with PRV at all
+	addPool("tokenID6", 1000000000000, "tokenID7", 1000000000000)
+
+	tradeActions := map[byte][][]string{
+		1: {
+			// 100 for 1000000000 PRV
+			buildPDECrossPoolTradeReqAction("tokenID1", common.PRVIDStr, 1000000000, 1, 100, "trader1"),
+			// 1000 for no PRV
+			buildPDECrossPoolTradeReqAction("tokenID6", "tokenID7", 1000000000, 1, 1000, "trader2"),
+			// 50 for about 100000000 PRV through token1
+			buildPDECrossPoolTradeReqAction("tokenID1", "tokenID5", 1000000000, 1, 50, "trader3"),
+		},
+	}
+	sortedTradableActions, untradableActions := categorizeNSortPDECrossPoolTradeInstsByFee(beaconHeight, &s.currentPDEStateForProducer, tradeActions, true)
+	s.Equal(0, len(untradableActions))
+	s.Equal(3, len(sortedTradableActions))
+	s.Equal("trader3", sortedTradableActions[0].Meta.TraderAddressStr)
+	s.Equal("trader1", sortedTradableActions[1].Meta.TraderAddressStr)
+	s.Equal("trader2", sortedTradableActions[2].Meta.TraderAddressStr)
+}
+
 func TestPDETestSuiteV2(t *testing.T) {
 	suite.Run(t, new(PDETestSuiteV2))
 }
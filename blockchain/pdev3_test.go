@@ -0,0 +1,328 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/suite"
+)
+
+type PDETestSuiteV3 struct {
+	suite.Suite
+	currentPDEStateForProducer CurrentPDEState
+	currentPDEStateForProcess  CurrentPDEState
+	sdb                        *statedb.StateDB
+}
+
+func (s *PDETestSuiteV3) SetupTest() {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_statedb_")
+	if err != nil {
+		panic(err)
+	}
+	diskBD, _ := incdb.Open("leveldb", dbPath)
+	warperDBStatedbTest := statedb.NewDatabaseAccessWarper(diskBD)
+	emptyRoot := common.HexToHash(common.HexEmptyRoot)
+	stateDB, _ := statedb.NewWithPrefixTrie(emptyRoot, warperDBStatedbTest)
+
+	s.sdb = stateDB
+	s.currentPDEStateForProducer = CurrentPDEState{
+		WaitingPDEContributions:        make(map[string]*rawdbv2.PDEContribution),
+		DeletedWaitingPDEContributions: make(map[string]*rawdbv2.PDEContribution),
+		PDEPoolPairs:                   make(map[string]*rawdbv2.PDEPoolForPair),
+		PDEShares:                      make(map[string]uint64),
+		PDETradingFees:                 make(map[string]uint64),
+	}
+	s.currentPDEStateForProcess = CurrentPDEState{
+		WaitingPDEContributions:        make(map[string]*rawdbv2.PDEContribution),
+		DeletedWaitingPDEContributions: make(map[string]*rawdbv2.PDEContribution),
+		PDEPoolPairs:                   make(map[string]*rawdbv2.PDEPoolForPair),
+		PDEShares:                      make(map[string]uint64),
+		PDETradingFees:                 make(map[string]uint64),
+	}
+}
+
+func buildPDEContributionV3Action(
+	pdeContributionPairID string,
+	contributorAddressStr string,
+	contributedAmount uint64,
+	tokenIDStr string,
+	feeTier uint64,
+) []string {
+	meta, _ := metadata.NewPDEContributionV3(pdeContributionPairID, contributorAddressStr, contributedAmount, tokenIDStr, feeTier, metadata.PDEContributionV3Meta)
+	actionContent := metadata.PDEContributionV3Action{
+		Meta:    *meta,
+		TxReqID: common.Hash{},
+	}
+	actionContentBytes, _ := json.Marshal(actionContent)
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	return []string{strconv.Itoa(metadata.PDEContributionV3Meta), actionContentBase64Str}
+}
+
+func buildPDETradeRequestV3Action(
+	tokenIDToBuyStr string,
+	tokenIDToSellStr string,
+	sellAmount uint64,
+	minAcceptableAmount uint64,
+	traderAddressStr string,
+) []string {
+	meta, _ := metadata.NewPDETradeRequestV3(tokenIDToBuyStr, tokenIDToSellStr, sellAmount, minAcceptableAmount, traderAddressStr, metadata.PDETradeRequestV3Meta)
+	actionContent := metadata.PDETradeRequestV3Action{
+		Meta:    *meta,
+		TxReqID: common.Hash{},
+	}
+	actionContentBytes, _ := json.Marshal(actionContent)
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	return []string{strconv.Itoa(metadata.PDETradeRequestV3Meta), actionContentBase64Str}
+}
+
+func buildPDEWithdrawalRequestV3Action(
+	withdrawerAddressStr string,
+	withdrawalToken1IDStr string,
+	withdrawalToken2IDStr string,
+	withdrawalShareAmt uint64,
+	feeTier uint64,
+) []string {
+	meta, _ := metadata.NewPDEWithdrawalRequestV3(withdrawerAddressStr, withdrawalToken1IDStr, withdrawalToken2IDStr, withdrawalShareAmt, feeTier, metadata.PDEWithdrawalRequestV3Meta)
+	actionContent := metadata.PDEWithdrawalRequestV3Action{
+		Meta:    *meta,
+		TxReqID: common.Hash{},
+	}
+	actionContentBytes, _ := json.Marshal(actionContent)
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	return []string{strconv.Itoa(metadata.PDEWithdrawalRequestV3Meta), actionContentBase64Str}
+}
+
+func processAllNewInstsV3(
+	blockchain *BlockChain,
+	insts [][]string,
+	pdexStateDB *statedb.StateDB,
+	beaconHeight uint64,
+	currentPDEState *CurrentPDEState,
+) {
+	for _, inst := range insts {
+		if len(inst) < 2 {
+			continue // Not error, just not PDE instruction
+		}
+		var err error
+		switch inst[0] {
+		case strconv.Itoa(metadata.PDEPRVRequiredContributionRequestMeta):
+			err = blockchain.processPDEContributionV2(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDEContributionV3Meta):
+			err = blockchain.processPDEContributionV2(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDETradeRequestV3Meta):
+			err = blockchain.processPDETradeV3(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDEWithdrawalRequestV3Meta):
+			err = blockchain.processPDEWithdrawalV3(pdexStateDB, beaconHeight, inst, currentPDEState)
+		case strconv.Itoa(metadata.PDETradingFeesDistributionV3Meta):
+			err = blockchain.processPDETradingFeesDistributionV3(pdexStateDB, beaconHeight, inst, currentPDEState)
+		}
+		if err != nil {
+			fmt.Printf("An error occured while process instruction: %v\n", inst)
+		}
+	}
+}
+
+// test contribution to fee tiers
+func (s *PDETestSuiteV3) TestContributionV3() {
+	bc := &BlockChain{config: Config{ChainParams: &Params{BCHeightBreakPointPDEV3: 1001}}}
+	shardID := byte(1)
+	beaconHeight := uint64(1001)
+
+	pdeContributionV3Actions := map[byte][][]string{
+		shardID: {
+			// (valid) both sides at the same fee tier
+			buildPDEContributionV3Action("pair-1", "contributorAddress1", 1000000000000, common.PRVIDStr, 30),
+			buildPDEContributionV3Action("pair-1", "contributorAddress1", 2000000000000, "tokenID1", 30),
+			// (invalid) sides at different fee tiers
+			buildPDEContributionV3Action("pair-2", "contributorAddress1", 1000000000000, common.PRVIDStr, 5),
+			buildPDEContributionV3Action("pair-2", "contributorAddress1", 2000000000000, "tokenID1", 100),
+			// (invalid) no PRV side
+			buildPDEContributionV3Action("pair-3", "contributorAddress1", 1000000000000, "tokenID1", 30),
+			buildPDEContributionV3Action("pair-3", "contributorAddress1", 2000000000000, "tokenID2", 30),
+		},
+	}
+	newInsts, err := bc.handlePDEV3Insts(beaconHeight, &s.currentPDEStateForProducer, pdeContributionV3Actions, nil, nil)
+	s.Equal(nil, err)
+	s.Equal(8, len(newInsts))
+	s.Equal(common.PDEContributionWaitingChainStatus, newInsts[0][2])
+	s.Equal(common.PDEContributionMatchedChainStatus, newInsts[1][2])
+	for _, inst := range newInsts[2:] {
+		if inst[2] == common.PDEContributionWaitingChainStatus {
+			continue
+		}
+		s.Equal(common.PDEContributionRefundChainStatus, inst[2])
+	}
+	processAllNewInstsV3(bc, newInsts, s.sdb, beaconHeight, &s.currentPDEStateForProcess)
+
+	poolKey := string(rawdbv2.BuildPDEPoolForPairKeyV3(beaconHeight, 30, common.PRVIDStr, "tokenID1"))
+	shareKey := string(rawdbv2.BuildPDESharesKeyV3(beaconHeight, 30, common.PRVIDStr, "tokenID1", "contributorAddress1"))
+	for _, state := range []CurrentPDEState{s.currentPDEStateForProducer, s.currentPDEStateForProcess} {
+		s.Equal(1, len(state.PDEPoolPairsV3))
+		s.Equal(uint64(30), state.PDEPoolPairsV3[poolKey].FeeTier)
+		s.Equal(uint64(1000000000000), state.PDEPoolPairsV3[poolKey].Token1PoolValue)
+		s.Equal(uint64(2000000000000), state.PDEPoolPairsV3[poolKey].Token2PoolValue)
+		s.Equal(uint64(1000000000000), state.PDESharesV3[shareKey])
+		s.Equal(0, len(state.PDEPoolPairs))
+		s.Equal(0, len(state.WaitingPDEContributions))
+	}
+}
+
+// test a v3 waiting contribution can not be matched by a v2 one
+func (s *PDETestSuiteV3) TestContributionV3MismatchV2() {
+	bc := &BlockChain{config: Config{ChainParams: &Params{BCHeightBreakPointPDEV3: 1001}}}
+	shardID := byte(1)
+	beaconHeight := uint64(1001)
+
+	newInsts, err := bc.handlePDEV3Insts(beaconHeight, &s.currentPDEStateForProducer, map[byte][][]string{
+		shardID: {buildPDEContributionV3Action("pair-1", "contributorAddress1", 1000000000000, common.PRVIDStr, 30)},
+	}, nil, nil)
+	s.Equal(nil, err)
+	s.Equal(1, len(newInsts))
+
+	v2Action := buildPDEPRVRequiredContributionAction("pair-1", "contributorAddress1", 2000000000000, "tokenID1")
+	v2Insts, err := bc.buildInstructionsForPDEContribution(v2Action[1], shardID, metadata.PDEPRVRequiredContributionRequestMeta, &s.currentPDEStateForProducer, beaconHeight, true)
+	s.Equal(nil, err)
+	s.Equal(2, len(v2Insts))
+	s.Equal(common.PDEContributionRefundChainStatus, v2Insts[0][2])
+	s.Equal(common.PDEContributionRefundChainStatus, v2Insts[1][2])
+	s.Equal(0, len(s.currentPDEStateForProducer.WaitingPDEContributions))
+	s.Equal(0, len(s.currentPDEStateForProducer.PDEPoolPairs))
+	s.Equal(0, len(s.currentPDEStateForProducer.PDEPoolPairsV3))
+}
+
+// test trades are routed to the fee tier paying the most and the fee goes to the pool
+func (s *PDETestSuiteV3) TestTradeV3() {
+	bc := &BlockChain{config: Config{ChainParams: &Params{BCHeightBreakPointPDEV3: 1001}}}
+	shardID := byte(1)
+	beaconHeight := uint64(1001)
+
+	newInsts, err := bc.handlePDEV3Insts(beaconHeight, &s.currentPDEStateForProducer, map[byte][][]string{
+		shardID: {
+			buildPDEContributionV3Action("pair-1", "contributorAddress1", 1000000000, common.PRVIDStr, 5),
+			buildPDEContributionV3Action("pair-1", "contributorAddress1", 1000000000, "tokenID1", 5),
+			buildPDEContributionV3Action("pair-2", "contributorAddress1", 1000000000000, common.PRVIDStr, 100),
+			buildPDEContributionV3Action("pair-2", "contributorAddress1", 1000000000000, "tokenID1", 100),
+		},
+	}, nil, nil)
+	s.Equal(nil, err)
+	processAllNewInstsV3(bc, newInsts, s.sdb, beaconHeight, &s.currentPDEStateForProcess)
+
+	newInsts, err = bc.handlePDEV3Insts(beaconHeight, &s.currentPDEStateForProducer, nil, map[byte][][]string{
+		shardID: {
+			// (valid) the deeper pool pays more in spite of the higher fee
+			buildPDETradeRequestV3Action("tokenID1", common.PRVIDStr, 100000000, 1, "traderAddress1"),
+			// (invalid) min acceptable amount can not be reached
+			buildPDETradeRequestV3Action("tokenID1", common.PRVIDStr, 100000000, 100000000, "traderAddress1"),
+			// (invalid) there is no pool for the token
+			buildPDETradeRequestV3Action("tokenID1", "tokenID2", 100000000, 1, "traderAddress1"),
+		},
+	}, nil)
+	s.Equal(nil, err)
+	s.Equal(4, len(newInsts))
+	s.Equal(common.PDECrossPoolTradeAcceptedChainStatus, newInsts[0][2])
+	s.Equal(common.PDECrossPoolTradeSellingTokenRefundChainStatus, newInsts[1][2])
+	s.Equal(common.PDECrossPoolTradeSellingTokenRefundChainStatus, newInsts[2][2])
+	s.Equal(strconv.Itoa(metadata.PDETradingFeesDistributionV3Meta), newInsts[3][0])
+
+	acceptedContents := getPDECrossPoolTradeAcceptedContentFromInst(newInsts[0][3])
+	s.Equal(1, len(acceptedContents))
+	s.Equal(uint64(100), acceptedContents[0].FeeTier)
+	s.Equal(uint64(1000000), acceptedContents[0].AddingFee)
+	processAllNewInstsV3(bc, newInsts, s.sdb, beaconHeight, &s.currentPDEStateForProcess)
+
+	poolKey := string(rawdbv2.BuildPDEPoolForPairKeyV3(beaconHeight, 100, common.PRVIDStr, "tokenID1"))
+	lowFeePoolKey := string(rawdbv2.BuildPDEPoolForPairKeyV3(beaconHeight, 5, common.PRVIDStr, "tokenID1"))
+	for _, state := range []CurrentPDEState{s.currentPDEStateForProducer, s.currentPDEStateForProcess} {
+		s.Equal(uint64(1000000000000+100000000), state.PDEPoolPairsV3[poolKey].Token1PoolValue)
+		s.Equal(uint64(1000000000000)-acceptedContents[0].ReceiveAmount, state.PDEPoolPairsV3[poolKey].Token2PoolValue)
+		s.Equal(uint64(1000000000), state.PDEPoolPairsV3[lowFeePoolKey].Token1PoolValue)
+		s.Equal(uint64(1000000000), state.PDEPoolPairsV3[lowFeePoolKey].Token2PoolValue)
+	}
+}
+
+// test withdrawal from a fee tier
+func (s *PDETestSuiteV3) TestWithdrawalV3() {
+	bc := &BlockChain{config: Config{ChainParams: &Params{BCHeightBreakPointPDEV3: 1001}}}
+	shardID := byte(1)
+	beaconHeight := uint64(1001)
+
+	newInsts, err := bc.handlePDEV3Insts(beaconHeight, &s.currentPDEStateForProducer, map[byte][][]string{
+		shardID: {
+			buildPDEContributionV3Action("pair-1", "contributorAddress1", 1000000000000, common.PRVIDStr, 30),
+			buildPDEContributionV3Action("pair-1", "contributorAddress1", 2000000000000, "tokenID1", 30),
+		},
+	}, nil, nil)
+	s.Equal(nil, err)
+	processAllNewInstsV3(bc, newInsts, s.sdb, beaconHeight, &s.currentPDEStateForProcess)
+
+	newInsts, err = bc.handlePDEV3Insts(beaconHeight, &s.currentPDEStateForProducer, nil, nil, map[byte][][]string{
+		shardID: {
+			// (valid) withdraw a quarter of the shares
+			buildPDEWithdrawalRequestV3Action("contributorAddress1", common.PRVIDStr, "tokenID1", 250000000000, 30),
+			// (invalid) there is no pool at the fee tier
+			buildPDEWithdrawalRequestV3Action("contributorAddress1", common.PRVIDStr, "tokenID1", 250000000000, 5),
+		},
+	})
+	s.Equal(nil, err)
+	s.Equal(3, len(newInsts))
+	s.Equal(common.PDEWithdrawalAcceptedChainStatus, newInsts[0][2])
+	s.Equal(common.PDEWithdrawalAcceptedChainStatus, newInsts[1][2])
+	s.Equal(common.PDEWithdrawalRejectedChainStatus, newInsts[2][2])
+
+	wdContent := getPDEWithdrawAcceptedContentFromInst(newInsts[0][3])
+	s.Equal(uint64(30), wdContent.FeeTier)
+	s.Equal(uint64(250000000000), wdContent.DeductingPoolValue)
+	processAllNewInstsV3(bc, newInsts, s.sdb, beaconHeight, &s.currentPDEStateForProcess)
+
+	poolKey := string(rawdbv2.BuildPDEPoolForPairKeyV3(beaconHeight, 30, common.PRVIDStr, "tokenID1"))
+	shareKey := string(rawdbv2.BuildPDESharesKeyV3(beaconHeight, 30, common.PRVIDStr, "tokenID1", "contributorAddress1"))
+	for _, state := range []CurrentPDEState{s.currentPDEStateForProducer, s.currentPDEStateForProcess} {
+		s.Equal(uint64(750000000000), state.PDEPoolPairsV3[poolKey].Token1PoolValue)
+		s.Equal(uint64(1500000000000), state.PDEPoolPairsV3[poolKey].Token2PoolValue)
+		s.Equal(uint64(750000000000), state.PDESharesV3[shareKey])
+	}
+}
+
+// test the v3 requests are refunded before the v3 pools are activated
+func (s *PDETestSuiteV3) TestInactiveV3() {
+	bc := &BlockChain{config: Config{ChainParams: &Params{BCHeightBreakPointPDEV3: 1001}}}
+	shardID := byte(1)
+	beaconHeight := uint64(1000)
+
+	newInsts, err := bc.handlePDEV3Insts(beaconHeight, &s.currentPDEStateForProducer, map[byte][][]string{
+		shardID: {
+			buildPDEContributionV3Action("pair-1", "contributorAddress1", 1000000000000, common.PRVIDStr, 30),
+			buildPDEContributionV3Action("pair-1", "contributorAddress1", 2000000000000, "tokenID1", 30),
+		},
+	}, map[byte][][]string{
+		shardID: {buildPDETradeRequestV3Action("tokenID1", common.PRVIDStr, 1000000000, 1, "traderAddress1")},
+	}, map[byte][][]string{
+		shardID: {buildPDEWithdrawalRequestV3Action("contributorAddress1", common.PRVIDStr, "tokenID1", 250000000000, 30)},
+	})
+	s.Equal(nil, err)
+	s.Equal(4, len(newInsts))
+	s.Equal(common.PDECrossPoolTradeSellingTokenRefundChainStatus, newInsts[0][2])
+	s.Equal(common.PDEWithdrawalRejectedChainStatus, newInsts[1][2])
+	s.Equal(common.PDEContributionRefundChainStatus, newInsts[2][2])
+	s.Equal(common.PDEContributionRefundChainStatus, newInsts[3][2])
+	var refundContribution metadata.PDERefundContribution
+	s.Equal(nil, json.Unmarshal([]byte(newInsts[3][3]), &refundContribution))
+	s.Equal(uint64(2000000000000), refundContribution.ContributedAmount)
+	s.Equal("tokenID1", refundContribution.TokenIDStr)
+	s.Equal(0, len(s.currentPDEStateForProducer.WaitingPDEContributions))
+	s.Equal(0, len(s.currentPDEStateForProducer.PDEPoolPairsV3))
+}
+
+func TestPDETestSuiteV3(t *testing.T) {
+	suite.Run(t, new(PDETestSuiteV3))
+}
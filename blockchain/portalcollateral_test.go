@@ -0,0 +1,134 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCollateralTestPortalState(custodians ...*statedb.CustodianState) *CurrentPortalState {
+	custodianPoolState := make(map[string]*statedb.CustodianState)
+	for _, cus := range custodians {
+		custodianPoolState[statedb.GenerateCustodianStateObjectKey(cus.GetIncognitoAddress()).String()] = cus
+	}
+	return &CurrentPortalState{
+		CustodianPoolState:     custodianPoolState,
+		WaitingPortingRequests: make(map[string]*statedb.WaitingPortingRequest),
+		WaitingRedeemRequests:  make(map[string]*statedb.RedeemRequest),
+		MatchedRedeemRequests:  make(map[string]*statedb.RedeemRequest),
+		FinalExchangeRatesState: statedb.NewFinalExchangeRatesStateWithValue(map[string]statedb.FinalExchangeRatesDetail{
+			common.PRVIDStr:       {Amount: 1},
+			common.PortalBTCIDStr: {Amount: 1000},
+			common.PortalBNBIDStr: {Amount: 100},
+		}),
+		TransferObligationIDs: make(map[string]bool),
+	}
+}
+
+// newCollateralTestCustodian holds 10 pBTC and 100 pBNB, worth 10000 PRV each
+func newCollateralTestCustodian(incAddress string, lockedBTC uint64, lockedBNB uint64) *statedb.CustodianState {
+	return statedb.NewCustodianStateWithValue(
+		incAddress,
+		100000,
+		100000-lockedBTC-lockedBNB,
+		map[string]uint64{common.PortalBTCIDStr: 10, common.PortalBNBIDStr: 100},
+		map[string]uint64{common.PortalBTCIDStr: lockedBTC, common.PortalBNBIDStr: lockedBNB},
+		map[string]string{common.PortalBTCIDStr: "btcAddress", common.PortalBNBIDStr: "bnbAddress"},
+		nil,
+	)
+}
+
+func buildPortalRebalanceCollateralAction(custodianAddress string, fromTokenID string, toTokenID string, amount uint64) string {
+	meta, _ := metadata.NewPortalRebalanceCollateral(metadata.PortalRebalanceCollateralMeta, custodianAddress, fromTokenID, toTokenID, amount)
+	actionContentBytes, _ := json.Marshal(metadata.PortalRebalanceCollateralAction{Meta: *meta, TxReqID: common.Hash{1}, ShardID: 0})
+	return base64.StdEncoding.EncodeToString(actionContentBytes)
+}
+
+func TestBlockChain_buildInstructionsForRebalanceCollateral(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	bc := &BlockChain{}
+	portalParams := PortalParams{TP120: 120, TP130: 130, MinPercentLockedCollateral: 150}
+	currentPortalState := newCollateralTestPortalState(newCollateralTestCustodian("custodian1", 15000, 20000))
+	custodianKey := statedb.GenerateCustodianStateObjectKey("custodian1").String()
+
+	insts, err := bc.buildInstructionsForRebalanceCollateral(buildPortalRebalanceCollateralAction("custodian1", common.PortalBNBIDStr, common.PortalBTCIDStr, 5000), 0, metadata.PortalRebalanceCollateralMeta, currentPortalState, 1, portalParams)
+	assert.Nil(t, err)
+	assert.Equal(t, common.PortalRebalanceCollateralAcceptedChainStatus, insts[0][2])
+	lockedAmount := currentPortalState.CustodianPoolState[custodianKey].GetLockedAmountCollateral()
+	assert.Equal(t, uint64(20000), lockedAmount[common.PortalBTCIDStr])
+	assert.Equal(t, uint64(15000), lockedAmount[common.PortalBNBIDStr])
+	assert.Equal(t, uint64(65000), currentPortalState.CustodianPoolState[custodianKey].GetFreeCollateral())
+
+	//pBNB would be down to TP130
+	insts, err = bc.buildInstructionsForRebalanceCollateral(buildPortalRebalanceCollateralAction("custodian1", common.PortalBNBIDStr, common.PortalBTCIDStr, 2000), 0, metadata.PortalRebalanceCollateralMeta, currentPortalState, 1, portalParams)
+	assert.Nil(t, err)
+	assert.Equal(t, common.PortalRebalanceCollateralRejectedChainStatus, insts[0][2])
+	assert.Equal(t, uint64(15000), currentPortalState.CustodianPoolState[custodianKey].GetLockedAmountCollateral()[common.PortalBNBIDStr])
+
+	//the collateral locked for a waiting porting request can not be moved
+	currentPortalState.WaitingPortingRequests["porting1"] = statedb.NewWaitingPortingRequestWithValue(
+		"porting1", common.Hash{}, common.PortalBTCIDStr, "porter", 5, []*statedb.MatchingPortingCustodianDetail{
+			{IncAddress: "custodian1", Amount: 5, LockedAmountCollateral: 7500},
+		}, 0, 1)
+	lockedAmount[common.PortalBTCIDStr] += 7500
+	insts, err = bc.buildInstructionsForRebalanceCollateral(buildPortalRebalanceCollateralAction("custodian1", common.PortalBTCIDStr, common.PortalBNBIDStr, 7501), 0, metadata.PortalRebalanceCollateralMeta, currentPortalState, 1, portalParams)
+	assert.Nil(t, err)
+	assert.Equal(t, common.PortalRebalanceCollateralRejectedChainStatus, insts[0][2])
+	insts, err = bc.buildInstructionsForRebalanceCollateral(buildPortalRebalanceCollateralAction("custodian1", common.PortalBTCIDStr, common.PortalBNBIDStr, 5000), 0, metadata.PortalRebalanceCollateralMeta, currentPortalState, 1, portalParams)
+	assert.Nil(t, err)
+	assert.Equal(t, common.PortalRebalanceCollateralAcceptedChainStatus, insts[0][2])
+
+	//a custodian waiting for the liquidation can not rebalance its collateral
+	currentPortalState = newCollateralTestPortalState(newCollateralTestCustodian("custodian2", 11000, 40000))
+	insts, err = bc.buildInstructionsForRebalanceCollateral(buildPortalRebalanceCollateralAction("custodian2", common.PortalBNBIDStr, common.PortalBTCIDStr, 10000), 0, metadata.PortalRebalanceCollateralMeta, currentPortalState, 1, portalParams)
+	assert.Nil(t, err)
+	assert.Equal(t, common.PortalRebalanceCollateralRejectedChainStatus, insts[0][2])
+
+	//but it can between TP120 and TP130
+	currentPortalState = newCollateralTestPortalState(newCollateralTestCustodian("custodian3", 12500, 40000))
+	insts, err = bc.buildInstructionsForRebalanceCollateral(buildPortalRebalanceCollateralAction("custodian3", common.PortalBNBIDStr, common.PortalBTCIDStr, 10000), 0, metadata.PortalRebalanceCollateralMeta, currentPortalState, 1, portalParams)
+	assert.Nil(t, err)
+	assert.Equal(t, common.PortalRebalanceCollateralAcceptedChainStatus, insts[0][2])
+}
+
+func TestUpdateCustodianStatesAfterTransferObligation(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	portalParams := PortalParams{TP120: 120, TP130: 130, MinPercentLockedCollateral: 150}
+	sender := newCollateralTestCustodian("sender", 15000, 20000)
+	receiver := statedb.NewCustodianStateWithValue("receiver", 20000, 20000, nil, nil, map[string]string{common.PortalBTCIDStr: "btcAddress"}, nil)
+	currentPortalState := newCollateralTestPortalState(sender, receiver)
+
+	unlockAmount, err := CalUnlockCollateralAmount(currentPortalState, statedb.GenerateCustodianStateObjectKey("sender").String(), 4, common.PortalBTCIDStr)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(6000), unlockAmount)
+
+	newSender := cloneCustodianState(sender)
+	newReceiver := cloneCustodianState(receiver)
+	assert.Nil(t, updateCustodianStatesAfterTransferObligation(newSender, newReceiver, common.PortalBTCIDStr, 4, unlockAmount, 6000))
+	assert.Equal(t, uint64(6), newSender.GetHoldingPublicTokens()[common.PortalBTCIDStr])
+	assert.Equal(t, uint64(9000), newSender.GetLockedAmountCollateral()[common.PortalBTCIDStr])
+	assert.Equal(t, uint64(71000), newSender.GetFreeCollateral())
+	assert.Equal(t, uint64(4), newReceiver.GetHoldingPublicTokens()[common.PortalBTCIDStr])
+	assert.Equal(t, uint64(6000), newReceiver.GetLockedAmountCollateral()[common.PortalBTCIDStr])
+	assert.Equal(t, uint64(14000), newReceiver.GetFreeCollateral())
+	//the states in the pool are unchanged
+	assert.Equal(t, uint64(10), sender.GetHoldingPublicTokens()[common.PortalBTCIDStr])
+	assert.Nil(t, receiver.GetHoldingPublicTokens())
+
+	assert.Nil(t, checkTPRatioBeforeAndAfterChangingCollateral(currentPortalState, sender, newSender, currentPortalState.FinalExchangeRatesState, portalParams))
+	assert.Nil(t, checkTPRatioBeforeAndAfterChangingCollateral(currentPortalState, receiver, newReceiver, currentPortalState.FinalExchangeRatesState, portalParams))
+
+	//the receiver can not take over the obligation with a lower collateral
+	newReceiver = cloneCustodianState(receiver)
+	assert.Nil(t, updateCustodianStatesAfterTransferObligation(cloneCustodianState(sender), newReceiver, common.PortalBTCIDStr, 4, unlockAmount, 5000))
+	assert.NotNil(t, checkTPRatioBeforeAndAfterChangingCollateral(currentPortalState, receiver, newReceiver, currentPortalState.FinalExchangeRatesState, portalParams))
+
+	//not enough holding public token or free collateral
+	assert.NotNil(t, updateCustodianStatesAfterTransferObligation(cloneCustodianState(sender), cloneCustodianState(receiver), common.PortalBTCIDStr, 11, 15000, 6000))
+	assert.NotNil(t, updateCustodianStatesAfterTransferObligation(cloneCustodianState(sender), cloneCustodianState(receiver), common.PortalBTCIDStr, 4, unlockAmount, 20001))
+}
@@ -0,0 +1,129 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalcExchangeRatesMedian(t *testing.T) {
+	//not enough feeds
+	_, ok := calcExchangeRatesMedian([]uint64{100, 101}, 3, 10)
+	assert.False(t, ok)
+
+	//one outlier cannot move the median
+	rate, ok := calcExchangeRatesMedian([]uint64{100, 5, 104, 102, 1000}, 3, 10)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(102), rate)
+
+	//too many outliers
+	_, ok = calcExchangeRatesMedian([]uint64{100, 5, 1000}, 2, 10)
+	assert.False(t, ok)
+
+	//outliers are kept without deviation limit
+	rate, ok = calcExchangeRatesMedian([]uint64{100, 5, 1000}, 1, 0)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(100), rate)
+
+	//huge rates do not overflow
+	rate, ok = calcExchangeRatesMedian([]uint64{math.MaxUint64, math.MaxUint64 - 2}, 2, 10)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(math.MaxUint64-1), rate)
+}
+
+func newOracleTestPortalState(feeders []string) *CurrentPortalState {
+	return &CurrentPortalState{
+		FinalExchangeRatesState: statedb.NewFinalExchangeRatesStateWithValue(map[string]statedb.FinalExchangeRatesDetail{
+			common.PortalBTCIDStr: {Amount: 90},
+		}),
+		ExchangeRatesRequests: make(map[string]*metadata.ExchangeRatesRequestStatus),
+		Feeders:               feeders,
+	}
+}
+
+func TestBlockChain_aggregateExchangeRatesFeeds(t *testing.T) {
+	bc := &BlockChain{config: Config{ChainParams: &Params{PortalFeederAddresses: []string{"feeder1"}}}}
+	portalParams := PortalParams{
+		ExchangeRatesWindow:              10,
+		MinExchangeRatesFeeds:            2,
+		MaxExchangeRatesFeedAge:          15,
+		MaxPercentExchangeRatesDeviation: 10,
+	}
+	currentPortalState := newOracleTestPortalState([]string{"feeder1", "feeder2", "feeder3", "feeder4", "feeder5"})
+	btcRate := func(rate uint64) []*metadata.ExchangeRateInfo {
+		return []*metadata.ExchangeRateInfo{{PTokenID: common.PortalBTCIDStr, Rate: rate}}
+	}
+	recordExchangeRatesFeeds(currentPortalState, "feeder5", btcRate(95), 1) //stale at height 20
+	recordExchangeRatesFeeds(currentPortalState, "feeder1", btcRate(100), 12)
+	recordExchangeRatesFeeds(currentPortalState, "feeder4", btcRate(102), 14)
+	recordExchangeRatesFeeds(currentPortalState, "feeder2", btcRate(104), 15)
+	recordExchangeRatesFeeds(currentPortalState, "feeder3", btcRate(1000), 18)
+	recordExchangeRatesFeeds(currentPortalState, "removed", btcRate(106), 18)
+
+	//the rates are only computed at the end of the window
+	bc.aggregateExchangeRatesFeeds(19, currentPortalState, portalParams)
+	assert.Equal(t, uint64(90), currentPortalState.FinalExchangeRatesState.Rates()[common.PortalBTCIDStr].Amount)
+	assert.Equal(t, 6, len(currentPortalState.FinalExchangeRatesState.Feeds()[common.PortalBTCIDStr]))
+
+	bc.aggregateExchangeRatesFeeds(20, currentPortalState, portalParams)
+	assert.Equal(t, uint64(102), currentPortalState.FinalExchangeRatesState.Rates()[common.PortalBTCIDStr].Amount)
+	feeds := currentPortalState.FinalExchangeRatesState.Feeds()[common.PortalBTCIDStr]
+	assert.Equal(t, 4, len(feeds))
+	assert.Equal(t, statedb.ExchangeRatesFeed{Rate: 1000, BeaconHeight: 18}, feeds["feeder3"])
+
+	//the previous rate is kept when less than a majority of the feeders have fresh feeds
+	bc.aggregateExchangeRatesFeeds(30, currentPortalState, portalParams)
+	assert.Equal(t, uint64(102), currentPortalState.FinalExchangeRatesState.Rates()[common.PortalBTCIDStr].Amount)
+	assert.Equal(t, 2, len(currentPortalState.FinalExchangeRatesState.Feeds()[common.PortalBTCIDStr]))
+}
+
+func buildPortalUpdateFeedersAction(governorAddress string, feeders []string, txReqID common.Hash) string {
+	meta, _ := metadata.NewPortalUpdateFeeders(metadata.PortalUpdateFeedersMeta, governorAddress, feeders)
+	actionContentBytes, _ := json.Marshal(metadata.PortalUpdateFeedersAction{Meta: *meta, TxReqID: txReqID, ShardID: 0})
+	return base64.StdEncoding.EncodeToString(actionContentBytes)
+}
+
+func buildPortalExchangeRatesAction(senderAddress string, rate uint64, txReqID common.Hash) string {
+	meta, _ := metadata.NewPortalExchangeRates(metadata.PortalExchangeRatesMeta, senderAddress, []*metadata.ExchangeRateInfo{{PTokenID: common.PortalBTCIDStr, Rate: rate}})
+	actionContentBytes, _ := json.Marshal(metadata.PortalExchangeRatesAction{Meta: *meta, TxReqID: txReqID, ShardID: 0})
+	return base64.StdEncoding.EncodeToString(actionContentBytes)
+}
+
+func TestBlockChain_buildInstructionsForUpdateFeeders(t *testing.T) {
+	bc := &BlockChain{config: Config{ChainParams: &Params{
+		PortalFeederAddresses:       []string{"feeder1"},
+		PortalFeederGovernorAddress: "governor",
+	}}}
+	currentPortalState := newOracleTestPortalState(nil)
+	portalParams := PortalParams{}
+
+	insts, err := bc.buildInstructionsForExchangeRates(buildPortalExchangeRatesAction("feeder1", 100, common.Hash{1}), 0, metadata.PortalExchangeRatesMeta, currentPortalState, 1, portalParams)
+	assert.Nil(t, err)
+	assert.Equal(t, common.PortalExchangeRatesAcceptedChainStatus, insts[0][2])
+
+	//only the governor updates the feeders
+	insts, err = bc.buildInstructionsForUpdateFeeders(buildPortalUpdateFeedersAction("feeder1", []string{"feeder1", "feeder2"}, common.Hash{2}), 0, metadata.PortalUpdateFeedersMeta, currentPortalState, 1, portalParams)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{strconv.Itoa(metadata.PortalUpdateFeedersMeta), "0", common.PortalUpdateFeedersRejectedChainStatus}, insts[0][:3])
+	assert.Nil(t, currentPortalState.Feeders)
+
+	insts, err = bc.buildInstructionsForUpdateFeeders(buildPortalUpdateFeedersAction("governor", []string{"feeder2", "feeder3"}, common.Hash{3}), 0, metadata.PortalUpdateFeedersMeta, currentPortalState, 1, portalParams)
+	assert.Nil(t, err)
+	assert.Equal(t, common.PortalUpdateFeedersAcceptedChainStatus, insts[0][2])
+	assert.Equal(t, []string{"feeder2", "feeder3"}, currentPortalState.Feeders)
+
+	//the removed feeder cannot feed anymore
+	insts, err = bc.buildInstructionsForExchangeRates(buildPortalExchangeRatesAction("feeder1", 100, common.Hash{4}), 0, metadata.PortalExchangeRatesMeta, currentPortalState, 1, portalParams)
+	assert.Nil(t, err)
+	assert.Equal(t, common.PortalExchangeRatesRejectedChainStatus, insts[0][2])
+	insts, err = bc.buildInstructionsForExchangeRates(buildPortalExchangeRatesAction("feeder3", 100, common.Hash{5}), 0, metadata.PortalExchangeRatesMeta, currentPortalState, 1, portalParams)
+	assert.Nil(t, err)
+	assert.Equal(t, common.PortalExchangeRatesAcceptedChainStatus, insts[0][2])
+}
@@ -26,6 +26,10 @@ type CurrentPortalState struct {
 	LockedCollateralForRewards *statedb.LockedCollateralState
 	//Store temporary exchange rates requests
 	ExchangeRatesRequests map[string]*metadata.ExchangeRatesRequestStatus // key : hash(beaconHeight | TxID)
+	// feeders of the exchange rates, nil until the governor updates the feeders of the chain params
+	Feeders []string
+	// ids of the obligation transfers accepted in the current block, the ones of the previous blocks are in db
+	TransferObligationIDs map[string]bool
 }
 
 type CustodianStateSlice struct {
@@ -33,15 +37,6 @@ type CustodianStateSlice struct {
 	Value *statedb.CustodianState
 }
 
-type RedeemMemoBNB struct {
-	RedeemID                  string `json:"RedeemID"`
-	CustodianIncognitoAddress string `json:"CustodianIncognitoAddress"`
-}
-
-type PortingMemoBNB struct {
-	PortingID string `json:"PortingID"`
-}
-
 func InitCurrentPortalStateFromDB(
 	stateDB *statedb.StateDB,
 ) (*CurrentPortalState, error) {
@@ -73,6 +68,10 @@ func InitCurrentPortalStateFromDB(
 	if err != nil {
 		return nil, err
 	}
+	feeders, _, err := statedb.GetPortalFeeders(stateDB)
+	if err != nil {
+		return nil, err
+	}
 
 	return &CurrentPortalState{
 		CustodianPoolState:         custodianPoolState,
@@ -83,6 +82,8 @@ func InitCurrentPortalStateFromDB(
 		ExchangeRatesRequests:      make(map[string]*metadata.ExchangeRatesRequestStatus),
 		LiquidationPool:            liquidateExchangeRatesPool,
 		LockedCollateralForRewards: lockedCollateralState,
+		Feeders:                    feeders,
+		TransferObligationIDs:      make(map[string]bool),
 	}, nil
 }
 
@@ -118,6 +119,12 @@ func storePortalStateToDB(
 	if err != nil {
 		return err
 	}
+	if currentPortalState.Feeders != nil {
+		err = statedb.StorePortalFeeders(stateDB, currentPortalState.Feeders)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -450,11 +457,6 @@ func pickupCustodianForRedeem(redeemAmount uint64, tokenID string, portalState *
 	return nil, errors.New("Not enough amount public token to return user")
 }
 
-// convertIncPBNBAmountToExternalBNBAmount converts amount in inc chain (decimal 9) to amount in bnb chain (decimal 8)
-func convertIncPBNBAmountToExternalBNBAmount(incPBNBAmount int64) int64 {
-	return incPBNBAmount / 10 // incPBNBAmount / 1^9 * 1^8
-}
-
 // updateCustodianStateAfterReqUnlockCollateral updates custodian state (amount collaterals) when custodian returns redeemAmount public token to user
 func updateCustodianStateAfterReqUnlockCollateral(custodianState *statedb.CustodianState, unlockedAmount uint64, tokenID string) error {
 	lockedAmount := custodianState.GetLockedAmountCollateral()
@@ -636,35 +638,23 @@ func NewConvertExchangeRatesObject(finalExchangeRates *statedb.FinalExchangeRate
 }
 
 func (c ConvertExchangeRatesObject) ExchangePToken2PRVByTokenId(pTokenId string, value uint64) (uint64, error) {
-	switch pTokenId {
-	case common.PortalBTCIDStr:
-		result, err := c.ExchangeBTC2PRV(value)
-		if err != nil {
-			return 0, err
-		}
-
-		return result, nil
-	case common.PortalBNBIDStr:
-		result, err := c.ExchangeBNB2PRV(value)
-		if err != nil {
-			return 0, err
-		}
-
-		return result, nil
+	if !common.IsPortalToken(pTokenId) {
+		return 0, errors.New("Ptoken is not support")
 	}
+	pTokenRates := c.finalExchangeRates.Rates()[pTokenId].Amount
+	PRVRates := c.finalExchangeRates.Rates()[common.PRVIDStr].Amount
 
-	return 0, errors.New("Ptoken is not support")
+	return c.convert(value, pTokenRates, PRVRates)
 }
 
 func (c *ConvertExchangeRatesObject) ExchangePRV2PTokenByTokenId(pTokenId string, value uint64) (uint64, error) {
-	switch pTokenId {
-	case common.PortalBTCIDStr:
-		return c.ExchangePRV2BTC(value)
-	case common.PortalBNBIDStr:
-		return c.ExchangePRV2BNB(value)
+	if !common.IsPortalToken(pTokenId) {
+		return 0, errors.New("Ptoken is not support")
 	}
+	pTokenRates := c.finalExchangeRates.Rates()[pTokenId].Amount
+	PRVRates := c.finalExchangeRates.Rates()[common.PRVIDStr].Amount
 
-	return 0, errors.New("Ptoken is not support")
+	return c.convert(value, PRVRates, pTokenRates)
 }
 
 func (c *ConvertExchangeRatesObject) convert(value uint64, ratesFrom uint64, RatesTo uint64) (uint64, error) {
@@ -1265,3 +1255,127 @@ func CalAmountTopUpWaitingPortings(
 
 	return result, nil
 }
+
+func cloneCustodianState(custodianState *statedb.CustodianState) *statedb.CustodianState {
+	cloneUint64Map := func(m map[string]uint64) map[string]uint64 {
+		if m == nil {
+			return nil
+		}
+		newMap := make(map[string]uint64, len(m))
+		for k, v := range m {
+			newMap[k] = v
+		}
+		return newMap
+	}
+	var remoteAddresses map[string]string
+	if custodianState.GetRemoteAddresses() != nil {
+		remoteAddresses = make(map[string]string, len(custodianState.GetRemoteAddresses()))
+		for k, v := range custodianState.GetRemoteAddresses() {
+			remoteAddresses[k] = v
+		}
+	}
+	return statedb.NewCustodianStateWithValue(
+		custodianState.GetIncognitoAddress(),
+		custodianState.GetTotalCollateral(),
+		custodianState.GetFreeCollateral(),
+		cloneUint64Map(custodianState.GetHoldingPublicTokens()),
+		cloneUint64Map(custodianState.GetLockedAmountCollateral()),
+		remoteAddresses,
+		cloneUint64Map(custodianState.GetRewardAmount()),
+	)
+}
+
+// checkTPRatioBeforeAndAfterChangingCollateral checks a custodian moving its locked collateral or its obligations:
+// none of its tokens may be down to TP120 before the change, the custodian is waiting for the liquidation,
+// and none of them may be down to TP130 after the change
+func checkTPRatioBeforeAndAfterChangingCollateral(
+	portalState *CurrentPortalState,
+	custodianState *statedb.CustodianState,
+	newCustodianState *statedb.CustodianState,
+	finalExchange *statedb.FinalExchangeRatesState,
+	portalParams PortalParams) error {
+	tpRatios, err := calAndCheckTPRatio(portalState, custodianState, finalExchange, portalParams)
+	if err != nil {
+		return err
+	}
+	for tokenID, tpRatio := range tpRatios {
+		if tpRatio.TPKey == int(portalParams.TP120) {
+			return fmt.Errorf("Custodian %v is waiting for the liquidation of token %v", custodianState.GetIncognitoAddress(), tokenID)
+		}
+	}
+
+	newTPRatios, err := calAndCheckTPRatio(portalState, newCustodianState, finalExchange, portalParams)
+	if err != nil {
+		return err
+	}
+	for tokenID, tpRatio := range newTPRatios {
+		return fmt.Errorf("Collateral of token %v of custodian %v would be down to TP%v", tokenID, custodianState.GetIncognitoAddress(), tpRatio.TPKey)
+	}
+	return nil
+}
+
+// updateCustodianStatesAfterTransferObligation moves amount public token from the sender to the receiver,
+// the sender gets back unlockAmount collateral and the receiver locks lockAmount collateral
+func updateCustodianStatesAfterTransferObligation(
+	senderState *statedb.CustodianState,
+	receiverState *statedb.CustodianState,
+	tokenID string,
+	amount uint64,
+	unlockAmount uint64,
+	lockAmount uint64) error {
+	if senderState.GetHoldingPublicTokens()[tokenID] < amount {
+		return errors.New("[portal-updateCustodianStatesAfterTransferObligation] Holding public token amount of sender is less than transfer amount")
+	}
+	if senderState.GetLockedAmountCollateral()[tokenID] < unlockAmount {
+		return errors.New("[portal-updateCustodianStatesAfterTransferObligation] Locked amount of sender is less than amount need to unlocked")
+	}
+	if receiverState.GetFreeCollateral() < lockAmount {
+		return errors.New("[portal-updateCustodianStatesAfterTransferObligation] Free collateral of receiver is less than amount need to locked")
+	}
+
+	senderHoldingPubTokens := senderState.GetHoldingPublicTokens()
+	senderHoldingPubTokens[tokenID] -= amount
+	senderState.SetHoldingPublicTokens(senderHoldingPubTokens)
+	senderLockedAmount := senderState.GetLockedAmountCollateral()
+	senderLockedAmount[tokenID] -= unlockAmount
+	senderState.SetLockedAmountCollateral(senderLockedAmount)
+	senderState.SetFreeCollateral(senderState.GetFreeCollateral() + unlockAmount)
+
+	receiverHoldingPubTokens := receiverState.GetHoldingPublicTokens()
+	if receiverHoldingPubTokens == nil {
+		receiverHoldingPubTokens = make(map[string]uint64)
+	}
+	receiverHoldingPubTokens[tokenID] += amount
+	receiverState.SetHoldingPublicTokens(receiverHoldingPubTokens)
+	receiverLockedAmount := receiverState.GetLockedAmountCollateral()
+	if receiverLockedAmount == nil {
+		receiverLockedAmount = make(map[string]uint64)
+	}
+	receiverLockedAmount[tokenID] += lockAmount
+	receiverState.SetLockedAmountCollateral(receiverLockedAmount)
+	receiverState.SetFreeCollateral(receiverState.GetFreeCollateral() - lockAmount)
+	return nil
+}
+
+// updateCustodianStateAfterRebalanceCollateral moves amount locked collateral of custodian from fromTokenID to toTokenID
+func updateCustodianStateAfterRebalanceCollateral(
+	portalState *CurrentPortalState,
+	custodianState *statedb.CustodianState,
+	fromTokenID string,
+	toTokenID string,
+	amount uint64) error {
+	lockedAmount := custodianState.GetLockedAmountCollateral()
+	if lockedAmount == nil {
+		return errors.New("[portal-updateCustodianStateAfterRebalanceCollateral] Locked amount is nil")
+	}
+	// the collateral locked for the waiting porting requests can not be moved
+	totalLockedAmountInWaitingPortings := GetTotalLockedCollateralAmountInWaitingPortings(portalState, custodianState, fromTokenID)
+	if lockedAmount[fromTokenID] < totalLockedAmountInWaitingPortings+amount {
+		return errors.New("[portal-updateCustodianStateAfterRebalanceCollateral] Locked amount is less than amount need to moved")
+	}
+
+	lockedAmount[fromTokenID] -= amount
+	lockedAmount[toTokenID] += amount
+	custodianState.SetLockedAmountCollateral(lockedAmount)
+	return nil
+}
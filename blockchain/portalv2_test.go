@@ -527,6 +527,7 @@ func processPortalInstructions(
 			portalStateDB,
 			updatingInfo.tokenID,
 			updatingInfo.externalTokenID,
+			updatingInfo.network,
 			updatingInfo.isCentralized,
 			updatingAmt,
 			updatingType,
@@ -0,0 +1,147 @@
+package blockchain
+
+import (
+	"errors"
+	"strings"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+const preValidatedBlockCacheSize = 2000
+
+// The syncker pre-validates the blocks it is about to insert in parallel: the committee signatures
+// of a block do not depend on the state of its previous view. A block whose signatures were
+// pre-validated against the committee of its previous view is inserted without checking them again.
+//
+// The proof of a transaction only reads the commitments it spends, a proof valid on the final view
+// stays valid on every view descending from it. The transactions whose proofs were pre-validated on
+// the final view are not verified again when their block is inserted, the other ones are.
+
+func committeeKey(committee []incognitokey.CommitteePublicKey) (string, error) {
+	committeeStr, err := incognitokey.CommitteeKeyListToString(committee)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(committeeStr, ","), nil
+}
+
+func markSignaturesPreValidated(cache *lru.Cache, block common.BlockInterface, committee []incognitokey.CommitteePublicKey) {
+	if cache == nil {
+		return
+	}
+	key, err := committeeKey(committee)
+	if err != nil {
+		return
+	}
+	cache.Add(block.Hash().String(), key)
+}
+
+func isSignaturesPreValidated(cache *lru.Cache, blockHash string, committee []incognitokey.CommitteePublicKey) bool {
+	if cache == nil {
+		return false
+	}
+	preValidatedKey, ok := cache.Get(blockHash)
+	if !ok {
+		return false
+	}
+	key, err := committeeKey(committee)
+	return err == nil && key == preValidatedKey.(string)
+}
+
+// PreValidateBlockSignatures validates the producer and committee signatures of block against committee,
+// they are not checked again when the block is inserted on a view of the same committee
+func (chain *ShardChain) PreValidateBlockSignatures(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error {
+	if err := chain.ValidateBlockSignatures(block, committee); err != nil {
+		return err
+	}
+	markSignaturesPreValidated(chain.preValidatedBlocks, block, committee)
+	return nil
+}
+
+// txProofParams are the parameters of the verification of the proofs of the transactions of a block
+// of beacon height beaconHeight, the stateful checks of the transactions are done at insert
+func txProofParams(bc *BlockChain, beaconHeight uint64) map[string]bool {
+	boolParams := make(map[string]bool)
+	boolParams["isNewTransaction"] = false
+	boolParams["isBatch"] = true
+	boolParams["isNewZKP"] = bc.IsAfterNewZKPCheckPoint(beaconHeight)
+	return boolParams
+}
+
+// proofTxs returns the transactions of block whose proofs are verified, all but the salary ones
+func proofTxs(block *ShardBlock) []metadata.Transaction {
+	txs := []metadata.Transaction{}
+	for _, tx := range block.Body.Transactions {
+		if !tx.IsSalaryTx() {
+			txs = append(txs, tx)
+		}
+	}
+	return txs
+}
+
+func isTxPreValidated(cache *lru.Cache, blockHash string, txHash common.Hash) bool {
+	if cache == nil {
+		return false
+	}
+	validated, ok := cache.Get(blockHash)
+	if !ok {
+		return false
+	}
+	_, ok = validated.(map[common.Hash]struct{})[txHash]
+	return ok
+}
+
+// PreValidateBlockTxs batch verifies the proofs of the transactions of block against the transaction
+// state of the final view and records the transactions passing. A transaction spending the outputs of
+// a block not final yet fails, it is verified again when its block is inserted
+func (chain *ShardChain) PreValidateBlockTxs(block common.BlockInterface) error {
+	shardBlock, ok := block.(*ShardBlock)
+	if !ok {
+		return errors.New("not a shard block")
+	}
+	txs := proofTxs(shardBlock)
+	if len(txs) == 0 {
+		return nil
+	}
+	finalView := chain.GetFinalView().(*ShardBestState)
+	beaconView, err := chain.Blockchain.GetBeaconViewStateDataFromBlockHash(finalView.BestBlock.Header.BeaconHash)
+	if err != nil {
+		return err
+	}
+	transactionStateDB := finalView.GetCopiedTransactionStateDB()
+	beaconFeatureStateDB := beaconView.GetBeaconFeatureStateDB()
+	validated := make(map[common.Hash]struct{})
+	ok, _, _ = transaction.NewBatchTransaction(txs).Validate(transactionStateDB, beaconFeatureStateDB, txProofParams(chain.Blockchain, shardBlock.Header.BeaconHeight))
+	for _, tx := range txs {
+		if !ok {
+			//find the transactions passing alone
+			if txOk, _, _ := transaction.NewBatchTransaction([]metadata.Transaction{tx}).Validate(transactionStateDB, beaconFeatureStateDB, txProofParams(chain.Blockchain, shardBlock.Header.BeaconHeight)); !txOk {
+				continue
+			}
+		}
+		validated[*tx.Hash()] = struct{}{}
+	}
+	if chain.preValidatedTxs != nil {
+		chain.preValidatedTxs.Add(shardBlock.Hash().String(), validated)
+	}
+	return nil
+}
+
+// PreValidateBlockSignatures validates the producer and committee signatures of block against committee,
+// they are not checked again when the block is inserted on a view of the same committee
+func (chain *BeaconChain) PreValidateBlockSignatures(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error {
+	if err := chain.ValidateBlockSignatures(block, committee); err != nil {
+		return err
+	}
+	markSignaturesPreValidated(chain.preValidatedBlocks, block, committee)
+	return nil
+}
+
+// PreValidateBlockTxs does nothing, a beacon block has no transaction
+func (chain *BeaconChain) PreValidateBlockTxs(block common.BlockInterface) error {
+	return nil
+}
@@ -0,0 +1,28 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyTransactionProofsFromNewBlock_PreValidated(t *testing.T) {
+	bc := &BlockChain{ShardChain: []*ShardChain{NewShardChain(0, nil, nil, nil, "shard")}}
+	txs := []metadata.Transaction{
+		&transaction.Tx{Type: common.TxNormalType, LockTime: 1},
+		&transaction.Tx{Type: common.TxNormalType, LockTime: 2},
+	}
+	block := &ShardBlock{Header: ShardHeader{ShardID: 0, Height: 2}, Body: ShardBody{Transactions: txs}}
+	blockHash := block.Hash().String()
+	bc.ShardChain[0].preValidatedTxs.Add(blockHash, map[common.Hash]struct{}{*txs[0].Hash(): {}})
+	assert.True(t, isTxPreValidated(bc.ShardChain[0].preValidatedTxs, blockHash, *txs[0].Hash()))
+	assert.False(t, isTxPreValidated(bc.ShardChain[0].preValidatedTxs, blockHash, *txs[1].Hash()))
+	assert.False(t, isTxPreValidated(bc.ShardChain[0].preValidatedTxs, common.Hash{}.String(), *txs[0].Hash()))
+
+	//the transactions pre-validated are not verified again, the view they extend is not read
+	bc.ShardChain[0].preValidatedTxs.Add(blockHash, map[common.Hash]struct{}{*txs[0].Hash(): {}, *txs[1].Hash(): {}})
+	assert.Nil(t, bc.verifyTransactionProofsFromNewBlock(nil, block))
+}
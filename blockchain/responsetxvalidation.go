@@ -16,7 +16,7 @@ func (blockchain *BlockChain) verifyMinerCreatedTxBeforeGettingInBlock(
 	invalidTxs := []metadata.Transaction{}
 	accumulatedValues := &metadata.AccumulatedValues{
 		UniqETHTxsUsed:   [][]byte{},
-		DBridgeTokenPair: map[string][]byte{},
+		DBridgeTokenPair: map[string]metadata.BridgeTokenPair{},
 		CBridgeTokens:    []*common.Hash{},
 	}
 	for _, tx := range txs {
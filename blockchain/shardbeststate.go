@@ -89,6 +89,10 @@ func (shardBestState *ShardBestState) GetShardRewardStateDB() *statedb.StateDB {
 	return shardBestState.rewardStateDB.Copy()
 }
 
+func (shardBestState *ShardBestState) GetCopiedSlashStateDB() *statedb.StateDB {
+	return shardBestState.slashStateDB.Copy()
+}
+
 func (shardBestState *ShardBestState) GetHash() *common.Hash {
 	return shardBestState.BestBlock.Hash()
 }
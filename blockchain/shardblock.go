@@ -41,6 +41,7 @@ type ShardHeader struct {
 	StakingTxRoot         common.Hash            `json:"StakingTxRoot"`         // hash from staking transaction map in shard best state
 	InstructionMerkleRoot common.Hash            `json:"InstructionMerkleRoot"` // Merkle root of all instructions (using Keccak256 hash func) to relay to Ethreum
 	// This obsoletes InstructionMerkleRoot but for simplicity, we keep it for now
+	PreviousStateRootsHash common.Hash `json:"PreviousStateRootsHash"` // hash of the state roots of the previous block, empty before the state roots hash break point
 
 	//for version 2
 	Proposer    string
@@ -335,6 +336,9 @@ func (shardHeader *ShardHeader) String() string {
 	for _, value := range shardHeader.CrossShardBitMap {
 		res += string(value)
 	}
+	if !shardHeader.PreviousStateRootsHash.IsEqual(&common.Hash{}) {
+		res += shardHeader.PreviousStateRootsHash.String()
+	}
 
 	if shardHeader.Version == 2 {
 		res += shardHeader.Proposer
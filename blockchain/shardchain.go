@@ -24,11 +24,17 @@ type ShardChain struct {
 	ChainName   string
 	Ready       bool
 
-	insertLock sync.Mutex
+	insertLock  sync.Mutex
+	statePruner *statePruner
+
+	preValidatedBlocks *lru.Cache // block hash -> committee its signatures were checked against
+	preValidatedTxs    *lru.Cache // block hash -> hashes of its transactions whose proofs were checked
 }
 
 func NewShardChain(shardID int, multiView *multiview.MultiView, blockGen *BlockGenerator, blockchain *BlockChain, chainName string) *ShardChain {
-	return &ShardChain{shardID: shardID, multiView: multiView, BlockGen: blockGen, Blockchain: blockchain, ChainName: chainName}
+	preValidatedBlocks, _ := lru.New(preValidatedBlockCacheSize)
+	preValidatedTxs, _ := lru.New(preValidatedBlockCacheSize)
+	return &ShardChain{shardID: shardID, multiView: multiView, BlockGen: blockGen, Blockchain: blockchain, ChainName: chainName, preValidatedBlocks: preValidatedBlocks, preValidatedTxs: preValidatedTxs}
 }
 
 func (chain *ShardChain) GetFinalView() multiview.View {
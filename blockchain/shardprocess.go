@@ -144,7 +144,7 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, shouldVal
 	if shouldValidate {
 		Logger.log.Infof("SHARD %+v | Verify Pre Processing, block height %+v with hash %+vt \n", shardID, blockHeight, blockHash)
 		if err := blockchain.verifyPreProcessingShardBlock(curView, shardBlock, beaconBlocks, shardID, false); err != nil {
-			return err
+			return wrapBlockValidationError(err)
 		}
 	} else {
 		Logger.log.Infof("SHARD %+v | SKIP Verify Pre Processing, block height %+v with hash %+v \n", shardID, blockHeight, blockHash)
@@ -154,11 +154,16 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, shouldVal
 		// Verify block with previous best state
 		Logger.log.Debugf("SHARD %+v | Verify BestState With Shard Block, block height %+v with hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
 		if err := curView.verifyBestStateWithShardBlock(blockchain, shardBlock, true, shardID); err != nil {
-			return err
+			return wrapBlockValidationError(err)
 		}
-		if err := blockchain.ShardChain[shardBlock.Header.ShardID].ValidateBlockSignatures(shardBlock, curView.ShardCommittee); err != nil {
+		if isSignaturesPreValidated(blockchain.ShardChain[shardID].preValidatedBlocks, blockHash.String(), curView.ShardCommittee) {
+			Logger.log.Debugf("SHARD %+v | SKIP Validate Signatures of pre-validated Shard Block, block height %+v with hash %+v", shardID, blockHeight, blockHash)
+		} else if err := blockchain.ShardChain[shardBlock.Header.ShardID].ValidateBlockSignatures(shardBlock, curView.ShardCommittee); err != nil {
 			Logger.log.Errorf("Validate block %v shard %v using view %v return error %v", shardBlock.GetHeight(), shardBlock.GetShardID(), preHash, err)
-			return err
+			return NewInvalidBlockError(err)
+		}
+		if err := blockchain.verifyTransactionProofsFromNewBlock(curView, shardBlock); err != nil {
+			return wrapBlockValidationError(err)
 		}
 	} else {
 		Logger.log.Debugf("SHARD %+v | SKIP Verify Best State With Shard Block, Shard Block Height %+v with hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
@@ -179,7 +184,7 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, shouldVal
 		Logger.log.Infof("SHARD %+v | Verify Post Processing, block height %+v with hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
 		if err := blockchain.verifyPostProcessingShardBlock(newBestState, shardBlock, shardID); err != nil {
 			fmt.Println("Instructions", shardBlock.Body.Instructions)
-			return err
+			return wrapBlockValidationError(err)
 		}
 	} else {
 		Logger.log.Infof("SHARD %+v | SKIP Verify Post Processing, block height %+v with hash %+v \n", shardID, blockHeight, blockHash)
@@ -192,12 +197,15 @@ func (blockchain *BlockChain) InsertShardBlock(shardBlock *ShardBlock, shouldVal
 	}
 	Logger.log.Infof("SHARD %+v | Store New Shard Block And Update Data, block height %+v with hash %+v \n", shardID, blockHeight, blockHash)
 	//========Store new  Shard block and new shard bestState
+	blockchain.ShardChain[int(shardID)].statePruner.lockCommit()
 	err = blockchain.processStoreShardBlock(newBestState, shardBlock, committeeChange, beaconBlocks)
+	blockchain.ShardChain[int(shardID)].statePruner.unlockCommit(newBestState.stateRoots())
 	if err != nil {
 
 		return err
 	}
 	blockchain.removeOldDataAfterProcessingShardBlock(shardBlock, shardID)
+	blockchain.pruneShardState(shardID)
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewShardblockTopic, shardBlock))
 	go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.ShardBeststateTopic, newBestState))
 	Logger.log.Infof("SHARD %+v | Finish Insert new block %d, with hash %+v 🔗", shardBlock.Header.ShardID, shardBlock.Header.Height, blockHash)
@@ -559,7 +567,7 @@ func (shardBestState *ShardBestState) verifyBestStateWithShardBlock(blockchain *
 	Logger.log.Debugf("SHARD %+v | Begin VerifyBestStateWithShardBlock Block with height %+v at hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, shardBlock.Hash())
 	//verify producer via index
 	if err := blockchain.config.ConsensusEngine.ValidateProducerPosition(shardBlock, shardBestState.ShardProposerIdx, shardBestState.ShardCommittee, shardBestState.MinShardCommitteeSize); err != nil {
-		return err
+		return NewBlockChainError(ProducerError, err)
 	}
 
 	// check with current final best state
@@ -573,6 +581,9 @@ func (shardBestState *ShardBestState) verifyBestStateWithShardBlock(blockchain *
 	if shardBlock.Header.BeaconHeight < shardBestState.BeaconHeight {
 		return NewBlockChainError(ShardBestStateBeaconHeightNotCompatibleError, fmt.Errorf("Shard Block contain invalid beacon height, current beacon height %+v but get %+v ", shardBestState.BeaconHeight, shardBlock.Header.BeaconHeight))
 	}
+	if err := verifyPreviousStateRootsHash(blockchain.previousStateRootsHash(shardBestState.stateRoots(), shardBlock.Header.BeaconHeight), shardBlock.Header.PreviousStateRootsHash); err != nil {
+		return err
+	}
 	shardVerifyWithBestStateTimer.UpdateSince(startTimeVerifyBestStateWithShardBlock)
 	Logger.log.Debugf("SHARD %+v | Finish VerifyBestStateWithShardBlock Block with height %+v at hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, shardBlock.Hash())
 	return nil
@@ -761,13 +772,13 @@ func (shardBestState *ShardBestState) processShardBlockInstruction(blockchain *B
 	if len(shardBlock.Body.Instructions) != 0 {
 		Logger.log.Debugf("Shard Process/updateShardBestState: Shard Instruction %+v", shardBlock.Body.Instructions)
 	}
-	producersBlackList, err := blockchain.getUpdatedProducersBlackList(blockchain.GetBeaconBestState().slashStateDB, false, int(shardID), shardCommittee, shardBlock.Header.BeaconHeight)
-	if err != nil {
-		return err
-	}
 	// Swap committee
 	for _, l := range shardBlock.Body.Instructions {
 		if l[0] == SwapAction {
+			producersBlackList, err := blockchain.getShardProducersBlackList(shardBlock.Header.BeaconHeight, shardBlock.Header.BeaconHash, shardID, shardCommittee)
+			if err != nil {
+				return err
+			}
 			// #1 remaining pendingValidators, #2 new currentValidators #3 swapped out validator, #4 incoming validator
 			maxShardCommitteeSize := shardBestState.MaxShardCommitteeSize - NumberOfFixedBlockValidators
 			var minShardCommitteeSize int
@@ -907,21 +918,9 @@ func (blockchain *BlockChain) verifyPostProcessingShardBlock(shardBestState *Sha
 	startTimeVerifyPostProcessingShardBlock := time.Now()
 	Logger.log.Debugf("SHARD %+v | Begin VerifyPostProcessing Block with height %+v at hash %+v", shardBlock.Header.ShardID, shardBlock.Header.Height, shardBlock.Hash())
 
-	shardCommitteeStr, err := incognitokey.CommitteeKeyListToString(shardBestState.ShardCommittee)
-	if err != nil {
-		return err
-	}
-	if hash, ok := verifyHashFromStringArray(shardCommitteeStr, shardBlock.Header.CommitteeRoot); !ok {
-		return NewBlockChainError(ShardCommitteeRootHashError, fmt.Errorf("Expect shard committee root hash to be %+v but get %+v", shardBlock.Header.CommitteeRoot, hash))
-	}
-
-	shardPendingValidatorStr, err := incognitokey.CommitteeKeyListToString(shardBestState.ShardPendingValidator)
-	if err != nil {
+	if err := shardBestState.verifyCommitteeRoots(shardBlock); err != nil {
 		return err
 	}
-	if hash, isOk := verifyHashFromStringArray(shardPendingValidatorStr, shardBlock.Header.PendingValidatorRoot); !isOk {
-		return NewBlockChainError(ShardPendingValidatorRootHashError, fmt.Errorf("Expect shard pending validator root hash to be %+v but get %+v", shardBlock.Header.PendingValidatorRoot, hash))
-	}
 	if shardBestState.BeaconHeight > blockchain.config.ChainParams.ReplaceStakingTxHeight {
 
 		//stakingTx := NewMapStringString()
@@ -947,6 +946,27 @@ func (blockchain *BlockChain) verifyPostProcessingShardBlock(shardBestState *Sha
 	return nil
 }
 
+// verifyCommitteeRoots checks the committee and pending validator roots in the
+// header of shardBlock against the committees of shardBestState
+func (shardBestState *ShardBestState) verifyCommitteeRoots(shardBlock *ShardBlock) error {
+	shardCommitteeStr, err := incognitokey.CommitteeKeyListToString(shardBestState.ShardCommittee)
+	if err != nil {
+		return err
+	}
+	if hash, ok := verifyHashFromStringArray(shardCommitteeStr, shardBlock.Header.CommitteeRoot); !ok {
+		return NewBlockChainError(ShardCommitteeRootHashError, fmt.Errorf("Expect shard committee root hash to be %+v but get %+v", shardBlock.Header.CommitteeRoot, hash))
+	}
+
+	shardPendingValidatorStr, err := incognitokey.CommitteeKeyListToString(shardBestState.ShardPendingValidator)
+	if err != nil {
+		return err
+	}
+	if hash, isOk := verifyHashFromStringArray(shardPendingValidatorStr, shardBlock.Header.PendingValidatorRoot); !isOk {
+		return NewBlockChainError(ShardPendingValidatorRootHashError, fmt.Errorf("Expect shard pending validator root hash to be %+v but get %+v", shardBlock.Header.PendingValidatorRoot, hash))
+	}
+	return nil
+}
+
 // Verify Transaction with these condition:
 //	1. Validate tx version
 //	2. Validate fee with tx size
@@ -994,6 +1014,30 @@ func (blockchain *BlockChain) verifyTransactionFromNewBlock(shardID byte, txs []
 	return nil
 }
 
+// verifyTransactionProofsFromNewBlock verifies the proofs of the transactions of shardBlock on the view it extends,
+// but the ones pre-validated on the final view by the syncker
+func (blockchain *BlockChain) verifyTransactionProofsFromNewBlock(curView *ShardBestState, shardBlock *ShardBlock) error {
+	blockHash := shardBlock.Hash().String()
+	txs := []metadata.Transaction{}
+	for _, tx := range proofTxs(shardBlock) {
+		if !isTxPreValidated(blockchain.ShardChain[shardBlock.Header.ShardID].preValidatedTxs, blockHash, *tx.Hash()) {
+			txs = append(txs, tx)
+		}
+	}
+	if len(txs) == 0 {
+		return nil
+	}
+	beaconView, err := blockchain.GetBeaconViewStateDataFromBlockHash(curView.BestBlock.Header.BeaconHash)
+	if err != nil {
+		return err
+	}
+	ok, err, index := transaction.NewBatchTransaction(txs).Validate(curView.GetCopiedTransactionStateDB(), beaconView.GetBeaconFeatureStateDB(), txProofParams(blockchain, shardBlock.Header.BeaconHeight))
+	if !ok {
+		return NewBlockChainError(TransactionFromNewBlockError, fmt.Errorf("Verify transactions of block %+v failed at index %+v: %+v", blockHash, index, err))
+	}
+	return nil
+}
+
 // processStoreShardBlock Store All information after Insert
 //	- Shard Block
 //	- Shard Best State
@@ -183,6 +183,7 @@ func (blockchain *BlockChain) NewBlockShard(curView *ShardBestState, version int
 		TotalTxsFee:       totalTxsFee,
 		ConsensusType:     curView.ConsensusAlgorithm,
 	}
+	newShardBlock.Header.PreviousStateRootsHash = blockchain.previousStateRootsHash(curView.stateRoots(), beaconHeight)
 	//============Update Shard BestState=============
 	// startStep = time.Now()
 	newShardBestState, err := shardBestState.updateShardBestState(blockchain, newShardBlock, beaconBlocks, committeeChange)
@@ -333,11 +334,11 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(cur
 				if len(l) >= 4 {
 					newTx, err = blockGenerator.buildPDETradeIssuanceTx(l[2], l[3], producerPrivateKey, shardID, curView, beaconView)
 				}
-			case metadata.PDECrossPoolTradeRequestMeta:
+			case metadata.PDECrossPoolTradeRequestMeta, metadata.PDETradeRequestV3Meta:
 				if len(l) >= 4 {
 					newTx, err = blockGenerator.buildPDECrossPoolTradeIssuanceTx(l[2], l[3], producerPrivateKey, shardID, curView, beaconView)
 				}
-			case metadata.PDEWithdrawalRequestMeta:
+			case metadata.PDEWithdrawalRequestMeta, metadata.PDEWithdrawalRequestV3Meta:
 				if len(l) >= 4 && l[2] == common.PDEWithdrawalAcceptedChainStatus {
 					newTx, err = blockGenerator.buildPDEWithdrawalTx(l[3], producerPrivateKey, shardID, curView, beaconView)
 				}
@@ -345,7 +346,7 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(cur
 				if len(l) >= 4 && l[2] == common.PDEFeeWithdrawalAcceptedChainStatus {
 					newTx, err = blockGenerator.buildPDEFeeWithdrawalTx(l[3], producerPrivateKey, shardID, curView, beaconView)
 				}
-			case metadata.PDEContributionMeta, metadata.PDEPRVRequiredContributionRequestMeta:
+			case metadata.PDEContributionMeta, metadata.PDEPRVRequiredContributionRequestMeta, metadata.PDEContributionV3Meta:
 				if len(l) >= 4 {
 					if l[2] == common.PDEContributionRefundChainStatus {
 						newTx, err = blockGenerator.buildPDERefundContributionTx(l[3], producerPrivateKey, shardID, curView, beaconView)
@@ -353,6 +354,10 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(cur
 						newTx, err = blockGenerator.buildPDEMatchedNReturnedContributionTx(l[3], producerPrivateKey, shardID, curView, beaconView)
 					}
 				}
+			case metadata.PDELimitOrderRequestMeta, metadata.PDECancelLimitOrderRequestMeta:
+				if len(l) >= 4 {
+					newTx, err = blockGenerator.buildPDELimitOrderResTx(l[2], l[3], producerPrivateKey, shardID, curView, beaconView)
+				}
 			// portal
 			case metadata.PortalUserRegisterMeta:
 				if len(l) >= 4 && l[2] == common.PortalPortingRequestRejectedChainStatus {
@@ -548,7 +553,6 @@ func (blockchain *BlockChain) generateInstruction(view *ShardBestState, shardID
 		//	return instructions, shardPendingValidator, shardCommittee, err
 		//}
 		var err error
-		producersBlackList := make(map[string]uint8)
 
 		maxShardCommitteeSize := view.MaxShardCommitteeSize - NumberOfFixedBlockValidators
 		var minShardCommitteeSize int
@@ -563,6 +567,14 @@ func (blockchain *BlockChain) generateInstruction(view *ShardBestState, shardID
 			epoch := beaconHeight / blockchain.config.ChainParams.Epoch
 			swapInstruction, shardPendingValidator, shardCommittee = CreateShardSwapActionForKeyListV2(blockchain.config.GenesisParams, shardPendingValidator, backupShardCommittee, NumberOfFixedBlockValidators, blockchain.config.ChainParams.ActiveShards, shardID, epoch)
 		} else {
+			beaconHash, err := rawdbv2.GetFinalizedBeaconBlockHashByIndex(blockchain.GetBeaconChainDatabase(), beaconHeight)
+			if err != nil {
+				return instructions, shardPendingValidator, shardCommittee, err
+			}
+			producersBlackList, err := blockchain.getShardProducersBlackList(beaconHeight, *beaconHash, shardID, shardCommittee)
+			if err != nil {
+				return instructions, shardPendingValidator, shardCommittee, err
+			}
 			swapInstruction, shardPendingValidator, shardCommittee, err = CreateSwapInstruction(shardPendingValidator, shardCommittee, maxShardCommitteeSize, minShardCommitteeSize, shardID, producersBlackList, badProducersWithPunishment, blockchain.config.ChainParams.Offset, blockchain.config.ChainParams.SwapOffset)
 			if err != nil {
 				Logger.log.Error(err)
@@ -667,6 +679,8 @@ func (blockGenerator *BlockGenerator) getPendingTransaction(
 	maxBlockCreationTimeLeftTime := blockCreationTimeLeftOver - spareTime.Nanoseconds()
 	startTime := time.Now()
 	sourceTxns := blockGenerator.GetPendingTxsV2()
+	// fill block with the highest fee per KB txs first
+	blockGenerator.txPool.SortTxsByFeePriority(sourceTxns)
 	var elasped int64
 	Logger.log.Info("Number of transaction get from Block Generator: ", len(sourceTxns))
 	isEmpty := blockGenerator.chain.config.TempTxPool.EmptyPool()
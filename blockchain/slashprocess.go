@@ -115,6 +115,9 @@ func (blockchain *BlockChain) processForSlashing(slashStateDB *statedb.StateDB,
 	newBeaconHeight := beaconBlock.GetHeight()
 	if newBeaconHeight%uint64(chainParamEpoch) == 0 { // end of epoch
 		for producer := range producersBlackList {
+			if producersBlackList[producer] == EquivocationPunishedEpoches {
+				continue
+			}
 			producersBlackList[producer]--
 			if producersBlackList[producer] == 0 {
 				punishedProducersFinished = append(punishedProducersFinished, producer)
@@ -129,6 +132,12 @@ func (blockchain *BlockChain) processForSlashing(slashStateDB *statedb.StateDB,
 		if len(inst) == 0 {
 			continue
 		}
+		if inst[0] == EquivocationSlash && len(inst) == 2 && blockchain.IsAfterEquivocationSlashCheckPoint(beaconHeight) {
+			for _, producer := range strings.Split(inst[1], ",") {
+				producersBlackList[producer] = EquivocationPunishedEpoches
+			}
+			continue
+		}
 		if inst[0] != SwapAction {
 			continue
 		}
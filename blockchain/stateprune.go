@@ -0,0 +1,244 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/metrics"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+// statePruner deletes the state trie nodes of one chain database which are
+// not reachable from the views of the chain or from the roots of the last
+// StatePruneKeep finalized blocks. A prune runs in its own goroutine, blocks
+// keep being inserted meanwhile: their state is committed between LockCommit
+// and UnlockCommit of the trie pruner, which keeps the committed roots.
+type statePruner struct {
+	pruner *trie.Pruner
+
+	lock            sync.Mutex // Guards the fields below
+	running         bool       // Whether the goroutine of a prune is running
+	lastPruneHeight uint64     // Final height of the last finished prune
+	keptFromHeight  uint64     // Lowest finalized height whose state was kept by the last prune
+
+	prunedHeight metrics.Gauge
+}
+
+// pruneRootsFunc returns the roots kept by a prune with the final height and
+// the lowest finalized height whose state they keep, keptFromHeight is the
+// lowest height kept by the last prune.
+type pruneRootsFunc func(keptFromHeight uint64) (roots []common.Hash, finalHeight uint64, fromHeight uint64, err error)
+
+func newStatePruner(db incdb.Database, chainName string) *statePruner {
+	return &statePruner{
+		pruner:       trie.NewPruner(db, chainName),
+		prunedHeight: metrics.GetOrRegisterGauge(chainName+"/prune/height", nil),
+	}
+}
+
+// lockCommit must be called before the state of a block is committed, a nil
+// pruner does nothing.
+func (sp *statePruner) lockCommit() {
+	if sp != nil {
+		sp.pruner.LockCommit()
+	}
+}
+
+// unlockCommit ends the commit of the state with roots.
+func (sp *statePruner) unlockCommit(roots []common.Hash) {
+	if sp != nil {
+		sp.pruner.UnlockCommit(roots)
+	}
+}
+
+// shouldPrune reports whether the final view went StatePruneKeep blocks
+// further than the last prune, or a prune of the database was interrupted.
+func (sp *statePruner) shouldPrune(finalHeight uint64, keep uint64) bool {
+	return finalHeight >= sp.lastPruneHeight+keep || sp.pruner.HasUnfinishedPrune()
+}
+
+// start runs a prune in a new goroutine when no prune is running and the
+// final view moved far enough. The kept roots are read by pruneRoots under
+// the commit lock of the pruner, when no state is being committed.
+func (sp *statePruner) start(finalHeight uint64, keep uint64, chainName string, pruneRoots pruneRootsFunc) {
+	if sp == nil {
+		return
+	}
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	if sp.running || !sp.shouldPrune(finalHeight, keep) {
+		return
+	}
+	sp.running = true
+	lastKeptFromHeight := sp.keptFromHeight
+	go func() {
+		var prunedFinalHeight, keptFromHeight uint64
+		_, err := sp.pruner.Prune(func() ([]common.Hash, error) {
+			roots, finalHeight, fromHeight, err := pruneRoots(lastKeptFromHeight)
+			prunedFinalHeight, keptFromHeight = finalHeight, fromHeight
+			return roots, err
+		})
+		sp.lock.Lock()
+		defer sp.lock.Unlock()
+		sp.running = false
+		if err != nil {
+			Logger.log.Errorf("Failed to prune %+v state with error: %+v", chainName, NewBlockChainError(PruneStateError, err))
+			return
+		}
+		sp.lastPruneHeight = prunedFinalHeight
+		sp.keptFromHeight = keptFromHeight
+		sp.prunedHeight.Update(int64(keptFromHeight))
+	}()
+}
+
+// stateRoots returns the roots of the state tries of the view.
+func (beaconBestState *BeaconBestState) stateRoots() []common.Hash {
+	return []common.Hash{beaconBestState.ConsensusStateDBRootHash, beaconBestState.FeatureStateDBRootHash, beaconBestState.RewardStateDBRootHash, beaconBestState.SlashStateDBRootHash}
+}
+
+// stateRoots returns the roots of the state tries of the view.
+func (shardBestState *ShardBestState) stateRoots() []common.Hash {
+	return []common.Hash{shardBestState.ConsensusStateDBRootHash, shardBestState.TransactionStateDBRootHash, shardBestState.FeatureStateDBRootHash, shardBestState.RewardStateDBRootHash, shardBestState.SlashStateDBRootHash}
+}
+
+// initStatePruners creates the state pruner of every chain when state pruning
+// is enabled, and resumes the prunes interrupted by a previous shutdown.
+func (blockchain *BlockChain) initStatePruners() error {
+	if !blockchain.config.StatePrune {
+		return nil
+	}
+	blockchain.BeaconChain.statePruner = newStatePruner(blockchain.GetBeaconChainDatabase(), blockchain.BeaconChain.ChainName)
+	for _, shardChain := range blockchain.ShardChain {
+		shardChain.statePruner = newStatePruner(blockchain.GetShardChainDatabase(byte(shardChain.shardID)), shardChain.ChainName)
+	}
+	if blockchain.BeaconChain.statePruner.pruner.HasUnfinishedPrune() {
+		Logger.log.Info("Resume interrupted beacon state prune")
+		blockchain.pruneBeaconState()
+	}
+	for _, shardChain := range blockchain.ShardChain {
+		if shardChain.statePruner.pruner.HasUnfinishedPrune() {
+			Logger.log.Infof("Resume interrupted shard %+v state prune", shardChain.shardID)
+			blockchain.pruneShardState(byte(shardChain.shardID))
+		}
+	}
+	return nil
+}
+
+// pruneBeaconState starts a prune of the beacon state when the final view
+// moved far enough since the last prune.
+func (blockchain *BlockChain) pruneBeaconState() {
+	blockchain.BeaconChain.statePruner.start(blockchain.BeaconChain.GetFinalViewHeight(), blockchain.config.StatePruneKeep, blockchain.BeaconChain.ChainName, blockchain.beaconPruneRoots)
+}
+
+// beaconPruneRoots returns the roots kept by a prune of the beacon state.
+// Shards read the beacon consensus state at the beacon height of their
+// blocks, so the state is also kept down to the lowest beacon height of the
+// shards this node syncs. The slash states are never pruned: they are small
+// and a shard swap reads the slash state of any beacon block.
+func (blockchain *BlockChain) beaconPruneRoots(keptFromHeight uint64) ([]common.Hash, uint64, uint64, error) {
+	keep := blockchain.config.StatePruneKeep
+	finalHeight := blockchain.BeaconChain.GetFinalViewHeight()
+	fromHeight := uint64(1)
+	if finalHeight > keep {
+		fromHeight = finalHeight - keep + 1
+	}
+	for _, shardChain := range blockchain.ShardChain {
+		shardView := shardChain.GetFinalView().(*ShardBestState)
+		if shardView.ShardHeight <= 1 {
+			continue // this shard is not synced by the node
+		}
+		if shardView.BeaconHeight < fromHeight {
+			fromHeight = shardView.BeaconHeight
+		}
+	}
+	if fromHeight < keptFromHeight {
+		fromHeight = keptFromHeight
+	}
+
+	// views are read with the roots hash stored for their block, which is the
+	// root of their state tries
+	blockHashes := []common.Hash{}
+	for _, view := range blockchain.BeaconChain.multiView.GetAllViewsWithBFS() {
+		blockHashes = append(blockHashes, *view.GetHash())
+	}
+	db := blockchain.GetBeaconChainDatabase()
+	for height := fromHeight; height <= finalHeight; height++ {
+		hash, err := rawdbv2.GetFinalizedBeaconBlockHashByIndex(db, height)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		blockHashes = append(blockHashes, *hash)
+	}
+	roots := []common.Hash{}
+	for _, hash := range blockHashes {
+		data, err := rawdbv2.GetBeaconRootsHash(db, hash)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		bRH := &BeaconRootHash{}
+		if err := json.Unmarshal(data, bRH); err != nil {
+			return nil, 0, 0, err
+		}
+		roots = append(roots, bRH.ConsensusStateDBRootHash, bRH.FeatureStateDBRootHash, bRH.RewardStateDBRootHash, bRH.SlashStateDBRootHash)
+	}
+	slashRoots, err := rawdbv2.GetBeaconSlashRootHashes(db)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	roots = append(roots, slashRoots...)
+	Logger.log.Infof("Prune beacon state, keep finalized state from height %+v to %+v", fromHeight, finalHeight)
+	return roots, finalHeight, fromHeight, nil
+}
+
+// pruneShardState starts a prune of the state of a shard when its final view
+// moved far enough since the last prune.
+func (blockchain *BlockChain) pruneShardState(shardID byte) {
+	shardChain := blockchain.ShardChain[shardID]
+	shardChain.statePruner.start(shardChain.GetFinalViewHeight(), blockchain.config.StatePruneKeep, shardChain.ChainName, func(keptFromHeight uint64) ([]common.Hash, uint64, uint64, error) {
+		return blockchain.shardPruneRoots(shardID, keptFromHeight)
+	})
+}
+
+// shardPruneRoots returns the roots kept by a prune of the state of a shard.
+func (blockchain *BlockChain) shardPruneRoots(shardID byte, keptFromHeight uint64) ([]common.Hash, uint64, uint64, error) {
+	shardChain := blockchain.ShardChain[shardID]
+	keep := blockchain.config.StatePruneKeep
+	finalHeight := shardChain.GetFinalViewHeight()
+	fromHeight := uint64(1)
+	if finalHeight > keep {
+		fromHeight = finalHeight - keep + 1
+	}
+	if fromHeight < keptFromHeight {
+		fromHeight = keptFromHeight
+	}
+
+	blockHashes := []common.Hash{}
+	for _, view := range shardChain.multiView.GetAllViewsWithBFS() {
+		blockHashes = append(blockHashes, *view.GetHash())
+	}
+	db := blockchain.GetShardChainDatabase(shardID)
+	for height := fromHeight; height <= finalHeight; height++ {
+		hash, err := rawdbv2.GetFinalizedShardBlockHashByIndex(db, shardID, height)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		blockHashes = append(blockHashes, *hash)
+	}
+	roots := []common.Hash{}
+	for _, hash := range blockHashes {
+		data, err := rawdbv2.GetShardRootsHash(db, shardID, hash)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		sRH := &ShardRootHash{}
+		if err := json.Unmarshal(data, sRH); err != nil {
+			return nil, 0, 0, err
+		}
+		roots = append(roots, sRH.ConsensusStateDBRootHash, sRH.TransactionStateDBRootHash, sRH.FeatureStateDBRootHash, sRH.RewardStateDBRootHash, sRH.SlashStateDBRootHash)
+	}
+	Logger.log.Infof("Prune shard %+v state, keep finalized state from height %+v to %+v", shardID, fromHeight, finalHeight)
+	return roots, finalHeight, fromHeight, nil
+}
@@ -0,0 +1,97 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+// From the state roots hash break point, the header of a block commits to the
+// hash of the state roots of its previous block. A state object is proved by
+// the merkle proof of its key against one of these roots and the header of the
+// block following the one of the state.
+
+var beaconStateDBNames = []string{statedb.ConsensusStateDBName, statedb.FeatureStateDBName, statedb.RewardStateDBName, statedb.SlashStateDBName}
+var shardStateDBNames = []string{statedb.ConsensusStateDBName, statedb.TransactionStateDBName, statedb.FeatureStateDBName, statedb.RewardStateDBName, statedb.SlashStateDBName}
+
+// HashStateRoots returns the hash of the state roots of a block, committed by
+// the header of the next block
+func HashStateRoots(roots []common.Hash) common.Hash {
+	data := []byte{}
+	for _, root := range roots {
+		data = append(data, root[:]...)
+	}
+	return common.HashH(data)
+}
+
+// StateRoots returns the roots of the state tries, in the order they are hashed
+func (bRH *BeaconRootHash) StateRoots() []common.Hash {
+	return []common.Hash{bRH.ConsensusStateDBRootHash, bRH.FeatureStateDBRootHash, bRH.RewardStateDBRootHash, bRH.SlashStateDBRootHash}
+}
+
+// StateRoots returns the roots of the state tries, in the order they are hashed
+func (sRH *ShardRootHash) StateRoots() []common.Hash {
+	return []common.Hash{sRH.ConsensusStateDBRootHash, sRH.TransactionStateDBRootHash, sRH.FeatureStateDBRootHash, sRH.RewardStateDBRootHash, sRH.SlashStateDBRootHash}
+}
+
+// StateRootIndex returns the index of the root of the state database
+// stateDBName in the state roots of the beacon chain (shardID -1) or of a
+// shard chain
+func StateRootIndex(shardID int, stateDBName string) (int, error) {
+	names := shardStateDBNames
+	if shardID == -1 {
+		names = beaconStateDBNames
+	}
+	for i, name := range names {
+		if name == stateDBName {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("chain of shard %+v has no %+v state", shardID, stateDBName)
+}
+
+// previousStateRootsHash returns the state roots hash the header of a block
+// extending a view of roots must commit to at beaconHeight, empty before the
+// break point
+func (blockchain *BlockChain) previousStateRootsHash(roots []common.Hash, beaconHeight uint64) common.Hash {
+	if !blockchain.IsAfterStateRootsHashCheckPoint(beaconHeight) {
+		return common.Hash{}
+	}
+	return HashStateRoots(roots)
+}
+
+func verifyPreviousStateRootsHash(expected common.Hash, committed common.Hash) error {
+	if !expected.IsEqual(&committed) {
+		return NewBlockChainError(StateRootsHashError, fmt.Errorf("Expect previous state roots hash %+v but get %+v", expected, committed))
+	}
+	return nil
+}
+
+// VerifyStateProof checks the merkle proof of the state object at key against
+// stateRoots, the state roots of the beacon chain (shardID -1) or shard chain
+// after a block, and returns the object, nil if the proof proves there is no
+// object at key. stateRootsHash is the PreviousStateRootsHash of the header of
+// the next block, whose committee signatures the caller checked.
+func VerifyStateProof(stateRootsHash common.Hash, shardID int, stateRoots []common.Hash, objectType int, key common.Hash, proof trie.ProofList) (statedb.StateObject, error) {
+	if stateRootsHash.IsEqual(&common.Hash{}) {
+		return nil, errors.New("the header does not commit to the state roots")
+	}
+	if h := HashStateRoots(stateRoots); !h.IsEqual(&stateRootsHash) {
+		return nil, fmt.Errorf("state roots hash %+v does not match the committed hash %+v", h, stateRootsHash)
+	}
+	stateDBName, err := statedb.GetStateDBNameByObjectType(objectType)
+	if err != nil {
+		return nil, err
+	}
+	index, err := StateRootIndex(shardID, stateDBName)
+	if err != nil {
+		return nil, err
+	}
+	if index >= len(stateRoots) {
+		return nil, fmt.Errorf("expect the %+v state roots of shard %+v but get %+v", index+1, shardID, len(stateRoots))
+	}
+	return statedb.VerifyStateObjectProof(stateRoots[index], objectType, key, proof)
+}
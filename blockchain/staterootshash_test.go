@@ -0,0 +1,74 @@
+package blockchain
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateRootsHash_Header(t *testing.T) {
+	bc := &BlockChain{config: Config{ChainParams: &Params{BCHeightBreakPointStateRootsHash: 100}}}
+	roots := []common.Hash{common.HashH([]byte("consensus")), common.HashH([]byte("feature")), common.HashH([]byte("reward")), common.HashH([]byte("slash"))}
+
+	// the headers before the break point do not commit to the state roots
+	assert.Equal(t, common.Hash{}, bc.previousStateRootsHash(roots, 99))
+	assert.Equal(t, HashStateRoots(roots), bc.previousStateRootsHash(roots, 100))
+
+	// the hash of a header without state roots hash is unchanged
+	header := &BeaconHeader{Version: 1, Height: 100}
+	headerHash := header.Hash()
+	header.PreviousStateRootsHash = HashStateRoots(roots)
+	assert.NotEqual(t, headerHash, header.Hash())
+	header.PreviousStateRootsHash = common.Hash{}
+	assert.Equal(t, headerHash, header.Hash())
+
+	// a block committing to other roots is invalid
+	err := verifyPreviousStateRootsHash(bc.previousStateRootsHash(roots, 100), HashStateRoots(roots[1:]))
+	assert.True(t, IsInvalidBlockError(wrapBlockValidationError(err)))
+	assert.Nil(t, verifyPreviousStateRootsHash(bc.previousStateRootsHash(roots, 100), HashStateRoots(roots)))
+}
+
+func TestVerifyStateProof(t *testing.T) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_statedb_")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dbPath)
+	diskBD, err := incdb.Open("leveldb", dbPath)
+	assert.Nil(t, err)
+	slashStateDB, err := statedb.NewWithPrefixTrie(common.HexToHash(common.HexEmptyRoot), statedb.NewDatabaseAccessWarper(diskBD))
+	assert.Nil(t, err)
+	assert.Nil(t, statedb.StoreProducersBlackList(slashStateDB, 150, map[string]uint8{"producer": 2}))
+	slashRoot, err := slashStateDB.Commit(true)
+	assert.Nil(t, err)
+
+	key := statedb.GenerateBlackListProducerObjectKey("producer")
+	proof, err := slashStateDB.GetProof(key)
+	assert.Nil(t, err)
+	bRH := &BeaconRootHash{
+		ConsensusStateDBRootHash: common.HashH([]byte("consensus")),
+		FeatureStateDBRootHash:   common.HashH([]byte("feature")),
+		RewardStateDBRootHash:    common.HashH([]byte("reward")),
+		SlashStateDBRootHash:     slashRoot,
+	}
+	stateRootsHash := HashStateRoots(bRH.StateRoots())
+
+	object, err := VerifyStateProof(stateRootsHash, -1, bRH.StateRoots(), statedb.BlackListProducerObjectType, key, proof)
+	assert.Nil(t, err)
+	assert.NotNil(t, object)
+
+	// the roots must be the ones committed by the header
+	_, err = VerifyStateProof(common.Hash{}, -1, bRH.StateRoots(), statedb.BlackListProducerObjectType, key, proof)
+	assert.NotNil(t, err)
+	forgedRoots := bRH.StateRoots()
+	forgedRoots[0] = common.HashH([]byte("forged"))
+	_, err = VerifyStateProof(stateRootsHash, -1, forgedRoots, statedb.BlackListProducerObjectType, key, proof)
+	assert.NotNil(t, err)
+	// the proof is checked against the root of the state storing the object type
+	_, err = VerifyStateProof(stateRootsHash, -1, bRH.StateRoots(), statedb.CommitteeRewardObjectType, key, proof)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,328 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/privacy"
+)
+
+// StateCheckpoint is the final view of a chain and the data needed to restore
+// it on a node which downloads the state tries of the view instead of
+// inserting every block before it.
+type StateCheckpoint struct {
+	View      []byte // json of the final view
+	Block     []byte // json of the block of the final view
+	RootsHash []byte // json of the state roots of the block
+	StakingTx []byte // json of the staking transactions of a shard view, empty for beacon
+}
+
+// GetStateRoots returns the roots of the state tries of the checkpoint of
+// the chain shardID (-1 for beacon).
+func (cp *StateCheckpoint) GetStateRoots(shardID int) ([]common.Hash, error) {
+	if shardID == -1 {
+		bRH := &BeaconRootHash{}
+		if err := json.Unmarshal(cp.RootsHash, bRH); err != nil {
+			return nil, err
+		}
+		return []common.Hash{bRH.ConsensusStateDBRootHash, bRH.FeatureStateDBRootHash, bRH.RewardStateDBRootHash, bRH.SlashStateDBRootHash}, nil
+	}
+	sRH := &ShardRootHash{}
+	if err := json.Unmarshal(cp.RootsHash, sRH); err != nil {
+		return nil, err
+	}
+	return []common.Hash{sRH.ConsensusStateDBRootHash, sRH.TransactionStateDBRootHash, sRH.FeatureStateDBRootHash, sRH.RewardStateDBRootHash, sRH.SlashStateDBRootHash}, nil
+}
+
+func (blockchain *BlockChain) getChainDatabase(shardID int) incdb.Database {
+	if shardID == -1 {
+		return blockchain.GetBeaconChainDatabase()
+	}
+	return blockchain.GetShardChainDatabase(byte(shardID))
+}
+
+// GetStateCheckpoint returns the final view of the chain shardID (-1 for
+// beacon) as a state checkpoint.
+func (blockchain *BlockChain) GetStateCheckpoint(shardID int) (*StateCheckpoint, error) {
+	var err error
+	cp := &StateCheckpoint{}
+	if shardID == -1 {
+		view := blockchain.BeaconChain.GetFinalView().(*BeaconBestState)
+		if cp.View, err = json.Marshal(view); err != nil {
+			return nil, err
+		}
+		if cp.Block, err = json.Marshal(view.BestBlock); err != nil {
+			return nil, err
+		}
+		cp.RootsHash, err = rawdbv2.GetBeaconRootsHash(blockchain.GetBeaconChainDatabase(), view.BestBlockHash)
+		return cp, err
+	}
+	if shardID >= len(blockchain.ShardChain) {
+		return nil, fmt.Errorf("shard %+v does not exist", shardID)
+	}
+	view := blockchain.ShardChain[shardID].GetFinalView().(*ShardBestState)
+	if cp.View, err = json.Marshal(view); err != nil {
+		return nil, err
+	}
+	if cp.Block, err = json.Marshal(view.BestBlock); err != nil {
+		return nil, err
+	}
+	if cp.StakingTx, err = json.Marshal(view.StakingTx.GetMap()); err != nil {
+		return nil, err
+	}
+	cp.RootsHash, err = rawdbv2.GetShardRootsHash(blockchain.GetShardChainDatabase(byte(shardID)), byte(shardID), view.BestBlockHash)
+	return cp, err
+}
+
+// GetStateTrieNode returns the state trie node stored at hash in the database
+// of the chain shardID (-1 for beacon).
+func (blockchain *BlockChain) GetStateTrieNode(shardID int, hash common.Hash) ([]byte, error) {
+	if shardID >= len(blockchain.ShardChain) {
+		return nil, fmt.Errorf("shard %+v does not exist", shardID)
+	}
+	return blockchain.getChainDatabase(shardID).Get(hash[:])
+}
+
+// GetStateLeaves returns at most limit keys and values of the state trie at
+// root of the chain shardID (-1 for beacon) which start with prefix and come
+// after start.
+func (blockchain *BlockChain) GetStateLeaves(shardID int, root common.Hash, prefix []byte, start []byte, limit int) ([][]byte, [][]byte, error) {
+	if shardID >= len(blockchain.ShardChain) {
+		return nil, nil, fmt.Errorf("shard %+v does not exist", shardID)
+	}
+	stateDB, err := statedb.NewWithPrefixTrie(root, statedb.NewDatabaseAccessWarper(blockchain.getChainDatabase(shardID)))
+	if err != nil {
+		return nil, nil, err
+	}
+	return stateDB.GetLeavesByPrefix(prefix, start, limit)
+}
+
+// InsertBeaconStateCheckpoint replaces the views of the beacon chain with the
+// view of cp. The state tries of cp must be already downloaded into the
+// beacon database. The checkpoint block must be the block of the trusted
+// checkpoint or the last block verified to descend from it by a
+// BeaconCheckpointVerifier, a node without trusted checkpoint never
+// inserts a beacon state checkpoint. The committees restored from the tries
+// are checked against the roots in the header of the checkpoint block, the
+// other tries are only checked to be complete since block headers do not
+// commit their roots.
+func (blockchain *BlockChain) InsertBeaconStateCheckpoint(cp *StateCheckpoint) error {
+	blockchain.BeaconChain.insertLock.Lock()
+	defer blockchain.BeaconChain.insertLock.Unlock()
+	// a running prune keeps the tries of the checkpoint from now on
+	var roots []common.Hash
+	blockchain.BeaconChain.statePruner.lockCommit()
+	defer func() {
+		blockchain.BeaconChain.statePruner.unlockCommit(roots)
+	}()
+
+	view := &BeaconBestState{}
+	if err := json.Unmarshal(cp.View, view); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	block := NewBeaconBlock()
+	if err := json.Unmarshal(cp.Block, block); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	bRH := BeaconRootHash{}
+	if err := json.Unmarshal(cp.RootsHash, &bRH); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	blockHash := *block.Hash()
+	if !view.BestBlockHash.IsEqual(&blockHash) || view.BeaconHeight != block.GetHeight() {
+		return NewBlockChainError(InsertStateCheckpointError, fmt.Errorf("expect checkpoint block %+v at height %+v but get %+v at height %+v", view.BestBlockHash, view.BeaconHeight, blockHash, block.GetHeight()))
+	}
+	trusted := blockchain.config.TrustedCheckpoint
+	if trusted == nil {
+		return NewBlockChainError(InsertStateCheckpointError, errors.New("no trusted checkpoint to verify the beacon state checkpoint"))
+	}
+	if block.GetHeight() < trusted.Height {
+		return NewBlockChainError(InsertStateCheckpointError, fmt.Errorf("checkpoint height %+v is lower than trusted checkpoint height %+v", block.GetHeight(), trusted.Height))
+	}
+	if err := blockchain.checkTrustedCheckpoint(block.GetHeight(), blockHash); err != nil {
+		return err
+	}
+	if !blockchain.isVerifiedCheckpoint(blockHash) {
+		return NewBlockChainError(InsertStateCheckpointError, fmt.Errorf("checkpoint block %+v is not verified to descend from the trusted checkpoint", blockHash))
+	}
+	if block.GetHeight() <= blockchain.BeaconChain.GetFinalViewHeight() {
+		return NewBlockChainError(InsertStateCheckpointError, fmt.Errorf("checkpoint height %+v is not higher than final height %+v", block.GetHeight(), blockchain.BeaconChain.GetFinalViewHeight()))
+	}
+
+	view.ConsensusStateDBRootHash = bRH.ConsensusStateDBRootHash
+	view.FeatureStateDBRootHash = bRH.FeatureStateDBRootHash
+	view.RewardStateDBRootHash = bRH.RewardStateDBRootHash
+	view.SlashStateDBRootHash = bRH.SlashStateDBRootHash
+	roots = view.stateRoots()
+	if err := view.InitStateRootHash(blockchain); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	view.BestBlock = *block
+	if view.RewardReceiver == nil {
+		view.RewardReceiver = make(map[string]privacy.PaymentAddress)
+	}
+	restores := []func() error{
+		view.RestoreBeaconCommittee,
+		view.RestoreShardCommittee,
+		view.RestoreBeaconPendingValidator,
+		view.RestoreShardPendingValidator,
+		view.RestoreCandidateBeaconWaitingForCurrentRandom,
+		view.RestoreCandidateBeaconWaitingForNextRandom,
+		view.RestoreCandidateShardWaitingForCurrentRandom,
+		view.RestoreCandidateShardWaitingForNextRandom,
+	}
+	for _, restore := range restores {
+		if err := restore(); err != nil {
+			return NewBlockChainError(InsertStateCheckpointError, err)
+		}
+	}
+	sIDs := []int{}
+	for i := 0; i < view.ActiveShards; i++ {
+		sIDs = append(sIDs, i)
+	}
+	view.AutoStaking = NewMapStringBool()
+	view.AutoStaking.data = statedb.GetMapAutoStaking(view.consensusStateDB, sIDs)
+	if err := view.verifyCommitteeRoots(block); err != nil {
+		return err
+	}
+
+	batch := blockchain.GetBeaconChainDatabase().NewBatch()
+	if err := rawdbv2.StoreBeaconRootsHash(batch, blockHash, bRH); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	if err := rawdbv2.StoreBeaconSlashRootHash(batch, bRH.SlashStateDBRootHash); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	if err := rawdbv2.StoreBeaconBlockByHash(batch, blockHash, block); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	if err := rawdbv2.StoreFinalizedBeaconBlockHashByIndex(batch, block.GetHeight(), blockHash); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	blockchain.BeaconChain.multiView.Reset()
+	if !blockchain.BeaconChain.multiView.AddView(view) {
+		return NewBlockChainError(InsertStateCheckpointError, errors.New("cannot add checkpoint view"))
+	}
+	if err := blockchain.BackupBeaconViews(batch); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	if err := batch.Write(); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	Logger.log.Infof("Insert beacon state checkpoint at height %+v, hash %+v", block.GetHeight(), blockHash)
+	return nil
+}
+
+// InsertShardStateCheckpoint replaces the views of the shard chain with the
+// view of cp. The state tries of cp must be already downloaded into the shard
+// database, and the beacon block of the view must be finalized on this node.
+// The committees and staking transactions of the view are checked against the
+// roots in the header of the checkpoint block.
+func (blockchain *BlockChain) InsertShardStateCheckpoint(shardID byte, cp *StateCheckpoint) error {
+	if int(shardID) >= len(blockchain.ShardChain) {
+		return NewBlockChainError(InsertStateCheckpointError, fmt.Errorf("shard %+v does not exist", shardID))
+	}
+	shardChain := blockchain.ShardChain[shardID]
+	shardChain.insertLock.Lock()
+	defer shardChain.insertLock.Unlock()
+	// a running prune keeps the tries of the checkpoint from now on
+	var roots []common.Hash
+	shardChain.statePruner.lockCommit()
+	defer func() {
+		shardChain.statePruner.unlockCommit(roots)
+	}()
+
+	view := &ShardBestState{}
+	if err := json.Unmarshal(cp.View, view); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	block := NewShardBlock()
+	if err := json.Unmarshal(cp.Block, block); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	sRH := ShardRootHash{}
+	if err := json.Unmarshal(cp.RootsHash, &sRH); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	stakingTx := make(map[string]string)
+	if err := json.Unmarshal(cp.StakingTx, &stakingTx); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	blockHash := *block.Hash()
+	if view.ShardID != shardID || block.Header.ShardID != shardID {
+		return NewBlockChainError(InsertStateCheckpointError, fmt.Errorf("expect checkpoint of shard %+v but get view of shard %+v, block of shard %+v", shardID, view.ShardID, block.Header.ShardID))
+	}
+	if !view.BestBlockHash.IsEqual(&blockHash) || view.ShardHeight != block.GetHeight() {
+		return NewBlockChainError(InsertStateCheckpointError, fmt.Errorf("expect checkpoint block %+v at height %+v but get %+v at height %+v", view.BestBlockHash, view.ShardHeight, blockHash, block.GetHeight()))
+	}
+	if block.GetHeight() <= shardChain.GetFinalViewHeight() {
+		return NewBlockChainError(InsertStateCheckpointError, fmt.Errorf("checkpoint height %+v is not higher than final height %+v", block.GetHeight(), shardChain.GetFinalViewHeight()))
+	}
+	// the next shard blocks are processed with the beacon blocks after the
+	// beacon height of the view, which must be on the beacon chain of this node
+	if view.BeaconHeight != block.Header.BeaconHeight || !view.BestBeaconHash.IsEqual(&block.Header.BeaconHash) {
+		return NewBlockChainError(InsertStateCheckpointError, fmt.Errorf("checkpoint view beacon block %+v does not match block beacon block %+v", view.BestBeaconHash, block.Header.BeaconHash))
+	}
+	beaconHash, err := rawdbv2.GetFinalizedBeaconBlockHashByIndex(blockchain.GetBeaconChainDatabase(), view.BeaconHeight)
+	if err != nil || !beaconHash.IsEqual(&view.BestBeaconHash) {
+		return NewBlockChainError(InsertStateCheckpointError, fmt.Errorf("beacon block %+v at height %+v is not finalized on this node", view.BestBeaconHash, view.BeaconHeight))
+	}
+
+	db := blockchain.GetShardChainDatabase(shardID)
+	view.ConsensusStateDBRootHash = sRH.ConsensusStateDBRootHash
+	view.TransactionStateDBRootHash = sRH.TransactionStateDBRootHash
+	view.FeatureStateDBRootHash = sRH.FeatureStateDBRootHash
+	view.RewardStateDBRootHash = sRH.RewardStateDBRootHash
+	view.SlashStateDBRootHash = sRH.SlashStateDBRootHash
+	roots = view.stateRoots()
+	if err := view.InitStateRootHash(db, blockchain); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	view.BestBlock = block
+	if err := view.RestoreCommittee(shardID, blockchain); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	if err := view.RestorePendingValidators(shardID, blockchain); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	// staking transactions are indexed from the blocks which include them, so
+	// they come with the checkpoint and are checked against the header
+	view.StakingTx = NewMapStringString()
+	view.StakingTx.data = stakingTx
+	if err := view.verifyCommitteeRoots(block); err != nil {
+		return err
+	}
+	if view.BeaconHeight > blockchain.config.ChainParams.ReplaceStakingTxHeight {
+		if hash, ok := verifyHashFromMapStringString(stakingTx, block.Header.StakingTxRoot); !ok {
+			return NewBlockChainError(ShardStakingTxRootHashError, fmt.Errorf("Expect shard staking root hash to be %+v but get %+v", block.Header.StakingTxRoot, hash))
+		}
+	}
+
+	batch := db.NewBatch()
+	if err := rawdbv2.StoreShardRootsHash(batch, shardID, blockHash, sRH); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	if err := rawdbv2.StoreShardBlock(batch, blockHash, block); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	if err := rawdbv2.StoreFinalizedShardBlockHashByIndex(batch, shardID, block.GetHeight(), blockHash); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	shardChain.multiView.Reset()
+	if !shardChain.multiView.AddView(view) {
+		return NewBlockChainError(InsertStateCheckpointError, errors.New("cannot add checkpoint view"))
+	}
+	if err := blockchain.BackupShardViews(batch, shardID); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	if err := batch.Write(); err != nil {
+		return NewBlockChainError(InsertStateCheckpointError, err)
+	}
+	Logger.log.Infof("Insert shard %+v state checkpoint at height %+v, hash %+v", shardID, block.GetHeight(), blockHash)
+	return nil
+}
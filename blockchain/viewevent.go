@@ -0,0 +1,83 @@
+package blockchain
+
+import (
+	"sync/atomic"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/multiview"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+// ViewEvent is published on NewBestViewTopic and NewFinalViewTopic when the
+// best or the final view of a chain changes.
+type ViewEvent struct {
+	ChainID  int    // -1 for beacon
+	Sequence uint64 // order of the event among the view and reorg events of the chain
+	Height   uint64
+	Hash     common.Hash
+}
+
+// ReorgEvent is published on ReorgTopic when the best view of a chain moves
+// to a branch not containing the previous best view.
+type ReorgEvent struct {
+	ChainID              int    // -1 for beacon
+	Sequence             uint64 // order of the event among the view and reorg events of the chain
+	OldTip               common.Hash
+	OldTipHeight         uint64
+	NewTip               common.Hash
+	NewTipHeight         uint64
+	CommonAncestor       common.Hash
+	CommonAncestorHeight uint64
+	DroppedBlocks        []common.Hash           // blocks of the old branch after the common ancestor, from the old tip
+	Blocks               []common.BlockInterface `json:"-"` // the dropped blocks
+}
+
+// viewEventPublisher publishes the view changes of the multiview of a chain.
+// The pubsub manager delivers the messages of different topics, and of a same
+// topic, in any order, so the events of a chain are numbered in the order of
+// the changes (eg. a reorg before its new best view) and subscribers order
+// them by Sequence. Publishing does not block on the subscribers.
+type viewEventPublisher struct {
+	sequence      uint64 // last sequence number, accessed atomically, first for its 64-bit alignment
+	chainID       int
+	pubSubManager *pubsub.PubSubManager
+}
+
+func newViewEventPublisher(chainID int, pubSubManager *pubsub.PubSubManager) *viewEventPublisher {
+	return &viewEventPublisher{chainID: chainID, pubSubManager: pubSubManager}
+}
+
+func (p *viewEventPublisher) newViewEvent(view multiview.View) *ViewEvent {
+	return &ViewEvent{ChainID: p.chainID, Sequence: p.nextSequence(), Height: view.GetHeight(), Hash: *view.GetHash()}
+}
+
+func (p *viewEventPublisher) nextSequence() uint64 {
+	return atomic.AddUint64(&p.sequence, 1)
+}
+
+func (p *viewEventPublisher) OnNewBestView(view multiview.View) {
+	p.pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewBestViewTopic, p.newViewEvent(view)))
+}
+
+func (p *viewEventPublisher) OnNewFinalView(view multiview.View) {
+	p.pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.NewFinalViewTopic, p.newViewEvent(view)))
+}
+
+func (p *viewEventPublisher) OnReorg(reorg *multiview.Reorg) {
+	event := &ReorgEvent{
+		ChainID:              p.chainID,
+		Sequence:             p.nextSequence(),
+		OldTip:               *reorg.OldTip.GetHash(),
+		OldTipHeight:         reorg.OldTip.GetHeight(),
+		NewTip:               *reorg.NewTip.GetHash(),
+		NewTipHeight:         reorg.NewTip.GetHeight(),
+		CommonAncestor:       *reorg.CommonAncestor.GetHash(),
+		CommonAncestorHeight: reorg.CommonAncestor.GetHeight(),
+	}
+	for _, view := range reorg.Dropped {
+		event.DroppedBlocks = append(event.DroppedBlocks, *view.GetHash())
+		event.Blocks = append(event.Blocks, view.GetBlock())
+	}
+	Logger.log.Infof("Chain %+v reorg from %+v at height %+v to %+v at height %+v, %+v blocks dropped", p.chainID, event.OldTip, event.OldTipHeight, event.NewTip, event.NewTipHeight, len(event.DroppedBlocks))
+	p.pubSubManager.PublishMessage(pubsub.NewMessage(pubsub.ReorgTopic, event))
+}
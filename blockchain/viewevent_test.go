@@ -0,0 +1,48 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/multiview"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewEventPublisher_Sequence(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	pubSubManager := pubsub.NewPubSubManager()
+	go pubSubManager.Start()
+	_, bestViewChan, err := pubSubManager.RegisterNewSubscriber(pubsub.NewBestViewTopic)
+	assert.Nil(t, err)
+	_, reorgChan, err := pubSubManager.RegisterNewSubscriber(pubsub.ReorgTopic)
+	assert.Nil(t, err)
+
+	newView := func(height uint64) *BeaconBestState {
+		view := &BeaconBestState{}
+		view.BestBlock.Header.Height = height
+		return view
+	}
+	ancestor, oldTip, newTip := newView(1), newView(2), newView(3)
+	publisher := newViewEventPublisher(-1, pubSubManager)
+	publisher.OnReorg(&multiview.Reorg{OldTip: oldTip, NewTip: newTip, CommonAncestor: ancestor, Dropped: []multiview.View{oldTip}})
+	publisher.OnNewBestView(newTip)
+
+	// the topics are delivered in any order, the sequence gives the order of the changes
+	var reorg *ReorgEvent
+	var bestView *ViewEvent
+	for reorg == nil || bestView == nil {
+		select {
+		case msg := <-reorgChan:
+			reorg = msg.Value.(*ReorgEvent)
+		case msg := <-bestViewChan:
+			bestView = msg.Value.(*ViewEvent)
+		case <-time.After(5 * time.Second):
+			t.Fatal("events not delivered")
+		}
+	}
+	assert.Equal(t, uint64(1), reorg.Sequence)
+	assert.Equal(t, uint64(2), bestView.Sequence)
+	assert.Equal(t, uint64(3), bestView.Height)
+}
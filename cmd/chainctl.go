@@ -16,6 +16,7 @@ import (
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/badgerdb"
 	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/pubsub"
@@ -27,7 +28,7 @@ func makeBlockChain(databaseDir string, testNet bool) (*blockchain.BlockChain, e
 	mempool.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
 	dataaccessobject.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
 	trie.Logger.Init(common.NewBackend(nil).Logger("ChainCMD", true))
-	db, err := incdb.Open("leveldb", filepath.Join(databaseDir))
+	db, err := incdb.OpenMultipleDB("leveldb", filepath.Join(databaseDir))
 	if err != nil {
 		return nil, err
 	}
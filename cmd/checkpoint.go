@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+)
+
+// getTrustedCheckpoint returns the unsigned checkpoint of the final beacon
+// view of the running node at rpcEndpoint.
+func getTrustedCheckpoint(rpcEndpoint string) (*blockchain.TrustedCheckpoint, error) {
+	request, err := json.Marshal(map[string]interface{}{
+		"Jsonrpc": "1.0",
+		"Method":  "gettrustedcheckpoint",
+		"Params":  []interface{}{},
+		"Id":      1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Timeout: time.Minute}
+	resp, err := client.Post(rpcEndpoint, "application/json", bytes.NewReader(request))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	response := struct {
+		Result *blockchain.TrustedCheckpoint
+		Error  *struct {
+			Code    int
+			Message string
+		}
+	}{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+	if response.Error != nil {
+		return nil, fmt.Errorf("%d: %s", response.Error.Code, response.Error.Message)
+	}
+	if response.Result == nil {
+		return nil, errors.New("empty checkpoint")
+	}
+	return response.Result, nil
+}
+
+// generateCheckpoint signs the final beacon view of a running node with
+// privateKey and writes it to a JSON file, to be given to a node with
+// --checkpoint. The node trusts the checkpoint if the payment address of
+// privateKey is one of its --checkpointsigner.
+func generateCheckpoint(rpcEndpoint string, privateKey string, fileName string) error {
+	cp, err := getTrustedCheckpoint(rpcEndpoint)
+	if err != nil {
+		return err
+	}
+	if err := cp.Sign(privateKey); err != nil {
+		return err
+	}
+	result, err := parseToJsonString(cp)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(fileName, result, 0644); err != nil {
+		return err
+	}
+	log.Printf("Checkpoint of beacon height %+v hash %+v signed by %+v", cp.Height, cp.Hash.String(), cp.Signer)
+	return nil
+}
@@ -39,6 +39,14 @@ type params struct {
 	ChainDataDir string `long:"chaindatadir" description:"Directory of Stored Blockchain Database"`
 	OutDataDir   string `long:"outdatadir" description:"Directory of Export Blockchain Data"`
 	FileName     string `long:"filename" description:"Filename of Backup Blockchin Data"`
+	// migrate database
+	FromDBDriver string `long:"fromdbdriver" description:"Database driver of the chain data in chaindatadir"`
+	ToDBDriver   string `long:"todbdriver" description:"Database driver of the chain data written to outdatadir"`
+	// slashing protection
+	SlashingProtectionDir string `long:"slashingprotectiondir" description:"Directory of the slashing protection database of a node"`
+	// checkpoint
+	RPCEndpoint string `long:"rpcendpoint" description:"RPC endpoint of a running node"`
+	PrivateKey  string `long:"privatekey" description:"Private key in base58 signing the checkpoint"`
 	// wallet
 	WalletName        string `long:"wallet" description:"Wallet Database Name file, default is 'wallet'"`
 	WalletPassphrase  string `long:"walletpassphrase" description:"Wallet passphrase"`
@@ -58,8 +66,9 @@ func newConfigParser(cfg *params, options flags.Options) *flags.Parser {
 
 func loadParams() (*params, error) {
 	cfg := params{
-		DataDir: defaultDataDir,
-		TestNet: false,
+		DataDir:      defaultDataDir,
+		TestNet:      false,
+		FromDBDriver: "leveldb",
 	}
 
 	preParser := newConfigParser(&cfg, flags.HelpFlag)
@@ -1,13 +1,17 @@
 package main
 
 const (
-	createWalletCmd        = "createwallet"
-	listWalletAccountCmd   = "listaccounts"
-	getWalletAccountCmd    = "getaccount"
-	createWalletAccountCmd = "createaccount"
-	getPrivacyTokenID      = "getprivacytokenid"
-	backupChain            = "backupchain"
-	restoreChain           = "restorechain"
+	createWalletCmd          = "createwallet"
+	listWalletAccountCmd     = "listaccounts"
+	getWalletAccountCmd      = "getaccount"
+	createWalletAccountCmd   = "createaccount"
+	getPrivacyTokenID        = "getprivacytokenid"
+	backupChain              = "backupchain"
+	restoreChain             = "restorechain"
+	migrateDB                = "migratedb"
+	exportSlashingProtection = "exportslashingprotection"
+	importSlashingProtection = "importslashingprotection"
+	genCheckpoint            = "gencheckpoint"
 )
 
 var CmdList = []string{
@@ -18,4 +22,8 @@ var CmdList = []string{
 	getPrivacyTokenID,
 	backupChain,
 	restoreChain,
+	migrateDB,
+	exportSlashingProtection,
+	importSlashingProtection,
+	genCheckpoint,
 }
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"syscall"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+const migrateCheckpointFile = "migratedb.checkpoint"
+
+// migrateCheckpoint records how far the copy of each chain database went, so
+// an interrupted migration restarts where it stopped. Keys are copied by
+// first byte buckets, LastKey is the last key written in the current bucket.
+type migrateCheckpoint struct {
+	FromDriver string
+	ToDriver   string
+	Chains     map[int]*chainCheckpoint
+}
+
+type chainCheckpoint struct {
+	Bucket  int
+	LastKey []byte
+	Keys    uint64
+	Done    bool
+}
+
+func loadMigrateCheckpoint(file string, fromDriver string, toDriver string) (*migrateCheckpoint, error) {
+	checkpoint := &migrateCheckpoint{
+		FromDriver: fromDriver,
+		ToDriver:   toDriver,
+		Chains:     make(map[int]*chainCheckpoint),
+	}
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, err
+	}
+	if checkpoint.FromDriver != fromDriver || checkpoint.ToDriver != toDriver {
+		return nil, fmt.Errorf("checkpoint %+v is for a migration from %+v to %+v", file, checkpoint.FromDriver, checkpoint.ToDriver)
+	}
+	return checkpoint, nil
+}
+
+// save writes the checkpoint atomically, a crash never leaves a partial file.
+func (checkpoint *migrateCheckpoint) save(file string) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(file+"_", data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(file+"_", file)
+}
+
+// migrateDatabase copies the beacon and shard databases found in srcDir with
+// fromDriver into dstDir with toDriver, then checks the roots hash of every
+// finalized block is the same in both layouts.
+func migrateDatabase(srcDir string, fromDriver string, dstDir string, toDriver string) error {
+	if srcDir == "" || dstDir == "" {
+		return fmt.Errorf("source and destination database directory must be set")
+	}
+	if filepath.Clean(srcDir) == filepath.Clean(dstDir) {
+		return fmt.Errorf("source and destination database directory must be different")
+	}
+	if err := os.MkdirAll(dstDir, 0700); err != nil {
+		return err
+	}
+	checkpointFile := filepath.Join(dstDir, migrateCheckpointFile)
+	checkpoint, err := loadMigrateCheckpoint(checkpointFile, fromDriver, toDriver)
+	if err != nil {
+		return err
+	}
+	srcDBs, err := incdb.OpenMultipleDB(fromDriver, srcDir)
+	if err != nil {
+		return err
+	}
+	defer closeDatabases(srcDBs)
+	dstDBs, err := incdb.OpenMultipleDB(toDriver, dstDir)
+	if err != nil {
+		return err
+	}
+	defer closeDatabases(dstDBs)
+
+	// Watch for Ctrl-C while the migration is running.
+	// If a signal is received, the migration will stop at the next batch.
+	interrupt := make(chan os.Signal, 1)
+	stop := make(chan struct{})
+	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
+	defer func() {
+		// stop the signal delivery before closing, a late signal would send on the closed channel
+		signal.Stop(interrupt)
+		close(interrupt)
+	}()
+	go func() {
+		if _, ok := <-interrupt; ok {
+			log.Println("Interrupted during migration, stopping at next batch")
+		}
+		close(stop)
+	}()
+	checkInterrupt := func() bool {
+		select {
+		case <-stop:
+			return true
+		default:
+			return false
+		}
+	}
+
+	for _, chainID := range sortedChainIDs(srcDBs) {
+		if _, ok := checkpoint.Chains[chainID]; !ok {
+			checkpoint.Chains[chainID] = &chainCheckpoint{}
+		}
+		if checkpoint.Chains[chainID].Done {
+			log.Printf("Chain %+v already migrated, %+v keys", chainID, checkpoint.Chains[chainID].Keys)
+			continue
+		}
+		err := migrateChainDatabase(srcDBs[chainID], dstDBs[chainID], checkpoint.Chains[chainID], func() error {
+			return checkpoint.save(checkpointFile)
+		}, checkInterrupt)
+		if err != nil {
+			return fmt.Errorf("migrate chain %+v failed, %+v", chainID, err)
+		}
+		log.Printf("Migrate chain %+v successfully, %+v keys", chainID, checkpoint.Chains[chainID].Keys)
+	}
+
+	if err := verifyBeaconRootsHash(srcDBs[common.BeaconChainDataBaseID], dstDBs[common.BeaconChainDataBaseID]); err != nil {
+		return err
+	}
+	for shardID := 0; shardID < common.MaxShardNumber; shardID++ {
+		if err := verifyShardRootsHash(srcDBs[shardID], dstDBs[shardID], byte(shardID)); err != nil {
+			return err
+		}
+	}
+	log.Printf("Migrate database from %+v to %+v successfully", fromDriver, toDriver)
+	return nil
+}
+
+// migrateChainDatabase streams every key of src into dst by batches, saving
+// the checkpoint after each written batch.
+func migrateChainDatabase(src incdb.Database, dst incdb.Database, checkpoint *chainCheckpoint, save func() error, checkInterrupt func() bool) error {
+	batch := dst.NewBatch()
+	var lastKey []byte
+	flush := func(bucket int) error {
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+		checkpoint.Bucket = bucket
+		checkpoint.LastKey = lastKey
+		return save()
+	}
+	for bucket := checkpoint.Bucket; bucket <= 0xff; bucket++ {
+		resumeKey := []byte(nil)
+		if bucket == checkpoint.Bucket {
+			resumeKey = checkpoint.LastKey
+		}
+		lastKey = resumeKey
+		iter := src.NewIteratorWithPrefix([]byte{byte(bucket)})
+		for iter.Next() {
+			if resumeKey != nil && bytes.Compare(iter.Key(), resumeKey) <= 0 {
+				continue
+			}
+			if err := batch.Put(iter.Key(), iter.Value()); err != nil {
+				iter.Release()
+				return err
+			}
+			lastKey = append([]byte{}, iter.Key()...)
+			checkpoint.Keys++
+			if batch.ValueSize() >= incdb.IdealBatchSize {
+				if err := flush(bucket); err != nil {
+					iter.Release()
+					return err
+				}
+				if checkInterrupt() {
+					iter.Release()
+					return fmt.Errorf("interrupted, run the same command again to resume")
+				}
+			}
+		}
+		err := iter.Error()
+		iter.Release()
+		if err != nil {
+			return err
+		}
+		lastKey = nil
+		if err := flush(bucket + 1); err != nil {
+			return err
+		}
+	}
+	checkpoint.Done = true
+	return save()
+}
+
+func verifyBeaconRootsHash(src incdb.Database, dst incdb.Database) error {
+	for height := uint64(1); ; height++ {
+		hash, err := rawdbv2.GetFinalizedBeaconBlockHashByIndex(src, height)
+		if err != nil {
+			log.Printf("Verify beacon roots hash successfully, %+v blocks", height-1)
+			return nil
+		}
+		if err := compareRootsHash(height, func(db incdb.Database) ([]byte, error) {
+			return rawdbv2.GetBeaconRootsHash(db, *hash)
+		}, src, dst); err != nil {
+			return fmt.Errorf("beacon %+v", err)
+		}
+	}
+}
+
+func verifyShardRootsHash(src incdb.Database, dst incdb.Database, shardID byte) error {
+	for height := uint64(1); ; height++ {
+		hash, err := rawdbv2.GetFinalizedShardBlockHashByIndex(src, shardID, height)
+		if err != nil {
+			log.Printf("Verify shard %+v roots hash successfully, %+v blocks", shardID, height-1)
+			return nil
+		}
+		if err := compareRootsHash(height, func(db incdb.Database) ([]byte, error) {
+			return rawdbv2.GetShardRootsHash(db, shardID, *hash)
+		}, src, dst); err != nil {
+			return fmt.Errorf("shard %+v %+v", shardID, err)
+		}
+	}
+}
+
+func compareRootsHash(height uint64, getRootsHash func(incdb.Database) ([]byte, error), src incdb.Database, dst incdb.Database) error {
+	srcRootsHash, srcErr := getRootsHash(src)
+	dstRootsHash, err := getRootsHash(dst)
+	if srcErr != nil {
+		// genesis and pruned blocks may have no roots hash, then the migrated block has none either
+		if err == nil {
+			return fmt.Errorf("block %+v roots hash not found in source database but found in migrated database, %+v", height, srcErr)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("block %+v roots hash not found in migrated database, %+v", height, err)
+	}
+	if !bytes.Equal(srcRootsHash, dstRootsHash) {
+		return fmt.Errorf("block %+v roots hash mismatch, expect %+v, got %+v", height, string(srcRootsHash), string(dstRootsHash))
+	}
+	return nil
+}
+
+func sortedChainIDs(dbs map[int]incdb.Database) []int {
+	chainIDs := make([]int, 0, len(dbs))
+	for chainID := range dbs {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Ints(chainIDs)
+	return chainIDs
+}
+
+func closeDatabases(dbs map[int]incdb.Database) {
+	for _, db := range dbs {
+		db.Close()
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateDatabase(t *testing.T) {
+	root, err := ioutil.TempDir(os.TempDir(), "test_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %+v", err)
+	}
+	defer os.RemoveAll(root)
+	srcDir := filepath.Join(root, "block")
+	dstDir := filepath.Join(root, "block_badger")
+
+	srcDBs, err := incdb.OpenMultipleDB("leveldb", srcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	beaconHash := common.HashH([]byte("beacon"))
+	shardHash := common.HashH([]byte("shard"))
+	assert.Equal(t, nil, rawdbv2.StoreFinalizedBeaconBlockHashByIndex(srcDBs[common.BeaconChainDataBaseID], 1, beaconHash))
+	assert.Equal(t, nil, rawdbv2.StoreBeaconRootsHash(srcDBs[common.BeaconChainDataBaseID], beaconHash, "beacon roots"))
+	assert.Equal(t, nil, rawdbv2.StoreFinalizedShardBlockHashByIndex(srcDBs[0], 0, 1, shardHash))
+	assert.Equal(t, nil, rawdbv2.StoreShardRootsHash(srcDBs[0], 0, shardHash, "shard roots"))
+	for i := 0; i < 1000; i++ {
+		key := common.HashH([]byte{byte(i), byte(i >> 8)})
+		assert.Equal(t, nil, srcDBs[1].Put(key[:], make([]byte, 1024)))
+	}
+	closeDatabases(srcDBs)
+
+	// a partial checkpoint is resumed
+	checkpoint := &migrateCheckpoint{
+		FromDriver: "leveldb",
+		ToDriver:   "badgerdb",
+		Chains: map[int]*chainCheckpoint{
+			common.BeaconChainDataBaseID: {Bucket: 256, Done: true},
+		},
+	}
+	assert.Equal(t, nil, os.MkdirAll(dstDir, 0700))
+	assert.Equal(t, nil, checkpoint.save(filepath.Join(dstDir, migrateCheckpointFile)))
+	err = migrateDatabase(srcDir, "leveldb", dstDir, "badgerdb")
+	assert.NotEqual(t, nil, err, "beacon roots hash was skipped by the checkpoint")
+
+	os.Remove(filepath.Join(dstDir, migrateCheckpointFile))
+	err = migrateDatabase(srcDir, "leveldb", dstDir, "badgerdb")
+	assert.Equal(t, nil, err)
+
+	// a done migration is not copied again
+	err = migrateDatabase(srcDir, "leveldb", dstDir, "badgerdb")
+	assert.Equal(t, nil, err)
+	err = migrateDatabase(srcDir, "leveldb", dstDir, "leveldb")
+	assert.NotEqual(t, nil, err)
+
+	dstDBs, err := incdb.OpenMultipleDB("badgerdb", dstDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeDatabases(dstDBs)
+	rootsHash, err := rawdbv2.GetShardRootsHash(dstDBs[0], 0, shardHash)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `"shard roots"`, string(rootsHash))
+	count := 0
+	iter := dstDBs[1].NewIterator()
+	for iter.Next() {
+		count++
+	}
+	iter.Release()
+	assert.Equal(t, 1000, count)
+}
+
+func TestCompareRootsHash(t *testing.T) {
+	root, err := ioutil.TempDir(os.TempDir(), "test_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %+v", err)
+	}
+	defer os.RemoveAll(root)
+	src, err := incdb.Open("leveldb", filepath.Join(root, "src"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+	dst, err := incdb.Open("leveldb", filepath.Join(root, "dst"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+	hash := common.HashH([]byte("beacon"))
+	getRootsHash := func(db incdb.Database) ([]byte, error) {
+		return rawdbv2.GetBeaconRootsHash(db, hash)
+	}
+
+	// missing in both databases
+	assert.Equal(t, nil, compareRootsHash(1, getRootsHash, src, dst))
+
+	// missing in the source only
+	assert.Equal(t, nil, rawdbv2.StoreBeaconRootsHash(dst, hash, "beacon roots"))
+	assert.NotEqual(t, nil, compareRootsHash(1, getRootsHash, src, dst))
+
+	assert.Equal(t, nil, rawdbv2.StoreBeaconRootsHash(src, hash, "beacon roots"))
+	assert.Equal(t, nil, compareRootsHash(1, getRootsHash, src, dst))
+	assert.Equal(t, nil, rawdbv2.StoreBeaconRootsHash(src, hash, "other roots"))
+	assert.NotEqual(t, nil, compareRootsHash(1, getRootsHash, src, dst))
+}
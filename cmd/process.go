@@ -213,5 +213,49 @@ func processCmd() {
 				}
 			}
 		}
+	case migrateDB:
+		{
+			if cfg.ToDBDriver == "" {
+				log.Println("No Database Driver to Migrate to")
+				return
+			}
+			err := migrateDatabase(cfg.ChainDataDir, cfg.FromDBDriver, cfg.OutDataDir, cfg.ToDBDriver)
+			if err != nil {
+				log.Printf("Migrate database failed, err %+v", err)
+			}
+		}
+	case exportSlashingProtection:
+		{
+			if cfg.SlashingProtectionDir == "" || cfg.FileName == "" {
+				log.Println("Wrong param")
+				return
+			}
+			err := exportSlashingProtectionData(cfg.SlashingProtectionDir, cfg.FileName)
+			if err != nil {
+				log.Printf("Export slashing protection data failed, err %+v", err)
+			}
+		}
+	case importSlashingProtection:
+		{
+			if cfg.SlashingProtectionDir == "" || cfg.FileName == "" {
+				log.Println("Wrong param")
+				return
+			}
+			err := importSlashingProtectionData(cfg.SlashingProtectionDir, cfg.FileName)
+			if err != nil {
+				log.Printf("Import slashing protection data failed, err %+v", err)
+			}
+		}
+	case genCheckpoint:
+		{
+			if cfg.RPCEndpoint == "" || cfg.PrivateKey == "" || cfg.FileName == "" {
+				log.Println("Wrong param")
+				return
+			}
+			err := generateCheckpoint(cfg.RPCEndpoint, cfg.PrivateKey, cfg.FileName)
+			if err != nil {
+				log.Printf("Generate checkpoint failed, err %+v", err)
+			}
+		}
 	}
 }
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// exportSlashingProtectionData writes the votes and proposals recorded in the
+// slashing protection database of a node to a JSON file, which can be imported
+// on the host the validator moves to. The node must be stopped.
+func exportSlashingProtectionData(slashingProtectionDir string, fileName string) error {
+	db, err := incdb.Open("leveldb", slashingProtectionDir)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	data, err := blsbftv2.NewSlashingProtectionDB(db).Export()
+	if err != nil {
+		return err
+	}
+	result, err := parseToJsonString(data)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(fileName, result, 0600); err != nil {
+		return err
+	}
+	for _, v := range data.Validators {
+		log.Printf("Export %+v votes and %+v proposals of %+v", len(v.Votes), len(v.Proposals), v.Validator)
+	}
+	return nil
+}
+
+// importSlashingProtectionData merges an exported JSON file into the slashing
+// protection database of a node. The node must be stopped.
+func importSlashingProtectionData(slashingProtectionDir string, fileName string) error {
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	data := new(blsbftv2.SlashingProtectionData)
+	if err := json.Unmarshal(content, data); err != nil {
+		return err
+	}
+	db, err := incdb.Open("leveldb", slashingProtectionDir)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if err := blsbftv2.NewSlashingProtectionDB(db).Import(data); err != nil {
+		return err
+	}
+	for _, v := range data.Validators {
+		log.Printf("Import %+v votes and %+v proposals of %+v", len(v.Votes), len(v.Proposals), v.Validator)
+	}
+	return nil
+}
@@ -12,10 +12,13 @@ import (
 	"path/filepath"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/jessevdk/go-flags"
 )
 
@@ -25,6 +28,10 @@ const (
 	DefaultDataDirname                 = "data"
 	DefaultDatabaseDirname             = "block"
 	DefaultDatabaseMempoolDirname      = "mempool"
+	DefaultSlashingProtectionDirname   = "slashingprotection"
+	DefaultDatabaseDriver              = "leveldb"
+	DefaultStatePruneKeep              = uint64(1000)
+	DefaultSyncLookahead               = 32
 	DefaultLogLevel                    = "info"
 	DefaultLogDirname                  = "logs"
 	DefaultLogFilename                 = "log.log"
@@ -70,14 +77,15 @@ var runServiceCommand func(string) error
 
 // See loadConfig for details on the configuration load process.
 type config struct {
-	Nodename           string `short:"n" long:"name" description:"Node name"`
-	ShowVersion        bool   `short:"V" long:"version" description:"Display version information and exit"`
-	ConfigFile         string `short:"C" long:"configfile" description:"Path to configuration file"`
-	DataDir            string `short:"D" long:"datadir" description:"Directory to store data"`
-	DatabaseDir        string `short:"d" long:"datapre" description:"Database dir"`
-	DatabaseMempoolDir string `short:"m" long:"datamempool" description:"Mempool Database Dir"`
-	LogDir             string `short:"l" long:"logdir" description:"Directory to log output."`
-	LogLevel           string `long:"loglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
+	Nodename              string `short:"n" long:"name" description:"Node name"`
+	ShowVersion           bool   `short:"V" long:"version" description:"Display version information and exit"`
+	ConfigFile            string `short:"C" long:"configfile" description:"Path to configuration file"`
+	DataDir               string `short:"D" long:"datadir" description:"Directory to store data"`
+	DatabaseDir           string `short:"d" long:"datapre" description:"Database dir"`
+	DatabaseMempoolDir    string `short:"m" long:"datamempool" description:"Mempool Database Dir"`
+	SlashingProtectionDir string `long:"slashingprotectiondir" description:"Database dir of the votes and proposals signed by the mining key"`
+	LogDir                string `short:"l" long:"logdir" description:"Directory to log output."`
+	LogLevel              string `long:"loglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
 
 	AddPeers             []string `short:"a" long:"addpeer" description:"Add a peer to connect with at startup"`
 	ConnectPeers         []string `short:"c" long:"connect" description:"Connect only to the specified peers at startup"`
@@ -122,7 +130,7 @@ type config struct {
 	TestNet        string `long:"testnet" description:"Use the test network"`
 	TestNetVersion string `long:"testnetversion" description:"Use the test network"`
 
-	NodeMode    string `long:"nodemode" description:"Role of this node (beacon/shard/wallet/relay | default role is 'relay' (relayshards must be set to run), 'auto' mode will switch between 'beacon' and 'shard')"`
+	NodeMode    string `long:"nodemode" description:"Role of this node (beacon/shard/wallet/relay/light | default role is 'relay' (relayshards must be set to run), 'auto' mode will switch between 'beacon' and 'shard', 'light' mode only syncs the beacon headers and verifies shard data by proof)"`
 	RelayShards string `long:"relayshards" description:"set relay shards of this node when in 'relay' mode if noderole is auto then it only sync shard data when user is a shard producer/validator"`
 	// For Wallet
 	Wallet           bool   `long:"enablewallet" description:"Enable wallet"`
@@ -148,14 +156,35 @@ type config struct {
 	EnableMining      bool   `long:"mining" description:"enable mining"`
 	MiningKeys        string `long:"miningkeys" description:"keys used for different consensus algorigthm"`
 	PrivateKey        string `long:"privatekey" description:"your wallet privatekey"`
+	RemoteSigner      string `long:"remotesigner" description:"Unix socket of the remote signer holding the mining key, instead of miningkeys or privatekey"`
+	RemoteSignerKey   string `long:"remotesignerkey" description:"BLS mining public key in base58 of the key to sign with, can be empty if the remote signer holds one key"`
 	Accelerator       bool   `long:"accelerator" description:"Relay Node Configuration For Consensus"`
 
 	// Highway
 	Libp2pPrivateKey string `long:"libp2pprivatekey" description:"Private key used to create node's PeerID, empty to generate random key each run"`
 
+	// Database
+	DatabaseDriver string   `long:"dbdriver" description:"Database driver of beacon and shard chain data {leveldb, badgerdb}"`
+	ChainDBDrivers []string `long:"chaindbdriver" description:"Database driver of one chain overriding dbdriver, formatted as <chain>:<driver> where chain is 'beacon' or a shard id (eg. 0:badgerdb)"`
+	StatePrune     bool     `long:"stateprune" description:"Delete the state of blocks older than the last stateprunekeep finalized blocks"`
+	StatePruneKeep uint64   `long:"stateprunekeep" description:"Number of last finalized blocks whose state is kept when stateprune is enabled"`
+
 	//backup
 	PreloadAddress string `long:"preloadaddress" description:"Endpoint of fullnode to download backup database"`
 	ForceBackup    bool   `long:"forcebackup" description:"Force node to backup"`
+
+	//sync
+	FastSync          bool     `long:"fastsync" description:"Download the state tries of a finalized checkpoint from peers instead of inserting every block when a chain is empty, the beacon checkpoint must descend from the trusted checkpoint"`
+	SyncSigWorkers    int      `long:"syncsigworkers" description:"Number of synced blocks whose committee signatures are validated in parallel, 0 for the number of CPUs"`
+	SyncTxWorkers     int      `long:"synctxworkers" description:"Number of synced blocks whose transaction proofs are validated in parallel, 0 for the number of CPUs"`
+	SyncLookahead     int      `long:"synclookahead" description:"Number of synced blocks validated ahead of the block being inserted"`
+	Checkpoint        string   `long:"checkpoint" description:"Signed trusted beacon checkpoint, as json or the path of a json file, to sync the beacon chain from"`
+	CheckpointSigners []string `long:"checkpointsigner" description:"Payment address of a signer trusted to sign the checkpoint"`
+
+	//ethereum bridge
+	ETHEndpoints []string `long:"ethendpoint" description:"RPC endpoint of an Ethereum node verifying the proofs of the ETH bridge, eg. http://127.0.0.1:8545"`
+	ETHQuorum    int      `long:"ethquorum" description:"Number of ethendpoint which must return the same Ethereum header, 0 for a majority"`
+	EVMEndpoints []string `long:"evmendpoint" description:"RPC endpoint of a node of an EVM chain other than Ethereum, formatted as <chainid>:<endpoint> (eg. 56:http://127.0.0.1:8575), a majority of the endpoints of a chain must return the same header"`
 }
 
 func (cfg config) IsTestnet() bool {
@@ -163,6 +192,101 @@ func (cfg config) IsTestnet() bool {
 	return testnet
 }
 
+// GetChainDatabaseDrivers returns the database driver of every chain set by
+// the chaindbdriver option, keyed by shard id (common.BeaconChainDataBaseID
+// for beacon).
+func (cfg config) GetChainDatabaseDrivers() (map[int]string, error) {
+	res := make(map[int]string)
+	for _, v := range cfg.ChainDBDrivers {
+		parts := strings.Split(v, ":")
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("invalid chain database driver %v, expected <chain>:<driver>", v)
+		}
+		chainID := common.BeaconChainDataBaseID
+		if parts[0] != common.BeaconChainKey {
+			shardID, err := strconv.Atoi(parts[0])
+			if err != nil || shardID < 0 || shardID >= common.MaxShardNumber {
+				return nil, fmt.Errorf("invalid chain %v in chain database driver %v", parts[0], v)
+			}
+			chainID = shardID
+		}
+		res[chainID] = parts[1]
+	}
+	return res, nil
+}
+
+// GetTrustedCheckpoint returns the checkpoint of the config after verifying its
+// signer, nil if no checkpoint is configured.
+func (cfg config) GetTrustedCheckpoint() (*blockchain.TrustedCheckpoint, error) {
+	if cfg.Checkpoint == "" {
+		return nil, nil
+	}
+	if len(cfg.CheckpointSigners) == 0 {
+		return nil, errors.New("checkpoint needs at least one checkpointsigner")
+	}
+	cp, err := blockchain.LoadTrustedCheckpoint(cfg.Checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	if err := cp.Verify(cfg.CheckpointSigners); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// GetETHHeaderSource returns the source of the Ethereum headers of the
+// ethendpoint and ethquorum options, nil if no endpoint is set.
+func (cfg config) GetETHHeaderSource() (metadata.ETHHeaderSource, error) {
+	if len(cfg.ETHEndpoints) == 0 {
+		if cfg.ETHQuorum != 0 {
+			return nil, errors.New("ethquorum needs at least one ethendpoint")
+		}
+		return nil, nil
+	}
+	if len(cfg.ETHEndpoints) == 1 && cfg.ETHQuorum <= 1 {
+		return metadata.NewRPCETHHeaderSource(cfg.ETHEndpoints[0]), nil
+	}
+	sources := []metadata.ETHHeaderSource{}
+	for _, endpoint := range cfg.ETHEndpoints {
+		sources = append(sources, metadata.NewRPCETHHeaderSource(endpoint))
+	}
+	quorum := cfg.ETHQuorum
+	if quorum == 0 {
+		quorum = len(sources)/2 + 1
+	}
+	return metadata.NewQuorumETHHeaderSource(sources, quorum)
+}
+
+// GetEVMHeaderSources returns the sources of the headers of the EVM chains
+// other than Ethereum of the evmendpoint option, by chain id.
+func (cfg config) GetEVMHeaderSources() (map[uint64]metadata.ETHHeaderSource, error) {
+	endpoints := make(map[uint64][]metadata.ETHHeaderSource)
+	for _, v := range cfg.EVMEndpoints {
+		parts := strings.SplitN(v, ":", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("invalid EVM endpoint %v, expected <chainid>:<endpoint>", v)
+		}
+		evmChainID, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil || evmChainID == 0 || evmChainID == common.ETHChainID {
+			return nil, fmt.Errorf("invalid chain id %v in EVM endpoint %v, use ethendpoint for Ethereum", parts[0], v)
+		}
+		endpoints[evmChainID] = append(endpoints[evmChainID], metadata.NewRPCETHHeaderSource(parts[1]))
+	}
+	res := make(map[uint64]metadata.ETHHeaderSource)
+	for evmChainID, sources := range endpoints {
+		if len(sources) == 1 {
+			res[evmChainID] = sources[0]
+			continue
+		}
+		source, err := metadata.NewQuorumETHHeaderSource(sources, len(sources)/2+1)
+		if err != nil {
+			return nil, err
+		}
+		res[evmChainID] = source
+	}
+	return res, nil
+}
+
 // serviceOptions defines the configuration options for the daemon as a service on
 // Windows.
 type serviceOptions struct {
@@ -322,6 +446,10 @@ func loadConfig() (*config, []string, error) {
 		DataDir:                     defaultDataDir,
 		DatabaseDir:                 DefaultDatabaseDirname,
 		DatabaseMempoolDir:          DefaultDatabaseMempoolDirname,
+		SlashingProtectionDir:       DefaultSlashingProtectionDirname,
+		DatabaseDriver:              DefaultDatabaseDriver,
+		StatePruneKeep:              DefaultStatePruneKeep,
+		SyncLookahead:               DefaultSyncLookahead,
 		LogDir:                      defaultLogDir,
 		RPCKey:                      defaultRPCKeyFile,
 		RPCCert:                     defaultRPCCertFile,
@@ -647,8 +775,46 @@ func loadConfig() (*config, []string, error) {
 		}
 	}
 
-	if cfg.MiningKeys == "" && cfg.PrivateKey == "" && cfg.NodeMode != common.NodeModeRelay {
-		return nil, nil, errors.New("MiningKeys can't be empty if nodemode isn't relay")
+	if _, err := cfg.GetChainDatabaseDrivers(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	if _, err := cfg.GetTrustedCheckpoint(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	if cfg.FastSync && cfg.Checkpoint == "" {
+		err := errors.New("fastsync needs a trusted checkpoint")
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	if _, err := cfg.GetETHHeaderSource(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	if _, err := cfg.GetEVMHeaderSources(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	if cfg.StatePrune && cfg.StatePruneKeep == 0 {
+		err := errors.New("stateprunekeep must be greater than 0")
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	if cfg.MiningKeys == "" && cfg.PrivateKey == "" && cfg.RemoteSigner == "" && cfg.NodeMode != common.NodeModeRelay && cfg.NodeMode != common.NodeModeLight {
+		return nil, nil, errors.New("MiningKeys can't be empty if nodemode isn't relay or light")
 	}
 
 	// Warn about missing config file only after all other configuration is
@@ -13,6 +13,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/wire"
 )
@@ -24,7 +25,7 @@ type BLSBFT struct {
 	ChainID  int
 	PeerID   string
 
-	UserKeySet       *MiningKey
+	UserKeySet       signer.Signer
 	BFTMessageCh     chan wire.MessageBFT
 	ProposeMessageCh chan BFTPropose
 	VoteMessageCh    chan BFTVote
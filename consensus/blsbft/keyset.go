@@ -8,6 +8,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/wallet"
 )
@@ -67,6 +68,46 @@ func (miningKey *MiningKey) BriSignData(
 	return sig, nil
 }
 
+func (miningKey *MiningKey) SignPeerData(data []byte) ([]byte, error) {
+	if err := signer.CheckPeerData(data); err != nil {
+		return nil, NewConsensusError(SignDataError, err)
+	}
+	return miningKey.BriSignData(data)
+}
+
+func (miningKey *MiningKey) SignVote(
+	block signer.BlockInfo,
+	selfIdx int,
+	committee []blsmultisig.PublicKey,
+	withBridgeSig bool,
+) (
+	[]byte,
+	[]byte,
+	[]byte,
+	error,
+) {
+	blsSig, err := miningKey.BLSSignData(block.BlockHash.GetBytes(), selfIdx, committee)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	bridgeSig := []byte{}
+	if withBridgeSig {
+		bridgeSig, err = miningKey.BriSignData(block.BlockHash.GetBytes())
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	confirmation, err := miningKey.BriSignData(signer.VoteConfirmationData(block, blsSig, bridgeSig))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return blsSig, bridgeSig, confirmation, nil
+}
+
+func (miningKey *MiningKey) SignProposal(block signer.BlockInfo) ([]byte, error) {
+	return miningKey.BriSignData(block.BlockHash.GetBytes())
+}
+
 func (e *BLSBFT) LoadUserKey(privateSeed string) error {
 	var miningKey MiningKey
 	privateSeedBytes, _, err := base58.Base58Check{}.Decode(privateSeed)
@@ -89,6 +130,11 @@ func (e *BLSBFT) LoadUserKey(privateSeed string) error {
 	return nil
 }
 
+// LoadSigner - sign with a mining key held by userSigner, e.g. a remote signer
+func (e *BLSBFT) LoadSigner(userSigner signer.Signer) {
+	e.UserKeySet = userSigner
+}
+
 func LoadUserKeyFromIncPrivateKey(privateKey string) (string, error) {
 	wl, err := wallet.Base58CheckDeserialize(privateKey)
 	if err != nil {
@@ -111,7 +157,7 @@ func (e *BLSBFT) GetUserPublicKey() *incognitokey.CommitteePublicKey {
 }
 
 func (e *BLSBFT) SignData(data []byte) (string, error) {
-	result, err := e.UserKeySet.BriSignData(data) //, 0, []blsmultisig.PublicKey{e.UserKeySet.PubKey[common.BlsConsensus]})
+	result, err := e.UserKeySet.SignPeerData(data) //, 0, []blsmultisig.PublicKey{e.UserKeySet.PubKey[common.BlsConsensus]})
 	if err != nil {
 		return "", NewConsensusError(SignDataError, err)
 	}
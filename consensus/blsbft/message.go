@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/wire"
 )
@@ -24,7 +25,7 @@ type BFTVote struct {
 	Vote      vote
 }
 
-func MakeBFTProposeMsg(block []byte, chainKey string, userKeySet *MiningKey) (wire.Message, error) {
+func MakeBFTProposeMsg(block []byte, chainKey string, userKeySet signer.Signer) (wire.Message, error) {
 	var proposeCtn BFTPropose
 	proposeCtn.Block = block
 	proposeCtnBytes, err := json.Marshal(proposeCtn)
@@ -79,16 +80,6 @@ func (e *BLSBFT) ProcessBFTMsg(msg *wire.MessageBFT) {
 	}
 }
 
-func (e *BLSBFT) confirmVote(Vote *vote) error {
-	data := e.RoundData.Block.Hash().GetBytes()
-	data = append(data, Vote.BLS...)
-	data = append(data, Vote.BRI...)
-	data = common.HashB(data)
-	var err error
-	Vote.Confirmation, err = e.UserKeySet.BriSignData(data)
-	return err
-}
-
 func (e *BLSBFT) preValidateVote(blockHash []byte, Vote *vote, candidate []byte) error {
 	data := []byte{}
 	data = append(data, blockHash...)
@@ -105,26 +96,15 @@ func (e *BLSBFT) sendVote() error {
 	pubKey := e.UserKeySet.GetPublicKey()
 	selfIdx := common.IndexOfStr(pubKey.GetMiningKeyBase58(consensusName), e.RoundData.CommitteeBLS.StringList)
 
-	blsSig, err := e.UserKeySet.BLSSignData(e.RoundData.Block.Hash().GetBytes(), selfIdx, e.RoundData.CommitteeBLS.ByteList)
+	withBridgeSig := metadata.HasBridgeInstructions(e.RoundData.Block.GetInstructions())
+	blsSig, bridgeSig, confirmation, err := e.UserKeySet.SignVote(signer.NewBlockInfo(e.ChainKey, e.RoundData.Block), selfIdx, e.RoundData.CommitteeBLS.ByteList, withBridgeSig)
 	if err != nil {
 		return NewConsensusError(UnExpectedError, err)
 	}
-	bridgeSig := []byte{}
-	if metadata.HasBridgeInstructions(e.RoundData.Block.GetInstructions()) {
-		bridgeSig, err = e.UserKeySet.BriSignData(e.RoundData.Block.Hash().GetBytes())
-		if err != nil {
-			return NewConsensusError(UnExpectedError, err)
-		}
-	}
 
 	Vote.BLS = blsSig
 	Vote.BRI = bridgeSig
-
-	//TODO hy
-	err = e.confirmVote(&Vote)
-	if err != nil {
-		return NewConsensusError(UnExpectedError, err)
-	}
+	Vote.Confirmation = confirmation
 	key := e.UserKeySet.GetPublicKey()
 
 	msg, err := MakeBFTVoteMsg(key.GetMiningKeyBase58(consensusName), e.ChainKey, getRoundKey(e.RoundData.NextHeight, e.RoundData.Round), Vote)
@@ -7,6 +7,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 )
 
@@ -51,7 +52,7 @@ func (e BLSBFT) CreateValidationData(block common.BlockInterface) ValidationData
 	// selfPublicKey := e.UserKeySet.GetPublicKey()
 	// keyByte, _ := selfPublicKey.GetMiningKey(consensusName)
 	// valData.ProducerBLSSig, _ = e.UserKeySet.BLSSignData(block.Hash().GetBytes(), 0, []blsmultisig.PublicKey{keyByte})
-	valData.ProducerBLSSig, _ = e.UserKeySet.BriSignData(block.Hash().GetBytes()) //, 0, []blsmultisig.PublicKey{keyByte})
+	valData.ProducerBLSSig, _ = e.UserKeySet.SignProposal(signer.NewBlockInfo(e.ChainKey, block)) //, 0, []blsmultisig.PublicKey{keyByte})
 	return valData
 }
 
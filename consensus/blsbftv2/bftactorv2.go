@@ -11,6 +11,7 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/wire"
@@ -23,7 +24,7 @@ type BLSBFT_V2 struct {
 	ChainID  int
 	PeerID   string
 
-	UserKeySet   *MiningKey
+	UserKeySet   signer.Signer
 	BFTMessageCh chan wire.MessageBFT
 	isStarted    bool
 	StopCh       chan struct{}
@@ -38,6 +39,15 @@ type BLSBFT_V2 struct {
 	receiveBlockByHeight map[uint64][]*ProposeBlockInfo   //blockHeight -> blockInfo
 	receiveBlockByHash   map[string]*ProposeBlockInfo     //blockHash -> blockInfo
 	voteHistory          map[uint64]common.BlockInterface // bestview height (previsous height )-> block
+
+	SlashingProtection *SlashingProtectionDB // on disk history of signed votes and proposals, nil to disable
+
+	// Clock returns the current unix time, nil for the system clock
+	Clock func() int64
+	// Sync runs the consensus without the actor loop: messages are processed when ProcessBFTMsg
+	// is called and timeslots when Tick is called, so that simulations are deterministic
+	Sync  bool
+	tasks []func()
 }
 
 func (e BLSBFT_V2) GetChainKey() string {
@@ -99,6 +109,11 @@ func (e *BLSBFT_V2) Start() error {
 		panic(err)
 	}
 
+	if e.Sync {
+		e.Logger.Info("start bls-bftv2 consensus for chain", e.ChainKey, "without actor loop")
+		return nil
+	}
+
 	//init view maps
 	ticker := time.Tick(200 * time.Millisecond)
 	e.Logger.Info("start bls-bftv2 consensus for chain", e.ChainKey)
@@ -110,166 +125,215 @@ func (e *BLSBFT_V2) Start() error {
 			case <-e.StopCh:
 				return
 			case proposeMsg := <-e.ProposeMessageCh:
-				//fmt.Println("debug receive propose message", string(proposeMsg.Block))
-				blockIntf, err := e.Chain.UnmarshalBlock(proposeMsg.Block)
-				if err != nil || blockIntf == nil {
-					e.Logger.Info(err)
-					continue
-				}
-				block := blockIntf.(common.BlockInterface)
-				blkHash := block.Hash().String()
-
-				if _, ok := e.receiveBlockByHash[blkHash]; !ok {
-					e.receiveBlockByHash[blkHash] = &ProposeBlockInfo{
-						block:      block,
-						votes:      make(map[string]BFTVote),
-						hasNewVote: false,
-					}
-					e.Logger.Info("Receive block ", block.Hash().String(), "height", block.GetHeight(), ",block timeslot ", common.CalculateTimeSlot(block.GetProposeTime()))
-					e.receiveBlockByHeight[block.GetHeight()] = append(e.receiveBlockByHeight[block.GetHeight()], e.receiveBlockByHash[blkHash])
-				} else {
-					e.receiveBlockByHash[blkHash].block = block
-				}
+				e.processProposeMsg(proposeMsg)
+			case voteMsg := <-e.VoteMessageCh:
+				e.processVoteMsg(voteMsg)
+			case <-ticker:
+				e.processTick()
+			}
+		}
+	}()
+	return nil
+}
 
-				if block.GetHeight() <= e.Chain.GetBestViewHeight() {
-					e.Logger.Info("Receive block create from old view. Rejected!")
-					continue
-				}
+func (e *BLSBFT_V2) processProposeMsg(proposeMsg BFTPropose) {
+	//fmt.Println("debug receive propose message", string(proposeMsg.Block))
+	blockIntf, err := e.Chain.UnmarshalBlock(proposeMsg.Block)
+	if err != nil || blockIntf == nil {
+		e.Logger.Info(err)
+		return
+	}
+	block := blockIntf.(common.BlockInterface)
+	blkHash := block.Hash().String()
+
+	if _, ok := e.receiveBlockByHash[blkHash]; !ok {
+		e.receiveBlockByHash[blkHash] = &ProposeBlockInfo{
+			block:      block,
+			votes:      make(map[string]BFTVote),
+			hasNewVote: false,
+		}
+		e.Logger.Info("Receive block ", block.Hash().String(), "height", block.GetHeight(), ",block timeslot ", common.CalculateTimeSlot(block.GetProposeTime()))
+		e.receiveBlockByHeight[block.GetHeight()] = append(e.receiveBlockByHeight[block.GetHeight()], e.receiveBlockByHash[blkHash])
+	} else {
+		e.receiveBlockByHash[blkHash].block = block
+	}
 
-				proposeView := e.Chain.GetViewByHash(block.GetPrevHash())
-				if proposeView == nil {
-					e.Logger.Infof("Request sync block from node %s from %s to %s", proposeMsg.PeerID, block.GetPrevHash().String(), block.GetPrevHash().Bytes())
-					e.Node.RequestMissingViewViaStream(proposeMsg.PeerID, [][]byte{block.GetPrevHash().Bytes()}, e.Chain.GetShardID(), e.Chain.GetChainName())
-				}
+	if block.GetHeight() <= e.Chain.GetBestViewHeight() {
+		e.Logger.Info("Receive block create from old view. Rejected!")
+		return
+	}
 
-			case voteMsg := <-e.VoteMessageCh:
-				voteMsg.isValid = 0
-				if b, ok := e.receiveBlockByHash[voteMsg.BlockHash]; ok { //if receiveblock is already initiated
-					if _, ok := b.votes[voteMsg.Validator]; !ok { // and not receive validatorA vote
-						b.votes[voteMsg.Validator] = voteMsg // store it
-						e.Logger.Infof("Receive vote for block %s (%d) from %v", voteMsg.BlockHash, len(e.receiveBlockByHash[voteMsg.BlockHash].votes), voteMsg.Validator)
-						b.hasNewVote = true
-					}
-				} else {
-					e.receiveBlockByHash[voteMsg.BlockHash] = &ProposeBlockInfo{
-						votes:      make(map[string]BFTVote),
-						hasNewVote: true,
-					}
-					if _, ok := e.receiveBlockByHash[voteMsg.BlockHash].votes[voteMsg.Validator]; !ok {
-						e.receiveBlockByHash[voteMsg.BlockHash].votes[voteMsg.Validator] = voteMsg
-						e.Logger.Infof("[Monitor] receive vote for block %s (%d) from %v", voteMsg.BlockHash, len(e.receiveBlockByHash[voteMsg.BlockHash].votes), voteMsg.Validator)
-					}
-				}
-				// e.Logger.Infof("receive vote for block %s (%d)", voteMsg.BlockHash, len(e.receiveBlockByHash[voteMsg.BlockHash].votes))
+	proposeView := e.Chain.GetViewByHash(block.GetPrevHash())
+	if proposeView == nil {
+		e.Logger.Infof("Request sync block from node %s from %s to %s", proposeMsg.PeerID, block.GetPrevHash().String(), block.GetPrevHash().Bytes())
+		e.Node.RequestMissingViewViaStream(proposeMsg.PeerID, [][]byte{block.GetPrevHash().Bytes()}, e.Chain.GetShardID(), e.Chain.GetChainName())
+	}
+}
 
-			case <-ticker:
-				if !e.Chain.IsReady() {
-					continue
-				}
-				e.currentTime = time.Now().Unix()
+func (e *BLSBFT_V2) processVoteMsg(voteMsg BFTVote) {
+	voteMsg.isValid = 0
+	if b, ok := e.receiveBlockByHash[voteMsg.BlockHash]; ok { //if receiveblock is already initiated
+		if _, ok := b.votes[voteMsg.Validator]; !ok { // and not receive validatorA vote
+			b.votes[voteMsg.Validator] = voteMsg // store it
+			e.Logger.Infof("Receive vote for block %s (%d) from %v", voteMsg.BlockHash, len(e.receiveBlockByHash[voteMsg.BlockHash].votes), voteMsg.Validator)
+			b.hasNewVote = true
+		}
+	} else {
+		e.receiveBlockByHash[voteMsg.BlockHash] = &ProposeBlockInfo{
+			votes:      make(map[string]BFTVote),
+			hasNewVote: true,
+		}
+		if _, ok := e.receiveBlockByHash[voteMsg.BlockHash].votes[voteMsg.Validator]; !ok {
+			e.receiveBlockByHash[voteMsg.BlockHash].votes[voteMsg.Validator] = voteMsg
+			e.Logger.Infof("[Monitor] receive vote for block %s (%d) from %v", voteMsg.BlockHash, len(e.receiveBlockByHash[voteMsg.BlockHash].votes), voteMsg.Validator)
+		}
+	}
+	// e.Logger.Infof("receive vote for block %s (%d)", voteMsg.BlockHash, len(e.receiveBlockByHash[voteMsg.BlockHash].votes))
+}
 
-				newTimeSlot := false
-				if e.currentTimeSlot != common.CalculateTimeSlot(e.currentTime) {
-					newTimeSlot = true
+func (e *BLSBFT_V2) processTick() {
+	if !e.Chain.IsReady() {
+		return
+	}
+	e.currentTime = e.now()
 
-				}
+	newTimeSlot := false
+	if e.currentTimeSlot != common.CalculateTimeSlot(e.currentTime) {
+		newTimeSlot = true
 
-				e.currentTimeSlot = common.CalculateTimeSlot(e.currentTime)
-				bestView := e.Chain.GetBestView()
-
-				/*
-					Check for whether we should propose block
-				*/
-				proposerPk := bestView.GetProposerByTimeSlot(e.currentTimeSlot, 2)
-				userPk := e.GetUserPublicKey().GetMiningKeyBase58(common.BlsConsensus)
-
-				if newTimeSlot { //for logging
-					e.Logger.Info("")
-					e.Logger.Info("======================================================")
-					e.Logger.Info("")
-					if proposerPk.GetMiningKeyBase58(common.BlsConsensus) == userPk {
-						e.Logger.Infof("TS: %v , PROPOSE BLOCK %v", common.CalculateTimeSlot(e.currentTime), bestView.GetHeight()+1)
-					} else {
-						e.Logger.Infof("TS: %v , LISTEN BLOCK %v", common.CalculateTimeSlot(e.currentTime), bestView.GetHeight()+1)
-					}
-				}
+	}
 
-				if proposerPk.GetMiningKeyBase58(common.BlsConsensus) == userPk && common.CalculateTimeSlot(bestView.GetBlock().GetProduceTime()) != e.currentTimeSlot { // current timeslot is not add to view, and this user is proposer of this timeslot
-					//using block hash as key of best view -> check if this best view we propose or not
-					if _, ok := e.proposeHistory.Get(fmt.Sprintf("%s%d", e.currentTimeSlot)); !ok {
-						e.proposeHistory.Add(fmt.Sprintf("%s%d", e.currentTimeSlot), 1)
-						//Proposer Rule: check propose block connected to bestview(longest chain rule 1) and re-propose valid block with smallest timestamp (including already propose in the past) (rule 2)
-						sort.Slice(e.receiveBlockByHeight[bestView.GetHeight()+1], func(i, j int) bool {
-							return e.receiveBlockByHeight[bestView.GetHeight()+1][i].block.GetProduceTime() < e.receiveBlockByHeight[bestView.GetHeight()+1][j].block.GetProduceTime()
-						})
-
-						var proposeBlock common.BlockInterface = nil
-						for _, v := range e.receiveBlockByHeight[bestView.GetHeight()+1] {
-							if v.isValid {
-								proposeBlock = v.block
-								break
-							}
-						}
-
-						if createdBlk, err := e.proposeBlock(proposerPk, proposeBlock); err != nil {
-							e.Logger.Critical(UnExpectedError, errors.New("can't propose block"))
-							e.Logger.Critical(err)
-
-						} else {
-							e.Logger.Infof("proposer block %v round %v time slot %v blockTimeSlot %v with hash %v", createdBlk.GetHeight(), createdBlk.GetRound(), e.currentTimeSlot, common.CalculateTimeSlot(createdBlk.GetProduceTime()), createdBlk.Hash().String())
-						}
-					}
-				}
+	e.currentTimeSlot = common.CalculateTimeSlot(e.currentTime)
+	bestView := e.Chain.GetBestView()
+
+	/*
+		Check for whether we should propose block
+	*/
+	proposerPk := bestView.GetProposerByTimeSlot(e.currentTimeSlot, 2)
+	userPk := e.GetUserPublicKey().GetMiningKeyBase58(common.BlsConsensus)
+
+	if newTimeSlot { //for logging
+		e.Logger.Info("")
+		e.Logger.Info("======================================================")
+		e.Logger.Info("")
+		if proposerPk.GetMiningKeyBase58(common.BlsConsensus) == userPk {
+			e.Logger.Infof("TS: %v , PROPOSE BLOCK %v", common.CalculateTimeSlot(e.currentTime), bestView.GetHeight()+1)
+		} else {
+			e.Logger.Infof("TS: %v , LISTEN BLOCK %v", common.CalculateTimeSlot(e.currentTime), bestView.GetHeight()+1)
+		}
+	}
 
-				/*
-					Check for valid block to vote
-				*/
-				validProposeBlock := []*ProposeBlockInfo{}
-				//get all block that has height = bestview height  + 1(rule 2 & rule 3) (
-				for h, proposeBlockInfo := range e.receiveBlockByHash {
-					if proposeBlockInfo.block == nil {
-						continue
-					}
-					bestViewHeight := bestView.GetHeight()
-					// e.Logger.Infof("[Monitor] bestview height %v, finalview height %v, block height %v %v", bestViewHeight, e.Chain.GetFinalView().GetHeight(), proposeBlockInfo.block.GetHeight(), proposeBlockInfo.block.GetProduceTime())
-					if proposeBlockInfo.block.GetHeight() == bestViewHeight+1 {
-						validProposeBlock = append(validProposeBlock, proposeBlockInfo)
-					}
-
-					if proposeBlockInfo.block.GetHeight() < e.Chain.GetFinalView().GetHeight() {
-						delete(e.receiveBlockByHash, h)
-					}
-				}
-				//rule 1: get history of vote for this height, vote if (round is lower than the vote before) or (round is equal but new proposer) or (there is no vote for this height yet)
-				sort.Slice(validProposeBlock, func(i, j int) bool {
-					return validProposeBlock[i].block.GetProduceTime() < validProposeBlock[j].block.GetProduceTime()
-				})
-				for _, v := range validProposeBlock {
-					blkCreateTimeSlot := common.CalculateTimeSlot(v.block.GetProduceTime())
-					bestViewHeight := bestView.GetHeight()
-
-					if lastVotedBlk, ok := e.voteHistory[bestViewHeight+1]; ok {
-						if blkCreateTimeSlot < common.CalculateTimeSlot(lastVotedBlk.GetProduceTime()) { //blkCreateTimeSlot is smaller than voted block => vote for this blk
-							e.validateAndVote(v)
-						} else if blkCreateTimeSlot == common.CalculateTimeSlot(lastVotedBlk.GetProduceTime()) && common.CalculateTimeSlot(v.block.GetProposeTime()) > common.CalculateTimeSlot(lastVotedBlk.GetProposeTime()) { //blk is old block (same round), but new proposer(larger timeslot) => vote again
-							e.validateAndVote(v)
-						} //blkCreateTimeSlot is larger or equal than voted block => do nothing
-					} else { //there is no vote for this height yet
-						e.validateAndVote(v)
-					}
+	if proposerPk.GetMiningKeyBase58(common.BlsConsensus) == userPk && common.CalculateTimeSlot(bestView.GetBlock().GetProduceTime()) != e.currentTimeSlot { // current timeslot is not add to view, and this user is proposer of this timeslot
+		//using block hash as key of best view -> check if this best view we propose or not
+		if _, ok := e.proposeHistory.Get(fmt.Sprintf("%s%d", e.currentTimeSlot)); !ok {
+			e.proposeHistory.Add(fmt.Sprintf("%s%d", e.currentTimeSlot), 1)
+			//Proposer Rule: check propose block connected to bestview(longest chain rule 1) and re-propose valid block with smallest timestamp (including already propose in the past) (rule 2)
+			sort.Slice(e.receiveBlockByHeight[bestView.GetHeight()+1], func(i, j int) bool {
+				return e.receiveBlockByHeight[bestView.GetHeight()+1][i].block.GetProduceTime() < e.receiveBlockByHeight[bestView.GetHeight()+1][j].block.GetProduceTime()
+			})
+
+			var proposeBlock common.BlockInterface = nil
+			for _, v := range e.receiveBlockByHeight[bestView.GetHeight()+1] {
+				if v.isValid {
+					proposeBlock = v.block
+					break
 				}
+			}
 
-				/*
-					Check for 2/3 vote to commit
-				*/
-				for k, v := range e.receiveBlockByHash {
-					e.processIfBlockGetEnoughVote(k, v)
-				}
+			if createdBlk, err := e.proposeBlock(proposerPk, proposeBlock); err != nil {
+				e.Logger.Critical(UnExpectedError, errors.New("can't propose block"))
+				e.Logger.Critical(err)
 
+			} else {
+				e.Logger.Infof("proposer block %v round %v time slot %v blockTimeSlot %v with hash %v", createdBlk.GetHeight(), createdBlk.GetRound(), e.currentTimeSlot, common.CalculateTimeSlot(createdBlk.GetProduceTime()), createdBlk.Hash().String())
 			}
 		}
-	}()
-	return nil
+	}
+
+	/*
+		Check for valid block to vote
+	*/
+	validProposeBlock := []*ProposeBlockInfo{}
+	//get all block that has height = bestview height  + 1(rule 2 & rule 3) (
+	for h, proposeBlockInfo := range e.receiveBlockByHash {
+		if proposeBlockInfo.block == nil {
+			continue
+		}
+		bestViewHeight := bestView.GetHeight()
+		// e.Logger.Infof("[Monitor] bestview height %v, finalview height %v, block height %v %v", bestViewHeight, e.Chain.GetFinalView().GetHeight(), proposeBlockInfo.block.GetHeight(), proposeBlockInfo.block.GetProduceTime())
+		if proposeBlockInfo.block.GetHeight() == bestViewHeight+1 {
+			validProposeBlock = append(validProposeBlock, proposeBlockInfo)
+		}
+
+		if proposeBlockInfo.block.GetHeight() < e.Chain.GetFinalView().GetHeight() {
+			delete(e.receiveBlockByHash, h)
+		}
+	}
+	//rule 1: get history of vote for this height, vote if (round is lower than the vote before) or (round is equal but new proposer) or (there is no vote for this height yet)
+	sort.Slice(validProposeBlock, func(i, j int) bool {
+		if validProposeBlock[i].block.GetProduceTime() != validProposeBlock[j].block.GetProduceTime() {
+			return validProposeBlock[i].block.GetProduceTime() < validProposeBlock[j].block.GetProduceTime()
+		}
+		return validProposeBlock[i].block.Hash().String() < validProposeBlock[j].block.Hash().String()
+	})
+	for _, v := range validProposeBlock {
+		blkCreateTimeSlot := common.CalculateTimeSlot(v.block.GetProduceTime())
+		bestViewHeight := bestView.GetHeight()
+
+		if lastVotedBlk, ok := e.voteHistory[bestViewHeight+1]; ok {
+			if blkCreateTimeSlot < common.CalculateTimeSlot(lastVotedBlk.GetProduceTime()) { //blkCreateTimeSlot is smaller than voted block => vote for this blk
+				e.validateAndVote(v)
+			} else if blkCreateTimeSlot == common.CalculateTimeSlot(lastVotedBlk.GetProduceTime()) && common.CalculateTimeSlot(v.block.GetProposeTime()) > common.CalculateTimeSlot(lastVotedBlk.GetProposeTime()) { //blk is old block (same round), but new proposer(larger timeslot) => vote again
+				e.validateAndVote(v)
+			} //blkCreateTimeSlot is larger or equal than voted block => do nothing
+		} else { //there is no vote for this height yet
+			e.validateAndVote(v)
+		}
+	}
+
+	/*
+		Check for 2/3 vote to commit
+	*/
+	blockHashes := []string{}
+	for k := range e.receiveBlockByHash {
+		blockHashes = append(blockHashes, k)
+	}
+	sort.Strings(blockHashes)
+	for _, k := range blockHashes {
+		e.processIfBlockGetEnoughVote(k, e.receiveBlockByHash[k])
+	}
+}
+
+// Tick runs the consensus once at the current time of its clock, it is used instead of
+// the actor loop to drive a consensus started with Sync
+func (e *BLSBFT_V2) Tick() {
+	e.processTick()
+	e.runTasks()
+}
+
+// now returns the current unix time of the clock of the consensus
+func (e *BLSBFT_V2) now() int64 {
+	if e.Clock != nil {
+		return e.Clock()
+	}
+	return time.Now().Unix()
+}
+
+// runTask runs task in a goroutine, or after the message or the tick being processed with Sync
+func (e *BLSBFT_V2) runTask(task func()) {
+	if !e.Sync {
+		go task()
+		return
+	}
+	e.tasks = append(e.tasks, task)
+}
+
+func (e *BLSBFT_V2) runTasks() {
+	for len(e.tasks) > 0 {
+		task := e.tasks[0]
+		e.tasks = e.tasks[1:]
+		task()
+	}
 }
 
 func NewInstance(chain ChainInterface, chainKey string, chainID int, node NodeInterface, logger common.Logger) *BLSBFT_V2 {
@@ -364,7 +428,7 @@ func (e *BLSBFT_V2) processIfBlockGetEnoughVote(blockHash string, v *ProposeBloc
 			return
 		}
 
-		go e.Chain.InsertAndBroadcastBlock(v.block)
+		e.runTask(func() { e.Chain.InsertAndBroadcastBlock(v.block) })
 
 		delete(e.receiveBlockByHash, blockHash)
 	}
@@ -388,6 +452,12 @@ func (e *BLSBFT_V2) validateAndVote(v *ProposeBlockInfo) error {
 		return err
 	}
 
+	//refuse to vote a block conflicting with a former vote
+	if err := e.recordSignedBlock(v.block, false); err != nil {
+		e.Logger.Error(err)
+		return err
+	}
+
 	//if valid then vote
 	var Vote = new(BFTVote)
 	bytelist := []blsmultisig.PublicKey{}
@@ -400,19 +470,12 @@ func (e *BLSBFT_V2) validateAndVote(v *ProposeBlockInfo) error {
 		bytelist = append(bytelist, v.MiningPubKey[common.BlsConsensus])
 	}
 
-	blsSig, err := e.UserKeySet.BLSSignData(v.block.Hash().GetBytes(), selfIdx, bytelist)
+	withBridgeSig := metadata.HasBridgeInstructions(v.block.GetInstructions())
+	blsSig, bridgeSig, confirmation, err := e.UserKeySet.SignVote(signer.NewBlockInfo(e.ChainKey, v.block), selfIdx, bytelist, withBridgeSig)
 	if err != nil {
 		e.Logger.Error(err)
 		return NewConsensusError(UnExpectedError, err)
 	}
-	bridgeSig := []byte{}
-	if metadata.HasBridgeInstructions(v.block.GetInstructions()) {
-		bridgeSig, err = e.UserKeySet.BriSignData(v.block.Hash().GetBytes())
-		if err != nil {
-			e.Logger.Error(err)
-			return NewConsensusError(UnExpectedError, err)
-		}
-	}
 	Vote.BLS = blsSig
 	Vote.BRI = bridgeSig
 	Vote.BlockHash = v.block.Hash().String()
@@ -420,11 +483,7 @@ func (e *BLSBFT_V2) validateAndVote(v *ProposeBlockInfo) error {
 	userPk := e.UserKeySet.GetPublicKey()
 	Vote.Validator = userPk.GetMiningKeyBase58(common.BlsConsensus)
 	Vote.PrevBlockHash = v.block.GetPrevHash().String()
-	err = Vote.signVote(e.UserKeySet)
-	if err != nil {
-		e.Logger.Error(err)
-		return NewConsensusError(UnExpectedError, err)
-	}
+	Vote.Confirmation = confirmation
 
 	msg, err := MakeBFTVoteMsg(Vote, e.ChainKey, e.currentTimeSlot, v.block.GetHeight())
 	if err != nil {
@@ -435,7 +494,7 @@ func (e *BLSBFT_V2) validateAndVote(v *ProposeBlockInfo) error {
 	v.isValid = true
 	e.voteHistory[v.block.GetHeight()] = v.block
 	e.Logger.Info("sending vote...")
-	go e.Node.PushMessageToChain(msg, e.Chain)
+	e.runTask(func() { e.Node.PushMessageToChain(msg, e.Chain) })
 	//go func() {
 	//	e.VoteMessageCh <- *Vote
 	//}()
@@ -470,6 +529,10 @@ func (e *BLSBFT_V2) proposeBlock(proposerPk incognitokey.CommitteePublicKey, blo
 		return nil, NewConsensusError(BlockCreationError, errors.New("block is nil"))
 	}
 
+	if err := e.recordSignedBlock(block, true); err != nil {
+		return nil, err
+	}
+
 	validationData := e.CreateValidationData(block)
 	validationDataString, _ := EncodeValidationData(validationData)
 	block.(blockValidation).AddValidationField(validationDataString)
@@ -478,8 +541,8 @@ func (e *BLSBFT_V2) proposeBlock(proposerPk incognitokey.CommitteePublicKey, blo
 	proposeCtn.Block = blockData
 	proposeCtn.PeerID = e.Node.GetSelfPeerID().String()
 	msg, _ := MakeBFTProposeMsg(proposeCtn, e.ChainKey, e.currentTimeSlot, block.GetHeight())
-	go e.ProcessBFTMsg(msg.(*wire.MessageBFT))
-	go e.Node.PushMessageToChain(msg, e.Chain)
+	e.runTask(func() { e.ProcessBFTMsg(msg.(*wire.MessageBFT)) })
+	e.runTask(func() { e.Node.PushMessageToChain(msg, e.Chain) })
 
 	return block, nil
 }
@@ -494,6 +557,11 @@ func (e *BLSBFT_V2) ProcessBFTMsg(msgBFT *wire.MessageBFT) {
 			return
 		}
 		msgPropose.PeerID = msgBFT.PeerID
+		if e.Sync {
+			e.processProposeMsg(msgPropose)
+			e.runTasks()
+			return
+		}
 		e.ProposeMessageCh <- msgPropose
 	case MSG_VOTE:
 		var msgVote BFTVote
@@ -502,6 +570,11 @@ func (e *BLSBFT_V2) ProcessBFTMsg(msgBFT *wire.MessageBFT) {
 			e.Logger.Error(err)
 			return
 		}
+		if e.Sync {
+			e.processVoteMsg(msgVote)
+			e.runTasks()
+			return
+		}
 		e.VoteMessageCh <- msgVote
 	default:
 		e.Logger.Critical("Unknown BFT message type")
@@ -519,17 +592,6 @@ func (e *BLSBFT_V2) preValidateVote(blockHash []byte, Vote *BFTVote, candidate [
 	return err
 }
 
-func (s *BFTVote) signVote(key *MiningKey) error {
-	data := []byte{}
-	data = append(data, s.BlockHash...)
-	data = append(data, s.BLS...)
-	data = append(data, s.BRI...)
-	data = common.HashB(data)
-	var err error
-	s.Confirmation, err = key.BriSignData(data)
-	return err
-}
-
 func (s *BFTVote) validateVoteOwner(ownerPk []byte) error {
 	data := []byte{}
 	data = append(data, s.BlockHash...)
@@ -540,6 +602,25 @@ func (s *BFTVote) validateVoteOwner(ownerPk []byte) error {
 	return err
 }
 
+// recordSignedBlock checks a block against the slashing protection history
+// of this validator and records it before the block is signed
+func (e *BLSBFT_V2) recordSignedBlock(block common.BlockInterface, isProposal bool) error {
+	if e.SlashingProtection == nil {
+		return nil
+	}
+	validator := e.GetUserPublicKey().GetMiningKeyBase58(common.BlsConsensus)
+	record := SignedBlockRecord{
+		ChainKey:  e.ChainKey,
+		Height:    block.GetHeight(),
+		TimeSlot:  common.CalculateTimeSlot(block.GetProposeTime()),
+		BlockHash: *block.Hash(),
+	}
+	if isProposal {
+		return e.SlashingProtection.CheckAndRecordProposal(validator, record)
+	}
+	return e.SlashingProtection.CheckAndRecordVote(validator, record)
+}
+
 func ExtractBridgeValidationData(block common.BlockInterface) ([][]byte, []int, error) {
 	valData, err := DecodeValidationData(block.GetValidationField())
 	if err != nil {
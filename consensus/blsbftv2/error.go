@@ -19,6 +19,8 @@ const (
 	DecodeValidationDataError
 	EncodeValidationDataError
 	BlockCreationError
+	SlashingProtectionDBError
+	DoubleSignError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -37,6 +39,8 @@ var ErrCodeMessage = map[int]struct {
 	DecodeValidationDataError:    {-1009, "Decode Validation Data error"},
 	EncodeValidationDataError:    {-1010, "Encode Validation Data Error"},
 	BlockCreationError:           {-1011, "Block Creation Error"},
+	SlashingProtectionDBError:    {-1012, "Slashing Protection Database Error"},
+	DoubleSignError:              {-1013, "Refuse to sign data conflicting with slashing protection history"},
 }
 
 type ConsensusError struct {
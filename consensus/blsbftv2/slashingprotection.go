@@ -0,0 +1,266 @@
+package blsbftv2
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// SlashingProtectionVersion is the version of the exported slashing protection data
+const SlashingProtectionVersion = 1
+
+var (
+	slashingProtectionVotePrefix     = []byte("slashingprotection-vote-")
+	slashingProtectionProposalPrefix = []byte("slashingprotection-proposal-")
+)
+
+// SignedBlockRecord is a vote or a proposal signed by a validator
+type SignedBlockRecord struct {
+	ChainKey  string
+	Height    uint64
+	TimeSlot  int64
+	BlockHash common.Hash
+}
+
+// SlashingProtectionValidator is every vote and proposal signed with a mining key,
+// Validator is the BLS mining public key in base58
+type SlashingProtectionValidator struct {
+	Validator string
+	Votes     []SignedBlockRecord
+	Proposals []SignedBlockRecord
+}
+
+// SlashingProtectionData is the portable JSON format used to move the
+// slashing protection history of validators between hosts
+type SlashingProtectionData struct {
+	Version    int
+	Validators []*SlashingProtectionValidator
+}
+
+type signedBlockEntry struct {
+	Validator string
+	SignedBlockRecord
+}
+
+// SlashingProtectionDB keeps on disk every vote and proposal signed by the
+// validators of this node, so that after a restart or on another host
+// running the same mining key they never sign two conflicting blocks:
+//   - at one height, a new vote must be for a block proposed in a later timeslot than every former vote
+//   - in one timeslot, only one block can be proposed
+//
+// Signing the same block again is allowed.
+type SlashingProtectionDB struct {
+	db  incdb.Database
+	mtx sync.Mutex
+}
+
+func NewSlashingProtectionDB(db incdb.Database) *SlashingProtectionDB {
+	return &SlashingProtectionDB{db: db}
+}
+
+func buildSlashingProtectionKey(prefix []byte, validator string, chainKey string, nums ...uint64) []byte {
+	key := append([]byte{}, prefix...)
+	key = append(key, []byte(validator+"-"+chainKey+"-")...)
+	for _, num := range nums {
+		key = append(key, common.Uint64ToBytes(num)...)
+	}
+	return key
+}
+
+func (s *SlashingProtectionDB) getEntries(prefix []byte) ([]*signedBlockEntry, error) {
+	iter := s.db.NewIteratorWithPrefix(prefix)
+	defer iter.Release()
+	entries := []*signedBlockEntry{}
+	for iter.Next() {
+		entry := new(signedBlockEntry)
+		if err := json.Unmarshal(iter.Value(), entry); err != nil {
+			return nil, NewConsensusError(SlashingProtectionDBError, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, NewConsensusError(SlashingProtectionDBError, err)
+	}
+	return entries, nil
+}
+
+func (s *SlashingProtectionDB) putEntry(writer incdb.KeyValueWriter, key []byte, entry *signedBlockEntry) error {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return NewConsensusError(SlashingProtectionDBError, err)
+	}
+	if err := writer.Put(key, value); err != nil {
+		return NewConsensusError(SlashingProtectionDBError, err)
+	}
+	return nil
+}
+
+func (s *SlashingProtectionDB) checkVote(validator string, record SignedBlockRecord) (bool, error) {
+	prefix := buildSlashingProtectionKey(slashingProtectionVotePrefix, validator, record.ChainKey, record.Height)
+	votes, err := s.getEntries(prefix)
+	if err != nil {
+		return false, err
+	}
+	for _, vote := range votes {
+		if vote.BlockHash == record.BlockHash {
+			return true, nil
+		}
+	}
+	for _, vote := range votes {
+		if vote.TimeSlot >= record.TimeSlot {
+			return false, NewConsensusError(DoubleSignError, fmt.Errorf("%v already voted block %v of timeslot %v at height %v of %v, refuse to vote block %v of timeslot %v",
+				validator, vote.BlockHash.String(), vote.TimeSlot, vote.Height, vote.ChainKey, record.BlockHash.String(), record.TimeSlot))
+		}
+	}
+	return false, nil
+}
+
+func (s *SlashingProtectionDB) checkProposal(validator string, record SignedBlockRecord) (bool, error) {
+	key := buildSlashingProtectionKey(slashingProtectionProposalPrefix, validator, record.ChainKey, uint64(record.TimeSlot))
+	proposals, err := s.getEntries(key)
+	if err != nil {
+		return false, err
+	}
+	for _, proposal := range proposals {
+		if proposal.BlockHash != record.BlockHash {
+			return false, NewConsensusError(DoubleSignError, fmt.Errorf("%v already proposed block %v at height %v in timeslot %v of %v, refuse to propose block %v",
+				validator, proposal.BlockHash.String(), proposal.Height, proposal.TimeSlot, proposal.ChainKey, record.BlockHash.String()))
+		}
+	}
+	return len(proposals) > 0, nil
+}
+
+// CheckAndRecordVote returns an error if voting the block conflicts with a
+// former vote of validator, otherwise records the vote before it is signed
+func (s *SlashingProtectionDB) CheckAndRecordVote(validator string, record SignedBlockRecord) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	found, err := s.checkVote(validator, record)
+	if err != nil || found {
+		return err
+	}
+	key := buildSlashingProtectionKey(slashingProtectionVotePrefix, validator, record.ChainKey, record.Height, uint64(record.TimeSlot))
+	return s.putEntry(s.db, key, &signedBlockEntry{Validator: validator, SignedBlockRecord: record})
+}
+
+// CheckAndRecordProposal returns an error if proposing the block conflicts with
+// a former proposal of validator, otherwise records the proposal before it is signed
+func (s *SlashingProtectionDB) CheckAndRecordProposal(validator string, record SignedBlockRecord) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	found, err := s.checkProposal(validator, record)
+	if err != nil || found {
+		return err
+	}
+	key := buildSlashingProtectionKey(slashingProtectionProposalPrefix, validator, record.ChainKey, uint64(record.TimeSlot))
+	return s.putEntry(s.db, key, &signedBlockEntry{Validator: validator, SignedBlockRecord: record})
+}
+
+// Export returns the whole signing history of the database
+func (s *SlashingProtectionDB) Export() (*SlashingProtectionData, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	data := &SlashingProtectionData{
+		Version:    SlashingProtectionVersion,
+		Validators: []*SlashingProtectionValidator{},
+	}
+	validators := make(map[string]*SlashingProtectionValidator)
+	getValidator := func(validator string) *SlashingProtectionValidator {
+		if _, ok := validators[validator]; !ok {
+			validators[validator] = &SlashingProtectionValidator{
+				Validator: validator,
+				Votes:     []SignedBlockRecord{},
+				Proposals: []SignedBlockRecord{},
+			}
+			data.Validators = append(data.Validators, validators[validator])
+		}
+		return validators[validator]
+	}
+	votes, err := s.getEntries(slashingProtectionVotePrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, vote := range votes {
+		v := getValidator(vote.Validator)
+		v.Votes = append(v.Votes, vote.SignedBlockRecord)
+	}
+	proposals, err := s.getEntries(slashingProtectionProposalPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, proposal := range proposals {
+		v := getValidator(proposal.Validator)
+		v.Proposals = append(v.Proposals, proposal.SignedBlockRecord)
+	}
+	return data, nil
+}
+
+// Import merges an exported signing history into the database. Nothing is
+// written if a record conflicts with the history already in the database.
+func (s *SlashingProtectionDB) Import(data *SlashingProtectionData) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if data.Version != SlashingProtectionVersion {
+		return NewConsensusError(SlashingProtectionDBError, fmt.Errorf("unsupported slashing protection data version %v", data.Version))
+	}
+	batch := s.db.NewBatch()
+	written := make(map[string]common.Hash)
+	for _, v := range data.Validators {
+		for _, record := range v.Votes {
+			key := buildSlashingProtectionKey(slashingProtectionVotePrefix, v.Validator, record.ChainKey, record.Height, uint64(record.TimeSlot))
+			if blockHash, ok := written[string(key)]; ok {
+				if blockHash != record.BlockHash {
+					return NewConsensusError(DoubleSignError, fmt.Errorf("conflicting votes of %v at height %v in timeslot %v of %v", v.Validator, record.Height, record.TimeSlot, record.ChainKey))
+				}
+				continue
+			}
+			if err := s.checkImportedRecord(key, v.Validator, record); err != nil {
+				return err
+			}
+			if err := s.putEntry(batch, key, &signedBlockEntry{Validator: v.Validator, SignedBlockRecord: record}); err != nil {
+				return err
+			}
+			written[string(key)] = record.BlockHash
+		}
+		for _, record := range v.Proposals {
+			key := buildSlashingProtectionKey(slashingProtectionProposalPrefix, v.Validator, record.ChainKey, uint64(record.TimeSlot))
+			if blockHash, ok := written[string(key)]; ok {
+				if blockHash != record.BlockHash {
+					return NewConsensusError(DoubleSignError, fmt.Errorf("conflicting proposals of %v in timeslot %v of %v", v.Validator, record.TimeSlot, record.ChainKey))
+				}
+				continue
+			}
+			if _, err := s.checkProposal(v.Validator, record); err != nil {
+				return err
+			}
+			if err := s.putEntry(batch, key, &signedBlockEntry{Validator: v.Validator, SignedBlockRecord: record}); err != nil {
+				return err
+			}
+			written[string(key)] = record.BlockHash
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return NewConsensusError(SlashingProtectionDBError, err)
+	}
+	return nil
+}
+
+// checkImportedRecord rejects an imported vote voting another block than the
+// vote recorded in the same timeslot at the same height. Imported votes keep
+// the history, they do not need to be in a later timeslot than the recorded ones.
+func (s *SlashingProtectionDB) checkImportedRecord(key []byte, validator string, record SignedBlockRecord) error {
+	votes, err := s.getEntries(key)
+	if err != nil {
+		return err
+	}
+	for _, vote := range votes {
+		if vote.BlockHash != record.BlockHash {
+			return NewConsensusError(DoubleSignError, fmt.Errorf("%v already voted block %v at height %v in timeslot %v of %v, imported vote is for block %v",
+				validator, vote.BlockHash.String(), vote.Height, vote.TimeSlot, vote.ChainKey, record.BlockHash.String()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package blsbftv2
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSlashingProtectionTestDB(t *testing.T) (*SlashingProtectionDB, func()) {
+	dir, err := ioutil.TempDir("", "slashingprotection")
+	assert.Equal(t, nil, err)
+	db, err := incdb.Open("leveldb", dir)
+	assert.Equal(t, nil, err)
+	return NewSlashingProtectionDB(db), func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestSlashingProtectionDB(t *testing.T) {
+	sp, closeDB := newSlashingProtectionTestDB(t)
+	defer closeDB()
+	validator := "validator1"
+	blockA := SignedBlockRecord{ChainKey: "shard-0", Height: 10, TimeSlot: 100, BlockHash: common.HashH([]byte("a"))}
+	blockB := SignedBlockRecord{ChainKey: "shard-0", Height: 10, TimeSlot: 100, BlockHash: common.HashH([]byte("b"))}
+	blockC := SignedBlockRecord{ChainKey: "shard-0", Height: 10, TimeSlot: 101, BlockHash: common.HashH([]byte("c"))}
+	blockD := SignedBlockRecord{ChainKey: "shard-0", Height: 10, TimeSlot: 99, BlockHash: common.HashH([]byte("d"))}
+
+	assert.Equal(t, nil, sp.CheckAndRecordVote(validator, blockA))
+	// voting the same block again
+	assert.Equal(t, nil, sp.CheckAndRecordVote(validator, blockA))
+	// another block in the same timeslot
+	err := sp.CheckAndRecordVote(validator, blockB)
+	assert.Equal(t, ErrCodeMessage[DoubleSignError].Code, err.(*ConsensusError).Code)
+	// another key or another chain is not affected
+	assert.Equal(t, nil, sp.CheckAndRecordVote("validator2", blockB))
+	blockBOfBeacon := blockB
+	blockBOfBeacon.ChainKey = common.BeaconChainKey
+	assert.Equal(t, nil, sp.CheckAndRecordVote(validator, blockBOfBeacon))
+	// a block proposed later, then a block proposed earlier
+	assert.Equal(t, nil, sp.CheckAndRecordVote(validator, blockC))
+	assert.NotEqual(t, nil, sp.CheckAndRecordVote(validator, blockD))
+
+	assert.Equal(t, nil, sp.CheckAndRecordProposal(validator, blockA))
+	assert.Equal(t, nil, sp.CheckAndRecordProposal(validator, blockA))
+	assert.NotEqual(t, nil, sp.CheckAndRecordProposal(validator, blockB))
+	assert.Equal(t, nil, sp.CheckAndRecordProposal(validator, blockC))
+
+	data, err := sp.Export()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(data.Validators))
+	for _, v := range data.Validators {
+		if v.Validator == validator {
+			assert.Equal(t, 3, len(v.Votes))
+			assert.Equal(t, 2, len(v.Proposals))
+		} else {
+			assert.Equal(t, 1, len(v.Votes))
+			assert.Equal(t, 0, len(v.Proposals))
+		}
+	}
+
+	// the history moves to a new host
+	newSP, closeNewDB := newSlashingProtectionTestDB(t)
+	defer closeNewDB()
+	assert.Equal(t, nil, newSP.CheckAndRecordVote(validator, SignedBlockRecord{ChainKey: "shard-0", Height: 11, TimeSlot: 102, BlockHash: common.HashH([]byte("e"))}))
+	assert.Equal(t, nil, newSP.Import(data))
+	assert.Equal(t, nil, newSP.Import(data))
+	assert.NotEqual(t, nil, newSP.CheckAndRecordVote(validator, blockB))
+	assert.NotEqual(t, nil, newSP.CheckAndRecordProposal(validator, blockB))
+	newData, err := newSP.Export()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(newData.Validators))
+
+	// import fails without writing anything when the history conflicts
+	conflictingSP, closeConflictingDB := newSlashingProtectionTestDB(t)
+	defer closeConflictingDB()
+	assert.Equal(t, nil, conflictingSP.CheckAndRecordVote(validator, blockB))
+	assert.NotEqual(t, nil, conflictingSP.Import(data))
+	conflictingData, err := conflictingSP.Export()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(conflictingData.Validators))
+	assert.Equal(t, 1, len(conflictingData.Validators[0].Votes))
+}
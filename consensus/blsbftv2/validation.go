@@ -9,6 +9,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
 	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 )
 
@@ -44,7 +45,7 @@ func EncodeValidationData(validationData ValidationData) (string, error) {
 
 func (e BLSBFT_V2) CreateValidationData(block common.BlockInterface) ValidationData {
 	var valData ValidationData
-	valData.ProducerBLSSig, _ = e.UserKeySet.BriSignData(block.Hash().GetBytes())
+	valData.ProducerBLSSig, _ = e.UserKeySet.SignProposal(signer.NewBlockInfo(e.ChainKey, block))
 	return valData
 }
 
@@ -167,11 +167,14 @@ func (engine *Engine) initProcess(chainID int, chainName string) {
 			engine.BFTProcess[chainID] = blsbft.NewInstance(engine.config.Blockchain.ShardChain[chainID], chainName, chainID, engine.config.Node, Logger.Log)
 		}
 	} else {
+		var bftProcess *blsbft2.BLSBFT_V2
 		if chainID == -1 {
-			engine.BFTProcess[chainID] = blsbft2.NewInstance(engine.config.Blockchain.BeaconChain, chainName, chainID, engine.config.Node, Logger.Log)
+			bftProcess = blsbft2.NewInstance(engine.config.Blockchain.BeaconChain, chainName, chainID, engine.config.Node, Logger.Log)
 		} else {
-			engine.BFTProcess[chainID] = blsbft2.NewInstance(engine.config.Blockchain.ShardChain[chainID], chainName, chainID, engine.config.Node, Logger.Log)
+			bftProcess = blsbft2.NewInstance(engine.config.Blockchain.ShardChain[chainID], chainName, chainID, engine.config.Node, Logger.Log)
 		}
+		bftProcess.SlashingProtection = engine.config.SlashingProtectionDB
+		engine.BFTProcess[chainID] = bftProcess
 	}
 }
 
@@ -3,6 +3,8 @@ package consensus
 import (
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	blsbft2 "github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/incognitochain/incognito-chain/wire"
@@ -10,9 +12,11 @@ import (
 )
 
 type EngineConfig struct {
-	Node          NodeInterface
-	Blockchain    *blockchain.BlockChain
-	PubSubManager *pubsub.PubSubManager
+	Node                 NodeInterface
+	Blockchain           *blockchain.BlockChain
+	PubSubManager        *pubsub.PubSubManager
+	SlashingProtectionDB *blsbft2.SlashingProtectionDB
+	Signer               signer.Signer // remote signer holding the mining key, nil to load the mining keys of the node
 }
 
 type NodeInterface interface {
@@ -47,6 +51,8 @@ type ConsensusInterface interface {
 
 	// LoadUserKey - load user mining key
 	LoadUserKey(miningKey string) error
+	// LoadSigner - sign with a mining key held by a signer instead of a loaded mining key
+	LoadSigner(userSigner signer.Signer)
 	// GetUserPublicKey - get user public key of loaded mining key
 	GetUserPublicKey() *incognitokey.CommitteePublicKey
 	// ValidateData - validate data with this consensus signature scheme
@@ -10,10 +10,14 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/consensus/blsbft"
 	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 )
 
 func (engine *Engine) LoadMiningKeys(keysString string) error {
+	if engine.config != nil && engine.config.Signer != nil {
+		return engine.loadSigner(engine.config.Signer)
+	}
 	if len(keysString) > 0 {
 		keys := strings.Split(keysString, "|")
 		if len(keys) > 0 {
@@ -48,6 +52,23 @@ func (engine *Engine) LoadMiningKeys(keysString string) error {
 	return nil
 }
 
+// loadSigner makes the consensus sign with the mining key held by userSigner
+func (engine *Engine) loadSigner(userSigner signer.Signer) error {
+	var f ConsensusInterface
+	if engine.currentMiningProcess == nil {
+		if engine.version == 1 {
+			f = &blsbft.BLSBFT{}
+		} else {
+			f = &blsbftv2.BLSBFT_V2{}
+		}
+	} else {
+		f = engine.currentMiningProcess
+	}
+	f.LoadSigner(userSigner)
+	engine.SetMiningPublicKeys(common.BlsConsensus, f.GetUserPublicKey())
+	return nil
+}
+
 func (engine *Engine) GetCurrentMiningPublicKey() (publickey string, keyType string) {
 	if engine != nil && engine.GetMiningPublicKeys() != nil {
 		name := engine.consensusName
@@ -0,0 +1,144 @@
+package signer
+
+import (
+	"errors"
+	"net/rpc"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// methods of the remote signer rpc server
+const (
+	GetPublicKeyMethod = "Handler.GetPublicKey"
+	SignPeerDataMethod = "Handler.SignPeerData"
+	SignVoteMethod     = "Handler.SignVote"
+	SignProposalMethod = "Handler.SignProposal"
+)
+
+// Validator of the args is the BLS mining public key in base58 of the key to sign with,
+// it can be empty if the remote signer holds only one key
+type GetPublicKeyArgs struct {
+	Validator string
+}
+
+type SignDataArgs struct {
+	Validator string
+	Data      []byte
+}
+
+type SignVoteArgs struct {
+	Validator     string
+	Block         BlockInfo
+	SelfIdx       int
+	Committee     []blsmultisig.PublicKey
+	WithBridgeSig bool
+}
+
+type SignVoteReply struct {
+	BLSSig       []byte
+	BridgeSig    []byte
+	Confirmation []byte
+}
+
+type SignProposalArgs struct {
+	Validator string
+	Block     BlockInfo
+}
+
+// RemoteSigner signs with a mining key held by a remote signer process,
+// it is connected over the unix socket the remote signer listens on
+type RemoteSigner struct {
+	address   string
+	validator string
+	publicKey incognitokey.CommitteePublicKey
+	client    *rpc.Client
+	mtx       sync.Mutex
+}
+
+// NewRemoteSigner connects to the remote signer at address and gets the public key of validator
+func NewRemoteSigner(address string, validator string) (*RemoteSigner, error) {
+	remoteSigner := &RemoteSigner{
+		address:   address,
+		validator: validator,
+	}
+	var publicKey incognitokey.CommitteePublicKey
+	if err := remoteSigner.call(GetPublicKeyMethod, &GetPublicKeyArgs{Validator: validator}, &publicKey); err != nil {
+		return nil, err
+	}
+	remoteSigner.publicKey = publicKey
+	remoteSigner.validator = publicKey.GetMiningKeyBase58(common.BlsConsensus)
+	return remoteSigner, nil
+}
+
+// call calls method of the remote signer, it reconnects once if the connection is lost
+func (s *RemoteSigner) call(method string, args interface{}, reply interface{}) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for retry := 0; ; retry++ {
+		if s.client == nil {
+			client, err := rpc.Dial("unix", s.address)
+			if err != nil {
+				return err
+			}
+			s.client = client
+		}
+		err := s.client.Call(method, args, reply)
+		if err != rpc.ErrShutdown || retry > 0 {
+			return err
+		}
+		s.client.Close()
+		s.client = nil
+	}
+}
+
+func (s *RemoteSigner) GetPublicKey() incognitokey.CommitteePublicKey {
+	return s.publicKey
+}
+
+func (s *RemoteSigner) SignPeerData(data []byte) ([]byte, error) {
+	if err := CheckPeerData(data); err != nil {
+		return nil, err
+	}
+	var sig []byte
+	err := s.call(SignPeerDataMethod, &SignDataArgs{Validator: s.validator, Data: data}, &sig)
+	return sig, err
+}
+
+func (s *RemoteSigner) SignVote(block BlockInfo, selfIdx int, committee []blsmultisig.PublicKey, withBridgeSig bool) ([]byte, []byte, []byte, error) {
+	var reply SignVoteReply
+	err := s.call(SignVoteMethod, &SignVoteArgs{
+		Validator:     s.validator,
+		Block:         block,
+		SelfIdx:       selfIdx,
+		Committee:     committee,
+		WithBridgeSig: withBridgeSig,
+	}, &reply)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(reply.BLSSig) == 0 || len(reply.Confirmation) == 0 {
+		return nil, nil, nil, errors.New("remote signer returned an empty signature")
+	}
+	return reply.BLSSig, reply.BridgeSig, reply.Confirmation, nil
+}
+
+func (s *RemoteSigner) SignProposal(block BlockInfo) ([]byte, error) {
+	var sig []byte
+	err := s.call(SignProposalMethod, &SignProposalArgs{Validator: s.validator, Block: block}, &sig)
+	return sig, err
+}
+
+// Close closes the connection to the remote signer
+func (s *RemoteSigner) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.client == nil {
+		return nil
+	}
+	err := s.client.Close()
+	s.client = nil
+	return err
+}
@@ -0,0 +1,72 @@
+package signer
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// BlockInfo identifies the block a vote or a proposal is signed for,
+// a signer refuses to sign a block conflicting with the blocks it signed before
+type BlockInfo struct {
+	ChainKey  string
+	Height    uint64
+	TimeSlot  int64
+	BlockHash common.Hash
+	Version   int
+}
+
+// NewBlockInfo returns the info of a block of chainKey, the timeslot of a block
+// version 1 is the one it is produced in, of a block version 2 the one it is proposed in
+func NewBlockInfo(chainKey string, block common.BlockInterface) BlockInfo {
+	blockTime := block.GetProposeTime()
+	if block.GetVersion() == 1 {
+		blockTime = block.GetProduceTime()
+	}
+	return BlockInfo{
+		ChainKey:  chainKey,
+		Height:    block.GetHeight(),
+		TimeSlot:  common.CalculateTimeSlot(blockTime),
+		BlockHash: *block.Hash(),
+		Version:   block.GetVersion(),
+	}
+}
+
+// VoteConfirmationData returns the data a vote for block is confirmed on with the bridge key,
+// the hash of a block version 1 is confirmed in bytes, of a block version 2 in hex
+func VoteConfirmationData(block BlockInfo, blsSig []byte, bridgeSig []byte) []byte {
+	data := []byte{}
+	if block.Version == 1 {
+		data = append(data, block.BlockHash.GetBytes()...)
+	} else {
+		data = append(data, block.BlockHash.String()...)
+	}
+	data = append(data, blsSig...)
+	data = append(data, bridgeSig...)
+	return common.HashB(data)
+}
+
+// CheckPeerData returns an error if data has the size of a block hash
+func CheckPeerData(data []byte) error {
+	if len(data) == common.HashSize {
+		return errors.New("peer data has the size of a block hash")
+	}
+	return nil
+}
+
+// Signer holds a mining key and signs with it for the consensus,
+// the key can be kept in memory or in a remote signer process
+type Signer interface {
+	// GetPublicKey - get the public key of the mining key
+	GetPublicKey() incognitokey.CommitteePublicKey
+	// SignPeerData - sign the peer id or address announced to other peers with the bridge key,
+	// data of the size of a block hash is refused so the signature is never a block signature
+	SignPeerData(data []byte) ([]byte, error)
+	// SignVote - sign the hash of a block voted with the BLS key of the committee member selfIdx,
+	// and with the bridge key if withBridgeSig, the vote is confirmed with the bridge key
+	SignVote(block BlockInfo, selfIdx int, committee []blsmultisig.PublicKey, withBridgeSig bool) (blsSig []byte, bridgeSig []byte, confirmation []byte, err error)
+	// SignProposal - sign the hash of a block proposed with the bridge key
+	SignProposal(block BlockInfo) ([]byte, error)
+}
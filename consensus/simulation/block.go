@@ -0,0 +1,91 @@
+package simulation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// BlockHeader is the header of a simulated block, the hash of a block is the hash of its header
+type BlockHeader struct {
+	Version     int
+	ChainID     int
+	Height      uint64
+	Round       int
+	Epoch       uint64
+	PrevHash    common.Hash
+	Producer    string
+	ProduceTime int64
+	Proposer    string
+	ProposeTime int64
+}
+
+// Block is a block without body, it carries what the consensus needs to agree on a chain
+type Block struct {
+	Header         BlockHeader
+	ValidationData string
+}
+
+func (block *Block) GetVersion() int {
+	return block.Header.Version
+}
+
+func (block *Block) GetHeight() uint64 {
+	return block.Header.Height
+}
+
+func (block *Block) Hash() *common.Hash {
+	headerBytes, _ := json.Marshal(block.Header)
+	hash := common.HashH(headerBytes)
+	return &hash
+}
+
+func (block *Block) GetProducer() string {
+	return block.Header.Producer
+}
+
+func (block *Block) GetValidationField() string {
+	return block.ValidationData
+}
+
+func (block *Block) AddValidationField(validationData string) error {
+	block.ValidationData = validationData
+	return nil
+}
+
+func (block *Block) GetRound() int {
+	return block.Header.Round
+}
+
+func (block *Block) GetRoundKey() string {
+	return fmt.Sprint(block.Header.Height, "_", block.Header.Round)
+}
+
+func (block *Block) GetInstructions() [][]string {
+	return [][]string{}
+}
+
+func (block *Block) GetConsensusType() string {
+	return common.BlsConsensus
+}
+
+func (block *Block) GetCurrentEpoch() uint64 {
+	return block.Header.Epoch
+}
+
+func (block *Block) GetProduceTime() int64 {
+	return block.Header.ProduceTime
+}
+
+func (block *Block) GetProposeTime() int64 {
+	return block.Header.ProposeTime
+}
+
+func (block *Block) GetPrevHash() common.Hash {
+	return block.Header.PrevHash
+}
+
+func (block *Block) GetProposer() string {
+	return block.Header.Proposer
+}
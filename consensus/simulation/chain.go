@@ -0,0 +1,253 @@
+package simulation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/multiview"
+)
+
+// View is the state of a simulated chain after a block, the committee never changes
+type View struct {
+	block     *Block
+	committee []incognitokey.CommitteePublicKey
+}
+
+func (view *View) GetHash() *common.Hash {
+	return view.block.Hash()
+}
+
+func (view *View) GetPreviousHash() *common.Hash {
+	return &view.block.Header.PrevHash
+}
+
+func (view *View) GetHeight() uint64 {
+	return view.block.Header.Height
+}
+
+func (view *View) GetCommittee() []incognitokey.CommitteePublicKey {
+	return view.committee
+}
+
+func (view *View) GetProposerByTimeSlot(ts int64, version int) incognitokey.CommitteePublicKey {
+	return view.committee[int(ts)%len(view.committee)]
+}
+
+func (view *View) GetBlock() common.BlockInterface {
+	return view.block
+}
+
+// Chain is the chain of a simulated node, it keeps its views in a multiview like the blockchain
+// does and every block it inserted, so that the simulation can check the chains once it ran
+type Chain struct {
+	name      string
+	chainID   int
+	committee []incognitokey.CommitteePublicKey
+	multiView *multiview.MultiView
+	blocks    map[common.Hash]*Block
+	isReady   bool
+	node      *Node
+}
+
+func NewChain(name string, chainID int, committee []incognitokey.CommitteePublicKey, genesis *Block, node *Node) *Chain {
+	chain := &Chain{
+		name:      name,
+		chainID:   chainID,
+		committee: committee,
+		multiView: multiview.NewMultiView(),
+		blocks:    make(map[common.Hash]*Block),
+		isReady:   true,
+		node:      node,
+	}
+	chain.multiView.AddView(&View{block: genesis, committee: committee})
+	chain.blocks[*genesis.Hash()] = genesis
+	return chain
+}
+
+// GetBlock returns a block inserted in the chain, even if the multiview pruned its view
+func (chain *Chain) GetBlock(hash common.Hash) *Block {
+	return chain.blocks[hash]
+}
+
+// InsertBlock validates the signatures of block and adds its view to the chain
+func (chain *Chain) InsertBlock(block *Block) error {
+	if _, ok := chain.blocks[*block.Hash()]; ok {
+		return nil
+	}
+	prevView := chain.multiView.GetViewByHash(block.GetPrevHash())
+	if prevView == nil {
+		return fmt.Errorf("previous view %v of block %v not found", block.GetPrevHash().String(), block.Hash().String())
+	}
+	if err := chain.ValidateBlockSignatures(block, prevView.GetCommittee()); err != nil {
+		return err
+	}
+	if !chain.multiView.AddView(&View{block: block, committee: chain.committee}) {
+		return fmt.Errorf("cannot add view of block %v", block.Hash().String())
+	}
+	chain.blocks[*block.Hash()] = block
+	return nil
+}
+
+func (chain *Chain) GetFinalView() multiview.View {
+	return chain.multiView.GetFinalView()
+}
+
+func (chain *Chain) GetBestView() multiview.View {
+	return chain.multiView.GetBestView()
+}
+
+func (chain *Chain) GetEpoch() uint64 {
+	return chain.GetBestView().GetBlock().GetCurrentEpoch()
+}
+
+func (chain *Chain) GetChainName() string {
+	return chain.name
+}
+
+func (chain *Chain) GetConsensusType() string {
+	return common.BlsConsensus
+}
+
+func (chain *Chain) GetLastBlockTimeStamp() int64 {
+	return chain.GetBestView().GetBlock().GetProduceTime()
+}
+
+func (chain *Chain) GetMinBlkInterval() time.Duration {
+	return common.TIMESLOT * time.Second
+}
+
+func (chain *Chain) GetMaxBlkCreateTime() time.Duration {
+	return common.TIMESLOT / 2 * time.Second
+}
+
+func (chain *Chain) IsReady() bool {
+	return chain.isReady
+}
+
+func (chain *Chain) SetReady(ready bool) {
+	chain.isReady = ready
+}
+
+func (chain *Chain) GetActiveShardNumber() int {
+	return 1
+}
+
+func (chain *Chain) CurrentHeight() uint64 {
+	return chain.GetBestView().GetHeight()
+}
+
+func (chain *Chain) GetCommitteeSize() int {
+	return len(chain.committee)
+}
+
+func (chain *Chain) GetCommittee() []incognitokey.CommitteePublicKey {
+	return chain.committee
+}
+
+func (chain *Chain) GetPendingCommittee() []incognitokey.CommitteePublicKey {
+	return []incognitokey.CommitteePublicKey{}
+}
+
+func (chain *Chain) GetPubKeyCommitteeIndex(pubKey string) int {
+	for i, member := range chain.committee {
+		if member.GetMiningKeyBase58(common.BlsConsensus) == pubKey {
+			return i
+		}
+	}
+	return -1
+}
+
+func (chain *Chain) GetLastProposerIndex() int {
+	proposer := chain.GetBestView().GetProposerByTimeSlot(common.CalculateTimeSlot(chain.GetLastBlockTimeStamp()), 2)
+	return chain.GetPubKeyCommitteeIndex(proposer.GetMiningKeyBase58(common.BlsConsensus))
+}
+
+func (chain *Chain) UnmarshalBlock(blockString []byte) (common.BlockInterface, error) {
+	var block Block
+	if err := json.Unmarshal(blockString, &block); err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+func (chain *Chain) CreateNewBlock(version int, proposer string, round int, startTime int64) (common.BlockInterface, error) {
+	bestView := chain.GetBestView()
+	return &Block{
+		Header: BlockHeader{
+			Version:     version,
+			ChainID:     chain.chainID,
+			Height:      bestView.GetHeight() + 1,
+			Round:       round,
+			Epoch:       bestView.GetBlock().GetCurrentEpoch(),
+			PrevHash:    *bestView.GetHash(),
+			Producer:    proposer,
+			ProduceTime: startTime,
+			Proposer:    proposer,
+			ProposeTime: startTime,
+		},
+	}, nil
+}
+
+func (chain *Chain) CreateNewBlockFromOldBlock(oldBlock common.BlockInterface, proposer string, startTime int64) (common.BlockInterface, error) {
+	block := &Block{Header: oldBlock.(*Block).Header}
+	block.Header.Proposer = proposer
+	block.Header.ProposeTime = startTime
+	return block, nil
+}
+
+func (chain *Chain) InsertAndBroadcastBlock(block common.BlockInterface) error {
+	if err := chain.InsertBlock(block.(*Block)); err != nil {
+		return err
+	}
+	chain.node.broadcastBlock(block.(*Block))
+	return nil
+}
+
+func (chain *Chain) ValidateBlockSignatures(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error {
+	if err := blsbftv2.ValidateProducerSig(block); err != nil {
+		return err
+	}
+	return blsbftv2.ValidateCommitteeSig(block, committee)
+}
+
+func (chain *Chain) ValidatePreSignBlock(block common.BlockInterface) error {
+	prevView := chain.multiView.GetViewByHash(block.GetPrevHash())
+	if prevView == nil {
+		return errors.New("previous view not found")
+	}
+	proposer := prevView.GetProposerByTimeSlot(common.CalculateTimeSlot(block.GetProposeTime()), 2)
+	proposerBase58, _ := proposer.ToBase58()
+	if proposerBase58 != block.GetProposer() {
+		return fmt.Errorf("block %v is not proposed by the proposer of its timeslot", block.Hash().String())
+	}
+	return blsbftv2.ValidateProducerSig(block)
+}
+
+func (chain *Chain) GetShardID() int {
+	return chain.chainID
+}
+
+func (chain *Chain) GetBestViewHeight() uint64 {
+	return chain.GetBestView().GetHeight()
+}
+
+func (chain *Chain) GetFinalViewHeight() uint64 {
+	return chain.GetFinalView().GetHeight()
+}
+
+func (chain *Chain) GetBestViewHash() string {
+	return chain.GetBestView().GetHash().String()
+}
+
+func (chain *Chain) GetFinalViewHash() string {
+	return chain.GetFinalView().GetHash().String()
+}
+
+func (chain *Chain) GetViewByHash(hash common.Hash) multiview.View {
+	return chain.multiView.GetViewByHash(hash)
+}
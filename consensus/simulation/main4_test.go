@@ -1,496 +1,135 @@
-package main
+package simulation
 
 import (
-	"fmt"
-	"github.com/incognitochain/incognito-chain/blockchain"
-	"github.com/incognitochain/incognito-chain/blockchain/v2/shard"
-	"github.com/incognitochain/incognito-chain/common"
-	"github.com/incognitochain/incognito-chain/consensus_v2/blsbftv2"
-	"github.com/incognitochain/incognito-chain/incognitokey"
 	"testing"
-	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
-func Test_Main4Committee(t *testing.T) {
+// committee of 4 nodes, node k proposes in timeslot k+1, k+5, ...
+
+func scenarioMain4() *Scenario {
+	scenario := NewScenario()
+	// timeslot 1: normal communication, full connect by default
+	// timeslot 2
+	scenario.Communicate(2, MsgPropose, 1, []int{0, 0, 0, 1})
+	scenario.Communicate(2, MsgVote, 3, []int{0, 1, 0, 0})
+	// timeslot 3
+	scenario.Communicate(3, MsgVote, 0, []int{0, 0, 0, 0})
+	scenario.Communicate(3, MsgVote, 1, []int{1, 0, 0, 0})
+	scenario.Communicate(3, MsgVote, 2, []int{1, 0, 0, 0})
+	scenario.Communicate(3, MsgVote, 3, []int{1, 0, 0, 0})
+	// timeslot 4
+	scenario.Communicate(4, MsgVote, 1, []int{0, 1, 1, 1})
+	scenario.Communicate(4, MsgVote, 2, []int{0, 1, 1, 1})
+	scenario.Communicate(4, MsgVote, 3, []int{0, 1, 1, 1})
+	// timeslot 5: normal communication
+	return scenario
+}
 
-	committee := []string{
-		"112t8rnXB47RhSdyVRU41TEf78nxbtWGtmjutwSp9YqsNaCpFxQGXcnwcXTtBkCGDk1KLBRBeWMvb2aXG5SeDUJRHtFV8jTB3weHEkbMJ1AL",
-		"112t8rnXVdfBqBMigSs5fm9NSS8rgsVVURUxArpv6DxYmPZujKqomqUa2H9wh1zkkmDGtDn2woK4NuRDYnYRtVkUhK34TMfbUF4MShSkrCw5",
-		"112t8rnXi8eKJ5RYJjyQYcFMThfbXHgaL6pq5AF5bWsDXwfsw8pqQUreDv6qgWyiABoDdphvqE7NFr9K92aomX7Gi5Nm1e4tEoV3qRLVdfSR",
-		"112t8rnY42xRqJghQX3zvhgEa2ZJBwSzJ46SXyVQEam1yNpN4bfAqJwh1SsobjHAz8wwRvwnqJBfxrbwUuTxqgEbuEE8yMu6F14QmwtwyM43",
-	}
-	committeePkStruct := []incognitokey.CommitteePublicKey{}
-	for _, v := range committee {
-		p, _ := blsbftv2.LoadUserKeyFromIncPrivateKey(v)
-		m, _ := blsbftv2.GetMiningKeyFromPrivateSeed(p)
-		committeePkStruct = append(committeePkStruct, m.GetPublicKey())
-	}
-	nodeList := []*Node{}
-	genesisTime, _ := time.Parse(shard.GENESIS_TIMESTAMP, blockchain.TestnetGenesisBlockTime)
-	for {
-		if int(common.GetTimeSlot(genesisTime.Unix(), time.Now().Unix(), blsbftv2.TIMESLOT))%len(committee) == len(committee)-1 {
-			break
-		} else {
-			time.Sleep(1 * time.Millisecond)
-		}
-	}
+func scenarioMain4A() *Scenario {
+	scenario := scenarioMain4()
+	// timeslot 5
+	scenario.Communicate(5, MsgPropose, 0, []int{0, 0, 1, 0})
+	scenario.Communicate(5, MsgVote, 2, []int{1, 0, 0, 0})
+	// timeslot 6
+	scenario.Communicate(6, MsgVote, 0, []int{1, 0, 0, 0})
+	scenario.Communicate(6, MsgVote, 1, []int{1, 0, 0, 0})
+	scenario.Communicate(6, MsgVote, 3, []int{1, 0, 0, 0})
+	// timeslot 7
+	scenario.Communicate(7, MsgVote, 1, []int{0, 1, 1, 1})
+	scenario.Communicate(7, MsgVote, 2, []int{0, 1, 1, 1})
+	scenario.Communicate(7, MsgVote, 3, []int{0, 1, 1, 1})
+	// timeslot 8
+	scenario.Communicate(8, MsgPropose, 3, []int{0, 0, 0, 0})
+	// timeslot 9
+	scenario.Communicate(9, MsgPropose, 0, []int{0, 0, 1, 0})
+	scenario.Communicate(9, MsgVote, 2, []int{1, 0, 0, 0})
+	// timeslot 10
+	scenario.Communicate(10, MsgVote, 0, []int{1, 0, 0, 0})
+	scenario.Communicate(10, MsgVote, 1, []int{1, 0, 0, 0})
+	scenario.Communicate(10, MsgVote, 3, []int{1, 0, 0, 0})
+	// timeslot 11, 12: normal communication
+	return scenario
+}
 
-	for i, _ := range committee {
-		ni := NewNode(committeePkStruct, committee, i)
-		nodeList = append(nodeList, ni)
-	}
-	var startNode = func() {
-		for _, v := range nodeList {
-			v.nodeList = nodeList
-			go v.Start()
-		}
-	}
-	GetSimulation().nodeList = nodeList
-	//simulation
-	rootTimeSlot := nodeList[0].chain.GetBestView().GetRootTimeSlot()
-	startTimeSlot := rootTimeSlot + 1
-	fmt.Println("root Time slot", rootTimeSlot)
-	GetSimulation().setStartTimeSlot(startTimeSlot)
-	var setTimeSlot = func(s int) uint64 {
-		return startTimeSlot + uint64(s) - 1
-	}
-	var setProposeCommunication = func(timeslot uint64, nodeID int, scenario []int) {
-		if GetSimulation().scenario.proposeComm[timeslot] == nil {
-			GetSimulation().scenario.proposeComm[timeslot] = make(map[string][]int)
-		}
-		GetSimulation().scenario.proposeComm[timeslot][fmt.Sprintf("%d", int(int(startTimeSlot)+nodeID)%len(committee))] = scenario
-	}
-	var setVoteCommunication = func(timeslot uint64, nodeID int, scenario []int) {
-		if GetSimulation().scenario.voteComm[timeslot] == nil {
-			GetSimulation().scenario.voteComm[timeslot] = make(map[string][]int)
-		}
-		GetSimulation().scenario.voteComm[timeslot][fmt.Sprintf("%d", int(int(startTimeSlot)+nodeID)%len(committee))] = scenario
-	}
+func scenarioMain4B() *Scenario {
+	scenario := NewScenario()
+	// timeslot 2
+	scenario.Communicate(2, MsgPropose, 1, []int{0, 0, 0, 1})
+	scenario.Communicate(2, MsgVote, 3, []int{0, 1, 0, 0})
+	// timeslot 3
+	scenario.Communicate(3, MsgVote, 0, []int{0, 0, 0, 0})
+	scenario.Communicate(3, MsgVote, 1, []int{1, 0, 0, 0})
+	scenario.Communicate(3, MsgVote, 2, []int{1, 0, 0, 0})
+	scenario.Communicate(3, MsgVote, 3, []int{1, 0, 0, 0})
+	// timeslot 4: normal communication
+	// timeslot 5
+	scenario.Communicate(5, MsgPropose, 0, []int{0, 0, 1, 0})
+	scenario.Communicate(5, MsgVote, 2, []int{1, 0, 0, 0})
+	// timeslot 6
+	scenario.Communicate(6, MsgVote, 0, []int{1, 0, 0, 0})
+	scenario.Communicate(6, MsgVote, 1, []int{1, 0, 0, 0})
+	scenario.Communicate(6, MsgVote, 2, []int{1, 0, 0, 0})
+	scenario.Communicate(6, MsgVote, 3, []int{1, 0, 0, 0})
+	// timeslot 7
+	scenario.Communicate(7, MsgVote, 1, []int{0, 1, 1, 1})
+	scenario.Communicate(7, MsgVote, 2, []int{0, 1, 1, 1})
+	scenario.Communicate(7, MsgVote, 3, []int{0, 1, 1, 1})
+	// timeslot 8
+	scenario.Communicate(8, MsgPropose, 3, []int{0, 1, 0, 0})
+	scenario.Communicate(8, MsgPropose, 1, []int{0, 0, 0, 1})
+	// timeslot 9
+	scenario.Communicate(9, MsgPropose, 0, []int{0, 0, 0, 0})
+	// timeslot 10 to 12
+	scenario.Communicate(10, MsgPropose, 1, []int{0, 1, 1, 1})
+	scenario.Communicate(11, MsgPropose, 2, []int{0, 1, 1, 1})
+	scenario.Communicate(12, MsgPropose, 3, []int{0, 1, 1, 1})
+	// timeslot 13: normal communication
+	return scenario
+}
 
-	for _, v := range nodeList {
-		v.consensusEngine.Logger.Info("\n\n")
-		v.consensusEngine.Logger.Info("===============================")
-		v.consensusEngine.Logger.Info("\n\n")
-		fmt.Printf("Node %s log is %s\n", v.id, fmt.Sprintf("log%s.log", v.id))
+func scenarioMain4C() *Scenario {
+	scenario := NewScenario()
+	// timeslot 1: no proposal delivered
+	for i := 0; i < 4; i++ {
+		scenario.Communicate(1, MsgPropose, i, []int{0, 0, 0, 0})
 	}
+	// then normal communication
+	return scenario
+}
 
-	/*
-		START YOUR SIMULATION HERE
-	*/
-	timeslot := setTimeSlot(1) //normal communication, full connect by default
-
-	timeslot = setTimeSlot(2)
-	setProposeCommunication(timeslot, 1, []int{0, 0, 0, 1})
-	setVoteCommunication(timeslot, 3, []int{0, 1, 0, 0})
-	//
-	timeslot = setTimeSlot(3)
-	setVoteCommunication(timeslot, 0, []int{0, 0, 0, 0})
-	setVoteCommunication(timeslot, 1, []int{1, 0, 0, 0})
-	setVoteCommunication(timeslot, 2, []int{1, 0, 0, 0})
-	setVoteCommunication(timeslot, 3, []int{1, 0, 0, 0})
-	//
-	timeslot = setTimeSlot(4)
-	setVoteCommunication(timeslot, 1, []int{0, 1, 1, 1})
-	setVoteCommunication(timeslot, 2, []int{0, 1, 1, 1})
-	setVoteCommunication(timeslot, 3, []int{0, 1, 1, 1})
-
-	timeslot = setTimeSlot(5) //normal cimmunication
-
-	/*
-		END YOUR SIMULATION HERE
-	*/
-	GetSimulation().setMaxTimeSlot(timeslot)
-	startNode()
-	go func() {
-		lastTimeSlot := uint64(0)
-		for {
-			curTimeSlot := (common.GetTimeSlot(genesisTime.Unix(), time.Now().Unix(), blsbftv2.TIMESLOT) - startTimeSlot) + 1
-			for _, v := range nodeList {
-				if lastTimeSlot != curTimeSlot && curTimeSlot <= GetSimulation().maxTimeSlot {
-					v.consensusEngine.Logger.Info("========================================")
-					v.consensusEngine.Logger.Info("SIMULATION NODE", v.id, "TIMESLOT", curTimeSlot)
-					v.consensusEngine.Logger.Info("========================================")
-				}
-
-			}
-			lastTimeSlot = curTimeSlot
-			time.Sleep(1 * time.Millisecond)
-		}
-	}()
-	select {}
+func runMain4(t *testing.T, chainID int, scenario *Scenario, timeSlots int, expected Expected) *Simulation {
+	s, err := NewSimulation(SimulationConfig{NumNodes: 4, ChainID: chainID, Scenario: scenario})
+	assert.Equal(t, nil, err)
+	s.Run(timeSlots)
+	defer s.Stop()
+	t.Log("final heights", s.FinalHeights(), "best heights", s.BestHeights())
+	assert.Equal(t, nil, s.Check(expected))
+	return s
+}
 
+func Test_Main4Committee(t *testing.T) {
+	runMain4(t, 0, scenarioMain4(), 10, Expected{MinFinalHeight: 8})
 }
 
 func Test_Main4Committee_ScenarioA(t *testing.T) {
-	committee := []string{
-		"112t8rnXB47RhSdyVRU41TEf78nxbtWGtmjutwSp9YqsNaCpFxQGXcnwcXTtBkCGDk1KLBRBeWMvb2aXG5SeDUJRHtFV8jTB3weHEkbMJ1AL",
-		"112t8rnXVdfBqBMigSs5fm9NSS8rgsVVURUxArpv6DxYmPZujKqomqUa2H9wh1zkkmDGtDn2woK4NuRDYnYRtVkUhK34TMfbUF4MShSkrCw5",
-		"112t8rnXi8eKJ5RYJjyQYcFMThfbXHgaL6pq5AF5bWsDXwfsw8pqQUreDv6qgWyiABoDdphvqE7NFr9K92aomX7Gi5Nm1e4tEoV3qRLVdfSR",
-		"112t8rnY42xRqJghQX3zvhgEa2ZJBwSzJ46SXyVQEam1yNpN4bfAqJwh1SsobjHAz8wwRvwnqJBfxrbwUuTxqgEbuEE8yMu6F14QmwtwyM43",
-	}
-	committeePkStruct := []incognitokey.CommitteePublicKey{}
-	for _, v := range committee {
-		p, _ := blsbftv2.LoadUserKeyFromIncPrivateKey(v)
-		m, _ := blsbftv2.GetMiningKeyFromPrivateSeed(p)
-		committeePkStruct = append(committeePkStruct, m.GetPublicKey())
-	}
-	nodeList := []*Node{}
-	genesisTime, _ := time.Parse(shard.GENESIS_TIMESTAMP, blockchain.TestnetGenesisBlockTime)
-	for {
-		if int(common.GetTimeSlot(genesisTime.Unix(), time.Now().Unix(), blsbftv2.TIMESLOT))%len(committee) == len(committee)-1 {
-			break
-		} else {
-			time.Sleep(1 * time.Millisecond)
-		}
-	}
-
-	for i, _ := range committee {
-		ni := NewNode(committeePkStruct, committee, i)
-		nodeList = append(nodeList, ni)
-	}
-	var startNode = func() {
-		for _, v := range nodeList {
-			v.nodeList = nodeList
-			go v.Start()
-		}
-	}
-	GetSimulation().nodeList = nodeList
-	//simulation
-	rootTimeSlot := nodeList[0].chain.GetBestView().GetRootTimeSlot()
-	startTimeSlot := rootTimeSlot + 1
-	fmt.Println("root Time slot", rootTimeSlot)
-	GetSimulation().setStartTimeSlot(startTimeSlot)
-	var setTimeSlot = func(s int) uint64 {
-		return startTimeSlot + uint64(s) - 1
-	}
-	var setProposeCommunication = func(timeslot uint64, nodeID int, scenario []int) {
-		if GetSimulation().scenario.proposeComm[timeslot] == nil {
-			GetSimulation().scenario.proposeComm[timeslot] = make(map[string][]int)
-		}
-		GetSimulation().scenario.proposeComm[timeslot][fmt.Sprintf("%d", int(int(startTimeSlot)+nodeID)%len(committee))] = scenario
-	}
-	var setVoteCommunication = func(timeslot uint64, nodeID int, scenario []int) {
-		if GetSimulation().scenario.voteComm[timeslot] == nil {
-			GetSimulation().scenario.voteComm[timeslot] = make(map[string][]int)
-		}
-		GetSimulation().scenario.voteComm[timeslot][fmt.Sprintf("%d", int(int(startTimeSlot)+nodeID)%len(committee))] = scenario
-	}
-
-	for _, v := range nodeList {
-		v.consensusEngine.Logger.Info("\n\n")
-		v.consensusEngine.Logger.Info("===============================")
-		v.consensusEngine.Logger.Info("\n\n")
-		fmt.Printf("Node %s log is %s\n", v.id, fmt.Sprintf("log%s.log", v.id))
-	}
-
-	/*
-		START YOUR SIMULATION HERE
-	*/
-	timeslot := setTimeSlot(1) //normal communication, full connect by default
-
-	timeslot = setTimeSlot(2)
-	setProposeCommunication(timeslot, 1, []int{0, 0, 0, 1})
-	setVoteCommunication(timeslot, 3, []int{0, 1, 0, 0})
-	//
-	timeslot = setTimeSlot(3)
-	setVoteCommunication(timeslot, 0, []int{0, 0, 0, 0})
-	setVoteCommunication(timeslot, 1, []int{1, 0, 0, 0})
-	setVoteCommunication(timeslot, 2, []int{1, 0, 0, 0})
-	setVoteCommunication(timeslot, 3, []int{1, 0, 0, 0})
-	//
-	timeslot = setTimeSlot(4)
-	setVoteCommunication(timeslot, 1, []int{0, 1, 1, 1})
-	setVoteCommunication(timeslot, 2, []int{0, 1, 1, 1})
-	setVoteCommunication(timeslot, 3, []int{0, 1, 1, 1})
-
-	timeslot = setTimeSlot(5)
-	setProposeCommunication(timeslot, 0, []int{0, 0, 1, 0})
-	setVoteCommunication(timeslot, 2, []int{1, 0, 0, 0})
-
-	timeslot = setTimeSlot(6)
-	setVoteCommunication(timeslot, 0, []int{1, 0, 0, 0})
-	setVoteCommunication(timeslot, 1, []int{1, 0, 0, 0})
-	setVoteCommunication(timeslot, 3, []int{1, 0, 0, 0})
-
-	timeslot = setTimeSlot(7)
-	setVoteCommunication(timeslot, 1, []int{0, 1, 1, 1})
-	setVoteCommunication(timeslot, 2, []int{0, 1, 1, 1})
-	setVoteCommunication(timeslot, 3, []int{0, 1, 1, 1})
-
-	timeslot = setTimeSlot(8)
-	setProposeCommunication(timeslot, 3, []int{0, 0, 0, 0})
-
-	timeslot = setTimeSlot(9)
-	setProposeCommunication(timeslot, 0, []int{0, 0, 1, 0})
-	setVoteCommunication(timeslot, 2, []int{1, 0, 0, 0})
-
-	timeslot = setTimeSlot(10)
-	setVoteCommunication(timeslot, 0, []int{1, 0, 0, 0})
-	setVoteCommunication(timeslot, 1, []int{1, 0, 0, 0})
-	setVoteCommunication(timeslot, 3, []int{1, 0, 0, 0})
-
-	timeslot = setTimeSlot(11)
-	timeslot = setTimeSlot(12)
-
-	/*
-		END YOUR SIMULATION HERE
-	*/
-	GetSimulation().setMaxTimeSlot(timeslot)
-	startNode()
-	go func() {
-		lastTimeSlot := uint64(0)
-		for {
-			curTimeSlot := (common.GetTimeSlot(genesisTime.Unix(), time.Now().Unix(), blsbftv2.TIMESLOT) - startTimeSlot) + 1
-			for _, v := range nodeList {
-				if lastTimeSlot != curTimeSlot && curTimeSlot <= GetSimulation().maxTimeSlot {
-					v.consensusEngine.Logger.Info("========================================")
-					v.consensusEngine.Logger.Info("SIMULATION NODE", v.id, "TIMESLOT", curTimeSlot)
-					v.consensusEngine.Logger.Info("========================================")
-				}
-
-			}
-			lastTimeSlot = curTimeSlot
-			time.Sleep(1 * time.Millisecond)
-		}
-	}()
-	select {}
+	runMain4(t, 0, scenarioMain4A(), 16, Expected{MinFinalHeight: 9})
 }
 
 func Test_Main4Committee_ScenarioB(t *testing.T) {
-	committee := []string{
-		"112t8rnXB47RhSdyVRU41TEf78nxbtWGtmjutwSp9YqsNaCpFxQGXcnwcXTtBkCGDk1KLBRBeWMvb2aXG5SeDUJRHtFV8jTB3weHEkbMJ1AL",
-		"112t8rnXVdfBqBMigSs5fm9NSS8rgsVVURUxArpv6DxYmPZujKqomqUa2H9wh1zkkmDGtDn2woK4NuRDYnYRtVkUhK34TMfbUF4MShSkrCw5",
-		"112t8rnXi8eKJ5RYJjyQYcFMThfbXHgaL6pq5AF5bWsDXwfsw8pqQUreDv6qgWyiABoDdphvqE7NFr9K92aomX7Gi5Nm1e4tEoV3qRLVdfSR",
-		"112t8rnY42xRqJghQX3zvhgEa2ZJBwSzJ46SXyVQEam1yNpN4bfAqJwh1SsobjHAz8wwRvwnqJBfxrbwUuTxqgEbuEE8yMu6F14QmwtwyM43",
-	}
-	committeePkStruct := []incognitokey.CommitteePublicKey{}
-	for _, v := range committee {
-		p, _ := blsbftv2.LoadUserKeyFromIncPrivateKey(v)
-		m, _ := blsbftv2.GetMiningKeyFromPrivateSeed(p)
-		committeePkStruct = append(committeePkStruct, m.GetPublicKey())
-	}
-	nodeList := []*Node{}
-	genesisTime, _ := time.Parse(shard.GENESIS_TIMESTAMP, blockchain.TestnetGenesisBlockTime)
-	for {
-		if int(common.GetTimeSlot(genesisTime.Unix(), time.Now().Unix(), blsbftv2.TIMESLOT))%len(committee) == len(committee)-1 {
-			break
-		} else {
-			time.Sleep(1 * time.Millisecond)
-		}
-	}
-
-	for i, _ := range committee {
-		ni := NewNode(committeePkStruct, committee, i)
-		nodeList = append(nodeList, ni)
-	}
-	var startNode = func() {
-		for _, v := range nodeList {
-			v.nodeList = nodeList
-			go v.Start()
-		}
-	}
-	GetSimulation().nodeList = nodeList
-	//simulation
-	rootTimeSlot := nodeList[0].chain.GetBestView().GetRootTimeSlot()
-	startTimeSlot := rootTimeSlot + 1
-	fmt.Println("root Time slot", rootTimeSlot)
-	GetSimulation().setStartTimeSlot(startTimeSlot)
-	var setTimeSlot = func(s int) uint64 {
-		return startTimeSlot + uint64(s) - 1
-	}
-	var setProposeCommunication = func(timeslot uint64, nodeID int, scenario []int) {
-		if GetSimulation().scenario.proposeComm[timeslot] == nil {
-			GetSimulation().scenario.proposeComm[timeslot] = make(map[string][]int)
-		}
-		GetSimulation().scenario.proposeComm[timeslot][fmt.Sprintf("%d", int(int(startTimeSlot)+nodeID)%len(committee))] = scenario
-	}
-	var setVoteCommunication = func(timeslot uint64, nodeID int, scenario []int) {
-		if GetSimulation().scenario.voteComm[timeslot] == nil {
-			GetSimulation().scenario.voteComm[timeslot] = make(map[string][]int)
-		}
-		GetSimulation().scenario.voteComm[timeslot][fmt.Sprintf("%d", int(int(startTimeSlot)+nodeID)%len(committee))] = scenario
-	}
-
-	for _, v := range nodeList {
-		v.consensusEngine.Logger.Info("\n\n")
-		v.consensusEngine.Logger.Info("===============================")
-		v.consensusEngine.Logger.Info("\n\n")
-		fmt.Printf("Node %s log is %s\n", v.id, fmt.Sprintf("log%s.log", v.id))
-	}
-
-	/*
-		START YOUR SIMULATION HERE
-	*/
-	timeslot := setTimeSlot(1) //normal communication, full connect by default
-
-	timeslot = setTimeSlot(2)
-	setProposeCommunication(timeslot, 1, []int{0, 0, 0, 1})
-	setVoteCommunication(timeslot, 3, []int{0, 1, 0, 0})
-	//
-	timeslot = setTimeSlot(3)
-	setVoteCommunication(timeslot, 0, []int{0, 0, 0, 0})
-	setVoteCommunication(timeslot, 1, []int{1, 0, 0, 0})
-	setVoteCommunication(timeslot, 2, []int{1, 0, 0, 0})
-	setVoteCommunication(timeslot, 3, []int{1, 0, 0, 0})
-	//
-	timeslot = setTimeSlot(4)
-
-	timeslot = setTimeSlot(5)
-	setProposeCommunication(timeslot, 0, []int{0, 0, 1, 0})
-	setVoteCommunication(timeslot, 2, []int{1, 0, 0, 0})
-
-	timeslot = setTimeSlot(6)
-	setVoteCommunication(timeslot, 0, []int{1, 0, 0, 0})
-	setVoteCommunication(timeslot, 1, []int{1, 0, 0, 0})
-	setVoteCommunication(timeslot, 2, []int{1, 0, 0, 0})
-	setVoteCommunication(timeslot, 3, []int{1, 0, 0, 0})
-
-	timeslot = setTimeSlot(7)
-	setVoteCommunication(timeslot, 1, []int{0, 1, 1, 1})
-	setVoteCommunication(timeslot, 2, []int{0, 1, 1, 1})
-	setVoteCommunication(timeslot, 3, []int{0, 1, 1, 1})
-
-	timeslot = setTimeSlot(8)
-	setProposeCommunication(timeslot, 3, []int{0, 1, 0, 0})
-	setProposeCommunication(timeslot, 1, []int{0, 0, 0, 1})
-
-	timeslot = setTimeSlot(9)
-	setProposeCommunication(timeslot, 0, []int{0, 0, 0, 0})
-
-	timeslot = setTimeSlot(10)
-	setProposeCommunication(timeslot, 1, []int{0, 1, 1, 1})
-	timeslot = setTimeSlot(11)
-	setProposeCommunication(timeslot, 2, []int{0, 1, 1, 1})
-	timeslot = setTimeSlot(12)
-	setProposeCommunication(timeslot, 3, []int{0, 1, 1, 1})
-
-	timeslot = setTimeSlot(13)
-
-	/*
-		END YOUR SIMULATION HERE
-	*/
-	GetSimulation().setMaxTimeSlot(timeslot)
-	startNode()
-	go func() {
-		lastTimeSlot := uint64(0)
-		for {
-			curTimeSlot := (common.GetTimeSlot(genesisTime.Unix(), time.Now().Unix(), blsbftv2.TIMESLOT) - startTimeSlot) + 1
-			if lastTimeSlot != curTimeSlot {
-				time.AfterFunc(time.Millisecond*500, func() {
-					fmt.Printf("Best view height: %d. Final view height: %d\n", fullnode.GetBestView().GetHeight(), fullnode.GetFinalView().GetHeight())
-				})
-			}
-			for _, v := range nodeList {
-				if lastTimeSlot != curTimeSlot && curTimeSlot <= GetSimulation().maxTimeSlot {
-					v.consensusEngine.Logger.Info("========================================")
-					v.consensusEngine.Logger.Info("SIMULATION NODE", v.id, "TIMESLOT", curTimeSlot)
-					v.consensusEngine.Logger.Info("========================================")
-				}
-			}
-			lastTimeSlot = curTimeSlot
-			time.Sleep(1 * time.Millisecond)
-		}
-	}()
-	select {}
+	runMain4(t, 0, scenarioMain4B(), 17, Expected{MinFinalHeight: 11})
 }
 
 func Test_Main4Committee_ScenarioC(t *testing.T) {
-	committee := []string{
-		"112t8rnXB47RhSdyVRU41TEf78nxbtWGtmjutwSp9YqsNaCpFxQGXcnwcXTtBkCGDk1KLBRBeWMvb2aXG5SeDUJRHtFV8jTB3weHEkbMJ1AL",
-		"112t8rnXVdfBqBMigSs5fm9NSS8rgsVVURUxArpv6DxYmPZujKqomqUa2H9wh1zkkmDGtDn2woK4NuRDYnYRtVkUhK34TMfbUF4MShSkrCw5",
-		"112t8rnXi8eKJ5RYJjyQYcFMThfbXHgaL6pq5AF5bWsDXwfsw8pqQUreDv6qgWyiABoDdphvqE7NFr9K92aomX7Gi5Nm1e4tEoV3qRLVdfSR",
-		"112t8rnY42xRqJghQX3zvhgEa2ZJBwSzJ46SXyVQEam1yNpN4bfAqJwh1SsobjHAz8wwRvwnqJBfxrbwUuTxqgEbuEE8yMu6F14QmwtwyM43",
-	}
-	committeePkStruct := []incognitokey.CommitteePublicKey{}
-	for _, v := range committee {
-		p, _ := blsbftv2.LoadUserKeyFromIncPrivateKey(v)
-		m, _ := blsbftv2.GetMiningKeyFromPrivateSeed(p)
-		committeePkStruct = append(committeePkStruct, m.GetPublicKey())
-	}
-	nodeList := []*Node{}
-	genesisTime, _ := time.Parse(shard.GENESIS_TIMESTAMP, blockchain.TestnetGenesisBlockTime)
-	for {
-		if int(common.GetTimeSlot(genesisTime.Unix(), time.Now().Unix(), blsbftv2.TIMESLOT))%len(committee) == len(committee)-1 {
-			break
-		} else {
-			time.Sleep(1 * time.Millisecond)
-		}
-	}
-
-	for i, _ := range committee {
-		ni := NewNode(committeePkStruct, committee, i)
-		nodeList = append(nodeList, ni)
-	}
-	var startNode = func() {
-		for _, v := range nodeList {
-			v.nodeList = nodeList
-			go v.Start()
-		}
-	}
-	GetSimulation().nodeList = nodeList
-	//simulation
-	rootTimeSlot := nodeList[0].chain.GetBestView().GetRootTimeSlot()
-	startTimeSlot := rootTimeSlot + 1
-	fmt.Println("root Time slot", rootTimeSlot)
-	GetSimulation().setStartTimeSlot(startTimeSlot)
-	var setTimeSlot = func(s int) uint64 {
-		return startTimeSlot + uint64(s) - 1
-	}
-	var setProposeCommunication = func(timeslot uint64, nodeID int, scenario []int) {
-		if GetSimulation().scenario.proposeComm[timeslot] == nil {
-			GetSimulation().scenario.proposeComm[timeslot] = make(map[string][]int)
-		}
-		GetSimulation().scenario.proposeComm[timeslot][fmt.Sprintf("%d", int(int(startTimeSlot)+nodeID)%len(committee))] = scenario
-	}
-	//var setVoteCommunication = func(timeslot uint64, nodeID int, scenario []int) {
-	//	if GetSimulation().scenario.voteComm[timeslot] == nil {
-	//		GetSimulation().scenario.voteComm[timeslot] = make(map[string][]int)
-	//	}
-	//	GetSimulation().scenario.voteComm[timeslot][fmt.Sprintf("%d", int(int(startTimeSlot)+nodeID)%len(committee))] = scenario
-	//}
+	runMain4(t, 0, scenarioMain4C(), 10, Expected{MinFinalHeight: 9})
+}
 
-	for _, v := range nodeList {
-		v.consensusEngine.Logger.Info("\n\n")
-		v.consensusEngine.Logger.Info("===============================")
-		v.consensusEngine.Logger.Info("\n\n")
-		fmt.Printf("Node %s log is %s\n", v.id, fmt.Sprintf("log%s.log", v.id))
+func Test_Main4Committee_Deterministic(t *testing.T) {
+	first := runMain4(t, 0, scenarioMain4B(), 17, Expected{})
+	second := runMain4(t, 0, scenarioMain4B(), 17, Expected{})
+	for i := range first.Nodes() {
+		assert.Equal(t, first.Node(i).GetChain().GetBestViewHash(), second.Node(i).GetChain().GetBestViewHash())
+		assert.Equal(t, first.Node(i).GetChain().GetFinalViewHash(), second.Node(i).GetChain().GetFinalViewHash())
 	}
-
-	/*
-		START YOUR SIMULATION HERE
-	*/
-	timeslot := setTimeSlot(1)
-	setProposeCommunication(timeslot, 0, []int{0, 0, 0, 0})
-	setProposeCommunication(timeslot, 1, []int{0, 0, 0, 0})
-	setProposeCommunication(timeslot, 2, []int{0, 0, 0, 0})
-	setProposeCommunication(timeslot, 3, []int{0, 0, 0, 0})
-
-	timeslot = setTimeSlot(100) //normal communication, full connect by default
-
-	/*
-		END YOUR SIMULATION HERE
-	*/
-	GetSimulation().setMaxTimeSlot(timeslot)
-	startNode()
-	go func() {
-		lastTimeSlot := uint64(0)
-		for {
-			curTimeSlot := (common.GetTimeSlot(genesisTime.Unix(), time.Now().Unix(), blsbftv2.TIMESLOT) - startTimeSlot) + 1
-			if lastTimeSlot != curTimeSlot {
-				time.AfterFunc(time.Millisecond*500, func() {
-					fmt.Printf("Best view height: %d. Final view height: %d\n", fullnode.GetBestView().GetHeight(), fullnode.GetFinalView().GetHeight())
-				})
-			}
-			for _, v := range nodeList {
-				if lastTimeSlot != curTimeSlot && curTimeSlot <= GetSimulation().maxTimeSlot {
-					v.consensusEngine.Logger.Info("========================================")
-					v.consensusEngine.Logger.Info("SIMULATION NODE", v.id, "TIMESLOT", curTimeSlot)
-					v.consensusEngine.Logger.Info("========================================")
-				}
-			}
-			lastTimeSlot = curTimeSlot
-			time.Sleep(1 * time.Millisecond)
-		}
-	}()
-	select {}
 }
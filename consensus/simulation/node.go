@@ -1,241 +1,109 @@
-package main
+package simulation
 
 import (
 	"fmt"
-	"github.com/incognitochain/incognito-chain/blockchain"
-	"strconv"
-	"time"
-
-	"os"
-
-	blockchainv2 "github.com/incognitochain/incognito-chain/blockchain/v2"
-	shardv2 "github.com/incognitochain/incognito-chain/blockchain/v2/shard"
-	"github.com/incognitochain/incognito-chain/consensus_v2"
 
 	"github.com/incognitochain/incognito-chain/common"
-	"github.com/incognitochain/incognito-chain/consensus_v2/blsbftv2"
-	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
 	"github.com/incognitochain/incognito-chain/wire"
-	libp2p "github.com/libp2p/go-libp2p-peer"
+	peer "github.com/libp2p/go-libp2p-peer"
 )
 
+// Node is a committee member of the simulation, it runs the consensus on its chain
+// and talks to the other nodes through the simulated network
 type Node struct {
-	id              string
-	consensusEngine *blsbftv2.BLSBFT
-	chain           consensus.ChainViewManagerInterface
-	nodeList        []*Node
-	startSimulation bool
+	id         int
+	peerID     peer.ID
+	miningKey  *blsbftv2.MiningKey
+	chain      *Chain
+	consensus  *blsbftv2.BLSBFT_V2
+	simulation *Simulation
 }
 
-type logWriter struct {
-	NodeID string
-	fd     *os.File
+func (node *Node) GetID() int {
+	return node.id
 }
 
-func (s logWriter) Write(p []byte) (n int, err error) {
-	s.fd.Write(p)
-	return len(p), nil
+func (node *Node) GetChain() *Chain {
+	return node.chain
 }
 
-var fullnode *blockchainv2.ChainViewManager
-
-func NewNode(committeePkStruct []incognitokey.CommitteePublicKey, committee []string, index int) *Node {
-	name := fmt.Sprintf("log%d", index)
-	fd, err := os.OpenFile(fmt.Sprintf("%s.log", name), os.O_CREATE|os.O_WRONLY, 0666)
-	if err != nil {
-		panic(err)
-	}
-	fd.Truncate(0)
-	backendLog := common.NewBackend(logWriter{
-		NodeID: name,
-		fd:     fd,
-	})
-	consensusLogger := backendLog.Logger("Consensus", false)
-	consensusLogger.SetLevel(1)
-	chainViewLogger := backendLog.Logger("ShardView", false)
-	chainViewLogger.SetLevel(1)
-
-	node := Node{id: fmt.Sprintf("%d", index)}
-	db := &FakeDB{}
-	db.genesisBlock = shardv2.CreateShardGenesisBlock(1, blockchain.Testnet, blockchain.TestnetGenesisBlockTime, blockchain.TestnetInitPRV)
-	node.chain = blockchainv2.InitNewChainViewManager(fmt.Sprintf("shard0_%d", index), &shardv2.ShardView{
-		BC:             &shardv2.FakeBC{},
-		Block:          db.genesisBlock.(*shardv2.ShardBlock),
-		ShardCommittee: committeePkStruct,
-		DB:             db,
-		Logger:         chainViewLogger,
-	})
-
-	if fullnode == nil {
-		db := &FakeDB{}
-		db.genesisBlock = shardv2.CreateShardGenesisBlock(1, blockchain.Testnet, blockchain.TestnetGenesisBlockTime, blockchain.TestnetInitPRV)
-		backendLog := common.NewBackend(nil).Logger("Fullnode", false)
-		fullnode = blockchainv2.InitNewChainViewManager("fullnode", &shardv2.ShardView{
-			ShardID:        0,
-			Block:          db.genesisBlock.(*shardv2.ShardBlock),
-			ShardCommittee: committeePkStruct,
-			DB:             db,
-			Logger:         backendLog,
-		})
-	}
-
-	//node.chain.UserPubKey = committeePkStruct[index]
-	node.chain.GetBestView()
-
-	node.consensusEngine = &blsbftv2.BLSBFT{
-		Chain:    node.chain,
-		Node:     &node,
-		ChainKey: "shard",
-		PeerID:   name,
-		Logger:   consensusLogger,
-	}
-
-	prvSeed, err := blsbftv2.LoadUserKeyFromIncPrivateKey(committee[index])
-	failOnError(err)
-	failOnError(node.consensusEngine.LoadUserKey(prvSeed))
-	return &node
+func (node *Node) GetConsensus() *blsbftv2.BLSBFT_V2 {
+	return node.consensus
 }
 
-func (s *Node) Start() {
-	s.consensusEngine.Start()
+func (node *Node) PushMessageToChain(msg wire.Message, chain common.ChainInterface) error {
+	bftMsg, ok := msg.(*wire.MessageBFT)
+	if !ok {
+		return fmt.Errorf("unexpected message %v", msg.MessageType())
+	}
+	node.simulation.broadcast(node.id, &message{kind: bftMsg.Type, bft: bftMsg})
+	return nil
 }
 
-func (s *Node) GetID() string {
-	return s.id
+func (node *Node) IsEnableMining() bool {
+	return true
 }
 
-func (s *Node) RequestSyncBlock(nodeID string, fromView string, toView string) {
-	s.consensusEngine.Logger.Debug("Sync block from node", nodeID)
-	time.AfterFunc(time.Millisecond*100, func() {
-		nodeIDNumber, _ := strconv.Atoi(nodeID)
-		views := GetSimulation().nodeList[nodeIDNumber].chain.GetViewByRange(fromView, toView)
-		for _, v := range views {
-			s.chain.ConnectBlockAndAddView(v.GetBlock())
-		}
-		s.consensusEngine.Logger.Debug("Sync block ", len(views))
-	})
-
+func (node *Node) GetMiningKeys() string {
+	return ""
 }
 
-func (s *Node) NotifyOutdatedView(nodeID string, latestView string) {
-	time.AfterFunc(time.Millisecond*100, func() {
-		nodeIDNumber, _ := strconv.Atoi(nodeID)
-		views := s.chain.GetViewByRange("", latestView)
-		for _, v := range views {
-			GetSimulation().nodeList[nodeIDNumber].chain.ConnectBlockAndAddView(v.GetBlock())
-			GetSimulation().nodeList[nodeIDNumber].consensusEngine.Logger.Debug("Sync from notify outdated view, block height", v.GetHeight())
-		}
-	})
-
+func (node *Node) GetPrivateKey() string {
+	return ""
 }
 
-func (s *Node) BroadCastBlock(block consensus.BlockInterface) {
-	fullnode.ConnectBlockAndAddView(block)
+func (node *Node) GetUserMiningState() (role string, chainID int) {
+	return common.CommitteeRole, node.chain.chainID
 }
 
-func (s *Node) PushMessageToChain(msg interface{}, chain consensus.ChainViewManagerInterface) error {
-	time.AfterFunc(time.Millisecond*100, func() {
-		if msg.(*wire.MessageBFT).Type == "propose" {
-			timeSlot := msg.(*wire.MessageBFT).TimeSlot
-			if timeSlot > GetSimulation().maxTimeSlot {
-				os.Exit(0)
-			}
-			pComm := GetSimulation().scenario.proposeComm
-			if comm, ok := pComm[timeSlot]; ok {
-				for i, c := range s.nodeList {
-					if s.id == c.id {
-						continue
-					}
-					if senderComm, ok := comm[s.id]; ok {
-						if senderComm[i] == 1 {
-							s.consensusEngine.Logger.Debug("Send propose to ", c.id)
-							c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT), s)
-						}
-					} else {
-						s.consensusEngine.Logger.Debug("Send propose to ", c.id)
-						c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT), s)
-					}
-
-				}
-			} else {
-				for _, c := range s.nodeList {
-					if s.id == c.id {
-						continue
-					}
-					s.consensusEngine.Logger.Debug("Send propose to ", c.id)
-					c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT), s)
-				}
-			}
-			return
+// RequestMissingViewViaStream asks peerID for the blocks leading to hashes, they are
+// sent back over the network like the blocks of a stream
+func (node *Node) RequestMissingViewViaStream(peerID string, hashes [][]byte, fromCID int, chainName string) error {
+	sender := node.simulation.nodeByPeerID(peerID)
+	if sender == nil {
+		return fmt.Errorf("peer %v not found", peerID)
+	}
+	for _, hashBytes := range hashes {
+		hash, err := common.Hash{}.NewHash(hashBytes)
+		if err != nil {
+			return err
 		}
-
-		if msg.(*wire.MessageBFT).Type == "vote" {
-			vComm := GetSimulation().scenario.voteComm
-			timeSlot := msg.(*wire.MessageBFT).TimeSlot
-			if timeSlot > GetSimulation().maxTimeSlot {
-				os.Exit(0)
-			}
-			if comm, ok := vComm[timeSlot]; ok {
-				for i, c := range s.nodeList {
-					if s.id == c.id {
-						continue
-					}
-					if senderComm, ok := comm[s.id]; ok {
-						if senderComm[i] == 1 {
-							s.consensusEngine.Logger.Debug("Send vote to ", c.id)
-							c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT), s)
-						}
-					} else {
-						s.consensusEngine.Logger.Debug("Send vote to ", c.id)
-						c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT), s)
-					}
-
-				}
-			} else {
-				for _, c := range s.nodeList {
-					if s.id == c.id {
-						continue
-					}
-					s.consensusEngine.Logger.Debug("Send vote to ", c.id)
-					c.consensusEngine.ProcessBFTMsg(msg.(*wire.MessageBFT), s)
-				}
-			}
-			return
+		for _, block := range sender.missingBlocks(node, *hash) {
+			node.simulation.send(sender.id, node.id, &message{kind: MsgBlock, block: block})
 		}
-	})
-
+	}
 	return nil
 }
 
-func (Node) UpdateConsensusState(role string, userPbk string, currentShard *byte, beaconCommittee []string, shardCommittee map[byte][]string) {
-	//not use in bft
-	return
-}
-
-func (Node) IsEnableMining() bool {
-	//not use in bft
-	return true
-}
-
-func (Node) GetMiningKeys() string {
-	//not use in bft
-	panic("implement me")
+func (node *Node) GetSelfPeerID() peer.ID {
+	return node.peerID
 }
 
-func (Node) GetPrivateKey() string {
-	//not use in bft
-	panic("implement me")
-}
-
-func (Node) DropAllConnections() {
-	//not use in bft
-	return
+// missingBlocks returns the blocks of the chain of node up to hash that receiver has not inserted, oldest first
+func (node *Node) missingBlocks(receiver *Node, hash common.Hash) []*Block {
+	blocks := []*Block{}
+	for block := node.chain.GetBlock(hash); block != nil && receiver.chain.GetBlock(*block.Hash()) == nil; block = node.chain.GetBlock(block.GetPrevHash()) {
+		blocks = append([]*Block{block}, blocks...)
+	}
+	return blocks
 }
 
-func (Node) PushMessageToPeer(msg interface{}, peerId libp2p.ID) error {
-	return nil
+func (node *Node) broadcastBlock(block *Block) {
+	node.simulation.broadcast(node.id, &message{kind: MsgBlock, block: block})
 }
 
-func main() {
-
+// receiveBlock inserts a block received from sender, if the block does not connect
+// to the chain it asks sender for the block and the missing blocks before it
+func (node *Node) receiveBlock(sender *Node, block *Block) {
+	if node.chain.GetBlock(*block.Hash()) != nil {
+		return
+	}
+	if node.chain.GetViewByHash(block.GetPrevHash()) == nil {
+		node.RequestMissingViewViaStream(sender.peerID.String(), [][]byte{block.Hash().GetBytes()}, node.chain.chainID, node.chain.name)
+		return
+	}
+	if err := node.chain.InsertBlock(block); err != nil {
+		node.consensus.Logger.Info(err)
+	}
 }
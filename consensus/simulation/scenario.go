@@ -0,0 +1,140 @@
+package simulation
+
+import "github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+
+// kinds of the messages of the simulated network
+const (
+	MsgAny     = ""
+	MsgPropose = blsbftv2.MSG_PROPOSE
+	MsgVote    = blsbftv2.MSG_VOTE
+	MsgBlock   = "block"
+)
+
+// AnyNode matches every sender of a rule
+const AnyNode = -1
+
+// Scenario scripts the faults of the network, timeslots are counted from 1, the
+// first timeslot of the simulation, and node k is the proposer of timeslot k+1 modulo
+// the committee size. A message sent by a node to itself is never faulty
+type Scenario struct {
+	rules     []rule
+	byzantine map[int]byzantineProposal // timeslot -> conflicting proposal
+}
+
+// rule applies to the messages of kind sent by sender to receivers from timeslot fromTS to toTS
+type rule struct {
+	fromTS    int
+	toTS      int
+	kind      string
+	sender    int
+	receivers map[int]bool // nil for every receiver
+	groups    [][]int      // a partition drops the messages between groups
+	drop      bool
+	delay     int64
+}
+
+type byzantineProposal struct {
+	groupA []int
+	groupB []int
+}
+
+func NewScenario() *Scenario {
+	return &Scenario{byzantine: make(map[int]byzantineProposal)}
+}
+
+func toSet(nodes []int) map[int]bool {
+	if len(nodes) == 0 {
+		return nil
+	}
+	set := make(map[int]bool)
+	for _, node := range nodes {
+		set[node] = true
+	}
+	return set
+}
+
+// Drop drops the messages of kind sent by sender in timeSlot to receivers, to every node if receivers is empty
+func (scenario *Scenario) Drop(timeSlot int, kind string, sender int, receivers ...int) *Scenario {
+	scenario.rules = append(scenario.rules, rule{fromTS: timeSlot, toTS: timeSlot, kind: kind, sender: sender, receivers: toSet(receivers), drop: true})
+	return scenario
+}
+
+// Communicate only delivers the messages of kind sent by sender in timeSlot to the nodes i with connect[i] == 1
+func (scenario *Scenario) Communicate(timeSlot int, kind string, sender int, connect []int) *Scenario {
+	receivers := []int{}
+	for i, c := range connect {
+		if c == 0 {
+			receivers = append(receivers, i)
+		}
+	}
+	if len(receivers) == 0 {
+		return scenario
+	}
+	return scenario.Drop(timeSlot, kind, sender, receivers...)
+}
+
+// Delay delays by delay seconds the messages of kind sent by sender in timeSlot to receivers, to every node if receivers is empty
+func (scenario *Scenario) Delay(timeSlot int, kind string, sender int, delay int64, receivers ...int) *Scenario {
+	scenario.rules = append(scenario.rules, rule{fromTS: timeSlot, toTS: timeSlot, kind: kind, sender: sender, receivers: toSet(receivers), delay: delay})
+	return scenario
+}
+
+// Partition splits the network into groups from timeslot fromTS to toTS, the messages between
+// two groups are dropped, a node not in any group is isolated
+func (scenario *Scenario) Partition(fromTS int, toTS int, groups ...[]int) *Scenario {
+	scenario.rules = append(scenario.rules, rule{fromTS: fromTS, toTS: toTS, kind: MsgAny, sender: AnyNode, groups: groups, drop: true})
+	return scenario
+}
+
+// ByzantineProposer makes the proposer of timeSlot equivocate: its proposal is sent to groupA
+// and a conflicting block of the same height, signed by it too, is sent to groupB
+func (scenario *Scenario) ByzantineProposer(timeSlot int, groupA []int, groupB []int) *Scenario {
+	scenario.byzantine[timeSlot] = byzantineProposal{groupA: groupA, groupB: groupB}
+	return scenario
+}
+
+func groupOf(groups [][]int, node int) int {
+	for i, group := range groups {
+		for _, member := range group {
+			if member == node {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (r rule) match(timeSlot int, kind string, sender int, receiver int) bool {
+	if timeSlot < r.fromTS || timeSlot > r.toTS {
+		return false
+	}
+	if r.kind != MsgAny && r.kind != kind {
+		return false
+	}
+	if r.sender != AnyNode && r.sender != sender {
+		return false
+	}
+	if r.receivers != nil && !r.receivers[receiver] {
+		return false
+	}
+	if r.groups != nil {
+		senderGroup := groupOf(r.groups, sender)
+		return senderGroup == -1 || senderGroup != groupOf(r.groups, receiver)
+	}
+	return true
+}
+
+// fault returns whether a message is dropped and its extra delay
+func (scenario *Scenario) fault(timeSlot int, kind string, sender int, receiver int) (bool, int64) {
+	delay := int64(0)
+	for _, r := range scenario.rules {
+		if !r.match(timeSlot, kind, sender, receiver) {
+			continue
+		}
+		if r.drop {
+			return true, 0
+		}
+		delay += r.delay
+	}
+	return false, delay
+}
@@ -1,56 +1,390 @@
-package main
+package simulation
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/wire"
+	peer "github.com/libp2p/go-libp2p-peer"
 )
 
+// Simulation runs the BLSBFT_V2 consensus of a committee on a simulated network driven by a
+// virtual clock: every second it delivers the messages due and ticks every node, in order,
+// so that a run of a scenario is always the same
 type Simulation struct {
-	nodeList      []*Node
-	fd            os.File
-	maxTimeSlot   uint64
-	startTimeSlot uint64
-	scenario      Scenario
-	expected      Expected
+	config    SimulationConfig
+	nodes     []*Node
+	now       int64
+	startTime int64
+	queue     []*message
+	seq       int
+	byzantine map[int]*wire.MessageBFT // timeslot -> conflicting proposal sent
 }
 
-type Scenario struct {
-	proposeComm map[uint64]map[string][]int // timeSlot -> sender -> receiver
-	voteComm    map[uint64]map[string][]int
-	sync        map[string][]string
+type SimulationConfig struct {
+	NumNodes int
+	ChainID  int   // -1 for the beacon chain
+	Latency  int64 // seconds to deliver a message, 1 by default
+	Scenario *Scenario
+	LogDir   string // directory of the logs of the nodes, no log if empty
 }
 
+// Expected is checked against the chains of the nodes once the simulation ran
 type Expected struct {
+	MinFinalHeight uint64 // every node finalized at least this height
+}
+
+type message struct {
+	kind      string
+	from      int
+	to        int
+	deliverAt int64
+	seq       int
+	bft       *wire.MessageBFT
+	block     *Block
 }
 
-var simulation *Simulation
+type logWriter struct {
+	fd *os.File
+}
 
-func InitSimulation(scenario []map[string]Scenario, expected []map[string]Expected) *Simulation {
-	return simulation
+func (w logWriter) Write(p []byte) (n int, err error) {
+	return w.fd.Write(p)
 }
 
-func GetSimulation() *Simulation {
-	if simulation == nil {
-		simulation = new(Simulation)
-		simulation.scenario = Scenario{
-			proposeComm: make(map[uint64]map[string][]int),
-			voteComm:    make(map[uint64]map[string][]int),
-			sync:        make(map[string][]string),
+func NewSimulation(config SimulationConfig) (*Simulation, error) {
+	if config.NumNodes == 0 {
+		return nil, fmt.Errorf("no node to simulate")
+	}
+	if config.Latency == 0 {
+		config.Latency = 1
+	}
+	if config.Scenario == nil {
+		config.Scenario = NewScenario()
+	}
+	s := &Simulation{
+		config:    config,
+		byzantine: make(map[int]*wire.MessageBFT),
+	}
+	// start at a timeslot node 0 proposes in
+	startTimeSlot := int64(160000000)
+	startTimeSlot += int64(config.NumNodes) - startTimeSlot%int64(config.NumNodes)
+	s.startTime = startTimeSlot * common.TIMESLOT
+
+	privateSeeds := []string{}
+	committee := []incognitokey.CommitteePublicKey{}
+	for i := 0; i < config.NumNodes; i++ {
+		privateSeed := base58.Base58Check{}.Encode(common.HashB([]byte(fmt.Sprintf("simulation-%d", i))), common.Base58Version)
+		miningKey, err := blsbftv2.GetMiningKeyFromPrivateSeed(privateSeed)
+		if err != nil {
+			return nil, err
 		}
+		privateSeeds = append(privateSeeds, privateSeed)
+		committee = append(committee, miningKey.GetPublicKey())
 	}
-	return simulation
+	chainName := common.BeaconChainKey
+	if config.ChainID != -1 {
+		chainName = fmt.Sprintf("%s-%d", common.ShardChainKey, config.ChainID)
+	}
+	genesis := &Block{
+		Header: BlockHeader{
+			Version:     2,
+			ChainID:     config.ChainID,
+			Height:      1,
+			Epoch:       1,
+			ProduceTime: s.startTime - common.TIMESLOT,
+			ProposeTime: s.startTime - common.TIMESLOT,
+		},
+	}
+
+	for i := 0; i < config.NumNodes; i++ {
+		logger, err := s.newLogger(i)
+		if err != nil {
+			return nil, err
+		}
+		node := &Node{
+			id:         i,
+			peerID:     peer.ID(fmt.Sprintf("node-%d", i)),
+			simulation: s,
+		}
+		if node.miningKey, err = blsbftv2.GetMiningKeyFromPrivateSeed(privateSeeds[i]); err != nil {
+			return nil, err
+		}
+		node.chain = NewChain(chainName, config.ChainID, committee, genesis, node)
+		node.consensus = blsbftv2.NewInstance(node.chain, chainName, config.ChainID, node, logger)
+		node.consensus.PeerID = node.peerID.String()
+		node.consensus.Clock = func() int64 { return s.now }
+		node.consensus.Sync = true
+		if err := node.consensus.LoadUserKey(privateSeeds[i]); err != nil {
+			return nil, err
+		}
+		s.nodes = append(s.nodes, node)
+	}
+	return s, nil
 }
 
-func (s *Simulation) setMaxTimeSlot(max uint64) {
-	fmt.Println("Set Max Time Slot", max)
-	s.maxTimeSlot = max
+func (s *Simulation) newLogger(nodeID int) (common.Logger, error) {
+	if s.config.LogDir == "" {
+		return common.NewBackend(nil).Logger("Consensus", true), nil
+	}
+	fd, err := os.OpenFile(filepath.Join(s.config.LogDir, fmt.Sprintf("log%d.log", nodeID)), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
+	if err != nil {
+		return nil, err
+	}
+	logger := common.NewBackend(logWriter{fd: fd}).Logger("Consensus", false)
+	logger.SetLevel(common.LevelDebug)
+	return logger, nil
 }
-func (s *Simulation) setStartTimeSlot(start uint64) {
-	fmt.Println("Set Start Time Slot", start)
-	s.startTimeSlot = start
+
+func (s *Simulation) Node(id int) *Node {
+	return s.nodes[id]
 }
 
-func (s *Simulation) setLogfile(fd os.File) {
-	s.fd = fd
+func (s *Simulation) Nodes() []*Node {
+	return s.nodes
+}
+
+// TimeSlot returns the timeslot of the simulation the virtual clock is in, counted from 1
+func (s *Simulation) TimeSlot() int {
+	return int((s.now-s.startTime)/common.TIMESLOT) + 1
+}
 
+func (s *Simulation) nodeByPeerID(peerID string) *Node {
+	for _, node := range s.nodes {
+		if node.peerID.String() == peerID {
+			return node
+		}
+	}
+	return nil
+}
+
+// Run starts the nodes and runs the simulation for timeSlots timeslots
+func (s *Simulation) Run(timeSlots int) {
+	for _, node := range s.nodes {
+		if !node.consensus.IsStarted() {
+			node.consensus.Start()
+		}
+	}
+	if s.now < s.startTime {
+		s.now = s.startTime
+	}
+	endTime := s.startTime + int64(timeSlots)*common.TIMESLOT
+	for ; s.now < endTime; s.now++ {
+		for s.deliverNext() {
+		}
+		for _, node := range s.nodes {
+			node.consensus.Tick()
+		}
+	}
+}
+
+// Stop stops the consensus of the nodes
+func (s *Simulation) Stop() {
+	for _, node := range s.nodes {
+		node.consensus.Stop()
+	}
+}
+
+// send queues a message from a node to another one, unless the scenario drops it
+func (s *Simulation) send(from int, to int, msg *message) {
+	deliverAt := s.now
+	if from != to {
+		drop, delay := s.config.Scenario.fault(s.TimeSlot(), msg.kind, from, to)
+		if drop {
+			return
+		}
+		deliverAt += s.config.Latency + delay
+	}
+	s.seq++
+	s.queue = append(s.queue, &message{
+		kind:      msg.kind,
+		from:      from,
+		to:        to,
+		deliverAt: deliverAt,
+		seq:       s.seq,
+		bft:       msg.bft,
+		block:     msg.block,
+	})
+}
+
+// broadcast sends a message to every node, the sender included as the pubsub of the network does,
+// the proposal of a byzantine proposer is replaced by a conflicting one for some nodes
+func (s *Simulation) broadcast(from int, msg *message) {
+	timeSlot := s.TimeSlot()
+	byzantine, isByzantine := s.config.Scenario.byzantine[timeSlot]
+	if msg.kind != MsgPropose || !isByzantine || s.proposerOf(timeSlot) != from {
+		for to := range s.nodes {
+			if msg.kind == MsgBlock && to == from {
+				continue
+			}
+			s.send(from, to, msg)
+		}
+		return
+	}
+	s.send(from, from, msg)
+	for _, to := range byzantine.groupA {
+		if to != from {
+			s.send(from, to, msg)
+		}
+	}
+	conflicting, err := s.conflictingProposal(timeSlot, msg.bft)
+	if err != nil {
+		s.nodes[from].consensus.Logger.Error(err)
+		return
+	}
+	for _, to := range byzantine.groupB {
+		if to != from {
+			s.send(from, to, &message{kind: MsgPropose, bft: conflicting})
+		}
+	}
+}
+
+func (s *Simulation) proposerOf(timeSlot int) int {
+	return (timeSlot - 1) % len(s.nodes)
+}
+
+// conflictingProposal returns a proposal of a block conflicting with the one proposed in proposeMsg,
+// produced a second later and signed by the same proposer
+func (s *Simulation) conflictingProposal(timeSlot int, proposeMsg *wire.MessageBFT) (*wire.MessageBFT, error) {
+	if conflicting, ok := s.byzantine[timeSlot]; ok {
+		return conflicting, nil
+	}
+	var propose blsbftv2.BFTPropose
+	if err := json.Unmarshal(proposeMsg.Content, &propose); err != nil {
+		return nil, err
+	}
+	var block Block
+	if err := json.Unmarshal(propose.Block, &block); err != nil {
+		return nil, err
+	}
+	block.Header.ProduceTime++
+	proposer := s.nodes[s.proposerOf(timeSlot)]
+	sig, err := proposer.miningKey.SignProposal(signer.NewBlockInfo(proposeMsg.ChainKey, &block))
+	if err != nil {
+		return nil, err
+	}
+	validationData, err := blsbftv2.EncodeValidationData(blsbftv2.ValidationData{ProducerBLSSig: sig})
+	if err != nil {
+		return nil, err
+	}
+	block.ValidationData = validationData
+	if propose.Block, err = json.Marshal(&block); err != nil {
+		return nil, err
+	}
+	msg, err := blsbftv2.MakeBFTProposeMsg(&propose, proposeMsg.ChainKey, proposeMsg.TimeSlot, block.GetHeight())
+	if err != nil {
+		return nil, err
+	}
+	s.byzantine[timeSlot] = msg.(*wire.MessageBFT)
+	return s.byzantine[timeSlot], nil
+}
+
+// deliverNext delivers the first message due, it returns false if there is none
+func (s *Simulation) deliverNext() bool {
+	next := -1
+	for i, msg := range s.queue {
+		if msg.deliverAt > s.now {
+			continue
+		}
+		if next == -1 || msg.deliverAt < s.queue[next].deliverAt || (msg.deliverAt == s.queue[next].deliverAt && msg.seq < s.queue[next].seq) {
+			next = i
+		}
+	}
+	if next == -1 {
+		return false
+	}
+	msg := s.queue[next]
+	s.queue = append(s.queue[:next], s.queue[next+1:]...)
+	sender, receiver := s.nodes[msg.from], s.nodes[msg.to]
+	if msg.kind == MsgBlock {
+		receiver.receiveBlock(sender, msg.block)
+		return true
+	}
+	bftMsg := *msg.bft
+	bftMsg.PeerID = sender.peerID.String()
+	receiver.consensus.ProcessBFTMsg(&bftMsg)
+	return true
+}
+
+// Check checks the chains of the nodes against the properties of the consensus and expected
+func (s *Simulation) Check(expected Expected) error {
+	if err := s.CheckSafety(); err != nil {
+		return err
+	}
+	if err := s.CheckForkChoice(); err != nil {
+		return err
+	}
+	return s.CheckLiveness(expected.MinFinalHeight)
+}
+
+// CheckSafety checks that the final views of the nodes are on the same chain
+func (s *Simulation) CheckSafety() error {
+	for _, node := range s.nodes {
+		for _, other := range s.nodes {
+			finalView, otherFinalView := node.chain.GetFinalView(), other.chain.GetFinalView()
+			if finalView.GetHeight() < otherFinalView.GetHeight() {
+				continue
+			}
+			block := node.chain.GetBlock(*finalView.GetHash())
+			for block != nil && block.GetHeight() > otherFinalView.GetHeight() {
+				block = node.chain.GetBlock(block.GetPrevHash())
+			}
+			if block == nil || !block.Hash().IsEqual(otherFinalView.GetHash()) {
+				return fmt.Errorf("node %v finalized block %v at height %v, node %v finalized a conflicting chain", other.id, otherFinalView.GetHash().String(), otherFinalView.GetHeight(), node.id)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckForkChoice checks that the best view of every node is the highest view it has,
+// and the one produced first among the views of the same height
+func (s *Simulation) CheckForkChoice() error {
+	for _, node := range s.nodes {
+		bestView := node.chain.GetBestView()
+		for _, view := range node.chain.multiView.GetAllViewsWithBFS() {
+			if view.GetHeight() > bestView.GetHeight() {
+				return fmt.Errorf("node %v has view %v higher than its best view %v", node.id, view.GetHash().String(), bestView.GetHash().String())
+			}
+			if view.GetHeight() == bestView.GetHeight() && view.GetBlock().GetProduceTime() < bestView.GetBlock().GetProduceTime() {
+				return fmt.Errorf("node %v has view %v produced before its best view %v", node.id, view.GetHash().String(), bestView.GetHash().String())
+			}
+		}
+	}
+	return nil
+}
+
+// CheckLiveness checks that every node finalized at least minFinalHeight
+func (s *Simulation) CheckLiveness(minFinalHeight uint64) error {
+	for _, node := range s.nodes {
+		if height := node.chain.GetFinalView().GetHeight(); height < minFinalHeight {
+			return fmt.Errorf("node %v finalized height %v, expected at least %v", node.id, height, minFinalHeight)
+		}
+	}
+	return nil
+}
+
+// FinalHeights returns the height of the final view of every node
+func (s *Simulation) FinalHeights() []uint64 {
+	heights := []uint64{}
+	for _, node := range s.nodes {
+		heights = append(heights, node.chain.GetFinalView().GetHeight())
+	}
+	return heights
+}
+
+// BestHeights returns the height of the best view of every node
+func (s *Simulation) BestHeights() []uint64 {
+	heights := []uint64{}
+	for _, node := range s.nodes {
+		heights = append(heights, node.chain.GetBestView().GetHeight())
+	}
+	return heights
 }
@@ -0,0 +1,50 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulationPartition(t *testing.T) {
+	// no group holds more than 2/3 of the committee while the network is split
+	scenario := NewScenario().Partition(3, 8, []int{0, 1}, []int{2, 3})
+	s, err := NewSimulation(SimulationConfig{NumNodes: 4, ChainID: 0, Scenario: scenario})
+	assert.Equal(t, nil, err)
+	defer s.Stop()
+	s.Run(8)
+	t.Log("final heights", s.FinalHeights(), "best heights", s.BestHeights())
+	assert.Equal(t, nil, s.CheckSafety())
+	assert.Equal(t, nil, s.CheckForkChoice())
+	finalHeights := s.FinalHeights()
+
+	// the chain goes on once the partition heals
+	s.Run(16)
+	t.Log("final heights", s.FinalHeights(), "best heights", s.BestHeights())
+	assert.Equal(t, nil, s.Check(Expected{MinFinalHeight: finalHeights[0] + 1}))
+}
+
+func TestSimulationDelay(t *testing.T) {
+	scenario := NewScenario()
+	for timeSlot := 2; timeSlot <= 6; timeSlot++ {
+		scenario.Delay(timeSlot, MsgVote, AnyNode, 12)
+	}
+	s, err := NewSimulation(SimulationConfig{NumNodes: 4, ChainID: 0, Scenario: scenario})
+	assert.Equal(t, nil, err)
+	defer s.Stop()
+	s.Run(12)
+	t.Log("final heights", s.FinalHeights(), "best heights", s.BestHeights())
+	assert.Equal(t, nil, s.Check(Expected{MinFinalHeight: 9}))
+}
+
+func TestSimulationByzantineProposer(t *testing.T) {
+	// neither block gets enough votes in timeslot 3
+	scenario := NewScenario().ByzantineProposer(3, []int{0}, []int{1, 3})
+	s, err := NewSimulation(SimulationConfig{NumNodes: 4, ChainID: 0, Scenario: scenario})
+	assert.Equal(t, nil, err)
+	defer s.Stop()
+	s.Run(12)
+	t.Log("final heights", s.FinalHeights(), "best heights", s.BestHeights())
+	assert.NotEqual(t, nil, s.byzantine[3])
+	assert.Equal(t, nil, s.Check(Expected{MinFinalHeight: 11}))
+}
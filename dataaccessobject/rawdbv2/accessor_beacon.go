@@ -23,6 +23,34 @@ func GetBeaconRootsHash(db incdb.KeyValueReader, hash common.Hash) ([]byte, erro
 	return data, err
 }
 
+// StoreBeaconSlashRootHash records the root of a beacon slash state, the
+// state of the recorded roots is never pruned
+func StoreBeaconSlashRootHash(db incdb.KeyValueWriter, rootHash common.Hash) error {
+	if err := db.Put(GetBeaconSlashRootHashKey(rootHash), []byte{}); err != nil {
+		return NewRawdbError(StoreBeaconSlashRootHashError, err)
+	}
+	return nil
+}
+
+// GetBeaconSlashRootHashes returns the roots recorded by StoreBeaconSlashRootHash
+func GetBeaconSlashRootHashes(db incdb.Database) ([]common.Hash, error) {
+	prefix := GetBeaconSlashRootHashPrefix()
+	iterator := db.NewIteratorWithPrefix(prefix)
+	defer iterator.Release()
+	rootHashes := []common.Hash{}
+	for iterator.Next() {
+		rootHash := common.Hash{}
+		if err := rootHash.SetBytes(iterator.Key()[len(prefix):]); err != nil {
+			return nil, NewRawdbError(GetBeaconSlashRootHashError, err)
+		}
+		rootHashes = append(rootHashes, rootHash)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, NewRawdbError(GetBeaconSlashRootHashError, err)
+	}
+	return rootHashes, nil
+}
+
 // StoreBeaconBlock store block hash => block value
 func StoreBeaconBlockByHash(db incdb.KeyValueWriter, hash common.Hash, v interface{}) error {
 	keyHash := GetBeaconHashToBlockKey(hash)
@@ -0,0 +1,63 @@
+package rawdbv2
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// StoreLightBeaconBlock store beacon height => beacon block kept by a light client
+func StoreLightBeaconBlock(db incdb.KeyValueWriter, height uint64, v interface{}) error {
+	val, err := json.Marshal(v)
+	if err != nil {
+		return NewRawdbError(StoreLightBeaconBlockError, err)
+	}
+	if err := db.Put(GetLightBeaconBlockKey(height), val); err != nil {
+		return NewRawdbError(StoreLightBeaconBlockError, err)
+	}
+	return nil
+}
+
+func GetLightBeaconBlock(db incdb.KeyValueReader, height uint64) ([]byte, error) {
+	val, err := db.Get(GetLightBeaconBlockKey(height))
+	if err != nil {
+		return nil, NewRawdbError(GetLightBeaconBlockError, err)
+	}
+	return val, nil
+}
+
+// StoreLightShardBlockHash store shard id, shard height => hash of the shard block confirmed by a beacon block
+func StoreLightShardBlockHash(db incdb.KeyValueWriter, shardID byte, height uint64, hash common.Hash) error {
+	if err := db.Put(GetLightShardBlockHashKey(shardID, height), hash.Bytes()); err != nil {
+		return NewRawdbError(StoreLightBeaconBlockError, err)
+	}
+	return nil
+}
+
+func GetLightShardBlockHash(db incdb.KeyValueReader, shardID byte, height uint64) (*common.Hash, error) {
+	val, err := db.Get(GetLightShardBlockHashKey(shardID, height))
+	if err != nil {
+		return nil, NewRawdbError(GetLightBeaconBlockError, err)
+	}
+	return common.Hash{}.NewHash(val)
+}
+
+func StoreLightClientState(db incdb.KeyValueWriter, v interface{}) error {
+	val, err := json.Marshal(v)
+	if err != nil {
+		return NewRawdbError(StoreLightClientStateError, err)
+	}
+	if err := db.Put(GetLightClientStateKey(), val); err != nil {
+		return NewRawdbError(StoreLightClientStateError, err)
+	}
+	return nil
+}
+
+func GetLightClientState(db incdb.KeyValueReader) ([]byte, error) {
+	val, err := db.Get(GetLightClientStateKey())
+	if err != nil {
+		return nil, NewRawdbError(GetLightClientStateError, err)
+	}
+	return val, nil
+}
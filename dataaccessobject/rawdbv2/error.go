@@ -88,6 +88,12 @@ const (
 	StoreRelayingBNBHeaderError
 	GetRelayingBNBHeaderError
 	GetBNBDataHashError
+
+	// light client
+	StoreLightBeaconBlockError
+	GetLightBeaconBlockError
+	StoreLightClientStateError
+	GetLightClientStateError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -173,6 +179,12 @@ var ErrCodeMessage = map[int]struct {
 	StoreRelayingBNBHeaderError: {-5001, "Store relaying header bnb error"},
 	GetRelayingBNBHeaderError:   {-5002, "Get relaying header bnb error"},
 	GetBNBDataHashError:         {-5003, "Get bnb data hash by block height error"},
+
+	// light client
+	StoreLightBeaconBlockError: {-6000, "Store Light Beacon Block Error"},
+	GetLightBeaconBlockError:   {-6001, "Get Light Beacon Block Error"},
+	StoreLightClientStateError: {-6002, "Store Light Client State Error"},
+	GetLightClientStateError:   {-6003, "Get Light Client State Error"},
 }
 
 type RawdbError struct {
@@ -35,6 +35,9 @@ var (
 	shardSlashRootHashPrefix           = []byte("s-sl" + string(splitter))
 	shardFeatureRootHashPrefix         = []byte("s-fe" + string(splitter))
 	previousBestStatePrefix            = []byte("previous-best-state" + string(splitter))
+	lightBeaconBlockPrefix             = []byte("l-b-i" + string(splitter))
+	lightShardBlockHashPrefix          = []byte("l-s-i" + string(splitter))
+	lightClientStateKey                = []byte("LightClientState")
 	splitter                           = []byte("-[-]-")
 )
 
@@ -215,13 +218,16 @@ func GetBeaconFeatureRootHashKey(height uint64) []byte {
 	return key
 }
 
-func GetBeaconSlashRootHashKey(height uint64) []byte {
-	buf := common.Uint64ToBytes(height)
+func GetBeaconSlashRootHashPrefix() []byte {
 	rootHashPrefix := GetRootHashPrefix()
 	temp := make([]byte, 0, len(beaconSlashRootHashPrefix))
 	temp = append(temp, beaconSlashRootHashPrefix...)
-	key := append(rootHashPrefix, temp...)
-	key = append(key, buf...)
+	return append(rootHashPrefix, temp...)
+}
+
+func GetBeaconSlashRootHashKey(rootHash common.Hash) []byte {
+	key := GetBeaconSlashRootHashPrefix()
+	key = append(key, rootHash.Bytes()...)
 	return key
 }
 
@@ -333,3 +339,22 @@ func getShardPreCommitteeInfoForShardKey(hash common.Hash) []byte {
 func getShardPendingValidatorsKey(hash common.Hash) []byte {
 	return hash.Bytes()
 }
+
+// ============================= Light client =======================================
+func GetLightBeaconBlockKey(height uint64) []byte {
+	temp := make([]byte, 0, len(lightBeaconBlockPrefix))
+	temp = append(temp, lightBeaconBlockPrefix...)
+	return append(temp, common.Uint64ToBytes(height)...)
+}
+
+func GetLightShardBlockHashKey(shardID byte, height uint64) []byte {
+	temp := make([]byte, 0, len(lightShardBlockHashPrefix))
+	temp = append(temp, lightShardBlockHashPrefix...)
+	temp = append(temp, shardID)
+	return append(temp, common.Uint64ToBytes(height)...)
+}
+
+func GetLightClientStateKey() []byte {
+	temp := make([]byte, 0, len(lightClientStateKey))
+	return append(temp, lightClientStateKey...)
+}
@@ -19,6 +19,15 @@ var (
 	PDETradeStatusPrefix         = []byte("pdetradestatus-")
 	PDEWithdrawalStatusPrefix    = []byte("pdewithdrawalstatus-")
 	PDEFeeWithdrawalStatusPrefix = []byte("pdefeewithdrawalstatus-")
+
+	// PDE v3
+	PDEPoolV3Prefix  = []byte("pdepoolv3-")
+	PDEShareV3Prefix = []byte("pdesharev3-")
+
+	// PDE limit orders
+	PDELimitOrderPrefix             = []byte("pdelimitorder-")
+	PDELimitOrderStatusPrefix       = []byte("pdelimitorderstatus-")
+	PDECancelLimitOrderStatusPrefix = []byte("pdecancellimitorderstatus-")
 )
 
 // TODO - change json to CamelCase
@@ -39,6 +48,7 @@ type PDEContribution struct {
 	TokenIDStr            string
 	Amount                uint64
 	TxReqID               common.Hash
+	FeeTier               uint64 `json:",omitempty"` // only set by PDE v3 contributions
 }
 
 func NewPDEContribution(contributorAddressStr string, tokenIDStr string, amount uint64, txReqID common.Hash) *PDEContribution {
@@ -50,12 +60,30 @@ type PDEPoolForPair struct {
 	Token1PoolValue uint64
 	Token2IDStr     string
 	Token2PoolValue uint64
+	FeeTier         uint64 `json:",omitempty"` // only set for PDE v3 pools
 }
 
 func NewPDEPoolForPair(token1IDStr string, token1PoolValue uint64, token2IDStr string, token2PoolValue uint64) *PDEPoolForPair {
 	return &PDEPoolForPair{Token1IDStr: token1IDStr, Token1PoolValue: token1PoolValue, Token2IDStr: token2IDStr, Token2PoolValue: token2PoolValue}
 }
 
+// PDELimitOrder is a limit order resting in pde state, ExpiryBeaconHeight is 0
+// for an order that never expires
+type PDELimitOrder struct {
+	OrderID             common.Hash
+	TraderAddressStr    string
+	TokenIDToBuyStr     string
+	TokenIDToSellStr    string
+	SellAmount          uint64
+	MinAcceptableAmount uint64
+	ExpiryBeaconHeight  uint64
+	ShardID             byte
+}
+
+func NewPDELimitOrder(orderID common.Hash, traderAddressStr string, tokenIDToBuyStr string, tokenIDToSellStr string, sellAmount uint64, minAcceptableAmount uint64, expiryBeaconHeight uint64, shardID byte) *PDELimitOrder {
+	return &PDELimitOrder{OrderID: orderID, TraderAddressStr: traderAddressStr, TokenIDToBuyStr: tokenIDToBuyStr, TokenIDToSellStr: tokenIDToSellStr, SellAmount: sellAmount, MinAcceptableAmount: minAcceptableAmount, ExpiryBeaconHeight: expiryBeaconHeight, ShardID: shardID}
+}
+
 func BuildPDESharesKey(
 	beaconHeight uint64,
 	token1IDStr string,
@@ -95,6 +123,45 @@ func BuildPDEPoolForPairKey(
 	return append(pdePoolForPairByBCHeightPrefix, []byte(tokenIDStrs[0]+"-"+tokenIDStrs[1])...)
 }
 
+// BuildPDEPoolForPairKeyV3: PDEPoolV3Prefix - beacon height - fee tier - token1ID - token2ID
+func BuildPDEPoolForPairKeyV3(
+	beaconHeight uint64,
+	feeTier uint64,
+	token1IDStr string,
+	token2IDStr string,
+) []byte {
+	prefixBytes := []byte(fmt.Sprintf("%d-%d-", beaconHeight, feeTier))
+	pdePoolForPairByBCHeightPrefix := append(PDEPoolV3Prefix, prefixBytes...)
+	tokenIDStrs := []string{token1IDStr, token2IDStr}
+	sort.Strings(tokenIDStrs)
+	return append(pdePoolForPairByBCHeightPrefix, []byte(tokenIDStrs[0]+"-"+tokenIDStrs[1])...)
+}
+
+// BuildPDESharesKeyV3: PDEShareV3Prefix - beacon height - fee tier - token1ID - token2ID - contributor address
+func BuildPDESharesKeyV3(
+	beaconHeight uint64,
+	feeTier uint64,
+	token1IDStr string,
+	token2IDStr string,
+	contributorAddressStr string,
+) []byte {
+	prefixBytes := []byte(fmt.Sprintf("%d-%d-", beaconHeight, feeTier))
+	pdeSharesByBCHeightPrefix := append(PDEShareV3Prefix, prefixBytes...)
+	tokenIDStrs := []string{token1IDStr, token2IDStr}
+	sort.Strings(tokenIDStrs)
+	return append(pdeSharesByBCHeightPrefix, []byte(tokenIDStrs[0]+"-"+tokenIDStrs[1]+"-"+contributorAddressStr)...)
+}
+
+// BuildPDELimitOrderKey: PDELimitOrderPrefix - beacon height - order id
+func BuildPDELimitOrderKey(
+	beaconHeight uint64,
+	orderIDStr string,
+) []byte {
+	beaconHeightBytes := []byte(fmt.Sprintf("%d-", beaconHeight))
+	pdeLimitOrderByBCHeightPrefix := append(PDELimitOrderPrefix, beaconHeightBytes...)
+	return append(pdeLimitOrderByBCHeightPrefix, []byte(orderIDStr)...)
+}
+
 func BuildPDETradingFeeKey(
 	beaconHeight uint64,
 	token1IDStr string,
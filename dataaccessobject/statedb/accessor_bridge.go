@@ -86,7 +86,10 @@ func getBridgeTokenByType(stateDB *StateDB, incTokenID common.Hash, isCentralize
 	return tokenInfoState, has, nil
 }
 
-func CanProcessTokenPair(stateDB *StateDB, externalTokenID []byte, incTokenID common.Hash) (bool, error) {
+// CanProcessTokenPair returns whether the decentralized bridge token
+// incTokenID can be the token externalTokenID of network, network is empty for
+// Ethereum.
+func CanProcessTokenPair(stateDB *StateDB, network string, externalTokenID []byte, incTokenID common.Hash) (bool, error) {
 	if len(externalTokenID) == 0 || len(incTokenID[:]) == 0 {
 		return false, nil
 	}
@@ -113,25 +116,50 @@ func CanProcessTokenPair(stateDB *StateDB, externalTokenID []byte, incTokenID co
 		return false, NewStatedbError(CanProcessTokenPairError, err)
 	}
 	if has {
-		if bytes.Compare(bridgeTokenInfoState.ExternalTokenID(), externalTokenID) == 0 {
+		if bytes.Compare(bridgeTokenInfoState.ExternalTokenID(), externalTokenID) == 0 && bridgeTokenInfoState.Network() == network {
 			return true, nil
 		}
-		log.Println("WARNING: failed at condition 2:", bridgeTokenInfoState.ExternalTokenID()[:], externalTokenID[:])
+		log.Println("WARNING: failed at condition 2:", bridgeTokenInfoState.Network(), bridgeTokenInfoState.ExternalTokenID()[:], network, externalTokenID[:])
 		return false, nil
 	}
-	bridgeTokenInfoStates := stateDB.getAllBridgeTokenInfoState(false)
-	for _, tempBridgeTokenInfoState := range bridgeTokenInfoStates {
-		if bytes.Compare(tempBridgeTokenInfoState.ExternalTokenID(), externalTokenID) != 0 {
-			continue
-		}
-		log.Println("WARNING: failed at condition 3:", tempBridgeTokenInfoState.ExternalTokenID()[:], externalTokenID[:])
+	existedIncTokenID, err := GetDecentralizedBridgeTokenID(stateDB, network, externalTokenID)
+	if err != nil {
+		return false, NewStatedbError(CanProcessTokenPairError, err)
+	}
+	if existedIncTokenID != nil {
+		log.Println("WARNING: failed at condition 3:", network, externalTokenID[:])
 		return false, nil
 	}
 	// both tokens are not existed -> can create new one
 	return true, nil
 }
 
-func UpdateBridgeTokenInfo(stateDB *StateDB, incTokenID common.Hash, externalTokenID []byte, isCentralized bool, updatingAmount uint64, updateType string) error {
+// GetDecentralizedBridgeTokenID returns the incognito token of the token
+// externalTokenID of network in the decentralized bridge, nil if it is not
+// bridged yet.
+func GetDecentralizedBridgeTokenID(stateDB *StateDB, network string, externalTokenID []byte) (*common.Hash, error) {
+	bridgeTokenInfoStates := stateDB.getAllBridgeTokenInfoState(false)
+	for _, bridgeTokenInfoState := range bridgeTokenInfoStates {
+		if bridgeTokenInfoState.Network() != network || bytes.Compare(bridgeTokenInfoState.ExternalTokenID(), externalTokenID) != 0 {
+			continue
+		}
+		incTokenID := bridgeTokenInfoState.IncTokenID()
+		return &incTokenID, nil
+	}
+	return nil, nil
+}
+
+// IsBridgeTokenOfNetwork returns whether incTokenID is a decentralized bridge
+// token of network.
+func IsBridgeTokenOfNetwork(stateDB *StateDB, incTokenID common.Hash, network string) (bool, error) {
+	bridgeTokenInfoState, has, err := getBridgeTokenByType(stateDB, incTokenID, false)
+	if err != nil {
+		return false, NewStatedbError(IsBridgeTokenExistedByTypeError, err)
+	}
+	return has && bridgeTokenInfoState.Network() == network, nil
+}
+
+func UpdateBridgeTokenInfo(stateDB *StateDB, incTokenID common.Hash, externalTokenID []byte, network string, isCentralized bool, updatingAmount uint64, updateType string) error {
 	bridgeTokenInfoState, has, err := getBridgeTokenByType(stateDB, incTokenID, isCentralized)
 	if err != nil {
 		return NewStatedbError(UpdateBridgeTokenInfoError, err)
@@ -139,6 +167,7 @@ func UpdateBridgeTokenInfo(stateDB *StateDB, incTokenID common.Hash, externalTok
 	if !has {
 		bridgeTokenInfoState.SetIncTokenID(incTokenID)
 		bridgeTokenInfoState.SetExternalTokenID(externalTokenID)
+		bridgeTokenInfoState.SetNetwork(network)
 		bridgeTokenInfoState.SetIsCentralized(isCentralized)
 		if updateType == BridgeMinorOperator {
 			bridgeTokenInfoState.SetAmount(0)
@@ -0,0 +1,79 @@
+package statedb
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+func TestStateDB_BridgeTokenOfNetwork(t *testing.T) {
+	sDB, err := NewWithPrefixTrie(emptyRoot, wrarperDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenIDs := testGenerateTokenIDs(3)
+	externalTokenID := []byte{1, 2, 3}
+	// the same external token address on Ethereum and on the chain 56
+	if err := UpdateBridgeTokenInfo(sDB, tokenIDs[0], externalTokenID, "", false, 100, BridgePlusOperator); err != nil {
+		t.Fatal(err)
+	}
+	if err := UpdateBridgeTokenInfo(sDB, tokenIDs[1], externalTokenID, "56", false, 100, BridgePlusOperator); err != nil {
+		t.Fatal(err)
+	}
+	rootHash, err := sDB.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sDB.Database().TrieDB().Commit(rootHash, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for network, wantTokenID := range map[string]common.Hash{"": tokenIDs[0], "56": tokenIDs[1]} {
+		incTokenID, err := GetDecentralizedBridgeTokenID(sDB, network, externalTokenID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if incTokenID == nil || *incTokenID != wantTokenID {
+			t.Fatalf("GetDecentralizedBridgeTokenID() of network %v = %v, want %v", network, incTokenID, wantTokenID)
+		}
+	}
+	incTokenID, err := GetDecentralizedBridgeTokenID(sDB, "137", externalTokenID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if incTokenID != nil {
+		t.Fatalf("GetDecentralizedBridgeTokenID() of network 137 = %v, want nil", incTokenID)
+	}
+
+	tests := []struct {
+		name       string
+		network    string
+		incTokenID common.Hash
+		want       bool
+	}{
+		{name: "same network", network: "56", incTokenID: tokenIDs[1], want: true},
+		{name: "token of another network", network: "", incTokenID: tokenIDs[1], want: false},
+		{name: "external token bridged to another token", network: "56", incTokenID: tokenIDs[2], want: false},
+		{name: "new network", network: "137", incTokenID: tokenIDs[2], want: true},
+		{name: "existing token to new network", network: "137", incTokenID: tokenIDs[0], want: false},
+	}
+	for _, tt := range tests {
+		got, err := CanProcessTokenPair(sDB, tt.network, externalTokenID, tt.incTokenID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("CanProcessTokenPair() %v = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	isOfNetwork, err := IsBridgeTokenOfNetwork(sDB, tokenIDs[1], "56")
+	if err != nil || !isOfNetwork {
+		t.Errorf("IsBridgeTokenOfNetwork() = %v, %v, want true", isOfNetwork, err)
+	}
+	isOfNetwork, err = IsBridgeTokenOfNetwork(sDB, tokenIDs[1], "")
+	if err != nil || isOfNetwork {
+		t.Errorf("IsBridgeTokenOfNetwork() = %v, %v, want false", isOfNetwork, err)
+	}
+}
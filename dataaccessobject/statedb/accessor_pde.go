@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
@@ -15,6 +16,7 @@ func StoreWaitingPDEContributions(stateDB *StateDB, beaconHeight uint64, waiting
 		pairID := strings.Join(strs[2:], "-")
 		key := GenerateWaitingPDEContributionObjectKey(pairID)
 		value := NewWaitingPDEContributionStateWithValue(pairID, contribution.ContributorAddressStr, contribution.TokenIDStr, contribution.Amount, contribution.TxReqID)
+		value.SetFeeTier(contribution.FeeTier)
 		err := stateDB.SetStateObject(WaitingPDEContributionObjectType, key, value)
 		if err != nil {
 			return NewStatedbError(StoreWaitingPDEContributionError, err)
@@ -29,6 +31,7 @@ func GetWaitingPDEContributions(stateDB *StateDB, beaconHeight uint64) (map[stri
 	for _, wcState := range waitingPDEContributionStates {
 		key := string(GetWaitingPDEContributionKey(beaconHeight, wcState.PairID()))
 		value := rawdbv2.NewPDEContribution(wcState.ContributorAddress(), wcState.TokenID(), wcState.Amount(), wcState.TxReqID())
+		value.FeeTier = wcState.FeeTier()
 		waitingPDEContributions[key] = value
 	}
 	return waitingPDEContributions, nil
@@ -185,3 +188,108 @@ func GetPDETradingFees(stateDB *StateDB, beaconHeight uint64) (map[string]uint64
 	}
 	return pdeTradingFees, nil
 }
+
+func StorePDEPoolPairsV3(stateDB *StateDB, beaconHeight uint64, pdePoolPairs map[string]*rawdbv2.PDEPoolForPair) error {
+	for _, pdePoolPair := range pdePoolPairs {
+		key := GeneratePDEPoolPairV3ObjectKey(pdePoolPair.FeeTier, pdePoolPair.Token1IDStr, pdePoolPair.Token2IDStr)
+		value := NewPDEPoolPairV3StateWithValue(pdePoolPair.FeeTier, pdePoolPair.Token1IDStr, pdePoolPair.Token1PoolValue, pdePoolPair.Token2IDStr, pdePoolPair.Token2PoolValue)
+		err := stateDB.SetStateObject(PDEPoolPairV3ObjectType, key, value)
+		if err != nil {
+			return NewStatedbError(StorePDEPoolPairV3Error, err)
+		}
+	}
+	return nil
+}
+
+func GetPDEPoolPairsV3(stateDB *StateDB, beaconHeight uint64) (map[string]*rawdbv2.PDEPoolForPair, error) {
+	pdePoolPairs := make(map[string]*rawdbv2.PDEPoolForPair)
+	pdePoolPairStates := stateDB.getAllPDEPoolPairV3State()
+	for _, ppState := range pdePoolPairStates {
+		key := string(GetPDEPoolForPairV3Key(beaconHeight, ppState.FeeTier(), ppState.Token1ID(), ppState.Token2ID()))
+		value := rawdbv2.NewPDEPoolForPair(ppState.Token1ID(), ppState.Token1PoolValue(), ppState.Token2ID(), ppState.Token2PoolValue())
+		value.FeeTier = ppState.FeeTier()
+		pdePoolPairs[key] = value
+	}
+	return pdePoolPairs, nil
+}
+
+// GetPDEPoolForPairV3 returns the pool of a pair at a fee tier
+func GetPDEPoolForPairV3(stateDB *StateDB, beaconHeight uint64, feeTier uint64, tokenIDToBuy string, tokenIDToSell string) ([]byte, error) {
+	tokenIDs := []string{tokenIDToBuy, tokenIDToSell}
+	sort.Strings(tokenIDs)
+	key := GeneratePDEPoolPairV3ObjectKey(feeTier, tokenIDs[0], tokenIDs[1])
+	ppState, has, err := stateDB.getPDEPoolPairV3State(key)
+	if err != nil {
+		return []byte{}, NewStatedbError(GetPDEPoolForPairV3Error, err)
+	}
+	if !has {
+		return []byte{}, NewStatedbError(GetPDEPoolForPairV3Error, fmt.Errorf("key with beacon height %+v, fee tier %+v, token1ID %+v, token2ID %+v not found", beaconHeight, feeTier, tokenIDToBuy, tokenIDToSell))
+	}
+	value := rawdbv2.NewPDEPoolForPair(ppState.Token1ID(), ppState.Token1PoolValue(), ppState.Token2ID(), ppState.Token2PoolValue())
+	value.FeeTier = ppState.FeeTier()
+	res, err := json.Marshal(value)
+	if err != nil {
+		return []byte{}, NewStatedbError(GetPDEPoolForPairV3Error, err)
+	}
+	return res, nil
+}
+
+func StorePDESharesV3(stateDB *StateDB, beaconHeight uint64, pdeShares map[string]uint64) error {
+	for tempKey, shareAmount := range pdeShares {
+		strs := strings.Split(tempKey, "-")
+		feeTier, err := strconv.ParseUint(strs[2], 10, 64)
+		if err != nil {
+			return NewStatedbError(StorePDEShareV3Error, err)
+		}
+		token1ID := strs[3]
+		token2ID := strs[4]
+		contributorAddress := strs[5]
+		key := GeneratePDEShareV3ObjectKey(feeTier, token1ID, token2ID, contributorAddress)
+		value := NewPDEShareV3StateWithValue(feeTier, token1ID, token2ID, contributorAddress, shareAmount)
+		err = stateDB.SetStateObject(PDEShareV3ObjectType, key, value)
+		if err != nil {
+			return NewStatedbError(StorePDEShareV3Error, err)
+		}
+	}
+	return nil
+}
+
+func GetPDESharesV3(stateDB *StateDB, beaconHeight uint64) (map[string]uint64, error) {
+	pdeShares := make(map[string]uint64)
+	pdeShareStates := stateDB.getAllPDEShareV3State()
+	for _, sState := range pdeShareStates {
+		key := string(GetPDEShareV3Key(beaconHeight, sState.FeeTier(), sState.Token1ID(), sState.Token2ID(), sState.ContributorAddress()))
+		pdeShares[key] = sState.Amount()
+	}
+	return pdeShares, nil
+}
+
+func StorePDELimitOrders(stateDB *StateDB, beaconHeight uint64, pdeLimitOrders map[string]*rawdbv2.PDELimitOrder) error {
+	for _, order := range pdeLimitOrders {
+		key := GeneratePDELimitOrderObjectKey(order.OrderID.String())
+		value := NewPDELimitOrderStateWithValue(order.OrderID, order.TraderAddressStr, order.TokenIDToBuyStr, order.TokenIDToSellStr, order.SellAmount, order.MinAcceptableAmount, order.ExpiryBeaconHeight, order.ShardID)
+		err := stateDB.SetStateObject(PDELimitOrderObjectType, key, value)
+		if err != nil {
+			return NewStatedbError(StorePDELimitOrderError, err)
+		}
+	}
+	return nil
+}
+
+func GetPDELimitOrders(stateDB *StateDB, beaconHeight uint64) (map[string]*rawdbv2.PDELimitOrder, error) {
+	pdeLimitOrders := make(map[string]*rawdbv2.PDELimitOrder)
+	pdeLimitOrderStates := stateDB.getAllPDELimitOrderState()
+	for _, loState := range pdeLimitOrderStates {
+		key := string(GetPDELimitOrderKey(beaconHeight, loState.OrderID().String()))
+		value := rawdbv2.NewPDELimitOrder(loState.OrderID(), loState.TraderAddress(), loState.TokenIDToBuy(), loState.TokenIDToSell(), loState.SellAmount(), loState.MinAcceptableAmount(), loState.ExpiryBeaconHeight(), loState.ShardID())
+		pdeLimitOrders[key] = value
+	}
+	return pdeLimitOrders, nil
+}
+
+func DeletePDELimitOrders(stateDB *StateDB, deletedPDELimitOrders map[string]*rawdbv2.PDELimitOrder) {
+	for _, order := range deletedPDELimitOrders {
+		key := GeneratePDELimitOrderObjectKey(order.OrderID.String())
+		stateDB.MarkDeleteStateObject(PDELimitOrderObjectType, key)
+	}
+}
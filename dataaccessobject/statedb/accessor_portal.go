@@ -591,6 +591,25 @@ func StoreLockedCollateralState(
 	return nil
 }
 
+// GetPortalFeeders returns the feeders of the portal exchange rates registered
+// by the governor, false if the feeders have never been updated.
+func GetPortalFeeders(stateDB *StateDB) ([]string, bool, error) {
+	portalFeedersState, has, err := stateDB.getPortalFeedersState()
+	if err != nil {
+		return nil, false, NewStatedbError(GetPortalFeedersStateError, err)
+	}
+	return portalFeedersState.Feeders(), has, nil
+}
+
+func StorePortalFeeders(stateDB *StateDB, feeders []string) error {
+	key := GeneratePortalFeedersStateObjectKey()
+	err := stateDB.SetStateObject(PortalFeedersStateObjectType, key, NewPortalFeedersStateWithValue(feeders))
+	if err != nil {
+		return NewStatedbError(StorePortalFeedersStateError, err)
+	}
+	return nil
+}
+
 //======================  Feature reward  ======================
 func StoreRewardFeatureState(
 	stateDB *StateDB,
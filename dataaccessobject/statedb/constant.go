@@ -49,6 +49,16 @@ const (
 	PDETradingFeeObjectType
 
 	StakerObjectType
+
+	// PDEX v3
+	PDEPoolPairV3ObjectType
+	PDEShareV3ObjectType
+
+	// PDEX limit orders
+	PDELimitOrderObjectType
+
+	// portal exchange rates feeders
+	PortalFeedersStateObjectType
 )
 
 // Prefix length
@@ -61,7 +61,7 @@ type Trie interface {
 	// If the trie does not contain a value for key, the returned proof contains all
 	// nodes of the longest existing prefix of the key (at least the root), ending
 	// with the node that proves the absence of the key.
-	Prove(key []byte, fromLevel uint, proofDb incdb.Database) error
+	Prove(key []byte, fromLevel uint, proofDb incdb.KeyValueWriter) error
 }
 
 type accessorWarper struct {
@@ -43,6 +43,10 @@ const (
 	ErrInvalidPortalLockedCollateralStateType = "invalid portal locked collateral state type"
 	ErrInvalidRewardFeatureStateType          = "invalid feature reward state type"
 	ErrInvalidPDETradingFeeStateType          = "invalid pde trading fee state type"
+	ErrInvalidPDEPoolPairV3StateType          = "invalid pde v3 pool pair state type"
+	ErrInvalidPDEShareV3StateType             = "invalid pde v3 share state type"
+	ErrInvalidPDELimitOrderStateType          = "invalid pde limit order state type"
+	ErrInvalidPortalFeedersStateType          = "invalid portal feeders state type"
 	ErrInvalidBlockHashType                   = "invalid block hash type"
 )
 const (
@@ -210,6 +214,18 @@ const (
 	StorePDETradingFeeError
 	
 	InvalidStakerInfoTypeError
+
+	// PDEX v3
+	StorePDEPoolPairV3Error
+	StorePDEShareV3Error
+	GetPDEPoolForPairV3Error
+
+	// PDEX limit orders
+	StorePDELimitOrderError
+
+	// portal exchange rates feeders
+	StorePortalFeedersStateError
+	GetPortalFeedersStateError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -273,6 +289,10 @@ var ErrCodeMessage = map[int]struct {
 	GetPDEPoolForPairError:           {-4003, "Get PDEX Pool Pair Error"},
 	TrackPDEStatusError:              {-4004, "Track PDEX Status Error"},
 	GetPDEStatusError:                {-4005, "Get PDEX Status Error"},
+	StorePDEPoolPairV3Error:          {-4006, "Store PDEX v3 Pool Pair Error"},
+	StorePDEShareV3Error:             {-4007, "Store PDEX v3 Share Error"},
+	GetPDEPoolForPairV3Error:         {-4008, "Get PDEX v3 Pool Pair Error"},
+	StorePDELimitOrderError:          {-4009, "Store PDEX Limit Order Error"},
 	// -5xxx: bridge error
 	BridgeInsertETHTxHashIssuedError: {-5000, "Bridge Insert ETH Tx Hash Issued Error"},
 	IsETHTxHashIssuedError:           {-5001, "Is ETH Tx Hash Issued Error"},
@@ -331,6 +351,8 @@ var ErrCodeMessage = map[int]struct {
 	GetPortalReqMatchingRedeemByTxIDStatusError:            {-14041, "Get req matching redeem request error"},
 	GetPortalTopupWaitingPortingStatusError:                {-14042, "Get custodian top up for waiting porting error"},
 	GetPortalRedeemRequestFromLiquidationByTxIDStatusError: {-14043, "Get portal redeem req from liquidation pool status error"},
+	StorePortalFeedersStateError:                           {-14044, "Store portal feeders state error"},
+	GetPortalFeedersStateError:                             {-14045, "Get portal feeders state error"},
 
 	StoreRewardFeatureError:              {-15000, "Store reward feature state error"},
 	GetRewardFeatureError:                {-15001, "Get reward feature state error"},
@@ -0,0 +1,107 @@
+package statedb
+
+import (
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+// State databases of a chain, a state object is proved against the root of
+// the state database which stores its object type.
+const (
+	ConsensusStateDBName   = "consensus"
+	TransactionStateDBName = "transaction"
+	FeatureStateDBName     = "feature"
+	RewardStateDBName      = "reward"
+	SlashStateDBName       = "slash"
+)
+
+var stateDBNameByObjectType = map[int]string{
+	CommitteeObjectType: ConsensusStateDBName,
+	StakerObjectType:    ConsensusStateDBName,
+
+	SerialNumberObjectType:     TransactionStateDBName,
+	CommitmentObjectType:       TransactionStateDBName,
+	CommitmentIndexObjectType:  TransactionStateDBName,
+	CommitmentLengthObjectType: TransactionStateDBName,
+	SNDerivatorObjectType:      TransactionStateDBName,
+	OutputCoinObjectType:       TransactionStateDBName,
+	TokenObjectType:            TransactionStateDBName,
+	TokenTransactionObjectType: TransactionStateDBName,
+
+	WaitingPDEContributionObjectType:        FeatureStateDBName,
+	PDEPoolPairObjectType:                   FeatureStateDBName,
+	PDEShareObjectType:                      FeatureStateDBName,
+	PDEStatusObjectType:                     FeatureStateDBName,
+	PDETradingFeeObjectType:                 FeatureStateDBName,
+	PDEPoolPairV3ObjectType:                 FeatureStateDBName,
+	PDEShareV3ObjectType:                    FeatureStateDBName,
+	PDELimitOrderObjectType:                 FeatureStateDBName,
+	BridgeEthTxObjectType:                   FeatureStateDBName,
+	BridgeTokenInfoObjectType:               FeatureStateDBName,
+	BridgeStatusObjectType:                  FeatureStateDBName,
+	BurningConfirmObjectType:                FeatureStateDBName,
+	PortalFinalExchangeRatesStateObjectType: FeatureStateDBName,
+	PortalWaitingPortingRequestObjectType:   FeatureStateDBName,
+	PortalLiquidationPoolObjectType:         FeatureStateDBName,
+	PortalStatusObjectType:                  FeatureStateDBName,
+	CustodianStateObjectType:                FeatureStateDBName,
+	WaitingRedeemRequestObjectType:          FeatureStateDBName,
+	PortalRewardInfoObjectType:              FeatureStateDBName,
+	LockedCollateralStateObjectType:         FeatureStateDBName,
+	RewardFeatureStateObjectType:            FeatureStateDBName,
+	PortalFeedersStateObjectType:            FeatureStateDBName,
+
+	CommitteeRewardObjectType: RewardStateDBName,
+	RewardRequestObjectType:   RewardStateDBName,
+
+	BlackListProducerObjectType: SlashStateDBName,
+}
+
+// GetStateDBNameByObjectType returns the name of the state database which
+// stores objects of objectType.
+func GetStateDBNameByObjectType(objectType int) (string, error) {
+	name, ok := stateDBNameByObjectType[objectType]
+	if !ok {
+		return "", fmt.Errorf("state object type %+v is not stored in a state database", objectType)
+	}
+	return name, nil
+}
+
+// GetProof returns the merkle proof of the state object at key against the
+// root of the committed trie. The value of the object is in the last node of
+// the proof. If there is no object at key, the proof proves its absence.
+func (stateDB *StateDB) GetProof(key common.Hash) (trie.ProofList, error) {
+	proof := trie.ProofList{}
+	if err := stateDB.trie.Prove(key[:], 0, &proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// VerifyProof checks the merkle proof of key against root and returns the
+// value of the state object at key, the value is nil if the proof proves
+// there is no object at key.
+func VerifyProof(root common.Hash, key common.Hash, proof trie.ProofList) ([]byte, error) {
+	value, _, err := trie.VerifyProof(root, key[:], proof)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// VerifyStateObjectProof checks the merkle proof of key against root and
+// decodes the value as a state object of objectType. The returned object is
+// nil if the proof proves there is no object at key. The object is not
+// attached to a state database, only its getters may be used.
+func VerifyStateObjectProof(root common.Hash, objectType int, key common.Hash, proof trie.ProofList) (StateObject, error) {
+	if _, err := GetStateDBNameByObjectType(objectType); err != nil {
+		return nil, err
+	}
+	value, err := VerifyProof(root, key, proof)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return newStateObjectWithValue(nil, objectType, key, value)
+}
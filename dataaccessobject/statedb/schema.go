@@ -32,6 +32,9 @@ var (
 	pdePoolPrefix                      = []byte("pdepool-")
 	pdeSharePrefix                     = []byte("pdeshare-")
 	pdeTradingFeePrefix                = []byte("pdetradingfee-")
+	pdePoolV3Prefix                    = []byte("pdepoolv3-")
+	pdeShareV3Prefix                   = []byte("pdesharev3-")
+	pdeLimitOrderPrefix                = []byte("pdelimitorder-")
 	pdeTradeFeePrefix                  = []byte("pdetradefee-")
 	pdeContributionStatusPrefix        = []byte("pdecontributionstatus-")
 	pdeTradeStatusPrefix               = []byte("pdetradestatus-")
@@ -55,6 +58,9 @@ var (
 	portalLiquidationCustodianDepositStatusPrefix = []byte("portalliquidationcustodiandepositstatus-")
 	portalTopUpWaitingPortingStatusPrefix         = []byte("portaltopupwaitingportingstatus-")
 	portalLiquidationRedeemRequestStatusPrefix    = []byte("portalliquidationredeemrequeststatus-")
+	portalTransferObligationStatusPrefix          = []byte("portaltransferobligationstatus-")
+	portalTransferObligationIDPrefix              = []byte("portaltransferobligationid-")
+	portalRebalanceCollateralStatusPrefix         = []byte("portalrebalancecollateralstatus-")
 	portalWaitingPortingRequestPrefix             = []byte("portalwaitingportingrequest-")
 	portalCustodianStatePrefix                    = []byte("portalcustodian-")
 	portalWaitingRedeemRequestsPrefix             = []byte("portalwaitingredeemrequest-")
@@ -77,6 +83,9 @@ var (
 	portalRewardInfoStatePrefix       = []byte("portalreward-")
 	portalLockedCollateralStatePrefix = []byte("portallockedcollateral-")
 
+	// feeders of the portal exchange rates
+	portalFeedersStatePrefix = []byte("portalfeeders-")
+
 	// reward for features in network (such as portal, pdex, etc)
 	rewardFeatureStatePrefix = []byte("rewardfeaturestate-")
 	// feature names
@@ -206,6 +215,21 @@ func GetPDETradingFeePrefix() []byte {
 	return h[:][:prefixHashKeyLength]
 }
 
+func GetPDEPoolPairV3Prefix() []byte {
+	h := common.HashH(pdePoolV3Prefix)
+	return h[:][:prefixHashKeyLength]
+}
+
+func GetPDEShareV3Prefix() []byte {
+	h := common.HashH(pdeShareV3Prefix)
+	return h[:][:prefixHashKeyLength]
+}
+
+func GetPDELimitOrderPrefix() []byte {
+	h := common.HashH(pdeLimitOrderPrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
 func GetPDEStatusPrefix() []byte {
 	h := common.HashH(pdeStatusPrefix)
 	return h[:][:prefixHashKeyLength]
@@ -287,6 +311,28 @@ func GetPDETradingFeeKey(beaconHeight uint64, token1ID string, token2ID string,
 	return append(prefix, []byte(tokenIDs[0]+"-"+tokenIDs[1]+"-"+contributorAddress)...)
 }
 
+// GetPDEPoolForPairV3Key: PDEPoolV3Prefix - beacon height - fee tier - token1ID - token2ID
+func GetPDEPoolForPairV3Key(beaconHeight uint64, feeTier uint64, token1ID string, token2ID string) []byte {
+	prefix := append(pdePoolV3Prefix, []byte(fmt.Sprintf("%d-%d-", beaconHeight, feeTier))...)
+	tokenIDs := []string{token1ID, token2ID}
+	sort.Strings(tokenIDs)
+	return append(prefix, []byte(tokenIDs[0]+"-"+tokenIDs[1])...)
+}
+
+// GetPDEShareV3Key: PDEShareV3Prefix + beacon height + fee tier + token1ID + token2ID + contributor address
+func GetPDEShareV3Key(beaconHeight uint64, feeTier uint64, token1ID string, token2ID string, contributorAddress string) []byte {
+	prefix := append(pdeShareV3Prefix, []byte(fmt.Sprintf("%d-%d-", beaconHeight, feeTier))...)
+	tokenIDs := []string{token1ID, token2ID}
+	sort.Strings(tokenIDs)
+	return append(prefix, []byte(tokenIDs[0]+"-"+tokenIDs[1]+"-"+contributorAddress)...)
+}
+
+// GetPDELimitOrderKey: PDELimitOrderPrefix - beacon height - order id
+func GetPDELimitOrderKey(beaconHeight uint64, orderID string) []byte {
+	prefix := append(pdeLimitOrderPrefix, []byte(fmt.Sprintf("%d-", beaconHeight))...)
+	return append(prefix, []byte(orderID)...)
+}
+
 func GetPDEStatusKey(prefix []byte, suffix []byte) []byte {
 	return append(prefix, suffix...)
 }
@@ -329,6 +375,18 @@ func PortalLiquidationRedeemRequestStatusPrefix() []byte {
 	return portalLiquidationRedeemRequestStatusPrefix
 }
 
+func PortalTransferObligationStatusPrefix() []byte {
+	return portalTransferObligationStatusPrefix
+}
+
+func PortalTransferObligationIDPrefix() []byte {
+	return portalTransferObligationIDPrefix
+}
+
+func PortalRebalanceCollateralStatusPrefix() []byte {
+	return portalRebalanceCollateralStatusPrefix
+}
+
 func GetPortalWaitingPortingRequestPrefix() []byte {
 	h := common.HashH(portalWaitingPortingRequestPrefix)
 	return h[:][:prefixHashKeyLength]
@@ -369,6 +427,11 @@ func GetLockedCollateralStatePrefix() []byte {
 	return h[:][:prefixHashKeyLength]
 }
 
+func GetPortalFeedersStatePrefix() []byte {
+	h := common.HashH(portalFeedersStatePrefix)
+	return h[:][:prefixHashKeyLength]
+}
+
 func GetRewardFeatureStatePrefix(epoch uint64) []byte {
 	h := common.HashH(append(rewardFeatureStatePrefix, []byte(fmt.Sprintf("%d-", epoch))...))
 	return h[:][:prefixHashKeyLength]
@@ -427,6 +427,13 @@ func (stateDB *StateDB) getByPrefixTestObjectList(prefix []byte) ([]common.Hash,
 	return keys, values
 }
 
+// GetLeavesByPrefix returns at most limit keys and values of the committed
+// trie which start with prefix, in key order. Only keys after start are
+// returned if start is not empty, so a range continues from its last key.
+func (stateDB *StateDB) GetLeavesByPrefix(prefix []byte, start []byte, limit int) ([][]byte, [][]byte, error) {
+	return trie.LeavesAfter(stateDB.trie.NodeIterator(prefix), start, limit)
+}
+
 // ================================= Committee OBJECT =======================================
 func (stateDB *StateDB) getCommitteeState(key common.Hash) (*CommitteeState, bool, error) {
 	committeeStateObject, err := stateDB.getStateObject(CommitteeObjectType, key)
@@ -1305,6 +1312,71 @@ func (stateDB *StateDB) getAllPDETradingFeeState() []*PDETradingFeeState {
 	return pdeTradingFeeStates
 }
 
+func (stateDB *StateDB) getAllPDEPoolPairV3State() []*PDEPoolPairV3State {
+	pdePoolPairV3States := []*PDEPoolPairV3State{}
+	temp := stateDB.trie.NodeIterator(GetPDEPoolPairV3Prefix())
+	it := trie.NewIterator(temp)
+	for it.Next() {
+		value := it.Value
+		newValue := make([]byte, len(value))
+		copy(newValue, value)
+		pp := NewPDEPoolPairV3State()
+		err := json.Unmarshal(newValue, pp)
+		if err != nil {
+			panic("wrong expect type")
+		}
+		pdePoolPairV3States = append(pdePoolPairV3States, pp)
+	}
+	return pdePoolPairV3States
+}
+
+func (stateDB *StateDB) getPDEPoolPairV3State(key common.Hash) (*PDEPoolPairV3State, bool, error) {
+	ppState, err := stateDB.getStateObject(PDEPoolPairV3ObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if ppState != nil {
+		return ppState.GetValue().(*PDEPoolPairV3State), true, nil
+	}
+	return NewPDEPoolPairV3State(), false, nil
+}
+
+func (stateDB *StateDB) getAllPDEShareV3State() []*PDEShareV3State {
+	pdeShareV3States := []*PDEShareV3State{}
+	temp := stateDB.trie.NodeIterator(GetPDEShareV3Prefix())
+	it := trie.NewIterator(temp)
+	for it.Next() {
+		value := it.Value
+		newValue := make([]byte, len(value))
+		copy(newValue, value)
+		s := NewPDEShareV3State()
+		err := json.Unmarshal(newValue, s)
+		if err != nil {
+			panic("wrong expect type")
+		}
+		pdeShareV3States = append(pdeShareV3States, s)
+	}
+	return pdeShareV3States
+}
+
+func (stateDB *StateDB) getAllPDELimitOrderState() []*PDELimitOrderState {
+	pdeLimitOrderStates := []*PDELimitOrderState{}
+	temp := stateDB.trie.NodeIterator(GetPDELimitOrderPrefix())
+	it := trie.NewIterator(temp)
+	for it.Next() {
+		value := it.Value
+		newValue := make([]byte, len(value))
+		copy(newValue, value)
+		s := NewPDELimitOrderState()
+		err := json.Unmarshal(newValue, s)
+		if err != nil {
+			panic("wrong expect type")
+		}
+		pdeLimitOrderStates = append(pdeLimitOrderStates, s)
+	}
+	return pdeLimitOrderStates
+}
+
 func (stateDB *StateDB) getAllPDEStatus() []*PDEStatusState {
 	pdeStatusStates := []*PDEStatusState{}
 	temp := stateDB.trie.NodeIterator(GetPDEStatusPrefix())
@@ -1589,6 +1661,18 @@ func (stateDB *StateDB) getLockedCollateralState() (*LockedCollateralState, bool
 	return NewLockedCollateralState(), false, nil
 }
 
+func (stateDB *StateDB) getPortalFeedersState() (*PortalFeedersState, bool, error) {
+	key := GeneratePortalFeedersStateObjectKey()
+	portalFeedersState, err := stateDB.getStateObject(PortalFeedersStateObjectType, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if portalFeedersState != nil {
+		return portalFeedersState.GetValue().(*PortalFeedersState), true, nil
+	}
+	return NewPortalFeedersState(), false, nil
+}
+
 // ================================= Feature reward OBJECT =======================================
 func (stateDB *StateDB) getFeatureRewardByFeatureName(featureName string, epoch uint64) (*RewardFeatureState, bool, error) {
 	key := GenerateRewardFeatureStateObjectKey(featureName, epoch)
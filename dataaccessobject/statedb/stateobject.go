@@ -53,6 +53,12 @@ func newStateObjectWithValue(db *StateDB, objectType int, hash common.Hash, valu
 		return newPDEShareObjectWithValue(db, hash, value)
 	case PDETradingFeeObjectType:
 		return newPDETradingFeeObjectWithValue(db, hash, value)
+	case PDEPoolPairV3ObjectType:
+		return newPDEPoolPairV3ObjectWithValue(db, hash, value)
+	case PDEShareV3ObjectType:
+		return newPDEShareV3ObjectWithValue(db, hash, value)
+	case PDELimitOrderObjectType:
+		return newPDELimitOrderObjectWithValue(db, hash, value)
 	case PDEStatusObjectType:
 		return newPDEStatusObjectWithValue(db, hash, value)
 	case BridgeEthTxObjectType:
@@ -81,6 +87,8 @@ func newStateObjectWithValue(db *StateDB, objectType int, hash common.Hash, valu
 		return newCustodianStateObjectWithValue(db, hash, value)
 	case LockedCollateralStateObjectType:
 		return newLockedCollateralStateObjectWithValue(db, hash, value)
+	case PortalFeedersStateObjectType:
+		return newPortalFeedersStateObjectWithValue(db, hash, value)
 	case RewardFeatureStateObjectType:
 		return newRewardFeatureStateObjectWithValue(db, hash, value)
 	case StakerObjectType:
@@ -120,6 +128,12 @@ func newStateObject(db *StateDB, objectType int, hash common.Hash) StateObject {
 		return newPDEShareObject(db, hash)
 	case PDETradingFeeObjectType:
 		return newPDETradingFeeObject(db, hash)
+	case PDEPoolPairV3ObjectType:
+		return newPDEPoolPairV3Object(db, hash)
+	case PDEShareV3ObjectType:
+		return newPDEShareV3Object(db, hash)
+	case PDELimitOrderObjectType:
+		return newPDELimitOrderObject(db, hash)
 	case PDEStatusObjectType:
 		return newPDEStatusObject(db, hash)
 	case BridgeEthTxObjectType:
@@ -146,6 +160,8 @@ func newStateObject(db *StateDB, objectType int, hash common.Hash) StateObject {
 		return newCustodianStateObject(db, hash)
 	case LockedCollateralStateObjectType:
 		return newLockedCollateralStateObject(db, hash)
+	case PortalFeedersStateObjectType:
+		return newPortalFeedersStateObject(db, hash)
 	case RewardFeatureStateObjectType:
 		return newRewardFeatureStateObject(db, hash)
 	case StakerObjectType:
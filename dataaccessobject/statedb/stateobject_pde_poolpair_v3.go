@@ -0,0 +1,237 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// PDEPoolPairV3State is a pool of PDE v3, pools of the same pair at different
+// fee tiers are different pools
+type PDEPoolPairV3State struct {
+	feeTier         uint64
+	token1ID        string
+	token1PoolValue uint64
+	token2ID        string
+	token2PoolValue uint64
+}
+
+func (pp PDEPoolPairV3State) FeeTier() uint64 {
+	return pp.feeTier
+}
+
+func (pp *PDEPoolPairV3State) SetFeeTier(feeTier uint64) {
+	pp.feeTier = feeTier
+}
+
+func (pp PDEPoolPairV3State) Token1ID() string {
+	return pp.token1ID
+}
+
+func (pp *PDEPoolPairV3State) SetToken1ID(token1ID string) {
+	pp.token1ID = token1ID
+}
+
+func (pp PDEPoolPairV3State) Token1PoolValue() uint64 {
+	return pp.token1PoolValue
+}
+
+func (pp *PDEPoolPairV3State) SetToken1PoolValue(token1PoolValue uint64) {
+	pp.token1PoolValue = token1PoolValue
+}
+
+func (pp PDEPoolPairV3State) Token2ID() string {
+	return pp.token2ID
+}
+
+func (pp *PDEPoolPairV3State) SetToken2ID(token2ID string) {
+	pp.token2ID = token2ID
+}
+
+func (pp PDEPoolPairV3State) Token2PoolValue() uint64 {
+	return pp.token2PoolValue
+}
+
+func (pp *PDEPoolPairV3State) SetToken2PoolValue(token2PoolValue uint64) {
+	pp.token2PoolValue = token2PoolValue
+}
+
+func (pp PDEPoolPairV3State) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		FeeTier         uint64
+		Token1ID        string
+		Token1PoolValue uint64
+		Token2ID        string
+		Token2PoolValue uint64
+	}{
+		FeeTier:         pp.feeTier,
+		Token1ID:        pp.token1ID,
+		Token1PoolValue: pp.token1PoolValue,
+		Token2ID:        pp.token2ID,
+		Token2PoolValue: pp.token2PoolValue,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (pp *PDEPoolPairV3State) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		FeeTier         uint64
+		Token1ID        string
+		Token1PoolValue uint64
+		Token2ID        string
+		Token2PoolValue uint64
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	pp.feeTier = temp.FeeTier
+	pp.token1ID = temp.Token1ID
+	pp.token1PoolValue = temp.Token1PoolValue
+	pp.token2ID = temp.Token2ID
+	pp.token2PoolValue = temp.Token2PoolValue
+	return nil
+}
+
+func NewPDEPoolPairV3State() *PDEPoolPairV3State {
+	return &PDEPoolPairV3State{}
+}
+
+func NewPDEPoolPairV3StateWithValue(feeTier uint64, token1ID string, token1PoolValue uint64, token2ID string, token2PoolValue uint64) *PDEPoolPairV3State {
+	return &PDEPoolPairV3State{feeTier: feeTier, token1ID: token1ID, token1PoolValue: token1PoolValue, token2ID: token2ID, token2PoolValue: token2PoolValue}
+}
+
+type PDEPoolPairV3Object struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version            int
+	pdePoolPairV3Hash  common.Hash
+	pdePoolPairV3State *PDEPoolPairV3State
+	objectType         int
+	deleted            bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newPDEPoolPairV3Object(db *StateDB, hash common.Hash) *PDEPoolPairV3Object {
+	return &PDEPoolPairV3Object{
+		version:            defaultVersion,
+		db:                 db,
+		pdePoolPairV3Hash:  hash,
+		pdePoolPairV3State: NewPDEPoolPairV3State(),
+		objectType:         PDEPoolPairV3ObjectType,
+		deleted:            false,
+	}
+}
+
+func newPDEPoolPairV3ObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*PDEPoolPairV3Object, error) {
+	var newPDEPoolPairV3State = NewPDEPoolPairV3State()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newPDEPoolPairV3State)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newPDEPoolPairV3State, ok = data.(*PDEPoolPairV3State)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidPDEPoolPairV3StateType, reflect.TypeOf(data))
+		}
+	}
+	return &PDEPoolPairV3Object{
+		version:            defaultVersion,
+		pdePoolPairV3Hash:  key,
+		pdePoolPairV3State: newPDEPoolPairV3State,
+		db:                 db,
+		objectType:         PDEPoolPairV3ObjectType,
+		deleted:            false,
+	}, nil
+}
+
+func GeneratePDEPoolPairV3ObjectKey(feeTier uint64, token1ID, token2ID string) common.Hash {
+	prefixHash := GetPDEPoolPairV3Prefix()
+	valueHash := common.HashH([]byte(fmt.Sprintf("%d", feeTier) + token1ID + token2ID))
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (t PDEPoolPairV3Object) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *PDEPoolPairV3Object) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t PDEPoolPairV3Object) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *PDEPoolPairV3Object) SetValue(data interface{}) error {
+	newPDEPoolPairV3State, ok := data.(*PDEPoolPairV3State)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidPDEPoolPairV3StateType, reflect.TypeOf(data))
+	}
+	t.pdePoolPairV3State = newPDEPoolPairV3State
+	return nil
+}
+
+func (t PDEPoolPairV3Object) GetValue() interface{} {
+	return t.pdePoolPairV3State
+}
+
+func (t PDEPoolPairV3Object) GetValueBytes() []byte {
+	pdePoolPairV3State, ok := t.GetValue().(*PDEPoolPairV3State)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(pdePoolPairV3State)
+	if err != nil {
+		panic("failed to marshal pde v3 pool pair state")
+	}
+	return value
+}
+
+func (t PDEPoolPairV3Object) GetHash() common.Hash {
+	return t.pdePoolPairV3Hash
+}
+
+func (t PDEPoolPairV3Object) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *PDEPoolPairV3Object) MarkDelete() {
+	t.deleted = true
+}
+
+// reset all pool value into default value
+func (t *PDEPoolPairV3Object) Reset() bool {
+	t.pdePoolPairV3State = NewPDEPoolPairV3State()
+	return true
+}
+
+func (t PDEPoolPairV3Object) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t PDEPoolPairV3Object) IsEmpty() bool {
+	temp := NewPDEPoolPairV3State()
+	return reflect.DeepEqual(temp, t.pdePoolPairV3State) || t.pdePoolPairV3State == nil
+}
@@ -0,0 +1,236 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// PDEShareV3State is the share of a contributor in a PDE v3 pool
+type PDEShareV3State struct {
+	feeTier            uint64
+	token1ID           string
+	token2ID           string
+	contributorAddress string
+	amount             uint64
+}
+
+func (s PDEShareV3State) FeeTier() uint64 {
+	return s.feeTier
+}
+
+func (s *PDEShareV3State) SetFeeTier(feeTier uint64) {
+	s.feeTier = feeTier
+}
+
+func (s PDEShareV3State) Token1ID() string {
+	return s.token1ID
+}
+
+func (s *PDEShareV3State) SetToken1ID(token1ID string) {
+	s.token1ID = token1ID
+}
+
+func (s PDEShareV3State) Token2ID() string {
+	return s.token2ID
+}
+
+func (s *PDEShareV3State) SetToken2ID(token2ID string) {
+	s.token2ID = token2ID
+}
+
+func (s PDEShareV3State) Amount() uint64 {
+	return s.amount
+}
+
+func (s *PDEShareV3State) SetAmount(amount uint64) {
+	s.amount = amount
+}
+
+func (s PDEShareV3State) ContributorAddress() string {
+	return s.contributorAddress
+}
+
+func (s *PDEShareV3State) SetContributorAddress(contributorAddress string) {
+	s.contributorAddress = contributorAddress
+}
+
+func (s PDEShareV3State) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		FeeTier            uint64
+		Token1ID           string
+		Token2ID           string
+		ContributorAddress string
+		Amount             uint64
+	}{
+		FeeTier:            s.feeTier,
+		Token1ID:           s.token1ID,
+		Token2ID:           s.token2ID,
+		ContributorAddress: s.contributorAddress,
+		Amount:             s.amount,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (s *PDEShareV3State) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		FeeTier            uint64
+		Token1ID           string
+		Token2ID           string
+		ContributorAddress string
+		Amount             uint64
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	s.feeTier = temp.FeeTier
+	s.token1ID = temp.Token1ID
+	s.token2ID = temp.Token2ID
+	s.contributorAddress = temp.ContributorAddress
+	s.amount = temp.Amount
+	return nil
+}
+
+func NewPDEShareV3State() *PDEShareV3State {
+	return &PDEShareV3State{}
+}
+
+func NewPDEShareV3StateWithValue(feeTier uint64, token1ID string, token2ID string, contributorAddress string, amount uint64) *PDEShareV3State {
+	return &PDEShareV3State{feeTier: feeTier, token1ID: token1ID, token2ID: token2ID, contributorAddress: contributorAddress, amount: amount}
+}
+
+type PDEShareV3Object struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version         int
+	pdeShareV3Hash  common.Hash
+	pdeShareV3State *PDEShareV3State
+	objectType      int
+	deleted         bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newPDEShareV3Object(db *StateDB, hash common.Hash) *PDEShareV3Object {
+	return &PDEShareV3Object{
+		version:         defaultVersion,
+		db:              db,
+		pdeShareV3Hash:  hash,
+		pdeShareV3State: NewPDEShareV3State(),
+		objectType:      PDEShareV3ObjectType,
+		deleted:         false,
+	}
+}
+
+func newPDEShareV3ObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*PDEShareV3Object, error) {
+	var newPDEShareV3State = NewPDEShareV3State()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, newPDEShareV3State)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newPDEShareV3State, ok = data.(*PDEShareV3State)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidPDEShareV3StateType, reflect.TypeOf(data))
+		}
+	}
+	return &PDEShareV3Object{
+		version:         defaultVersion,
+		pdeShareV3Hash:  key,
+		pdeShareV3State: newPDEShareV3State,
+		db:              db,
+		objectType:      PDEShareV3ObjectType,
+		deleted:         false,
+	}, nil
+}
+
+func GeneratePDEShareV3ObjectKey(feeTier uint64, token1ID, token2ID, contributorAddress string) common.Hash {
+	prefixHash := GetPDEShareV3Prefix()
+	valueHash := common.HashH([]byte(fmt.Sprintf("%d", feeTier) + token1ID + token2ID + contributorAddress))
+	return common.BytesToHash(append(prefixHash, valueHash[:][:prefixKeyLength]...))
+}
+
+func (t PDEShareV3Object) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *PDEShareV3Object) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t PDEShareV3Object) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *PDEShareV3Object) SetValue(data interface{}) error {
+	newPDEShareV3State, ok := data.(*PDEShareV3State)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidPDEShareV3StateType, reflect.TypeOf(data))
+	}
+	t.pdeShareV3State = newPDEShareV3State
+	return nil
+}
+
+func (t PDEShareV3Object) GetValue() interface{} {
+	return t.pdeShareV3State
+}
+
+func (t PDEShareV3Object) GetValueBytes() []byte {
+	pdeShareV3State, ok := t.GetValue().(*PDEShareV3State)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(pdeShareV3State)
+	if err != nil {
+		panic("failed to marshal pde v3 share state")
+	}
+	return value
+}
+
+func (t PDEShareV3Object) GetHash() common.Hash {
+	return t.pdeShareV3Hash
+}
+
+func (t PDEShareV3Object) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *PDEShareV3Object) MarkDelete() {
+	t.deleted = true
+}
+
+// reset all share value into default value
+func (t *PDEShareV3Object) Reset() bool {
+	t.pdeShareV3State = NewPDEShareV3State()
+	return true
+}
+
+func (t PDEShareV3Object) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t PDEShareV3Object) IsEmpty() bool {
+	temp := NewPDEShareV3State()
+	return reflect.DeepEqual(temp, t.pdeShareV3State) || t.pdeShareV3State == nil
+}
@@ -13,6 +13,15 @@ type WaitingPDEContributionState struct {
 	tokenID            string
 	amount             uint64
 	txReqID            common.Hash
+	feeTier            uint64 // only set by PDE v3 contributions
+}
+
+func (wc WaitingPDEContributionState) FeeTier() uint64 {
+	return wc.feeTier
+}
+
+func (wc *WaitingPDEContributionState) SetFeeTier(feeTier uint64) {
+	wc.feeTier = feeTier
 }
 
 func (wc WaitingPDEContributionState) TxReqID() common.Hash {
@@ -62,12 +71,14 @@ func (wc WaitingPDEContributionState) MarshalJSON() ([]byte, error) {
 		TokenID            string
 		Amount             uint64
 		TxReqID            common.Hash
+		FeeTier            uint64 `json:",omitempty"`
 	}{
 		PairID:             wc.pairID,
 		ContributorAddress: wc.contributorAddress,
 		TokenID:            wc.tokenID,
 		Amount:             wc.amount,
 		TxReqID:            wc.txReqID,
+		FeeTier:            wc.feeTier,
 	})
 	if err != nil {
 		return []byte{}, err
@@ -82,6 +93,7 @@ func (wc *WaitingPDEContributionState) UnmarshalJSON(data []byte) error {
 		TokenID            string
 		Amount             uint64
 		TxReqID            common.Hash
+		FeeTier            uint64 `json:",omitempty"`
 	}{}
 	err := json.Unmarshal(data, &temp)
 	if err != nil {
@@ -92,6 +104,7 @@ func (wc *WaitingPDEContributionState) UnmarshalJSON(data []byte) error {
 	wc.tokenID = temp.TokenID
 	wc.amount = temp.Amount
 	wc.txReqID = temp.TxReqID
+	wc.feeTier = temp.FeeTier
 	return nil
 }
 
@@ -0,0 +1,183 @@
+package statedb
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// PortalFeedersState is the set of incognito addresses allowed to feed the
+// portal exchange rates
+type PortalFeedersState struct {
+	feeders []string
+}
+
+func (s PortalFeedersState) Feeders() []string {
+	return s.feeders
+}
+
+func (s *PortalFeedersState) SetFeeders(feeders []string) {
+	s.feeders = feeders
+}
+
+func (s PortalFeedersState) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		Feeders []string
+	}{
+		Feeders: s.feeders,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+func (s *PortalFeedersState) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		Feeders []string
+	}{}
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return err
+	}
+	s.feeders = temp.Feeders
+	return nil
+}
+
+func NewPortalFeedersState() *PortalFeedersState {
+	return &PortalFeedersState{}
+}
+
+func NewPortalFeedersStateWithValue(feeders []string) *PortalFeedersState {
+	return &PortalFeedersState{feeders: feeders}
+}
+
+type PortalFeedersStateObject struct {
+	db *StateDB
+	// Write caches.
+	trie Trie // storage trie, which becomes non-nil on first access
+
+	version                int
+	portalFeedersStateHash common.Hash
+	portalFeedersState     *PortalFeedersState
+	objectType             int
+	deleted                bool
+
+	// DB error.
+	// State objects are used by the consensus core and VM which are
+	// unable to deal with database-level errors. Any error that occurs
+	// during a database read is memoized here and will eventually be returned
+	// by StateDB.Commit.
+	dbErr error
+}
+
+func newPortalFeedersStateObject(db *StateDB, hash common.Hash) *PortalFeedersStateObject {
+	return &PortalFeedersStateObject{
+		version:                defaultVersion,
+		db:                     db,
+		portalFeedersStateHash: hash,
+		portalFeedersState:     NewPortalFeedersState(),
+		objectType:             PortalFeedersStateObjectType,
+		deleted:                false,
+	}
+}
+
+func newPortalFeedersStateObjectWithValue(db *StateDB, key common.Hash, data interface{}) (*PortalFeedersStateObject, error) {
+	var portalFeedersState = NewPortalFeedersState()
+	var ok bool
+	var dataBytes []byte
+	if dataBytes, ok = data.([]byte); ok {
+		err := json.Unmarshal(dataBytes, portalFeedersState)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		portalFeedersState, ok = data.(*PortalFeedersState)
+		if !ok {
+			return nil, fmt.Errorf("%+v, got type %+v", ErrInvalidPortalFeedersStateType, reflect.TypeOf(data))
+		}
+	}
+	return &PortalFeedersStateObject{
+		version:                defaultVersion,
+		portalFeedersStateHash: key,
+		portalFeedersState:     portalFeedersState,
+		db:                     db,
+		objectType:             PortalFeedersStateObjectType,
+		deleted:                false,
+	}, nil
+}
+
+func GeneratePortalFeedersStateObjectKey() common.Hash {
+	prefixHash := GetPortalFeedersStatePrefix()
+	return common.BytesToHash(prefixHash)
+}
+
+func (t PortalFeedersStateObject) GetVersion() int {
+	return t.version
+}
+
+// setError remembers the first non-nil error it is called with.
+func (t *PortalFeedersStateObject) SetError(err error) {
+	if t.dbErr == nil {
+		t.dbErr = err
+	}
+}
+
+func (t PortalFeedersStateObject) GetTrie(db DatabaseAccessWarper) Trie {
+	return t.trie
+}
+
+func (t *PortalFeedersStateObject) SetValue(data interface{}) error {
+	portalFeedersState, ok := data.(*PortalFeedersState)
+	if !ok {
+		return fmt.Errorf("%+v, got type %+v", ErrInvalidPortalFeedersStateType, reflect.TypeOf(data))
+	}
+	t.portalFeedersState = portalFeedersState
+	return nil
+}
+
+func (t PortalFeedersStateObject) GetValue() interface{} {
+	return t.portalFeedersState
+}
+
+func (t PortalFeedersStateObject) GetValueBytes() []byte {
+	portalFeedersState, ok := t.GetValue().(*PortalFeedersState)
+	if !ok {
+		panic("wrong expected value type")
+	}
+	value, err := json.Marshal(portalFeedersState)
+	if err != nil {
+		panic("failed to marshal portal feeders state")
+	}
+	return value
+}
+
+func (t PortalFeedersStateObject) GetHash() common.Hash {
+	return t.portalFeedersStateHash
+}
+
+func (t PortalFeedersStateObject) GetType() int {
+	return t.objectType
+}
+
+// MarkDelete will delete an object in trie
+func (t *PortalFeedersStateObject) MarkDelete() {
+	t.deleted = true
+}
+
+func (t *PortalFeedersStateObject) Reset() bool {
+	t.portalFeedersState = NewPortalFeedersState()
+	return true
+}
+
+func (t PortalFeedersStateObject) IsDeleted() bool {
+	return t.deleted
+}
+
+// value is either default or nil
+func (t PortalFeedersStateObject) IsEmpty() bool {
+	temp := NewPortalFeedersState()
+	return reflect.DeepEqual(temp, t.portalFeedersState) || t.portalFeedersState == nil
+}
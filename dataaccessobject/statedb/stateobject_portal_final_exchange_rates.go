@@ -11,8 +11,15 @@ type FinalExchangeRatesDetail struct {
 	Amount uint64
 }
 
+// ExchangeRatesFeed is the last rate of a token submitted by a feeder
+type ExchangeRatesFeed struct {
+	Rate         uint64
+	BeaconHeight uint64
+}
+
 type FinalExchangeRatesState struct {
 	rates map[string]FinalExchangeRatesDetail
+	feeds map[string]map[string]ExchangeRatesFeed // pTokenID : feeder address : last feed
 }
 
 func (f *FinalExchangeRatesState) Rates() map[string]FinalExchangeRatesDetail {
@@ -23,6 +30,14 @@ func (f *FinalExchangeRatesState) SetRates(rates map[string]FinalExchangeRatesDe
 	f.rates = rates
 }
 
+func (f *FinalExchangeRatesState) Feeds() map[string]map[string]ExchangeRatesFeed {
+	return f.feeds
+}
+
+func (f *FinalExchangeRatesState) SetFeeds(feeds map[string]map[string]ExchangeRatesFeed) {
+	f.feeds = feeds
+}
+
 func NewFinalExchangeRatesState() *FinalExchangeRatesState {
 	return &FinalExchangeRatesState{}
 }
@@ -31,6 +46,10 @@ func NewFinalExchangeRatesStateWithValue(rates map[string]FinalExchangeRatesDeta
 	return &FinalExchangeRatesState{rates: rates}
 }
 
+func NewFinalExchangeRatesStateWithFeeds(rates map[string]FinalExchangeRatesDetail, feeds map[string]map[string]ExchangeRatesFeed) *FinalExchangeRatesState {
+	return &FinalExchangeRatesState{rates: rates, feeds: feeds}
+}
+
 func GeneratePortalFinalExchangeRatesStateObjectKey() common.Hash {
 	suffix := "exchangerates"
 	prefixHash := GetFinalExchangeRatesStatePrefix()
@@ -41,8 +60,10 @@ func GeneratePortalFinalExchangeRatesStateObjectKey() common.Hash {
 func (f *FinalExchangeRatesState) MarshalJSON() ([]byte, error) {
 	data, err := json.Marshal(struct {
 		Rates map[string]FinalExchangeRatesDetail
+		Feeds map[string]map[string]ExchangeRatesFeed `json:",omitempty"`
 	}{
 		Rates: f.rates,
+		Feeds: f.feeds,
 	})
 	if err != nil {
 		return []byte{}, err
@@ -53,12 +74,14 @@ func (f *FinalExchangeRatesState) MarshalJSON() ([]byte, error) {
 func (f *FinalExchangeRatesState) UnmarshalJSON(data []byte) error {
 	temp := struct {
 		Rates map[string]FinalExchangeRatesDetail
+		Feeds map[string]map[string]ExchangeRatesFeed
 	}{}
 	err := json.Unmarshal(data, &temp)
 	if err != nil {
 		return err
 	}
 	f.rates = temp.Rates
+	f.feeds = temp.Feeds
 	return nil
 }
 
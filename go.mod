@@ -16,6 +16,7 @@ require (
 	github.com/davecgh/go-spew v1.1.1
 	github.com/dchest/siphash v1.2.1 // indirect
 	github.com/deckarep/golang-set v1.7.1 // indirect
+	github.com/dgraph-io/badger v1.6.1
 	github.com/dgryski/go-identicon v0.0.0-20140725220403-371855927d74
 	github.com/ebfe/keccak v0.0.0-20150115210727-5cc570678d1b
 	github.com/edsrzf/mmap-go v1.0.0 // indirect
@@ -64,7 +65,6 @@ require (
 	gopkg.in/natefinch/npipe.v2 v2.0.0-20160621034901-c1b8fa8bdcce // indirect
 	gopkg.in/yaml.v2 v2.2.4
 	stathat.com/c/consistent v1.0.0
-
 )
 
 replace github.com/tendermint/go-amino => github.com/binance-chain/bnc-go-amino v0.14.1-binance.1
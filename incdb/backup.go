@@ -0,0 +1,154 @@
+package incdb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// Backup files are compressed copies of a whole database directory, named by
+// the epoch they were taken at. These helpers are shared by every driver so
+// that a backup taken by one node can be preloaded by another one.
+
+// LatestBackupOfDatabase returns the highest epoch backup found in path,
+// which is relative to the database directory dbPath.
+func LatestBackupOfDatabase(dbPath string, path string) (int, string) {
+	backupFolder := filepath.Join(dbPath, path)
+	files, err := ioutil.ReadDir(backupFolder)
+	if err != nil {
+		return 0, ""
+	}
+	if len(files) == 0 {
+		return 0, ""
+	}
+	latestBackupEpoch := 0
+	//Get max epoch
+	for _, file := range files {
+		epoch, err := strconv.Atoi(file.Name())
+		if err != nil {
+			return 0, ""
+		}
+		if epoch > latestBackupEpoch {
+			latestBackupEpoch = epoch
+		}
+	}
+
+	return latestBackupEpoch, fmt.Sprintf("%v/%v", backupFolder, latestBackupEpoch)
+}
+
+// RestoreDatabaseFromBackup replaces the content of dbPath with the content of
+// the compressed backupFile. The database must be closed by the caller.
+func RestoreDatabaseFromBackup(backupFile string, dbPath string) error {
+	err := uncompress(backupFile, dbPath+"_")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("remove ", dbPath)
+	err = os.RemoveAll(dbPath)
+	if err != nil {
+		return err
+	}
+	fmt.Println("rename ", dbPath)
+	err = os.Rename(dbPath+"_", dbPath)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CompressDatabaseToBackup writes a compressed copy of dbPath into backupFile
+// and removes the older backups next to it. The database must be closed by
+// the caller.
+func CompressDatabaseToBackup(dbPath string, backupFile string) error {
+	if err := os.MkdirAll(filepath.Dir(backupFile), 0700); err != nil {
+		return err
+	}
+	if err := common.CompressDatabase(dbPath, backupFile); err != nil {
+		return err
+	}
+	return removeUnusedBackupDatabase(backupFile)
+}
+
+// ClearDatabaseDirectory removes every file under dbPath.
+func ClearDatabaseDirectory(dbPath string) error {
+	files, err := filepath.Glob(filepath.Join(dbPath, "*"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		err = os.RemoveAll(file)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeUnusedBackupDatabase ...
+// for remove unused databases in backup folder
+func removeUnusedBackupDatabase(filePath string) error {
+	strs := strings.Split(filePath, "/")
+
+	//Get latest epoch
+	latestEpoch, err := strconv.Atoi(strs[len(strs)-1])
+	if err != nil {
+		return err
+	}
+
+	//Get path directory of this file
+	path := filepath.Dir(filePath)
+
+	//Get needed epoch to download
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return err
+	}
+
+	//Get file name and compare with latest epoch
+	for _, file := range files {
+
+		epoch, err := strconv.Atoi(file.Name())
+		if err != nil {
+			return err
+		}
+
+		if epoch != latestEpoch && epoch != latestEpoch-1 {
+			name := path + "/" + file.Name()
+			err = os.Remove(name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Uncompress file from zip file
+func uncompress(srcPath, desPath string) error {
+	fmt.Println("start decompress", srcPath)
+	if err := os.RemoveAll(desPath); err != nil {
+		return err
+	}
+	//Create new data
+	if err := os.MkdirAll(desPath, 0700); err != nil {
+		return err
+	}
+
+	err := common.DecompressDatabaseBackup(srcPath, desPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("done decompress", desPath)
+	return nil
+}
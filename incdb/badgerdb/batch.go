@@ -0,0 +1,90 @@
+package badgerdb
+
+import (
+	"github.com/dgraph-io/badger"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/pkg/errors"
+)
+
+// operation is a single write or removal queued in a batch.
+type operation struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// batch is a write-only badger batch that commits changes to its host database
+// when Write is called. A batch cannot be used concurrently.
+type batch struct {
+	db   *badger.DB
+	ops  []operation
+	size int
+}
+
+// Put inserts the given value into the batch for later committing.
+func (b *batch) Put(key, value []byte) error {
+	b.ops = append(b.ops, operation{
+		key:   append([]byte{}, key...),
+		value: append([]byte{}, value...),
+	})
+	b.size += len(value)
+	return nil
+}
+
+// Delete inserts the a key removal into the batch for later committing.
+func (b *batch) Delete(key []byte) error {
+	b.ops = append(b.ops, operation{
+		key:    append([]byte{}, key...),
+		delete: true,
+	})
+	b.size++
+	return nil
+}
+
+// ValueSize retrieves the amount of data queued up for writing.
+func (b *batch) ValueSize() int {
+	return b.size
+}
+
+// Write flushes any accumulated data to disk. The batch is split into as many
+// badger transactions as needed, so a batch of any size is written, but unlike
+// the leveldb batch it is not atomic: a crash during Write may leave only the
+// first transactions of the batch committed.
+func (b *batch) Write() error {
+	wb := b.db.NewWriteBatch()
+	defer wb.Cancel()
+	for _, op := range b.ops {
+		var err error
+		if op.delete {
+			err = wb.Delete(op.key)
+		} else {
+			err = wb.Set(op.key, op.value)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "batch of %d operations", len(b.ops))
+		}
+	}
+	return errors.Wrapf(wb.Flush(), "batch of %d operations", len(b.ops))
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	b.ops = b.ops[:0]
+	b.size = 0
+}
+
+// Replay replays the batch contents.
+func (b *batch) Replay(w incdb.KeyValueWriter) error {
+	for _, op := range b.ops {
+		var err error
+		if op.delete {
+			err = w.Delete(op.key)
+		} else {
+			err = w.Put(op.key, op.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package badgerdb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dgraph-io/badger"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/pkg/errors"
+)
+
+const (
+	// gcDiscardRatio is the ratio of stale data a value log file must hold
+	// before it is rewritten by Compact.
+	gcDiscardRatio = 0.5
+	// compactWorkers is the number of goroutines used to flatten the LSM tree.
+	compactWorkers = 2
+)
+
+type db struct {
+	fn     string // filename for reporting
+	dbPath string
+	bgdb   *badger.DB
+	lock   sync.RWMutex
+}
+
+func init() {
+	driver := incdb.Driver{
+		DbType: "badgerdb",
+		Open:   openDriver,
+	}
+	if err := incdb.RegisterDriver(driver); err != nil {
+		panic("failed to register db driver")
+	}
+}
+
+func openDriver(args ...interface{}) (incdb.Database, error) {
+	if len(args) != 1 {
+		return nil, errors.New("invalid arguments")
+	}
+	dbPath, ok := args[0].(string)
+	if !ok {
+		return nil, errors.New("expected db path")
+	}
+	return open(dbPath)
+}
+
+func openBadger(dbPath string) (*badger.DB, error) {
+	if err := os.MkdirAll(dbPath, 0700); err != nil {
+		return nil, errors.Wrapf(err, "os.MkdirAll %s", dbPath)
+	}
+	opts := badger.DefaultOptions(dbPath).
+		WithSyncWrites(false).
+		WithTruncate(true).
+		WithLogger(&logger{})
+	bgdb, err := badger.Open(opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "badger.Open %s", dbPath)
+	}
+	return bgdb, nil
+}
+
+func open(dbPath string) (incdb.Database, error) {
+	bgdb, err := openBadger(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	return &db{fn: dbPath, bgdb: bgdb, dbPath: dbPath}, nil
+}
+
+func (db *db) GetPath() string {
+	return db.fn
+}
+
+func (db *db) Close() error {
+	return errors.Wrap(db.bgdb.Close(), "db.bgdb.Close")
+}
+
+func (db *db) ReOpen() error {
+	bgdb, err := openBadger(db.dbPath)
+	if err != nil {
+		return err
+	}
+	db.bgdb = bgdb
+	return nil
+}
+
+func (db *db) Has(key []byte) (bool, error) {
+	err := db.bgdb.View(func(txn *badger.Txn) error {
+		_, err := txn.Get(key)
+		return err
+	})
+	if err == badger.ErrKeyNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (db *db) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+	var value []byte
+	err := db.bgdb.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		value, err = item.ValueCopy(nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (db *db) Put(key, value []byte) error {
+	return db.bgdb.Update(func(txn *badger.Txn) error {
+		return txn.Set(key, value)
+	})
+}
+
+func (db *db) Delete(key []byte) error {
+	return db.bgdb.Update(func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	})
+}
+
+// NewBatch creates a write-only key-value store that buffers changes to its host
+// database until a final write is called.
+func (db *db) NewBatch() incdb.Batch {
+	return &batch{
+		db: db.bgdb,
+	}
+}
+
+// NewIterator creates a binary-alphabetical iterator over the entire keyspace
+// contained within the badger database.
+func (db *db) NewIterator() incdb.Iterator {
+	return newIterator(db.bgdb, nil, nil)
+}
+
+// NewIteratorWithStart creates a binary-alphabetical iterator over a subset of
+// database content starting at a particular initial key (or after, if it does
+// not exist).
+func (db *db) NewIteratorWithStart(start []byte) incdb.Iterator {
+	return newIterator(db.bgdb, nil, start)
+}
+
+// NewIteratorWithPrefix creates a binary-alphabetical iterator over a subset
+// of database content with a particular key prefix.
+func (db *db) NewIteratorWithPrefix(prefix []byte) incdb.Iterator {
+	return newIterator(db.bgdb, prefix, prefix)
+}
+
+// Stat returns a particular internal stat of the database. Badger only
+// reports the size of its LSM tree and value log, whatever the property is.
+func (db *db) Stat(property string) (string, error) {
+	lsm, vlog := db.bgdb.Size()
+	return fmt.Sprintf("lsm: %d, vlog: %d", lsm, vlog), nil
+}
+
+// Compact flattens the LSM tree and garbage collects the value log. Badger
+// cannot compact a key range, so start and limit are ignored and the entire
+// data store is compacted.
+func (db *db) Compact(start []byte, limit []byte) error {
+	if err := db.bgdb.Flatten(compactWorkers); err != nil {
+		return err
+	}
+	for {
+		err := db.bgdb.RunValueLogGC(gcDiscardRatio)
+		if err == badger.ErrNoRewrite {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Path returns the path to the database directory.
+func (db *db) Path() string {
+	return db.fn
+}
+
+func (db *db) PreloadBackup(backupFile string) error {
+	return incdb.RestoreDatabaseFromBackup(backupFile, db.dbPath)
+}
+
+func (db *db) LatestBackup(path string) (int, string) {
+	return incdb.LatestBackupOfDatabase(db.dbPath, path)
+}
+
+func (db *db) RemoveBackup(backupFile string) {
+	backupFile = filepath.Join(db.dbPath, backupFile)
+	os.Remove(backupFile)
+}
+
+func (db *db) Backup(backupFile string) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	backupFile = filepath.Join(db.dbPath, backupFile)
+	fmt.Println("backupFile", backupFile)
+
+	if err := db.Close(); err != nil {
+		return err
+	}
+
+	err := incdb.CompressDatabaseToBackup(db.dbPath, backupFile)
+
+	if err := db.ReOpen(); err != nil {
+		panic(err)
+	}
+
+	return err
+}
+
+// Clear drops every key of the database, the database stays usable.
+func (db *db) Clear() error {
+	return db.bgdb.DropAll()
+}
@@ -0,0 +1,65 @@
+package badgerdb_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/badgerdb"
+	"github.com/incognitochain/incognito-chain/incdb/dbtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = func() (_ struct{}) {
+	incdb.Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
+func TestDb(t *testing.T) {
+	dbtest.TestDatabaseSuite(t, func(dbPath string) (incdb.Database, error) {
+		return incdb.Open("badgerdb", dbPath)
+	})
+}
+
+func TestDb_OversizedBatch(t *testing.T) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_badger_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %+v", err)
+	}
+	defer os.RemoveAll(dbPath)
+	db, err := incdb.Open("badgerdb", dbPath)
+	if err != nil {
+		t.Fatalf("could not open db path: %s, %+v", dbPath, err)
+	}
+	defer db.Close()
+
+	// more entries than a single badger transaction can hold
+	batch := db.NewBatch()
+	for i := 0; i < 200000; i++ {
+		batch.Put([]byte(fmt.Sprintf("key-%d", i)), []byte("value"))
+	}
+	err = batch.Write()
+	assert.Nil(t, err)
+
+	// the batch is split over several transactions and entirely committed
+	for _, key := range []string{"key-0", "key-100000", "key-199999"} {
+		value, err := db.Get([]byte(key))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("value"), value)
+	}
+
+	// deletions are split as well
+	batch.Reset()
+	for i := 0; i < 200000; i++ {
+		batch.Delete([]byte(fmt.Sprintf("key-%d", i)))
+	}
+	assert.Nil(t, batch.Write())
+	for _, key := range []string{"key-0", "key-199999"} {
+		has, err := db.Has([]byte(key))
+		assert.Nil(t, err)
+		assert.False(t, has)
+	}
+}
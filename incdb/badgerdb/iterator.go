@@ -0,0 +1,122 @@
+package badgerdb
+
+import (
+	"bytes"
+
+	"github.com/dgraph-io/badger"
+)
+
+// iterator wraps a badger iterator and its read-only transaction to behave
+// like a leveldb iterator: it starts before the first pair and Next must be
+// called before reading the first key/value.
+type iterator struct {
+	txn     *badger.Txn
+	it      *badger.Iterator
+	prefix  []byte
+	start   []byte
+	started bool
+	done    bool
+	key     []byte
+	value   []byte
+	err     error
+}
+
+func newIterator(bgdb *badger.DB, prefix []byte, start []byte) *iterator {
+	txn := bgdb.NewTransaction(false)
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = prefix
+	return &iterator{
+		txn:    txn,
+		it:     txn.NewIterator(opts),
+		prefix: prefix,
+		start:  start,
+	}
+}
+
+// Next moves the iterator to the next key/value pair. It returns whether the
+// iterator is exhausted.
+func (iter *iterator) Next() bool {
+	if iter.done || iter.err != nil {
+		return false
+	}
+	if !iter.started {
+		iter.it.Seek(iter.start)
+		iter.started = true
+	} else {
+		iter.it.Next()
+	}
+	return iter.load(iter.it)
+}
+
+// Error returns any accumulated error.
+func (iter *iterator) Error() error {
+	return iter.err
+}
+
+// Key returns the key of the current key/value pair, or nil if done.
+func (iter *iterator) Key() []byte {
+	return iter.key
+}
+
+// Value returns the value of the current key/value pair, or nil if done.
+func (iter *iterator) Value() []byte {
+	return iter.value
+}
+
+// Last moves the iterator to the last key/value pair of its range. Badger
+// iterators only go in one direction, so a reverse iterator is used to find
+// the last pair and the iterator is exhausted afterward.
+func (iter *iterator) Last() bool {
+	if iter.err != nil {
+		return false
+	}
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = iter.prefix
+	opts.Reverse = true
+	reverse := iter.txn.NewIterator(opts)
+	defer reverse.Close()
+	if len(iter.prefix) > 0 {
+		reverse.Seek(append(append([]byte{}, iter.prefix...), bytes.Repeat([]byte{0xff}, 32)...))
+	} else {
+		reverse.Rewind()
+	}
+	iter.started = true
+	ok := iter.load(reverse)
+	if ok && bytes.Compare(iter.key, iter.start) < 0 {
+		iter.key, iter.value = nil, nil
+		ok = false
+	}
+	iter.done = true
+	return ok
+}
+
+// Release releases associated resources. It can be called multiple times.
+func (iter *iterator) Release() {
+	if iter.it != nil {
+		iter.it.Close()
+		iter.it = nil
+	}
+	if iter.txn != nil {
+		iter.txn.Discard()
+		iter.txn = nil
+	}
+	iter.done = true
+	iter.key, iter.value = nil, nil
+}
+
+// load copies the current item of it into the iterator.
+func (iter *iterator) load(it *badger.Iterator) bool {
+	if !it.ValidForPrefix(iter.prefix) {
+		iter.done = true
+		iter.key, iter.value = nil, nil
+		return false
+	}
+	item := it.Item()
+	iter.key = item.KeyCopy(nil)
+	iter.value, iter.err = item.ValueCopy(nil)
+	if iter.err != nil {
+		iter.key, iter.value = nil, nil
+		return false
+	}
+	return true
+}
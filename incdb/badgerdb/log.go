@@ -0,0 +1,33 @@
+package badgerdb
+
+import (
+	"github.com/incognitochain/incognito-chain/incdb"
+)
+
+// logger forwards badger logs to the incdb logger. Badger info logs are very
+// verbose, so they are written at debug level.
+type logger struct{}
+
+func (l *logger) Errorf(format string, args ...interface{}) {
+	if incdb.Logger.Log != nil {
+		incdb.Logger.Log.Errorf(format, args...)
+	}
+}
+
+func (l *logger) Warningf(format string, args ...interface{}) {
+	if incdb.Logger.Log != nil {
+		incdb.Logger.Log.Warnf(format, args...)
+	}
+}
+
+func (l *logger) Infof(format string, args ...interface{}) {
+	if incdb.Logger.Log != nil {
+		incdb.Logger.Log.Debugf(format, args...)
+	}
+}
+
+func (l *logger) Debugf(format string, args ...interface{}) {
+	if incdb.Logger.Log != nil {
+		incdb.Logger.Log.Debugf(format, args...)
+	}
+}
@@ -0,0 +1,21 @@
+package dbtest_test
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incdb/dbtest"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+)
+
+var _ = func() (_ struct{}) {
+	incdb.Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return
+}()
+
+func TestLevelDB(t *testing.T) {
+	dbtest.TestDatabaseSuite(t, func(dbPath string) (incdb.Database, error) {
+		return incdb.Open("leveldb", dbPath)
+	})
+}
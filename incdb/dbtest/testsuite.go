@@ -0,0 +1,211 @@
+// Package dbtest contains the tests every incdb.Database driver must pass.
+package dbtest
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDatabaseSuite runs the driver tests against the databases returned by
+// open, which must create a new database at the given path.
+func TestDatabaseSuite(t *testing.T, open func(dbPath string) (incdb.Database, error)) {
+	t.Run("Setup", func(t *testing.T) {
+		db, dbPath := newTestDatabase(t, open)
+		defer os.RemoveAll(dbPath)
+		if err := db.Close(); err != nil {
+			t.Fatalf("db.close %+v", err)
+		}
+	})
+
+	t.Run("Base", func(t *testing.T) {
+		db, dbPath := newTestDatabase(t, open)
+		defer os.RemoveAll(dbPath)
+		defer db.Close()
+
+		err := db.Put([]byte("a"), []byte{1})
+		assert.Equal(t, nil, err)
+		result, err := db.Get([]byte("a"))
+		if err != nil {
+			t.Error(err)
+		}
+		assert.Equal(t, result[0], []byte{1}[0])
+		has, err := db.Has([]byte("a"))
+		if err != nil {
+			t.Error(err)
+		}
+		assert.Equal(t, has, true)
+
+		err = db.Delete([]byte("a"))
+		assert.Equal(t, nil, err)
+		err = db.Delete([]byte("b"))
+		assert.Equal(t, nil, err)
+		has, err = db.Has([]byte("a"))
+		assert.Equal(t, err, nil)
+		assert.Equal(t, has, false)
+		_, err = db.Get([]byte("a"))
+		assert.NotEqual(t, nil, err)
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		db, dbPath := newTestDatabase(t, open)
+		defer os.RemoveAll(dbPath)
+		defer db.Close()
+
+		err := db.Put([]byte("abc0"), []byte("abc0"))
+		assert.Equal(t, nil, err)
+		batch := db.NewBatch()
+		assert.Equal(t, nil, batch.Put([]byte("abc1"), []byte("abc1")))
+		assert.Equal(t, nil, batch.Put([]byte("abc2"), []byte("abc2")))
+		assert.Equal(t, nil, batch.Delete([]byte("abc0")))
+		assert.Equal(t, 9, batch.ValueSize())
+
+		has, err := db.Has([]byte("abc1"))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, false, has)
+
+		err = batch.Write()
+		assert.Equal(t, nil, err)
+		v, err := db.Get([]byte("abc2"))
+		assert.Equal(t, err, nil)
+		assert.Equal(t, "abc2", string(v))
+		has, err = db.Has([]byte("abc0"))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, false, has)
+
+		replayed := db.NewBatch()
+		assert.Equal(t, nil, batch.Replay(replayed))
+		assert.Equal(t, batch.ValueSize(), replayed.ValueSize())
+
+		batch.Reset()
+		assert.Equal(t, 0, batch.ValueSize())
+	})
+
+	t.Run("Iterator", func(t *testing.T) {
+		db, dbPath := newTestDatabase(t, open)
+		defer os.RemoveAll(dbPath)
+		defer db.Close()
+
+		keys := []string{"a1", "a2", "a3", "b1", "b2", "c1"}
+		for _, k := range keys {
+			assert.Equal(t, nil, db.Put([]byte(k), []byte("v"+k)))
+		}
+
+		assert.Equal(t, keys, iterateKeys(t, db.NewIterator()))
+		assert.Equal(t, []string{"b1", "b2"}, iterateKeys(t, db.NewIteratorWithPrefix([]byte("b"))))
+		assert.Equal(t, []string{"a3", "b1", "b2", "c1"}, iterateKeys(t, db.NewIteratorWithStart([]byte("a3"))))
+		assert.Equal(t, []string{"b1", "b2", "c1"}, iterateKeys(t, db.NewIteratorWithStart([]byte("a4"))))
+		assert.Equal(t, []string(nil), iterateKeys(t, db.NewIteratorWithPrefix([]byte("d"))))
+
+		iter := db.NewIteratorWithPrefix([]byte("a"))
+		assert.Equal(t, true, iter.Next())
+		assert.Equal(t, "va1", string(iter.Value()))
+		assert.Equal(t, true, iter.Last())
+		assert.Equal(t, "a3", string(iter.Key()))
+		assert.Equal(t, "va3", string(iter.Value()))
+		iter.Release()
+		iter.Release()
+	})
+
+	t.Run("ReOpen", func(t *testing.T) {
+		db, dbPath := newTestDatabase(t, open)
+		defer os.RemoveAll(dbPath)
+		defer db.Close()
+
+		assert.Equal(t, nil, db.Put([]byte("a"), []byte("a")))
+		assert.Equal(t, nil, db.Close())
+		assert.Equal(t, nil, db.ReOpen())
+		v, err := db.Get([]byte("a"))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "a", string(v))
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		db, dbPath := newTestDatabase(t, open)
+		defer os.RemoveAll(dbPath)
+		defer db.Close()
+
+		assert.Equal(t, nil, db.Put([]byte("a"), []byte("a")))
+		assert.Equal(t, nil, db.Clear())
+		db.Close()
+		assert.Equal(t, nil, db.ReOpen())
+		has, err := db.Has([]byte("a"))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, false, has)
+	})
+
+	t.Run("Backup", func(t *testing.T) {
+		root, err := ioutil.TempDir(os.TempDir(), "test_")
+		if err != nil {
+			t.Fatalf("failed to create temp dir: %+v", err)
+		}
+		defer os.RemoveAll(root)
+		dbPath := filepath.Join(root, "block", "beacon")
+		db, err := open(dbPath)
+		if err != nil {
+			t.Fatalf("could not open db path: %s, %+v", dbPath, err)
+		}
+		defer db.Close()
+
+		for epoch := 1; epoch <= 3; epoch++ {
+			assert.Equal(t, nil, db.Put([]byte("epoch"), []byte(fmt.Sprint(epoch))))
+			assert.Equal(t, nil, db.Backup(fmt.Sprintf("../../backup/beacon/%d", epoch)))
+		}
+		// the database is still usable after a backup
+		assert.Equal(t, nil, db.Put([]byte("epoch"), []byte("4")))
+
+		// only the two latest backups are kept
+		files, err := ioutil.ReadDir(filepath.Join(root, "backup", "beacon"))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 2, len(files))
+
+		epoch, backupFile := db.LatestBackup("../../backup/beacon")
+		assert.Equal(t, 3, epoch)
+		assert.Equal(t, filepath.Join(root, "backup", "beacon", "3"), filepath.Clean(backupFile))
+
+		db.RemoveBackup("../../backup/beacon/2")
+		files, err = ioutil.ReadDir(filepath.Join(root, "backup", "beacon"))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 1, len(files))
+
+		assert.Equal(t, nil, db.Close())
+		assert.Equal(t, nil, db.PreloadBackup(backupFile))
+		assert.Equal(t, nil, db.ReOpen())
+		v, err := db.Get([]byte("epoch"))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "3", string(v))
+	})
+}
+
+func newTestDatabase(t *testing.T, open func(dbPath string) (incdb.Database, error)) (incdb.Database, string) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %+v", err)
+	}
+	db, err := open(dbPath)
+	if err != nil {
+		t.Fatalf("could not open db path: %s, %+v", dbPath, err)
+	}
+	return db, dbPath
+}
+
+func iterateKeys(t *testing.T, iter incdb.Iterator) []string {
+	defer iter.Release()
+	var keys []string
+	var prev []byte
+	for iter.Next() {
+		if prev != nil && bytes.Compare(prev, iter.Key()) >= 0 {
+			t.Errorf("keys are not in ascending order: %s >= %s", prev, iter.Key())
+		}
+		prev = append([]byte{}, iter.Key()...)
+		keys = append(keys, string(iter.Key()))
+	}
+	assert.Equal(t, nil, iter.Error())
+	return keys
+}
@@ -35,21 +35,26 @@ func Open(typ string, args ...interface{}) (Database, error) {
 	return d.Open(args...)
 }
 
-// Open opens the db connection.
+// OpenMultipleDB opens the beacon and shard databases with the same driver.
 func OpenMultipleDB(typ string, dbPath string) (map[int]Database, error) {
+	return OpenMultipleDBWithDrivers(typ, nil, dbPath)
+}
+
+// OpenMultipleDBWithDrivers opens the beacon and shard databases. A chain
+// listed in chainTypes (keyed by shard id, common.BeaconChainDataBaseID for
+// beacon) is opened with its own driver, the others use typ.
+func OpenMultipleDBWithDrivers(typ string, chainTypes map[int]string, dbPath string) (map[int]Database, error) {
 	m := make(map[int]Database)
-	d, exists := drivers[typ]
-	if !exists {
-		return nil, errors.Wrapf(errors.New("Driver is not registered"), typ)
-	}
 	for i := -1; i < common.MaxShardNumber; i++ {
-		newPath := ""
-		if i == common.BeaconChainDataBaseID {
-			newPath = path.Join(dbPath, common.BeaconChainDatabaseDirectory)
-		} else {
-			newPath = path.Join(dbPath, common.ShardChainDatabaseDirectory+strconv.Itoa(i))
+		chainTyp := typ
+		if t, ok := chainTypes[i]; ok {
+			chainTyp = t
+		}
+		d, exists := drivers[chainTyp]
+		if !exists {
+			return nil, errors.Wrapf(errors.New("Driver is not registered"), chainTyp)
 		}
-		db, err := d.Open(newPath)
+		db, err := d.Open(GetChainDatabasePath(dbPath, i))
 		if err != nil {
 			return nil, errors.WithStack(fmt.Errorf("Open database error %+v", err))
 		}
@@ -57,3 +62,12 @@ func OpenMultipleDB(typ string, dbPath string) (map[int]Database, error) {
 	}
 	return m, nil
 }
+
+// GetChainDatabasePath returns the directory of the database of a chain
+// (beacon or shard) under dbPath.
+func GetChainDatabasePath(dbPath string, chainID int) string {
+	if chainID == common.BeaconChainDataBaseID {
+		return path.Join(dbPath, common.BeaconChainDatabaseDirectory)
+	}
+	return path.Join(dbPath, common.ShardChainDatabaseDirectory+strconv.Itoa(chainID))
+}
@@ -2,16 +2,10 @@ package lvdb
 
 import (
 	"fmt"
-	"io/ioutil"
-	"log"
 	"os"
 	"path/filepath"
-	"strconv"
-	"strings"
 	"sync"
 
-	"github.com/incognitochain/incognito-chain/common"
-
 	"github.com/pkg/errors"
 
 	"github.com/incognitochain/incognito-chain/incdb"
@@ -252,49 +246,11 @@ func (db *db) Batch(data []incdb.BatchData) leveldb.Batch {
 }
 
 func (db *db) PreloadBackup(backupFile string) error {
-	err := uncompress(backupFile, db.dbPath+"_")
-	if err != nil {
-		return err
-	}
-
-	fmt.Println("remove ", db.dbPath)
-	err = os.RemoveAll(db.dbPath)
-	if err != nil {
-		return err
-	}
-	fmt.Println("rename ", db.dbPath)
-	err = os.Rename(db.dbPath+"_", db.dbPath)
-	if err != nil {
-		return err
-	}
-	return nil
+	return incdb.RestoreDatabaseFromBackup(backupFile, db.dbPath)
 }
 
 func (db *db) LatestBackup(path string) (int, string) {
-	backupFolder := filepath.Join(db.dbPath, path)
-	//fmt.Println("backupFolder", backupFolder)
-	files, err := ioutil.ReadDir(backupFolder)
-	if err != nil {
-		return 0, ""
-	}
-	//fmt.Println("files", files)
-	if len(files) == 0 {
-		return 0, ""
-	}
-	latestBackupEpoch := 0
-	//Get max epoch
-	for _, file := range files {
-		//fmt.Println("file", file.Name())
-		epoch, err := strconv.Atoi(file.Name())
-		if err != nil {
-			return 0, ""
-		}
-		if epoch > latestBackupEpoch {
-			latestBackupEpoch = epoch
-		}
-	}
-
-	return latestBackupEpoch, fmt.Sprintf("%v/%v", backupFolder, latestBackupEpoch)
+	return incdb.LatestBackupOfDatabase(db.dbPath, path)
 }
 
 func (db *db) RemoveBackup(backupFile string) {
@@ -309,117 +265,19 @@ func (db *db) Backup(backupFile string) error {
 	backupFile = filepath.Join(db.dbPath, backupFile)
 	fmt.Println("backupFile", backupFile)
 
-	if err := os.MkdirAll(filepath.Dir(backupFile), 0700); err != nil {
-		panic(err)
-	}
-
 	if err := db.Close(); err != nil {
 		return err
 	}
 
-	err := common.CompressDatabase(db.dbPath, backupFile)
-	if err != nil {
-		return err
-	}
+	err := incdb.CompressDatabaseToBackup(db.dbPath, backupFile)
 
 	if err := db.ReOpen(); err != nil {
 		panic(err)
 	}
 
-	if err := removeUnusedBackupDatabase(backupFile); err != nil {
-		panic(err)
-	}
-
-	return nil
+	return err
 }
 
 func (db *db) Clear() error {
-	files, err := filepath.Glob(filepath.Join(db.dbPath, "*"))
-	if err != nil {
-		return err
-	}
-	for _, file := range files {
-		err = os.RemoveAll(file)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-//removeUnusedBackupDatabase ...
-// for remove unused databases in backup folder
-func removeUnusedBackupDatabase(filePath string) error {
-	strs := strings.Split(filePath, "/")
-
-	//Get latest epoch
-	latestEpoch, err := strconv.Atoi(strs[len(strs)-1])
-	if err != nil {
-		return err
-	}
-
-	//Get path directory of this file
-	path := filePath
-	for i := len(path) - 1; i > -1; i-- {
-		if path[i] != '/' {
-			path = path[:len(path)-1]
-		} else {
-			log.Println(1)
-			break
-		}
-	}
-
-	//Get needed epoch to download
-	files, err := ioutil.ReadDir(path)
-	if err != nil {
-		return err
-	}
-	if len(files) == 0 {
-		return err
-	}
-
-	//Get file name and compare with latest epoch
-	for _, file := range files {
-
-		epoch, err := strconv.Atoi(file.Name())
-		if err != nil {
-			return err
-		}
-
-		if epoch != latestEpoch && epoch != latestEpoch-1 {
-			name := path + "/" + file.Name()
-			err = os.Remove(name)
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
-
-//Uncompress file from zip file
-func uncompress(srcPath, desPath string) error {
-
-	//uncompress write
-	//Remove all old data
-	// if err := os.RemoveAll(srcPath); err != nil {
-	// 	panic(err)
-	// }
-	fmt.Println("start decompress", srcPath)
-	if err := os.RemoveAll(desPath); err != nil {
-		panic(err)
-	}
-	//Create new data
-	if err := os.MkdirAll(desPath, 0700); err != nil {
-		panic(err)
-	}
-
-	err := common.DecompressDatabaseBackup(srcPath, desPath)
-	if err != nil {
-		return err
-	}
-
-	fmt.Println("done decompress", desPath)
-	return nil
+	return incdb.ClearDatabaseDirectory(db.dbPath)
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/incognitochain/incognito-chain/databasemp"
 	_ "github.com/incognitochain/incognito-chain/databasemp/lvdb"
 	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/badgerdb"
 	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
 	"github.com/incognitochain/incognito-chain/limits"
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
@@ -92,10 +93,14 @@ func mainMaster(serverChan chan<- *Server) error {
 	if interruptRequested(interrupt) {
 		return nil
 	}
-	db, err := incdb.OpenMultipleDB("leveldb", filepath.Join(cfg.DataDir, cfg.DatabaseDir))
+	chainDBDrivers, err := cfg.GetChainDatabaseDrivers()
+	if err != nil {
+		return err
+	}
+	db, err := incdb.OpenMultipleDBWithDrivers(cfg.DatabaseDriver, chainDBDrivers, filepath.Join(cfg.DataDir, cfg.DatabaseDir))
 	// Create db and use it.
 	if err != nil {
-		Logger.log.Error("could not open connection to leveldb")
+		Logger.log.Error("could not open connection to chain database")
 		Logger.log.Error(err)
 		panic(err)
 	}
@@ -0,0 +1,341 @@
+package lightclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/syncker"
+	"github.com/incognitochain/incognito-chain/wire"
+)
+
+const (
+	shardBlockCacheSize = 100
+	peerStateTimeout    = 30 * time.Second
+)
+
+var errFork = errors.New("beacon block on another branch than the blocks not final")
+
+type Server interface {
+	RequestBeaconBlocksViaStream(ctx context.Context, peerID string, from uint64, to uint64) (blockCh chan common.BlockInterface, err error)
+	RequestShardBlocksViaStream(ctx context.Context, peerID string, fromSID int, from uint64, to uint64) (blockCh chan common.BlockInterface, err error)
+}
+
+type ConsensusEngine interface {
+	ValidateProducerSig(block common.BlockInterface, consensusType string) error
+	ValidateBlockCommitteSig(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error
+}
+
+type Config struct {
+	Server          Server
+	ConsensusEngine ConsensusEngine
+	ChainParams     *blockchain.Params
+	DataBase        incdb.Database
+}
+
+// BeaconBlock is what the light client keeps of a beacon block: its header, the signatures of its
+// committee and the states of the shard blocks it confirms
+type BeaconBlock struct {
+	Header         blockchain.BeaconHeader
+	ValidationData string
+	ShardState     map[byte][]blockchain.ShardState
+}
+
+// state is the last final beacon block of the light client and the committee signing its next block
+type state struct {
+	Height    uint64
+	Hash      common.Hash
+	Epoch     uint64
+	Committee []string
+}
+
+// view is a beacon block verified by the light client
+type view struct {
+	block     *blockchain.BeaconBlock
+	committee []incognitokey.CommitteePublicKey // committee signing the next block
+}
+
+type peerState struct {
+	height    uint64
+	timestamp time.Time
+}
+
+// Client is a light client: it only syncs the beacon blocks, verifies the signatures of their committee
+// and follows the committee changes. The shard blocks confirmed by the beacon blocks are downloaded on
+// demand and checked against the shard block hashes confirmed by the beacon chain
+type Client struct {
+	config Config
+	lock   sync.RWMutex
+	final  *view
+	// verified blocks following the final block, not final yet
+	pending     []*view
+	peers       map[string]peerState
+	peerScorer  *syncker.PeerScorer
+	shardBlocks *lru.Cache
+	cQuit       chan struct{}
+}
+
+func NewClient(config Config) (*Client, error) {
+	client := &Client{
+		config:     config,
+		peers:      make(map[string]peerState),
+		peerScorer: syncker.NewPeerScorer(),
+		cQuit:      make(chan struct{}),
+	}
+	client.shardBlocks, _ = lru.New(shardBlockCacheSize)
+	if err := client.restoreState(); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// restoreState loads the last final block of the light client, the genesis block for an empty database
+func (client *Client) restoreState() error {
+	data, err := rawdbv2.GetLightClientState(client.config.DataBase)
+	if err != nil {
+		genesis := client.config.ChainParams.GenesisBeaconBlock
+		committee, err := blockchain.GetGenesisBeaconCommittee(genesis)
+		if err != nil {
+			return err
+		}
+		client.final = &view{block: genesis, committee: committee}
+		return client.commit([]*view{client.final})
+	}
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	committee, err := incognitokey.CommitteeBase58KeyListToStruct(s.Committee)
+	if err != nil {
+		return err
+	}
+	block, err := client.GetBeaconBlock(s.Height)
+	if err != nil {
+		return err
+	}
+	client.final = &view{
+		block:     &blockchain.BeaconBlock{Header: block.Header, ValidationData: block.ValidationData},
+		committee: committee,
+	}
+	Logger.Infof("Light client restored at beacon height %v hash %v", s.Height, s.Hash.String())
+	return nil
+}
+
+// commit stores the blocks of views, the last one becomes the final block of the light client
+func (client *Client) commit(views []*view) error {
+	batch := client.config.DataBase.NewBatch()
+	for _, v := range views {
+		header := v.block.Header
+		err := rawdbv2.StoreLightBeaconBlock(batch, header.Height, BeaconBlock{
+			Header:         header,
+			ValidationData: v.block.ValidationData,
+			ShardState:     v.block.Body.ShardState,
+		})
+		if err != nil {
+			return err
+		}
+		for shardID, shardStates := range v.block.Body.ShardState {
+			for _, shardState := range shardStates {
+				if err := rawdbv2.StoreLightShardBlockHash(batch, shardID, shardState.Height, shardState.Hash); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	last := views[len(views)-1]
+	committee, err := incognitokey.CommitteeKeyListToString(last.committee)
+	if err != nil {
+		return err
+	}
+	err = rawdbv2.StoreLightClientState(batch, state{
+		Height:    last.block.Header.Height,
+		Hash:      *last.block.Hash(),
+		Epoch:     last.block.Header.Epoch,
+		Committee: committee,
+	})
+	if err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (client *Client) Start() {
+	Logger.Infof("Start light client at beacon height %v", client.GetFinalHeight())
+	go client.syncBeacon()
+}
+
+func (client *Client) Stop() {
+	close(client.cQuit)
+}
+
+// ReceivePeerState records the height of the beacon chain of a peer
+func (client *Client) ReceivePeerState(msg *wire.MessagePeerState) {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	client.peers[msg.SenderID] = peerState{height: msg.Beacon.Height, timestamp: time.Now()}
+}
+
+func (client *Client) getPeerHeights() map[string]uint64 {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	peers := make(map[string]uint64)
+	for peerID, ps := range client.peers {
+		if time.Since(ps.timestamp) > peerStateTimeout {
+			delete(client.peers, peerID)
+			continue
+		}
+		peers[peerID] = ps.height
+	}
+	return peers
+}
+
+func (client *Client) syncBeacon() {
+	for {
+		select {
+		case <-client.cQuit:
+			return
+		default:
+		}
+		peers := client.getPeerHeights()
+		toHeight := uint64(0)
+		for _, height := range peers {
+			if height > toHeight {
+				toHeight = height
+			}
+		}
+		if toHeight <= client.GetBestHeight() {
+			time.Sleep(5 * time.Second)
+			continue
+		}
+		downloader := syncker.NewRangeDownloader(syncker.DefaultDownloaderConfig(), client.peerScorer, client.config.Server.RequestBeaconBlocksViaStream, nil, client.InsertBeaconBlocks)
+		inserted, err := downloader.Download(peers, client.GetBestHeight()+1, toHeight)
+		if err != nil {
+			Logger.Errorf("Light client sync beacon blocks to height %v fail: %v", toHeight, err)
+		}
+		if inserted == 0 {
+			time.Sleep(5 * time.Second)
+		}
+	}
+}
+
+// InsertBeaconBlocks verifies blocks, each one following the one before it, and returns the number of blocks verified.
+// A block is stored once it is final
+func (client *Client) InsertBeaconBlocks(blocks []common.BlockInterface) (int, error) {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	for i, blk := range blocks {
+		beaconBlock, ok := blk.(*blockchain.BeaconBlock)
+		if !ok {
+			return i, blockchain.NewInvalidBlockError(errors.New("not a beacon block"))
+		}
+		if err := client.insertBeaconBlock(beaconBlock); err != nil {
+			if err == errFork {
+				//stop this download, it starts again from the final block
+				return i, nil
+			}
+			return i, err
+		}
+	}
+	return len(blocks), nil
+}
+
+func (client *Client) tip() *view {
+	if len(client.pending) > 0 {
+		return client.pending[len(client.pending)-1]
+	}
+	return client.final
+}
+
+func (client *Client) insertBeaconBlock(beaconBlock *blockchain.BeaconBlock) error {
+	tip := client.tip()
+	if beaconBlock.Header.Height <= tip.block.Header.Height {
+		return nil
+	}
+	if beaconBlock.Header.Height != tip.block.Header.Height+1 {
+		return fmt.Errorf("expect beacon block height %v but get %v", tip.block.Header.Height+1, beaconBlock.Header.Height)
+	}
+	if beaconBlock.Header.PreviousBlockHash != *tip.block.Hash() {
+		//another branch, the blocks not final are synced again
+		if len(client.pending) > 0 {
+			client.pending = nil
+			return errFork
+		}
+		return fmt.Errorf("beacon block %v does not follow the final block %v", beaconBlock.Hash().String(), tip.block.Hash().String())
+	}
+	if err := blockchain.VerifyBeaconBlockBody(beaconBlock); err != nil {
+		return blockchain.NewInvalidBlockError(err)
+	}
+	if err := client.config.ConsensusEngine.ValidateProducerSig(beaconBlock, common.BlsConsensus); err != nil {
+		return blockchain.NewInvalidBlockError(err)
+	}
+	if err := client.config.ConsensusEngine.ValidateBlockCommitteSig(beaconBlock, tip.committee); err != nil {
+		return blockchain.NewInvalidBlockError(err)
+	}
+	committee, err := blockchain.ProcessBeaconCommitteeInstructions(tip.committee, beaconBlock, client.config.ChainParams)
+	if err != nil {
+		return blockchain.NewInvalidBlockError(err)
+	}
+	newView := &view{block: beaconBlock, committee: committee}
+	client.pending = append(client.pending, newView)
+
+	//the previous block is final as in the multiview
+	isPrevFinal := beaconBlock.GetVersion() == 1 ||
+		common.CalculateTimeSlot(tip.block.GetProposeTime())+1 == common.CalculateTimeSlot(beaconBlock.GetProposeTime())
+	if isPrevFinal && len(client.pending) > 1 {
+		finalViews := client.pending[:len(client.pending)-1]
+		if err := client.commit(finalViews); err != nil {
+			return err
+		}
+		client.final = finalViews[len(finalViews)-1]
+		client.pending = []*view{newView}
+	}
+	return nil
+}
+
+// GetFinalHeight returns the height of the last final beacon block
+func (client *Client) GetFinalHeight() uint64 {
+	client.lock.RLock()
+	defer client.lock.RUnlock()
+	return client.final.block.Header.Height
+}
+
+// GetBestHeight returns the height of the last verified beacon block
+func (client *Client) GetBestHeight() uint64 {
+	client.lock.RLock()
+	defer client.lock.RUnlock()
+	return client.tip().block.Header.Height
+}
+
+// GetFinalBlock returns the last final beacon block and the committee signing its next block
+func (client *Client) GetFinalBlock() (*BeaconBlock, []incognitokey.CommitteePublicKey) {
+	client.lock.RLock()
+	defer client.lock.RUnlock()
+	block := client.final.block
+	return &BeaconBlock{
+		Header:         block.Header,
+		ValidationData: block.ValidationData,
+		ShardState:     block.Body.ShardState,
+	}, client.final.committee
+}
+
+// GetBeaconBlock returns the final beacon block of height
+func (client *Client) GetBeaconBlock(height uint64) (*BeaconBlock, error) {
+	data, err := rawdbv2.GetLightBeaconBlock(client.config.DataBase, height)
+	if err != nil {
+		return nil, err
+	}
+	block := &BeaconBlock{}
+	if err := json.Unmarshal(data, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
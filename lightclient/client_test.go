@@ -0,0 +1,316 @@
+package lightclient
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/syncker"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	syncker.Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+// lightNetwork is a beacon chain confirming one shard block per beacon block, served to the light client
+type lightNetwork struct {
+	params       *blockchain.Params
+	keys         []string                  // the first 4 keys are the genesis committee
+	beaconBlocks []*blockchain.BeaconBlock // beaconBlocks[i] is the block of height i+1
+	shardBlocks  map[uint64]*blockchain.ShardBlock
+	forgedShard  bool
+	// peer sending a beacon block with a forged committee signature at height forgedHeight
+	forgedPeer   string
+	forgedHeight uint64
+	// committee used to check the signatures of each beacon block
+	checkedCommittee map[uint64][]string
+}
+
+func newLightNetwork(n int, instructions func(keys []string) map[uint64][][]string) *lightNetwork {
+	keys := []string{}
+	for i := 0; i < 6; i++ {
+		key := incognitokey.CommitteePublicKey{IncPubKey: []byte{byte(i)}}
+		keyStr, _ := key.ToBase58()
+		keys = append(keys, keyStr)
+	}
+	genesis := blockchain.NewBeaconBlock()
+	genesis.Header.Version = 1
+	genesis.Header.Height = 1
+	genesis.Header.Epoch = 1
+	genesis.Body.Instructions = [][]string{{blockchain.StakeAction, strings.Join(keys[:4], ","), "beacon", "", "", ""}}
+	params := blockchain.ChainTestParam
+	params.Epoch = 100
+	params.GenesisBeaconBlock = genesis
+	network := &lightNetwork{
+		params:           &params,
+		keys:             keys,
+		beaconBlocks:     []*blockchain.BeaconBlock{genesis},
+		shardBlocks:      make(map[uint64]*blockchain.ShardBlock),
+		checkedCommittee: make(map[uint64][]string),
+	}
+	for height := uint64(2); height <= uint64(n); height++ {
+		shardBlock := blockchain.NewShardBlock()
+		shardBlock.Header.Height = height - 1
+		for i := 0; i < int(height%4); i++ {
+			shardBlock.Body.Transactions = append(shardBlock.Body.Transactions, &transaction.Tx{LockTime: int64(height*10) + int64(i)})
+		}
+		if len(shardBlock.Body.Transactions) > 0 {
+			merkleTree := blockchain.Merkle{}.BuildMerkleTreeStore(shardBlock.Body.Transactions)
+			shardBlock.Header.TxRoot = *merkleTree[len(merkleTree)-1]
+		}
+		network.shardBlocks[shardBlock.Header.Height] = shardBlock
+
+		prev := network.beaconBlocks[len(network.beaconBlocks)-1]
+		beaconBlock := blockchain.NewBeaconBlock()
+		beaconBlock.Header.Version = 1
+		beaconBlock.Header.Height = height
+		beaconBlock.Header.Epoch = 1
+		beaconBlock.Header.PreviousBlockHash = *prev.Hash()
+		beaconBlock.Body.ShardState = map[byte][]blockchain.ShardState{
+			0: {{Height: shardBlock.Header.Height, Hash: *shardBlock.Hash()}},
+		}
+		if instructions != nil {
+			beaconBlock.Body.Instructions = instructions(keys)[height]
+		}
+		beaconBlock.Header.ShardStateHash = hashShardState(beaconBlock.Body.ShardState[0])
+		beaconBlock.Header.InstructionHash = hashInstructions(beaconBlock.Body.Instructions)
+		network.beaconBlocks = append(network.beaconBlocks, beaconBlock)
+	}
+	return network
+}
+
+func hashStrings(strs []string) common.Hash {
+	if len(strs) == 0 {
+		return common.Hash{}
+	}
+	return common.HashH([]byte(strings.Join(strs, "")))
+}
+
+func hashShardState(shardStates []blockchain.ShardState) common.Hash {
+	res := ""
+	for _, shardState := range shardStates {
+		res += strconv.Itoa(int(shardState.Height)) + shardState.Hash.String() + "null"
+	}
+	return hashStrings([]string{res})
+}
+
+func hashInstructions(instructions [][]string) common.Hash {
+	strs := []string{}
+	for _, instruction := range instructions {
+		strs = append(strs, instruction...)
+	}
+	return hashStrings(strs)
+}
+
+func (network *lightNetwork) RequestBeaconBlocksViaStream(ctx context.Context, peerID string, from uint64, to uint64) (chan common.BlockInterface, error) {
+	ch := make(chan common.BlockInterface, to-from+1)
+	for height := from; height <= to && height <= uint64(len(network.beaconBlocks)); height++ {
+		if peerID == network.forgedPeer && height == network.forgedHeight {
+			forged := *network.beaconBlocks[height-1]
+			forged.ValidationData = "forged"
+			ch <- &forged
+			continue
+		}
+		ch <- network.beaconBlocks[height-1]
+	}
+	close(ch)
+	return ch, nil
+}
+
+func (network *lightNetwork) RequestShardBlocksViaStream(ctx context.Context, peerID string, fromSID int, from uint64, to uint64) (chan common.BlockInterface, error) {
+	ch := make(chan common.BlockInterface, 1)
+	shardBlock, ok := network.shardBlocks[from]
+	if ok {
+		if network.forgedShard {
+			forged := *shardBlock
+			forged.Body.Transactions = append([]metadata.Transaction{&transaction.Tx{LockTime: 1}}, forged.Body.Transactions...)
+			shardBlock = &forged
+		}
+		ch <- shardBlock
+	}
+	close(ch)
+	return ch, nil
+}
+
+func (network *lightNetwork) ValidateProducerSig(block common.BlockInterface, consensusType string) error {
+	return nil
+}
+
+func (network *lightNetwork) ValidateBlockCommitteSig(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error {
+	if block.(*blockchain.BeaconBlock).ValidationData == "forged" {
+		return errors.New("invalid committee signature")
+	}
+	committeeStr, err := incognitokey.CommitteeKeyListToString(committee)
+	if err != nil {
+		return err
+	}
+	network.checkedCommittee[block.GetHeight()] = committeeStr
+	return nil
+}
+
+func newTestClient(t *testing.T, network *lightNetwork) (*Client, incdb.Database, func()) {
+	dir, err := ioutil.TempDir("", "lightclient")
+	assert.Nil(t, err)
+	db, err := incdb.Open("leveldb", dir)
+	assert.Nil(t, err)
+	client, err := NewClient(Config{
+		Server:          network,
+		ConsensusEngine: network,
+		ChainParams:     network.params,
+		DataBase:        db,
+	})
+	assert.Nil(t, err)
+	return client, db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func newPeerState(peerID string, height uint64) *wire.MessagePeerState {
+	return &wire.MessagePeerState{SenderID: peerID, Beacon: wire.ChainState{Height: height}}
+}
+
+func (network *lightNetwork) blocks(from uint64, to uint64) []common.BlockInterface {
+	blocks := []common.BlockInterface{}
+	for height := from; height <= to; height++ {
+		blocks = append(blocks, network.beaconBlocks[height-1])
+	}
+	return blocks
+}
+
+func TestClient_InsertBeaconBlocks(t *testing.T) {
+	//the first key of the committee is swapped out at height 5
+	network := newLightNetwork(10, func(keys []string) map[uint64][][]string {
+		return map[uint64][][]string{
+			5: {{blockchain.SwapAction, keys[4], keys[0], "beacon", "", "", ""}},
+		}
+	})
+	client, _, closeDB := newTestClient(t, network)
+	defer closeDB()
+
+	inserted, err := client.InsertBeaconBlocks(network.blocks(2, 10))
+	assert.Nil(t, err)
+	assert.Equal(t, 9, inserted)
+	assert.Equal(t, uint64(10), client.GetBestHeight())
+	//a version 1 block makes its previous block final
+	assert.Equal(t, uint64(9), client.GetFinalHeight())
+
+	assert.Equal(t, network.keys[:4], network.checkedCommittee[5])
+	swappedCommittee := []string{network.keys[4], network.keys[1], network.keys[2], network.keys[3]}
+	assert.Equal(t, swappedCommittee, network.checkedCommittee[6])
+	assert.Equal(t, swappedCommittee, network.checkedCommittee[10])
+
+	block, err := client.GetBeaconBlock(7)
+	assert.Nil(t, err)
+	assert.Equal(t, *network.beaconBlocks[6].Hash(), block.Header.Hash())
+	_, err = client.GetBeaconBlock(10)
+	assert.NotNil(t, err)
+}
+
+func TestClient_InsertBeaconBlocksInvalid(t *testing.T) {
+	network := newLightNetwork(6, nil)
+	client, _, closeDB := newTestClient(t, network)
+	defer closeDB()
+
+	forged := *network.beaconBlocks[3]
+	forged.ValidationData = "forged"
+	inserted, err := client.InsertBeaconBlocks([]common.BlockInterface{network.beaconBlocks[1], network.beaconBlocks[2], &forged})
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, inserted)
+	assert.Equal(t, uint64(3), client.GetBestHeight())
+
+	//a block with a body not matching its header
+	tampered := *network.beaconBlocks[3]
+	tampered.Body.ShardState = map[byte][]blockchain.ShardState{}
+	_, err = client.InsertBeaconBlocks([]common.BlockInterface{&tampered})
+	assert.NotNil(t, err)
+
+	//a block not following the last block
+	_, err = client.InsertBeaconBlocks([]common.BlockInterface{network.beaconBlocks[4]})
+	assert.NotNil(t, err)
+	assert.Equal(t, uint64(3), client.GetBestHeight())
+}
+
+func TestClient_SyncBeaconBanInvalid(t *testing.T) {
+	network := newLightNetwork(10, nil)
+	network.forgedPeer, network.forgedHeight = "peer-0", 4
+	client, _, closeDB := newTestClient(t, network)
+	defer closeDB()
+
+	config := syncker.DownloaderConfig{ChunkSize: 3, MaxPeers: 2}
+	downloader := syncker.NewRangeDownloader(config, client.peerScorer, network.RequestBeaconBlocksViaStream, nil, client.InsertBeaconBlocks)
+	_, err := downloader.Download(map[string]uint64{"peer-0": 10, "peer-1": 10}, 2, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(10), client.GetBestHeight())
+	//the peer sending a block with a bad committee signature is banned
+	assert.True(t, client.peerScorer.IsBanned("peer-0"))
+	assert.False(t, client.peerScorer.IsBanned("peer-1"))
+}
+
+func TestClient_Restore(t *testing.T) {
+	network := newLightNetwork(6, nil)
+	client, db, closeDB := newTestClient(t, network)
+	defer closeDB()
+	_, err := client.InsertBeaconBlocks(network.blocks(2, 6))
+	assert.Nil(t, err)
+
+	restored, err := NewClient(Config{Server: network, ConsensusEngine: network, ChainParams: network.params, DataBase: db})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), restored.GetFinalHeight())
+	inserted, err := restored.InsertBeaconBlocks(network.blocks(6, 6))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, inserted)
+}
+
+func TestClient_GetTransactionProof(t *testing.T) {
+	network := newLightNetwork(10, nil)
+	client, _, closeDB := newTestClient(t, network)
+	defer closeDB()
+	_, err := client.InsertBeaconBlocks(network.blocks(2, 10))
+	assert.Nil(t, err)
+	client.ReceivePeerState(newPeerState("peer-0", 10))
+
+	shardBlock := network.shardBlocks[6]
+	for _, tx := range shardBlock.Body.Transactions {
+		proof, err := client.GetTransactionProof(0, 6, *tx.Hash())
+		assert.Nil(t, err)
+		assert.True(t, proof.Verify())
+		assert.Equal(t, *shardBlock.Hash(), proof.BlockHash)
+	}
+	_, err = client.GetTransactionProof(0, 6, common.HashH([]byte("unknown")))
+	assert.NotNil(t, err)
+
+	//shard block 9 is confirmed by beacon block 10, not final yet
+	_, err = client.GetShardBlock(0, 9)
+	assert.NotNil(t, err)
+
+	//a shard block with transactions not matching its header is rejected
+	network.forgedShard = true
+	_, err = client.GetShardBlock(0, 7)
+	assert.NotNil(t, err)
+	assert.True(t, client.peerScorer.IsBanned("peer-0"))
+}
+
+func TestVerifyShardBlock(t *testing.T) {
+	network := newLightNetwork(4, nil)
+	shardBlock := network.shardBlocks[3]
+	assert.Nil(t, verifyShardBlock(shardBlock, *shardBlock.Hash()))
+	assert.NotNil(t, verifyShardBlock(shardBlock, common.Hash{}))
+	tampered := *shardBlock
+	tampered.Body.Transactions = []metadata.Transaction{&transaction.Tx{LockTime: 1}}
+	assert.NotNil(t, verifyShardBlock(&tampered, *shardBlock.Hash()))
+}
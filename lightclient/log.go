@@ -0,0 +1,14 @@
+package lightclient
+
+import "github.com/incognitochain/incognito-chain/common"
+
+type LightClientLogger struct {
+	common.Logger
+}
+
+func (self *LightClientLogger) Init(inst common.Logger) {
+	self.Logger = inst
+}
+
+// Global instant to use
+var Logger = LightClientLogger{}
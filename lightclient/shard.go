@@ -0,0 +1,192 @@
+package lightclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+const shardBlockTimeout = 10 * time.Second
+
+// TxProof proves that Tx is in the shard block of BlockHash, confirmed by the beacon chain:
+// MerklePath links the hash of Tx at Index to the TxRoot of the block header
+type TxProof struct {
+	ShardID     byte
+	BlockHeight uint64
+	BlockHash   common.Hash
+	TxRoot      common.Hash
+	Index       int
+	MerklePath  []common.Hash
+	Tx          metadata.Transaction
+}
+
+// OutputCoin is an output coin of a transaction and the proof of this transaction
+type OutputCoin struct {
+	TokenID common.Hash
+	Coin    *privacy.OutputCoin
+	Proof   *TxProof
+}
+
+// GetShardBlock downloads the shard block of height from the peers. The block must be the one confirmed
+// by a final beacon block, with a transaction root built from its transactions
+func (client *Client) GetShardBlock(shardID byte, height uint64) (*blockchain.ShardBlock, error) {
+	confirmedHash, err := rawdbv2.GetLightShardBlockHash(client.config.DataBase, shardID, height)
+	if err != nil {
+		return nil, fmt.Errorf("shard %v block %v is not confirmed by a final beacon block", shardID, height)
+	}
+	if blk, ok := client.shardBlocks.Get(*confirmedHash); ok {
+		return blk.(*blockchain.ShardBlock), nil
+	}
+	peers := []string{}
+	for peerID := range client.getPeerHeights() {
+		peers = append(peers, peerID)
+	}
+	for _, peerID := range client.peerScorer.Rank(peers) {
+		start := time.Now()
+		shardBlock, err := client.requestShardBlock(peerID, shardID, height)
+		if err != nil {
+			Logger.Debugf("Get shard %v block %v from peer %v fail: %v", shardID, height, peerID, err)
+			client.peerScorer.RecordTimeout(peerID)
+			continue
+		}
+		if err := verifyShardBlock(shardBlock, *confirmedHash); err != nil {
+			Logger.Errorf("Peer %v sent an invalid shard %v block %v: %v", peerID, shardID, height, err)
+			client.peerScorer.RecordInvalid(peerID)
+			continue
+		}
+		client.peerScorer.RecordSuccess(peerID, 1, time.Since(start))
+		client.shardBlocks.Add(*confirmedHash, shardBlock)
+		return shardBlock, nil
+	}
+	return nil, fmt.Errorf("no peer sent the shard %v block %v", shardID, height)
+}
+
+func (client *Client) requestShardBlock(peerID string, shardID byte, height uint64) (*blockchain.ShardBlock, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shardBlockTimeout)
+	defer cancel()
+	ch, err := client.config.Server.RequestShardBlocksViaStream(ctx, peerID, int(shardID), height, height)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case blk := <-ch:
+		shardBlock, ok := blk.(*blockchain.ShardBlock)
+		if !ok || shardBlock == nil {
+			return nil, errors.New("no shard block received")
+		}
+		return shardBlock, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// verifyShardBlock checks shardBlock against the hash confirmed by the beacon chain, and its transactions
+// against the transaction root of its header
+func verifyShardBlock(shardBlock *blockchain.ShardBlock, confirmedHash common.Hash) error {
+	if !shardBlock.Hash().IsEqual(&confirmedHash) {
+		return fmt.Errorf("expect shard block hash %v but get %v", confirmedHash.String(), shardBlock.Hash().String())
+	}
+	txRoot := common.Hash{}
+	if len(shardBlock.Body.Transactions) > 0 {
+		merkleRoots := blockchain.Merkle{}.BuildMerkleTreeStore(shardBlock.Body.Transactions)
+		txRoot = *merkleRoots[len(merkleRoots)-1]
+	}
+	if !txRoot.IsEqual(&shardBlock.Header.TxRoot) {
+		return fmt.Errorf("expect tx root %v but get %v", shardBlock.Header.TxRoot.String(), txRoot.String())
+	}
+	return nil
+}
+
+// GetTransactionProof returns the transaction txHash of the shard block of height and its proof
+func (client *Client) GetTransactionProof(shardID byte, height uint64, txHash common.Hash) (*TxProof, error) {
+	shardBlock, err := client.GetShardBlock(shardID, height)
+	if err != nil {
+		return nil, err
+	}
+	for index, tx := range shardBlock.Body.Transactions {
+		if tx.Hash().IsEqual(&txHash) {
+			return newTxProof(shardBlock, index)
+		}
+	}
+	return nil, fmt.Errorf("transaction %v not in shard %v block %v", txHash.String(), shardID, height)
+}
+
+func newTxProof(shardBlock *blockchain.ShardBlock, index int) (*TxProof, error) {
+	merklePath, err := blockchain.Merkle{}.GetMerklePathForTx(shardBlock.Body.Transactions, index)
+	if err != nil {
+		return nil, err
+	}
+	return &TxProof{
+		ShardID:     shardBlock.Header.ShardID,
+		BlockHeight: shardBlock.Header.Height,
+		BlockHash:   *shardBlock.Hash(),
+		TxRoot:      shardBlock.Header.TxRoot,
+		Index:       index,
+		MerklePath:  merklePath,
+		Tx:          shardBlock.Body.Transactions[index],
+	}, nil
+}
+
+// Verify checks the transaction of proof against its transaction root
+func (proof *TxProof) Verify() bool {
+	return blockchain.Merkle{}.VerifyMerklePathForTx(*proof.Tx.Hash(), proof.Index, proof.MerklePath, proof.TxRoot)
+}
+
+// GetOutputCoins returns the output coins of publicKey created by the transactions of the shard blocks
+// from fromHeight to toHeight, PRV and privacy tokens.
+// The coins received from the other shards are not returned: their cross shard blocks are not confirmed by the light client
+func (client *Client) GetOutputCoins(shardID byte, fromHeight uint64, toHeight uint64, publicKey []byte) ([]OutputCoin, error) {
+	outputCoins := []OutputCoin{}
+	for height := fromHeight; height <= toHeight; height++ {
+		shardBlock, err := client.GetShardBlock(shardID, height)
+		if err != nil {
+			return nil, err
+		}
+		for index, tx := range shardBlock.Body.Transactions {
+			coins := filterOutputCoins(tx.GetProof(), common.PRVCoinID, publicKey)
+			if tx.GetType() == common.TxCustomTokenPrivacyType {
+				customTokenTx := tx.(*transaction.TxCustomTokenPrivacy)
+				tokenData := customTokenTx.TxPrivacyTokenData
+				coins = append(coins, filterOutputCoins(tokenData.TxNormal.GetProof(), tokenData.PropertyID, publicKey)...)
+			}
+			if len(coins) == 0 {
+				continue
+			}
+			proof, err := newTxProof(shardBlock, index)
+			if err != nil {
+				return nil, err
+			}
+			for i := range coins {
+				coins[i].Proof = proof
+			}
+			outputCoins = append(outputCoins, coins...)
+		}
+	}
+	return outputCoins, nil
+}
+
+func filterOutputCoins(proof *zkp.PaymentProof, tokenID common.Hash, publicKey []byte) []OutputCoin {
+	coins := []OutputCoin{}
+	if proof == nil {
+		return coins
+	}
+	for _, outCoin := range proof.GetOutputCoins() {
+		if outCoin.CoinDetails == nil || outCoin.CoinDetails.GetPublicKey() == nil {
+			continue
+		}
+		if bytes.Equal(outCoin.CoinDetails.GetPublicKey().ToBytesS(), publicKey) {
+			coins = append(coins, OutputCoin{TokenID: tokenID, Coin: outCoin})
+		}
+	}
+	return coins
+}
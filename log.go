@@ -9,6 +9,7 @@ import (
 	relaying "github.com/incognitochain/incognito-chain/relaying/bnb"
 	btcRelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 
+	"github.com/incognitochain/incognito-chain/lightclient"
 	"github.com/incognitochain/incognito-chain/syncker"
 
 	"github.com/incognitochain/incognito-chain/addrmanager"
@@ -66,6 +67,7 @@ var (
 	daov2Logger            = backendLog.Logger("DAO log", false)
 	btcRelayingLogger      = backendLog.Logger("BTC relaying log", false)
 	synckerLogger          = backendLog.Logger("Syncker log ", false)
+	lightClientLogger      = backendLog.Logger("Light client log", false)
 )
 
 // logWriter implements an io.Writer that outputs to both standard output and
@@ -109,6 +111,7 @@ func init() {
 	dataaccessobject.Logger.Init(daov2Logger)
 	btcRelaying.Logger.Init(btcRelayingLogger)
 	syncker.Logger.Init(synckerLogger)
+	lightclient.Logger.Init(lightClientLogger)
 }
 
 // subsystemLoggers maps each subsystem identifier to its associated logger.
@@ -138,6 +141,7 @@ var subsystemLoggers = map[string]common.Logger{
 	"DAO":               daov2Logger,
 	"BTCRELAYING":       btcRelayingLogger,
 	"SYNCKER":           synckerLogger,
+	"LIGHTCLIENT":       lightClientLogger,
 }
 
 // initLogRotator initializes the logging rotater to write logs to logFile and
@@ -821,3 +821,38 @@ func (ef FeeEstimator) GetLimitFeeForNativeToken() uint64 {
 
 	return limitFee
 }
+
+// ConvertTokenFeeRateToNativeToken converts a fee rate paid in tokenID into PRV
+// with the ratio between the average PRV and token fee rates of mined txs
+func (ef *FeeEstimator) ConvertTokenFeeRateToNativeToken(feeRate CoinPerKilobyte, tokenID *common.Hash) (CoinPerKilobyte, error) {
+	ef.mtx.RLock()
+	defer ef.mtx.RUnlock()
+
+	if ef.numBlocksRegistered < ef.minRegisteredBlocks {
+		return 0, errors.New("not enough blocks have been observed")
+	}
+	if tokenID == nil {
+		return feeRate, nil
+	}
+
+	totalFeeRate, numTxs := float64(0), 0
+	totalTokenFeeRate, numTokenTxs := float64(0), 0
+	for _, b := range ef.bin {
+		for _, o := range b {
+			if o.feeRate > 0 {
+				totalFeeRate += float64(o.feeRate)
+				numTxs++
+			}
+			if tokenFeeRate, ok := o.feeRateForToken[*tokenID]; ok && tokenFeeRate > 0 {
+				totalTokenFeeRate += float64(tokenFeeRate)
+				numTokenTxs++
+			}
+		}
+	}
+	if numTxs == 0 || numTokenTxs == 0 {
+		return 0, fmt.Errorf("no fee rate has been observed for token %+v", tokenID.String())
+	}
+	averageFeeRate := totalFeeRate / float64(numTxs)
+	averageTokenFeeRate := totalTokenFeeRate / float64(numTokenTxs)
+	return CoinPerKilobyte(float64(feeRate) * averageFeeRate / averageTokenFeeRate), nil
+}
@@ -45,6 +45,8 @@ type Config struct {
 	// UserKeyset            *incognitokey.KeySet
 	PubSubManager         *pubsub.PubSubManager
 	RoleInCommitteesEvent pubsub.EventChannel
+	ReinjectOrphanedTxs   bool // put back the transactions of the shard blocks dropped by a reorg
+	ReorgEvent            pubsub.EventChannel
 }
 
 // TxDesc is transaction message in mempool
@@ -52,6 +54,7 @@ type TxDesc struct {
 	Desc            metadata.TxDesc // transaction details
 	StartTime       time.Time       //Unix Time that transaction enter mempool
 	IsFowardMessage bool
+	FeePerKB        CoinPerKilobyte // fee per KB with token fee converted to PRV, priority of tx in pool
 }
 
 type TxPool struct {
@@ -61,6 +64,7 @@ type TxPool struct {
 	pool                      map[common.Hash]*TxDesc
 	poolSerialNumbersHashList map[common.Hash][]common.Hash // [txHash] -> list hash serialNumbers of input coin
 	poolSerialNumberHash      map[common.Hash]common.Hash   // [hash from list of serialNumber] -> txHash
+	poolFeeIndex              []feeIndexEntry               // txs in pool ordered by fee per KB, lowest first
 	mtx                       sync.RWMutex
 	poolCandidate             map[common.Hash]string //Candidate List in mempool
 	candidateMtx              sync.RWMutex
@@ -88,11 +92,16 @@ func (tp *TxPool) Init(cfg *Config) {
 	tp.pool = make(map[common.Hash]*TxDesc)
 	tp.poolSerialNumbersHashList = make(map[common.Hash][]common.Hash)
 	tp.poolSerialNumberHash = make(map[common.Hash]common.Hash)
+	tp.poolFeeIndex = []feeIndexEntry{}
 	tp.poolCandidate = make(map[common.Hash]string)
 	tp.poolRequestStopStaking = make(map[common.Hash]string)
 	tp.duplicateTxs = make(map[common.Hash]uint64)
 	_, subChanRole, _ := tp.config.PubSubManager.RegisterNewSubscriber(pubsub.ShardRoleTopic)
 	tp.config.RoleInCommitteesEvent = subChanRole
+	if tp.config.ReinjectOrphanedTxs {
+		_, subChanReorg, _ := tp.config.PubSubManager.RegisterNewSubscriber(pubsub.ReorgTopic)
+		tp.config.ReorgEvent = subChanReorg
+	}
 	tp.ScanTime = defaultScanTime
 	tp.IsUnlockMempool = defaultIsUnlockMempool
 	tp.IsBlockGenStarted = defaultIsBlockGenStarted
@@ -156,10 +165,53 @@ func (tp *TxPool) Start(cQuit chan struct{}) {
 					tp.RoleInCommittees = shardID
 				}()
 			}
+		case msg := <-tp.config.ReorgEvent:
+			{
+				event, ok := msg.Value.(*blockchain.ReorgEvent)
+				if !ok || event.ChainID < 0 {
+					continue
+				}
+				go tp.reinjectOrphanedTxs(event)
+			}
 		}
 	}
 }
 
+// reinjectOrphanedTxs puts back into the pool the transactions of the shard blocks dropped by a reorg,
+// oldest block first. The transactions already in the new branch are rejected as double spends
+func (tp *TxPool) reinjectOrphanedTxs(event *blockchain.ReorgEvent) {
+	beaconHeight := int64(tp.config.BlockChain.GetBeaconBestState().BeaconHeight)
+	reinjected := 0
+	for i := len(event.Blocks) - 1; i >= 0; i-- {
+		shardBlock, ok := event.Blocks[i].(*blockchain.ShardBlock)
+		if !ok {
+			continue
+		}
+		for _, tx := range getOrphanedTxs(shardBlock) {
+			if _, _, err := tp.MaybeAcceptTransaction(tx, beaconHeight); err == nil {
+				reinjected++
+			}
+		}
+	}
+	Logger.log.Infof("Reinject %+v transactions of %+v shard %+v blocks dropped by reorg to %+v", reinjected, len(event.Blocks), event.ChainID, event.NewTip)
+}
+
+// getOrphanedTxs returns the transactions of a dropped shard block which can be put back into the pool,
+// the salary and response transactions are created by the block producer
+func getOrphanedTxs(shardBlock *blockchain.ShardBlock) []metadata.Transaction {
+	txs := []metadata.Transaction{}
+	for _, tx := range shardBlock.Body.Transactions {
+		if tx.IsSalaryTx() || tx.GetType() == common.TxReturnStakingType || tx.GetType() == common.TxRewardType {
+			continue
+		}
+		if tx.GetMetadata() != nil && tx.GetMetadata().IsMinerCreatedMetaType() {
+			continue
+		}
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
 func (tp *TxPool) MonitorPool() {
 	if tp.config.TxLifeTime == 0 {
 		return
@@ -226,8 +278,13 @@ func (tp *TxPool) MaybeAcceptTransaction(tx metadata.Transaction, beaconHeight i
 	beaconView := tp.config.BlockChain.BeaconChain.GetFinalView().(*blockchain.BeaconBestState)
 	shardView := tp.config.BlockChain.ShardChain[senderShardID].GetBestView().(*blockchain.ShardBestState)
 	//==========
-	if uint64(len(tp.pool)) >= tp.config.MaxTx {
-		return nil, nil, NewMempoolTxError(MaxPoolSizeError, errors.New("Pool reach max number of transaction"))
+	// when pool is full, only accept tx paying more than the lowest fee tx in pool, which will be evicted
+	isPoolFull := uint64(len(tp.pool)) >= tp.config.MaxTx
+	if isPoolFull {
+		lowestFeePerKB, ok := tp.lowestFeePerKB()
+		if !ok || tp.calculateFeePerKB(tx, tx.GetTxFee(), tx.GetTxFeeToken()) <= lowestFeePerKB {
+			return nil, nil, NewMempoolTxError(MaxPoolSizeError, errors.New("Pool reach max number of transaction"))
+		}
 	}
 	if tx.GetType() == common.TxReturnStakingType{
 		return &common.Hash{}, &TxDesc{}, NewMempoolTxError(RejectInvalidTx, fmt.Errorf("%+v is a return staking tx", tx.Hash().String()))
@@ -246,6 +303,9 @@ func (tp *TxPool) MaybeAcceptTransaction(tx metadata.Transaction, beaconHeight i
 	if err != nil {
 		Logger.log.Error(err)
 	} else {
+		if isPoolFull {
+			tp.evictLowestFeeTxs()
+		}
 		if tp.IsBlockGenStarted {
 			if tp.IsUnlockMempool {
 				go func(tx metadata.Transaction) {
@@ -691,6 +751,7 @@ func (tp *TxPool) TriggerCRemoveTxs(tx metadata.Transaction) {
 func (tp *TxPool) addTx(txD *TxDesc, isStore bool) error {
 	tx := txD.Desc.Tx
 	txHash := tx.Hash()
+	txD.FeePerKB = tp.calculateFeePerKB(tx, txD.Desc.Fee, txD.Desc.FeeToken)
 	if isStore {
 		err := tp.addTransactionToDatabaseMempool(txHash, *txD)
 		if err != nil {
@@ -700,6 +761,7 @@ func (tp *TxPool) addTx(txD *TxDesc, isStore bool) error {
 		}
 	}
 	tp.pool[*txHash] = txD
+	tp.addToFeeIndex(*txHash, txD.FeePerKB)
 	var serialNumberList []common.Hash
 	serialNumberList = append(serialNumberList, txD.Desc.Tx.ListSerialNumbersHashH()...)
 	serialNumberListHash := common.HashArrayOfHashArray(serialNumberList)
@@ -808,7 +870,8 @@ func (tp *TxPool) RemoveTx(txs []metadata.Transaction, isInBlock bool) {
 */
 func (tp *TxPool) removeTx(tx metadata.Transaction) {
 	//Logger.log.Infof((*tx).Hash().String())
-	if _, exists := tp.pool[*tx.Hash()]; exists {
+	if txD, exists := tp.pool[*tx.Hash()]; exists {
+		tp.removeFromFeeIndex(*tx.Hash(), txD.FeePerKB)
 		delete(tp.pool, *tx.Hash())
 		atomic.StoreInt64(&tp.lastUpdated, time.Now().Unix())
 	}
@@ -821,7 +884,8 @@ func (tp *TxPool) removeTx(tx metadata.Transaction) {
 		delete(tp.poolSerialNumberHash, hash)
 		// Using the same list serial number to delete new transaction out of pool
 		// this new transaction maybe not exist
-		if _, exists := tp.pool[hash]; exists {
+		if txD, exists := tp.pool[hash]; exists {
+			tp.removeFromFeeIndex(hash, txD.FeePerKB)
 			delete(tp.pool, hash)
 			atomic.StoreInt64(&tp.lastUpdated, time.Now().Unix())
 		}
@@ -1083,6 +1147,7 @@ func (tp *TxPool) EmptyPool() bool {
 	tp.pool = make(map[common.Hash]*TxDesc)
 	tp.poolSerialNumbersHashList = make(map[common.Hash][]common.Hash)
 	tp.poolSerialNumberHash = make(map[common.Hash]common.Hash)
+	tp.poolFeeIndex = []feeIndexEntry{}
 	tp.poolCandidate = make(map[common.Hash]string)
 	tp.poolRequestStopStaking = make(map[common.Hash]string)
 	if len(tp.pool) == 0 && len(tp.poolSerialNumbersHashList) == 0 && len(tp.poolSerialNumberHash) == 0 && len(tp.poolCandidate) == 0 && len(tp.poolRequestStopStaking) == 0 {
@@ -0,0 +1,139 @@
+package mempool
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// feeIndexEntry is a tx in the fee index of pool
+type feeIndexEntry struct {
+	txHash   common.Hash
+	feePerKB CoinPerKilobyte
+}
+
+func (e feeIndexEntry) less(other feeIndexEntry) bool {
+	if e.feePerKB != other.feePerKB {
+		return e.feePerKB < other.feePerKB
+	}
+	return bytes.Compare(e.txHash[:], other.txHash[:]) < 0
+}
+
+// calculateFeePerKB returns the priority of a tx in pool:
+// PRV fee per KB plus token fee per KB converted to PRV with rates of FeeEstimator.
+// Token fee which can not be converted yet is counted as the limit fee,
+// checkFees already required the tx to pay at least that much
+func (tp *TxPool) calculateFeePerKB(tx metadata.Transaction, fee uint64, feeToken uint64) CoinPerKilobyte {
+	size := tx.GetTxActualSize()
+	if size == 0 {
+		size = 1
+	}
+	feePerKB := NewCoinPerKilobyte(fee, size)
+	if feeToken == 0 {
+		return feePerKB
+	}
+	shardID := common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
+	feeEstimator, ok := tp.config.FeeEstimator[shardID]
+	if !ok || feeEstimator == nil {
+		return feePerKB
+	}
+	tokenFeePerKB, err := feeEstimator.ConvertTokenFeeRateToNativeToken(NewCoinPerKilobyte(feeToken, size), tx.GetTokenID())
+	if err != nil {
+		limitFee := CoinPerKilobyte(feeEstimator.GetLimitFeeForNativeToken())
+		if feePerKB < limitFee {
+			return limitFee
+		}
+		return feePerKB
+	}
+	return feePerKB + tokenFeePerKB
+}
+
+// addToFeeIndex inserts a tx into fee index, keeping the index ordered
+func (tp *TxPool) addToFeeIndex(txHash common.Hash, feePerKB CoinPerKilobyte) {
+	entry := feeIndexEntry{txHash: txHash, feePerKB: feePerKB}
+	i := sort.Search(len(tp.poolFeeIndex), func(i int) bool {
+		return !tp.poolFeeIndex[i].less(entry)
+	})
+	tp.poolFeeIndex = append(tp.poolFeeIndex, feeIndexEntry{})
+	copy(tp.poolFeeIndex[i+1:], tp.poolFeeIndex[i:])
+	tp.poolFeeIndex[i] = entry
+}
+
+// removeFromFeeIndex removes a tx out of fee index, do nothing if tx is not indexed
+func (tp *TxPool) removeFromFeeIndex(txHash common.Hash, feePerKB CoinPerKilobyte) {
+	entry := feeIndexEntry{txHash: txHash, feePerKB: feePerKB}
+	i := sort.Search(len(tp.poolFeeIndex), func(i int) bool {
+		return !tp.poolFeeIndex[i].less(entry)
+	})
+	if i < len(tp.poolFeeIndex) && tp.poolFeeIndex[i] == entry {
+		tp.poolFeeIndex = append(tp.poolFeeIndex[:i], tp.poolFeeIndex[i+1:]...)
+	}
+}
+
+// lowestFeePerKB returns fee per KB of the lowest fee tx in pool
+func (tp *TxPool) lowestFeePerKB() (CoinPerKilobyte, bool) {
+	if len(tp.poolFeeIndex) == 0 {
+		return 0, false
+	}
+	return tp.poolFeeIndex[0].feePerKB, true
+}
+
+// evictLowestFeeTxs removes the lowest fee txs until pool is back to its max number of transaction
+func (tp *TxPool) evictLowestFeeTxs() {
+	for uint64(len(tp.pool)) > tp.config.MaxTx && len(tp.poolFeeIndex) > 0 {
+		entry := tp.poolFeeIndex[0]
+		txDesc, ok := tp.pool[entry.txHash]
+		if !ok {
+			tp.poolFeeIndex = tp.poolFeeIndex[1:]
+			continue
+		}
+		tx := txDesc.Desc.Tx
+		Logger.log.Infof("Evict tx %+v with fee per KB %+v from full pool", entry.txHash.String(), entry.feePerKB)
+		tp.removeTx(tx)
+		tp.TriggerCRemoveTxs(tx)
+		tp.removeCandidateByTxHash(entry.txHash)
+		if tp.config.PersistMempool {
+			err := tp.removeTransactionFromDatabaseMP(&entry.txHash)
+			if err != nil {
+				Logger.log.Error(err)
+			}
+		}
+	}
+}
+
+// SortTxsByFeePriority orders txs by their fee per KB in pool, highest first,
+// txs which are not in pool go last
+func (tp *TxPool) SortTxsByFeePriority(txs []metadata.Transaction) {
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+	entries := make([]feeIndexEntry, len(txs))
+	for i, tx := range txs {
+		entries[i].txHash = *tx.Hash()
+		if txDesc, ok := tp.pool[entries[i].txHash]; ok {
+			entries[i].feePerKB = txDesc.FeePerKB
+		}
+	}
+	sort.Sort(byFeePriority{entries: entries, txs: txs})
+}
+
+// byFeePriority sorts txs together with their fee index entries, highest fee first
+type byFeePriority struct {
+	entries []feeIndexEntry
+	txs     []metadata.Transaction
+}
+
+func (s byFeePriority) Len() int { return len(s.txs) }
+
+func (s byFeePriority) Less(i, j int) bool {
+	if s.entries[i].feePerKB != s.entries[j].feePerKB {
+		return s.entries[i].feePerKB > s.entries[j].feePerKB
+	}
+	return bytes.Compare(s.entries[i].txHash[:], s.entries[j].txHash[:]) < 0
+}
+
+func (s byFeePriority) Swap(i, j int) {
+	s.entries[i], s.entries[j] = s.entries[j], s.entries[i]
+	s.txs[i], s.txs[j] = s.txs[j], s.txs[i]
+}
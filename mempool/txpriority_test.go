@@ -0,0 +1,57 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFeePriorityTestPool(maxTx uint64) *TxPool {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	return &TxPool{
+		config:                    Config{MaxTx: maxTx},
+		pool:                      make(map[common.Hash]*TxDesc),
+		poolSerialNumbersHashList: make(map[common.Hash][]common.Hash),
+		poolSerialNumberHash:      make(map[common.Hash]common.Hash),
+		poolFeeIndex:              []feeIndexEntry{},
+		poolCandidate:             make(map[common.Hash]string),
+		poolRequestStopStaking:    make(map[common.Hash]string),
+	}
+}
+
+func newFeePriorityTestTx(fee uint64, lockTime int64) metadata.Transaction {
+	return &transaction.Tx{Fee: fee, LockTime: lockTime, Type: common.TxNormalType}
+}
+
+func TestTxPoolFeeIndex(t *testing.T) {
+	tp := newFeePriorityTestPool(2)
+	tx1 := newFeePriorityTestTx(300, 1)
+	tx2 := newFeePriorityTestTx(100, 2)
+	tx3 := newFeePriorityTestTx(200, 3)
+	for _, tx := range []metadata.Transaction{tx1, tx2, tx3} {
+		err := tp.addTx(createTxDescMempool(tx, 1, tx.GetTxFee(), 0), false)
+		assert.Equal(t, nil, err)
+	}
+	assert.Equal(t, 3, len(tp.poolFeeIndex))
+	lowestFeePerKB, ok := tp.lowestFeePerKB()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, tp.pool[*tx2.Hash()].FeePerKB, lowestFeePerKB)
+
+	txs := []metadata.Transaction{tx2, newFeePriorityTestTx(1000, 4), tx3, tx1}
+	tp.SortTxsByFeePriority(txs)
+	// tx which is not in pool goes last
+	assert.Equal(t, []metadata.Transaction{tx1, tx3, tx2, txs[3]}, txs)
+
+	tp.evictLowestFeeTxs()
+	assert.Equal(t, 2, len(tp.pool))
+	assert.Equal(t, 2, len(tp.poolFeeIndex))
+	_, found := tp.pool[*tx2.Hash()]
+	assert.Equal(t, false, found)
+
+	tp.removeTx(tx1)
+	assert.Equal(t, 1, len(tp.poolFeeIndex))
+	assert.Equal(t, *tx3.Hash(), tp.poolFeeIndex[0].txHash)
+}
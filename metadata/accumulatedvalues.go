@@ -8,11 +8,18 @@ import (
 
 type AccumulatedValues struct {
 	UniqETHTxsUsed   [][]byte
-	DBridgeTokenPair map[string][]byte
+	DBridgeTokenPair map[string]BridgeTokenPair
 	CBridgeTokens    []*common.Hash
 }
 
+// BridgeTokenPair is the external token of a decentralized bridge token
+type BridgeTokenPair struct {
+	Network         string // empty for Ethereum
+	ExternalTokenID []byte
+}
+
 func (ac AccumulatedValues) CanProcessTokenPair(
+	network string,
 	externalTokenID []byte,
 	incTokenID common.Hash,
 ) (bool, error) {
@@ -23,14 +30,14 @@ func (ac AccumulatedValues) CanProcessTokenPair(
 		}
 	}
 	bridgeTokenPair := ac.DBridgeTokenPair
-	if existedExtTokenID, found := bridgeTokenPair[incTokenIDStr]; found {
-		if bytes.Equal(existedExtTokenID, externalTokenID) {
+	if existedPair, found := bridgeTokenPair[incTokenIDStr]; found {
+		if existedPair.Network == network && bytes.Equal(existedPair.ExternalTokenID, externalTokenID) {
 			return true, nil
 		}
 		return false, nil
 	}
-	for _, existedExtTokenID := range bridgeTokenPair {
-		if !bytes.Equal(existedExtTokenID, externalTokenID) {
+	for _, existedPair := range bridgeTokenPair {
+		if existedPair.Network != network || !bytes.Equal(existedPair.ExternalTokenID, externalTokenID) {
 			continue
 		}
 		return false, nil
@@ -23,6 +23,7 @@ type BurningRequest struct {
 	TokenID       common.Hash
 	TokenName     string
 	RemoteAddress string
+	EVMChainID    uint64 `json:",omitempty"` // chain of the withdrawal, Ethereum if 0
 	MetadataBase
 }
 
@@ -49,12 +50,12 @@ func NewBurningRequest(
 }
 
 func (bReq BurningRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
-	bridgeTokenExisted, err := statedb.IsBridgeTokenExistedByType(beaconViewRetriever.GetBeaconFeatureStateDB(), bReq.TokenID, false)
+	bridgeTokenExisted, err := statedb.IsBridgeTokenOfNetwork(beaconViewRetriever.GetBeaconFeatureStateDB(), bReq.TokenID, GetEVMNetwork(bReq.EVMChainID))
 	if err != nil {
 		return false, err
 	}
 	if !bridgeTokenExisted {
-		return false, errors.New("the burning token is not existed in bridge tokens")
+		return false, fmt.Errorf("the burning token is not existed in bridge tokens of EVM chain %d", GetEVMChainID(bReq.EVMChainID))
 	}
 	return true, nil
 }
@@ -104,6 +105,10 @@ func (bReq BurningRequest) ValidateSanityData(chainRetriever ChainRetriever, sha
 	if shardViewRetriever.GetEpoch() < chainRetriever.GetETHRemoveBridgeSigEpoch() && (bReq.Type == BurningRequestMetaV2 || bReq.Type == BurningForDepositToSCRequestMetaV2) {
 		return false, false, fmt.Errorf("metadata type %d is not supported", bReq.Type)
 	}
+	// the bridge shard only signs the burns to Ethereum
+	if !IsETHChain(bReq.EVMChainID) && (bReq.Type == BurningRequestMeta || bReq.Type == BurningForDepositToSCRequestMeta) {
+		return false, false, fmt.Errorf("metadata type %d is not supported for EVM chain %d", bReq.Type, bReq.EVMChainID)
+	}
 	return true, true, nil
 }
 
@@ -118,6 +123,9 @@ func (bReq BurningRequest) Hash() *common.Hash {
 	record += strconv.FormatUint(bReq.BurningAmount, 10)
 	record += bReq.TokenName
 	record += bReq.RemoteAddress
+	if bReq.EVMChainID != 0 {
+		record += strconv.FormatUint(bReq.EVMChainID, 10)
+	}
 
 	// final hash
 	hash := common.HashH([]byte(record))
@@ -59,6 +59,8 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &WithDrawRewardResponse{}
 	case StopAutoStakingMeta:
 		md = &StopAutoStakingMetadata{}
+	case EquivocationEvidenceMeta:
+		md = &EquivocationEvidence{}
 	case PDEContributionMeta:
 		md = &PDEContribution{}
 	case PDEPRVRequiredContributionRequestMeta:
@@ -81,6 +83,18 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &PDEFeeWithdrawalResponse{}
 	case PDEContributionResponseMeta:
 		md = &PDEContributionResponse{}
+	case PDEContributionV3Meta:
+		md = &PDEContributionV3{}
+	case PDETradeRequestV3Meta:
+		md = &PDETradeRequestV3{}
+	case PDEWithdrawalRequestV3Meta:
+		md = &PDEWithdrawalRequestV3{}
+	case PDELimitOrderRequestMeta:
+		md = &PDELimitOrderRequest{}
+	case PDECancelLimitOrderRequestMeta:
+		md = &PDECancelLimitOrderRequest{}
+	case PDELimitOrderResponseMeta:
+		md = &PDELimitOrderResponse{}
 	case PortalCustodianDepositMeta:
 		md = &PortalCustodianDeposit{}
 	case PortalUserRegisterMeta:
@@ -99,6 +113,12 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &PortalRequestUnlockCollateral{}
 	case PortalExchangeRatesMeta:
 		md = &PortalExchangeRates{}
+	case PortalUpdateFeedersMeta:
+		md = &PortalUpdateFeeders{}
+	case PortalTransferObligationMeta:
+		md = &PortalTransferObligation{}
+	case PortalRebalanceCollateralMeta:
+		md = &PortalRebalanceCollateral{}
 	case RelayingBNBHeaderMeta:
 		md = &RelayingHeader{}
 	case RelayingBTCHeaderMeta:
@@ -27,6 +27,9 @@ const (
 	StopAutoStakingMeta = 127
 	BeaconStakingMeta   = 64
 
+	// slashing
+	EquivocationEvidenceMeta = 217
+
 	// Incognito -> Ethereum bridge
 	BeaconSwapConfirmMeta = 70
 	BridgeSwapConfirmMeta = 71
@@ -49,6 +52,17 @@ const (
 	PDEFeeWithdrawalResponseMeta          = 208
 	PDETradingFeesDistributionMeta        = 209
 
+	// pde v3
+	PDEContributionV3Meta            = 210
+	PDETradeRequestV3Meta            = 211
+	PDEWithdrawalRequestV3Meta       = 212
+	PDETradingFeesDistributionV3Meta = 213
+
+	// pde limit orders
+	PDELimitOrderRequestMeta       = 214
+	PDECancelLimitOrderRequestMeta = 215
+	PDELimitOrderResponseMeta      = 216
+
 	// portal
 	PortalCustodianDepositMeta                      = 100
 	PortalUserRegisterMeta                          = 101
@@ -78,6 +92,9 @@ const (
 	PortalPickMoreCustodianForRedeemMeta            = 128
 	PortalLiquidationCustodianDepositMetaV2         = 129
 	PortalLiquidationCustodianDepositResponseMetaV2 = 130
+	PortalUpdateFeedersMeta                         = 131
+	PortalTransferObligationMeta                    = 132
+	PortalRebalanceCollateralMeta                   = 133
 
 	//Note: don't use this metadata type for others
 	PortalResetPortalDBMeta = 199
@@ -108,6 +125,7 @@ var minerCreatedMetaTypes = []int{
 	PDEWithdrawalResponseMeta,
 	PDEFeeWithdrawalResponseMeta,
 	PDEContributionResponseMeta,
+	PDELimitOrderResponseMeta,
 	PortalUserRequestPTokenResponseMeta,
 	PortalCustodianDepositResponseMeta,
 	PortalRedeemRequestResponseMeta,
@@ -131,6 +149,7 @@ var (
 	// if the blockchain is running in Docker container
 	// then using GETH_NAME env's value (aka geth container name)
 	// otherwise using localhost
+	// only used when no ethendpoint is set in the config
 	EthereumLightNodeHost     = common.GetENV("GETH_NAME", "127.0.0.1")
 	EthereumLightNodeProtocol = common.GetENV("GETH_PROTOCOL", "http")
 	EthereumLightNodePort     = common.GetENV("GETH_PORT", "8545")
@@ -146,3 +165,18 @@ const (
 )
 
 var AcceptedWithdrawRewardRequestVersion = []int{0, 1}
+
+// PDEFeeTiers are the trading fees in basis points of PDE v3 pools, a pair
+// has one pool for each fee tier
+var PDEFeeTiers = []uint64{5, 30, 100}
+
+const PDEFeeTierBase = 10000
+
+func IsValidPDEFeeTier(feeTier uint64) bool {
+	for _, tier := range PDEFeeTiers {
+		if tier == feeTier {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package metadata
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// SignedBlockEvidence - a block header signed by a validator
+// Header is the json of the shard or beacon block header
+// Committee is the committee the vote was signed with, in base58, empty for a proposal
+// Sig is the BLS signature of a vote or the producer signature of a proposal
+type SignedBlockEvidence struct {
+	Header    string
+	Committee []string
+	Sig       []byte
+}
+
+// EquivocationEvidence - two different blocks of a chain signed by the same
+// validator at the same height and in the same timeslot, ChainID is -1 for beacon
+// Anyone can submit it, beacon verifies the signatures and slashes the offender
+type EquivocationEvidence struct {
+	ChainID     int
+	Offender    string
+	IsProposal  bool
+	FirstBlock  SignedBlockEvidence
+	SecondBlock SignedBlockEvidence
+	MetadataBase
+}
+
+type EquivocationEvidenceAction struct {
+	Meta    EquivocationEvidence
+	TxReqID common.Hash
+	ShardID byte
+}
+
+func NewEquivocationEvidence(
+	chainID int,
+	offender string,
+	isProposal bool,
+	firstBlock SignedBlockEvidence,
+	secondBlock SignedBlockEvidence,
+	metaType int,
+) (*EquivocationEvidence, error) {
+	if metaType != EquivocationEvidenceMeta {
+		return nil, errors.New("invalid equivocation evidence type")
+	}
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	equivocationEvidence := &EquivocationEvidence{
+		ChainID:     chainID,
+		Offender:    offender,
+		IsProposal:  isProposal,
+		FirstBlock:  firstBlock,
+		SecondBlock: secondBlock,
+	}
+	equivocationEvidence.MetadataBase = metadataBase
+	return equivocationEvidence, nil
+}
+
+func (ee EquivocationEvidence) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// the signatures and the offender are verified by beacon
+	return true, nil
+}
+
+func (ee EquivocationEvidence) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	if beaconHeight < chainRetriever.GetBCHeightBreakPointEquivocationSlash() {
+		return false, false, errors.New("Equivocation evidence is not accepted before its activation height")
+	}
+	offender := new(incognitokey.CommitteePublicKey)
+	if err := offender.FromString(ee.Offender); err != nil {
+		return false, false, errors.New("Offender incorrect")
+	}
+	if !offender.CheckSanityData() {
+		return false, false, errors.New("Invalid committee public key of offender")
+	}
+	if ee.ChainID < -1 || ee.ChainID >= common.MaxShardNumber {
+		return false, false, errors.New("ChainID incorrect")
+	}
+	if len(ee.FirstBlock.Header) == 0 || len(ee.SecondBlock.Header) == 0 {
+		return false, false, errors.New("Signed block header is empty")
+	}
+	if ee.FirstBlock.Header == ee.SecondBlock.Header {
+		return false, false, errors.New("Signed blocks are the same")
+	}
+	if len(ee.FirstBlock.Sig) == 0 || len(ee.SecondBlock.Sig) == 0 {
+		return false, false, errors.New("Signature of signed block is empty")
+	}
+	if !ee.IsProposal && (len(ee.FirstBlock.Committee) == 0 || len(ee.SecondBlock.Committee) == 0) {
+		return false, false, errors.New("Committee of signed vote is empty")
+	}
+	return true, true, nil
+}
+
+func (ee EquivocationEvidence) ValidateMetadataByItself() bool {
+	return ee.Type == EquivocationEvidenceMeta
+}
+
+func (ee EquivocationEvidence) Hash() *common.Hash {
+	record := ee.MetadataBase.Hash().String()
+	record += strconv.Itoa(ee.ChainID)
+	record += ee.Offender
+	record += strconv.FormatBool(ee.IsProposal)
+	for _, block := range []SignedBlockEvidence{ee.FirstBlock, ee.SecondBlock} {
+		record += block.Header
+		for _, committee := range block.Committee {
+			record += committee
+		}
+		record += string(block.Sig)
+	}
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (ee *EquivocationEvidence) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := EquivocationEvidenceAction{
+		Meta:    *ee,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(ee.Type), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (ee *EquivocationEvidence) CalculateSize() uint64 {
+	return calculateSize(ee)
+}
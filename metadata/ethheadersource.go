@@ -0,0 +1,220 @@
+package metadata
+
+import (
+	"math/big"
+	"sort"
+	"sync"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/incognitochain/incognito-chain/metadata/rpccaller"
+	"github.com/pkg/errors"
+)
+
+// ETHHeaderSource provides the Ethereum block headers which the proofs of ETH
+// issuing requests are verified against.
+type ETHHeaderSource interface {
+	// GetETHHeader returns the header of the block ethBlockHash, nil if the block is not found
+	GetETHHeader(ethBlockHash rCommon.Hash) (*types.Header, error)
+	// GetMostRecentETHBlockHeight returns the height of the last Ethereum block
+	GetMostRecentETHBlockHeight() (*big.Int, error)
+}
+
+// RPCETHHeaderSource gets the headers from one Ethereum node over JSON-RPC.
+type RPCETHHeaderSource struct {
+	endpoint  string
+	rpcClient *rpccaller.RPCClient
+}
+
+// NewRPCETHHeaderSource returns a source calling the Ethereum node at
+// endpoint, eg. http://127.0.0.1:8545
+func NewRPCETHHeaderSource(endpoint string) *RPCETHHeaderSource {
+	return &RPCETHHeaderSource{endpoint: endpoint, rpcClient: rpccaller.NewRPCClient()}
+}
+
+func (source *RPCETHHeaderSource) GetETHHeader(ethBlockHash rCommon.Hash) (*types.Header, error) {
+	params := []interface{}{ethBlockHash, false}
+	var getBlockByNumberRes GetBlockByNumberRes
+	err := source.rpcClient.RPCCall("", source.endpoint, "", "eth_getBlockByHash", params, &getBlockByNumberRes)
+	if err != nil {
+		return nil, err
+	}
+	if getBlockByNumberRes.RPCError != nil {
+		Logger.log.Infof("WARNING: an error occured during calling eth_getBlockByHash on %s: %s", source.endpoint, getBlockByNumberRes.RPCError.Message)
+		return nil, nil
+	}
+	header := getBlockByNumberRes.Result
+	if header != nil && header.Hash() != ethBlockHash {
+		return nil, errors.Errorf("%s returned the header of the ETH block %s for the block %s", source.endpoint, header.Hash().String(), ethBlockHash.String())
+	}
+	return header, nil
+}
+
+func (source *RPCETHHeaderSource) GetMostRecentETHBlockHeight() (*big.Int, error) {
+	params := []interface{}{}
+	var getETHBlockNumRes GetETHBlockNumRes
+	err := source.rpcClient.RPCCall("", source.endpoint, "", "eth_blockNumber", params, &getETHBlockNumRes)
+	if err != nil {
+		return nil, err
+	}
+	if getETHBlockNumRes.RPCError != nil {
+		return nil, errors.Errorf("an error occured during calling eth_blockNumber on %s: %s", source.endpoint, getETHBlockNumRes.RPCError.Message)
+	}
+	if len(getETHBlockNumRes.Result) < 2 {
+		return nil, errors.New("Cannot convert blockNumber into integer")
+	}
+	blockNumber := new(big.Int)
+	_, ok := blockNumber.SetString(getETHBlockNumRes.Result[2:], 16)
+	if !ok {
+		return nil, errors.New("Cannot convert blockNumber into integer")
+	}
+	return blockNumber, nil
+}
+
+// QuorumETHHeaderSource asks several sources and only trusts an answer given
+// by at least quorum of them, so that up to quorum-1 endpoints being down or
+// lying cannot make a proof pass.
+type QuorumETHHeaderSource struct {
+	sources []ETHHeaderSource
+	quorum  int
+}
+
+func NewQuorumETHHeaderSource(sources []ETHHeaderSource, quorum int) (*QuorumETHHeaderSource, error) {
+	if quorum <= 0 || quorum > len(sources) {
+		return nil, errors.Errorf("quorum %d is not between 1 and the number of sources %d", quorum, len(sources))
+	}
+	return &QuorumETHHeaderSource{sources: sources, quorum: quorum}, nil
+}
+
+// GetETHHeader returns the header returned by at least quorum sources, nil if
+// at least quorum sources do not find the block.
+func (source *QuorumETHHeaderSource) GetETHHeader(ethBlockHash rCommon.Hash) (*types.Header, error) {
+	headers := make([]*types.Header, len(source.sources))
+	errs := make([]error, len(source.sources))
+	wg := sync.WaitGroup{}
+	for i, s := range source.sources {
+		wg.Add(1)
+		go func(i int, s ETHHeaderSource) {
+			defer wg.Done()
+			headers[i], errs[i] = s.GetETHHeader(ethBlockHash)
+		}(i, s)
+	}
+	wg.Wait()
+
+	votes := make(map[rCommon.Hash]int)
+	notFound, failed := 0, 0
+	for i, header := range headers {
+		if errs[i] != nil {
+			failed++
+			continue
+		}
+		if header == nil {
+			notFound++
+			continue
+		}
+		// a source returning the header of another block is failing
+		if header.Hash() != ethBlockHash {
+			failed++
+			continue
+		}
+		votes[header.Hash()]++
+		if votes[header.Hash()] >= source.quorum {
+			return header, nil
+		}
+	}
+	if notFound >= source.quorum {
+		return nil, nil
+	}
+	return nil, errors.Errorf("no quorum of %d sources on the ETH block %s: %d different headers, %d not found, %d failed", source.quorum, ethBlockHash.String(), len(votes), notFound, failed)
+}
+
+// GetMostRecentETHBlockHeight returns the highest height which at least
+// quorum sources have reached.
+func (source *QuorumETHHeaderSource) GetMostRecentETHBlockHeight() (*big.Int, error) {
+	heights := make([]*big.Int, len(source.sources))
+	wg := sync.WaitGroup{}
+	for i, s := range source.sources {
+		wg.Add(1)
+		go func(i int, s ETHHeaderSource) {
+			defer wg.Done()
+			height, err := s.GetMostRecentETHBlockHeight()
+			if err == nil {
+				heights[i] = height
+			}
+		}(i, s)
+	}
+	wg.Wait()
+
+	reached := []*big.Int{}
+	for _, height := range heights {
+		if height != nil {
+			reached = append(reached, height)
+		}
+	}
+	if len(reached) < source.quorum {
+		return nil, errors.Errorf("only %d of the %d sources needed returned the most recent ETH block height", len(reached), source.quorum)
+	}
+	sort.Slice(reached, func(i, j int) bool {
+		return reached[i].Cmp(reached[j]) > 0
+	})
+	return reached[source.quorum-1], nil
+}
+
+// MockETHHeaderSource is an in-process source for tests
+type MockETHHeaderSource struct {
+	mtx     sync.RWMutex
+	headers map[rCommon.Hash]*types.Header
+	height  *big.Int
+	err     error
+}
+
+func NewMockETHHeaderSource() *MockETHHeaderSource {
+	return &MockETHHeaderSource{headers: make(map[rCommon.Hash]*types.Header), height: big.NewInt(0)}
+}
+
+// AddHeader adds a block header, the most recent height is raised to its height
+func (source *MockETHHeaderSource) AddHeader(header *types.Header) {
+	source.SetHeader(header.Hash(), header)
+}
+
+// SetHeader returns header for the block ethBlockHash, even if it is not its
+// hash, to mock a lying node
+func (source *MockETHHeaderSource) SetHeader(ethBlockHash rCommon.Hash, header *types.Header) {
+	source.mtx.Lock()
+	defer source.mtx.Unlock()
+	source.headers[ethBlockHash] = header
+	if header.Number != nil && header.Number.Cmp(source.height) > 0 {
+		source.height = new(big.Int).Set(header.Number)
+	}
+}
+
+func (source *MockETHHeaderSource) SetMostRecentETHBlockHeight(height *big.Int) {
+	source.mtx.Lock()
+	defer source.mtx.Unlock()
+	source.height = height
+}
+
+// SetError makes every call fail with err, nil to recover
+func (source *MockETHHeaderSource) SetError(err error) {
+	source.mtx.Lock()
+	defer source.mtx.Unlock()
+	source.err = err
+}
+
+func (source *MockETHHeaderSource) GetETHHeader(ethBlockHash rCommon.Hash) (*types.Header, error) {
+	source.mtx.RLock()
+	defer source.mtx.RUnlock()
+	if source.err != nil {
+		return nil, source.err
+	}
+	return source.headers[ethBlockHash], nil
+}
+
+func (source *MockETHHeaderSource) GetMostRecentETHBlockHeight() (*big.Int, error) {
+	source.mtx.RLock()
+	defer source.mtx.RUnlock()
+	if source.err != nil {
+		return nil, source.err
+	}
+	return new(big.Int).Set(source.height), nil
+}
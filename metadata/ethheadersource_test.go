@@ -0,0 +1,137 @@
+package metadata
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func newETHHeader(number int64, extra string) *types.Header {
+	return &types.Header{Number: big.NewInt(number), Difficulty: big.NewInt(1), Extra: []byte(extra)}
+}
+
+func newMockETHHeaderSources(n int) ([]*MockETHHeaderSource, []ETHHeaderSource) {
+	mockSources := []*MockETHHeaderSource{}
+	sources := []ETHHeaderSource{}
+	for i := 0; i < n; i++ {
+		source := NewMockETHHeaderSource()
+		mockSources = append(mockSources, source)
+		sources = append(sources, source)
+	}
+	return mockSources, sources
+}
+
+func TestQuorumETHHeaderSource_GetETHHeader(t *testing.T) {
+	header := newETHHeader(100, "")
+	forged := newETHHeader(100, "forged")
+	mockSources, sources := newMockETHHeaderSources(3)
+	_, err := NewQuorumETHHeaderSource(sources, 0)
+	assert.NotNil(t, err)
+	_, err = NewQuorumETHHeaderSource(sources, 4)
+	assert.NotNil(t, err)
+	quorum, err := NewQuorumETHHeaderSource(sources, 2)
+	assert.Nil(t, err)
+
+	//no source finds the block
+	res, err := quorum.GetETHHeader(header.Hash())
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+
+	//one honest source, one lying source and one source down
+	mockSources[0].AddHeader(header)
+	mockSources[1].SetHeader(header.Hash(), forged)
+	mockSources[2].SetError(errors.New("unreachable"))
+	_, err = quorum.GetETHHeader(header.Hash())
+	assert.NotNil(t, err)
+
+	//two honest sources
+	mockSources[2].SetError(nil)
+	mockSources[2].AddHeader(header)
+	res, err = quorum.GetETHHeader(header.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, header.Hash(), res.Hash())
+
+	//a quorum of sources returning the same header of another block
+	mockSources[0].SetHeader(header.Hash(), forged)
+	mockSources[2].SetHeader(header.Hash(), forged)
+	_, err = quorum.GetETHHeader(header.Hash())
+	assert.NotNil(t, err)
+}
+
+func TestRPCETHHeaderSource_GetETHHeader(t *testing.T) {
+	header := newETHHeader(100, "")
+	forged := newETHHeader(100, "forged")
+	result := header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res := GetBlockByNumberRes{Result: result}
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	defer server.Close()
+	source := NewRPCETHHeaderSource(server.URL)
+
+	res, err := source.GetETHHeader(header.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, header.Hash(), res.Hash())
+
+	//the endpoint substitutes the header of another block
+	result = forged
+	_, err = source.GetETHHeader(header.Hash())
+	assert.NotNil(t, err)
+}
+
+func TestQuorumETHHeaderSource_GetMostRecentETHBlockHeight(t *testing.T) {
+	mockSources, sources := newMockETHHeaderSources(3)
+	quorum, err := NewQuorumETHHeaderSource(sources, 2)
+	assert.Nil(t, err)
+
+	mockSources[0].SetMostRecentETHBlockHeight(big.NewInt(1000))
+	mockSources[1].SetMostRecentETHBlockHeight(big.NewInt(120))
+	mockSources[2].SetMostRecentETHBlockHeight(big.NewInt(110))
+	//a single source cannot make the chain look longer
+	height, err := quorum.GetMostRecentETHBlockHeight()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(120), height.Int64())
+
+	mockSources[1].SetError(errors.New("unreachable"))
+	height, err = quorum.GetMostRecentETHBlockHeight()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(110), height.Int64())
+
+	mockSources[2].SetError(errors.New("unreachable"))
+	_, err = quorum.GetMostRecentETHBlockHeight()
+	assert.NotNil(t, err)
+}
+
+func TestIssuingETHRequest_verifyProofAndParseReceipt(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	header := newETHHeader(100, "")
+	source := NewMockETHHeaderSource()
+	iReq, _ := NewIssuingETHRequest(header.Hash(), 0, []string{}, common.PRVCoinID, IssuingETHRequestMeta)
+
+	//the block is not found
+	_, err := iReq.verifyProofAndParseReceipt(source)
+	assert.NotNil(t, err)
+
+	//the block does not have enough confirmations
+	source.AddHeader(header)
+	_, err = iReq.verifyProofAndParseReceipt(source)
+	assert.NotNil(t, err)
+
+	//the source is down
+	source.SetMostRecentETHBlockHeight(big.NewInt(100 + ETHConfirmationBlocks))
+	source.SetError(errors.New("unreachable"))
+	_, err = iReq.verifyProofAndParseReceipt(source)
+	assert.NotNil(t, err)
+
+	//the receipt is not proved by the header
+	source.SetError(nil)
+	_, err = iReq.verifyProofAndParseReceipt(source)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,44 @@
+package metadata
+
+import (
+	"strconv"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// GetEVMChainID returns the EVM chain of a bridge request, Ethereum for the
+// requests without chain id.
+func GetEVMChainID(evmChainID uint64) uint64 {
+	if evmChainID == 0 {
+		return common.ETHChainID
+	}
+	return evmChainID
+}
+
+// IsETHChain returns whether a bridge request is from or to Ethereum
+func IsETHChain(evmChainID uint64) bool {
+	return GetEVMChainID(evmChainID) == common.ETHChainID
+}
+
+// GetEVMNetwork returns the network of the bridge tokens of an EVM chain in the
+// bridge state, empty for Ethereum as the tokens registered before chain ids.
+func GetEVMNetwork(evmChainID uint64) string {
+	if IsETHChain(evmChainID) {
+		return ""
+	}
+	return strconv.FormatUint(evmChainID, 10)
+}
+
+// GetUniqEVMTx returns the key marking a tx of an EVM chain as issued. The
+// TxHash of a constructed receipt is always empty so the key is built from
+// the block hash and the tx index, prefixed by the chain id for the chains
+// other than Ethereum.
+func GetUniqEVMTx(evmChainID uint64, blockHash rCommon.Hash, txIndex uint) []byte {
+	uniqTx := []byte{}
+	if !IsETHChain(evmChainID) {
+		uniqTx = append(uniqTx, []byte(GetEVMNetwork(evmChainID)+"-")...)
+	}
+	uniqTx = append(uniqTx, blockHash[:]...)
+	return append(uniqTx, []byte(strconv.Itoa(int(txIndex)))...)
+}
@@ -0,0 +1,23 @@
+package metadata
+
+import (
+	"testing"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUniqEVMTx(t *testing.T) {
+	blockHash := rCommon.HexToHash("0x01")
+	//the requests without chain id keep the key of the ETH bridge
+	ethUniqTx := append(append([]byte{}, blockHash[:]...), []byte("7")...)
+	assert.Equal(t, ethUniqTx, GetUniqEVMTx(0, blockHash, 7))
+	assert.Equal(t, ethUniqTx, GetUniqEVMTx(common.ETHChainID, blockHash, 7))
+
+	//the same block hash and index on another chain is another tx
+	assert.NotEqual(t, ethUniqTx, GetUniqEVMTx(56, blockHash, 7))
+	assert.NotEqual(t, GetUniqEVMTx(56, blockHash, 7), GetUniqEVMTx(137, blockHash, 7))
+	assert.Equal(t, "", GetEVMNetwork(0))
+	assert.Equal(t, "56", GetEVMNetwork(56))
+}
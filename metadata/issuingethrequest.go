@@ -26,6 +26,7 @@ type IssuingETHRequest struct {
 	TxIndex    uint
 	ProofStrs  []string
 	IncTokenID common.Hash
+	EVMChainID uint64 `json:",omitempty"` // chain of the deposit, Ethereum if 0
 	MetadataBase
 }
 
@@ -43,6 +44,7 @@ type IssuingETHAcceptedInst struct {
 	TxReqID         common.Hash `json:"txReqId"`
 	UniqETHTx       []byte      `json:"uniqETHTx"`
 	ExternalTokenID []byte      `json:"externalTokenId"`
+	EVMChainID      uint64      `json:"evmChainId,omitempty"`
 }
 
 type GetBlockByNumberRes struct {
@@ -111,11 +113,22 @@ func NewIssuingETHRequestFromMap(
 		*incTokenID,
 		IssuingETHRequestMeta,
 	)
+	if evmChainID, ok := data["EVMChainID"]; ok {
+		evmChainIDFloat, ok := evmChainID.(float64)
+		if !ok || evmChainIDFloat < 0 {
+			return nil, NewMetadataTxError(IssuingEthRequestNewIssuingETHRequestFromMapEror, errors.Errorf("EVMChainID incorrect"))
+		}
+		req.EVMChainID = uint64(evmChainIDFloat)
+	}
 	return req, nil
 }
 
 func (iReq IssuingETHRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
-	ethReceipt, err := iReq.verifyProofAndParseReceipt()
+	ethHeaderSource, err := chainRetriever.GetEVMHeaderSource(iReq.EVMChainID)
+	if err != nil {
+		return false, NewMetadataTxError(IssuingEthRequestValidateTxWithBlockChainError, err)
+	}
+	ethReceipt, err := iReq.verifyProofAndParseReceipt(ethHeaderSource)
 	if err != nil {
 		return false, NewMetadataTxError(IssuingEthRequestValidateTxWithBlockChainError, err)
 	}
@@ -162,6 +175,9 @@ func (iReq IssuingETHRequest) Hash() *common.Hash {
 	}
 	record += iReq.MetadataBase.Hash().String()
 	record += iReq.IncTokenID.String()
+	if iReq.EVMChainID != 0 {
+		record += strconv.FormatUint(iReq.EVMChainID, 10)
+	}
 
 	// final hash
 	hash := common.HashH([]byte(record))
@@ -169,7 +185,11 @@ func (iReq IssuingETHRequest) Hash() *common.Hash {
 }
 
 func (iReq *IssuingETHRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
-	ethReceipt, err := iReq.verifyProofAndParseReceipt()
+	ethHeaderSource, err := chainRetriever.GetEVMHeaderSource(iReq.EVMChainID)
+	if err != nil {
+		return [][]string{}, NewMetadataTxError(IssuingEthRequestBuildReqActionsError, err)
+	}
+	ethReceipt, err := iReq.verifyProofAndParseReceipt(ethHeaderSource)
 	if err != nil {
 		return [][]string{}, NewMetadataTxError(IssuingEthRequestBuildReqActionsError, err)
 	}
@@ -200,8 +220,8 @@ func (iReq *IssuingETHRequest) CalculateSize() uint64 {
 	return calculateSize(iReq)
 }
 
-func (iReq *IssuingETHRequest) verifyProofAndParseReceipt() (*types.Receipt, error) {
-	ethHeader, err := GetETHHeader(iReq.BlockHash)
+func (iReq *IssuingETHRequest) verifyProofAndParseReceipt(ethHeaderSource ETHHeaderSource) (*types.Receipt, error) {
+	ethHeader, err := ethHeaderSource.GetETHHeader(iReq.BlockHash)
 	if err != nil {
 		return nil, NewMetadataTxError(IssuingEthRequestVerifyProofAndParseReceipt, err)
 	}
@@ -210,7 +230,7 @@ func (iReq *IssuingETHRequest) verifyProofAndParseReceipt() (*types.Receipt, err
 		return nil, NewMetadataTxError(IssuingEthRequestVerifyProofAndParseReceipt, errors.Errorf("WARNING: Could not find out the ETH block header with the hash: %s", iReq.BlockHash.String()))
 	}
 
-	mostRecentBlkNum, err := GetMostRecentETHBlockHeight()
+	mostRecentBlkNum, err := ethHeaderSource.GetMostRecentETHBlockHeight()
 	if err != nil {
 		Logger.log.Info("WARNING: Could not find the most recent block height on Ethereum")
 		return nil, NewMetadataTxError(IssuingEthRequestVerifyProofAndParseReceipt, err)
@@ -275,59 +295,6 @@ func IsETHTxHashUsedInBlock(uniqETHTx []byte, uniqETHTxsUsed [][]byte) bool {
 	return false
 }
 
-func GetETHHeader(
-	ethBlockHash rCommon.Hash,
-) (*types.Header, error) {
-	rpcClient := rpccaller.NewRPCClient()
-	params := []interface{}{ethBlockHash, false}
-	var getBlockByNumberRes GetBlockByNumberRes
-	err := rpcClient.RPCCall(
-		EthereumLightNodeProtocol,
-		EthereumLightNodeHost,
-		EthereumLightNodePort,
-		"eth_getBlockByHash",
-		params,
-		&getBlockByNumberRes,
-	)
-	if err != nil {
-		return nil, err
-	}
-	if getBlockByNumberRes.RPCError != nil {
-		Logger.log.Infof("WARNING: an error occured during calling eth_getBlockByHash: %s", getBlockByNumberRes.RPCError.Message)
-		return nil, nil
-	}
-	return getBlockByNumberRes.Result, nil
-}
-
-// GetMostRecentETHBlockHeight get most recent block height on Ethereum
-func GetMostRecentETHBlockHeight() (*big.Int, error) {
-	rpcClient := rpccaller.NewRPCClient()
-	params := []interface{}{}
-	var getETHBlockNumRes GetETHBlockNumRes
-	err := rpcClient.RPCCall(
-		EthereumLightNodeProtocol,
-		EthereumLightNodeHost,
-		EthereumLightNodePort,
-		"eth_blockNumber",
-		params,
-		&getETHBlockNumRes,
-	)
-	if err != nil {
-		return nil, err
-	}
-	if getETHBlockNumRes.RPCError != nil {
-		Logger.log.Debugf("WARNING: an error occured during calling eth_blockNumber: %s", getETHBlockNumRes.RPCError.Message)
-		return nil, nil
-	}
-
-	blockNumber := new(big.Int)
-	_, ok := blockNumber.SetString(getETHBlockNumRes.Result[2:], 16)
-	if !ok {
-		return nil, errors.New("Cannot convert blockNumber into integer")
-	}
-	return blockNumber, nil
-}
-
 func PickAndParseLogMapFromReceipt(constructedReceipt *types.Receipt, ethContractAddressStr string) (map[string]interface{}, error) {
 	logData := []byte{}
 	logLen := len(constructedReceipt.Logs)
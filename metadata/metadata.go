@@ -7,7 +7,6 @@ import (
 
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"github.com/incognitochain/incognito-chain/privacy"
-	"github.com/incognitochain/incognito-chain/relaying/bnb"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
@@ -60,14 +59,19 @@ type ChainRetriever interface {
 	GetStakingAmountShard() uint64
 	GetCentralizedWebsitePaymentAddress(uint64) string
 	GetBeaconHeightBreakPointBurnAddr() uint64
+	GetBCHeightBreakPointEquivocationSlash() uint64
 	GetBurningAddress(blockHeight uint64) string
 	GetTransactionByHash(common.Hash) (byte, common.Hash, uint64, int, Transaction, error)
 	ListPrivacyTokenAndBridgeTokenAndPRVByShardID(byte) ([]common.Hash, error)
 	GetBNBChainID() string
 	GetBTCChainID() string
 	GetBTCHeaderChain() *btcrelaying.BlockChain
-	GetPortalFeederAddress() string
+	GetLatestBNBBlkHeight() (int64, error)
+	GetBNBDataHash(blockHeight int64) ([]byte, error)
+	GetPortalFeederAddresses() []string
+	GetPortalFeederGovernorAddress() string
 	GetFixedRandomForShardIDCommitment(beaconHeight uint64) *privacy.Scalar
+	GetEVMHeaderSource(evmChainID uint64) (ETHHeaderSource, error)
 }
 
 type BeaconViewRetriever interface {
@@ -255,23 +259,17 @@ func IsValidRemoteAddress(
 	tokenID string,
 	chainID string,
 ) bool {
-	if tokenID == common.PortalBNBIDStr {
-		return bnb.IsValidBNBAddress(remoteAddress, chainID)
-	} else if tokenID == common.PortalBTCIDStr {
-		btcHeaderChain := bcr.GetBTCHeaderChain()
-		if btcHeaderChain == nil {
-			return false
-		}
-		return btcHeaderChain.IsBTCAddressValid(remoteAddress)
+	adapter, err := GetPortalChainAdapter(tokenID)
+	if err != nil {
+		return false
 	}
-	return false
+	return adapter.IsValidRemoteAddress(bcr, remoteAddress, chainID)
 }
 
 func GetChainIDByTokenID(tokenID string, chainRetriever ChainRetriever) string {
-	if tokenID == common.PortalBNBIDStr {
-		return chainRetriever.GetBNBChainID()
-	} else if tokenID == common.PortalBTCIDStr {
-		return chainRetriever.GetBTCChainID()
+	adapter, err := GetPortalChainAdapter(tokenID)
+	if err != nil {
+		return ""
 	}
-	return ""
+	return adapter.GetChainID(chainRetriever)
 }
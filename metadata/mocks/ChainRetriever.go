@@ -18,6 +18,20 @@ type ChainRetriever struct {
 	mock.Mock
 }
 
+// GetBCHeightBreakPointEquivocationSlash provides a mock function with given fields:
+func (_m *ChainRetriever) GetBCHeightBreakPointEquivocationSlash() uint64 {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
 // GetBNBChainID provides a mock function with given fields:
 func (_m *ChainRetriever) GetBNBChainID() string {
 	ret := _m.Called()
@@ -32,6 +46,29 @@ func (_m *ChainRetriever) GetBNBChainID() string {
 	return r0
 }
 
+// GetBNBDataHash provides a mock function with given fields: blockHeight
+func (_m *ChainRetriever) GetBNBDataHash(blockHeight int64) ([]byte, error) {
+	ret := _m.Called(blockHeight)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(int64) []byte); ok {
+		r0 = rf(blockHeight)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(blockHeight)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBTCChainID provides a mock function with given fields:
 func (_m *ChainRetriever) GetBTCChainID() string {
 	ret := _m.Called()
@@ -104,6 +141,29 @@ func (_m *ChainRetriever) GetCentralizedWebsitePaymentAddress(_a0 uint64) string
 	return r0
 }
 
+// GetEVMHeaderSource provides a mock function with given fields: evmChainID
+func (_m *ChainRetriever) GetEVMHeaderSource(evmChainID uint64) (metadata.ETHHeaderSource, error) {
+	ret := _m.Called(evmChainID)
+
+	var r0 metadata.ETHHeaderSource
+	if rf, ok := ret.Get(0).(func(uint64) metadata.ETHHeaderSource); ok {
+		r0 = rf(evmChainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(metadata.ETHHeaderSource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(evmChainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFixedRandomForShardIDCommitment provides a mock function with given fields: beaconHeight
 func (_m *ChainRetriever) GetFixedRandomForShardIDCommitment(beaconHeight uint64) *privacy.Scalar {
 	ret := _m.Called(beaconHeight)
@@ -120,8 +180,45 @@ func (_m *ChainRetriever) GetFixedRandomForShardIDCommitment(beaconHeight uint64
 	return r0
 }
 
-// GetPortalFeederAddress provides a mock function with given fields:
-func (_m *ChainRetriever) GetPortalFeederAddress() string {
+// GetLatestBNBBlkHeight provides a mock function with given fields:
+func (_m *ChainRetriever) GetLatestBNBBlkHeight() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPortalFeederAddresses provides a mock function with given fields:
+func (_m *ChainRetriever) GetPortalFeederAddresses() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// GetPortalFeederGovernorAddress provides a mock function with given fields:
+func (_m *ChainRetriever) GetPortalFeederGovernorAddress() string {
 	ret := _m.Called()
 
 	var r0 string
@@ -0,0 +1,99 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// PDECancelLimitOrderRequest - cancellation of a resting limit order by its
+// trader, the selling amount of the order is refunded
+type PDECancelLimitOrderRequest struct {
+	OrderID          string // hash of the limit order request tx
+	TraderAddressStr string
+	MetadataBase
+}
+
+type PDECancelLimitOrderRequestAction struct {
+	Meta    PDECancelLimitOrderRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+func NewPDECancelLimitOrderRequest(
+	orderID string,
+	traderAddressStr string,
+	metaType int,
+) (*PDECancelLimitOrderRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	pdeCancelLimitOrderRequest := &PDECancelLimitOrderRequest{
+		OrderID:          orderID,
+		TraderAddressStr: traderAddressStr,
+	}
+	pdeCancelLimitOrderRequest.MetadataBase = metadataBase
+	return pdeCancelLimitOrderRequest, nil
+}
+
+func (pc PDECancelLimitOrderRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// the order and its owner are verified by beacon
+	return true, nil
+}
+
+func (pc PDECancelLimitOrderRequest) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(pc.TraderAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TraderAddressStr incorrect"))
+	}
+	traderAddr := keyWallet.KeySet.PaymentAddress
+	if len(traderAddr.Pk) == 0 {
+		return false, false, errors.New("Wrong request info's trader address")
+	}
+	if !bytes.Equal(tx.GetSigPubKey()[:], traderAddr.Pk[:]) {
+		return false, false, errors.New("TraderAddress incorrect")
+	}
+	_, err = common.Hash{}.NewHashFromStr(pc.OrderID)
+	if err != nil {
+		return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("OrderID incorrect"))
+	}
+	return true, true, nil
+}
+
+func (pc PDECancelLimitOrderRequest) ValidateMetadataByItself() bool {
+	return pc.Type == PDECancelLimitOrderRequestMeta
+}
+
+func (pc PDECancelLimitOrderRequest) Hash() *common.Hash {
+	record := pc.MetadataBase.Hash().String()
+	record += pc.OrderID
+	record += pc.TraderAddressStr
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (pc *PDECancelLimitOrderRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := PDECancelLimitOrderRequestAction{
+		Meta:    *pc,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(pc.Type), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (pc *PDECancelLimitOrderRequest) CalculateSize() uint64 {
+	return calculateSize(pc)
+}
@@ -34,6 +34,7 @@ type PDEWaitingContribution struct {
 	ContributedAmount     uint64
 	TokenIDStr            string
 	TxReqID               common.Hash
+	FeeTier               uint64 `json:",omitempty"`
 }
 
 type PDERefundContribution struct {
@@ -43,6 +44,7 @@ type PDERefundContribution struct {
 	TokenIDStr            string
 	TxReqID               common.Hash
 	ShardID               byte
+	FeeTier               uint64 `json:",omitempty"`
 }
 
 type PDEMatchedContribution struct {
@@ -51,6 +53,7 @@ type PDEMatchedContribution struct {
 	ContributedAmount     uint64
 	TokenIDStr            string
 	TxReqID               common.Hash
+	FeeTier               uint64 `json:",omitempty"`
 }
 
 type PDEMatchedNReturnedContribution struct {
@@ -62,6 +65,7 @@ type PDEMatchedNReturnedContribution struct {
 	ShardID                    byte
 	TxReqID                    common.Hash
 	ActualWaitingContribAmount uint64
+	FeeTier                    uint64 `json:",omitempty"`
 }
 
 type PDEContributionStatus struct {
@@ -77,7 +77,8 @@ func (iRes PDEContributionResponse) VerifyMinerCreatedTxBeforeGettingInBlock(txs
 		}
 		instMetaType := inst[0]
 		if instUsed[i] > 0 ||
-			(instMetaType != strconv.Itoa(PDEContributionMeta) && instMetaType != strconv.Itoa(PDEPRVRequiredContributionRequestMeta)) {
+			(instMetaType != strconv.Itoa(PDEContributionMeta) && instMetaType != strconv.Itoa(PDEPRVRequiredContributionRequestMeta) &&
+				instMetaType != strconv.Itoa(PDEContributionV3Meta)) {
 			continue
 		}
 		instContributionStatus := inst[2]
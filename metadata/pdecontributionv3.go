@@ -0,0 +1,146 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// PDEContributionV3 - privacy dex contribution to the pool of a pair at a fee tier
+type PDEContributionV3 struct {
+	PDEContributionPairID string
+	ContributorAddressStr string
+	ContributedAmount     uint64 // must be equal to vout value
+	TokenIDStr            string
+	FeeTier               uint64 // trading fee of the pool in basis points, one of PDEFeeTiers
+	MetadataBase
+}
+
+type PDEContributionV3Action struct {
+	Meta    PDEContributionV3
+	TxReqID common.Hash
+	ShardID byte
+}
+
+func NewPDEContributionV3(
+	pdeContributionPairID string,
+	contributorAddressStr string,
+	contributedAmount uint64,
+	tokenIDStr string,
+	feeTier uint64,
+	metaType int,
+) (*PDEContributionV3, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	pdeContribution := &PDEContributionV3{
+		PDEContributionPairID: pdeContributionPairID,
+		ContributorAddressStr: contributorAddressStr,
+		ContributedAmount:     contributedAmount,
+		TokenIDStr:            tokenIDStr,
+		FeeTier:               feeTier,
+	}
+	pdeContribution.MetadataBase = metadataBase
+	return pdeContribution, nil
+}
+
+func (pc PDEContributionV3) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	return true, nil
+}
+
+func (pc PDEContributionV3) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	// Note: the metadata was already verified with *transaction.TxCustomToken level so no need to verify with *transaction.Tx level again as *transaction.Tx is embedding property of *transaction.TxCustomToken
+	if tx.GetType() == common.TxCustomTokenPrivacyType && reflect.TypeOf(tx).String() == "*transaction.Tx" {
+		return true, true, nil
+	}
+	if pc.PDEContributionPairID == "" {
+		return false, false, errors.New("PDE contribution pair id should not be empty.")
+	}
+	if !IsValidPDEFeeTier(pc.FeeTier) {
+		return false, false, fmt.Errorf("Fee tier %d is not supported, it should be one of %v", pc.FeeTier, PDEFeeTiers)
+	}
+
+	keyWallet, err := wallet.Base58CheckDeserialize(pc.ContributorAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("ContributorAddressStr incorrect"))
+	}
+	contributorAddr := keyWallet.KeySet.PaymentAddress
+
+	if len(contributorAddr.Pk) == 0 {
+		return false, false, errors.New("Wrong request info's contributed address")
+	}
+	if !tx.IsCoinsBurning(chainRetriever, shardViewRetriever, beaconViewRetriever, beaconHeight) {
+		return false, false, errors.New("Must send coin to burning address")
+	}
+	if pc.ContributedAmount == 0 {
+		return false, false, errors.New("Contributed Amount should be larger than 0")
+	}
+	if pc.ContributedAmount != tx.CalculateTxValue() {
+		return false, false, errors.New("Contributed Amount should be equal to the tx value")
+	}
+	if !bytes.Equal(tx.GetSigPubKey()[:], contributorAddr.Pk[:]) {
+		return false, false, errors.New("ContributorAddress incorrect")
+	}
+
+	tokenID, err := common.Hash{}.NewHashFromStr(pc.TokenIDStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TokenIDStr incorrect"))
+	}
+
+	if !bytes.Equal(tx.GetTokenID()[:], tokenID[:]) {
+		return false, false, errors.New("Wrong request info's token id, it should be equal to tx's token id.")
+	}
+
+	if tx.GetType() == common.TxNormalType && pc.TokenIDStr != common.PRVCoinID.String() {
+		return false, false, errors.New("With tx normal privacy, the tokenIDStr should be PRV, not custom token.")
+	}
+
+	if tx.GetType() == common.TxCustomTokenPrivacyType && pc.TokenIDStr == common.PRVCoinID.String() {
+		return false, false, errors.New("With tx custome token privacy, the tokenIDStr should not be PRV, but custom token.")
+	}
+
+	return true, true, nil
+}
+
+func (pc PDEContributionV3) ValidateMetadataByItself() bool {
+	return pc.Type == PDEContributionV3Meta
+}
+
+func (pc PDEContributionV3) Hash() *common.Hash {
+	record := pc.MetadataBase.Hash().String()
+	record += pc.PDEContributionPairID
+	record += pc.ContributorAddressStr
+	record += pc.TokenIDStr
+	record += strconv.FormatUint(pc.ContributedAmount, 10)
+	record += strconv.FormatUint(pc.FeeTier, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (pc *PDEContributionV3) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := PDEContributionV3Action{
+		Meta:    *pc,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(pc.Type), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (pc *PDEContributionV3) CalculateSize() uint64 {
+	return calculateSize(pc)
+}
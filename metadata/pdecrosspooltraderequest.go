@@ -43,6 +43,7 @@ type PDECrossPoolTradeAcceptedContent struct {
 	ShardID                  byte
 	RequestedTxID            common.Hash
 	AddingFee                uint64
+	FeeTier                  uint64 `json:",omitempty"`
 }
 
 type PDERefundCrossPoolTrade struct {
@@ -84,7 +84,7 @@ func (iRes PDECrossPoolTradeResponse) VerifyMinerCreatedTxBeforeGettingInBlock(
 		}
 		instMetaType := inst[0]
 		if instUsed[i] > 0 ||
-			instMetaType != strconv.Itoa(PDECrossPoolTradeRequestMeta) {
+			(instMetaType != strconv.Itoa(PDECrossPoolTradeRequestMeta) && instMetaType != strconv.Itoa(PDETradeRequestV3Meta)) {
 			continue
 		}
 		instTradeStatus := inst[2]
@@ -0,0 +1,187 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// PDELimitOrderRequest - privacy dex limit order, it rests in pde state until
+// selling SellAmount on the pool of the pair pays at least MinAcceptableAmount,
+// it expires at ExpiryBeaconHeight or never if ExpiryBeaconHeight is 0
+type PDELimitOrderRequest struct {
+	TokenIDToBuyStr     string
+	TokenIDToSellStr    string
+	SellAmount          uint64 // must be equal to vout value
+	MinAcceptableAmount uint64
+	ExpiryBeaconHeight  uint64
+	TraderAddressStr    string
+	MetadataBase
+}
+
+type PDELimitOrderRequestAction struct {
+	Meta    PDELimitOrderRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+// PDELimitOrder is the content of the instructions of a limit order, the
+// order id is the hash of the request tx
+type PDELimitOrder struct {
+	OrderID             common.Hash
+	TraderAddressStr    string
+	TokenIDToBuyStr     string
+	TokenIDToSellStr    string
+	SellAmount          uint64
+	MinAcceptableAmount uint64
+	ExpiryBeaconHeight  uint64
+	ShardID             byte
+}
+
+// PDECancelledLimitOrder is the content of the instruction of a limit order
+// cancelled by the request tx CancelTxReqID
+type PDECancelledLimitOrder struct {
+	PDELimitOrder
+	CancelTxReqID common.Hash
+}
+
+type PDEMatchedLimitOrder struct {
+	PDELimitOrder
+	ReceiveAmount            uint64
+	Token1IDStr              string
+	Token2IDStr              string
+	Token1PoolValueOperation TokenPoolValueOperation
+	Token2PoolValueOperation TokenPoolValueOperation
+}
+
+func NewPDELimitOrderRequest(
+	tokenIDToBuyStr string,
+	tokenIDToSellStr string,
+	sellAmount uint64,
+	minAcceptableAmount uint64,
+	expiryBeaconHeight uint64,
+	traderAddressStr string,
+	metaType int,
+) (*PDELimitOrderRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	pdeLimitOrderRequest := &PDELimitOrderRequest{
+		TokenIDToBuyStr:     tokenIDToBuyStr,
+		TokenIDToSellStr:    tokenIDToSellStr,
+		SellAmount:          sellAmount,
+		MinAcceptableAmount: minAcceptableAmount,
+		ExpiryBeaconHeight:  expiryBeaconHeight,
+		TraderAddressStr:    traderAddressStr,
+	}
+	pdeLimitOrderRequest.MetadataBase = metadataBase
+	return pdeLimitOrderRequest, nil
+}
+
+func (pc PDELimitOrderRequest) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	return true, nil
+}
+
+func (pc PDELimitOrderRequest) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	// Note: the metadata was already verified with *transaction.TxCustomToken level so no need to verify with *transaction.Tx level again as *transaction.Tx is embedding property of *transaction.TxCustomToken
+	if tx.GetType() == common.TxCustomTokenPrivacyType && reflect.TypeOf(tx).String() == "*transaction.Tx" {
+		return true, true, nil
+	}
+
+	keyWallet, err := wallet.Base58CheckDeserialize(pc.TraderAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TraderAddressStr incorrect"))
+	}
+	traderAddr := keyWallet.KeySet.PaymentAddress
+	if len(traderAddr.Pk) == 0 {
+		return false, false, errors.New("Wrong request info's trader address")
+	}
+	if !bytes.Equal(tx.GetSigPubKey()[:], traderAddr.Pk[:]) {
+		return false, false, errors.New("TraderAddress incorrect")
+	}
+
+	_, err = common.Hash{}.NewHashFromStr(pc.TokenIDToBuyStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TokenIDToBuyStr incorrect"))
+	}
+	if pc.TokenIDToSellStr == pc.TokenIDToBuyStr {
+		return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TokenIDToSellStr should be different from TokenIDToBuyStr"))
+	}
+	if pc.SellAmount == 0 {
+		return false, false, errors.New("Sell amount should be larger than 0")
+	}
+	if pc.MinAcceptableAmount == 0 {
+		return false, false, errors.New("Min acceptable amount of a limit order should be larger than 0")
+	}
+	if pc.ExpiryBeaconHeight != 0 && pc.ExpiryBeaconHeight <= beaconHeight {
+		return false, false, errors.New("Expiry beacon height should be larger than the current beacon height")
+	}
+
+	if !tx.IsCoinsBurning(chainRetriever, shardViewRetriever, beaconViewRetriever, beaconHeight) {
+		return false, false, errors.New("Must send coin to burning address")
+	}
+	if pc.SellAmount != tx.CalculateTxValue() {
+		return false, false, errors.New("Sell amount should be equal to the tx value")
+	}
+
+	if tx.GetType() == common.TxNormalType && pc.TokenIDToSellStr != common.PRVCoinID.String() {
+		return false, false, errors.New("With tx normal privacy, the tokenIDStr should be PRV, not custom token")
+	}
+
+	if tx.GetType() == common.TxCustomTokenPrivacyType {
+		if pc.TokenIDToSellStr == common.PRVCoinID.String() {
+			return false, false, errors.New("With custom token privacy tx, the tokenIDStr should not be PRV, but custom token")
+		}
+		tokenIDToSell, err := common.Hash{}.NewHashFromStr(pc.TokenIDToSellStr)
+		if err != nil {
+			return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TokenIDToSellStr incorrect"))
+		}
+		if !bytes.Equal(tx.GetTokenID()[:], tokenIDToSell[:]) {
+			return false, false, errors.New("Wrong request info's token id, it should be equal to tx's token id")
+		}
+	}
+	return true, true, nil
+}
+
+func (pc PDELimitOrderRequest) ValidateMetadataByItself() bool {
+	return pc.Type == PDELimitOrderRequestMeta
+}
+
+func (pc PDELimitOrderRequest) Hash() *common.Hash {
+	record := pc.MetadataBase.Hash().String()
+	record += pc.TokenIDToBuyStr
+	record += pc.TokenIDToSellStr
+	record += pc.TraderAddressStr
+	record += strconv.FormatUint(pc.SellAmount, 10)
+	record += strconv.FormatUint(pc.MinAcceptableAmount, 10)
+	record += strconv.FormatUint(pc.ExpiryBeaconHeight, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (pc *PDELimitOrderRequest) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := PDELimitOrderRequestAction{
+		Meta:    *pc,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(pc.Type), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (pc *PDELimitOrderRequest) CalculateSize() uint64 {
+	return calculateSize(pc)
+}
@@ -0,0 +1,148 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// PDELimitOrderResponse pays a matched limit order or refunds the selling
+// amount of a refunded, expired or cancelled one, RequestedTxID is the order id
+type PDELimitOrderResponse struct {
+	MetadataBase
+	OrderStatus   string
+	RequestedTxID common.Hash
+}
+
+func NewPDELimitOrderResponse(
+	orderStatus string,
+	requestedTxID common.Hash,
+	metaType int,
+) *PDELimitOrderResponse {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	return &PDELimitOrderResponse{
+		OrderStatus:   orderStatus,
+		RequestedTxID: requestedTxID,
+		MetadataBase:  metadataBase,
+	}
+}
+
+func (iRes PDELimitOrderResponse) CheckTransactionFee(tr Transaction, minFee uint64, beaconHeight int64, db *statedb.StateDB) bool {
+	// no need to have fee for this tx
+	return true
+}
+
+func (iRes PDELimitOrderResponse) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	// no need to validate tx with blockchain, just need to validate with requested tx (via RequestedTxID)
+	return false, nil
+}
+
+func (iRes PDELimitOrderResponse) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	return false, true, nil
+}
+
+func (iRes PDELimitOrderResponse) ValidateMetadataByItself() bool {
+	// The validation just need to check at tx level, so returning true here
+	return iRes.Type == PDELimitOrderResponseMeta
+}
+
+func (iRes PDELimitOrderResponse) Hash() *common.Hash {
+	record := iRes.RequestedTxID.String()
+	record += iRes.OrderStatus
+	record += iRes.MetadataBase.Hash().String()
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (iRes *PDELimitOrderResponse) CalculateSize() uint64 {
+	return calculateSize(iRes)
+}
+
+func (iRes PDELimitOrderResponse) VerifyMinerCreatedTxBeforeGettingInBlock(
+	txsInBlock []Transaction,
+	txsUsed []int,
+	insts [][]string,
+	instUsed []int,
+	shardID byte,
+	tx Transaction,
+	chainRetriever ChainRetriever,
+	ac *AccumulatedValues,
+	shardViewRetriever ShardViewRetriever,
+	beaconViewRetriever BeaconViewRetriever,
+) (bool, error) {
+	idx := -1
+	for i, inst := range insts {
+		if len(inst) < 4 { // this is not PDELimitOrderRequestMeta or PDECancelLimitOrderRequestMeta instruction
+			continue
+		}
+		instMetaType := inst[0]
+		if instUsed[i] > 0 ||
+			(instMetaType != strconv.Itoa(PDELimitOrderRequestMeta) && instMetaType != strconv.Itoa(PDECancelLimitOrderRequestMeta)) {
+			continue
+		}
+		instOrderStatus := inst[2]
+		if instOrderStatus != iRes.OrderStatus ||
+			(instOrderStatus != common.PDELimitOrderMatchedChainStatus &&
+				instOrderStatus != common.PDELimitOrderRefundChainStatus &&
+				instOrderStatus != common.PDELimitOrderExpiredChainStatus &&
+				instOrderStatus != common.PDELimitOrderCancelledChainStatus) {
+			continue
+		}
+
+		var order PDELimitOrder
+		receivingTokenIDStr := ""
+		receivingAmtFromInst := uint64(0)
+		contentBytes := []byte(inst[3])
+		if instOrderStatus == common.PDELimitOrderMatchedChainStatus {
+			var matchedOrder PDEMatchedLimitOrder
+			err := json.Unmarshal(contentBytes, &matchedOrder)
+			if err != nil {
+				Logger.log.Error("WARNING - VALIDATION: an error occured while parsing pde matched limit order content: ", err)
+				continue
+			}
+			order = matchedOrder.PDELimitOrder
+			receivingTokenIDStr = order.TokenIDToBuyStr
+			receivingAmtFromInst = matchedOrder.ReceiveAmount
+		} else {
+			err := json.Unmarshal(contentBytes, &order)
+			if err != nil {
+				Logger.log.Error("WARNING - VALIDATION: an error occured while parsing pde limit order content: ", err)
+				continue
+			}
+			receivingTokenIDStr = order.TokenIDToSellStr
+			receivingAmtFromInst = order.SellAmount
+		}
+
+		if !bytes.Equal(iRes.RequestedTxID[:], order.OrderID[:]) ||
+			shardID != order.ShardID {
+			continue
+		}
+		key, err := wallet.Base58CheckDeserialize(order.TraderAddressStr)
+		if err != nil {
+			Logger.log.Info("WARNING - VALIDATION: an error occured while deserializing receiver address string: ", err)
+			continue
+		}
+		_, pk, paidAmount, assetID := tx.GetTransferData()
+		if !bytes.Equal(key.KeySet.PaymentAddress.Pk[:], pk[:]) ||
+			receivingAmtFromInst != paidAmount ||
+			receivingTokenIDStr != assetID.String() {
+			continue
+		}
+		idx = i
+		break
+	}
+	if idx == -1 { // not found the limit order instruction for this response
+		return false, fmt.Errorf(fmt.Sprintf("no PDELimitOrderRequestMeta instruction found for PDELimitOrderResponse tx %s", tx.Hash().String()))
+	}
+	instUsed[idx] = 1
+	return true, nil
+}
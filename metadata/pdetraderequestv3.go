@@ -0,0 +1,162 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// PDETradeRequestV3 - privacy dex trade over v3 pools, the trading fee is
+// taken from the selling amount by the pools the trade is routed to
+type PDETradeRequestV3 struct {
+	TokenIDToBuyStr     string
+	TokenIDToSellStr    string
+	SellAmount          uint64 // must be equal to vout value
+	MinAcceptableAmount uint64
+	TraderAddressStr    string
+	MetadataBase
+}
+
+type PDETradeRequestV3Action struct {
+	Meta    PDETradeRequestV3
+	TxReqID common.Hash
+	ShardID byte
+}
+
+// PDETradingFeesDistributionV3 is the trading fee collected by a v3 pool in
+// a beacon block, it is added to the pool so it goes to the share holders
+type PDETradingFeesDistributionV3 struct {
+	FeeTier     uint64
+	Token1IDStr string
+	Token2IDStr string
+	TokenIDStr  string
+	FeeAmount   uint64
+}
+
+func NewPDETradeRequestV3(
+	tokenIDToBuyStr string,
+	tokenIDToSellStr string,
+	sellAmount uint64,
+	minAcceptableAmount uint64,
+	traderAddressStr string,
+	metaType int,
+) (*PDETradeRequestV3, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	pdeTradeRequest := &PDETradeRequestV3{
+		TokenIDToBuyStr:     tokenIDToBuyStr,
+		TokenIDToSellStr:    tokenIDToSellStr,
+		SellAmount:          sellAmount,
+		MinAcceptableAmount: minAcceptableAmount,
+		TraderAddressStr:    traderAddressStr,
+	}
+	pdeTradeRequest.MetadataBase = metadataBase
+	return pdeTradeRequest, nil
+}
+
+func (pc PDETradeRequestV3) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	return true, nil
+}
+
+func (pc PDETradeRequestV3) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	// Note: the metadata was already verified with *transaction.TxCustomToken level so no need to verify with *transaction.Tx level again as *transaction.Tx is embedding property of *transaction.TxCustomToken
+	if tx.GetType() == common.TxCustomTokenPrivacyType && reflect.TypeOf(tx).String() == "*transaction.Tx" {
+		return true, true, nil
+	}
+
+	keyWallet, err := wallet.Base58CheckDeserialize(pc.TraderAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TraderAddressStr incorrect"))
+	}
+	traderAddr := keyWallet.KeySet.PaymentAddress
+
+	if len(traderAddr.Pk) == 0 {
+		return false, false, errors.New("Wrong request info's trader address")
+	}
+
+	if !bytes.Equal(tx.GetSigPubKey()[:], traderAddr.Pk[:]) {
+		return false, false, errors.New("TraderAddress incorrect")
+	}
+
+	_, err = common.Hash{}.NewHashFromStr(pc.TokenIDToBuyStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TokenIDToBuyStr incorrect"))
+	}
+
+	if pc.TokenIDToSellStr == pc.TokenIDToBuyStr {
+		return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TokenIDToSellStr should be different from TokenIDToBuyStr"))
+	}
+
+	if pc.SellAmount == 0 {
+		return false, false, errors.New("Sell amount should be larger than 0")
+	}
+
+	if !tx.IsCoinsBurning(chainRetriever, shardViewRetriever, beaconViewRetriever, beaconHeight) {
+		return false, false, errors.New("Must send coin to burning address")
+	}
+	if pc.SellAmount != tx.CalculateTxValue() {
+		return false, false, errors.New("Sell amount should be equal to the tx value")
+	}
+
+	if tx.GetType() == common.TxNormalType && pc.TokenIDToSellStr != common.PRVCoinID.String() {
+		return false, false, errors.New("With tx normal privacy, the tokenIDStr should be PRV, not custom token")
+	}
+
+	if tx.GetType() == common.TxCustomTokenPrivacyType {
+		if pc.TokenIDToSellStr == common.PRVCoinID.String() {
+			return false, false, errors.New("With custom token privacy tx, the tokenIDStr should not be PRV, but custom token")
+		}
+		tokenIDToSell, err := common.Hash{}.NewHashFromStr(pc.TokenIDToSellStr)
+		if err != nil {
+			return false, false, NewMetadataTxError(IssuingRequestNewIssuingRequestFromMapEror, errors.New("TokenIDToSellStr incorrect"))
+		}
+		if !bytes.Equal(tx.GetTokenID()[:], tokenIDToSell[:]) {
+			return false, false, errors.New("Wrong request info's token id, it should be equal to tx's token id")
+		}
+	}
+
+	return true, true, nil
+}
+
+func (pc PDETradeRequestV3) ValidateMetadataByItself() bool {
+	return pc.Type == PDETradeRequestV3Meta
+}
+
+func (pc PDETradeRequestV3) Hash() *common.Hash {
+	record := pc.MetadataBase.Hash().String()
+	record += pc.TokenIDToBuyStr
+	record += pc.TokenIDToSellStr
+	record += pc.TraderAddressStr
+	record += strconv.FormatUint(pc.SellAmount, 10)
+	record += strconv.FormatUint(pc.MinAcceptableAmount, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (pc *PDETradeRequestV3) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := PDETradeRequestV3Action{
+		Meta:    *pc,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(pc.Type), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (pc *PDETradeRequestV3) CalculateSize() uint64 {
+	return calculateSize(pc)
+}
@@ -36,6 +36,7 @@ type PDEWithdrawalAcceptedContent struct {
 	PairToken2IDStr      string
 	TxReqID              common.Hash
 	ShardID              byte
+	FeeTier              uint64 `json:",omitempty"`
 }
 
 func NewPDEWithdrawalRequest(
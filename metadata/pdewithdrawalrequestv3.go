@@ -0,0 +1,119 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// PDEWithdrawalRequestV3 - privacy dex withdrawal from the pool of a pair at a fee tier
+type PDEWithdrawalRequestV3 struct {
+	WithdrawerAddressStr  string
+	WithdrawalToken1IDStr string
+	WithdrawalToken2IDStr string
+	WithdrawalShareAmt    uint64
+	FeeTier               uint64
+	MetadataBase
+}
+
+type PDEWithdrawalRequestV3Action struct {
+	Meta    PDEWithdrawalRequestV3
+	TxReqID common.Hash
+	ShardID byte
+}
+
+func NewPDEWithdrawalRequestV3(
+	withdrawerAddressStr string,
+	withdrawalToken1IDStr string,
+	withdrawalToken2IDStr string,
+	withdrawalShareAmt uint64,
+	feeTier uint64,
+	metaType int,
+) (*PDEWithdrawalRequestV3, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	pdeWithdrawalRequest := &PDEWithdrawalRequestV3{
+		WithdrawerAddressStr:  withdrawerAddressStr,
+		WithdrawalToken1IDStr: withdrawalToken1IDStr,
+		WithdrawalToken2IDStr: withdrawalToken2IDStr,
+		WithdrawalShareAmt:    withdrawalShareAmt,
+		FeeTier:               feeTier,
+	}
+	pdeWithdrawalRequest.MetadataBase = metadataBase
+	return pdeWithdrawalRequest, nil
+}
+
+func (pc PDEWithdrawalRequestV3) ValidateTxWithBlockChain(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte, transactionStateDB *statedb.StateDB) (bool, error) {
+	return true, nil
+}
+
+func (pc PDEWithdrawalRequestV3) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, tx Transaction) (bool, bool, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(pc.WithdrawerAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(PDEWithdrawalRequestFromMapError, errors.New("WithdrawerAddressStr incorrect"))
+	}
+	withdrawerAddr := keyWallet.KeySet.PaymentAddress
+	if len(withdrawerAddr.Pk) == 0 {
+		return false, false, errors.New("Wrong request info's withdrawer address")
+	}
+	if !bytes.Equal(tx.GetSigPubKey()[:], withdrawerAddr.Pk[:]) {
+		return false, false, errors.New("WithdrawerAddr incorrect")
+	}
+	_, err = common.Hash{}.NewHashFromStr(pc.WithdrawalToken1IDStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(PDEWithdrawalRequestFromMapError, errors.New("WithdrawalTokenID1Str incorrect"))
+	}
+	_, err = common.Hash{}.NewHashFromStr(pc.WithdrawalToken2IDStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(PDEWithdrawalRequestFromMapError, errors.New("WithdrawalTokenID2Str incorrect"))
+	}
+	if pc.WithdrawalShareAmt == 0 {
+		return false, false, NewMetadataTxError(PDEWithdrawalRequestFromMapError, errors.New("WithdrawalShareAmt should be large than 0"))
+	}
+	if !IsValidPDEFeeTier(pc.FeeTier) {
+		return false, false, NewMetadataTxError(PDEWithdrawalRequestFromMapError, errors.New("FeeTier is not supported"))
+	}
+	return true, true, nil
+}
+
+func (pc PDEWithdrawalRequestV3) ValidateMetadataByItself() bool {
+	return pc.Type == PDEWithdrawalRequestV3Meta
+}
+
+func (pc PDEWithdrawalRequestV3) Hash() *common.Hash {
+	record := pc.MetadataBase.Hash().String()
+	record += pc.WithdrawerAddressStr
+	record += pc.WithdrawalToken1IDStr
+	record += pc.WithdrawalToken2IDStr
+	record += strconv.FormatUint(pc.WithdrawalShareAmt, 10)
+	record += strconv.FormatUint(pc.FeeTier, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (pc *PDEWithdrawalRequestV3) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := PDEWithdrawalRequestV3Action{
+		Meta:    *pc,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(pc.Type), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (pc *PDEWithdrawalRequestV3) CalculateSize() uint64 {
+	return calculateSize(pc)
+}
@@ -73,7 +73,7 @@ func (iRes PDEWithdrawalResponse) VerifyMinerCreatedTxBeforeGettingInBlock(txsIn
 		}
 		instMetaType := inst[0]
 		if instUsed[i] > 0 ||
-			instMetaType != strconv.Itoa(PDEWithdrawalRequestMeta) {
+			(instMetaType != strconv.Itoa(PDEWithdrawalRequestMeta) && instMetaType != strconv.Itoa(PDEWithdrawalRequestV3Meta)) {
 			continue
 		}
 
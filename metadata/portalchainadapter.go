@@ -0,0 +1,117 @@
+package metadata
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// PortalChainAdapter hides the specifics of the external chain of a portal
+// token, so that the portal requests are handled the same way for all chains.
+// A new collateral-backed asset is added by registering its adapter, backed by
+// a relaying package, and adding its token to common.PortalSupportedIncTokenIDs.
+type PortalChainAdapter interface {
+	// GetChainID returns the id of the external chain the relayed headers belong to
+	GetChainID(chainRetriever ChainRetriever) string
+	// IsValidRemoteAddress checks an address on the external chain
+	IsValidRemoteAddress(chainRetriever ChainRetriever, remoteAddress string, chainID string) bool
+	// EncodePortingMemo returns the memo users attach to the porting tx of portingID
+	EncodePortingMemo(portingID string) (string, error)
+	// EncodeRedeemMemo returns the memo custodians attach to the tx returning redeemID
+	EncodeRedeemMemo(redeemID string, custodianIncAddress string) (string, error)
+	// EncodeTransferMemo returns the memo custodians attach to the tx handing transferID over to the receiver
+	EncodeTransferMemo(transferID string, receiverIncAddress string) (string, error)
+	// VerifyPortingMemo decodes the memo of a porting tx and checks it is for portingID
+	VerifyPortingMemo(memo string, portingID string) error
+	// VerifyRedeemMemo decodes the memo of a redeem tx and checks it is for redeemID and the custodian
+	VerifyRedeemMemo(memo string, redeemID string, custodianIncAddress string) error
+	// VerifyTransferMemo decodes the memo of a transfer tx and checks it is for transferID and the receiver
+	VerifyTransferMemo(memo string, transferID string, receiverIncAddress string) error
+	// ParseAndVerifyProof parses a base64 encoded proof and verifies it against the relayed headers
+	ParseAndVerifyProof(chainRetriever ChainRetriever, proof string) (*PortalExternalTx, error)
+	// ConvertIncAmountToExternalAmount converts an amount of ptoken (decimal 9) to the unit of the external chain
+	ConvertIncAmountToExternalAmount(incAmount int64) int64
+}
+
+// PortalExternalTx is the part of a verified external tx the portal checks
+type PortalExternalTx struct {
+	Memo    string
+	Inputs  []string // addresses the inputs are spent from, empty for an input whose address is unknown
+	Outputs []PortalExternalTxOutput
+}
+
+// PortalExternalTxOutput is an amount, in the unit of the external chain,
+// transferred to an address of the external chain
+type PortalExternalTxOutput struct {
+	Address string
+	Amount  int64
+}
+
+// GetTransferredAmount returns the amount of the first output to remoteAddress
+func (tx PortalExternalTx) GetTransferredAmount(remoteAddress string) (int64, bool) {
+	for _, out := range tx.Outputs {
+		if out.Address == remoteAddress {
+			return out.Amount, true
+		}
+	}
+	return 0, false
+}
+
+// VerifyTransfer checks that at least the ptoken amount incAmount was
+// transferred to remoteAddress
+func (tx PortalExternalTx) VerifyTransfer(adapter PortalChainAdapter, remoteAddress string, incAmount uint64) error {
+	amountNeedToBeTransfer := adapter.ConvertIncAmountToExternalAmount(int64(incAmount))
+	amountTransfer, ok := tx.GetTransferredAmount(remoteAddress)
+	if !ok {
+		return fmt.Errorf("Receiver address is invalid, expected %v", remoteAddress)
+	}
+	if amountTransfer < amountNeedToBeTransfer {
+		return fmt.Errorf("the transferred amount to %s must be equal to or greater than %d, but got %d",
+			remoteAddress, amountNeedToBeTransfer, amountTransfer)
+	}
+	return nil
+}
+
+// VerifySender checks that all the inputs of the tx are spent from remoteAddress
+func (tx PortalExternalTx) VerifySender(remoteAddress string) error {
+	if len(tx.Inputs) == 0 {
+		return errors.New("the external tx has no input")
+	}
+	for _, input := range tx.Inputs {
+		if input != remoteAddress {
+			return fmt.Errorf("Sender address is invalid, expected %v, but got %v", remoteAddress, input)
+		}
+	}
+	return nil
+}
+
+var portalChainAdapters = map[string]PortalChainAdapter{
+	common.PortalBTCIDStr: &PortalBTCChainAdapter{},
+	common.PortalBNBIDStr: &PortalBNBChainAdapter{},
+}
+
+// RegisterPortalChainAdapter sets the adapter of the external chain of the
+// portal token tokenID, it must be called before the chain starts
+func RegisterPortalChainAdapter(tokenID string, adapter PortalChainAdapter) error {
+	if adapter == nil {
+		return errors.New("Portal chain adapter should not be null")
+	}
+	if _, ok := portalChainAdapters[tokenID]; ok {
+		return fmt.Errorf("Portal chain adapter of token %v is already registered", tokenID)
+	}
+	portalChainAdapters[tokenID] = adapter
+	return nil
+}
+
+// GetPortalChainAdapter returns the adapter of the portal token tokenID
+func GetPortalChainAdapter(tokenID string) (PortalChainAdapter, error) {
+	if !common.IsPortalToken(tokenID) {
+		return nil, fmt.Errorf("TokenID %v is not supported currently on Portal", tokenID)
+	}
+	adapter, ok := portalChainAdapters[tokenID]
+	if !ok {
+		return nil, fmt.Errorf("Portal chain adapter of token %v is not registered", tokenID)
+	}
+	return adapter, nil
+}
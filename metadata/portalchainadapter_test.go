@@ -0,0 +1,82 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPortalChainAdapter(t *testing.T) {
+	for _, tokenID := range common.PortalSupportedIncTokenIDs {
+		adapter, err := GetPortalChainAdapter(tokenID)
+		assert.Nil(t, err)
+		assert.NotNil(t, adapter)
+	}
+	_, err := GetPortalChainAdapter(common.PRVIDStr)
+	assert.NotNil(t, err)
+
+	//the adapter of a supported token cannot be replaced
+	assert.NotNil(t, RegisterPortalChainAdapter(common.PortalBTCIDStr, PortalBNBChainAdapter{}))
+	assert.NotNil(t, RegisterPortalChainAdapter("newtoken", nil))
+}
+
+func TestPortalChainAdapter_Memo(t *testing.T) {
+	custodianIncAddr := "12Rwz4HXkVABgRnSb5Gfu1FaJ7auo3fLNXVGFhxx1dSytxHpWhbkimT1Mv5Z2oCMsssSXTVsapY8QGBZd2J4mPiCTzJAtMyCzb4dDcy"
+	for _, tokenID := range common.PortalSupportedIncTokenIDs {
+		adapter, _ := GetPortalChainAdapter(tokenID)
+
+		memo, err := adapter.EncodePortingMemo("porting-10")
+		assert.Nil(t, err)
+		assert.Nil(t, adapter.VerifyPortingMemo(memo, "porting-10"))
+		assert.NotNil(t, adapter.VerifyPortingMemo(memo, "porting-11"))
+
+		memo, err = adapter.EncodeRedeemMemo("bnb13", custodianIncAddr)
+		assert.Nil(t, err)
+		assert.Nil(t, adapter.VerifyRedeemMemo(memo, "bnb13", custodianIncAddr))
+		assert.NotNil(t, adapter.VerifyRedeemMemo(memo, "bnb14", custodianIncAddr))
+		assert.NotNil(t, adapter.VerifyRedeemMemo(memo, "bnb13", "custodian"))
+
+		//a redeem memo cannot be replayed as a transfer memo
+		assert.NotNil(t, adapter.VerifyTransferMemo(memo, "bnb13", custodianIncAddr))
+		memo, err = adapter.EncodeTransferMemo("transfer-1", custodianIncAddr)
+		assert.Nil(t, err)
+		assert.Nil(t, adapter.VerifyTransferMemo(memo, "transfer-1", custodianIncAddr))
+		assert.NotNil(t, adapter.VerifyTransferMemo(memo, "transfer-2", custodianIncAddr))
+		assert.NotNil(t, adapter.VerifyRedeemMemo(memo, "transfer-1", custodianIncAddr))
+	}
+
+	//the memos of the BNB txs are unchanged
+	memo, _ := PortalBNBChainAdapter{}.EncodePortingMemo("porting-10")
+	assert.Equal(t, "eyJQb3J0aW5nSUQiOiJwb3J0aW5nLTEwIn0=", memo)
+	assert.NotNil(t, PortalBNBChainAdapter{}.VerifyPortingMemo("porting-10", "porting-10"))
+}
+
+func TestPortalExternalTx_VerifyTransfer(t *testing.T) {
+	externalTx := PortalExternalTx{
+		Outputs: []PortalExternalTxOutput{
+			{Address: "address1", Amount: 100},
+			{Address: "address2", Amount: 50},
+			{Address: "address2", Amount: 200},
+		},
+	}
+	adapter := PortalBTCChainAdapter{}
+	assert.Nil(t, externalTx.VerifyTransfer(adapter, "address1", 1000))
+	assert.NotNil(t, externalTx.VerifyTransfer(adapter, "address1", 1010))
+	//only the first output to an address is counted
+	assert.NotNil(t, externalTx.VerifyTransfer(adapter, "address2", 1000))
+	assert.NotNil(t, externalTx.VerifyTransfer(adapter, "address3", 10))
+}
+
+func TestPortalExternalTx_VerifySender(t *testing.T) {
+	externalTx := PortalExternalTx{Inputs: []string{"address1", "address1"}}
+	assert.Nil(t, externalTx.VerifySender("address1"))
+	assert.NotNil(t, externalTx.VerifySender("address2"))
+
+	//every input is spent from the sender
+	externalTx.Inputs = append(externalTx.Inputs, "address2")
+	assert.NotNil(t, externalTx.VerifySender("address1"))
+	externalTx.Inputs = []string{"address1", ""}
+	assert.NotNil(t, externalTx.VerifySender("address1"))
+	assert.NotNil(t, PortalExternalTx{}.VerifySender("address1"))
+}
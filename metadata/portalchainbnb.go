@@ -0,0 +1,169 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/binance-chain/go-sdk/types/msg"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/relaying/bnb"
+)
+
+type RedeemMemoBNB struct {
+	RedeemID                  string `json:"RedeemID"`
+	CustodianIncognitoAddress string `json:"CustodianIncognitoAddress"`
+}
+
+type PortingMemoBNB struct {
+	PortingID string `json:"PortingID"`
+}
+
+type TransferMemoBNB struct {
+	TransferID               string `json:"TransferID"`
+	ReceiverIncognitoAddress string `json:"ReceiverIncognitoAddress"`
+}
+
+// PortalBNBChainAdapter verifies the BNB txs against the data hash of the
+// BNB headers, after bnb.MinConfirmationsBlock confirmations
+type PortalBNBChainAdapter struct{}
+
+func (adapter PortalBNBChainAdapter) GetChainID(chainRetriever ChainRetriever) string {
+	return chainRetriever.GetBNBChainID()
+}
+
+func (adapter PortalBNBChainAdapter) IsValidRemoteAddress(chainRetriever ChainRetriever, remoteAddress string, chainID string) bool {
+	return bnb.IsValidBNBAddress(remoteAddress, chainID)
+}
+
+// EncodePortingMemo returns the base64 encoded json of the porting memo
+func (adapter PortalBNBChainAdapter) EncodePortingMemo(portingID string) (string, error) {
+	memoBytes, err := json.Marshal(PortingMemoBNB{PortingID: portingID})
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(memoBytes), nil
+}
+
+// EncodeRedeemMemo returns the base64 encoded hash of the json of the redeem memo
+func (adapter PortalBNBChainAdapter) EncodeRedeemMemo(redeemID string, custodianIncAddress string) (string, error) {
+	memoBytes, err := json.Marshal(RedeemMemoBNB{RedeemID: redeemID, CustodianIncognitoAddress: custodianIncAddress})
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(common.HashB(memoBytes)), nil
+}
+
+// EncodeTransferMemo returns the base64 encoded hash of the json of the transfer memo
+func (adapter PortalBNBChainAdapter) EncodeTransferMemo(transferID string, receiverIncAddress string) (string, error) {
+	memoBytes, err := json.Marshal(TransferMemoBNB{TransferID: transferID, ReceiverIncognitoAddress: receiverIncAddress})
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(common.HashB(memoBytes)), nil
+}
+
+func (adapter PortalBNBChainAdapter) VerifyPortingMemo(memo string, portingID string) error {
+	memoBytes, err := base64.StdEncoding.DecodeString(memo)
+	if err != nil {
+		return fmt.Errorf("Can not decode memo in tx bnb proof %v", err)
+	}
+	var portingMemo PortingMemoBNB
+	err = json.Unmarshal(memoBytes, &portingMemo)
+	if err != nil {
+		return fmt.Errorf("Can not unmarshal memo in tx bnb proof %v", err)
+	}
+	if portingMemo.PortingID != portingID {
+		return errors.New("PortingId in memoTx is not matched with portingID in metadata")
+	}
+	return nil
+}
+
+func (adapter PortalBNBChainAdapter) VerifyRedeemMemo(memo string, redeemID string, custodianIncAddress string) error {
+	memoHashBytes, err := base64.StdEncoding.DecodeString(memo)
+	if err != nil {
+		return fmt.Errorf("Can not decode memo in tx bnb proof %v", err)
+	}
+	expectedRedeemMemoBytes, _ := json.Marshal(RedeemMemoBNB{RedeemID: redeemID, CustodianIncognitoAddress: custodianIncAddress})
+	if !bytes.Equal(memoHashBytes, common.HashB(expectedRedeemMemoBytes)) {
+		return errors.New("Memo redeem is invalid")
+	}
+	return nil
+}
+
+func (adapter PortalBNBChainAdapter) VerifyTransferMemo(memo string, transferID string, receiverIncAddress string) error {
+	memoHashBytes, err := base64.StdEncoding.DecodeString(memo)
+	if err != nil {
+		return fmt.Errorf("Can not decode memo in tx bnb proof %v", err)
+	}
+	expectedTransferMemoBytes, _ := json.Marshal(TransferMemoBNB{TransferID: transferID, ReceiverIncognitoAddress: receiverIncAddress})
+	if !bytes.Equal(memoHashBytes, common.HashB(expectedTransferMemoBytes)) {
+		return errors.New("Memo transfer is invalid")
+	}
+	return nil
+}
+
+func (adapter PortalBNBChainAdapter) ParseAndVerifyProof(chainRetriever ChainRetriever, proof string) (*PortalExternalTx, error) {
+	txProofBNB, err := bnb.ParseBNBProofFromB64EncodeStr(proof)
+	if err != nil {
+		return nil, fmt.Errorf("Proof is invalid %v", err)
+	}
+
+	// check minimum confirmations block of bnb proof
+	latestBNBBlockHeight, err2 := chainRetriever.GetLatestBNBBlkHeight()
+	if err2 != nil {
+		return nil, fmt.Errorf("Can not get latest relaying bnb block height %v", err2)
+	}
+	if latestBNBBlockHeight < txProofBNB.BlockHeight+bnb.MinConfirmationsBlock {
+		return nil, fmt.Errorf("Not enough min bnb confirmations block %v, latestBNBBlockHeight %v - txProofBNB.BlockHeight %v",
+			bnb.MinConfirmationsBlock, latestBNBBlockHeight, txProofBNB.BlockHeight)
+	}
+
+	dataHash, err2 := chainRetriever.GetBNBDataHash(txProofBNB.BlockHeight)
+	if err2 != nil {
+		return nil, fmt.Errorf("Error when get data hash in blockHeight %v - %v", txProofBNB.BlockHeight, err2)
+	}
+	isValid, err := txProofBNB.Verify(dataHash)
+	if !isValid || err != nil {
+		return nil, fmt.Errorf("Verify txProofBNB failed %v", err)
+	}
+
+	// parse Tx from Data in txProofBNB
+	txBNB, err := bnb.ParseTxFromData(txProofBNB.Proof.Data)
+	if err != nil {
+		return nil, fmt.Errorf("Data in proof is invalid %v", err)
+	}
+	if len(txBNB.Msgs) == 0 {
+		return nil, errors.New("Tx bnb proof has no message")
+	}
+	sendMsg, ok := txBNB.Msgs[0].(msg.SendMsg)
+	if !ok {
+		return nil, errors.New("Tx bnb proof is not a send tx")
+	}
+
+	externalTx := &PortalExternalTx{Memo: txBNB.Memo}
+	chainID := adapter.GetChainID(chainRetriever)
+	for _, in := range sendMsg.Inputs {
+		addr, _ := bnb.GetAccAddressString(&in.Address, chainID)
+		externalTx.Inputs = append(externalTx.Inputs, addr)
+	}
+	for _, out := range sendMsg.Outputs {
+		addr, _ := bnb.GetAccAddressString(&out.Address, chainID)
+		// calculate amount that was transferred to the address
+		amountTransfer := int64(0)
+		for _, coin := range out.Coins {
+			if coin.Denom == bnb.DenomBNB {
+				amountTransfer += coin.Amount
+			}
+		}
+		externalTx.Outputs = append(externalTx.Outputs, PortalExternalTxOutput{Address: addr, Amount: amountTransfer})
+	}
+	return externalTx, nil
+}
+
+// ConvertIncAmountToExternalAmount converts amount in inc chain (decimal 9) to amount in bnb chain (decimal 8)
+func (adapter PortalBNBChainAdapter) ConvertIncAmountToExternalAmount(incAmount int64) int64 {
+	return incAmount / 10 // incPBNBAmount / 1^9 * 1^8
+}
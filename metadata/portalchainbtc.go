@@ -0,0 +1,108 @@
+package metadata
+
+import (
+	"errors"
+	"fmt"
+
+	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
+)
+
+// PortalBTCChainAdapter verifies the BTC txs against the headers relayed to
+// the BTC header chain, the memo is the hash of the request attached in the
+// OP_RETURN output
+type PortalBTCChainAdapter struct{}
+
+func (adapter PortalBTCChainAdapter) GetChainID(chainRetriever ChainRetriever) string {
+	return chainRetriever.GetBTCChainID()
+}
+
+func (adapter PortalBTCChainAdapter) IsValidRemoteAddress(chainRetriever ChainRetriever, remoteAddress string, chainID string) bool {
+	btcHeaderChain := chainRetriever.GetBTCHeaderChain()
+	if btcHeaderChain == nil {
+		return false
+	}
+	return btcHeaderChain.IsBTCAddressValid(remoteAddress)
+}
+
+func (adapter PortalBTCChainAdapter) EncodePortingMemo(portingID string) (string, error) {
+	return btcrelaying.HashAndEncodeBase58(portingID), nil
+}
+
+func (adapter PortalBTCChainAdapter) EncodeRedeemMemo(redeemID string, custodianIncAddress string) (string, error) {
+	rawMsg := fmt.Sprintf("%s%s", redeemID, custodianIncAddress)
+	return btcrelaying.HashAndEncodeBase58(rawMsg), nil
+}
+
+// EncodeTransferMemo prefixes the message so that it never collides with a redeem memo
+func (adapter PortalBTCChainAdapter) EncodeTransferMemo(transferID string, receiverIncAddress string) (string, error) {
+	rawMsg := fmt.Sprintf("transfer%s%s", transferID, receiverIncAddress)
+	return btcrelaying.HashAndEncodeBase58(rawMsg), nil
+}
+
+func (adapter PortalBTCChainAdapter) VerifyPortingMemo(memo string, portingID string) error {
+	encodedMsg, _ := adapter.EncodePortingMemo(portingID)
+	if memo != encodedMsg {
+		return errors.New("PortingId in the btc attached message is not matched with portingID in metadata")
+	}
+	return nil
+}
+
+func (adapter PortalBTCChainAdapter) VerifyRedeemMemo(memo string, redeemID string, custodianIncAddress string) error {
+	encodedMsg, _ := adapter.EncodeRedeemMemo(redeemID, custodianIncAddress)
+	if memo != encodedMsg {
+		return fmt.Errorf("The hash of combination of UniqueRedeemID(%s) and CustodianAddressStr(%s) is not matched to tx's attached message", redeemID, custodianIncAddress)
+	}
+	return nil
+}
+
+func (adapter PortalBTCChainAdapter) VerifyTransferMemo(memo string, transferID string, receiverIncAddress string) error {
+	encodedMsg, _ := adapter.EncodeTransferMemo(transferID, receiverIncAddress)
+	if memo != encodedMsg {
+		return fmt.Errorf("The hash of combination of UniqueTransferID(%s) and ReceiverAddressStr(%s) is not matched to tx's attached message", transferID, receiverIncAddress)
+	}
+	return nil
+}
+
+func (adapter PortalBTCChainAdapter) ParseAndVerifyProof(chainRetriever ChainRetriever, proof string) (*PortalExternalTx, error) {
+	btcChain := chainRetriever.GetBTCHeaderChain()
+	if btcChain == nil {
+		return nil, errors.New("BTC relaying chain should not be null")
+	}
+	btcTxProof, err := btcrelaying.ParseBTCProofFromB64EncodeStr(proof)
+	if err != nil {
+		return nil, fmt.Errorf("Proof is invalid %v", err)
+	}
+
+	isValid, err := btcChain.VerifyTxWithMerkleProofs(btcTxProof)
+	if !isValid || err != nil {
+		return nil, fmt.Errorf("Verify btcTxProof failed %v", err)
+	}
+
+	// extract attached message from txOut's OP_RETURN
+	btcAttachedMsg, err := btcrelaying.ExtractAttachedMsgFromTx(btcTxProof.BTCTx)
+	if err != nil {
+		return nil, fmt.Errorf("Could not extract attached message from BTC tx proof with err: %v", err)
+	}
+
+	externalTx := &PortalExternalTx{Memo: btcAttachedMsg}
+	for _, in := range btcTxProof.BTCTx.TxIn {
+		addrStr, err := btcChain.ExtractPaymentAddrStrFromTxIn(in)
+		if err != nil {
+			Logger.log.Warnf("[portal] ExtractPaymentAddrStrFromTxIn: could not extract payment address string from input with err: %v\n", err)
+		}
+		externalTx.Inputs = append(externalTx.Inputs, addrStr)
+	}
+	for _, out := range btcTxProof.BTCTx.TxOut {
+		addrStr, err := btcChain.ExtractPaymentAddrStrFromPkScript(out.PkScript)
+		if err != nil {
+			Logger.log.Warnf("[portal] ExtractPaymentAddrStrFromPkScript: could not extract payment address string from pkscript with err: %v\n", err)
+			continue
+		}
+		externalTx.Outputs = append(externalTx.Outputs, PortalExternalTxOutput{Address: addrStr, Amount: out.Value})
+	}
+	return externalTx, nil
+}
+
+func (adapter PortalBTCChainAdapter) ConvertIncAmountToExternalAmount(incAmount int64) int64 {
+	return btcrelaying.ConvertIncPBTCAmountToExternalBTCAmount(incAmount)
+}
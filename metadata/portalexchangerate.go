@@ -69,15 +69,17 @@ func (portalExchangeRates PortalExchangeRates) ValidateTxWithBlockChain(
 	db *statedb.StateDB,
 ) (bool, error) {
 	// NOTE: verify supported tokens pair as needed
+	isFeeder, err := IsPortalFeeder(chainRetriever, beaconViewRetriever.GetBeaconFeatureStateDB(), portalExchangeRates.SenderAddress)
+	if err != nil {
+		return false, err
+	}
+	if !isFeeder {
+		return false, fmt.Errorf("Sender %v is not a registered feeder", portalExchangeRates.SenderAddress)
+	}
 	return true, nil
 }
 
 func (portalExchangeRates PortalExchangeRates) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, txr Transaction) (bool, bool, error) {
-	feederAddress := chainRetriever.GetPortalFeederAddress()
-	if portalExchangeRates.SenderAddress != feederAddress {
-		return false, false, fmt.Errorf("Sender must be feeder's address %v\n", feederAddress)
-	}
-
 	keyWallet, err := wallet.Base58CheckDeserialize(portalExchangeRates.SenderAddress)
 	if err != nil {
 		return false, false, errors.New("SenderAddress incorrect")
@@ -0,0 +1,163 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// PortalRebalanceCollateral - portal custodian moves a part of the collateral locked for a ptoken
+// to the collateral locked for another ptoken it holds
+// metadata - custodian requests rebalance collateral - create normal tx with this metadata
+type PortalRebalanceCollateral struct {
+	MetadataBase
+	CustodianAddressStr string
+	FromTokenID         string // pTokenID in incognito chain
+	ToTokenID           string // pTokenID in incognito chain
+	Amount              uint64 // prv
+}
+
+// PortalRebalanceCollateralAction - shard validator creates instruction that contain this action content
+type PortalRebalanceCollateralAction struct {
+	Meta    PortalRebalanceCollateral
+	TxReqID common.Hash
+	ShardID byte
+}
+
+// PortalRebalanceCollateralContent - Beacon builds a new instruction with this content after receiving a instruction from shard
+// It will be appended to beaconBlock
+// both accepted and rejected status
+type PortalRebalanceCollateralContent struct {
+	CustodianAddressStr string
+	FromTokenID         string
+	ToTokenID           string
+	Amount              uint64
+	TxReqID             common.Hash
+	ShardID             byte
+}
+
+// PortalRebalanceCollateralStatus - Beacon tracks status of rebalance collateral request into db
+type PortalRebalanceCollateralStatus struct {
+	Status              byte
+	CustodianAddressStr string
+	FromTokenID         string
+	ToTokenID           string
+	Amount              uint64
+	TxReqID             common.Hash
+}
+
+func NewPortalRebalanceCollateral(
+	metaType int,
+	custodianAddressStr string,
+	fromTokenID string,
+	toTokenID string,
+	amount uint64) (*PortalRebalanceCollateral, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	rebalanceCollateralMeta := &PortalRebalanceCollateral{
+		CustodianAddressStr: custodianAddressStr,
+		FromTokenID:         fromTokenID,
+		ToTokenID:           toTokenID,
+		Amount:              amount,
+	}
+	rebalanceCollateralMeta.MetadataBase = metadataBase
+	return rebalanceCollateralMeta, nil
+}
+
+func NewPortalRebalanceCollateralStatus(
+	status byte,
+	content PortalRebalanceCollateralContent) *PortalRebalanceCollateralStatus {
+	return &PortalRebalanceCollateralStatus{
+		Status:              status,
+		CustodianAddressStr: content.CustodianAddressStr,
+		FromTokenID:         content.FromTokenID,
+		ToTokenID:           content.ToTokenID,
+		Amount:              content.Amount,
+		TxReqID:             content.TxReqID,
+	}
+}
+
+func (meta PortalRebalanceCollateral) ValidateTxWithBlockChain(
+	txr Transaction,
+	chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever,
+	shardID byte,
+	db *statedb.StateDB,
+) (bool, error) {
+	return true, nil
+}
+
+func (meta PortalRebalanceCollateral) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, txr Transaction) (bool, bool, error) {
+	// validate CustodianAddressStr
+	keyWallet, err := wallet.Base58CheckDeserialize(meta.CustodianAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(PortalRequestPTokenParamError, errors.New("Custodian incognito address is invalid"))
+	}
+	incogAddr := keyWallet.KeySet.PaymentAddress
+	if len(incogAddr.Pk) == 0 {
+		return false, false, NewMetadataTxError(PortalRequestPTokenParamError, errors.New("Custodian incognito address is invalid"))
+	}
+	if !bytes.Equal(txr.GetSigPubKey()[:], incogAddr.Pk[:]) {
+		return false, false, NewMetadataTxError(PortalRequestPTokenParamError, errors.New("Custodian incognito address is not signer"))
+	}
+
+	// check tx type
+	if txr.GetType() != common.TxNormalType {
+		return false, false, errors.New("tx rebalance collateral must be TxNormalType")
+	}
+
+	// validate amount
+	if meta.Amount == 0 {
+		return false, false, errors.New("rebalance amount should be larger than 0")
+	}
+
+	// validate tokenIDs
+	if !common.IsPortalToken(meta.FromTokenID) || !common.IsPortalToken(meta.ToTokenID) {
+		return false, false, errors.New("TokenID is not a portal token")
+	}
+	if meta.FromTokenID == meta.ToTokenID {
+		return false, false, errors.New("FromTokenID must be different from ToTokenID")
+	}
+
+	return true, true, nil
+}
+
+func (meta PortalRebalanceCollateral) ValidateMetadataByItself() bool {
+	return meta.Type == PortalRebalanceCollateralMeta
+}
+
+func (meta PortalRebalanceCollateral) Hash() *common.Hash {
+	record := meta.MetadataBase.Hash().String()
+	record += meta.CustodianAddressStr
+	record += meta.FromTokenID
+	record += meta.ToTokenID
+	record += strconv.FormatUint(meta.Amount, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (meta *PortalRebalanceCollateral) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := PortalRebalanceCollateralAction{
+		Meta:    *meta,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(PortalRebalanceCollateralMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (meta *PortalRebalanceCollateral) CalculateSize() uint64 {
+	return calculateSize(meta)
+}
@@ -0,0 +1,217 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// PortalTransferObligation - portal custodian hands over a part of the public tokens it holds to another custodian
+// the sender signs the transfer and transfers the public tokens from its remote address to the remote address of the
+// receiver on the external chain with the transfer memo, then the receiver takes over the obligation: it submits the
+// proof with the signature of the sender and locks the collateral backing the public tokens
+// metadata - receiver requests transfer obligation - create normal tx with this metadata
+type PortalTransferObligation struct {
+	MetadataBase
+	UniqueTransferID   string
+	TokenID            string // pTokenID in incognito chain
+	SenderAddressStr   string
+	ReceiverAddressStr string
+	Amount             uint64
+	TransferProof      string
+	SenderSignature    string // base58 check encoded signature of GetSenderSignedData by the sender
+}
+
+// PortalTransferObligationAction - shard validator creates instruction that contain this action content
+type PortalTransferObligationAction struct {
+	Meta    PortalTransferObligation
+	TxReqID common.Hash
+	ShardID byte
+}
+
+// PortalTransferObligationContent - Beacon builds a new instruction with this content after receiving a instruction from shard
+// It will be appended to beaconBlock
+// both accepted and rejected status
+type PortalTransferObligationContent struct {
+	UniqueTransferID   string
+	TokenID            string // pTokenID in incognito chain
+	SenderAddressStr   string
+	ReceiverAddressStr string
+	Amount             uint64
+	UnlockAmount       uint64 // prv unlocked from the sender
+	LockAmount         uint64 // prv locked by the receiver
+	TxReqID            common.Hash
+	ShardID            byte
+}
+
+// PortalTransferObligationStatus - Beacon tracks status of transfer obligation request into db
+type PortalTransferObligationStatus struct {
+	Status             byte
+	UniqueTransferID   string
+	TokenID            string // pTokenID in incognito chain
+	SenderAddressStr   string
+	ReceiverAddressStr string
+	Amount             uint64
+	UnlockAmount       uint64 // prv
+	LockAmount         uint64 // prv
+	TxReqID            common.Hash
+}
+
+func NewPortalTransferObligation(
+	metaType int,
+	uniqueTransferID string,
+	tokenID string,
+	senderAddressStr string,
+	receiverAddressStr string,
+	amount uint64,
+	transferProof string,
+	senderSignature string) (*PortalTransferObligation, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	transferObligationMeta := &PortalTransferObligation{
+		UniqueTransferID:   uniqueTransferID,
+		TokenID:            tokenID,
+		SenderAddressStr:   senderAddressStr,
+		ReceiverAddressStr: receiverAddressStr,
+		Amount:             amount,
+		TransferProof:      transferProof,
+		SenderSignature:    senderSignature,
+	}
+	transferObligationMeta.MetadataBase = metadataBase
+	return transferObligationMeta, nil
+}
+
+func NewPortalTransferObligationStatus(
+	status byte,
+	content PortalTransferObligationContent) *PortalTransferObligationStatus {
+	return &PortalTransferObligationStatus{
+		Status:             status,
+		UniqueTransferID:   content.UniqueTransferID,
+		TokenID:            content.TokenID,
+		SenderAddressStr:   content.SenderAddressStr,
+		ReceiverAddressStr: content.ReceiverAddressStr,
+		Amount:             content.Amount,
+		UnlockAmount:       content.UnlockAmount,
+		LockAmount:         content.LockAmount,
+		TxReqID:            content.TxReqID,
+	}
+}
+
+func (meta PortalTransferObligation) ValidateTxWithBlockChain(
+	txr Transaction,
+	chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever,
+	shardID byte,
+	db *statedb.StateDB,
+) (bool, error) {
+	return true, nil
+}
+
+func (meta PortalTransferObligation) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, txr Transaction) (bool, bool, error) {
+	// validate ReceiverAddressStr
+	keyWallet, err := wallet.Base58CheckDeserialize(meta.ReceiverAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(PortalRequestPTokenParamError, errors.New("Receiver incognito address is invalid"))
+	}
+	incogAddr := keyWallet.KeySet.PaymentAddress
+	if len(incogAddr.Pk) == 0 {
+		return false, false, NewMetadataTxError(PortalRequestPTokenParamError, errors.New("Receiver incognito address is invalid"))
+	}
+	if !bytes.Equal(txr.GetSigPubKey()[:], incogAddr.Pk[:]) {
+		return false, false, NewMetadataTxError(PortalRequestPTokenParamError, errors.New("Receiver incognito address is not signer"))
+	}
+
+	// validate SenderAddressStr
+	keyWallet, err = wallet.Base58CheckDeserialize(meta.SenderAddressStr)
+	if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+		return false, false, NewMetadataTxError(PortalRequestPTokenParamError, errors.New("Sender incognito address is invalid"))
+	}
+	if meta.ReceiverAddressStr == meta.SenderAddressStr {
+		return false, false, errors.New("Receiver must be different from sender")
+	}
+
+	// the sender agrees to hand over the obligation
+	senderSig, _, err := base58.Base58Check{}.Decode(meta.SenderSignature)
+	if err != nil || len(senderSig) == 0 {
+		return false, false, NewMetadataTxError(PortalRequestPTokenParamError, errors.New("Sender signature is invalid"))
+	}
+	isValid, err := keyWallet.KeySet.Verify(meta.GetSenderSignedData(), senderSig)
+	if err != nil || !isValid {
+		return false, false, NewMetadataTxError(PortalRequestPTokenParamError, errors.New("Sender signature is invalid"))
+	}
+
+	// check tx type
+	if txr.GetType() != common.TxNormalType {
+		return false, false, errors.New("tx transfer obligation must be TxNormalType")
+	}
+
+	// validate amount
+	if meta.Amount == 0 {
+		return false, false, errors.New("transfer amount should be larger than 0")
+	}
+
+	// validate tokenID
+	if !common.IsPortalToken(meta.TokenID) {
+		return false, false, errors.New("TokenID is not a portal token")
+	}
+
+	if meta.UniqueTransferID == "" {
+		return false, false, errors.New("UniqueTransferID should not be empty")
+	}
+
+	return true, true, nil
+}
+
+func (meta PortalTransferObligation) ValidateMetadataByItself() bool {
+	return meta.Type == PortalTransferObligationMeta
+}
+
+func (meta PortalTransferObligation) Hash() *common.Hash {
+	record := meta.MetadataBase.Hash().String()
+	record += meta.UniqueTransferID
+	record += meta.TokenID
+	record += meta.SenderAddressStr
+	record += meta.ReceiverAddressStr
+	record += strconv.FormatUint(meta.Amount, 10)
+	record += meta.TransferProof
+	record += meta.SenderSignature
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+// GetSenderSignedData returns the terms of the transfer signed by the sender
+func (meta PortalTransferObligation) GetSenderSignedData() []byte {
+	record := meta.UniqueTransferID
+	record += meta.TokenID
+	record += meta.SenderAddressStr
+	record += meta.ReceiverAddressStr
+	record += strconv.FormatUint(meta.Amount, 10)
+	return []byte(record)
+}
+
+func (meta *PortalTransferObligation) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := PortalTransferObligationAction{
+		Meta:    *meta,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(PortalTransferObligationMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (meta *PortalTransferObligation) CalculateSize() uint64 {
+	return calculateSize(meta)
+}
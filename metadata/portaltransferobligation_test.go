@@ -0,0 +1,58 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+// signedTx is a tx signed by sigPubKey
+type signedTx struct {
+	Transaction
+	sigPubKey []byte
+}
+
+func (tx signedTx) GetSigPubKey() []byte {
+	return tx.sigPubKey
+}
+
+func (tx signedTx) GetType() string {
+	return common.TxNormalType
+}
+
+func TestPortalTransferObligation_ValidateSanityData(t *testing.T) {
+	senderKey, err := wallet.NewMasterKey([]byte("sender"))
+	assert.Nil(t, err)
+	receiverKey, err := wallet.NewMasterKey([]byte("receiver"))
+	assert.Nil(t, err)
+	senderAddress := senderKey.Base58CheckSerialize(wallet.PaymentAddressType)
+	receiverAddress := receiverKey.Base58CheckSerialize(wallet.PaymentAddressType)
+
+	tx := signedTx{sigPubKey: receiverKey.KeySet.PaymentAddress.Pk}
+
+	meta, _ := NewPortalTransferObligation(PortalTransferObligationMeta, "transfer-1", common.PortalBNBIDStr, senderAddress, receiverAddress, 100, "proof", "")
+	senderSignature, err := senderKey.KeySet.SignDataInBase58CheckEncode(meta.GetSenderSignedData())
+	assert.Nil(t, err)
+	receiverSignature, err := receiverKey.KeySet.SignDataInBase58CheckEncode(meta.GetSenderSignedData())
+	assert.Nil(t, err)
+
+	meta.SenderSignature = senderSignature
+	_, ok, err := meta.ValidateSanityData(nil, nil, nil, 0, tx)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	// the receiver cannot take over an obligation the sender did not agree to
+	for _, signature := range []string{"", "invalid", receiverSignature} {
+		meta.SenderSignature = signature
+		_, _, err = meta.ValidateSanityData(nil, nil, nil, 0, tx)
+		assert.NotNil(t, err)
+	}
+
+	// the signature only covers the terms signed by the sender
+	meta.SenderSignature = senderSignature
+	meta.Amount = 1000
+	_, _, err = meta.ValidateSanityData(nil, nil, nil, 0, tx)
+	assert.NotNil(t, err)
+}
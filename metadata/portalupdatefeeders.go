@@ -0,0 +1,157 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// PortalUpdateFeeders replaces the feeders of the portal exchange rates, it
+// must be signed by the feeder governor
+type PortalUpdateFeeders struct {
+	MetadataBase
+	GovernorAddress string
+	Feeders         []string
+}
+
+type PortalUpdateFeedersAction struct {
+	Meta    PortalUpdateFeeders
+	TxReqID common.Hash
+	ShardID byte
+}
+
+type PortalUpdateFeedersContent struct {
+	GovernorAddress string
+	Feeders         []string
+	TxReqID         common.Hash
+	ShardID         byte
+}
+
+func NewPortalUpdateFeeders(metaType int, governorAddress string, feeders []string) (*PortalUpdateFeeders, error) {
+	metadataBase := MetadataBase{Type: metaType}
+	portalUpdateFeeders := &PortalUpdateFeeders{
+		GovernorAddress: governorAddress,
+		Feeders:         feeders,
+	}
+	portalUpdateFeeders.MetadataBase = metadataBase
+	return portalUpdateFeeders, nil
+}
+
+// GetPortalFeeders returns the feeders of the portal exchange rates, the
+// feeders of the chain params until the governor updates them
+func GetPortalFeeders(chainRetriever ChainRetriever, stateDB *statedb.StateDB) ([]string, error) {
+	feeders, has, err := statedb.GetPortalFeeders(stateDB)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return chainRetriever.GetPortalFeederAddresses(), nil
+	}
+	return feeders, nil
+}
+
+func IsPortalFeeder(chainRetriever ChainRetriever, stateDB *statedb.StateDB, address string) (bool, error) {
+	feeders, err := GetPortalFeeders(chainRetriever, stateDB)
+	if err != nil {
+		return false, err
+	}
+	for _, feeder := range feeders {
+		if feeder == address {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (portalUpdateFeeders PortalUpdateFeeders) ValidateTxWithBlockChain(
+	txr Transaction,
+	chainRetriever ChainRetriever,
+	shardViewRetriever ShardViewRetriever,
+	beaconViewRetriever BeaconViewRetriever,
+	shardID byte,
+	db *statedb.StateDB,
+) (bool, error) {
+	return true, nil
+}
+
+func (portalUpdateFeeders PortalUpdateFeeders) ValidateSanityData(chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, beaconHeight uint64, txr Transaction) (bool, bool, error) {
+	governorAddress := chainRetriever.GetPortalFeederGovernorAddress()
+	if governorAddress == "" || portalUpdateFeeders.GovernorAddress != governorAddress {
+		return false, false, fmt.Errorf("Sender must be feeder governor's address %v", governorAddress)
+	}
+
+	keyWallet, err := wallet.Base58CheckDeserialize(portalUpdateFeeders.GovernorAddress)
+	if err != nil {
+		return false, false, errors.New("GovernorAddress incorrect")
+	}
+	governorAddr := keyWallet.KeySet.PaymentAddress
+	if len(governorAddr.Pk) == 0 {
+		return false, false, errors.New("Governor address invalid, governor address must be incognito address")
+	}
+	if !bytes.Equal(txr.GetSigPubKey()[:], governorAddr.Pk[:]) {
+		return false, false, errors.New("Governor address is not signer tx")
+	}
+
+	if txr.GetType() != common.TxNormalType {
+		return false, false, errors.New("Tx update feeders must be TxNormalType")
+	}
+
+	if len(portalUpdateFeeders.Feeders) == 0 {
+		return false, false, errors.New("Feeders should not be empty")
+	}
+	feeders := make(map[string]bool)
+	for _, feeder := range portalUpdateFeeders.Feeders {
+		keyWallet, err := wallet.Base58CheckDeserialize(feeder)
+		if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+			return false, false, fmt.Errorf("Feeder address %v is not an incognito address", feeder)
+		}
+		if feeders[feeder] {
+			return false, false, fmt.Errorf("Feeder address %v is duplicated", feeder)
+		}
+		feeders[feeder] = true
+	}
+
+	return true, true, nil
+}
+
+func (portalUpdateFeeders PortalUpdateFeeders) ValidateMetadataByItself() bool {
+	return portalUpdateFeeders.Type == PortalUpdateFeedersMeta
+}
+
+func (portalUpdateFeeders PortalUpdateFeeders) Hash() *common.Hash {
+	record := portalUpdateFeeders.MetadataBase.Hash().String()
+	record += portalUpdateFeeders.GovernorAddress
+	for _, feeder := range portalUpdateFeeders.Feeders {
+		record += feeder
+	}
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (portalUpdateFeeders *PortalUpdateFeeders) BuildReqActions(tx Transaction, chainRetriever ChainRetriever, shardViewRetriever ShardViewRetriever, beaconViewRetriever BeaconViewRetriever, shardID byte) ([][]string, error) {
+	actionContent := PortalUpdateFeedersAction{
+		Meta:    *portalUpdateFeeders,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(PortalUpdateFeedersMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (portalUpdateFeeders *PortalUpdateFeeders) CalculateSize() uint64 {
+	return calculateSize(portalUpdateFeeders)
+}
@@ -85,11 +85,11 @@ func (_m *Trie) NodeIterator(startKey []byte) trie.NodeIterator {
 }
 
 // Prove provides a mock function with given fields: key, fromLevel, proofDb
-func (_m *Trie) Prove(key []byte, fromLevel uint, proofDb incdb.Database) error {
+func (_m *Trie) Prove(key []byte, fromLevel uint, proofDb incdb.KeyValueWriter) error {
 	ret := _m.Called(key, fromLevel, proofDb)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func([]byte, uint, incdb.Database) error); ok {
+	if rf, ok := ret.Get(0).(func([]byte, uint, incdb.KeyValueWriter) error); ok {
 		r0 = rf(key, fromLevel, proofDb)
 	} else {
 		r0 = ret.Error(0)
@@ -0,0 +1,113 @@
+package multiview
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type eventBlock struct {
+	common.BlockInterface
+	produceTime int64
+}
+
+func (b *eventBlock) GetVersion() int {
+	return 1
+}
+
+func (b *eventBlock) GetProduceTime() int64 {
+	return b.produceTime
+}
+
+type eventView struct {
+	View
+	hash     common.Hash
+	prevHash common.Hash
+	height   uint64
+	block    *eventBlock
+}
+
+func newEventView(hash byte, prevHash byte, height uint64, produceTime int64) *eventView {
+	return &eventView{
+		hash:     common.Hash{hash},
+		prevHash: common.Hash{prevHash},
+		height:   height,
+		block:    &eventBlock{produceTime: produceTime},
+	}
+}
+
+func (v *eventView) GetHash() *common.Hash {
+	return &v.hash
+}
+
+func (v *eventView) GetPreviousHash() *common.Hash {
+	return &v.prevHash
+}
+
+func (v *eventView) GetHeight() uint64 {
+	return v.height
+}
+
+func (v *eventView) GetBlock() common.BlockInterface {
+	return v.block
+}
+
+type eventRecorder struct {
+	best   []common.Hash
+	final  []common.Hash
+	reorgs []*Reorg
+}
+
+func (r *eventRecorder) OnNewBestView(view View) {
+	r.best = append(r.best, *view.GetHash())
+}
+
+func (r *eventRecorder) OnNewFinalView(view View) {
+	r.final = append(r.final, *view.GetHash())
+}
+
+func (r *eventRecorder) OnReorg(reorg *Reorg) {
+	r.reorgs = append(r.reorgs, reorg)
+}
+
+func TestMultiView_Events(t *testing.T) {
+	multiView := NewMultiView()
+	recorder := &eventRecorder{}
+	multiView.SetEventHandler(recorder)
+
+	multiView.AddView(newEventView(1, 0, 1, 100))
+	multiView.AddView(newEventView(2, 1, 2, 100))
+	multiView.AddView(newEventView(3, 2, 3, 100))
+	multiView.AddView(newEventView(4, 3, 4, 100))
+	assert.Equal(t, []common.Hash{{2}, {3}, {4}}, recorder.best)
+	assert.Equal(t, []common.Hash{{2}, {3}}, recorder.final)
+	assert.Equal(t, 0, len(recorder.reorgs))
+
+	//a block of the same height produced earlier becomes the best view
+	multiView.AddView(newEventView(5, 3, 4, 50))
+	assert.Equal(t, common.Hash{5}, *multiView.GetBestView().GetHash())
+	assert.Equal(t, 1, len(recorder.reorgs))
+	reorg := recorder.reorgs[0]
+	assert.Equal(t, common.Hash{4}, *reorg.OldTip.GetHash())
+	assert.Equal(t, common.Hash{5}, *reorg.NewTip.GetHash())
+	assert.Equal(t, common.Hash{3}, *reorg.CommonAncestor.GetHash())
+	assert.Equal(t, 1, len(reorg.Dropped))
+	assert.Equal(t, common.Hash{4}, *reorg.Dropped[0].GetHash())
+
+	//the first branch grows longer again
+	multiView.AddView(newEventView(6, 4, 5, 100))
+	assert.Equal(t, 2, len(recorder.reorgs))
+	reorg = recorder.reorgs[1]
+	assert.Equal(t, common.Hash{5}, *reorg.OldTip.GetHash())
+	assert.Equal(t, common.Hash{6}, *reorg.NewTip.GetHash())
+	assert.Equal(t, common.Hash{3}, *reorg.CommonAncestor.GetHash())
+	assert.Equal(t, common.Hash{5}, *reorg.Dropped[0].GetHash())
+	assert.Equal(t, []common.Hash{{2}, {3}, {4}, {5}, {6}}, recorder.best)
+	assert.Equal(t, []common.Hash{{2}, {3}, {4}}, recorder.final)
+
+	//a view not changing the best view
+	multiView.AddView(newEventView(7, 4, 5, 200))
+	assert.Equal(t, 5, len(recorder.best))
+	assert.Equal(t, 2, len(recorder.reorgs))
+}
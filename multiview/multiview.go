@@ -16,10 +16,26 @@ type View interface {
 	GetBlock() common.BlockInterface
 }
 
+// Reorg is a switch of the best view to a branch not containing the previous best view
+type Reorg struct {
+	OldTip         View
+	NewTip         View
+	CommonAncestor View
+	Dropped        []View // views of the old branch after the common ancestor, from the old tip
+}
+
+// EventHandler is notified from the loop of the multiview, it must not block
+type EventHandler interface {
+	OnNewBestView(view View)
+	OnNewFinalView(view View)
+	OnReorg(reorg *Reorg)
+}
+
 type MultiView struct {
 	viewByHash     map[common.Hash]View //viewByPrevHash map[common.Hash][]View
 	viewByPrevHash map[common.Hash][]View
 	actionCh       chan func()
+	eventHandler   EventHandler
 
 	//state
 	finalView View
@@ -51,6 +67,16 @@ func NewMultiView() *MultiView {
 
 }
 
+// SetEventHandler sets the handler notified when the best view or the final view changes
+func (multiView *MultiView) SetEventHandler(handler EventHandler) {
+	done := make(chan struct{})
+	multiView.actionCh <- func() {
+		multiView.eventHandler = handler
+		close(done)
+	}
+	<-done
+}
+
 func (multiView *MultiView) Reset() {
 	multiView.viewByHash = make(map[common.Hash]View)
 	multiView.viewByPrevHash = make(map[common.Hash][]View)
@@ -118,6 +144,8 @@ func (multiView *MultiView) updateViewState(newView View) {
 			delete(multiView.viewByPrevHash, *multiView.finalView.GetPreviousHash())
 		}
 	}()
+	//notify before the previous view of the final view is deleted
+	defer multiView.notifyViewChange(multiView.bestView, multiView.finalView)
 
 	if multiView.finalView == nil {
 		multiView.bestView = newView
@@ -166,6 +194,40 @@ func (multiView *MultiView) updateViewState(newView View) {
 	return
 }
 
+// notifyViewChange notifies the event handler of the changes since oldBest and oldFinal
+func (multiView *MultiView) notifyViewChange(oldBest View, oldFinal View) {
+	if multiView.eventHandler == nil || oldBest == nil {
+		return
+	}
+	if *oldBest.GetHash() != *multiView.bestView.GetHash() {
+		if reorg := multiView.getReorg(oldBest, multiView.bestView); reorg != nil {
+			multiView.eventHandler.OnReorg(reorg)
+		}
+		multiView.eventHandler.OnNewBestView(multiView.bestView)
+	}
+	if *oldFinal.GetHash() != *multiView.finalView.GetHash() {
+		multiView.eventHandler.OnNewFinalView(multiView.finalView)
+	}
+}
+
+// getReorg returns the reorg from oldTip to newTip, nil if oldTip is an ancestor of newTip
+func (multiView *MultiView) getReorg(oldTip View, newTip View) *Reorg {
+	dropped := []View{}
+	oldView, newView := oldTip, newTip
+	for oldView != nil && newView != nil && *oldView.GetHash() != *newView.GetHash() {
+		if oldView.GetHeight() >= newView.GetHeight() {
+			dropped = append(dropped, oldView)
+			oldView = multiView.viewByHash[*oldView.GetPreviousHash()]
+		} else {
+			newView = multiView.viewByHash[*newView.GetPreviousHash()]
+		}
+	}
+	if len(dropped) == 0 || oldView == nil || newView == nil {
+		return nil
+	}
+	return &Reorg{OldTip: oldTip, NewTip: newTip, CommonAncestor: oldView, Dropped: dropped}
+}
+
 func (multiView *MultiView) GetAllViewsWithBFS() []View {
 	queue := []View{multiView.finalView}
 	resCh := make(chan []View)
@@ -0,0 +1,35 @@
+package netsync
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/peerv2/proto"
+)
+
+// GetStateCheckpoint returns the final view of the chain cID (-1 for beacon)
+// for a peer which syncs its state tries.
+func (netSync *NetSync) GetStateCheckpoint(cID int) (*proto.StateCheckpointResponse, error) {
+	cp, err := netSync.config.BlockChain.GetStateCheckpoint(cID)
+	if err != nil {
+		Logger.log.Errorf("[statesync] Netsync cannot get state checkpoint of chain %v, return error %+v", cID, err)
+		return nil, err
+	}
+	return &proto.StateCheckpointResponse{
+		View:      cp.View,
+		Block:     cp.Block,
+		RootsHash: cp.RootsHash,
+		StakingTx: cp.StakingTx,
+	}, nil
+}
+
+// GetStateTrieNode returns the state trie node at hash of the chain cID (-1
+// for beacon).
+func (netSync *NetSync) GetStateTrieNode(cID int, hash common.Hash) ([]byte, error) {
+	return netSync.config.BlockChain.GetStateTrieNode(cID, hash)
+}
+
+// GetStateLeaves returns at most limit keys and values of the state trie at
+// root of the chain cID (-1 for beacon) which start with prefix and come after
+// start.
+func (netSync *NetSync) GetStateLeaves(cID int, root common.Hash, prefix []byte, start []byte, limit int) ([][]byte, [][]byte, error) {
+	return netSync.config.BlockChain.GetStateLeaves(cID, root, prefix, start, limit)
+}
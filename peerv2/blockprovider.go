@@ -8,10 +8,18 @@ import (
 	"github.com/incognitochain/incognito-chain/peerv2/proto"
 	"github.com/incognitochain/incognito-chain/peerv2/wrapper"
 	"github.com/incognitochain/incognito-chain/wire"
+	"github.com/libp2p/go-libp2p-core/host"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-func NewBlockProvider(p *p2pgrpc.GRPCProtocol, ns NetSync) *BlockProvider {
-	bp := &BlockProvider{NetSync: ns}
+func NewBlockProvider(p *p2pgrpc.GRPCProtocol, h host.Host, ns NetSync) *BlockProvider {
+	bp := &BlockProvider{
+		NetSync:      ns,
+		stateLimiter: newRateLimiter(StateRequestRate, StateRequestBurst, StateServeRate, StateServeBurst),
+		streamPeers:  newStreamPeers(),
+	}
+	h.SetStreamHandler(p2pgrpc.Protocol, bp.streamPeers.wrap(p.HandleStream))
 	proto.RegisterHighwayServiceServer(p.GetGRPCServer(), bp)
 	go p.Serve() // NOTE: must serve after registering all services
 	return bp
@@ -137,9 +145,104 @@ func (bp *BlockProvider) StreamBlockByHash(
 	return nil
 }
 
+func (bp *BlockProvider) GetStateCheckpoint(ctx context.Context, req *proto.StateCheckpointRequest) (*proto.StateCheckpointResponse, error) {
+	Logger.Infof("[statesync] Receive GetStateCheckpoint request chain %v, uuid = %s", req.CID, req.GetUUID())
+	if !bp.stateLimiter.allow(requestPeerID(ctx, bp.streamPeers), 1) {
+		return nil, errStateRateLimited
+	}
+	return bp.NetSync.GetStateCheckpoint(stateSyncChainID(req.CID))
+}
+
+func (bp *BlockProvider) StreamStateNodes(
+	req *proto.StateNodesRequest,
+	stream proto.HighwayService_StreamStateNodesServer,
+) error {
+	uuid := req.GetUUID()
+	Logger.Infof("[statesync] Receive StreamStateNodes request chain %v, len %v, uuid = %s", req.CID, len(req.Hashes), uuid)
+	hashes := req.Hashes
+	if len(hashes) > MaxStateNodesPerRequest {
+		hashes = hashes[:MaxStateNodesPerRequest]
+	}
+	peerID := requestPeerID(stream.Context(), bp.streamPeers)
+	cnt := 0
+	for _, hashBytes := range hashes {
+		hash := common.Hash{}
+		if err := hash.SetBytes(hashBytes); err != nil {
+			continue
+		}
+		data, err := bp.NetSync.GetStateTrieNode(stateSyncChainID(req.CID), hash)
+		if err != nil {
+			continue // the requester asks another peer for missing nodes
+		}
+		if !bp.stateLimiter.allow(peerID, 1) {
+			Logger.Infof("[statesync] Rate limit peer %v after sending %v state nodes, uuid = %s", peerID, cnt, uuid)
+			return errStateRateLimited
+		}
+		if err := stream.Send(&proto.StateNodeData{Hash: hashBytes, Data: data}); err != nil {
+			Logger.Infof("[statesync] Server send state node to client return err %v, uuid = %s", err, uuid)
+			return err
+		}
+		cnt++
+	}
+	Logger.Infof("[statesync] Successfully sent %v state nodes to client, uuid %v", cnt, uuid)
+	return nil
+}
+
+// StreamStateLeaves sends the keys and values of the state trie at req.Root
+// which start with req.Prefix and come after req.Start, at most req.Limit of
+// them. A range is complete when less than the limit is received.
+func (bp *BlockProvider) StreamStateLeaves(
+	req *proto.StateLeavesRequest,
+	stream proto.HighwayService_StreamStateLeavesServer,
+) error {
+	uuid := req.GetUUID()
+	Logger.Infof("[statesync] Receive StreamStateLeaves request chain %v, root %x, prefix %x, limit %v, uuid = %s", req.CID, req.Root, req.Prefix, req.Limit, uuid)
+	root := common.Hash{}
+	if err := root.SetBytes(req.Root); err != nil {
+		return err
+	}
+	limit := int(req.Limit)
+	if limit <= 0 || limit > MaxStateLeavesPerRequest {
+		limit = MaxStateLeavesPerRequest
+	}
+	peerID := requestPeerID(stream.Context(), bp.streamPeers)
+	keys, values, err := bp.NetSync.GetStateLeaves(stateSyncChainID(req.CID), root, req.Prefix, req.Start, limit)
+	if err != nil {
+		Logger.Infof("[statesync] Netsync cannot get state leaves, return error %v, uuid = %s", err, uuid)
+		return err
+	}
+	for i := range keys {
+		if !bp.stateLimiter.allow(peerID, 1) {
+			Logger.Infof("[statesync] Rate limit peer %v after sending %v state leaves, uuid = %s", peerID, i, uuid)
+			return errStateRateLimited
+		}
+		if err := stream.Send(&proto.StateLeafData{Key: keys[i], Value: values[i]}); err != nil {
+			Logger.Infof("[statesync] Server send state leaf to client return err %v, uuid = %s", err, uuid)
+			return err
+		}
+	}
+	Logger.Infof("[statesync] Successfully sent %v state leaves to client, uuid %v", len(keys), uuid)
+	return nil
+}
+
+// stateSyncChainID converts the chain ID of a state sync request, which is
+// HighwayBeaconID for beacon, to the chain ID used by NetSync
+func stateSyncChainID(cID int32) int {
+	if cID == int32(HighwayBeaconID) {
+		return -1
+	}
+	return int(cID)
+}
+
+// errStateRateLimited is returned to a peer which requested more state
+// items than its rate limit allows, it should retry the rest later.
+var errStateRateLimited = status.Error(codes.ResourceExhausted, "state request rate limit exceeded")
+
 type BlockProvider struct {
 	proto.UnimplementedHighwayServiceServer
-	NetSync NetSync
+	NetSync      NetSync
+	stateLimiter *rateLimiter
+	streamPeers  *streamPeers
 }
 
 type NetSync interface {
@@ -147,4 +250,7 @@ type NetSync interface {
 	GetBlockBeaconByHash(blkHashes []common.Hash) []wire.Message
 	StreamBlockByHeight(fromPool bool, req *proto.BlockByHeightRequest) chan interface{}
 	StreamBlockByHash(fromPool bool, req *proto.BlockByHashRequest) chan interface{}
+	GetStateCheckpoint(cID int) (*proto.StateCheckpointResponse, error)
+	GetStateTrieNode(cID int, hash common.Hash) ([]byte, error)
+	GetStateLeaves(cID int, root common.Hash, prefix []byte, start []byte, limit int) ([][]byte, [][]byte, error)
 }
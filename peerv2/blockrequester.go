@@ -250,6 +250,64 @@ func (c *BlockRequester) StreamBlockByHash(
 	return stream, nil
 }
 
+func (c *BlockRequester) GetStateCheckpoint(
+	ctx context.Context,
+	req *proto.StateCheckpointRequest,
+) (*proto.StateCheckpointResponse, error) {
+	uuid := genUUID()
+	Logger.Infof("[statesync] Requesting state checkpoint of chain %v, uuid = %s", req.CID, uuid)
+	c.RLock()
+	defer c.RUnlock()
+	if !c.ready() {
+		return nil, errors.New("requester not ready")
+	}
+	req.UUID = uuid
+	client := proto.NewHighwayServiceClient(c.conn)
+	return client.GetStateCheckpoint(ctx, req, grpc.MaxCallRecvMsgSize(MaxCallRecvMsgSize))
+}
+
+func (c *BlockRequester) StreamStateNodes(
+	ctx context.Context,
+	req *proto.StateNodesRequest,
+) (proto.HighwayService_StreamStateNodesClient, error) {
+	uuid := genUUID()
+	Logger.Infof("[statesync] Requesting stream %v state nodes of chain %v, uuid = %s", len(req.Hashes), req.CID, uuid)
+	c.RLock()
+	defer c.RUnlock()
+	if !c.ready() {
+		return nil, errors.New("requester not ready")
+	}
+	req.UUID = uuid
+	client := proto.NewHighwayServiceClient(c.conn)
+	stream, err := client.StreamStateNodes(ctx, req, grpc.MaxCallRecvMsgSize(MaxCallRecvMsgSize))
+	if err != nil {
+		Logger.Infof("[statesync] This client not return stream for this request, got error %v ", err)
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (c *BlockRequester) StreamStateLeaves(
+	ctx context.Context,
+	req *proto.StateLeavesRequest,
+) (proto.HighwayService_StreamStateLeavesClient, error) {
+	uuid := genUUID()
+	Logger.Infof("[statesync] Requesting stream state leaves of chain %v, root %x, prefix %x, limit %v, uuid = %s", req.CID, req.Root, req.Prefix, req.Limit, uuid)
+	c.RLock()
+	defer c.RUnlock()
+	if !c.ready() {
+		return nil, errors.New("requester not ready")
+	}
+	req.UUID = uuid
+	client := proto.NewHighwayServiceClient(c.conn)
+	stream, err := client.StreamStateLeaves(ctx, req, grpc.MaxCallRecvMsgSize(MaxCallRecvMsgSize))
+	if err != nil {
+		Logger.Infof("[statesync] This client not return stream for this request, got error %v ", err)
+		return nil, err
+	}
+	return stream, nil
+}
+
 func (c *BlockRequester) GetBlockBeaconByHash(
 	hashes []common.Hash,
 ) ([][]byte, error) {
@@ -116,7 +116,7 @@ func (cm *ConnManager) Start(ns NetSync) {
 
 	cm.Requester = NewRequester(cm.LocalHost.GRPC)
 	cm.subscriber = NewSubManager(cm.info, cm.ps, cm.Requester, cm.messages)
-	cm.Provider = NewBlockProvider(cm.LocalHost.GRPC, ns)
+	cm.Provider = NewBlockProvider(cm.LocalHost.GRPC, cm.LocalHost.Host, ns)
 	go cm.manageRoleSubscription()
 	cm.process()
 }
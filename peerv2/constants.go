@@ -26,4 +26,11 @@ var (
 
 	IgnoreRPCDuration = 60 * time.Minute  // Ignore an address after a failed RPC
 	IgnoreHWDuration  = 360 * time.Minute // Ignore a highway when cannot connect
+
+	MaxStateNodesPerRequest  = 1024  // Trie nodes served for one request
+	MaxStateLeavesPerRequest = 4096  // State leaves served for one request
+	StateRequestRate         = 4096  // State items served per second to one peer
+	StateRequestBurst        = 16384 // State items served at once to one peer
+	StateServeRate           = 16384 // State items served per second to all the peers
+	StateServeBurst          = 65536 // State items served at once to all the peers
 )
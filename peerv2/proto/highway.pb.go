@@ -846,6 +846,392 @@ func (m *BlockData) GetData() []byte {
 	return nil
 }
 
+type StateCheckpointRequest struct {
+	CID                  int32    `protobuf:"varint,1,opt,name=CID,proto3" json:"CID,omitempty"`
+	UUID                 string   `protobuf:"bytes,2,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	SyncFromPeer         string   `protobuf:"bytes,3,opt,name=SyncFromPeer,proto3" json:"SyncFromPeer,omitempty"`
+	RequesterPeer        string   `protobuf:"bytes,4,opt,name=RequesterPeer,proto3" json:"RequesterPeer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateCheckpointRequest) Reset()         { *m = StateCheckpointRequest{} }
+func (m *StateCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*StateCheckpointRequest) ProtoMessage()    {}
+func (*StateCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a48762df9e8cc53a, []int{13}
+}
+
+func (m *StateCheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateCheckpointRequest.Unmarshal(m, b)
+}
+func (m *StateCheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateCheckpointRequest.Marshal(b, m, deterministic)
+}
+func (m *StateCheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateCheckpointRequest.Merge(m, src)
+}
+func (m *StateCheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_StateCheckpointRequest.Size(m)
+}
+func (m *StateCheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateCheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateCheckpointRequest proto.InternalMessageInfo
+
+func (m *StateCheckpointRequest) GetCID() int32 {
+	if m != nil {
+		return m.CID
+	}
+	return 0
+}
+
+func (m *StateCheckpointRequest) GetUUID() string {
+	if m != nil {
+		return m.UUID
+	}
+	return ""
+}
+
+func (m *StateCheckpointRequest) GetSyncFromPeer() string {
+	if m != nil {
+		return m.SyncFromPeer
+	}
+	return ""
+}
+
+func (m *StateCheckpointRequest) GetRequesterPeer() string {
+	if m != nil {
+		return m.RequesterPeer
+	}
+	return ""
+}
+
+type StateCheckpointResponse struct {
+	View                 []byte   `protobuf:"bytes,1,opt,name=View,proto3" json:"View,omitempty"`
+	Block                []byte   `protobuf:"bytes,2,opt,name=Block,proto3" json:"Block,omitempty"`
+	RootsHash            []byte   `protobuf:"bytes,3,opt,name=RootsHash,proto3" json:"RootsHash,omitempty"`
+	StakingTx            []byte   `protobuf:"bytes,4,opt,name=StakingTx,proto3" json:"StakingTx,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateCheckpointResponse) Reset()         { *m = StateCheckpointResponse{} }
+func (m *StateCheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*StateCheckpointResponse) ProtoMessage()    {}
+func (*StateCheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a48762df9e8cc53a, []int{14}
+}
+
+func (m *StateCheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateCheckpointResponse.Unmarshal(m, b)
+}
+func (m *StateCheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateCheckpointResponse.Marshal(b, m, deterministic)
+}
+func (m *StateCheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateCheckpointResponse.Merge(m, src)
+}
+func (m *StateCheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_StateCheckpointResponse.Size(m)
+}
+func (m *StateCheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateCheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateCheckpointResponse proto.InternalMessageInfo
+
+func (m *StateCheckpointResponse) GetView() []byte {
+	if m != nil {
+		return m.View
+	}
+	return nil
+}
+
+func (m *StateCheckpointResponse) GetBlock() []byte {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *StateCheckpointResponse) GetRootsHash() []byte {
+	if m != nil {
+		return m.RootsHash
+	}
+	return nil
+}
+
+func (m *StateCheckpointResponse) GetStakingTx() []byte {
+	if m != nil {
+		return m.StakingTx
+	}
+	return nil
+}
+
+type StateNodesRequest struct {
+	CID                  int32    `protobuf:"varint,1,opt,name=CID,proto3" json:"CID,omitempty"`
+	Hashes               [][]byte `protobuf:"bytes,2,rep,name=Hashes,proto3" json:"Hashes,omitempty"`
+	UUID                 string   `protobuf:"bytes,3,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	SyncFromPeer         string   `protobuf:"bytes,4,opt,name=SyncFromPeer,proto3" json:"SyncFromPeer,omitempty"`
+	RequesterPeer        string   `protobuf:"bytes,5,opt,name=RequesterPeer,proto3" json:"RequesterPeer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateNodesRequest) Reset()         { *m = StateNodesRequest{} }
+func (m *StateNodesRequest) String() string { return proto.CompactTextString(m) }
+func (*StateNodesRequest) ProtoMessage()    {}
+func (*StateNodesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a48762df9e8cc53a, []int{15}
+}
+
+func (m *StateNodesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateNodesRequest.Unmarshal(m, b)
+}
+func (m *StateNodesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateNodesRequest.Marshal(b, m, deterministic)
+}
+func (m *StateNodesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateNodesRequest.Merge(m, src)
+}
+func (m *StateNodesRequest) XXX_Size() int {
+	return xxx_messageInfo_StateNodesRequest.Size(m)
+}
+func (m *StateNodesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateNodesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateNodesRequest proto.InternalMessageInfo
+
+func (m *StateNodesRequest) GetCID() int32 {
+	if m != nil {
+		return m.CID
+	}
+	return 0
+}
+
+func (m *StateNodesRequest) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+func (m *StateNodesRequest) GetUUID() string {
+	if m != nil {
+		return m.UUID
+	}
+	return ""
+}
+
+func (m *StateNodesRequest) GetSyncFromPeer() string {
+	if m != nil {
+		return m.SyncFromPeer
+	}
+	return ""
+}
+
+func (m *StateNodesRequest) GetRequesterPeer() string {
+	if m != nil {
+		return m.RequesterPeer
+	}
+	return ""
+}
+
+type StateNodeData struct {
+	Hash                 []byte   `protobuf:"bytes,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=Data,proto3" json:"Data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateNodeData) Reset()         { *m = StateNodeData{} }
+func (m *StateNodeData) String() string { return proto.CompactTextString(m) }
+func (*StateNodeData) ProtoMessage()    {}
+func (*StateNodeData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a48762df9e8cc53a, []int{16}
+}
+
+func (m *StateNodeData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateNodeData.Unmarshal(m, b)
+}
+func (m *StateNodeData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateNodeData.Marshal(b, m, deterministic)
+}
+func (m *StateNodeData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateNodeData.Merge(m, src)
+}
+func (m *StateNodeData) XXX_Size() int {
+	return xxx_messageInfo_StateNodeData.Size(m)
+}
+func (m *StateNodeData) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateNodeData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateNodeData proto.InternalMessageInfo
+
+func (m *StateNodeData) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *StateNodeData) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type StateLeavesRequest struct {
+	CID                  int32    `protobuf:"varint,1,opt,name=CID,proto3" json:"CID,omitempty"`
+	Root                 []byte   `protobuf:"bytes,2,opt,name=Root,proto3" json:"Root,omitempty"`
+	Prefix               []byte   `protobuf:"bytes,3,opt,name=Prefix,proto3" json:"Prefix,omitempty"`
+	Start                []byte   `protobuf:"bytes,4,opt,name=Start,proto3" json:"Start,omitempty"`
+	Limit                int32    `protobuf:"varint,5,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	UUID                 string   `protobuf:"bytes,6,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	SyncFromPeer         string   `protobuf:"bytes,7,opt,name=SyncFromPeer,proto3" json:"SyncFromPeer,omitempty"`
+	RequesterPeer        string   `protobuf:"bytes,8,opt,name=RequesterPeer,proto3" json:"RequesterPeer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateLeavesRequest) Reset()         { *m = StateLeavesRequest{} }
+func (m *StateLeavesRequest) String() string { return proto.CompactTextString(m) }
+func (*StateLeavesRequest) ProtoMessage()    {}
+func (*StateLeavesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a48762df9e8cc53a, []int{17}
+}
+
+func (m *StateLeavesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateLeavesRequest.Unmarshal(m, b)
+}
+func (m *StateLeavesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateLeavesRequest.Marshal(b, m, deterministic)
+}
+func (m *StateLeavesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateLeavesRequest.Merge(m, src)
+}
+func (m *StateLeavesRequest) XXX_Size() int {
+	return xxx_messageInfo_StateLeavesRequest.Size(m)
+}
+func (m *StateLeavesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateLeavesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateLeavesRequest proto.InternalMessageInfo
+
+func (m *StateLeavesRequest) GetCID() int32 {
+	if m != nil {
+		return m.CID
+	}
+	return 0
+}
+
+func (m *StateLeavesRequest) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *StateLeavesRequest) GetPrefix() []byte {
+	if m != nil {
+		return m.Prefix
+	}
+	return nil
+}
+
+func (m *StateLeavesRequest) GetStart() []byte {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *StateLeavesRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *StateLeavesRequest) GetUUID() string {
+	if m != nil {
+		return m.UUID
+	}
+	return ""
+}
+
+func (m *StateLeavesRequest) GetSyncFromPeer() string {
+	if m != nil {
+		return m.SyncFromPeer
+	}
+	return ""
+}
+
+func (m *StateLeavesRequest) GetRequesterPeer() string {
+	if m != nil {
+		return m.RequesterPeer
+	}
+	return ""
+}
+
+type StateLeafData struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateLeafData) Reset()         { *m = StateLeafData{} }
+func (m *StateLeafData) String() string { return proto.CompactTextString(m) }
+func (*StateLeafData) ProtoMessage()    {}
+func (*StateLeafData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a48762df9e8cc53a, []int{18}
+}
+
+func (m *StateLeafData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateLeafData.Unmarshal(m, b)
+}
+func (m *StateLeafData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateLeafData.Marshal(b, m, deterministic)
+}
+func (m *StateLeafData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateLeafData.Merge(m, src)
+}
+func (m *StateLeafData) XXX_Size() int {
+	return xxx_messageInfo_StateLeafData.Size(m)
+}
+func (m *StateLeafData) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateLeafData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateLeafData proto.InternalMessageInfo
+
+func (m *StateLeafData) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *StateLeafData) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
 type GetChainCommitteeRequest struct {
 	Epoch                int32    `protobuf:"varint,1,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
 	CommitteeID          int32    `protobuf:"varint,2,opt,name=CommitteeID,proto3" json:"CommitteeID,omitempty"`
@@ -858,7 +1244,7 @@ func (m *GetChainCommitteeRequest) Reset()         { *m = GetChainCommitteeReque
 func (m *GetChainCommitteeRequest) String() string { return proto.CompactTextString(m) }
 func (*GetChainCommitteeRequest) ProtoMessage()    {}
 func (*GetChainCommitteeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a48762df9e8cc53a, []int{13}
+	return fileDescriptor_a48762df9e8cc53a, []int{19}
 }
 
 func (m *GetChainCommitteeRequest) XXX_Unmarshal(b []byte) error {
@@ -904,7 +1290,7 @@ func (m *GetChainCommitteeResponse) Reset()         { *m = GetChainCommitteeResp
 func (m *GetChainCommitteeResponse) String() string { return proto.CompactTextString(m) }
 func (*GetChainCommitteeResponse) ProtoMessage()    {}
 func (*GetChainCommitteeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a48762df9e8cc53a, []int{14}
+	return fileDescriptor_a48762df9e8cc53a, []int{20}
 }
 
 func (m *GetChainCommitteeResponse) XXX_Unmarshal(b []byte) error {
@@ -942,7 +1328,7 @@ func (m *GetHighwayInfosRequest) Reset()         { *m = GetHighwayInfosRequest{}
 func (m *GetHighwayInfosRequest) String() string { return proto.CompactTextString(m) }
 func (*GetHighwayInfosRequest) ProtoMessage()    {}
 func (*GetHighwayInfosRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a48762df9e8cc53a, []int{15}
+	return fileDescriptor_a48762df9e8cc53a, []int{21}
 }
 
 func (m *GetHighwayInfosRequest) XXX_Unmarshal(b []byte) error {
@@ -975,7 +1361,7 @@ func (m *HighwayInfo) Reset()         { *m = HighwayInfo{} }
 func (m *HighwayInfo) String() string { return proto.CompactTextString(m) }
 func (*HighwayInfo) ProtoMessage()    {}
 func (*HighwayInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a48762df9e8cc53a, []int{16}
+	return fileDescriptor_a48762df9e8cc53a, []int{22}
 }
 
 func (m *HighwayInfo) XXX_Unmarshal(b []byte) error {
@@ -1021,7 +1407,7 @@ func (m *GetHighwayInfosResponse) Reset()         { *m = GetHighwayInfosResponse
 func (m *GetHighwayInfosResponse) String() string { return proto.CompactTextString(m) }
 func (*GetHighwayInfosResponse) ProtoMessage()    {}
 func (*GetHighwayInfosResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a48762df9e8cc53a, []int{17}
+	return fileDescriptor_a48762df9e8cc53a, []int{23}
 }
 
 func (m *GetHighwayInfosResponse) XXX_Unmarshal(b []byte) error {
@@ -1065,6 +1451,12 @@ func init() {
 	proto.RegisterType((*BlockByHeightRequest)(nil), "BlockByHeightRequest")
 	proto.RegisterType((*BlockByHashRequest)(nil), "BlockByHashRequest")
 	proto.RegisterType((*BlockData)(nil), "BlockData")
+	proto.RegisterType((*StateCheckpointRequest)(nil), "StateCheckpointRequest")
+	proto.RegisterType((*StateCheckpointResponse)(nil), "StateCheckpointResponse")
+	proto.RegisterType((*StateNodesRequest)(nil), "StateNodesRequest")
+	proto.RegisterType((*StateNodeData)(nil), "StateNodeData")
+	proto.RegisterType((*StateLeavesRequest)(nil), "StateLeavesRequest")
+	proto.RegisterType((*StateLeafData)(nil), "StateLeafData")
 	proto.RegisterType((*GetChainCommitteeRequest)(nil), "GetChainCommitteeRequest")
 	proto.RegisterType((*GetChainCommitteeResponse)(nil), "GetChainCommitteeResponse")
 	proto.RegisterType((*GetHighwayInfosRequest)(nil), "GetHighwayInfosRequest")
@@ -1075,68 +1467,85 @@ func init() {
 func init() { proto.RegisterFile("highway.proto", fileDescriptor_a48762df9e8cc53a) }
 
 var fileDescriptor_a48762df9e8cc53a = []byte{
-	// 963 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0xdd, 0x8e, 0xdb, 0x44,
-	0x14, 0x8e, 0xe3, 0x38, 0x3f, 0x67, 0xb3, 0x69, 0xf6, 0xec, 0xd2, 0x75, 0xdd, 0xad, 0x1a, 0x2c,
-	0xa8, 0xa2, 0x5e, 0x0c, 0x34, 0x48, 0x88, 0x0b, 0xb8, 0xa8, 0x13, 0xba, 0xbb, 0x50, 0x44, 0x34,
-	0x49, 0x44, 0xc5, 0x9d, 0xeb, 0xce, 0x26, 0xd6, 0x66, 0x3d, 0xc1, 0xf6, 0x82, 0x22, 0xf1, 0x1c,
-	0xbc, 0x00, 0x0f, 0xc0, 0x4b, 0x70, 0x83, 0x78, 0x0d, 0x1e, 0x04, 0xcd, 0x78, 0xec, 0x38, 0x89,
-	0x9d, 0xbd, 0xca, 0x9c, 0x73, 0x3c, 0x33, 0xdf, 0x77, 0x7e, 0xbe, 0x09, 0x1c, 0x2f, 0xfc, 0xf9,
-	0xe2, 0x37, 0x77, 0x4d, 0x56, 0x21, 0x8f, 0xb9, 0xfd, 0xaf, 0x06, 0x8f, 0x28, 0x9b, 0xfb, 0x51,
-	0xcc, 0x42, 0xca, 0x7e, 0xb9, 0x67, 0x51, 0x8c, 0x04, 0x70, 0xc8, 0xef, 0xee, 0xfc, 0x38, 0x66,
-	0x6c, 0x7c, 0xff, 0x7e, 0xe9, 0x7b, 0xdf, 0xb3, 0xb5, 0xa9, 0xf5, 0xb4, 0x7e, 0x8b, 0x16, 0x44,
-	0xf0, 0x05, 0x74, 0x7e, 0x72, 0x83, 0x98, 0x7d, 0xf8, 0x81, 0x45, 0x91, 0x3b, 0x67, 0x91, 0x59,
-	0xed, 0xe9, 0xfd, 0x16, 0xdd, 0xf1, 0x62, 0x0f, 0x8e, 0xb2, 0xdd, 0xd7, 0x23, 0x53, 0xef, 0x69,
-	0xfd, 0x36, 0xcd, 0xbb, 0xf0, 0x31, 0xd4, 0xc7, 0x8c, 0x85, 0xd7, 0x23, 0xb3, 0x26, 0x6f, 0x53,
-	0x16, 0x22, 0xd4, 0x28, 0x5f, 0x32, 0xd3, 0x90, 0x5e, 0xb9, 0x16, 0xbe, 0xd9, 0xec, 0x7a, 0x64,
-	0xd6, 0x13, 0x9f, 0x58, 0xdb, 0xdf, 0x41, 0x73, 0x16, 0xb1, 0x50, 0xc6, 0xcf, 0xc0, 0x78, 0xeb,
-	0xae, 0x59, 0xa8, 0x80, 0x27, 0x46, 0x76, 0x52, 0x35, 0x77, 0xd2, 0x19, 0x18, 0x93, 0x85, 0x1b,
-	0x7e, 0x90, 0x88, 0x0c, 0x9a, 0x18, 0xf6, 0x3b, 0xe8, 0x6e, 0x12, 0x13, 0xad, 0x78, 0x10, 0x31,
-	0xfc, 0x14, 0x6a, 0x63, 0xd7, 0x17, 0x47, 0xea, 0xfd, 0xa3, 0xc1, 0x09, 0x51, 0xd4, 0xa6, 0x7c,
-	0xe5, 0x7b, 0x22, 0x40, 0x65, 0x18, 0x9f, 0xe5, 0x2e, 0x39, 0x1a, 0xb4, 0x48, 0x8a, 0x29, 0xb9,
-	0xcf, 0xfe, 0x43, 0x83, 0xee, 0xee, 0x4e, 0x34, 0xa1, 0xa1, 0x7c, 0x0a, 0x70, 0x6a, 0x0a, 0x78,
-	0xf2, 0x33, 0x95, 0xd5, 0xc4, 0xc0, 0x97, 0xa0, 0xbf, 0xf6, 0x62, 0x53, 0xef, 0xe9, 0xfd, 0xce,
-	0xc0, 0xdc, 0x43, 0x42, 0x5e, 0x7b, 0xb1, 0xcf, 0x03, 0x2a, 0x3e, 0xb2, 0x5f, 0x40, 0x3d, 0x31,
-	0x11, 0xa0, 0x3e, 0x9e, 0x39, 0x93, 0x99, 0xd3, 0xad, 0x60, 0x03, 0xf4, 0xf1, 0xcc, 0xe9, 0x6a,
-	0x62, 0x21, 0x3c, 0x55, 0xfb, 0x77, 0xb0, 0x2e, 0x59, 0xec, 0x2c, 0xb9, 0x77, 0x2b, 0x73, 0xe0,
-	0xac, 0xaf, 0xdc, 0x68, 0x91, 0xb6, 0x45, 0x96, 0x26, 0x2d, 0x97, 0x26, 0x51, 0x32, 0xf1, 0x91,
-	0x2a, 0x7a, 0x9b, 0x2a, 0x0b, 0x2f, 0xa0, 0x35, 0x74, 0x97, 0xcb, 0x11, 0x5b, 0xc5, 0x0b, 0x95,
-	0xd8, 0x8d, 0x23, 0x2b, 0x5e, 0x2d, 0x57, 0xbc, 0x57, 0xf0, 0xb4, 0xf0, 0x76, 0x95, 0x7b, 0x84,
-	0xda, 0xc8, 0x8d, 0x5d, 0x99, 0xfb, 0x36, 0x95, 0x6b, 0x7b, 0xbe, 0xd9, 0xe2, 0x30, 0xd7, 0xe3,
-	0xc1, 0x36, 0xe2, 0x0d, 0x36, 0xad, 0x1c, 0x5b, 0xb5, 0x0c, 0x9b, 0x9e, 0xc3, 0x36, 0x80, 0x8b,
-	0xe2, 0x8b, 0x0e, 0x80, 0xfb, 0x53, 0x83, 0xe7, 0xe9, 0xa6, 0x61, 0xc8, 0xa3, 0xa8, 0x20, 0xa7,
-	0x17, 0xd0, 0x7a, 0x13, 0xf2, 0xbb, 0x7c, 0x5e, 0x37, 0x0e, 0xd1, 0x13, 0x53, 0x9e, 0xc4, 0x12,
-	0x94, 0xa9, 0x99, 0x63, 0xa6, 0x97, 0x33, 0xab, 0x95, 0x31, 0x33, 0x72, 0xcc, 0xbe, 0x84, 0x5e,
-	0x39, 0xc8, 0x03, 0xec, 0xfe, 0xd3, 0xe0, 0x2c, 0xc9, 0xc7, 0xfa, 0x8a, 0xf9, 0xf3, 0x45, 0xbc,
-	0xa1, 0x54, 0x9b, 0xae, 0x57, 0x49, 0x17, 0x77, 0x06, 0x4d, 0xe2, 0x2c, 0x6f, 0x85, 0x4d, 0xa5,
-	0x17, 0x2d, 0x68, 0x4e, 0x56, 0xcc, 0xf3, 0x6f, 0x64, 0x3f, 0x6b, 0xfd, 0x26, 0xcd, 0x6c, 0x41,
-	0x37, 0x39, 0x2a, 0x61, 0x55, 0xa3, 0xa9, 0x29, 0x00, 0x88, 0xac, 0x28, 0x46, 0x72, 0x8d, 0x1d,
-	0xa8, 0x4e, 0xb9, 0xa4, 0x62, 0xd0, 0xea, 0x94, 0x6f, 0x53, 0xaf, 0x97, 0x51, 0x6f, 0x6c, 0xa8,
-	0xa3, 0x0d, 0xed, 0xc9, 0x3a, 0xf0, 0xc4, 0x69, 0x42, 0x67, 0xcc, 0xa6, 0x8c, 0x6d, 0xf9, 0xec,
-	0xbf, 0x35, 0xc0, 0x94, 0xe6, 0x56, 0xdd, 0x0e, 0x91, 0x2c, 0x9b, 0x89, 0x94, 0x86, 0xbe, 0x47,
-	0xa3, 0x56, 0x4c, 0xc3, 0x28, 0xa3, 0x51, 0x3f, 0x40, 0xa3, 0x51, 0x40, 0xe3, 0x39, 0xb4, 0x24,
-	0x0b, 0x51, 0xba, 0x5c, 0x39, 0xb5, 0xac, 0x9c, 0x14, 0xcc, 0x4b, 0x16, 0x0f, 0x17, 0xae, 0x1f,
-	0x64, 0x82, 0x9c, 0x1b, 0xfc, 0x6f, 0x57, 0xdc, 0x5b, 0xa4, 0x83, 0x2f, 0x8d, 0x5d, 0x35, 0x4f,
-	0x1a, 0x34, 0xef, 0xb2, 0x3f, 0x83, 0x27, 0x05, 0x67, 0xee, 0xf5, 0xd4, 0x06, 0x84, 0x09, 0x8f,
-	0x2f, 0x59, 0x7c, 0x95, 0x3c, 0x50, 0xd7, 0xc1, 0x0d, 0x8f, 0x14, 0x04, 0xfb, 0x47, 0x38, 0xca,
-	0xb9, 0x45, 0x17, 0xc9, 0x97, 0x21, 0xb8, 0xe1, 0x4a, 0x2d, 0x33, 0x1b, 0x3f, 0x81, 0xe3, 0xc9,
-	0xfd, 0x6a, 0xc5, 0xc3, 0x58, 0xb6, 0x72, 0x52, 0x03, 0x83, 0x6e, 0x3b, 0xed, 0x21, 0x9c, 0xef,
-	0x5d, 0xa5, 0x90, 0xf5, 0xa1, 0xa9, 0xfc, 0x91, 0x12, 0xfa, 0x36, 0xc9, 0x7d, 0x48, 0xb3, 0xe8,
-	0xcb, 0x6f, 0xa0, 0xa1, 0x0a, 0x8f, 0x6d, 0x68, 0x3a, 0xcb, 0x44, 0xb7, 0xba, 0x15, 0x3c, 0x16,
-	0xe9, 0xbe, 0x7d, 0x97, 0x98, 0x9a, 0x50, 0x5d, 0x11, 0x1c, 0x38, 0xdd, 0x2a, 0xb6, 0xc0, 0x70,
-	0x96, 0xb7, 0x8e, 0xd7, 0xd5, 0x07, 0xff, 0xe8, 0xd0, 0x51, 0x67, 0x4d, 0x58, 0xf8, 0xab, 0xef,
-	0x31, 0x7c, 0x05, 0xcd, 0xf4, 0xd1, 0xc1, 0x2e, 0xd9, 0x79, 0x98, 0xad, 0x13, 0xb2, 0xfb, 0x22,
-	0xd9, 0x15, 0xa4, 0x70, 0x5a, 0x20, 0x9b, 0xf8, 0x94, 0x94, 0x4b, 0xb9, 0x75, 0x41, 0x0e, 0x28,
-	0xad, 0x5d, 0xc1, 0x19, 0x9c, 0x15, 0xc9, 0x1d, 0x6e, 0xf6, 0x15, 0xc8, 0xad, 0xf5, 0x8c, 0x1c,
-	0xd2, 0x48, 0xbb, 0x82, 0xae, 0x6c, 0xb2, 0x42, 0xad, 0xc1, 0x1e, 0x79, 0x40, 0x2b, 0xad, 0x8f,
-	0xc9, 0x43, 0x42, 0x65, 0x57, 0xf0, 0x6b, 0x38, 0x9d, 0xc4, 0x21, 0x73, 0xef, 0xb6, 0xb4, 0x09,
-	0x3f, 0x22, 0x45, 0x5a, 0x65, 0x01, 0xc9, 0xa6, 0xc2, 0xae, 0x7c, 0xae, 0xe1, 0x57, 0x70, 0xb2,
-	0xbd, 0x5b, 0x20, 0x3b, 0x25, 0xfb, 0x02, 0xb0, 0xbb, 0x73, 0xf0, 0x97, 0x06, 0xe7, 0xaa, 0x96,
-	0x43, 0x1e, 0x04, 0xcc, 0x8b, 0x79, 0x98, 0x16, 0xf5, 0x2d, 0x9c, 0xec, 0xcd, 0x01, 0x3e, 0x21,
-	0x65, 0xf3, 0x66, 0x59, 0xa4, 0x74, 0x6c, 0xec, 0x0a, 0xbe, 0x81, 0x47, 0x3b, 0x9d, 0x8b, 0xe7,
-	0xa4, 0x78, 0x6c, 0x2c, 0x93, 0x94, 0x34, 0xb9, 0x5d, 0x71, 0x1a, 0x3f, 0x1b, 0xf2, 0x2f, 0xe0,
-	0xfb, 0xba, 0xfc, 0xf9, 0xe2, 0xff, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd9, 0xf0, 0xe1, 0x18, 0x1a,
-	0x0a, 0x00, 0x00,
+	// 1242 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0x4f, 0x8f, 0xdb, 0x44,
+	0x14, 0x8f, 0xe3, 0xfc, 0x7d, 0x9b, 0x4d, 0xb3, 0xb3, 0xcb, 0xae, 0x9b, 0x6e, 0xd5, 0x60, 0x41,
+	0x15, 0xf5, 0x30, 0xd0, 0x20, 0x01, 0x42, 0xe5, 0xd0, 0x24, 0x74, 0x37, 0xb0, 0xc0, 0xca, 0x49,
+	0x4a, 0xc5, 0xcd, 0xf5, 0xce, 0x26, 0x56, 0xb2, 0x9e, 0x60, 0xcf, 0xb6, 0x8d, 0x84, 0xc4, 0x95,
+	0x13, 0x47, 0x2e, 0x1c, 0xf9, 0x00, 0x7c, 0x09, 0x6e, 0x7c, 0x05, 0x8e, 0x7c, 0x10, 0x34, 0xe3,
+	0x19, 0xdb, 0x89, 0xed, 0x14, 0x71, 0xca, 0xbc, 0x37, 0x9e, 0x99, 0xdf, 0xef, 0xf7, 0x66, 0xde,
+	0x7b, 0x81, 0xfd, 0xb9, 0x3b, 0x9b, 0xbf, 0xb6, 0xd7, 0x78, 0xe5, 0x53, 0x46, 0xcd, 0xbf, 0x34,
+	0xb8, 0x63, 0x91, 0x99, 0x1b, 0x30, 0xe2, 0x5b, 0xe4, 0x87, 0x5b, 0x12, 0x30, 0x84, 0x01, 0x0d,
+	0xe8, 0xcd, 0x8d, 0xcb, 0x18, 0x21, 0x97, 0xb7, 0x2f, 0x97, 0xae, 0xf3, 0x15, 0x59, 0x1b, 0x5a,
+	0x47, 0xeb, 0xd6, 0xad, 0x8c, 0x19, 0xf4, 0x10, 0x9a, 0xdf, 0xd9, 0x1e, 0x23, 0x57, 0x5f, 0x93,
+	0x20, 0xb0, 0x67, 0x24, 0x30, 0x8a, 0x1d, 0xbd, 0x5b, 0xb7, 0xb6, 0xbc, 0xa8, 0x03, 0x7b, 0xd1,
+	0xea, 0xd1, 0xd0, 0xd0, 0x3b, 0x5a, 0xb7, 0x61, 0x25, 0x5d, 0xe8, 0x18, 0x2a, 0x97, 0x84, 0xf8,
+	0xa3, 0xa1, 0x51, 0x12, 0xa7, 0x49, 0x0b, 0x21, 0x28, 0x59, 0x74, 0x49, 0x8c, 0xb2, 0xf0, 0x8a,
+	0x31, 0xf7, 0x4d, 0xa7, 0xa3, 0xa1, 0x51, 0x09, 0x7d, 0x7c, 0x6c, 0x7e, 0x09, 0xb5, 0x69, 0x40,
+	0x7c, 0x31, 0x7f, 0x04, 0xe5, 0x0b, 0x7b, 0x4d, 0x7c, 0x09, 0x3c, 0x34, 0xa2, 0x9d, 0x8a, 0x89,
+	0x9d, 0x8e, 0xa0, 0x3c, 0x9e, 0xdb, 0xfe, 0x95, 0x40, 0x54, 0xb6, 0x42, 0xc3, 0x7c, 0x01, 0xad,
+	0x58, 0x98, 0x60, 0x45, 0xbd, 0x80, 0xa0, 0xf7, 0xa1, 0x74, 0x69, 0xbb, 0x7c, 0x4b, 0xbd, 0xbb,
+	0xd7, 0x3b, 0xc0, 0x92, 0xda, 0x84, 0xae, 0x5c, 0x87, 0x4f, 0x58, 0x62, 0x1a, 0xdd, 0x4f, 0x1c,
+	0xb2, 0xd7, 0xab, 0x63, 0x85, 0x29, 0x3c, 0xcf, 0xfc, 0x55, 0x83, 0xd6, 0xf6, 0x4a, 0x64, 0x40,
+	0x55, 0xfa, 0x24, 0x60, 0x65, 0x72, 0x78, 0xe2, 0x33, 0xa9, 0x6a, 0x68, 0xa0, 0x47, 0xa0, 0x3f,
+	0x75, 0x98, 0xa1, 0x77, 0xf4, 0x6e, 0xb3, 0x67, 0xa4, 0x90, 0xe0, 0xa7, 0x0e, 0x73, 0xa9, 0x67,
+	0xf1, 0x8f, 0xcc, 0x87, 0x50, 0x09, 0x4d, 0x04, 0x50, 0xb9, 0x9c, 0xf6, 0xc7, 0xd3, 0x7e, 0xab,
+	0x80, 0xaa, 0xa0, 0x5f, 0x4e, 0xfb, 0x2d, 0x8d, 0x0f, 0xb8, 0xa7, 0x68, 0xfe, 0x08, 0xed, 0x33,
+	0xc2, 0xfa, 0x4b, 0xea, 0x2c, 0x84, 0x06, 0xfd, 0xf5, 0xb9, 0x1d, 0xcc, 0xd5, 0xb5, 0x88, 0x64,
+	0xd2, 0x12, 0x32, 0xf1, 0x90, 0xf1, 0x8f, 0x64, 0xd0, 0x1b, 0x96, 0xb4, 0xd0, 0x29, 0xd4, 0x07,
+	0xf6, 0x72, 0x39, 0x24, 0x2b, 0x36, 0x97, 0xc2, 0xc6, 0x8e, 0x28, 0x78, 0xa5, 0x44, 0xf0, 0x1e,
+	0xc3, 0xbd, 0xcc, 0xd3, 0xa5, 0xf6, 0x08, 0x4a, 0x43, 0x9b, 0xd9, 0x42, 0xfb, 0x86, 0x25, 0xc6,
+	0xe6, 0x2c, 0x5e, 0xd2, 0x27, 0xb6, 0x43, 0xbd, 0x4d, 0xc4, 0x31, 0x36, 0x2d, 0x1f, 0x5b, 0x31,
+	0x0f, 0x9b, 0x9e, 0xc0, 0xd6, 0x83, 0xd3, 0xec, 0x83, 0x76, 0x80, 0xfb, 0x5d, 0x83, 0x07, 0x6a,
+	0xd1, 0xc0, 0xa7, 0x41, 0x90, 0xa1, 0xe9, 0x29, 0xd4, 0x9f, 0xf9, 0xf4, 0x26, 0xa9, 0x6b, 0xec,
+	0xe0, 0x77, 0x62, 0x42, 0xc3, 0xb9, 0x10, 0xa5, 0x32, 0x13, 0xcc, 0xf4, 0x7c, 0x66, 0xa5, 0x3c,
+	0x66, 0xe5, 0x04, 0xb3, 0x8f, 0xa1, 0x93, 0x0f, 0x72, 0x07, 0xbb, 0x7f, 0x34, 0x38, 0x0a, 0xf5,
+	0x58, 0x9f, 0x13, 0x77, 0x36, 0x67, 0x31, 0xa5, 0xd2, 0x64, 0xbd, 0x0a, 0x6f, 0x71, 0xb3, 0x57,
+	0xc3, 0xfd, 0xe5, 0x82, 0xdb, 0x96, 0xf0, 0xa2, 0x36, 0xd4, 0xc6, 0x2b, 0xe2, 0xb8, 0xd7, 0xe2,
+	0x3e, 0x6b, 0xdd, 0x9a, 0x15, 0xd9, 0x9c, 0x6e, 0xb8, 0x55, 0xc8, 0xaa, 0x64, 0x29, 0x93, 0x03,
+	0xe0, 0xaa, 0x48, 0x46, 0x62, 0x8c, 0x9a, 0x50, 0x9c, 0x50, 0x41, 0xa5, 0x6c, 0x15, 0x27, 0x74,
+	0x93, 0x7a, 0x25, 0x8f, 0x7a, 0x35, 0xa6, 0x8e, 0x4c, 0x68, 0x8c, 0xd7, 0x9e, 0xc3, 0x77, 0xe3,
+	0x79, 0xc6, 0xa8, 0x89, 0xb9, 0x0d, 0x9f, 0xf9, 0xa7, 0x06, 0x48, 0xd1, 0xdc, 0x88, 0xdb, 0x2e,
+	0x92, 0x79, 0x6f, 0x42, 0xd1, 0xd0, 0x53, 0x34, 0x4a, 0xd9, 0x34, 0xca, 0x79, 0x34, 0x2a, 0x3b,
+	0x68, 0x54, 0x33, 0x68, 0x3c, 0x80, 0xba, 0x60, 0xc1, 0x43, 0x97, 0x08, 0xa7, 0x16, 0x85, 0xf3,
+	0x67, 0x0d, 0x8e, 0xc7, 0xcc, 0x66, 0x64, 0x30, 0x27, 0xce, 0x62, 0x45, 0x5d, 0x2f, 0x0a, 0x68,
+	0x0b, 0xf4, 0xc1, 0x68, 0x28, 0x6f, 0x27, 0x1f, 0x46, 0x28, 0x8a, 0x3b, 0x50, 0xe8, 0x69, 0x14,
+	0xe8, 0x3d, 0xd8, 0x97, 0x9b, 0x12, 0x5f, 0x7c, 0x14, 0x3e, 0xff, 0x4d, 0xa7, 0xf9, 0x13, 0x9c,
+	0xa4, 0x90, 0xc4, 0x17, 0xf1, 0xb9, 0x4b, 0x5e, 0x2b, 0xe4, 0x7c, 0xcc, 0xd3, 0x92, 0xa0, 0x26,
+	0xd0, 0x34, 0xac, 0xd0, 0xe0, 0x32, 0x5a, 0x94, 0xb2, 0x80, 0x2b, 0x2f, 0x2b, 0x4d, 0xec, 0xe0,
+	0xb3, 0x63, 0x66, 0x2f, 0x5c, 0x6f, 0x36, 0x79, 0x23, 0x40, 0x34, 0xac, 0xd8, 0x61, 0xfe, 0xa6,
+	0xc1, 0x81, 0x40, 0xf0, 0x0d, 0xbd, 0x22, 0x41, 0xbe, 0x0c, 0x3b, 0xc2, 0xbc, 0x9d, 0x40, 0x52,
+	0xf2, 0x94, 0xfe, 0x8b, 0x3c, 0xe5, 0x2c, 0x79, 0x3e, 0x81, 0xfd, 0x08, 0x9c, 0x0a, 0xa7, 0x60,
+	0x29, 0x45, 0x11, 0x04, 0x55, 0x88, 0x8b, 0x89, 0x10, 0xff, 0xad, 0x01, 0x12, 0x2b, 0x2f, 0x88,
+	0xfd, 0x6a, 0x17, 0x2f, 0x51, 0x23, 0x29, 0x53, 0x8b, 0xf9, 0x58, 0x54, 0x66, 0x9f, 0x5c, 0xbb,
+	0x6f, 0xa4, 0x98, 0xd2, 0x12, 0x45, 0x81, 0xd9, 0x3e, 0x93, 0x2a, 0x86, 0x86, 0xa8, 0xbd, 0xee,
+	0x8d, 0xcb, 0xe4, 0x05, 0x0e, 0x8d, 0xff, 0x7b, 0x79, 0xd3, 0xba, 0xd4, 0x76, 0xe9, 0x72, 0x41,
+	0xec, 0x6b, 0xa1, 0x4b, 0x0b, 0x74, 0xd5, 0xb7, 0x34, 0x2c, 0x3e, 0xe4, 0xb0, 0x9e, 0xdb, 0xcb,
+	0x5b, 0xa2, 0xae, 0x8a, 0x30, 0x4c, 0x0b, 0x8c, 0x33, 0xc2, 0x06, 0x73, 0xdb, 0xf5, 0xa2, 0x5e,
+	0x24, 0x51, 0xf3, 0xbe, 0x58, 0x51, 0x67, 0xae, 0x6a, 0x9e, 0x30, 0xb6, 0x1b, 0x99, 0x30, 0x37,
+	0x27, 0x5d, 0xe6, 0x07, 0x70, 0x37, 0x63, 0xcf, 0x54, 0x3a, 0x8d, 0x83, 0x63, 0xc0, 0xf1, 0x19,
+	0x61, 0xe7, 0x61, 0x6f, 0x36, 0xf2, 0xae, 0xa9, 0x8a, 0x8f, 0xf9, 0x2d, 0xec, 0x25, 0xdc, 0x3c,
+	0x81, 0x8a, 0xa6, 0xc8, 0xbb, 0xa6, 0xb2, 0x51, 0x88, 0x6c, 0x2e, 0xd4, 0xf8, 0x76, 0xb5, 0xa2,
+	0x3e, 0x13, 0x59, 0x3c, 0xbc, 0x97, 0x65, 0x6b, 0xd3, 0x69, 0x0e, 0xe0, 0x24, 0x75, 0x94, 0x44,
+	0xd6, 0x85, 0x9a, 0xf4, 0x07, 0xb2, 0xc7, 0x69, 0xe0, 0xc4, 0x87, 0x56, 0x34, 0xfb, 0xe8, 0x73,
+	0xa8, 0xca, 0x9c, 0x87, 0x1a, 0x50, 0xeb, 0x2f, 0xc3, 0x92, 0xdd, 0x2a, 0xa0, 0x7d, 0x9e, 0x69,
+	0x16, 0x2f, 0x42, 0x53, 0xe3, 0x0d, 0x07, 0x9f, 0xec, 0xf5, 0x5b, 0x45, 0x54, 0xe7, 0x2f, 0x75,
+	0xd1, 0x77, 0x5a, 0x7a, 0xef, 0x97, 0x32, 0x34, 0xe5, 0x5e, 0x63, 0xe2, 0xbf, 0x72, 0x1d, 0x82,
+	0x1e, 0x43, 0x4d, 0xf5, 0x5b, 0xa8, 0x85, 0xb7, 0x7a, 0xd2, 0xf6, 0x01, 0xde, 0x6e, 0xc6, 0xcc,
+	0x02, 0xb2, 0xe0, 0x30, 0xa3, 0x63, 0x40, 0xf7, 0x70, 0x7e, 0x17, 0xd3, 0x3e, 0xc5, 0x3b, 0x9a,
+	0x0c, 0xb3, 0x80, 0xa6, 0x70, 0x94, 0x55, 0xe9, 0xd1, 0x29, 0xce, 0x72, 0xab, 0x5d, 0xef, 0xe3,
+	0x5d, 0xed, 0x81, 0x59, 0x40, 0x36, 0x18, 0xea, 0x8b, 0xed, 0x32, 0x8b, 0x3a, 0xf8, 0x2d, 0x6d,
+	0x42, 0xfb, 0x5d, 0xfc, 0xb6, 0x1a, 0x6d, 0x16, 0xd0, 0x13, 0x38, 0x1c, 0x33, 0x9f, 0xd8, 0x37,
+	0x1b, 0x65, 0x19, 0xbd, 0x83, 0xb3, 0xca, 0x74, 0x1b, 0x70, 0x54, 0x10, 0xcc, 0xc2, 0x87, 0x1a,
+	0xfa, 0x14, 0x0e, 0x36, 0x57, 0x73, 0x64, 0x87, 0x38, 0x5d, 0xfb, 0x52, 0x2b, 0x47, 0x80, 0xce,
+	0x08, 0xdb, 0x4a, 0xd9, 0xe8, 0x04, 0x67, 0x97, 0x93, 0xb6, 0x81, 0x73, 0xb2, 0xbb, 0x59, 0x40,
+	0x9f, 0x41, 0x2b, 0x04, 0x11, 0xa7, 0x5f, 0x84, 0x70, 0x2a, 0x17, 0xb7, 0x9b, 0x78, 0x23, 0x05,
+	0x0a, 0x18, 0x4f, 0x14, 0x81, 0x44, 0x8e, 0x43, 0x87, 0x38, 0x9d, 0xf1, 0xd4, 0x6a, 0x95, 0x28,
+	0xf8, 0xea, 0xde, 0x1f, 0x1a, 0x9c, 0xc8, 0x0b, 0x39, 0xa0, 0x9e, 0x47, 0x1c, 0x46, 0x7d, 0x75,
+	0x33, 0x2f, 0xe0, 0x20, 0xf5, 0x98, 0xd1, 0x5d, 0x9c, 0x97, 0x34, 0xda, 0x6d, 0x9c, 0xfb, 0xf6,
+	0xcd, 0x02, 0x7a, 0x06, 0x77, 0xb6, 0x9e, 0x1f, 0x3a, 0xc1, 0xd9, 0x6f, 0xbf, 0x6d, 0xe0, 0x9c,
+	0x97, 0x6a, 0x16, 0xfa, 0xd5, 0xef, 0xcb, 0xe2, 0x2f, 0xdc, 0xcb, 0x8a, 0xf8, 0xf9, 0xe8, 0xdf,
+	0x01, 0x00, 0x79, 0xe2, 0xb0, 0xc6, 0xda, 0x0d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1157,6 +1566,9 @@ type HighwayServiceClient interface {
 	GetBlockCrossShardByHash(ctx context.Context, in *GetBlockCrossShardByHashRequest, opts ...grpc.CallOption) (*GetBlockCrossShardByHashResponse, error)
 	StreamBlockByHeight(ctx context.Context, in *BlockByHeightRequest, opts ...grpc.CallOption) (HighwayService_StreamBlockByHeightClient, error)
 	StreamBlockByHash(ctx context.Context, in *BlockByHashRequest, opts ...grpc.CallOption) (HighwayService_StreamBlockByHashClient, error)
+	GetStateCheckpoint(ctx context.Context, in *StateCheckpointRequest, opts ...grpc.CallOption) (*StateCheckpointResponse, error)
+	StreamStateNodes(ctx context.Context, in *StateNodesRequest, opts ...grpc.CallOption) (HighwayService_StreamStateNodesClient, error)
+	StreamStateLeaves(ctx context.Context, in *StateLeavesRequest, opts ...grpc.CallOption) (HighwayService_StreamStateLeavesClient, error)
 }
 
 type highwayServiceClient struct {
@@ -1267,6 +1679,79 @@ func (x *highwayServiceStreamBlockByHashClient) Recv() (*BlockData, error) {
 	return m, nil
 }
 
+func (c *highwayServiceClient) GetStateCheckpoint(ctx context.Context, in *StateCheckpointRequest, opts ...grpc.CallOption) (*StateCheckpointResponse, error) {
+	out := new(StateCheckpointResponse)
+	err := c.cc.Invoke(ctx, "/HighwayService/GetStateCheckpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *highwayServiceClient) StreamStateNodes(ctx context.Context, in *StateNodesRequest, opts ...grpc.CallOption) (HighwayService_StreamStateNodesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_HighwayService_serviceDesc.Streams[2], "/HighwayService/StreamStateNodes", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &highwayServiceStreamStateNodesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type HighwayService_StreamStateNodesClient interface {
+	Recv() (*StateNodeData, error)
+	grpc.ClientStream
+}
+
+type highwayServiceStreamStateNodesClient struct {
+	grpc.ClientStream
+}
+
+func (x *highwayServiceStreamStateNodesClient) Recv() (*StateNodeData, error) {
+	m := new(StateNodeData)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *highwayServiceClient) StreamStateLeaves(ctx context.Context, in *StateLeavesRequest, opts ...grpc.CallOption) (HighwayService_StreamStateLeavesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_HighwayService_serviceDesc.Streams[3], "/HighwayService/StreamStateLeaves", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &highwayServiceStreamStateLeavesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type HighwayService_StreamStateLeavesClient interface {
+	Recv() (*StateLeafData, error)
+	grpc.ClientStream
+}
+
+type highwayServiceStreamStateLeavesClient struct {
+	grpc.ClientStream
+}
+
+func (x *highwayServiceStreamStateLeavesClient) Recv() (*StateLeafData, error) {
+	m := new(StateLeafData)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // HighwayServiceServer is the server API for HighwayService service.
 type HighwayServiceServer interface {
 	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
@@ -1275,6 +1760,9 @@ type HighwayServiceServer interface {
 	GetBlockCrossShardByHash(context.Context, *GetBlockCrossShardByHashRequest) (*GetBlockCrossShardByHashResponse, error)
 	StreamBlockByHeight(*BlockByHeightRequest, HighwayService_StreamBlockByHeightServer) error
 	StreamBlockByHash(*BlockByHashRequest, HighwayService_StreamBlockByHashServer) error
+	GetStateCheckpoint(context.Context, *StateCheckpointRequest) (*StateCheckpointResponse, error)
+	StreamStateNodes(*StateNodesRequest, HighwayService_StreamStateNodesServer) error
+	StreamStateLeaves(*StateLeavesRequest, HighwayService_StreamStateLeavesServer) error
 }
 
 // UnimplementedHighwayServiceServer can be embedded to have forward compatible implementations.
@@ -1299,6 +1787,15 @@ func (*UnimplementedHighwayServiceServer) StreamBlockByHeight(req *BlockByHeight
 func (*UnimplementedHighwayServiceServer) StreamBlockByHash(req *BlockByHashRequest, srv HighwayService_StreamBlockByHashServer) error {
 	return status.Errorf(codes.Unimplemented, "method StreamBlockByHash not implemented")
 }
+func (*UnimplementedHighwayServiceServer) GetStateCheckpoint(ctx context.Context, req *StateCheckpointRequest) (*StateCheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStateCheckpoint not implemented")
+}
+func (*UnimplementedHighwayServiceServer) StreamStateNodes(req *StateNodesRequest, srv HighwayService_StreamStateNodesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamStateNodes not implemented")
+}
+func (*UnimplementedHighwayServiceServer) StreamStateLeaves(req *StateLeavesRequest, srv HighwayService_StreamStateLeavesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamStateLeaves not implemented")
+}
 
 func RegisterHighwayServiceServer(s *grpc.Server, srv HighwayServiceServer) {
 	s.RegisterService(&_HighwayService_serviceDesc, srv)
@@ -1418,6 +1915,66 @@ func (x *highwayServiceStreamBlockByHashServer) Send(m *BlockData) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _HighwayService_GetStateCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StateCheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HighwayServiceServer).GetStateCheckpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/HighwayService/GetStateCheckpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HighwayServiceServer).GetStateCheckpoint(ctx, req.(*StateCheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HighwayService_StreamStateNodes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StateNodesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HighwayServiceServer).StreamStateNodes(m, &highwayServiceStreamStateNodesServer{stream})
+}
+
+type HighwayService_StreamStateNodesServer interface {
+	Send(*StateNodeData) error
+	grpc.ServerStream
+}
+
+type highwayServiceStreamStateNodesServer struct {
+	grpc.ServerStream
+}
+
+func (x *highwayServiceStreamStateNodesServer) Send(m *StateNodeData) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _HighwayService_StreamStateLeaves_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StateLeavesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HighwayServiceServer).StreamStateLeaves(m, &highwayServiceStreamStateLeavesServer{stream})
+}
+
+type HighwayService_StreamStateLeavesServer interface {
+	Send(*StateLeafData) error
+	grpc.ServerStream
+}
+
+type highwayServiceStreamStateLeavesServer struct {
+	grpc.ServerStream
+}
+
+func (x *highwayServiceStreamStateLeavesServer) Send(m *StateLeafData) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _HighwayService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "HighwayService",
 	HandlerType: (*HighwayServiceServer)(nil),
@@ -1438,6 +1995,10 @@ var _HighwayService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlockCrossShardByHash",
 			Handler:    _HighwayService_GetBlockCrossShardByHash_Handler,
 		},
+		{
+			MethodName: "GetStateCheckpoint",
+			Handler:    _HighwayService_GetStateCheckpoint_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1450,6 +2011,16 @@ var _HighwayService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _HighwayService_StreamBlockByHash_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamStateNodes",
+			Handler:       _HighwayService_StreamStateNodes_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamStateLeaves",
+			Handler:       _HighwayService_StreamStateLeaves_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "highway.proto",
 }
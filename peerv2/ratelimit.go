@@ -0,0 +1,139 @@
+package peerv2
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	manet "github.com/multiformats/go-multiaddr/net"
+	grpcpeer "google.golang.org/grpc/peer"
+)
+
+const maxRateLimitedPeers = 1024 // buckets kept before idle peers are dropped
+
+// rateLimiter is a token bucket for each peer, a bucket holds at most burst
+// tokens and is refilled with rate tokens per second. The peers also share a
+// global bucket, which caps the total rate whatever the number of peers.
+type rateLimiter struct {
+	rate    float64
+	burst   float64
+	buckets map[string]*tokenBucket
+	global  *tokenBucket
+	sync.Mutex
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	rate   float64
+	burst  float64
+}
+
+func newRateLimiter(rate, burst, globalRate, globalBurst int) *rateLimiter {
+	return &rateLimiter{
+		rate:    float64(rate),
+		burst:   float64(burst),
+		buckets: map[string]*tokenBucket{},
+		global:  &tokenBucket{tokens: float64(globalBurst), last: time.Now(), rate: float64(globalRate), burst: float64(globalBurst)},
+	}
+}
+
+// refill adds the tokens earned by b since its last use
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+}
+
+// allow takes n tokens from the bucket of peerID and from the global bucket
+// and reports whether both had enough of them. Nothing is taken when they do
+// not.
+func (l *rateLimiter) allow(peerID string, n int) bool {
+	l.Lock()
+	defer l.Unlock()
+	now := time.Now()
+	b, ok := l.buckets[peerID]
+	if !ok {
+		if len(l.buckets) >= maxRateLimitedPeers {
+			l.dropIdle(now)
+		}
+		b = &tokenBucket{tokens: l.burst, last: now, rate: l.rate, burst: l.burst}
+		l.buckets[peerID] = b
+	}
+	b.refill(now)
+	l.global.refill(now)
+	if b.tokens < float64(n) || l.global.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	l.global.tokens -= float64(n)
+	return true
+}
+
+// dropIdle removes the buckets which are full again, their peers are treated
+// as new peers on the next request
+func (l *rateLimiter) dropIdle(now time.Time) {
+	for peerID, b := range l.buckets {
+		b.refill(now)
+		if b.tokens >= l.burst {
+			delete(l.buckets, peerID)
+		}
+	}
+}
+
+// streamPeers records the peer ID authenticated by the libp2p connection of
+// the streams carrying gRPC requests, by the remote address gRPC reports for
+// the stream.
+type streamPeers struct {
+	peers map[string]peer.ID
+	sync.Mutex
+}
+
+func newStreamPeers() *streamPeers {
+	return &streamPeers{peers: map[string]peer.ID{}}
+}
+
+// wrap returns a stream handler recording the remote peer of a stream before
+// handing the stream to next
+func (s *streamPeers) wrap(next network.StreamHandler) network.StreamHandler {
+	return func(stream network.Stream) {
+		if addr, err := manet.ToNetAddr(stream.Conn().RemoteMultiaddr()); err == nil {
+			s.Lock()
+			if len(s.peers) >= maxRateLimitedPeers {
+				s.peers = map[string]peer.ID{}
+			}
+			s.peers[addr.String()] = stream.Conn().RemotePeer()
+			s.Unlock()
+		}
+		next(stream)
+	}
+}
+
+func (s *streamPeers) get(addr string) (peer.ID, bool) {
+	s.Lock()
+	defer s.Unlock()
+	pid, ok := s.peers[addr]
+	return pid, ok
+}
+
+// requestPeerID returns the ID of the peer which sent the request of ctx, as
+// authenticated by the transport: the libp2p peer of the stream, or its
+// address when the stream is unknown. The requester peer carried in a request
+// is not trusted. The requests relayed by a highway are all limited as the
+// highway, the global bucket of the limiter caps them whatever they carry.
+func requestPeerID(ctx context.Context, peers *streamPeers) string {
+	p, ok := grpcpeer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if peers != nil {
+		if pid, ok := peers.get(p.Addr.String()); ok {
+			return pid.Pretty()
+		}
+	}
+	return p.Addr.String()
+}
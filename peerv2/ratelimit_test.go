@@ -0,0 +1,98 @@
+package peerv2
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/peerv2/proto"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	grpcpeer "google.golang.org/grpc/peer"
+)
+
+type testConn struct {
+	network.Conn
+	remotePeer peer.ID
+	remoteAddr multiaddr.Multiaddr
+}
+
+func (c *testConn) RemotePeer() peer.ID                  { return c.remotePeer }
+func (c *testConn) RemoteMultiaddr() multiaddr.Multiaddr { return c.remoteAddr }
+
+type testStream struct {
+	network.Stream
+	conn *testConn
+}
+
+func (s *testStream) Conn() network.Conn { return s.conn }
+
+type stateNetSync struct {
+	NetSync
+}
+
+func (ns *stateNetSync) GetStateCheckpoint(cID int) (*proto.StateCheckpointResponse, error) {
+	return &proto.StateCheckpointResponse{}, nil
+}
+
+// peerContext returns the context of a request relayed by the gRPC stream
+// from port of localhost
+func peerContext(port int) context.Context {
+	return grpcpeer.NewContext(context.Background(), &grpcpeer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: port}})
+}
+
+func TestRequestPeerID(t *testing.T) {
+	highway, err := peer.Decode("QmbV4AAHWFFEtE67qqmNeEYXs5Yw5xNMS75oEKtdBvfoKN")
+	assert.Nil(t, err)
+	highwayAddr, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/9330")
+	assert.Nil(t, err)
+
+	peers := newStreamPeers()
+	handled := 0
+	handle := peers.wrap(func(network.Stream) { handled++ })
+	handle(&testStream{conn: &testConn{remotePeer: highway, remoteAddr: highwayAddr}})
+	assert.Equal(t, 1, handled)
+
+	// the peer authenticated by the stream, its address for an unknown stream
+	assert.Equal(t, highway.Pretty(), requestPeerID(peerContext(9330), peers))
+	assert.Equal(t, "127.0.0.1:9331", requestPeerID(peerContext(9331), peers))
+	assert.Equal(t, "127.0.0.1:9330", requestPeerID(peerContext(9330), nil))
+	assert.Equal(t, "", requestPeerID(context.Background(), peers))
+}
+
+func TestBlockProvider_StateRateLimitRotatingRequester(t *testing.T) {
+	newProvider := func() *BlockProvider {
+		return &BlockProvider{
+			NetSync:      &stateNetSync{},
+			stateLimiter: newRateLimiter(1, 2, 1, 5),
+			streamPeers:  newStreamPeers(),
+		}
+	}
+
+	// the requester peer carried by the requests relayed by a highway is not trusted
+	bp := newProvider()
+	served := 0
+	for i := 0; i < 20; i++ {
+		req := &proto.StateCheckpointRequest{RequesterPeer: fmt.Sprintf("requester-%v", i)}
+		if _, err := bp.GetStateCheckpoint(peerContext(9330), req); err == nil {
+			served++
+		} else {
+			assert.Equal(t, errStateRateLimited, err)
+		}
+	}
+	assert.Equal(t, 2, served)
+
+	// rotating the requester peer and the stream does not exceed the global cap
+	bp = newProvider()
+	served = 0
+	for i := 0; i < 20; i++ {
+		req := &proto.StateCheckpointRequest{RequesterPeer: fmt.Sprintf("requester-%v", i)}
+		if _, err := bp.GetStateCheckpoint(peerContext(10000+i), req); err == nil {
+			served++
+		}
+	}
+	assert.Equal(t, 5, served)
+}
@@ -334,6 +334,11 @@ func getMessagesForLayer(mode, layer string, shardID []byte) []string {
 			msgs = append(msgs, wire.CmdBlockShard)
 		}
 		return msgs
+	case common.NodeModeLight:
+		// the light client streams the beacon blocks from the peers it learns from their states
+		return []string{
+			wire.CmdPeerState,
+		}
 	}
 	return []string{}
 }
@@ -16,6 +16,9 @@ const (
 	RequestShardBlockByHeightTopic  = "requestshardblockbyheighttopic"
 	RequestBeaconBlockByHeightTopic = "requestbeaconblockbyheighttopic"
 	RequestBeaconBlockByHashTopic   = "requestbeaconblockbyhashtopic"
+	NewBestViewTopic                = "newbestviewtopic"
+	NewFinalViewTopic               = "newfinalviewtopic"
+	ReorgTopic                      = "reorgtopic"
 	TestTopic                       = "testtopic"
 )
 
@@ -33,4 +36,7 @@ var Topics = []string{
 	RequestShardBlockByHeightTopic,
 	RequestShardBlockByHashTopic,
 	ShardBeststateTopic,
+	NewBestViewTopic,
+	NewFinalViewTopic,
+	ReorgTopic,
 }
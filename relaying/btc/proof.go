@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
@@ -211,6 +213,53 @@ func (b *BlockChain) ExtractPaymentAddrStrFromPkScript(pkScript []byte) (string,
 	return addrs[0].EncodeAddress(), nil
 }
 
+// ExtractPaymentAddrStrFromTxIn extracts the payment address string spent by
+// a P2PKH, P2WPKH or P2SH-P2WPKH input from the public key it reveals. The
+// pkscript of the spent output is not in the tx, the other inputs (eg.
+// multisig) are not supported
+func (b *BlockChain) ExtractPaymentAddrStrFromTxIn(txIn *wire.TxIn) (string, error) {
+	chainParams := b.GetChainParams()
+	pushes, err := txscript.PushedData(txIn.SignatureScript)
+	if err != nil {
+		return "", err
+	}
+	var addr btcutil.Address
+	switch {
+	case len(txIn.Witness) == 2 && len(pushes) == 0:
+		// P2WPKH, the witness is the signature and the public key
+		if _, err := btcec.ParsePubKey(txIn.Witness[1], btcec.S256()); err != nil {
+			return "", err
+		}
+		addr, err = btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(txIn.Witness[1]), chainParams)
+	case len(txIn.Witness) == 2 && len(pushes) == 1:
+		// P2SH-P2WPKH, the signature script is the witness program of the public key
+		if _, err := btcec.ParsePubKey(txIn.Witness[1], btcec.S256()); err != nil {
+			return "", err
+		}
+		var witnessProgram []byte
+		witnessProgram, err = txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(btcutil.Hash160(txIn.Witness[1])).Script()
+		if err != nil {
+			return "", err
+		}
+		if !bytes.Equal(pushes[0], witnessProgram) {
+			return "", errors.New("signature script is not the witness program of the public key")
+		}
+		addr, err = btcutil.NewAddressScriptHash(witnessProgram, chainParams)
+	case len(txIn.Witness) == 0 && len(pushes) == 2:
+		// P2PKH, the signature script is the signature and the public key
+		if _, err := btcec.ParsePubKey(pushes[1], btcec.S256()); err != nil {
+			return "", err
+		}
+		addr, err = btcutil.NewAddressPubKeyHash(btcutil.Hash160(pushes[1]), chainParams)
+	default:
+		return "", errors.New("input is not a P2PKH, P2WPKH or P2SH-P2WPKH input")
+	}
+	if err != nil {
+		return "", err
+	}
+	return addr.EncodeAddress(), nil
+}
+
 // IsBTCAddressValid checks whether the passed btc address string is valid or not
 func (btcChain *BlockChain) IsBTCAddressValid(addrStr string) bool {
 	params := btcChain.GetChainParams()
@@ -4,7 +4,12 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
 )
 
 func TestMerkleVerification1(t *testing.T) {
@@ -81,3 +86,51 @@ func TestBuildTxMsg(t *testing.T) {
 		t.Errorf("Want tx hash %s but got %s", txID, msgTx.TxHash())
 	}
 }
+
+func TestExtractPaymentAddrStrFromTxIn(t *testing.T) {
+	btcChain := &BlockChain{chainParams: &chaincfg.TestNet3Params}
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey := privKey.PubKey().SerializeCompressed()
+	sig := make([]byte, 71)
+	pubKeyHash := btcutil.Hash160(pubKey)
+
+	// P2PKH
+	sigScript, _ := txscript.NewScriptBuilder().AddData(sig).AddData(pubKey).Script()
+	addr, err := btcChain.ExtractPaymentAddrStrFromTxIn(&wire.TxIn{SignatureScript: sigScript})
+	expectedAddr, _ := btcutil.NewAddressPubKeyHash(pubKeyHash, &chaincfg.TestNet3Params)
+	if err != nil || addr != expectedAddr.EncodeAddress() {
+		t.Errorf("P2PKH input address %v, expected %v, err %v", addr, expectedAddr.EncodeAddress(), err)
+	}
+
+	// P2WPKH
+	addr, err = btcChain.ExtractPaymentAddrStrFromTxIn(&wire.TxIn{Witness: wire.TxWitness{sig, pubKey}})
+	expectedWitnessAddr, _ := btcutil.NewAddressWitnessPubKeyHash(pubKeyHash, &chaincfg.TestNet3Params)
+	if err != nil || addr != expectedWitnessAddr.EncodeAddress() {
+		t.Errorf("P2WPKH input address %v, expected %v, err %v", addr, expectedWitnessAddr.EncodeAddress(), err)
+	}
+
+	// P2SH-P2WPKH
+	witnessProgram, _ := txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(pubKeyHash).Script()
+	sigScript, _ = txscript.NewScriptBuilder().AddData(witnessProgram).Script()
+	addr, err = btcChain.ExtractPaymentAddrStrFromTxIn(&wire.TxIn{SignatureScript: sigScript, Witness: wire.TxWitness{sig, pubKey}})
+	expectedScriptAddr, _ := btcutil.NewAddressScriptHash(witnessProgram, &chaincfg.TestNet3Params)
+	if err != nil || addr != expectedScriptAddr.EncodeAddress() {
+		t.Errorf("P2SH-P2WPKH input address %v, expected %v, err %v", addr, expectedScriptAddr.EncodeAddress(), err)
+	}
+
+	// the witness program of another key
+	otherProgram, _ := txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(make([]byte, 20)).Script()
+	sigScript, _ = txscript.NewScriptBuilder().AddData(otherProgram).Script()
+	if _, err := btcChain.ExtractPaymentAddrStrFromTxIn(&wire.TxIn{SignatureScript: sigScript, Witness: wire.TxWitness{sig, pubKey}}); err == nil {
+		t.Error("P2SH-P2WPKH input with the witness program of another key should be rejected")
+	}
+
+	// multisig inputs are not supported
+	sigScript, _ = txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(sig).AddData(sig).AddData(make([]byte, 71)).Script()
+	if _, err := btcChain.ExtractPaymentAddrStrFromTxIn(&wire.TxIn{SignatureScript: sigScript}); err == nil {
+		t.Error("multisig input should not be supported")
+	}
+}
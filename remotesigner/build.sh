@@ -0,0 +1,11 @@
+echo "Start build remote signer"
+
+echo "go get"
+go get -d
+
+APP_NAME="incognito-signer"
+
+echo "go build -o $APP_NAME"
+go build -o $APP_NAME
+
+echo "Build remote signer success!"
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jessevdk/go-flags"
+)
+
+// See loadConfig for details on the configuration load process.
+type config struct {
+	Listen                string `long:"listen" short:"l" description:"Unix socket the signer listens on for the nodes"`
+	MiningKeys            string `long:"miningkeys" description:"Mining keys held by the signer, separated by |"`
+	PrivateKey            string `long:"privatekey" description:"Wallet private key the mining key is generated from"`
+	SlashingProtectionDir string `long:"slashingprotectiondir" description:"Database dir of the votes and proposals signed by the mining keys"`
+}
+
+// newConfigParser returns a new command line flags parser.
+func newConfigParser(cfg *config, options flags.Options) *flags.Parser {
+	parser := flags.NewParser(cfg, options)
+	return parser
+}
+
+// loadConfig
+// - set default config
+// - read config from cmd line params
+// - return config object
+func loadConfig() (*config, error) {
+	// create config object from default values
+	cfg := config{
+		Listen:                defaultListen,
+		SlashingProtectionDir: defaultSlashingProtectionDirname,
+	}
+
+	preParser := newConfigParser(&cfg, flags.HelpFlag)
+	_, err := preParser.Parse()
+	if err != nil {
+		if e, ok := err.(*flags.Error); ok && e.Type == flags.ErrHelp {
+			fmt.Fprintln(os.Stderr, err)
+			return nil, err
+		}
+	}
+
+	if cfg.MiningKeys == "" && cfg.PrivateKey == "" {
+		return nil, errors.New("miningkeys or privatekey must be set")
+	}
+	return &cfg, nil
+}
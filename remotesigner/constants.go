@@ -0,0 +1,7 @@
+package main
+
+const (
+	version                          = "1.0.0"
+	defaultListen                    = "incognito-signer.sock"
+	defaultSlashingProtectionDirname = "slashingprotection"
+)
@@ -0,0 +1,85 @@
+//go:build !test
+// +build !test
+
+package main
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/incognitochain/incognito-chain/remotesigner/server"
+)
+
+// getPrivateSeeds returns the private seeds of the mining keys of cfg,
+// miningkeys has the format of the node: seed1|consensus:seed2|...
+func getPrivateSeeds(cfg *config) ([]string, error) {
+	privateSeeds := []string{}
+	if cfg.PrivateKey != "" {
+		privateSeed, err := blsbftv2.LoadUserKeyFromIncPrivateKey(cfg.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		privateSeeds = append(privateSeeds, privateSeed)
+	}
+	if cfg.MiningKeys != "" {
+		for _, key := range strings.Split(cfg.MiningKeys, "|") {
+			keyParts := strings.Split(key, ":")
+			privateSeeds = append(privateSeeds, keyParts[len(keyParts)-1])
+		}
+	}
+	return privateSeeds, nil
+}
+
+// Remote signer is a standalone process holding the mining keys of validators,
+// nodes connect to its unix socket to sign votes and proposals with them
+func main() {
+	// Show Version at startup.
+	log.Printf("Version %s\n", version)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+	privateSeeds, err := getPrivateSeeds(cfg)
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+	db, err := incdb.Open("leveldb", cfg.SlashingProtectionDir)
+	if err != nil {
+		log.Println("could not open slashing protection database", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	signerServer := &server.SignerServer{}
+	err = signerServer.Init(&server.SignerServerConfig{
+		Listen:             cfg.Listen,
+		PrivateSeeds:       privateSeeds,
+		SlashingProtection: blsbftv2.NewSlashingProtectionDB(db),
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-interrupt
+		log.Println("Stop remote signer")
+		signerServer.Stop()
+	}()
+
+	log.Printf("Listen on %s\n", cfg.Listen)
+	if err := signerServer.Start(); err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+type Handler struct {
+	signerServer *SignerServer
+}
+
+// GetPublicKey - handler func which returns the public key of the mining key of the validator
+func (s Handler) GetPublicKey(args *signer.GetPublicKeyArgs, publicKey *incognitokey.CommitteePublicKey) error {
+	_, miningKey, err := s.signerServer.getMiningKey(args.Validator)
+	if err != nil {
+		return err
+	}
+	*publicKey = miningKey.GetPublicKey()
+	return nil
+}
+
+// SignPeerData - handler func which signs the peer id or address announced by the node of the validator,
+// data which could be a block hash is refused
+func (s Handler) SignPeerData(args *signer.SignDataArgs, sig *[]byte) error {
+	_, miningKey, err := s.signerServer.getMiningKey(args.Validator)
+	if err != nil {
+		return err
+	}
+	*sig, err = miningKey.SignPeerData(args.Data)
+	return err
+}
+
+// SignVote - handler func which records the vote of the validator in the slashing protection
+// database and signs it, a vote conflicting with a former vote is refused
+func (s Handler) SignVote(args *signer.SignVoteArgs, reply *signer.SignVoteReply) error {
+	validator, miningKey, err := s.signerServer.getMiningKey(args.Validator)
+	if err != nil {
+		return err
+	}
+	if args.SelfIdx < 0 || args.SelfIdx >= len(args.Committee) || !bytes.Equal(args.Committee[args.SelfIdx], miningKey.PubKey[common.BlsConsensus]) {
+		return fmt.Errorf("validator %v is not at index %v of committee", validator, args.SelfIdx)
+	}
+	if err := s.signerServer.Config.SlashingProtection.CheckAndRecordVote(validator, signedBlockRecord(args.Block)); err != nil {
+		log.Printf("Refuse to vote block %v: %v", args.Block.BlockHash.String(), err)
+		return err
+	}
+	reply.BLSSig, reply.BridgeSig, reply.Confirmation, err = miningKey.SignVote(args.Block, args.SelfIdx, args.Committee, args.WithBridgeSig)
+	return err
+}
+
+// SignProposal - handler func which records the proposal of the validator in the slashing protection
+// database and signs it, a proposal conflicting with a former proposal is refused
+func (s Handler) SignProposal(args *signer.SignProposalArgs, sig *[]byte) error {
+	validator, miningKey, err := s.signerServer.getMiningKey(args.Validator)
+	if err != nil {
+		return err
+	}
+	if err := s.signerServer.Config.SlashingProtection.CheckAndRecordProposal(validator, signedBlockRecord(args.Block)); err != nil {
+		log.Printf("Refuse to propose block %v: %v", args.Block.BlockHash.String(), err)
+		return err
+	}
+	*sig, err = miningKey.SignProposal(args.Block)
+	return err
+}
+
+// signedBlockRecord returns the slashing protection record of block
+func signedBlockRecord(block signer.BlockInfo) blsbftv2.SignedBlockRecord {
+	return blsbftv2.SignedBlockRecord{
+		ChainKey:  block.ChainKey,
+		Height:    block.Height,
+		TimeSlot:  block.TimeSlot,
+		BlockHash: block.BlockHash,
+	}
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/rpc"
+	"os"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+)
+
+// SignerServer holds mining keys and signs with them for the nodes connected to its unix socket,
+// every vote and proposal is checked against the slashing protection history before it is signed
+type SignerServer struct {
+	keys     map[string]*blsbftv2.MiningKey // BLS mining public key in base58 -> mining key
+	server   *rpc.Server
+	listener net.Listener
+	Config   SignerServerConfig
+}
+
+type SignerServerConfig struct {
+	Listen             string   // unix socket path
+	PrivateSeeds       []string // private seeds of the mining keys
+	SlashingProtection *blsbftv2.SlashingProtectionDB
+}
+
+func (signerServer *SignerServer) Init(config *SignerServerConfig) error {
+	if config.SlashingProtection == nil {
+		return errors.New("slashing protection database is required")
+	}
+	signerServer.Config = *config
+	signerServer.keys = make(map[string]*blsbftv2.MiningKey)
+	for _, privateSeed := range config.PrivateSeeds {
+		miningKey, err := blsbftv2.GetMiningKeyFromPrivateSeed(privateSeed)
+		if err != nil {
+			return err
+		}
+		publicKey := miningKey.GetPublicKey()
+		signerServer.keys[publicKey.GetMiningKeyBase58(common.BlsConsensus)] = miningKey
+	}
+	if len(signerServer.keys) == 0 {
+		return errors.New("no mining key to sign with")
+	}
+	signerServer.server = rpc.NewServer()
+	return signerServer.server.Register(&Handler{signerServer})
+}
+
+// Start - listen on the unix socket and serve the connected nodes until Stop
+func (signerServer *SignerServer) Start() error {
+	// remove the socket left by a former run
+	if err := os.Remove(signerServer.Config.Listen); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	l, err := net.Listen("unix", signerServer.Config.Listen)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(signerServer.Config.Listen, 0600); err != nil {
+		l.Close()
+		return err
+	}
+	signerServer.listener = l
+	for publicKey := range signerServer.keys {
+		log.Printf("Sign with mining key %v", publicKey)
+	}
+	signerServer.server.Accept(l)
+	return nil
+}
+
+func (signerServer *SignerServer) Stop() error {
+	if signerServer.listener == nil {
+		return nil
+	}
+	return signerServer.listener.Close()
+}
+
+// getMiningKey returns the mining key of validator, validator can be empty if the server holds one key
+func (signerServer *SignerServer) getMiningKey(validator string) (string, *blsbftv2.MiningKey, error) {
+	if validator == "" {
+		if len(signerServer.keys) != 1 {
+			return "", nil, errors.New("validator must be set, the signer holds several mining keys")
+		}
+		for publicKey, miningKey := range signerServer.keys {
+			return publicKey, miningKey, nil
+		}
+	}
+	miningKey, ok := signerServer.keys[validator]
+	if !ok {
+		return "", nil, fmt.Errorf("mining key of validator %v not found", validator)
+	}
+	return validator, miningKey, nil
+}
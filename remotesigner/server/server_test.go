@@ -0,0 +1,115 @@
+package server
+
+import (
+	"io/ioutil"
+	"net/rpc"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/blsmultisig"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignerServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remotesigner")
+	assert.Equal(t, nil, err)
+	defer os.RemoveAll(dir)
+	db, err := incdb.Open("leveldb", filepath.Join(dir, "slashingprotection"))
+	assert.Equal(t, nil, err)
+	defer db.Close()
+
+	privateSeeds := []string{}
+	committee := []blsmultisig.PublicKey{}
+	for i := 0; i < 2; i++ {
+		privateSeed := base58.Base58Check{}.Encode(common.HashB([]byte{byte(i)}), common.Base58Version)
+		miningKey, err := blsbftv2.GetMiningKeyFromPrivateSeed(privateSeed)
+		assert.Equal(t, nil, err)
+		privateSeeds = append(privateSeeds, privateSeed)
+		committee = append(committee, miningKey.PubKey[common.BlsConsensus])
+	}
+	signerServer := &SignerServer{}
+	err = signerServer.Init(&SignerServerConfig{
+		Listen:             filepath.Join(dir, "signer.sock"),
+		PrivateSeeds:       privateSeeds[1:],
+		SlashingProtection: blsbftv2.NewSlashingProtectionDB(db),
+	})
+	assert.Equal(t, nil, err)
+	go signerServer.Start()
+	defer signerServer.Stop()
+
+	var remoteSigner *signer.RemoteSigner
+	for i := 0; i < 50; i++ {
+		if remoteSigner, err = signer.NewRemoteSigner(signerServer.Config.Listen, ""); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, nil, err)
+	defer remoteSigner.Close()
+	publicKey := remoteSigner.GetPublicKey()
+	assert.Equal(t, []byte(committee[1]), publicKey.MiningPubKey[common.BlsConsensus])
+
+	// vote
+	blockA := signer.BlockInfo{ChainKey: "shard-0", Height: 10, TimeSlot: 100, BlockHash: common.HashH([]byte("a")), Version: 2}
+	blockB := signer.BlockInfo{ChainKey: "shard-0", Height: 10, TimeSlot: 100, BlockHash: common.HashH([]byte("b")), Version: 2}
+	blsSig, bridgeSig, confirmation, err := remoteSigner.SignVote(blockA, 1, committee, true)
+	assert.Equal(t, nil, err)
+	ok, err := blsmultisig.Verify(blsSig, blockA.BlockHash.GetBytes(), []int{1}, committee)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+	ok, err = bridgesig.Verify(publicKey.MiningPubKey[common.BridgeConsensus], blockA.BlockHash.GetBytes(), bridgeSig)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+	ok, err = bridgesig.Verify(publicKey.MiningPubKey[common.BridgeConsensus], signer.VoteConfirmationData(blockA, blsSig, bridgeSig), confirmation)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+	// voting the same block again
+	_, _, _, err = remoteSigner.SignVote(blockA, 1, committee, false)
+	assert.Equal(t, nil, err)
+	// another block in the same timeslot
+	_, _, _, err = remoteSigner.SignVote(blockB, 1, committee, false)
+	assert.NotEqual(t, nil, err)
+	// signing as another committee member
+	blockC := signer.BlockInfo{ChainKey: "shard-0", Height: 11, TimeSlot: 101, BlockHash: common.HashH([]byte("c")), Version: 2}
+	_, _, _, err = remoteSigner.SignVote(blockC, 0, committee, false)
+	assert.NotEqual(t, nil, err)
+
+	// proposal
+	sig, err := remoteSigner.SignProposal(blockA)
+	assert.Equal(t, nil, err)
+	ok, err = bridgesig.Verify(publicKey.MiningPubKey[common.BridgeConsensus], blockA.BlockHash.GetBytes(), sig)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+	_, err = remoteSigner.SignProposal(blockB)
+	assert.NotEqual(t, nil, err)
+
+	// peer data
+	peerID := []byte("QmSoLPppuBtQSGwKDZT2M73ULpjvfd3aZ6ha4oFGL1KrGM")
+	sig, err = remoteSigner.SignPeerData(peerID)
+	assert.Equal(t, nil, err)
+	ok, err = bridgesig.Verify(publicKey.MiningPubKey[common.BridgeConsensus], peerID, sig)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, ok)
+	// a block hash is never signed as peer data
+	blockD := common.HashH([]byte("d"))
+	_, err = remoteSigner.SignPeerData(blockD.GetBytes())
+	assert.NotEqual(t, nil, err)
+	client, err := rpc.Dial("unix", signerServer.Config.Listen)
+	assert.Equal(t, nil, err)
+	defer client.Close()
+	err = client.Call(signer.SignPeerDataMethod, &signer.SignDataArgs{Data: blockD.GetBytes()}, &sig)
+	assert.NotEqual(t, nil, err)
+
+	// key not held by the signer
+	_, err = signer.NewRemoteSigner(signerServer.Config.Listen, base58.Base58Check{}.Encode(committee[0], common.Base58Version))
+	assert.NotEqual(t, nil, err)
+}
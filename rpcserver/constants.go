@@ -110,6 +110,8 @@ const (
 	getShardBestStateDetail  = "getshardbeststatedetail"
 	getBeaconBestState       = "getbeaconbeststate"
 	getBeaconBestStateDetail = "getbeaconbeststatedetail"
+	getStateProof            = "getstateproof"
+	getTrustedCheckpoint     = "gettrustedcheckpoint"
 
 	// Wallet rpc cmd
 	listAccounts               = "listaccounts"
@@ -198,6 +200,16 @@ const (
 	getPDEFeeWithdrawalStatus                  = "getpdefeewithdrawalstatus"
 	convertPDEPrices                           = "convertpdeprices"
 	extractPDEInstsFromBeaconBlock             = "extractpdeinstsfrombeaconblock"
+	createAndSendTxWithPRVContributionV3       = "createandsendtxwithprvcontributionv3"
+	createAndSendTxWithPTokenContributionV3    = "createandsendtxwithptokencontributionv3"
+	createAndSendTxWithPRVTradeReqV3           = "createandsendtxwithprvtradereqv3"
+	createAndSendTxWithPTokenTradeReqV3        = "createandsendtxwithptokentradereqv3"
+	createAndSendTxWithWithdrawalReqV3         = "createandsendtxwithwithdrawalreqv3"
+	createAndSendTxWithPRVLimitOrder           = "createandsendtxwithprvlimitorder"
+	createAndSendTxWithPTokenLimitOrder        = "createandsendtxwithptokenlimitorder"
+	createAndSendTxWithCancelLimitOrder        = "createandsendtxwithcancellimitorder"
+	getPDELimitOrderStatus                     = "getpdelimitorderstatus"
+	getPDECancelLimitOrderStatus               = "getpdecancellimitorderstatus"
 
 	// get burning address
 	getBurningAddress = "getburningaddress"
@@ -210,6 +222,7 @@ const (
 	createAndSendRegisterPortingPublicTokens      = "createandsendregisterportingpublictokens"
 	createAndSendPortalExchangeRates              = "createandsendportalexchangerates"
 	getPortalFinalExchangeRates                   = "getportalfinalexchangerates"
+	createAndSendPortalUpdateFeeders              = "createandsendportalupdatefeeders"
 	getPortalPortingRequestByKey                  = "getportalportingrequestbykey"
 	getPortalPortingRequestByPortingId            = "getportalportingrequestbyportingid"
 	convertExchangeRates                          = "convertexchangerates"
@@ -237,6 +250,11 @@ const (
 	getAmountTopUpWaitingPorting                  = "getamounttopupwaitingporting"
 	getPortalReqRedeemByTxIDStatus                = "getreqredeemstatusbytxid"
 	getReqRedeemFromLiquidationPoolByTxIDStatus   = "getreqredeemfromliquidationpoolbytxidstatus"
+	createAndSendTxWithTransferObligation         = "createandsendtxwithtransferobligation"
+	signPortalTransferObligation                  = "signportaltransferobligation"
+	getPortalTransferObligationStatus             = "getportaltransferobligationstatus"
+	createAndSendTxWithRebalanceCollateral        = "createandsendtxwithrebalancecollateral"
+	getPortalRebalanceCollateralStatus            = "getportalrebalancecollateralstatus"
 
 	// relaying
 	createAndSendTxWithRelayingBNBHeader = "createandsendtxwithrelayingbnbheader"
@@ -261,6 +279,13 @@ const (
 	getRewardFeature = "getrewardfeature"
 
 	getTotalStaker = "gettotalstaker"
+
+	// light client
+	getLightClientInfo       = "getlightclientinfo"
+	getLightBeaconBlock      = "getlightbeaconblock"
+	getLightShardBlock       = "getlightshardblock"
+	getLightTransactionProof = "getlighttransactionproof"
+	getLightOutputCoins      = "getlightoutputcoins"
 )
 
 const (
@@ -282,4 +307,5 @@ const (
 	subcribeBeaconBestState                     = "subcribebeaconbeststate"
 	subcribeBeaconPoolBeststate                 = "subcribebeaconpoolbeststate"
 	subcribeShardPoolBeststate                  = "subcribeshardpoolbeststate"
+	subcribeChainEvents                         = "subcribechainevents"
 )
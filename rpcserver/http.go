@@ -37,14 +37,16 @@ type HttpServer struct {
 	cRequestProcessShutdown chan struct{}
 
 	// service
-	blockService      *rpcservice.BlockService
-	outputCoinService *rpcservice.CoinService
-	txMemPoolService  *rpcservice.TxMemPoolService
-	networkService    *rpcservice.NetworkService
-	txService         *rpcservice.TxService
-	walletService     *rpcservice.WalletService
-	portal            *rpcservice.PortalService
-	synkerService     *rpcservice.SynkerService
+	blockService       *rpcservice.BlockService
+	outputCoinService  *rpcservice.CoinService
+	txMemPoolService   *rpcservice.TxMemPoolService
+	networkService     *rpcservice.NetworkService
+	txService          *rpcservice.TxService
+	walletService      *rpcservice.WalletService
+	portal             *rpcservice.PortalService
+	stateService       *rpcservice.StateService
+	synkerService      *rpcservice.SynkerService
+	lightClientService *rpcservice.LightClientService
 }
 
 func (httpServer *HttpServer) Init(config *RpcServerConfig) {
@@ -89,10 +91,16 @@ func (httpServer *HttpServer) Init(config *RpcServerConfig) {
 	httpServer.synkerService = &rpcservice.SynkerService{
 		Synker: config.Syncker,
 	}
+	httpServer.lightClientService = &rpcservice.LightClientService{
+		LightClient: config.LightClient,
+	}
 
 	httpServer.portal = &rpcservice.PortalService{
 		BlockChain: httpServer.config.BlockChain,
 	}
+	httpServer.stateService = &rpcservice.StateService{
+		BlockChain: httpServer.config.BlockChain,
+	}
 }
 
 // Start is used by rpcserver.go to start the rpc listener.
@@ -289,10 +297,15 @@ func (httpServer *HttpServer) ProcessRpcRequest(w http.ResponseWriter, r *http.R
 
 			// Attempt to parse the JSON-RPC request into a known concrete
 			// command.
-			command := HttpHandler[request.Method]
+			handlers, limitedHandlers := HttpHandler, LimitedHttpHandler
+			if httpServer.config.NodeMode == common.NodeModeLight {
+				// a light node does not keep the chains, it only serves the data it verifies
+				handlers, limitedHandlers = LightHttpHandler, nil
+			}
+			command := handlers[request.Method]
 			if command == nil {
 				if isLimitedUser {
-					command = LimitedHttpHandler[request.Method]
+					command = limitedHandlers[request.Method]
 				} else {
 					result = nil
 					jsonErr = rpcservice.NewRPCError(rpcservice.RPCMethodNotFoundError, errors.New("Method not found: "+request.Method))
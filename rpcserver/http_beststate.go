@@ -142,8 +142,20 @@ func (httpServer *HttpServer) handleGetCandidateList(params interface{}, closeCh
 	return result, nil
 }
 
-// handleGetCommitteeList - return current committee in network
+// handleGetCommitteeList - return current committee in network, or the committee
+// at the optional beacon height param
 func (httpServer *HttpServer) handleGetCommitteeList(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	beaconHeight, err := getHeightParam(common.InterfaceSlice(params), 0)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	if beaconHeight != 0 {
+		result, err := httpServer.blockService.GetCommitteeListByBeaconHeight(beaconHeight)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.GetStateAtHeightError, err)
+		}
+		return result, nil
+	}
 	clonedBeaconBestState, err := httpServer.blockService.GetBeaconBestState()
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetClonedBeaconBestStateError, err)
@@ -217,6 +229,18 @@ func (httpServer *HttpServer) handleGetBeaconBestStateDetail(params interface{},
 	return result, nil
 }
 
+/*
+handleGetTrustedCheckpoint - RPC get the unsigned checkpoint of the final beacon view
+*/
+func (httpServer *HttpServer) handleGetTrustedCheckpoint(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	finalView := httpServer.config.BlockChain.BeaconChain.GetFinalView().(*blockchain.BeaconBestState)
+	cp, err := blockchain.NewTrustedCheckpoint(finalView)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetClonedBeaconBestStateError, err)
+	}
+	return cp, nil
+}
+
 /*
 handleGetShardBestState - RPC get shard best state
 */
@@ -248,3 +272,41 @@ func (httpServer *HttpServer) handleGetTotalStaker(params interface{}, closeChan
 	result := jsonresult.NewGetTotalStaker(total)
 	return result, nil
 }
+
+/*
+handleGetStateProof - RPC get the merkle proof of a state object, params are
+[objectType, key, shardID, height]. The shard ID is -1 for the beacon chain,
+the height is optional and the state before the best block, the last one
+committed by a header, is read when it is not set.
+*/
+func (httpServer *HttpServer) handleGetStateProof(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 3 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Object type, key and shard ID are required"))
+	}
+	objectType, ok := arrayParams[0].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Object type is invalid"))
+	}
+	keyStr, ok := arrayParams[1].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Key is invalid"))
+	}
+	key, err := common.Hash{}.NewHashFromStr(keyStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	shardID, ok := arrayParams[2].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Shard ID is invalid"))
+	}
+	height, err := getHeightParam(arrayParams, 3)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	result, err := httpServer.stateService.GetStateProof(int(shardID), height, int(objectType), *key)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetStateProofError, err)
+	}
+	return result, nil
+}
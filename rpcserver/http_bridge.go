@@ -226,8 +226,17 @@ func (httpServer *HttpServer) handleGetETHHeaderByHash(params interface{}, close
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
 	}
 	ethBlockHash := arrayParams[0].(string)
+	// the EVM chain of the block, Ethereum if not set
+	evmChainID := uint64(0)
+	if len(arrayParams) > 1 {
+		evmChainIDParam, ok := arrayParams[1].(float64)
+		if !ok || evmChainIDParam < 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("EVM chain id is invalid"))
+		}
+		evmChainID = uint64(evmChainIDParam)
+	}
 
-	ethHeader, err := rpcservice.GetETHHeaderByHash(ethBlockHash)
+	ethHeader, err := httpServer.blockService.GetETHHeaderByHash(ethBlockHash, evmChainID)
 	if err != nil {
 		return false, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
@@ -300,7 +309,17 @@ func processBurningReq(
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("remote address is invalid"))
 	}
 
-	meta, err := rpcservice.NewBurningRequestMetadata(senderPrivateKeyParam, tokenReceivers, tokenID, tokenName, remoteAddress, burningMetaType, httpServer.GetBlockchain(), httpServer.GetBlockchain().BeaconChain.CurrentHeight())
+	// the chain to withdraw to, Ethereum if not set
+	evmChainID := uint64(0)
+	if evmChainIDParam, ok := tokenParamsRaw["EVMChainID"]; ok {
+		evmChainIDFloat, ok := evmChainIDParam.(float64)
+		if !ok || evmChainIDFloat < 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("EVM chain id is invalid"))
+		}
+		evmChainID = uint64(evmChainIDFloat)
+	}
+
+	meta, err := rpcservice.NewBurningRequestMetadata(senderPrivateKeyParam, tokenReceivers, tokenID, tokenName, remoteAddress, evmChainID, burningMetaType, httpServer.GetBlockchain(), httpServer.GetBlockchain().BeaconChain.CurrentHeight())
 	if err != nil {
 		return nil, err
 	}
@@ -17,7 +17,7 @@ func (httpServer *HttpServer) handleGetBurnProof(
 	params interface{},
 	closeChan <-chan struct{},
 ) (interface{}, *rpcservice.RPCError) {
-	onBeacon, height, txID, err := parseGetBurnProofParams(params, httpServer)
+	onBeacon, height, txID, evmChainID, err := parseGetBurnProofParams(params, httpServer)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
@@ -25,7 +25,7 @@ func (httpServer *HttpServer) handleGetBurnProof(
 	if onBeacon {
 		confirmMeta = metadata.BurningConfirmMetaV2
 	}
-	return retrieveBurnProof(confirmMeta, onBeacon, height, txID, httpServer)
+	return retrieveBurnProof(confirmMeta, onBeacon, height, txID, evmChainID, httpServer)
 }
 
 // handleGetBurnProof returns a proof of a tx burning pETH
@@ -33,7 +33,7 @@ func (httpServer *HttpServer) handleGetBurnProofForDepositToSC(
 	params interface{},
 	closeChan <-chan struct{},
 ) (interface{}, *rpcservice.RPCError) {
-	onBeacon, height, txID, err := parseGetBurnProofParams(params, httpServer)
+	onBeacon, height, txID, evmChainID, err := parseGetBurnProofParams(params, httpServer)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
@@ -41,30 +41,40 @@ func (httpServer *HttpServer) handleGetBurnProofForDepositToSC(
 	if onBeacon {
 		confirmMeta = metadata.BurningConfirmForDepositToSCMetaV2
 	}
-	return retrieveBurnProof(confirmMeta, onBeacon, height, txID, httpServer)
+	return retrieveBurnProof(confirmMeta, onBeacon, height, txID, evmChainID, httpServer)
 }
 
-func parseGetBurnProofParams(params interface{}, httpServer *HttpServer) (bool, uint64, *common.Hash, error) {
+// parseGetBurnProofParams parses the tx id of the burning request and the EVM
+// chain to withdraw to, Ethereum if not set
+func parseGetBurnProofParams(params interface{}, httpServer *HttpServer) (bool, uint64, *common.Hash, uint64, error) {
 	listParams, ok := params.([]interface{})
 	if !ok || len(listParams) < 1 {
-		return false, 0, nil, errors.New("param must be an array at least 1 element")
+		return false, 0, nil, 0, errors.New("param must be an array at least 1 element")
 	}
 
 	txIDParam, ok := listParams[0].(string)
 	if !ok {
-		return false, 0, nil, errors.New("Tx id invalid")
+		return false, 0, nil, 0, errors.New("Tx id invalid")
 	}
 
 	txID, err := common.Hash{}.NewHashFromStr(txIDParam)
 	if err != nil {
-		return false, 0, nil, err
+		return false, 0, nil, 0, err
+	}
+	evmChainID := common.ETHChainID
+	if len(listParams) > 1 {
+		evmChainIDParam, ok := listParams[1].(float64)
+		if !ok || evmChainIDParam < 0 {
+			return false, 0, nil, 0, errors.New("EVM chain id invalid")
+		}
+		evmChainID = metadata.GetEVMChainID(uint64(evmChainIDParam))
 	}
 	// Get block height from txID
 	height, onBeacon, err := httpServer.blockService.GetBurningConfirm(*txID)
 	if err != nil {
-		return false, 0, nil, fmt.Errorf("proof of tx not found")
+		return false, 0, nil, 0, fmt.Errorf("proof of tx not found")
 	}
-	return onBeacon, height, txID, nil
+	return onBeacon, height, txID, evmChainID, nil
 }
 
 func retrieveBurnProof(
@@ -72,12 +82,13 @@ func retrieveBurnProof(
 	onBeacon bool,
 	height uint64,
 	txID *common.Hash,
+	evmChainID uint64,
 	httpServer *HttpServer,
 ) (interface{}, *rpcservice.RPCError) {
 	if !onBeacon {
-		return getBurnProofByHeight(confirmMeta, httpServer, height, txID)
+		return getBurnProofByHeight(confirmMeta, httpServer, height, txID, evmChainID)
 	}
-	return getBurnProofByHeightV2(confirmMeta, httpServer, height, txID)
+	return getBurnProofByHeightV2(confirmMeta, httpServer, height, txID, evmChainID)
 }
 
 func getBurnProofByHeightV2(
@@ -85,6 +96,7 @@ func getBurnProofByHeightV2(
 	httpServer *HttpServer,
 	height uint64,
 	txID *common.Hash,
+	evmChainID uint64,
 ) (interface{}, *rpcservice.RPCError) {
 	// Get beacon block
 	beaconBlock, err := getSingleBeaconBlockByHeight(httpServer.GetBlockchain(), height)
@@ -93,7 +105,7 @@ func getBurnProofByHeightV2(
 	}
 
 	// Get proof of instruction on beacon
-	inst, instID := findBurnConfirmInst(burningMetaType, beaconBlock.Body.Instructions, txID)
+	inst, instID := findBurnConfirmInst(burningMetaType, beaconBlock.Body.Instructions, txID, evmChainID)
 	if instID == -1 {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, fmt.Errorf("cannot find inst %s in beacon block %d", txID.String(), height))
 	}
@@ -113,6 +125,7 @@ func getBurnProofByHeight(
 	httpServer *HttpServer,
 	height uint64,
 	txID *common.Hash,
+	evmChainID uint64,
 ) (interface{}, *rpcservice.RPCError) {
 
 	// Get bridge block and corresponding beacon blocks
@@ -121,7 +134,7 @@ func getBurnProofByHeight(
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
 	// Get proof of instruction on bridge
-	bridgeInstProof, err := getBurnProofOnBridge(burningMetaType, txID, bridgeBlock, httpServer.config.ConsensusEngine)
+	bridgeInstProof, err := getBurnProofOnBridge(burningMetaType, txID, evmChainID, bridgeBlock, httpServer.config.ConsensusEngine)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
@@ -146,11 +159,12 @@ func getBurnProofByHeight(
 func getBurnProofOnBridge(
 	burningMetaType int,
 	txID *common.Hash,
+	evmChainID uint64,
 	bridgeBlock *blockchain.ShardBlock,
 	ce ConsensusEngine,
 ) (*swapProof, error) {
 	insts := bridgeBlock.Body.Instructions
-	_, instID := findBurnConfirmInst(burningMetaType, insts, txID)
+	_, instID := findBurnConfirmInst(burningMetaType, insts, txID, evmChainID)
 	if instID < 0 {
 		return nil, fmt.Errorf("cannot find burning instruction in bridge block")
 	}
@@ -200,15 +214,16 @@ func findBeaconBlockWithBurnInst(beaconBlocks []*blockchain.BeaconBlock, inst []
 	return nil, -1
 }
 
-// findBurnConfirmInst finds a BurningConfirm instruction in a list, returns it along with its index
+// findBurnConfirmInst finds a BurningConfirm instruction to an EVM chain in a list, returns it along with its index
 func findBurnConfirmInst(
 	burningMetaType int,
 	insts [][]string,
 	txID *common.Hash,
+	evmChainID uint64,
 ) ([]string, int) {
 	instType := strconv.Itoa(burningMetaType)
 	for i, inst := range insts {
-		if inst[0] != instType || len(inst) < 5 {
+		if inst[0] != instType || len(inst) < 8 || blockchain.GetBurningConfirmEVMChainID(inst) != evmChainID {
 			continue
 		}
 
@@ -0,0 +1,80 @@
+package rpcserver
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+func (httpServer *HttpServer) handleGetLightClientInfo(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	return httpServer.lightClientService.GetInfo()
+}
+
+func (httpServer *HttpServer) handleGetLightBeaconBlock(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) != 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be the beacon height"))
+	}
+	height, ok := arrayParams[0].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("beacon height is invalid"))
+	}
+	return httpServer.lightClientService.GetBeaconBlock(uint64(height))
+}
+
+func (httpServer *HttpServer) handleGetLightShardBlock(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) != 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("params must be the shard id and the block height"))
+	}
+	shardID, ok := arrayParams[0].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("shard id is invalid"))
+	}
+	height, ok := arrayParams[1].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("block height is invalid"))
+	}
+	return httpServer.lightClientService.GetShardBlock(byte(shardID), uint64(height))
+}
+
+func (httpServer *HttpServer) handleGetLightTransactionProof(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) != 3 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("params must be the shard id, the block height and the tx hash"))
+	}
+	shardID, ok := arrayParams[0].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("shard id is invalid"))
+	}
+	height, ok := arrayParams[1].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("block height is invalid"))
+	}
+	txHash, ok := arrayParams[2].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("tx hash is invalid"))
+	}
+	return httpServer.lightClientService.GetTransactionProof(byte(shardID), uint64(height), txHash)
+}
+
+func (httpServer *HttpServer) handleGetLightOutputCoins(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) != 3 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("params must be the payment address, the first and the last block height"))
+	}
+	paymentAddress, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
+	}
+	fromHeight, ok := arrayParams[1].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("first block height is invalid"))
+	}
+	toHeight, ok := arrayParams[2].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("last block height is invalid"))
+	}
+	return httpServer.lightClientService.GetOutputCoins(paymentAddress, uint64(fromHeight), uint64(toHeight))
+}
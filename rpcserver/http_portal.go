@@ -207,28 +207,21 @@ func (httpServer *HttpServer) handleGetPortalState(params interface{}, closeChan
 	if !ok {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
 	}
-	beaconHeight, err := common.AssertAndConvertStrToNumber(data["BeaconHeight"])
+	// BeaconHeight is optional, the best view is read when it is not set
+	beaconHeight, err := getHeightParam([]interface{}{data["BeaconHeight"]}, 0)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
 
-	//beaconFeatureStateDB := httpServer.config.BlockChain.GetBeaconBestState().GetCopiedFeatureStateDB()
-	beaconFeatureStateRootHash, err := httpServer.config.BlockChain.GetBeaconFeatureRootHash(httpServer.config.BlockChain.GetBeaconBestState(), uint64(beaconHeight))
+	beaconState, err := httpServer.stateService.GetBeaconStateAtHeight(beaconHeight)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetPortalStateError, fmt.Errorf("Can't found FeatureStateRootHash of beacon height %+v, error %+v", beaconHeight, err))
 	}
-	beaconFeatureStateDB, err := statedb.NewWithPrefixTrie(beaconFeatureStateRootHash, statedb.NewDatabaseAccessWarper(httpServer.config.BlockChain.GetBeaconChainDatabase()))
 
-	portalState, err := blockchain.InitCurrentPortalStateFromDB(beaconFeatureStateDB)
-	if err != nil {
-		return nil, rpcservice.NewRPCError(rpcservice.GetPortalStateError, err)
-	}
-
-	beaconBlocks, err := httpServer.config.BlockChain.GetBeaconBlockByHeight(uint64(beaconHeight))
+	portalState, err := blockchain.InitCurrentPortalStateFromDB(beaconState.FeatureStateDB)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetPortalStateError, err)
 	}
-	beaconBlock := beaconBlocks[0]
 
 	type CurrentPortalState struct {
 		WaitingPortingRequests     map[string]*statedb.WaitingPortingRequest `json:"WaitingPortingRequests"`
@@ -242,7 +235,7 @@ func (httpServer *HttpServer) handleGetPortalState(params interface{}, closeChan
 	}
 
 	result := CurrentPortalState{
-		BeaconTimeStamp:            beaconBlock.Header.Timestamp,
+		BeaconTimeStamp:            beaconState.Timestamp,
 		WaitingPortingRequests:     portalState.WaitingPortingRequests,
 		WaitingRedeemRequests:      portalState.WaitingRedeemRequests,
 		MatchedRedeemRequests:      portalState.MatchedRedeemRequests,
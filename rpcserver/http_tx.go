@@ -301,7 +301,13 @@ func (httpServer *HttpServer) handleGetBalancePrivacyCustomToken(params interfac
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("tokenID is invalid"))
 	}
 
-	totalValue, err2 := httpServer.txService.GetBalancePrivacyCustomToken(privateKey, tokenID)
+	// param #3: optional beacon height
+	beaconHeight, err := getHeightParam(arrayParams, 2)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+
+	totalValue, err2 := httpServer.txService.GetBalancePrivacyCustomToken(privateKey, tokenID, beaconHeight)
 	if err2 != nil {
 		return nil, err2
 	}
@@ -77,3 +77,22 @@ func (httpServer *HttpServer) createAndSendTxWithMetadata(params interface{}, cl
 	newParam = append(newParam, base58CheckData)
 	return sendHandler(httpServer, newParam, closeChan)
 }
+
+// getHeightParam returns the optional height at index of the params, the
+// height of a past block whose state is read. It is 0, the best view, when
+// the param is not set. A number or a numeric string is accepted.
+func getHeightParam(arrayParams []interface{}, index int) (uint64, error) {
+	if len(arrayParams) <= index || arrayParams[index] == nil {
+		return 0, nil
+	}
+	switch height := arrayParams[index].(type) {
+	case float64:
+		if height < 0 || height != float64(uint64(height)) {
+			return 0, errors.Errorf("height %+v is invalid", height)
+		}
+		return uint64(height), nil
+	case string:
+		return common.AssertAndConvertStrToNumber(height)
+	}
+	return 0, errors.Errorf("height %+v is invalid", arrayParams[index])
+}
@@ -160,7 +160,7 @@ func (httpServer *HttpServer) handleRemoveAccount(params interface{}, closeChan
 func (httpServer *HttpServer) handleGetBalanceByPrivatekey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	// all component
 	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 1 {
+	if arrayParams == nil || len(arrayParams) < 1 {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
 	}
 	// param #1: private key of sender
@@ -168,8 +168,13 @@ func (httpServer *HttpServer) handleGetBalanceByPrivatekey(params interface{}, c
 	if !ok {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("invalid private key"))
 	}
+	// param #2: optional beacon height
+	beaconHeight, err := getHeightParam(arrayParams, 1)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
 
-	return httpServer.walletService.GetBalanceByPrivateKey(senderKeyParam)
+	return httpServer.walletService.GetBalanceByPrivateKey(senderKeyParam, beaconHeight)
 }
 
 // handleGetBalanceByPaymentAddress -  return balance of paymentaddress
@@ -177,7 +182,7 @@ func (httpServer *HttpServer) handleGetBalanceByPaymentAddress(params interface{
 
 	// all component
 	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 1 {
+	if arrayParams == nil || len(arrayParams) < 1 {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
 	}
 	// param #1: private key of sender
@@ -185,8 +190,13 @@ func (httpServer *HttpServer) handleGetBalanceByPaymentAddress(params interface{
 	if !ok {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
 	}
+	// param #2: optional beacon height
+	beaconHeight, err := getHeightParam(arrayParams, 1)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
 
-	return httpServer.walletService.GetBalanceByPaymentAddress(paymentAddressParam)
+	return httpServer.walletService.GetBalanceByPaymentAddress(paymentAddressParam, beaconHeight)
 }
 
 /*
@@ -62,7 +62,7 @@ func (httpServer *HttpServer) handleCreateAndSendWithDrawTransaction(params inte
 // handleGetRewardAmount - Get the reward amount of a payment address with all existed token
 func (httpServer *HttpServer) handleGetRewardAmount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 1 {
+	if arrayParams == nil || len(arrayParams) < 1 {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
 	}
 
@@ -70,7 +70,11 @@ func (httpServer *HttpServer) handleGetRewardAmount(params interface{}, closeCha
 	if !ok {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
 	}
-	rewardAmount, err := httpServer.blockService.GetRewardAmount(paymentAddress)
+	beaconHeight, err1 := getHeightParam(arrayParams, 1)
+	if err1 != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err1)
+	}
+	rewardAmount, err := httpServer.blockService.GetRewardAmount(paymentAddress, beaconHeight)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetRewardAmountError, err)
 	}
@@ -80,7 +84,7 @@ func (httpServer *HttpServer) handleGetRewardAmount(params interface{}, closeCha
 // handleGetRewardAmount - Get the reward amount of a payment address with all existed token
 func (httpServer *HttpServer) handleGetRewardAmountByPublicKey(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	arrayParams := common.InterfaceSlice(params)
-	if arrayParams == nil || len(arrayParams) != 1 {
+	if arrayParams == nil || len(arrayParams) < 1 {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
 	}
 
@@ -88,7 +92,11 @@ func (httpServer *HttpServer) handleGetRewardAmountByPublicKey(params interface{
 	if !ok {
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
 	}
-	rewardAmount, err := httpServer.blockService.GetRewardAmountByPublicKey(paymentAddress)
+	beaconHeight, err1 := getHeightParam(arrayParams, 1)
+	if err1 != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err1)
+	}
+	rewardAmount, err := httpServer.blockService.GetRewardAmountByPublicKey(paymentAddress, beaconHeight)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.GetRewardAmountError, err)
 	}
@@ -97,7 +105,11 @@ func (httpServer *HttpServer) handleGetRewardAmountByPublicKey(params interface{
 
 // handleListRewardAmount - Get the reward amount of all committee with all existed token
 func (httpServer *HttpServer) handleListRewardAmount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
-	result, err := httpServer.blockService.ListRewardAmount()
+	beaconHeight, err1 := getHeightParam(common.InterfaceSlice(params), 0)
+	if err1 != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err1)
+	}
+	result, err := httpServer.blockService.ListRewardAmount(beaconHeight)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.ListCommitteeRewardError, err)
 	}
@@ -4,9 +4,17 @@ type FinalExchangeRatesDetailResult struct {
 	Value uint64 `json:"Value"`
 }
 
+type ExchangeRatesFeedResult struct {
+	Feeder       string `json:"Feeder"`
+	Rate         uint64 `json:"Rate"`
+	BeaconHeight uint64 `json:"BeaconHeight"`
+}
+
 type FinalExchangeRatesResult struct {
 	BeaconHeight uint64                                    `json:"BeaconHeight"`
 	Rates        map[string]FinalExchangeRatesDetailResult `json:"Rates"`
+	Feeders      []string                                  `json:"Feeders"`
+	Feeds        map[string][]ExchangeRatesFeedResult      `json:"Feeds"` // pTokenID : last feed of each feeder
 }
 
 type ExchangeRatesResult struct {
@@ -0,0 +1,9 @@
+package jsonresult
+
+type LightClientInfo struct {
+	FinalHeight     uint64   `json:"FinalHeight"`
+	FinalHash       string   `json:"FinalHash"`
+	BestHeight      uint64   `json:"BestHeight"`
+	Epoch           uint64   `json:"Epoch"`
+	BeaconCommittee []string `json:"BeaconCommittee"`
+}
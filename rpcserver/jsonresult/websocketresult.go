@@ -6,3 +6,9 @@ type ClosedConnectionResult struct {
 type UnsubcribeResult struct {
 	Message string `json:"Message"`
 }
+
+// ChainEventResult is a view change of a chain, Type is newbestview, newfinalview or reorg
+type ChainEventResult struct {
+	Type  string      `json:"Type"`
+	Event interface{} `json:"Event"`
+}
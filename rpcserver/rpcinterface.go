@@ -97,6 +97,8 @@ var HttpHandler = map[string]httpHandler{
 	getShardBestStateDetail:  (*HttpServer).handleGetShardBestStateDetail,
 	getBeaconBestState:       (*HttpServer).handleGetBeaconBestState,
 	getBeaconBestStateDetail: (*HttpServer).handleGetBeaconBestStateDetail,
+	getStateProof:            (*HttpServer).handleGetStateProof,
+	getTrustedCheckpoint:     (*HttpServer).handleGetTrustedCheckpoint,
 	// getBeaconPoolState:            (*HttpServer).handleGetBeaconPoolState,
 	// getShardPoolState:             (*HttpServer).handleGetShardPoolState,
 	// getShardPoolLatestValidHeight: (*HttpServer).handleGetShardPoolLatestValidHeight,
@@ -179,6 +181,16 @@ var HttpHandler = map[string]httpHandler{
 	getPDEFeeWithdrawalStatus:                  (*HttpServer).handleGetPDEFeeWithdrawalStatus,
 	convertPDEPrices:                           (*HttpServer).handleConvertPDEPrices,
 	extractPDEInstsFromBeaconBlock:             (*HttpServer).handleExtractPDEInstsFromBeaconBlock,
+	createAndSendTxWithPRVContributionV3:       (*HttpServer).handleCreateAndSendTxWithPRVContributionV3,
+	createAndSendTxWithPTokenContributionV3:    (*HttpServer).handleCreateAndSendTxWithPTokenContributionV3,
+	createAndSendTxWithPRVTradeReqV3:           (*HttpServer).handleCreateAndSendTxWithPRVTradeReqV3,
+	createAndSendTxWithPTokenTradeReqV3:        (*HttpServer).handleCreateAndSendTxWithPTokenTradeReqV3,
+	createAndSendTxWithWithdrawalReqV3:         (*HttpServer).handleCreateAndSendTxWithWithdrawalReqV3,
+	createAndSendTxWithPRVLimitOrder:           (*HttpServer).handleCreateAndSendTxWithPRVLimitOrder,
+	createAndSendTxWithPTokenLimitOrder:        (*HttpServer).handleCreateAndSendTxWithPTokenLimitOrder,
+	createAndSendTxWithCancelLimitOrder:        (*HttpServer).handleCreateAndSendTxWithCancelLimitOrder,
+	getPDELimitOrderStatus:                     (*HttpServer).handleGetPDELimitOrderStatus,
+	getPDECancelLimitOrderStatus:               (*HttpServer).handleGetPDECancelLimitOrderStatus,
 
 	getBurningAddress: (*HttpServer).handleGetBurningAddress,
 
@@ -190,6 +202,7 @@ var HttpHandler = map[string]httpHandler{
 	createAndSendRegisterPortingPublicTokens:      (*HttpServer).handleCreateAndSendRegisterPortingPublicTokens,
 	createAndSendPortalExchangeRates:              (*HttpServer).handleCreateAndSendPortalExchangeRates,
 	getPortalFinalExchangeRates:                   (*HttpServer).handleGetPortalFinalExchangeRates,
+	createAndSendPortalUpdateFeeders:              (*HttpServer).handleCreateAndSendPortalUpdateFeeders,
 	getPortalPortingRequestByKey:                  (*HttpServer).handleGetPortingRequestByKey,
 	getPortalPortingRequestByPortingId:            (*HttpServer).handleGetPortingRequestByPortingId,
 	convertExchangeRates:                          (*HttpServer).handleConvertExchangeRates,
@@ -217,6 +230,11 @@ var HttpHandler = map[string]httpHandler{
 	getAmountTopUpWaitingPorting:                  (*HttpServer).handleGetAmountTopUpWaitingPorting,
 	getPortalReqRedeemByTxIDStatus:                (*HttpServer).handleGetPortalReqRedeemByTxIDStatus,
 	getReqRedeemFromLiquidationPoolByTxIDStatus:   (*HttpServer).handleGetReqRedeemFromLiquidationPoolByTxIDStatus,
+	createAndSendTxWithTransferObligation:         (*HttpServer).handleCreateAndSendTxWithTransferObligation,
+	signPortalTransferObligation:                  (*HttpServer).handleSignPortalTransferObligation,
+	getPortalTransferObligationStatus:             (*HttpServer).handleGetPortalTransferObligationStatus,
+	createAndSendTxWithRebalanceCollateral:        (*HttpServer).handleCreateAndSendTxWithRebalanceCollateral,
+	getPortalRebalanceCollateralStatus:            (*HttpServer).handleGetPortalRebalanceCollateralStatus,
 
 	// relaying
 	createAndSendTxWithRelayingBNBHeader: (*HttpServer).handleCreateAndSendTxWithRelayingBNBHeader,
@@ -266,6 +284,15 @@ var LimitedHttpHandler = map[string]httpHandler{
 	convertPrivacyTokenToNativeToken: (*HttpServer).handleConvertPrivacyTokenToNativeToken,
 }
 
+// Commands that are available on a light node, the only ones it serves
+var LightHttpHandler = map[string]httpHandler{
+	getLightClientInfo:       (*HttpServer).handleGetLightClientInfo,
+	getLightBeaconBlock:      (*HttpServer).handleGetLightBeaconBlock,
+	getLightShardBlock:       (*HttpServer).handleGetLightShardBlock,
+	getLightTransactionProof: (*HttpServer).handleGetLightTransactionProof,
+	getLightOutputCoins:      (*HttpServer).handleGetLightOutputCoins,
+}
+
 var WsHandler = map[string]wsHandler{
 	testSubcrice:                                (*WsServer).handleTestSubcribe,
 	subcribeNewShardBlock:                       (*WsServer).handleSubscribeNewShardBlock,
@@ -284,4 +311,5 @@ var WsHandler = map[string]wsHandler{
 	subcribeBeaconBestState:                     (*WsServer).handleSubscribeBeaconBestState,
 	subcribeBeaconPoolBeststate:                 (*WsServer).handleSubscribeBeaconPoolBestState,
 	subcribeShardPoolBeststate:                  (*WsServer).handleSubscribeShardPoolBeststate,
+	subcribeChainEvents:                         (*WsServer).handleSubscribeChainEvents,
 }
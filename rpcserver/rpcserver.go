@@ -11,6 +11,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/connmanager"
 	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/lightclient"
 	"github.com/incognitochain/incognito-chain/memcache"
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/netsync"
@@ -72,6 +73,7 @@ type RpcServerConfig struct {
 	NodeMode        string
 	NetSync         *netsync.NetSync
 	Syncker         *syncker.SynckerManager
+	LightClient     *lightclient.Client
 	Server          interface {
 		// Push TxNormal Message
 		PushMessageToAll(message wire.Message) error
@@ -8,12 +8,14 @@ import (
 	"strconv"
 
 	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/memcache"
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/metadata"
@@ -646,13 +648,18 @@ func (blockService BlockService) GetMinerRewardFromMiningKey(incPublicKey []byte
 	return rewardAmountResult, nil
 }
 
-func (blockService BlockService) ListRewardAmount() (map[string]map[common.Hash]uint64, error) {
+// ListRewardAmount lists the committee rewards of every shard at beaconHeight,
+// 0 for the best view
+func (blockService BlockService) ListRewardAmount(beaconHeight uint64) (map[string]map[common.Hash]uint64, error) {
 	m := make(map[string]map[common.Hash]uint64)
 	beaconBestState := blockService.BlockChain.GetBeaconBestState()
 	for i := 0; i < beaconBestState.ActiveShards; i++ {
 		shardID := byte(i)
-		committeeRewardStateDB := blockService.BlockChain.GetBestStateShard(shardID).GetShardRewardStateDB()
-		committeeReward := statedb.ListCommitteeReward(committeeRewardStateDB)
+		shardState, err := StateService{BlockChain: blockService.BlockChain}.GetShardStateAtBeaconHeight(shardID, beaconHeight)
+		if err != nil {
+			return nil, err
+		}
+		committeeReward := statedb.ListCommitteeReward(shardState.RewardStateDB)
 		for k, v := range committeeReward {
 			m[k] = v
 		}
@@ -660,7 +667,9 @@ func (blockService BlockService) ListRewardAmount() (map[string]map[common.Hash]
 	return m, nil
 }
 
-func (blockService BlockService) GetRewardAmount(paymentAddress string) (map[string]uint64, error) {
+// GetRewardAmount returns the committee reward of a payment address at
+// beaconHeight, 0 for the best view
+func (blockService BlockService) GetRewardAmount(paymentAddress string, beaconHeight uint64) (map[string]uint64, error) {
 	rewardAmountResult := make(map[string]uint64)
 	keySet, _, err := GetKeySetFromPaymentAddressParam(paymentAddress)
 	if err != nil {
@@ -675,8 +684,12 @@ func (blockService BlockService) GetRewardAmount(paymentAddress string) (map[str
 	if err != nil {
 		return nil, err
 	}
+	shardState, err := StateService{BlockChain: blockService.BlockChain}.GetShardStateAtBeaconHeight(shardID, beaconHeight)
+	if err != nil {
+		return nil, err
+	}
+	committeeRewardStateDB := shardState.RewardStateDB
 	for _, coinID := range allCoinIDs {
-		committeeRewardStateDB := blockService.BlockChain.GetBestStateShard(shardID).GetShardRewardStateDB()
 		tempPK := base58.Base58Check{}.Encode(publicKey, common.Base58Version)
 		amount, err := statedb.GetCommitteeReward(committeeRewardStateDB, tempPK, coinID)
 		if err != nil {
@@ -693,7 +706,9 @@ func (blockService BlockService) GetRewardAmount(paymentAddress string) (map[str
 	return rewardAmountResult, nil
 }
 
-func (blockService BlockService) GetRewardAmountByPublicKey(publicKey string) (map[string]uint64, error) {
+// GetRewardAmountByPublicKey returns the committee reward of a public key at
+// beaconHeight, 0 for the best view
+func (blockService BlockService) GetRewardAmountByPublicKey(publicKey string, beaconHeight uint64) (map[string]uint64, error) {
 	rewardAmountResult := make(map[string]uint64)
 	tempPK, _, err := base58.Base58Check{}.Decode(publicKey)
 	if err != nil {
@@ -704,8 +719,12 @@ func (blockService BlockService) GetRewardAmountByPublicKey(publicKey string) (m
 	if err != nil {
 		return nil, err
 	}
+	shardState, err := StateService{BlockChain: blockService.BlockChain}.GetShardStateAtBeaconHeight(shardID, beaconHeight)
+	if err != nil {
+		return nil, err
+	}
+	committeeRewardStateDB := shardState.RewardStateDB
 	for _, coinID := range allCoinIDs {
-		committeeRewardStateDB := blockService.BlockChain.GetBestStateShard(shardID).GetShardRewardStateDB()
 		amount, err := statedb.GetCommitteeReward(committeeRewardStateDB, publicKey, coinID)
 		if err != nil {
 			return nil, err
@@ -721,6 +740,27 @@ func (blockService BlockService) GetRewardAmountByPublicKey(publicKey string) (m
 	return rewardAmountResult, nil
 }
 
+// GetCommitteeListByBeaconHeight returns the committees and pending validators
+// stored in the beacon consensus state at beaconHeight
+func (blockService BlockService) GetCommitteeListByBeaconHeight(beaconHeight uint64) (*jsonresult.CommitteeListsResult, error) {
+	beaconState, err := StateService{BlockChain: blockService.BlockChain}.GetBeaconStateAtHeight(beaconHeight)
+	if err != nil {
+		return nil, err
+	}
+	shardIDs := blockService.BlockChain.GetShardIDs()
+	shardCommittee := make(map[byte][]incognitokey.CommitteePublicKey)
+	for shardID, committee := range statedb.GetAllShardCommittee(beaconState.ConsensusStateDB, shardIDs) {
+		shardCommittee[byte(shardID)] = committee
+	}
+	shardPendingValidator := make(map[byte][]incognitokey.CommitteePublicKey)
+	for shardID, validators := range statedb.GetAllShardSubstituteValidator(beaconState.ConsensusStateDB, shardIDs) {
+		shardPendingValidator[byte(shardID)] = validators
+	}
+	beaconCommittee := statedb.GetBeaconCommittee(beaconState.ConsensusStateDB)
+	beaconPendingValidator := statedb.GetBeaconSubstituteValidator(beaconState.ConsensusStateDB)
+	return jsonresult.NewCommitteeListsResult(beaconState.Epoch, shardCommittee, shardPendingValidator, beaconCommittee, beaconPendingValidator), nil
+}
+
 func (blockService BlockService) CanPubkeyStake(publicKey string) (bool, error) {
 	canStake := true
 	validStakers, err := blockService.GetValidStakers([]string{publicKey})
@@ -858,6 +898,14 @@ func (blockService BlockService) GetAllBridgeTokens() ([]*rawdbv2.BridgeTokenInf
 	return bridgeTokenInfos, err
 }
 
+func (blockService BlockService) GetETHHeaderByHash(ethBlockHash string, evmChainID uint64) (*types.Header, error) {
+	ethHeaderSource, err := blockService.BlockChain.GetEVMHeaderSource(evmChainID)
+	if err != nil {
+		return nil, err
+	}
+	return ethHeaderSource.GetETHHeader(rCommon.HexToHash(ethBlockHash))
+}
+
 func (blockService BlockService) CheckETHHashIssued(data map[string]interface{}) (bool, error) {
 	blockHashParam, ok := data["BlockHash"].(string)
 	if !ok {
@@ -870,7 +918,15 @@ func (blockService BlockService) CheckETHHashIssued(data map[string]interface{})
 		return false, errors.New("Tx index param is invalid")
 	}
 	txIdx := uint(txIdxParam)
-	uniqETHTx := append(blockHash[:], []byte(strconv.Itoa(int(txIdx)))...)
+	evmChainID := uint64(0)
+	if evmChainIDParam, ok := data["EVMChainID"]; ok {
+		evmChainIDFloat, ok := evmChainIDParam.(float64)
+		if !ok || evmChainIDFloat < 0 {
+			return false, errors.New("EVM chain id param is invalid")
+		}
+		evmChainID = uint64(evmChainIDFloat)
+	}
+	uniqETHTx := metadata.GetUniqEVMTx(evmChainID, blockHash, txIdx)
 	bridgeStateDB := blockService.BlockChain.GetBeaconBestState().GetBeaconFeatureStateDB()
 	issued, err := statedb.IsETHTxHashIssued(bridgeStateDB, uniqETHTx)
 	return issued, err
@@ -1100,6 +1156,44 @@ func (blockService BlockService) GetCustodianTopupWaitingPortingStatus(txID stri
 	return &status, nil
 }
 
+func (blockService BlockService) GetPortalTransferObligationStatus(txID string) (*metadata.PortalTransferObligationStatus, error) {
+	stateDB := blockService.BlockChain.GetBeaconBestState().GetBeaconFeatureStateDB()
+	data, err := statedb.GetPortalStateStatusMultiple(
+		stateDB,
+		statedb.PortalTransferObligationStatusPrefix(),
+		[]byte(txID))
+	if err != nil {
+		return nil, err
+	}
+
+	var status metadata.PortalTransferObligationStatus
+	err = json.Unmarshal(data, &status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+func (blockService BlockService) GetPortalRebalanceCollateralStatus(txID string) (*metadata.PortalRebalanceCollateralStatus, error) {
+	stateDB := blockService.BlockChain.GetBeaconBestState().GetBeaconFeatureStateDB()
+	data, err := statedb.GetPortalStateStatusMultiple(
+		stateDB,
+		statedb.PortalRebalanceCollateralStatusPrefix(),
+		[]byte(txID))
+	if err != nil {
+		return nil, err
+	}
+
+	var status metadata.PortalRebalanceCollateralStatus
+	err = json.Unmarshal(data, &status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
 func (blockService BlockService) GetAmountTopUpWaitingPorting(custodianAddr string) (map[string]uint64, error) {
 	stateDB := blockService.BlockChain.GetBeaconBestState().GetBeaconFeatureStateDB()
 	currentPortalState, err := blockchain.InitCurrentPortalStateFromDB(stateDB)
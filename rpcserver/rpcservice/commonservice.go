@@ -2,8 +2,6 @@ package rpcservice
 
 import (
 	"errors"
-	rCommon "github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/metadata"
@@ -48,6 +46,7 @@ func NewBurningRequestMetadata(
 	tokenID string,
 	tokenName string,
 	remoteAddress string,
+	evmChainID uint64,
 	burningMetaType int,
 	bcr metadata.ChainRetriever,
 	beaconHeight uint64,
@@ -83,14 +82,11 @@ func NewBurningRequestMetadata(
 	if err != nil {
 		return nil, NewRPCError(UnexpectedError, err)
 	}
+	meta.EVMChainID = evmChainID
 
 	return meta, nil
 }
 
-func GetETHHeaderByHash(ethBlockHash string) (*types.Header, error) {
-	return metadata.GetETHHeader(rCommon.HexToHash(ethBlockHash))
-}
-
 // GetKeySetFromPrivateKeyParams - deserialize a private key string
 // into keyWallet object and fill all keyset in keywallet with private key
 // return key set and shard ID
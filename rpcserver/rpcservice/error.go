@@ -90,6 +90,8 @@ const (
 	GetPortalRewardError
 	GetReqMatchingRedeemStatusError
 	GetReqRedeemFromLiquidationPoolStatusError
+	GetPortalTransferObligationStatusError
+	GetPortalRebalanceCollateralStatusError
 
 	GetCustodianLiquidationStatusError
 	GetTpExchangeRatesLiquidationError
@@ -127,6 +129,15 @@ const (
 	RestoreCandidateShardWaitingForNextRandom
 
 	GetTotalStakerError
+
+	GetStateAtHeightError
+	GetStateProofError
+
+	// light client
+	GetLightBeaconBlockError
+	GetLightShardBlockError
+	GetLightTransactionProofError
+	GetLightOutputCoinsError
 )
 
 // Standard JSON-RPC 2.0 errors.
@@ -175,6 +186,8 @@ var ErrCodeMessage = map[int]struct {
 	// best state -3xxx
 	GetClonedBeaconBestStateError: {-3000, "Get Cloned Beacon Best State Error"},
 	GetClonedShardBestStateError:  {-3001, "Get Cloned Shard Best State Error"},
+	GetStateAtHeightError:         {-3002, "Get State At Height Error"},
+	GetStateProofError:            {-3003, "Get State Proof Error"},
 
 	// tx -4xxx
 	CreateTxDataError:                {-4001, "Can not create tx"},
@@ -229,6 +242,8 @@ var ErrCodeMessage = map[int]struct {
 	GetCustodianTopupWaitingPortingStatusError:         {-9016, "Get custodian top up for waiting porting status error"},
 	GetAmountTopUpWaitingPortingError:                  {-9017, "Get amount top up for waiting porting error"},
 	GetReqRedeemFromLiquidationPoolStatusError:         {-9018, "Get redeem request form liquidation pool status error"},
+	GetPortalTransferObligationStatusError:             {-9019, "Get portal transfer obligation status error"},
+	GetPortalRebalanceCollateralStatusError:            {-9020, "Get portal rebalance collateral status error"},
 
 	// relaying
 	GetRelayingBNBHeaderByBlockHeightError: {-10001, "Get relaying bnb header by block height error"},
@@ -254,6 +269,12 @@ var ErrCodeMessage = map[int]struct {
 	RestoreCandidateShardWaitingForNextRandom:     {-12008, "Restore candidate shard waiting for next random"},
 	GetAllBeaconViews:                             {-12009, "Get all beacon views"},
 	GetTotalStakerError:                           {-12010, "Get total staker return error"},
+
+	// light client
+	GetLightBeaconBlockError:      {-13001, "Get light client beacon block error"},
+	GetLightShardBlockError:       {-13002, "Get light client shard block error"},
+	GetLightTransactionProofError: {-13003, "Get light client transaction proof error"},
+	GetLightOutputCoinsError:      {-13004, "Get light client output coins error"},
 }
 
 // RPCError represents an error that is used as a part of a JSON-RPC JsonResponse
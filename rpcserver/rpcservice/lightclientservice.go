@@ -0,0 +1,82 @@
+package rpcservice
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/lightclient"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// maxLightOutputCoinBlocks bounds the shard blocks downloaded by a request of output coins
+const maxLightOutputCoinBlocks = 100
+
+type LightClientService struct {
+	LightClient *lightclient.Client
+}
+
+func (s *LightClientService) GetInfo() (*jsonresult.LightClientInfo, *RPCError) {
+	block, committee := s.LightClient.GetFinalBlock()
+	committeeStr, err := incognitokey.CommitteeKeyListToString(committee)
+	if err != nil {
+		return nil, NewRPCError(UnexpectedError, err)
+	}
+	return &jsonresult.LightClientInfo{
+		FinalHeight:     block.Header.Height,
+		FinalHash:       block.Header.Hash().String(),
+		BestHeight:      s.LightClient.GetBestHeight(),
+		Epoch:           block.Header.Epoch,
+		BeaconCommittee: committeeStr,
+	}, nil
+}
+
+func (s *LightClientService) GetBeaconBlock(height uint64) (*lightclient.BeaconBlock, *RPCError) {
+	block, err := s.LightClient.GetBeaconBlock(height)
+	if err != nil {
+		return nil, NewRPCError(GetLightBeaconBlockError, err)
+	}
+	return block, nil
+}
+
+func (s *LightClientService) GetShardBlock(shardID byte, height uint64) (*blockchain.ShardBlock, *RPCError) {
+	block, err := s.LightClient.GetShardBlock(shardID, height)
+	if err != nil {
+		return nil, NewRPCError(GetLightShardBlockError, err)
+	}
+	return block, nil
+}
+
+func (s *LightClientService) GetTransactionProof(shardID byte, height uint64, txHashStr string) (*lightclient.TxProof, *RPCError) {
+	txHash, err := common.Hash{}.NewHashFromStr(txHashStr)
+	if err != nil {
+		return nil, NewRPCError(RPCInvalidParamsError, err)
+	}
+	proof, err := s.LightClient.GetTransactionProof(shardID, height, *txHash)
+	if err != nil {
+		return nil, NewRPCError(GetLightTransactionProofError, err)
+	}
+	return proof, nil
+}
+
+func (s *LightClientService) GetOutputCoins(paymentAddressStr string, fromHeight uint64, toHeight uint64) ([]lightclient.OutputCoin, *RPCError) {
+	if toHeight < fromHeight || toHeight-fromHeight >= maxLightOutputCoinBlocks {
+		return nil, NewRPCError(RPCInvalidParamsError, errors.New("invalid block range"))
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+	if err != nil {
+		return nil, NewRPCError(RPCInvalidParamsError, err)
+	}
+	publicKey := keyWallet.KeySet.PaymentAddress.Pk
+	if len(publicKey) == 0 {
+		return nil, NewRPCError(RPCInvalidParamsError, errors.New("invalid payment address"))
+	}
+	shardID := common.GetShardIDFromLastByte(publicKey[len(publicKey)-1])
+	coins, err := s.LightClient.GetOutputCoins(shardID, fromHeight, toHeight, publicKey)
+	if err != nil {
+		return nil, NewRPCError(GetLightOutputCoinsError, err)
+	}
+	return coins, nil
+}
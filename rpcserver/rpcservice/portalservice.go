@@ -6,6 +6,7 @@ import (
 	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"sort"
 )
 
 type PortalService struct {
@@ -94,9 +95,30 @@ func (portal *PortalService) GetFinalExchangeRates(stateDB *statedb.StateDB, bea
 		}
 	}
 
+	feeds := make(map[string][]jsonresult.ExchangeRatesFeedResult)
+	for pTokenId, tokenFeeds := range finalExchangeRates.Feeds() {
+		for feeder, feed := range tokenFeeds {
+			feeds[pTokenId] = append(feeds[pTokenId], jsonresult.ExchangeRatesFeedResult{
+				Feeder:       feeder,
+				Rate:         feed.Rate,
+				BeaconHeight: feed.BeaconHeight,
+			})
+		}
+		sort.Slice(feeds[pTokenId], func(i, j int) bool {
+			return feeds[pTokenId][i].Feeder < feeds[pTokenId][j].Feeder
+		})
+	}
+
+	feeders, err := metadata.GetPortalFeeders(portal.BlockChain, stateDB)
+	if err != nil {
+		return jsonresult.FinalExchangeRatesResult{}, err
+	}
+
 	result := jsonresult.FinalExchangeRatesResult{
 		BeaconHeight: beaconHeight,
 		Rates:        item,
+		Feeders:      feeders,
+		Feeds:        feeds,
 	}
 	return result, nil
 }
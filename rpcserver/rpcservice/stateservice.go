@@ -0,0 +1,385 @@
+package rpcservice
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/statedb"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+// StateService opens the state of a past beacon or shard block of the best
+// chain, so the RPCs reading the best view can also answer at a past height.
+// Blocks up to the final view are found by their finalized index, so their
+// state does not depend on the current fork. A height of 0 means the best view.
+type StateService struct {
+	BlockChain *blockchain.BlockChain
+}
+
+// BeaconStateAtHeight is the beacon state after a block. State databases are
+// opened at the roots stored for the block and are never committed.
+type BeaconStateAtHeight struct {
+	Height    uint64
+	BlockHash common.Hash
+	Epoch     uint64
+	Timestamp int64
+
+	ConsensusStateDB *statedb.StateDB
+	FeatureStateDB   *statedb.StateDB
+	RewardStateDB    *statedb.StateDB
+	SlashStateDB     *statedb.StateDB
+}
+
+// ShardStateAtHeight is the shard state after a block. State databases are
+// opened at the roots stored for the block and are never committed.
+type ShardStateAtHeight struct {
+	ShardID      byte
+	Height       uint64
+	BeaconHeight uint64
+	BlockHash    common.Hash
+	Epoch        uint64
+	Timestamp    int64
+
+	ConsensusStateDB   *statedb.StateDB
+	TransactionStateDB *statedb.StateDB
+	FeatureStateDB     *statedb.StateDB
+	RewardStateDB      *statedb.StateDB
+	SlashStateDB       *statedb.StateDB
+}
+
+func (stateService StateService) GetBeaconStateAtHeight(height uint64) (*BeaconStateAtHeight, error) {
+	if height == 0 {
+		beaconBestState := stateService.BlockChain.GetBeaconBestState()
+		return &BeaconStateAtHeight{
+			Height:           beaconBestState.BeaconHeight,
+			BlockHash:        beaconBestState.BestBlockHash,
+			Epoch:            beaconBestState.Epoch,
+			Timestamp:        beaconBestState.BestBlock.Header.Timestamp,
+			ConsensusStateDB: beaconBestState.GetBeaconConsensusStateDB(),
+			FeatureStateDB:   beaconBestState.GetBeaconFeatureStateDB(),
+			RewardStateDB:    beaconBestState.GetBeaconRewardStateDB(),
+			SlashStateDB:     beaconBestState.GetBeaconSlashStateDB(),
+		}, nil
+	}
+	beaconChain := stateService.BlockChain.BeaconChain
+	blockHash, err := stateService.BlockChain.GetBeaconBlockHashByHeight(beaconChain.GetFinalView(), beaconChain.GetBestView(), height)
+	if err != nil {
+		return nil, err
+	}
+	db := stateService.BlockChain.GetBeaconChainDatabase()
+	beaconBlock, _, err := stateService.BlockChain.GetBeaconBlockByHash(*blockHash)
+	if err != nil {
+		return nil, err
+	}
+	bRH, err := getBeaconRootsHash(db, *blockHash)
+	if err != nil {
+		return nil, err
+	}
+	stateDBs, err := openStateDBs(statedb.NewDatabaseAccessWarper(db), bRH.ConsensusStateDBRootHash, bRH.FeatureStateDBRootHash, bRH.RewardStateDBRootHash, bRH.SlashStateDBRootHash)
+	if err != nil {
+		return nil, fmt.Errorf("state of beacon height %+v is not available, %+v", height, err)
+	}
+	return &BeaconStateAtHeight{
+		Height:           height,
+		BlockHash:        *blockHash,
+		Epoch:            beaconBlock.Header.Epoch,
+		Timestamp:        beaconBlock.Header.Timestamp,
+		ConsensusStateDB: stateDBs[0],
+		FeatureStateDB:   stateDBs[1],
+		RewardStateDB:    stateDBs[2],
+		SlashStateDB:     stateDBs[3],
+	}, nil
+}
+
+func (stateService StateService) GetShardStateAtHeight(shardID byte, height uint64) (*ShardStateAtHeight, error) {
+	if int(shardID) >= stateService.BlockChain.GetBeaconBestState().ActiveShards {
+		return nil, fmt.Errorf("shard %+v is not active", shardID)
+	}
+	if height == 0 {
+		shardBestState := stateService.BlockChain.GetBestStateShard(shardID)
+		return &ShardStateAtHeight{
+			ShardID:            shardID,
+			Height:             shardBestState.ShardHeight,
+			BeaconHeight:       shardBestState.BeaconHeight,
+			BlockHash:          shardBestState.BestBlockHash,
+			Epoch:              shardBestState.Epoch,
+			Timestamp:          shardBestState.BestBlock.Header.Timestamp,
+			ConsensusStateDB:   shardBestState.GetCopiedConsensusStateDB(),
+			TransactionStateDB: shardBestState.GetCopiedTransactionStateDB(),
+			FeatureStateDB:     shardBestState.GetCopiedFeatureStateDB(),
+			RewardStateDB:      shardBestState.GetShardRewardStateDB(),
+			SlashStateDB:       shardBestState.GetCopiedSlashStateDB(),
+		}, nil
+	}
+	shardChain := stateService.BlockChain.ShardChain[shardID]
+	blockHash, err := stateService.BlockChain.GetShardBlockHashByHeight(shardChain.GetFinalView(), shardChain.GetBestView(), height)
+	if err != nil {
+		return nil, err
+	}
+	db := stateService.BlockChain.GetShardChainDatabase(shardID)
+	shardBlock, _, err := stateService.BlockChain.GetShardBlockByHashWithShardID(*blockHash, shardID)
+	if err != nil {
+		return nil, err
+	}
+	sRH, err := getShardRootsHash(db, shardID, *blockHash)
+	if err != nil {
+		return nil, err
+	}
+	stateDBs, err := openStateDBs(statedb.NewDatabaseAccessWarper(db), sRH.ConsensusStateDBRootHash, sRH.TransactionStateDBRootHash, sRH.FeatureStateDBRootHash, sRH.RewardStateDBRootHash, sRH.SlashStateDBRootHash)
+	if err != nil {
+		return nil, fmt.Errorf("state of shard %+v height %+v is not available, %+v", shardID, height, err)
+	}
+	return &ShardStateAtHeight{
+		ShardID:            shardID,
+		Height:             height,
+		BeaconHeight:       shardBlock.Header.BeaconHeight,
+		BlockHash:          *blockHash,
+		Epoch:              shardBlock.Header.Epoch,
+		Timestamp:          shardBlock.Header.Timestamp,
+		ConsensusStateDB:   stateDBs[0],
+		TransactionStateDB: stateDBs[1],
+		FeatureStateDB:     stateDBs[2],
+		RewardStateDB:      stateDBs[3],
+		SlashStateDB:       stateDBs[4],
+	}, nil
+}
+
+// GetShardStateAtBeaconHeight returns the state of the last finalized shard
+// block which had processed the beacon chain up to beaconHeight. A beacon
+// height is used by the RPCs reading several shards, so the state of every
+// shard is taken at the same point of the beacon chain (eg. an epoch boundary).
+func (stateService StateService) GetShardStateAtBeaconHeight(shardID byte, beaconHeight uint64) (*ShardStateAtHeight, error) {
+	if beaconHeight == 0 {
+		return stateService.GetShardStateAtHeight(shardID, 0)
+	}
+	height, err := stateService.GetShardHeightByBeaconHeight(shardID, beaconHeight)
+	if err != nil {
+		return nil, err
+	}
+	return stateService.GetShardStateAtHeight(shardID, height)
+}
+
+// GetShardHeightByBeaconHeight returns the height of the last finalized shard
+// block whose beacon height is not greater than beaconHeight. The beacon
+// height of shard blocks never decreases, so it is found by a binary search.
+func (stateService StateService) GetShardHeightByBeaconHeight(shardID byte, beaconHeight uint64) (uint64, error) {
+	if int(shardID) >= stateService.BlockChain.GetBeaconBestState().ActiveShards {
+		return 0, fmt.Errorf("shard %+v is not active", shardID)
+	}
+	db := stateService.BlockChain.GetShardChainDatabase(shardID)
+	getBeaconHeight := func(height uint64) (uint64, error) {
+		blockHash, err := rawdbv2.GetFinalizedShardBlockHashByIndex(db, shardID, height)
+		if err != nil {
+			return 0, err
+		}
+		shardBlock, _, err := stateService.BlockChain.GetShardBlockByHashWithShardID(*blockHash, shardID)
+		if err != nil {
+			return 0, err
+		}
+		return shardBlock.Header.BeaconHeight, nil
+	}
+	low, high := uint64(1), stateService.BlockChain.ShardChain[shardID].GetFinalViewHeight()
+	lowBeaconHeight, err := getBeaconHeight(low)
+	if err != nil {
+		return 0, err
+	}
+	if lowBeaconHeight > beaconHeight {
+		return 0, fmt.Errorf("shard %+v has no block at beacon height %+v", shardID, beaconHeight)
+	}
+	for low < high {
+		mid := low + (high-low+1)/2
+		midBeaconHeight, err := getBeaconHeight(mid)
+		if err != nil {
+			return 0, err
+		}
+		if midBeaconHeight <= beaconHeight {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	return low, nil
+}
+
+func getBeaconRootsHash(db incdb.Database, blockHash common.Hash) (*blockchain.BeaconRootHash, error) {
+	data, err := rawdbv2.GetBeaconRootsHash(db, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	bRH := &blockchain.BeaconRootHash{}
+	if err := json.Unmarshal(data, bRH); err != nil {
+		return nil, err
+	}
+	return bRH, nil
+}
+
+func getShardRootsHash(db incdb.Database, shardID byte, blockHash common.Hash) (*blockchain.ShardRootHash, error) {
+	data, err := rawdbv2.GetShardRootsHash(db, shardID, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	sRH := &blockchain.ShardRootHash{}
+	if err := json.Unmarshal(data, sRH); err != nil {
+		return nil, err
+	}
+	return sRH, nil
+}
+
+func openStateDBs(dbAccessWarper statedb.DatabaseAccessWarper, roots ...common.Hash) ([]*statedb.StateDB, error) {
+	stateDBs := make([]*statedb.StateDB, 0, len(roots))
+	for _, root := range roots {
+		stateDB, err := statedb.NewWithPrefixTrie(root, dbAccessWarper)
+		if err != nil {
+			return nil, err
+		}
+		stateDBs = append(stateDBs, stateDB)
+	}
+	return stateDBs, nil
+}
+
+// GetBalanceAtBeaconHeight returns the sum of the output coins of tokenID
+// owned by keySet in the shard state at beaconHeight.
+func (stateService StateService) GetBalanceAtBeaconHeight(keySet *incognitokey.KeySet, shardID byte, tokenID *common.Hash, beaconHeight uint64) (uint64, error) {
+	shardState, err := stateService.GetShardStateAtBeaconHeight(shardID, beaconHeight)
+	if err != nil {
+		return 0, err
+	}
+	outCoins, err := stateService.BlockChain.GetListOutputCoinsByKeysetFromStateDB(shardState.TransactionStateDB, keySet, shardID, tokenID)
+	if err != nil {
+		return 0, err
+	}
+	balance := uint64(0)
+	for _, out := range outCoins {
+		balance += out.CoinDetails.GetValue()
+	}
+	return balance, nil
+}
+
+// StateProof is the merkle proof of a state object in the state after a
+// beacon or shard block, checked with blockchain.VerifyStateProof. The state
+// roots are committed by the PreviousStateRootsHash of the header of the next
+// block, CommitBlockHash: a client which checked the committee signatures of
+// that header verifies the object without trusting the answering node.
+type StateProof struct {
+	ShardID         int // -1 for the beacon chain
+	Height          uint64
+	BlockHash       common.Hash
+	CommitHeight    uint64
+	CommitBlockHash common.Hash
+	StateRootsHash  common.Hash
+	ObjectType      int
+	Key             common.Hash
+	StateDB         string
+	StateRoots      []common.Hash
+	Value           json.RawMessage // nil if there is no object at key
+	Proof           trie.ProofList
+}
+
+// GetStateProof returns the merkle proof of the state object at key in the
+// state after the beacon block (shardID -1) or shard block at height. The
+// state must be committed by the next block, height defaults to the height
+// before the best one.
+func (stateService StateService) GetStateProof(shardID int, height uint64, objectType int, key common.Hash) (*StateProof, error) {
+	stateDBName, err := statedb.GetStateDBNameByObjectType(objectType)
+	if err != nil {
+		return nil, err
+	}
+	rootIndex, err := blockchain.StateRootIndex(shardID, stateDBName)
+	if err != nil {
+		return nil, err
+	}
+	var roots []common.Hash
+	var blockHash, commitBlockHash *common.Hash
+	var stateRootsHash common.Hash
+	var db incdb.Database
+	if shardID == -1 {
+		beaconChain := stateService.BlockChain.BeaconChain
+		if height == 0 {
+			height = beaconChain.GetBestViewHeight() - 1
+		}
+		blockHash, err = stateService.BlockChain.GetBeaconBlockHashByHeight(beaconChain.GetFinalView(), beaconChain.GetBestView(), height)
+		if err != nil {
+			return nil, err
+		}
+		commitBlockHash, err = stateService.BlockChain.GetBeaconBlockHashByHeight(beaconChain.GetFinalView(), beaconChain.GetBestView(), height+1)
+		if err != nil {
+			return nil, fmt.Errorf("the state of beacon height %+v is not committed yet, %+v", height, err)
+		}
+		commitBlock, _, err := stateService.BlockChain.GetBeaconBlockByHash(*commitBlockHash)
+		if err != nil {
+			return nil, err
+		}
+		stateRootsHash = commitBlock.Header.PreviousStateRootsHash
+		db = stateService.BlockChain.GetBeaconChainDatabase()
+		bRH, err := getBeaconRootsHash(db, *blockHash)
+		if err != nil {
+			return nil, err
+		}
+		roots = bRH.StateRoots()
+	} else {
+		if shardID < 0 || shardID >= stateService.BlockChain.GetBeaconBestState().ActiveShards {
+			return nil, fmt.Errorf("shard %+v is not active", shardID)
+		}
+		shardChain := stateService.BlockChain.ShardChain[shardID]
+		if height == 0 {
+			height = shardChain.GetBestViewHeight() - 1
+		}
+		blockHash, err = stateService.BlockChain.GetShardBlockHashByHeight(shardChain.GetFinalView(), shardChain.GetBestView(), height)
+		if err != nil {
+			return nil, err
+		}
+		commitBlockHash, err = stateService.BlockChain.GetShardBlockHashByHeight(shardChain.GetFinalView(), shardChain.GetBestView(), height+1)
+		if err != nil {
+			return nil, fmt.Errorf("the state of shard %+v height %+v is not committed yet, %+v", shardID, height, err)
+		}
+		commitBlock, _, err := stateService.BlockChain.GetShardBlockByHash(*commitBlockHash)
+		if err != nil {
+			return nil, err
+		}
+		stateRootsHash = commitBlock.Header.PreviousStateRootsHash
+		db = stateService.BlockChain.GetShardChainDatabase(byte(shardID))
+		sRH, err := getShardRootsHash(db, byte(shardID), *blockHash)
+		if err != nil {
+			return nil, err
+		}
+		roots = sRH.StateRoots()
+	}
+	if stateRootsHash.IsEqual(&common.Hash{}) {
+		return nil, fmt.Errorf("the header of height %+v is before the state roots hash break point, it does not commit to the state of height %+v", height+1, height)
+	}
+	if h := blockchain.HashStateRoots(roots); !h.IsEqual(&stateRootsHash) {
+		return nil, fmt.Errorf("state roots hash %+v of height %+v does not match the committed hash %+v", h, height, stateRootsHash)
+	}
+	root := roots[rootIndex]
+	stateDB, err := statedb.NewWithPrefixTrie(root, statedb.NewDatabaseAccessWarper(db))
+	if err != nil {
+		return nil, fmt.Errorf("%+v state of height %+v is not available, %+v", stateDBName, height, err)
+	}
+	proof, err := stateDB.GetProof(key)
+	if err != nil {
+		return nil, err
+	}
+	value, err := statedb.VerifyProof(root, key, proof)
+	if err != nil {
+		return nil, err
+	}
+	return &StateProof{
+		ShardID:         shardID,
+		Height:          height,
+		BlockHash:       *blockHash,
+		CommitHeight:    height + 1,
+		CommitBlockHash: *commitBlockHash,
+		StateRootsHash:  stateRootsHash,
+		ObjectType:      objectType,
+		Key:             key,
+		StateDB:         stateDBName,
+		StateRoots:      roots,
+		Value:           value,
+		Proof:           proof,
+	}, nil
+}
@@ -1067,7 +1067,9 @@ func (txService TxService) GetListPrivacyCustomTokenBalance(privateKey string) (
 	return result, nil
 }
 
-func (txService TxService) GetBalancePrivacyCustomToken(privateKey string, tokenIDStr string) (uint64, *RPCError) {
+// GetBalancePrivacyCustomToken returns the balance of a privacy or bridge
+// token of a private key at beaconHeight, 0 for the best view
+func (txService TxService) GetBalancePrivacyCustomToken(privateKey string, tokenIDStr string, beaconHeight uint64) (uint64, *RPCError) {
 	var totalValue uint64 = 0
 	account, err := wallet.Base58CheckDeserialize(privateKey)
 	if err != nil {
@@ -1083,6 +1085,16 @@ func (txService TxService) GetBalancePrivacyCustomToken(privateKey string, token
 	if err != nil {
 		return uint64(0), NewRPCError(UnexpectedError, err)
 	}
+	if beaconHeight != 0 {
+		// coins of an unknown token are never found, so the token does not need to be checked
+		lastByte := account.KeySet.PaymentAddress.Pk[len(account.KeySet.PaymentAddress.Pk)-1]
+		shardIDSender := common.GetShardIDFromLastByte(lastByte)
+		totalValue, err = StateService{BlockChain: txService.BlockChain}.GetBalanceAtBeaconHeight(&account.KeySet, shardIDSender, tokenID, beaconHeight)
+		if err != nil {
+			return uint64(0), NewRPCError(GetStateAtHeightError, err)
+		}
+		return totalValue, nil
+	}
 	isExisted := false
 	for _, i := range txService.BlockChain.GetShardIDs() {
 		shardID := byte(i)
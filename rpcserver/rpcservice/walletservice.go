@@ -107,7 +107,9 @@ func (walletService *WalletService) RemoveAccount(privateKey string, passPhrase
 	return true, nil
 }
 
-func (walletService WalletService) GetBalanceByPrivateKey(privateKey string) (uint64, *RPCError) {
+// GetBalanceByPrivateKey returns the PRV balance of a private key at
+// beaconHeight, 0 for the best view
+func (walletService WalletService) GetBalanceByPrivateKey(privateKey string, beaconHeight uint64) (uint64, *RPCError) {
 	keySet, shardIDSender, err := GetKeySetFromPrivateKeyParams(privateKey)
 	if err != nil {
 		return uint64(0), NewRPCError(RPCInvalidParamsError, err)
@@ -120,6 +122,13 @@ func (walletService WalletService) GetBalanceByPrivateKey(privateKey string) (ui
 	if err != nil {
 		return uint64(0), NewRPCError(TokenIsInvalidError, err)
 	}
+	if beaconHeight != 0 {
+		balance, err := StateService{BlockChain: walletService.BlockChain}.GetBalanceAtBeaconHeight(keySet, shardIDSender, prvCoinID, beaconHeight)
+		if err != nil {
+			return uint64(0), NewRPCError(GetStateAtHeightError, err)
+		}
+		return balance, nil
+	}
 	outcoints, err := walletService.BlockChain.GetListOutputCoinsByKeyset(keySet, shardIDSender, prvCoinID)
 	log.Println(err)
 	if err != nil {
@@ -135,7 +144,9 @@ func (walletService WalletService) GetBalanceByPrivateKey(privateKey string) (ui
 	return balance, nil
 }
 
-func (walletService WalletService) GetBalanceByPaymentAddress(paymentAddress string) (uint64, *RPCError) {
+// GetBalanceByPaymentAddress returns the PRV balance of a payment address at
+// beaconHeight, 0 for the best view
+func (walletService WalletService) GetBalanceByPaymentAddress(paymentAddress string, beaconHeight uint64) (uint64, *RPCError) {
 	keySet, shardIDSender, err := GetKeySetFromPaymentAddressParam(paymentAddress)
 	if err != nil {
 		return uint64(0), NewRPCError(RPCInvalidParamsError, errors.New("payment address is invalid"))
@@ -146,6 +157,13 @@ func (walletService WalletService) GetBalanceByPaymentAddress(paymentAddress str
 	if err1 != nil {
 		return uint64(0), NewRPCError(TokenIsInvalidError, err1)
 	}
+	if beaconHeight != 0 {
+		balance, err := StateService{BlockChain: walletService.BlockChain}.GetBalanceAtBeaconHeight(keySet, shardIDSender, prvCoinID, beaconHeight)
+		if err != nil {
+			return uint64(0), NewRPCError(GetStateAtHeightError, err)
+		}
+		return balance, nil
+	}
 	outcoints, err := walletService.BlockChain.GetListOutputCoinsByKeyset(keySet, shardIDSender, prvCoinID)
 	Logger.log.Debugf("OutCoins: %+v", outcoints)
 	Logger.log.Debugf("shardIDSender: %+v", shardIDSender)
@@ -197,6 +197,10 @@ func (wsServer *WsServer) subscribe(subManager *SubcriptionManager, subRequest *
 	}
 	// Attempt to parse the JSON-RPC request into a known concrete command.
 	command := WsHandler[request.Method]
+	if wsServer.config.NodeMode == common.NodeModeLight {
+		// the subscriptions follow the chains a light node does not keep
+		command = nil
+	}
 	if command == nil {
 		jsonErr = rpcservice.NewRPCError(rpcservice.RPCMethodNotFoundError, errors.New("Method"+request.Method+"Not found"))
 		Logger.log.Errorf("RPC from client %+v error %+v", subManager.ws.RemoteAddr(), jsonErr)
@@ -0,0 +1,94 @@
+package rpcserver
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+const (
+	chainEventNewBestView  = "newbestview"
+	chainEventNewFinalView = "newfinalview"
+	chainEventReorg        = "reorg"
+)
+
+/*
+handleSubscribeChainEvents - subscribe the new best views, new final views and reorgs of a chain
+param #1: chain id, -1 for beacon
+the events are not sent in order, the Sequence of an event gives its order among the events of the chain
+*/
+func (wsServer *WsServer) handleSubscribeChainEvents(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	Logger.log.Info("Handle Subscribe Chain Events", params, subcription)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain 1 params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	chainIDParam, ok := arrayParams[0].(float64)
+	if !ok {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Chain ID is invalid"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	chainID := int(chainIDParam)
+	topics := []string{pubsub.NewBestViewTopic, pubsub.NewFinalViewTopic, pubsub.ReorgTopic}
+	subIds := make(map[string]uint)
+	subChans := make(map[string]pubsub.EventChannel)
+	defer func() {
+		Logger.log.Info("Finish Subscribe Chain Events")
+		for topic, subId := range subIds {
+			wsServer.config.PubSubManager.Unsubscribe(topic, subId)
+		}
+		close(cResult)
+	}()
+	for _, topic := range topics {
+		subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(topic)
+		if err != nil {
+			err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+			cResult <- RpcSubResult{Error: err}
+			return
+		}
+		subIds[topic] = subId
+		subChans[topic] = subChan
+	}
+	for {
+		select {
+		case msg := <-subChans[pubsub.NewBestViewTopic]:
+			event, ok := msg.Value.(*blockchain.ViewEvent)
+			if !ok {
+				Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.ViewEvent, have %+v", reflect.TypeOf(msg.Value))
+				continue
+			}
+			if event.ChainID == chainID {
+				cResult <- RpcSubResult{Result: jsonresult.ChainEventResult{Type: chainEventNewBestView, Event: event}}
+			}
+		case msg := <-subChans[pubsub.NewFinalViewTopic]:
+			event, ok := msg.Value.(*blockchain.ViewEvent)
+			if !ok {
+				Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.ViewEvent, have %+v", reflect.TypeOf(msg.Value))
+				continue
+			}
+			if event.ChainID == chainID {
+				cResult <- RpcSubResult{Result: jsonresult.ChainEventResult{Type: chainEventNewFinalView, Event: event}}
+			}
+		case msg := <-subChans[pubsub.ReorgTopic]:
+			event, ok := msg.Value.(*blockchain.ReorgEvent)
+			if !ok {
+				Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted *blockchain.ReorgEvent, have %+v", reflect.TypeOf(msg.Value))
+				continue
+			}
+			if event.ChainID == chainID {
+				cResult <- RpcSubResult{Result: jsonresult.ChainEventResult{Type: chainEventReorg, Event: event}}
+			}
+		case <-closeChan:
+			cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Chain Events"}}
+			return
+		}
+	}
+}
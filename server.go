@@ -36,9 +36,12 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/connmanager"
 	"github.com/incognitochain/incognito-chain/consensus"
+	"github.com/incognitochain/incognito-chain/consensus/blsbftv2"
+	"github.com/incognitochain/incognito-chain/consensus/signer"
 	"github.com/incognitochain/incognito-chain/databasemp"
 	"github.com/incognitochain/incognito-chain/incdb"
 	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/lightclient"
 	"github.com/incognitochain/incognito-chain/memcache"
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/metadata"
@@ -48,6 +51,7 @@ import (
 	btcrelaying "github.com/incognitochain/incognito-chain/relaying/btc"
 	"github.com/incognitochain/incognito-chain/rpcserver"
 	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/trie"
 	"github.com/incognitochain/incognito-chain/wallet"
 	"github.com/incognitochain/incognito-chain/wire"
 	libp2p "github.com/libp2p/go-libp2p-peer"
@@ -68,6 +72,7 @@ type Server struct {
 	blockChain      *blockchain.BlockChain
 	dataBase        map[int]incdb.Database
 	syncker         *syncker.SynckerManager
+	lightClient     *lightclient.Client
 	memCache        *memcache.MemoryCache
 	rpcServer       *rpcserver.RpcServer
 	memPool         *mempool.TxPool
@@ -86,6 +91,11 @@ type Server struct {
 	// the mempool before they are mined into blocks.
 	feeEstimator map[byte]*mempool.FeeEstimator
 	highway      *peerv2.ConnManager
+	// The slashing protection database keeps the votes and proposals signed
+	// by the mining key, so that they are never signed twice for conflicting blocks.
+	slashingProtectionDB incdb.Database
+	// The remote signer holds the mining key out of the node process when it is configured.
+	remoteSigner *signer.RemoteSigner
 
 	cQuit     chan struct{}
 	cNewPeers chan *peer.Peer
@@ -225,7 +235,7 @@ func (serverObj *Server) NewServer(
 
 	serverObj.miningKeys = cfg.MiningKeys
 	serverObj.privateKey = cfg.PrivateKey
-	if serverObj.miningKeys == "" && serverObj.privateKey == "" {
+	if serverObj.miningKeys == "" && serverObj.privateKey == "" && cfg.RemoteSigner == "" {
 		if cfg.NodeMode == common.NodeModeAuto || cfg.NodeMode == common.NodeModeBeacon || cfg.NodeMode == common.NodeModeShard {
 			panic("miningkeys can't be empty in this node mode")
 		}
@@ -314,6 +324,18 @@ func (serverObj *Server) NewServer(
 		relayShards,
 	)
 
+	trustedCheckpoint, err := cfg.GetTrustedCheckpoint()
+	if err != nil {
+		return err
+	}
+	ethHeaderSource, err := cfg.GetETHHeaderSource()
+	if err != nil {
+		return err
+	}
+	evmHeaderSources, err := cfg.GetEVMHeaderSources()
+	if err != nil {
+		return err
+	}
 	err = serverObj.blockChain.Init(&blockchain.Config{
 		BTCChain:      btcChain,
 		BNBChainState: bnbChainState,
@@ -327,13 +349,19 @@ func (serverObj *Server) NewServer(
 		Server:      serverObj,
 		Syncker:     serverObj.syncker,
 		// UserKeySet:        serverObj.userKeySet,
-		NodeMode:        cfg.NodeMode,
-		FeeEstimator:    make(map[byte]blockchain.FeeEstimator),
-		PubSubManager:   pubsubManager,
-		RandomClient:    randomClient,
-		ConsensusEngine: serverObj.consensusEngine,
-		Highway:         serverObj.highway,
-		GenesisParams:   blockchain.GenesisParam,
+		NodeMode:          cfg.NodeMode,
+		FeeEstimator:      make(map[byte]blockchain.FeeEstimator),
+		PubSubManager:     pubsubManager,
+		RandomClient:      randomClient,
+		ConsensusEngine:   serverObj.consensusEngine,
+		Highway:           serverObj.highway,
+		GenesisParams:     blockchain.GenesisParam,
+		StatePrune:        cfg.StatePrune,
+		StatePruneKeep:    cfg.StatePruneKeep,
+		FastSync:          cfg.FastSync,
+		TrustedCheckpoint: trustedCheckpoint,
+		ETHHeaderSource:   ethHeaderSource,
+		EVMHeaderSources:  evmHeaderSources,
 	})
 	if err != nil {
 		return err
@@ -407,7 +435,8 @@ func (serverObj *Server) NewServer(
 		PersistMempool:    cfg.PersistMempool,
 		RelayShards:       relayShards,
 		// UserKeyset:        serverObj.userKeySet,
-		PubSubManager: serverObj.pusubManager,
+		PubSubManager:       serverObj.pusubManager,
+		ReinjectOrphanedTxs: true,
 	})
 	serverObj.memPool.AnnouncePersisDatabaseMempool()
 	//add tx pool
@@ -479,8 +508,50 @@ func (serverObj *Server) NewServer(
 	})
 
 	serverObj.connManager = connManager
-	serverObj.consensusEngine.Init(&consensus.EngineConfig{Node: serverObj, Blockchain: serverObj.blockChain, PubSubManager: serverObj.pusubManager})
-	serverObj.syncker.Init(&syncker.SynckerManagerConfig{Node: serverObj, Blockchain: serverObj.blockChain})
+	serverObj.slashingProtectionDB, err = incdb.Open("leveldb", filepath.Join(cfg.DataDir, cfg.SlashingProtectionDir))
+	if err != nil {
+		Logger.log.Error("could not open slashing protection database")
+		return err
+	}
+	engineConfig := &consensus.EngineConfig{
+		Node:                 serverObj,
+		Blockchain:           serverObj.blockChain,
+		PubSubManager:        serverObj.pusubManager,
+		SlashingProtectionDB: blsbftv2.NewSlashingProtectionDB(serverObj.slashingProtectionDB),
+	}
+	if cfg.RemoteSigner != "" {
+		serverObj.remoteSigner, err = signer.NewRemoteSigner(cfg.RemoteSigner, cfg.RemoteSignerKey)
+		if err != nil {
+			Logger.log.Error("could not connect to remote signer")
+			return err
+		}
+		engineConfig.Signer = serverObj.remoteSigner
+	}
+	serverObj.consensusEngine.Init(engineConfig)
+	err = serverObj.syncker.Init(&syncker.SynckerManagerConfig{
+		Node:       serverObj,
+		Blockchain: serverObj.blockChain,
+		Pipeline: syncker.PipelineConfig{
+			SignatureWorkers: cfg.SyncSigWorkers,
+			TxWorkers:        cfg.SyncTxWorkers,
+			Lookahead:        cfg.SyncLookahead,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if cfg.NodeMode == common.NodeModeLight {
+		serverObj.lightClient, err = lightclient.NewClient(lightclient.Config{
+			Server:          serverObj,
+			ConsensusEngine: serverObj.consensusEngine,
+			ChainParams:     chainParams,
+			DataBase:        serverObj.dataBase[common.BeaconChainDataBaseID],
+		})
+		if err != nil {
+			Logger.log.Error("could not init light client")
+			return err
+		}
+	}
 
 	// Start up persistent peers.
 	permanentPeers := cfg.ConnectPeers
@@ -536,6 +607,7 @@ func (serverObj *Server) NewServer(
 			ConsensusEngine:             serverObj.consensusEngine,
 			MemCache:                    serverObj.memCache,
 			Syncker:                     serverObj.syncker,
+			LightClient:                 serverObj.lightClient,
 		}
 		serverObj.rpcServer = &rpcserver.RpcServer{}
 		serverObj.rpcServer.Init(&rpcConfig)
@@ -618,10 +690,23 @@ func (serverObj *Server) Stop() error {
 		}
 	}
 
+	if serverObj.lightClient != nil {
+		serverObj.lightClient.Stop()
+	}
 	err := serverObj.consensusEngine.Stop()
 	if err != nil {
 		Logger.log.Error(err)
 	}
+	if serverObj.slashingProtectionDB != nil {
+		if err := serverObj.slashingProtectionDB.Close(); err != nil {
+			Logger.log.Error(err)
+		}
+	}
+	if serverObj.remoteSigner != nil {
+		if err := serverObj.remoteSigner.Close(); err != nil {
+			Logger.log.Error(err)
+		}
+	}
 	// Signal the remaining goroutines to cQuit.
 	close(serverObj.cQuit)
 	return nil
@@ -713,6 +798,13 @@ func (serverObj Server) Start() {
 		serverObj.rpcServer.Start()
 	}
 
+	if cfg.NodeMode == common.NodeModeLight {
+		// a light node does not keep the chains, it neither syncs the blocks nor accepts transactions
+		serverObj.lightClient.Start()
+		go serverObj.pusubManager.Start()
+		return
+	}
+
 	if cfg.NodeMode != common.NodeModeRelay {
 		serverObj.memPool.IsBlockGenStarted = true
 		serverObj.blockChain.SetIsBlockGenStarted(true)
@@ -1237,7 +1329,11 @@ func (serverObj *Server) OnPeerState(_ *peer.PeerConn, msg *wire.MessagePeerStat
 	Logger.log.Debug("Receive a peerstate START")
 	//var txProcessed chan struct{}
 	//serverObj.netSync.QueueMessage(nil, msg, txProcessed)
-	go serverObj.syncker.ReceivePeerState(msg)
+	if serverObj.lightClient != nil {
+		go serverObj.lightClient.ReceivePeerState(msg)
+	} else {
+		go serverObj.syncker.ReceivePeerState(msg)
+	}
 	Logger.log.Debug("Receive a peerstate END")
 }
 
@@ -1681,7 +1777,7 @@ func (serverObj *Server) GetChainMiningStatus(chain int) string {
 	if chain >= common.MaxShardNumber || chain < -1 {
 		return notmining
 	}
-	if cfg.MiningKeys != "" || cfg.PrivateKey != "" {
+	if cfg.MiningKeys != "" || cfg.PrivateKey != "" || cfg.RemoteSigner != "" {
 		//Beacon: chain = -1
 		role, chainID := serverObj.GetUserMiningState()
 		layer := ""
@@ -2162,6 +2258,64 @@ func (serverObj *Server) requestBlocksByHashViaStream(ctx context.Context, peerI
 	return blockCh, nil
 }
 
+// highwayChainID returns the chain ID of cID (-1 for beacon) in highway requests
+func highwayChainID(cID int) int32 {
+	if cID == -1 {
+		return int32(peerv2.HighwayBeaconID)
+	}
+	return int32(cID)
+}
+
+func (serverObj *Server) RequestStateCheckpoint(ctx context.Context, peerID string, cID int) (*blockchain.StateCheckpoint, error) {
+	Logger.log.Infof("[statesync] Request state checkpoint of chain %v from peer %v", cID, peerID)
+	resp, err := serverObj.highway.Requester.GetStateCheckpoint(ctx, &proto.StateCheckpointRequest{
+		CID:           highwayChainID(cID),
+		SyncFromPeer:  peerID,
+		RequesterPeer: serverObj.GetSelfPeerID().Pretty(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &blockchain.StateCheckpoint{
+		View:      resp.View,
+		Block:     resp.Block,
+		RootsHash: resp.RootsHash,
+		StakingTx: resp.StakingTx,
+	}, nil
+}
+
+// RequestStateNodes returns the state trie nodes at hashes which a peer
+// sent before the stream ended. The nodes are not verified against their hash.
+func (serverObj *Server) RequestStateNodes(ctx context.Context, peerID string, cID int, hashes []common.Hash) ([]trie.SyncResult, error) {
+	req := &proto.StateNodesRequest{
+		CID:           highwayChainID(cID),
+		SyncFromPeer:  peerID,
+		RequesterPeer: serverObj.GetSelfPeerID().Pretty(),
+	}
+	for _, hash := range hashes {
+		req.Hashes = append(req.Hashes, hash.GetBytes())
+	}
+	stream, err := serverObj.highway.Requester.StreamStateNodes(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	results := []trie.SyncResult{}
+	for {
+		node, err := stream.Recv()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return results, err
+		}
+		hash := common.Hash{}
+		if err := hash.SetBytes(node.Hash); err != nil {
+			continue
+		}
+		results = append(results, trie.SyncResult{Hash: hash, Data: node.Data})
+	}
+}
+
 func (s *Server) GetUserMiningState() (role string, chainID int) {
 	//TODO: check synker is in FewBlockBehind
 	userPk := s.consensusEngine.GetMiningPublicKeys()
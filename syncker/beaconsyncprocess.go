@@ -1,7 +1,6 @@
 package syncker
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -33,9 +32,11 @@ type BeaconSyncProcess struct {
 	beaconPool          *BlkPool
 	actionCh            chan func()
 	lastCrossShardState map[byte]map[byte]uint64
+	pipeline            *BlockPipeline
+	peerScorer          *PeerScorer
 }
 
-func NewBeaconSyncProcess(server Server, chain BeaconChainInterface) *BeaconSyncProcess {
+func NewBeaconSyncProcess(server Server, chain BeaconChainInterface, stateSyncer *StateSyncer, pipeline *BlockPipeline) *BeaconSyncProcess {
 
 	var isOutdatedBlock = func(blk interface{}) bool {
 		if blk.(*blockchain.BeaconBlock).GetHeight() < chain.GetFinalViewHeight() {
@@ -53,10 +54,18 @@ func NewBeaconSyncProcess(server Server, chain BeaconChainInterface) *BeaconSync
 		beaconPeerStateCh:   make(chan *wire.MessagePeerState),
 		actionCh:            make(chan func()),
 		lastCrossShardState: make(map[byte]map[byte]uint64),
+		pipeline:            pipeline,
+		peerScorer:          NewPeerScorer(),
 	}
-	go s.syncBeacon()
-	go s.insertBeaconBlockFromPool()
-	go s.updateConfirmCrossShard()
+	go func() {
+		//download the state of a peer first, blocks are synced from its height
+		if stateSyncer != nil && chain.GetFinalViewHeight() <= 1 {
+			stateSyncer.syncBeaconState(s)
+		}
+		go s.syncBeacon()
+		go s.insertBeaconBlockFromPool()
+		go s.updateConfirmCrossShard()
+	}()
 
 	go func() {
 		ticker := time.NewTicker(time.Millisecond * 500)
@@ -232,21 +241,23 @@ func (s *BeaconSyncProcess) insertBeaconBlockFromPool() {
 			}
 
 			//fullnode delay 1 block (make sure insert final block)
-			if os.Getenv("FULLNODE") != "" {
-				preBlk := s.beaconPool.GetBlockByPrevHash(*blk.Hash())
-				if len(preBlk) == 0 {
-					continue
-				}
+			blks := collectPoolBlocks(s.beaconPool, blk, MAX_PIPELINE_BLOCK, os.Getenv("FULLNODE") != "")
+			if len(blks) == 0 {
+				continue
 			}
 
 			insertBeaconTimeCache.Add(viewHash.String(), time.Now())
 			insertCnt++
-			//must validate this block when insert
-			if err := s.chain.InsertBlk(blk.(common.BlockInterface), true); err != nil {
-				Logger.Error("Insert beacon block from pool fail", blk.GetHeight(), blk.Hash(), err)
+			//must validate these blocks when insert
+			inserted, err := s.pipeline.InsertBlocks(s.chain, blks)
+			for _, insertedBlk := range blks[:inserted] {
+				s.beaconPool.RemoveBlock(insertedBlk.Hash())
+			}
+			if err != nil {
+				failBlk := blks[inserted]
+				Logger.Error("Insert beacon block from pool fail", failBlk.GetHeight(), failBlk.Hash(), err)
 				continue
 			}
-			s.beaconPool.RemoveBlock(blk.Hash())
 		}
 	}
 
@@ -262,9 +273,7 @@ func (s *BeaconSyncProcess) syncBeacon() {
 			continue
 		}
 
-		for peerID, pState := range s.getBeaconPeerStates() {
-			requestCnt += s.streamFromPeer(peerID, pState)
-		}
+		requestCnt += s.streamFromPeers(s.getBeaconPeerStates())
 
 		//last check, if we still need to sync more
 		if requestCnt > 0 {
@@ -278,74 +287,60 @@ func (s *BeaconSyncProcess) syncBeacon() {
 	}
 }
 
-func (s *BeaconSyncProcess) streamFromPeer(peerID string, pState BeaconPeerState) (requestCnt int) {
-	if pState.processed {
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
-	blockBuffer := []common.BlockInterface{}
-	defer func() {
-		if requestCnt == 0 {
-			pState.processed = true
+// streamFromPeers downloads the blocks up to the best height of the peers, from several peers at once
+func (s *BeaconSyncProcess) streamFromPeers(peerStates map[string]BeaconPeerState) (requestCnt int) {
+	peers := make(map[string]uint64)
+	toHeight := uint64(0)
+	for peerID, pState := range peerStates {
+		height := pState.BestViewHeight
+		//fullnode delay 1 block (make sure insert final block)
+		if os.Getenv("FULLNODE") != "" && height > 0 {
+			height--
+		}
+		peers[peerID] = height
+		if height > toHeight {
+			toHeight = height
 		}
-		cancel()
-	}()
-
-	toHeight := pState.BestViewHeight
-	//process param
-
-	//fullnode delay 1 block (make sure insert final block)
-	if os.Getenv("FULLNODE") != "" {
-		toHeight = toHeight - 1
 	}
 
 	if toHeight <= s.chain.GetBestViewHeight() {
 		return
 	}
 
-	//stream
-	ch, err := s.server.RequestBeaconBlocksViaStream(ctx, "", s.chain.GetFinalViewHeight()+1, toHeight)
-	if err != nil {
-		fmt.Println("Syncker: create channel fail")
-		return
+	requestCnt++
+	downloader := NewRangeDownloader(DefaultDownloaderConfig(), s.peerScorer, s.server.RequestBeaconBlocksViaStream, s.validateSyncedBlock, s.insertSyncedBlocks)
+	if inserted, err := downloader.Download(peers, s.chain.GetFinalViewHeight()+1, toHeight); err != nil {
+		Logger.Errorf("Syncker download beacon blocks to height %d fail: %v", toHeight, err)
+		if inserted == 0 {
+			time.Sleep(time.Second)
+		}
 	}
+	return
+}
 
-	//receive
-	requestCnt++
-	insertTime := time.Now()
-	for {
-		select {
-		case blk := <-ch:
-			if !isNil(blk) {
-				//Logger.Infof("Syncker beacon receive block %v", blk.GetHeight())
-				blockBuffer = append(blockBuffer, blk)
-			}
+//the signatures of a block of the current epoch are validated when it is downloaded, an invalid block bans its peer
+func (s *BeaconSyncProcess) validateSyncedBlock(blk common.BlockInterface) error {
+	if blk.GetCurrentEpoch() != s.chain.GetEpoch() {
+		return nil
+	}
+	return s.chain.PreValidateBlockSignatures(blk, s.chain.GetCommittee())
+}
 
-			if uint64(len(blockBuffer)) >= blockchain.DefaultMaxBlkReqPerPeer || (len(blockBuffer) > 0 && (isNil(blk) || time.Since(insertTime) > time.Millisecond*2000)) {
-				insertBlkCnt := 0
-				for {
-					time1 := time.Now()
-					if successBlk, err := InsertBatchBlock(s.chain, blockBuffer); err != nil {
-						if successBlk == 0 {
-							fmt.Println(err)
-						}
-						return
-					} else {
-						insertBlkCnt += successBlk
-						Logger.Infof("Syncker Insert %d beacon block (from %d to %d) elaspse %f \n", successBlk, blockBuffer[0].GetHeight(), blockBuffer[len(blockBuffer)-1].GetHeight(), time.Since(time1).Seconds())
-						if successBlk >= len(blockBuffer) || successBlk == 0 {
-							break
-						}
-						blockBuffer = blockBuffer[successBlk:]
-					}
-				}
-				insertTime = time.Now()
-				blockBuffer = []common.BlockInterface{}
-			}
-			if isNil(blk) && len(blockBuffer) == 0 {
-				return
-			}
+func (s *BeaconSyncProcess) insertSyncedBlocks(blocks []common.BlockInterface) (int, error) {
+	blockBuffer := blocks
+	insertBlkCnt := 0
+	for len(blockBuffer) > 0 {
+		time1 := time.Now()
+		successBlk, err := InsertBatchBlock(s.chain, blockBuffer)
+		if err != nil {
+			return insertBlkCnt, err
+		}
+		if successBlk == 0 {
+			break
 		}
+		insertBlkCnt += successBlk
+		Logger.Infof("Syncker Insert %d beacon block (from %d to %d) elaspse %f \n", successBlk, blockBuffer[0].GetHeight(), blockBuffer[successBlk-1].GetHeight(), time.Since(time1).Seconds())
+		blockBuffer = blockBuffer[successBlk:]
 	}
+	return insertBlkCnt, nil
 }
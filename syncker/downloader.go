@@ -0,0 +1,298 @@
+package syncker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// DownloaderConfig sets how a range of blocks is split and downloaded from the peers
+type DownloaderConfig struct {
+	ChunkSize    uint64        // number of blocks requested from a peer at once
+	MaxPeers     int           // number of chunks downloaded at the same time, from different peers
+	ChunkTimeout time.Duration // time to download a chunk
+	MaxAttempts  int           // number of failed downloads of a chunk before the download is given up
+}
+
+func DefaultDownloaderConfig() DownloaderConfig {
+	return DownloaderConfig{
+		ChunkSize:    50,
+		MaxPeers:     8,
+		ChunkTimeout: 30 * time.Second,
+		MaxAttempts:  5,
+	}
+}
+
+// RequestBlocksFunc streams the blocks from height from to height to from peerID, the channel is closed at the end of the stream
+type RequestBlocksFunc func(ctx context.Context, peerID string, from uint64, to uint64) (chan common.BlockInterface, error)
+
+// InsertBlocksFunc inserts blocks in the chain and returns the number of blocks inserted. Fewer blocks
+// inserted without error means the chain cannot insert more blocks for now. An invalid block is reported
+// with a blockchain.InvalidBlockError, the other errors are failures of the node
+type InsertBlocksFunc func(blocks []common.BlockInterface) (int, error)
+
+// RangeDownloader downloads a range of blocks: the range is split into chunks downloaded concurrently
+// from the best scored peers, a chunk failing to download is assigned to another peer. The chunks are
+// inserted in order
+type RangeDownloader struct {
+	config  DownloaderConfig
+	scorer  *PeerScorer
+	request RequestBlocksFunc
+	// validate checks a downloaded block on its own, an invalid block bans the peer sending it
+	validate func(blk common.BlockInterface) error
+	insert   InsertBlocksFunc
+}
+
+func NewRangeDownloader(config DownloaderConfig, scorer *PeerScorer, request RequestBlocksFunc, validate func(blk common.BlockInterface) error, insert InsertBlocksFunc) *RangeDownloader {
+	defaultConfig := DefaultDownloaderConfig()
+	if config.ChunkSize == 0 {
+		config.ChunkSize = defaultConfig.ChunkSize
+	}
+	if config.MaxPeers <= 0 {
+		config.MaxPeers = defaultConfig.MaxPeers
+	}
+	if config.ChunkTimeout <= 0 {
+		config.ChunkTimeout = defaultConfig.ChunkTimeout
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultConfig.MaxAttempts
+	}
+	return &RangeDownloader{
+		config:   config,
+		scorer:   scorer,
+		request:  request,
+		validate: validate,
+		insert:   insert,
+	}
+}
+
+type downloadChunk struct {
+	index    int
+	from     uint64
+	to       uint64
+	attempts int
+	excluded map[string]bool // peers whose download of the chunk failed
+}
+
+type chunkResult struct {
+	chunk   *downloadChunk
+	peerID  string
+	blocks  []common.BlockInterface
+	elapsed time.Duration
+	timeout bool
+	invalid bool
+	err     error
+}
+
+var errChunkTimeout = errors.New("chunk download timeout")
+
+// fetch downloads chunk from peerID, the blocks must be the ones of the heights of the chunk, each one
+// linked to the one before it
+func (d *RangeDownloader) fetch(ctx context.Context, peerID string, chunk *downloadChunk) (res chunkResult) {
+	res = chunkResult{chunk: chunk, peerID: peerID}
+	startTime := time.Now()
+	defer func() {
+		res.elapsed = time.Since(startTime)
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, d.config.ChunkTimeout)
+	defer cancel()
+	ch, err := d.request(ctx, peerID, chunk.from, chunk.to)
+	if err != nil {
+		res.err = err
+		return
+	}
+	for {
+		select {
+		case blk := <-ch:
+			if isNil(blk) {
+				if uint64(len(res.blocks)) != chunk.to-chunk.from+1 {
+					res.err = fmt.Errorf("peer %v sent %v blocks of [%v %v]", peerID, len(res.blocks), chunk.from, chunk.to)
+				}
+				return
+			}
+			height := chunk.from + uint64(len(res.blocks))
+			if blk.GetHeight() != height {
+				res.invalid = true
+				res.err = fmt.Errorf("peer %v sent block %v instead of block %v", peerID, blk.GetHeight(), height)
+				return
+			}
+			if len(res.blocks) > 0 && blk.GetPrevHash() != *res.blocks[len(res.blocks)-1].Hash() {
+				res.invalid = true
+				res.err = fmt.Errorf("block %v sent by peer %v is not linked to the previous block", blk.GetHeight(), peerID)
+				return
+			}
+			if d.validate != nil {
+				if err := d.validate(blk); err != nil {
+					res.invalid = true
+					res.err = fmt.Errorf("block %v sent by peer %v is invalid: %v", blk.GetHeight(), peerID, err)
+					return
+				}
+			}
+			res.blocks = append(res.blocks, blk)
+		case <-ctx.Done():
+			res.timeout = true
+			res.err = errChunkTimeout
+			return
+		}
+	}
+}
+
+// pickPeer returns the best ranked idle peer having the blocks of chunk. A peer whose download of the chunk
+// failed is picked again only if every peer having the blocks failed
+func pickPeer(ranked []string, peers map[string]uint64, busy map[string]bool, chunk *downloadChunk) string {
+	retry := ""
+	waitOther := false
+	for _, peerID := range ranked {
+		if peers[peerID] < chunk.to {
+			continue
+		}
+		if !chunk.excluded[peerID] {
+			if !busy[peerID] {
+				return peerID
+			}
+			waitOther = true
+		} else if retry == "" && !busy[peerID] {
+			retry = peerID
+		}
+	}
+	if waitOther {
+		return ""
+	}
+	return retry
+}
+
+// Download downloads and inserts the blocks from height from to height to. peers is the best height of
+// each peer, a chunk is only downloaded from a peer having all its blocks. It returns the number of blocks inserted
+func (d *RangeDownloader) Download(peers map[string]uint64, from uint64, to uint64) (int, error) {
+	if from > to {
+		return 0, nil
+	}
+	pending := []*downloadChunk{}
+	for start := from; start <= to; start += d.config.ChunkSize {
+		end := start + d.config.ChunkSize - 1
+		if end > to {
+			end = to
+		}
+		pending = append(pending, &downloadChunk{index: len(pending), from: start, to: end, excluded: make(map[string]bool)})
+	}
+	total := len(pending)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resultCh := make(chan chunkResult, d.config.MaxPeers)
+	busy := make(map[string]bool)
+	downloaded := make(map[int]chunkResult)
+	inflight := 0
+	next := 0
+	inserted := 0
+	var lastHash *common.Hash
+
+	peerIDs := []string{}
+	for peerID := range peers {
+		peerIDs = append(peerIDs, peerID)
+	}
+
+	//requeue puts back chunk in the pending chunks, ordered by index
+	requeue := func(chunk *downloadChunk) {
+		i := 0
+		for i < len(pending) && pending[i].index < chunk.index {
+			i++
+		}
+		pending = append(pending, nil)
+		copy(pending[i+1:], pending[i:])
+		pending[i] = chunk
+	}
+
+	for next < total {
+		//assign the pending chunks to the idle peers, at most MaxPeers chunks ahead of the next chunk to insert
+		ranked := d.scorer.Rank(peerIDs)
+		for i := 0; i < len(pending) && inflight < d.config.MaxPeers; {
+			chunk := pending[i]
+			if chunk.index >= next+d.config.MaxPeers*2 {
+				break
+			}
+			peerID := pickPeer(ranked, peers, busy, chunk)
+			if peerID == "" {
+				i++
+				continue
+			}
+			busy[peerID] = true
+			inflight++
+			pending = append(pending[:i], pending[i+1:]...)
+			go func(peerID string, chunk *downloadChunk) {
+				resultCh <- d.fetch(ctx, peerID, chunk)
+			}(peerID, chunk)
+		}
+		if inflight == 0 {
+			return inserted, fmt.Errorf("no peer to download blocks [%v %v]", from+uint64(inserted), to)
+		}
+
+		res := <-resultCh
+		inflight--
+		delete(busy, res.peerID)
+		if res.err != nil {
+			Logger.Infof("Download blocks [%v %v] from peer %v fail: %v", res.chunk.from, res.chunk.to, res.peerID, res.err)
+			switch {
+			case res.invalid:
+				d.scorer.RecordInvalid(res.peerID)
+			case res.timeout:
+				d.scorer.RecordTimeout(res.peerID)
+			default:
+				d.scorer.RecordFailure(res.peerID)
+			}
+			res.chunk.attempts++
+			if res.chunk.attempts >= d.config.MaxAttempts {
+				return inserted, fmt.Errorf("download blocks [%v %v] fail %v times: %v", res.chunk.from, res.chunk.to, res.chunk.attempts, res.err)
+			}
+			res.chunk.excluded[res.peerID] = true
+			requeue(res.chunk)
+			continue
+		}
+		d.scorer.RecordSuccess(res.peerID, len(res.blocks), res.elapsed)
+		downloaded[res.chunk.index] = res
+
+		//insert the downloaded chunks in order
+		for next < total {
+			res, ok := downloaded[next]
+			if !ok {
+				break
+			}
+			delete(downloaded, next)
+			if lastHash != nil && res.blocks[0].GetPrevHash() != *lastHash {
+				//the peer is on another branch
+				d.scorer.RecordFailure(res.peerID)
+				res.chunk.excluded[res.peerID] = true
+				requeue(res.chunk)
+				break
+			}
+			n, err := d.insert(res.blocks)
+			inserted += n
+			if err != nil {
+				Logger.Errorf("Insert blocks [%v %v] from peer %v fail: %v", res.chunk.from, res.chunk.to, res.peerID, err)
+				if blockchain.IsInvalidBlockError(err) {
+					d.scorer.RecordInvalid(res.peerID)
+				} else {
+					d.scorer.RecordFailure(res.peerID)
+				}
+				res.chunk.excluded[res.peerID] = true
+				res.chunk.attempts++
+				if res.chunk.attempts >= d.config.MaxAttempts {
+					return inserted, err
+				}
+				requeue(res.chunk)
+				break
+			}
+			if n < len(res.blocks) {
+				return inserted, nil
+			}
+			lastHash = res.blocks[len(res.blocks)-1].Hash()
+			next++
+		}
+	}
+	return inserted, nil
+}
@@ -0,0 +1,234 @@
+package syncker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	honestPeer = iota
+	silentPeer // never sends a block
+	lyingPeer  // sends forged blocks
+)
+
+// downloadNetwork serves the blocks of a chain to the downloader, each peer behaving as set in peers
+type downloadNetwork struct {
+	blocks   []common.BlockInterface // blocks[i] is the block of height i+1
+	valid    map[common.Hash]bool
+	peers    map[string]int
+	lock     sync.Mutex
+	requests map[string]int
+	inserted []common.BlockInterface
+}
+
+func newDownloadNetwork(n int, peers map[string]int) *downloadNetwork {
+	network := &downloadNetwork{
+		valid:    make(map[common.Hash]bool),
+		peers:    peers,
+		requests: make(map[string]int),
+	}
+	prevHash := common.Hash{}
+	for i := 1; i <= n; i++ {
+		blk := blockchain.NewBeaconBlock()
+		blk.Header.Height = uint64(i)
+		blk.Header.PreviousBlockHash = prevHash
+		prevHash = *blk.Hash()
+		network.blocks = append(network.blocks, blk)
+		network.valid[prevHash] = true
+	}
+	return network
+}
+
+func (network *downloadNetwork) request(ctx context.Context, peerID string, from uint64, to uint64) (chan common.BlockInterface, error) {
+	network.lock.Lock()
+	network.requests[peerID]++
+	network.lock.Unlock()
+	ch := make(chan common.BlockInterface)
+	go func() {
+		defer close(ch)
+		for height := from; height <= to; height++ {
+			blk := network.blocks[height-1]
+			switch network.peers[peerID] {
+			case silentPeer:
+				<-ctx.Done()
+				return
+			case lyingPeer:
+				forged := *blk.(*blockchain.BeaconBlock)
+				forged.Header.Timestamp++
+				blk = &forged
+			}
+			select {
+			case ch <- blk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (network *downloadNetwork) validate(blk common.BlockInterface) error {
+	if !network.valid[*blk.Hash()] {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func (network *downloadNetwork) insert(blocks []common.BlockInterface) (int, error) {
+	network.inserted = append(network.inserted, blocks...)
+	return len(blocks), nil
+}
+
+func (network *downloadNetwork) heights(to uint64) map[string]uint64 {
+	peers := make(map[string]uint64)
+	for peerID := range network.peers {
+		peers[peerID] = to
+	}
+	return peers
+}
+
+func (network *downloadNetwork) assertInserted(t *testing.T, n int) {
+	assert.Equal(t, n, len(network.inserted))
+	for i, blk := range network.inserted {
+		assert.Equal(t, network.blocks[i].Hash(), blk.Hash())
+	}
+}
+
+func TestRangeDownloader_Download(t *testing.T) {
+	network := newDownloadNetwork(500, map[string]int{"peer-0": honestPeer, "peer-1": honestPeer, "peer-2": honestPeer})
+	config := DownloaderConfig{ChunkSize: 20, MaxPeers: 3}
+	downloader := NewRangeDownloader(config, NewPeerScorer(), network.request, network.validate, network.insert)
+	inserted, err := downloader.Download(network.heights(500), 1, 500)
+	assert.Nil(t, err)
+	assert.Equal(t, 500, inserted)
+	network.assertInserted(t, 500)
+	//the chunks are spread over the peers
+	for peerID := range network.peers {
+		assert.True(t, network.requests[peerID] > 0, "no chunk downloaded from %v", peerID)
+	}
+}
+
+func TestRangeDownloader_DownloadReassignTimeout(t *testing.T) {
+	network := newDownloadNetwork(100, map[string]int{"peer-0": silentPeer, "peer-1": honestPeer})
+	config := DownloaderConfig{ChunkSize: 10, MaxPeers: 2, ChunkTimeout: 100 * time.Millisecond}
+	scorer := NewPeerScorer()
+	downloader := NewRangeDownloader(config, scorer, network.request, network.validate, network.insert)
+	inserted, err := downloader.Download(network.heights(100), 1, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 100, inserted)
+	network.assertInserted(t, 100)
+	assert.False(t, scorer.IsBanned("peer-0"))
+	assert.True(t, scorer.Score("peer-0") < scorer.Score("peer-1"))
+}
+
+func TestRangeDownloader_DownloadBanInvalid(t *testing.T) {
+	network := newDownloadNetwork(100, map[string]int{"peer-0": lyingPeer, "peer-1": honestPeer, "peer-2": honestPeer})
+	config := DownloaderConfig{ChunkSize: 10, MaxPeers: 3}
+	scorer := NewPeerScorer()
+	downloader := NewRangeDownloader(config, scorer, network.request, network.validate, network.insert)
+	inserted, err := downloader.Download(network.heights(100), 1, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 100, inserted)
+	network.assertInserted(t, 100)
+	assert.True(t, scorer.IsBanned("peer-0"))
+	//a banned peer is not asked again
+	assert.Equal(t, 1, network.requests["peer-0"])
+}
+
+func TestRangeDownloader_DownloadInsertError(t *testing.T) {
+	//the blocks are only checked at insert, as by the checkpoint verifier
+	network := newDownloadNetwork(100, map[string]int{"peer-0": lyingPeer, "peer-1": honestPeer})
+	config := DownloaderConfig{ChunkSize: 10, MaxPeers: 2}
+	scorer := NewPeerScorer()
+	failed := false
+	insert := func(blocks []common.BlockInterface) (int, error) {
+		for _, blk := range blocks {
+			if !network.valid[*blk.Hash()] {
+				return 0, blockchain.NewInvalidBlockError(errors.New("invalid signature"))
+			}
+		}
+		if !failed && blocks[0].GetHeight() > 50 {
+			failed = true
+			return 0, errors.New("database error")
+		}
+		return network.insert(blocks)
+	}
+	downloader := NewRangeDownloader(config, scorer, network.request, nil, insert)
+	inserted, err := downloader.Download(network.heights(100), 1, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 100, inserted)
+	network.assertInserted(t, 100)
+	assert.True(t, scorer.IsBanned("peer-0"))
+	//a failure of the node does not ban the peer which sent valid blocks
+	assert.True(t, failed)
+	assert.False(t, scorer.IsBanned("peer-1"))
+}
+
+func TestRangeDownloader_DownloadCrossShardTimeout(t *testing.T) {
+	network := newDownloadNetwork(100, map[string]int{"peer-0": honestPeer, "peer-1": honestPeer})
+	config := DownloaderConfig{ChunkSize: 10, MaxPeers: 2}
+	scorer := NewPeerScorer()
+	timeouts := 0
+	insert := func(blocks []common.BlockInterface) (int, error) {
+		//the cross shard blocks needed by the chunk are not in the pool yet, as returned by InsertShardBlock
+		if blocks[0].GetHeight() > 50 && timeouts < 2 {
+			timeouts++
+			return 0, blockchain.NewBlockChainError(blockchain.CrossShardBlockError, errors.New("Unable to get required crossShard blocks from pool in time"))
+		}
+		return network.insert(blocks)
+	}
+	downloader := NewRangeDownloader(config, scorer, network.request, network.validate, insert)
+	inserted, err := downloader.Download(network.heights(100), 1, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 100, inserted)
+	network.assertInserted(t, 100)
+	assert.Equal(t, 2, timeouts)
+	//the blocks were valid, neither peer is banned
+	assert.False(t, scorer.IsBanned("peer-0"))
+	assert.False(t, scorer.IsBanned("peer-1"))
+}
+
+func TestRangeDownloader_DownloadPeerHeight(t *testing.T) {
+	network := newDownloadNetwork(100, map[string]int{"peer-0": honestPeer, "peer-1": honestPeer})
+	config := DownloaderConfig{ChunkSize: 10, MaxPeers: 2}
+	downloader := NewRangeDownloader(config, NewPeerScorer(), network.request, network.validate, network.insert)
+	//only peer-1 has the blocks above 50
+	inserted, err := downloader.Download(map[string]uint64{"peer-0": 50, "peer-1": 100}, 1, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 100, inserted)
+	network.assertInserted(t, 100)
+	assert.True(t, network.requests["peer-0"] <= 5)
+}
+
+func TestRangeDownloader_DownloadNoPeer(t *testing.T) {
+	network := newDownloadNetwork(100, map[string]int{"peer-0": lyingPeer})
+	downloader := NewRangeDownloader(DownloaderConfig{ChunkSize: 10}, NewPeerScorer(), network.request, network.validate, network.insert)
+	inserted, err := downloader.Download(network.heights(100), 1, 100)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, inserted)
+}
+
+func TestPeerScorer(t *testing.T) {
+	scorer := NewPeerScorer()
+	now := time.Now()
+	scorer.now = func() time.Time {
+		return now
+	}
+	scorer.RecordSuccess("fast", 10, time.Second)
+	scorer.RecordSuccess("slow", 10, 10*time.Second)
+	scorer.RecordSuccess("flaky", 10, time.Second)
+	scorer.RecordTimeout("flaky")
+	scorer.RecordTimeout("flaky")
+	scorer.RecordInvalid("liar")
+	assert.Equal(t, []string{"new", "fast", "flaky", "slow"}, scorer.Rank([]string{"slow", "liar", "fast", "new", "flaky"}))
+
+	now = now.Add(PEER_BAN_DURATION)
+	assert.False(t, scorer.IsBanned("liar"))
+}
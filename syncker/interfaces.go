@@ -8,6 +8,7 @@ import (
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/trie"
 )
 
 type Server interface {
@@ -23,6 +24,8 @@ type Server interface {
 	RequestCrossShardBlocksByHashViaStream(ctx context.Context, peerID string, fromSID int, toSID int, hashes [][]byte) (blockCh chan common.BlockInterface, err error)
 	RequestBeaconBlocksByHashViaStream(ctx context.Context, peerID string, hashes [][]byte) (blockCh chan common.BlockInterface, err error)
 	RequestShardBlocksByHashViaStream(ctx context.Context, peerID string, fromSID int, hashes [][]byte) (blockCh chan common.BlockInterface, err error)
+	RequestStateCheckpoint(ctx context.Context, peerID string, cID int) (*blockchain.StateCheckpoint, error)
+	RequestStateNodes(ctx context.Context, peerID string, cID int, hashes []common.Hash) ([]trie.SyncResult, error)
 	//database
 	FetchConfirmBeaconBlockByHeight(height uint64) (*blockchain.BeaconBlock, error)
 	GetBeaconChainDatabase() incdb.Database
@@ -52,6 +55,8 @@ type Chain interface {
 	GetFinalViewHash() string
 	GetEpoch() uint64
 	ValidateBlockSignatures(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error
+	PreValidateBlockSignatures(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error
+	PreValidateBlockTxs(block common.BlockInterface) error
 	//ValidateProducerPosition(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error
 	GetCommittee() []incognitokey.CommitteePublicKey
 	CurrentHeight() uint64
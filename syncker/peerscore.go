@@ -0,0 +1,126 @@
+package syncker
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const PEER_BAN_DURATION = 10 * time.Minute
+
+// peerScore is what the syncker learnt about a peer from the blocks it downloaded from it
+type peerScore struct {
+	success     int           // number of chunks downloaded
+	timeout     int           // number of chunks not downloaded in time
+	fail        int           // number of chunks not connecting to the chain or not downloaded
+	latency     time.Duration // moving average of the download time of a block
+	bannedUntil time.Time
+}
+
+// PeerScorer scores the peers on the latency, the validity and the timeouts of their downloads.
+// A peer sending an invalid block is banned for PEER_BAN_DURATION
+type PeerScorer struct {
+	lock   sync.RWMutex
+	scores map[string]*peerScore
+	now    func() time.Time
+}
+
+func NewPeerScorer() *PeerScorer {
+	return &PeerScorer{
+		scores: make(map[string]*peerScore),
+		now:    time.Now,
+	}
+}
+
+func (scorer *PeerScorer) get(peerID string) *peerScore {
+	score, ok := scorer.scores[peerID]
+	if !ok {
+		score = &peerScore{}
+		scorer.scores[peerID] = score
+	}
+	return score
+}
+
+// RecordSuccess records that peerID sent a chunk of blocks valid blocks in elapsed
+func (scorer *PeerScorer) RecordSuccess(peerID string, blocks int, elapsed time.Duration) {
+	if blocks <= 0 {
+		return
+	}
+	scorer.lock.Lock()
+	defer scorer.lock.Unlock()
+	score := scorer.get(peerID)
+	latency := elapsed / time.Duration(blocks)
+	if score.success == 0 {
+		score.latency = latency
+	} else {
+		score.latency = (score.latency*3 + latency) / 4
+	}
+	score.success++
+}
+
+// RecordTimeout records that peerID did not send the requested blocks in time
+func (scorer *PeerScorer) RecordTimeout(peerID string) {
+	scorer.lock.Lock()
+	defer scorer.lock.Unlock()
+	scorer.get(peerID).timeout++
+}
+
+// RecordFailure records that the blocks of peerID could not be used, without them being invalid
+func (scorer *PeerScorer) RecordFailure(peerID string) {
+	scorer.lock.Lock()
+	defer scorer.lock.Unlock()
+	scorer.get(peerID).fail++
+}
+
+// RecordInvalid bans peerID, it sent an invalid block
+func (scorer *PeerScorer) RecordInvalid(peerID string) {
+	scorer.lock.Lock()
+	defer scorer.lock.Unlock()
+	score := scorer.get(peerID)
+	score.fail++
+	score.bannedUntil = scorer.now().Add(PEER_BAN_DURATION)
+	Logger.Infof("Ban peer %v until %v, it sent an invalid block", peerID, score.bannedUntil)
+}
+
+func (scorer *PeerScorer) IsBanned(peerID string) bool {
+	scorer.lock.RLock()
+	defer scorer.lock.RUnlock()
+	score, ok := scorer.scores[peerID]
+	return ok && scorer.now().Before(score.bannedUntil)
+}
+
+// Score is the rate of successful downloads of peerID divided by its latency per block in seconds.
+// An unknown peer has the best score, so that every peer is tried
+func (scorer *PeerScorer) Score(peerID string) float64 {
+	scorer.lock.RLock()
+	defer scorer.lock.RUnlock()
+	score, ok := scorer.scores[peerID]
+	if !ok {
+		return 1e9
+	}
+	if scorer.now().Before(score.bannedUntil) {
+		return 0
+	}
+	reliability := float64(score.success+1) / float64(score.success+score.timeout+score.fail+1)
+	return reliability / (score.latency.Seconds() + 0.001)
+}
+
+// Rank returns the peers not banned, best score first
+func (scorer *PeerScorer) Rank(peerIDs []string) []string {
+	scores := make(map[string]float64)
+	ranked := []string{}
+	for _, peerID := range peerIDs {
+		if scorer.IsBanned(peerID) {
+			continue
+		}
+		scores[peerID] = scorer.Score(peerID)
+		ranked = append(ranked, peerID)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if scores[ranked[i]] != scores[ranked[j]] {
+			return scores[ranked[i]] > scores[ranked[j]]
+		}
+		return ranked[i] < ranked[j]
+	})
+	return ranked
+}
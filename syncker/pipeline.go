@@ -0,0 +1,182 @@
+package syncker
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metrics"
+)
+
+// PipelineConfig sets the workers of the validation pipeline of the syncker
+type PipelineConfig struct {
+	SignatureWorkers int // number of blocks whose committee signatures are validated at the same time
+	TxWorkers        int // number of blocks whose transaction proofs are validated at the same time
+	Lookahead        int // number of blocks validated ahead of the block being inserted
+}
+
+func DefaultPipelineConfig() PipelineConfig {
+	return PipelineConfig{
+		SignatureWorkers: runtime.NumCPU(),
+		TxWorkers:        runtime.NumCPU(),
+		Lookahead:        32,
+	}
+}
+
+var (
+	pipelineValidatedMeter = metrics.NewRegisteredMeter("syncker/pipeline/validated", nil)
+	pipelineInsertedMeter  = metrics.NewRegisteredMeter("syncker/pipeline/inserted", nil)
+	pipelineSignatureTimer = metrics.NewRegisteredTimer("syncker/pipeline/signature", nil)
+	pipelineTxTimer        = metrics.NewRegisteredTimer("syncker/pipeline/tx", nil)
+	pipelineTxMeter        = metrics.NewRegisteredMeter("syncker/pipeline/tx/validated", nil)
+	pipelineInsertTimer    = metrics.NewRegisteredTimer("syncker/pipeline/insert", nil)
+)
+
+// BlockPipeline inserts a chain of blocks: the committee signatures and the transaction proofs
+// of the next Lookahead blocks are validated in parallel while the blocks are inserted one by one.
+// The stateful checks of a block are done at insert, which verifies the transactions failing ahead
+type BlockPipeline struct {
+	config PipelineConfig
+}
+
+func NewBlockPipeline(config PipelineConfig) *BlockPipeline {
+	defaultConfig := DefaultPipelineConfig()
+	if config.SignatureWorkers <= 0 {
+		config.SignatureWorkers = defaultConfig.SignatureWorkers
+	}
+	if config.TxWorkers <= 0 {
+		config.TxWorkers = defaultConfig.TxWorkers
+	}
+	if config.Lookahead <= 0 {
+		config.Lookahead = defaultConfig.Lookahead
+	}
+	return &BlockPipeline{config: config}
+}
+
+type pipelineBlock struct {
+	block        common.BlockInterface
+	sameEpoch    bool // signatures are validated ahead only for the blocks of the epoch of the chain
+	sigErr       error
+	txErr        error
+	sigValidated chan struct{}
+	txValidated  chan struct{}
+}
+
+// InsertBlocks inserts blocks, each one linked to the one before it, into chain. It stops at the first
+// block failing validation and returns the number of blocks inserted
+func (p *BlockPipeline) InsertBlocks(chain Chain, blocks []common.BlockInterface) (int, error) {
+	if len(blocks) == 0 {
+		return 0, nil
+	}
+	startTime := time.Now()
+	epoch := chain.GetEpoch()
+	committee := chain.GetCommittee()
+
+	items := make([]*pipelineBlock, len(blocks))
+	for i, blk := range blocks {
+		items[i] = &pipelineBlock{
+			block:        blk,
+			sameEpoch:    blk.GetCurrentEpoch() == epoch,
+			sigValidated: make(chan struct{}),
+			txValidated:  make(chan struct{}),
+		}
+	}
+
+	sigCh := make(chan *pipelineBlock)
+	txCh := make(chan *pipelineBlock)
+	window := make(chan struct{}, p.config.Lookahead)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	for i := 0; i < p.config.SignatureWorkers; i++ {
+		go func() {
+			for item := range sigCh {
+				if item.sameEpoch {
+					t := time.Now()
+					item.sigErr = chain.PreValidateBlockSignatures(item.block, committee)
+					pipelineSignatureTimer.UpdateSince(t)
+				}
+				close(item.sigValidated)
+			}
+		}()
+	}
+	for i := 0; i < p.config.TxWorkers; i++ {
+		go func() {
+			for item := range txCh {
+				t := time.Now()
+				item.txErr = chain.PreValidateBlockTxs(item.block)
+				pipelineTxTimer.UpdateSince(t)
+				pipelineTxMeter.Mark(1)
+				close(item.txValidated)
+			}
+		}()
+	}
+	//feed the workers, at most Lookahead blocks ahead of the inserted one
+	go func() {
+		defer close(sigCh)
+		defer close(txCh)
+		for _, item := range items {
+			select {
+			case window <- struct{}{}:
+			case <-stopCh:
+				return
+			}
+			for _, ch := range []chan *pipelineBlock{sigCh, txCh} {
+				select {
+				case ch <- item:
+				case <-stopCh:
+					return
+				}
+			}
+		}
+	}()
+
+	inserted := 0
+	for _, item := range items {
+		<-item.sigValidated
+		<-item.txValidated
+		pipelineValidatedMeter.Mark(1)
+		blk := item.block
+		if item.sigErr != nil {
+			Logger.Errorf("Validate signatures of block %v hash %v got error %v", blk.GetHeight(), blk.Hash().String(), item.sigErr)
+			return inserted, item.sigErr
+		}
+		if item.txErr != nil {
+			//the insert verifies the transactions not pre-validated
+			Logger.Debugf("Pre-validate transactions of block %v hash %v got error %v", blk.GetHeight(), blk.Hash().String(), item.txErr)
+		}
+		if !chain.CheckExistedBlk(blk) {
+			t := time.Now()
+			if err := chain.InsertBlk(blk, true); err != nil {
+				return inserted, err
+			}
+			pipelineInsertTimer.UpdateSince(t)
+		}
+		inserted++
+		pipelineInsertedMeter.Mark(1)
+		<-window
+	}
+	elapsed := time.Since(startTime).Seconds()
+	Logger.Infof("Pipeline inserted %d blocks (from %d to %d) in %.2fs, %.2f blocks/s", inserted, blocks[0].GetHeight(), blocks[len(blocks)-1].GetHeight(), elapsed, float64(inserted)/elapsed)
+	return inserted, nil
+}
+
+// collectPoolBlocks returns first and the blocks of pool following it, the first one of each height,
+// at most max blocks. If delayLast, the last block is kept in the pool until a block follows it
+func collectPoolBlocks(pool *BlkPool, first common.BlockPoolInterface, max int, delayLast bool) []common.BlockInterface {
+	blocks := []common.BlockInterface{first.(common.BlockInterface)}
+	for len(blocks) <= max {
+		next := pool.GetBlockByPrevHash(*blocks[len(blocks)-1].Hash())
+		if len(next) == 0 {
+			break
+		}
+		blocks = append(blocks, next[0].(common.BlockInterface))
+	}
+	if delayLast {
+		return blocks[:len(blocks)-1]
+	}
+	if len(blocks) > max {
+		return blocks[:max]
+	}
+	return blocks
+}
@@ -0,0 +1,159 @@
+package syncker
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/stretchr/testify/assert"
+)
+
+// pipelineChain records the calls of the pipeline, the validation of a block fails as set in sigErr and txFails
+type pipelineChain struct {
+	Chain
+	lock      sync.Mutex
+	epoch     uint64
+	sigErr    map[uint64]error
+	txFails   map[uint64]bool   // height -> the transactions of the block fail pre-validation
+	sigCalls  map[uint64]int    // height -> number of signature validations
+	txAhead   map[uint64]uint64 // height -> number of blocks not inserted yet when its txs were validated
+	inserted  []uint64
+	insertErr map[uint64]error
+}
+
+func newPipelineChain() *pipelineChain {
+	return &pipelineChain{
+		epoch:     1,
+		sigErr:    make(map[uint64]error),
+		txFails:   make(map[uint64]bool),
+		sigCalls:  make(map[uint64]int),
+		txAhead:   make(map[uint64]uint64),
+		insertErr: make(map[uint64]error),
+	}
+}
+
+func (chain *pipelineChain) GetEpoch() uint64 {
+	return chain.epoch
+}
+
+func (chain *pipelineChain) GetCommittee() []incognitokey.CommitteePublicKey {
+	return nil
+}
+
+func (chain *pipelineChain) PreValidateBlockSignatures(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error {
+	chain.lock.Lock()
+	defer chain.lock.Unlock()
+	chain.sigCalls[block.GetHeight()]++
+	return chain.sigErr[block.GetHeight()]
+}
+
+func (chain *pipelineChain) PreValidateBlockTxs(block common.BlockInterface) error {
+	chain.lock.Lock()
+	defer chain.lock.Unlock()
+	height := block.GetHeight()
+	if _, ok := chain.txAhead[height]; !ok {
+		chain.txAhead[height] = height - uint64(len(chain.inserted))
+	}
+	if chain.txFails[height] {
+		return errors.New("spend an output not final yet")
+	}
+	return nil
+}
+
+func (chain *pipelineChain) CheckExistedBlk(block common.BlockInterface) bool {
+	return false
+}
+
+func (chain *pipelineChain) InsertBlk(block common.BlockInterface, shouldValidate bool) error {
+	chain.lock.Lock()
+	defer chain.lock.Unlock()
+	if err := chain.insertErr[block.GetHeight()]; err != nil {
+		return err
+	}
+	chain.inserted = append(chain.inserted, block.GetHeight())
+	return nil
+}
+
+func newPipelineBlocks(n int, epoch func(height uint64) uint64) []common.BlockInterface {
+	blocks := []common.BlockInterface{}
+	for i := 1; i <= n; i++ {
+		blk := blockchain.NewBeaconBlock()
+		blk.Header.Height = uint64(i)
+		blk.Header.Epoch = epoch(uint64(i))
+		blocks = append(blocks, blk)
+	}
+	return blocks
+}
+
+func sameEpoch(height uint64) uint64 {
+	return 1
+}
+
+func init() {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+func TestBlockPipeline_InsertBlocks(t *testing.T) {
+	chain := newPipelineChain()
+	pipeline := NewBlockPipeline(PipelineConfig{SignatureWorkers: 4, TxWorkers: 4, Lookahead: 8})
+	inserted, err := pipeline.InsertBlocks(chain, newPipelineBlocks(100, sameEpoch))
+	assert.Nil(t, err)
+	assert.Equal(t, 100, inserted)
+	for i, height := range chain.inserted {
+		assert.Equal(t, uint64(i+1), height)
+	}
+	for height := uint64(1); height <= 100; height++ {
+		assert.Equal(t, 1, chain.sigCalls[height])
+		assert.True(t, chain.txAhead[height] <= 8, "block %v validated %v blocks ahead", height, chain.txAhead[height])
+	}
+}
+
+func TestBlockPipeline_InsertBlocksTxsNotPreValidated(t *testing.T) {
+	chain := newPipelineChain()
+	chain.txFails[5] = true
+	pipeline := NewBlockPipeline(PipelineConfig{SignatureWorkers: 2, TxWorkers: 2, Lookahead: 4})
+	inserted, err := pipeline.InsertBlocks(chain, newPipelineBlocks(10, sameEpoch))
+	//the insert verifies the transactions failing pre-validation
+	assert.Nil(t, err)
+	assert.Equal(t, 10, inserted)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, chain.inserted)
+}
+
+func TestBlockPipeline_InsertBlocksInvalidSignatures(t *testing.T) {
+	chain := newPipelineChain()
+	chain.sigErr[7] = errors.New("invalid committee signature")
+	pipeline := NewBlockPipeline(PipelineConfig{SignatureWorkers: 2, TxWorkers: 2, Lookahead: 4})
+	inserted, err := pipeline.InsertBlocks(chain, newPipelineBlocks(20, sameEpoch))
+	assert.Equal(t, chain.sigErr[7], err)
+	assert.Equal(t, 6, inserted)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6}, chain.inserted)
+}
+
+func TestBlockPipeline_InsertBlocksNextEpoch(t *testing.T) {
+	chain := newPipelineChain()
+	//the committee of the next epoch is unknown, the signatures of its blocks are validated on insert
+	chain.sigErr[8] = errors.New("signed by the committee of the next epoch")
+	pipeline := NewBlockPipeline(PipelineConfig{})
+	inserted, err := pipeline.InsertBlocks(chain, newPipelineBlocks(10, func(height uint64) uint64 {
+		if height > 5 {
+			return 2
+		}
+		return 1
+	}))
+	assert.Nil(t, err)
+	assert.Equal(t, 10, inserted)
+	assert.Equal(t, 0, chain.sigCalls[8])
+	assert.Equal(t, 1, chain.sigCalls[5])
+}
+
+func TestBlockPipeline_InsertBlocksInsertError(t *testing.T) {
+	chain := newPipelineChain()
+	chain.insertErr[3] = errors.New("invalid state root")
+	pipeline := NewBlockPipeline(PipelineConfig{})
+	inserted, err := pipeline.InsertBlocks(chain, newPipelineBlocks(10, sameEpoch))
+	assert.Equal(t, chain.insertErr[3], err)
+	assert.Equal(t, 2, inserted)
+}
@@ -2,7 +2,6 @@ package syncker
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"sync"
 	"time"
@@ -35,9 +34,11 @@ type ShardSyncProcess struct {
 	shardPool             *BlkPool
 	actionCh              chan func()
 	lock                  *sync.RWMutex
+	pipeline              *BlockPipeline
+	peerScorer            *PeerScorer
 }
 
-func NewShardSyncProcess(shardID int, server Server, beaconChain BeaconChainInterface, chain ShardChainInterface) *ShardSyncProcess {
+func NewShardSyncProcess(shardID int, server Server, beaconChain BeaconChainInterface, chain ShardChainInterface, stateSyncer *StateSyncer, pipeline *BlockPipeline) *ShardSyncProcess {
 	var isOutdatedBlock = func(blk interface{}) bool {
 		if blk.(*blockchain.ShardBlock).GetHeight() < chain.GetFinalViewHeight() {
 			return true
@@ -54,13 +55,21 @@ func NewShardSyncProcess(shardID int, server Server, beaconChain BeaconChainInte
 		shardPool:        NewBlkPool("ShardPool-"+string(shardID), isOutdatedBlock),
 		shardPeerState:   make(map[string]ShardPeerState),
 		shardPeerStateCh: make(chan *wire.MessagePeerState),
+		pipeline:         pipeline,
+		peerScorer:       NewPeerScorer(),
 
 		actionCh: make(chan func()),
 	}
 	s.crossShardSyncProcess = NewCrossShardSyncProcess(server, s, beaconChain)
 
-	go s.syncShardProcess()
-	go s.insertShardBlockFromPool()
+	go func() {
+		//download the state of a peer first, blocks are synced from its height
+		if stateSyncer != nil && chain.GetFinalViewHeight() <= 1 {
+			stateSyncer.syncShardState(s)
+		}
+		go s.syncShardProcess()
+		go s.insertShardBlockFromPool()
+	}()
 
 	go func() {
 		ticker := time.NewTicker(time.Millisecond * 500)
@@ -151,21 +160,23 @@ func (s *ShardSyncProcess) insertShardBlockFromPool() {
 			}
 
 			//fullnode delay 1 block (make sure insert final block)
-			if os.Getenv("FULLNODE") != "" {
-				preBlk := s.shardPool.GetBlockByPrevHash(*blk.Hash())
-				if len(preBlk) == 0 {
-					continue
-				}
+			blks := collectPoolBlocks(s.shardPool, blk, MAX_PIPELINE_BLOCK, os.Getenv("FULLNODE") != "")
+			if len(blks) == 0 {
+				continue
 			}
 
 			insertShardTimeCache.Add(viewHash.String(), time.Now())
 			insertCnt++
-			//must validate this block when insert
-			if err := s.Chain.InsertBlk(blk.(common.BlockInterface), true); err != nil {
-				Logger.Error("Insert shard block from pool fail", blk.GetHeight(), blk.Hash(), err)
+			//must validate these blocks when insert
+			inserted, err := s.pipeline.InsertBlocks(s.Chain, blks)
+			for _, insertedBlk := range blks[:inserted] {
+				s.shardPool.RemoveBlock(insertedBlk.Hash())
+			}
+			if err != nil {
+				failBlk := blks[inserted]
+				Logger.Error("Insert shard block from pool fail", failBlk.GetHeight(), failBlk.Hash(), err)
 				continue
 			}
-			s.shardPool.RemoveBlock(blk.Hash())
 		}
 	}
 }
@@ -179,9 +190,7 @@ func (s *ShardSyncProcess) syncShardProcess() {
 			continue
 		}
 
-		for peerID, pState := range s.getShardPeerStates() {
-			requestCnt += s.streamFromPeer(peerID, pState)
-		}
+		requestCnt += s.streamFromPeers(s.getShardPeerStates())
 
 		if requestCnt > 0 {
 			s.isCatchUp = false
@@ -196,83 +205,78 @@ func (s *ShardSyncProcess) syncShardProcess() {
 
 }
 
-func (s *ShardSyncProcess) streamFromPeer(peerID string, pState ShardPeerState) (requestCnt int) {
-	if pState.processed {
-		return
-	}
-
-	blockBuffer := []common.BlockInterface{}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
-	defer func() {
-		if requestCnt == 0 {
-			pState.processed = true
+// streamFromPeers downloads the blocks up to the best height of the peers, from several peers at once
+func (s *ShardSyncProcess) streamFromPeers(peerStates map[string]ShardPeerState) (requestCnt int) {
+	peers := make(map[string]uint64)
+	toHeight := uint64(0)
+	for peerID, pState := range peerStates {
+		height := pState.BestViewHeight
+		//fullnode delay 1 block (make sure insert final block)
+		if os.Getenv("FULLNODE") != "" && height > 0 {
+			height--
+		}
+		peers[peerID] = height
+		if height > toHeight {
+			toHeight = height
 		}
-		cancel()
-	}()
-
-	if pState.processed {
-		return
-	}
-	toHeight := pState.BestViewHeight
-
-	//fullnode delay 1 block (make sure insert final block)
-	if os.Getenv("FULLNODE") != "" {
-		toHeight = pState.BestViewHeight - 1
 	}
 
 	if toHeight <= s.Chain.GetBestViewHeight() {
 		return
 	}
 
-	//fmt.Println("SYNCKER Request Shard Block", peerID, s.ShardID, s.Chain.GetBestViewHeight()+1, pState.BestViewHeight)
-	ch, err := s.Server.RequestShardBlocksViaStream(ctx, peerID, s.shardID, s.Chain.GetFinalViewHeight()+1, toHeight)
-	// ch, err := s.Server.RequestShardBlocksViaStream(ctx, "", s.shardID, s.Chain.GetBestViewHeight()+1, pState.BestViewHeight)
-	if err != nil {
-		fmt.Println("Syncker: create channel fail")
-		return
-	}
-
 	requestCnt++
-	insertTime := time.Now()
-	for {
-		select {
-		case blk := <-ch:
-			if !isNil(blk) {
-				blockBuffer = append(blockBuffer, blk)
-
-				if blk.(*blockchain.ShardBlock).Header.BeaconHeight > s.beaconChain.GetBestViewHeight() {
-					time.Sleep(30 * time.Second)
-				}
-				// if blk.(*blockchain.ShardBlock).Header.BeaconHeight > s.beaconChain.GetBestViewHeight() {
-				// 	Logger.Infof("Cannot find beacon for inserting shard block")
-				// 	return
-				// }
-			}
-
-			if uint64(len(blockBuffer)) >= 500 || (len(blockBuffer) > 0 && (isNil(blk) || time.Since(insertTime) > time.Millisecond*2000)) {
-				insertBlkCnt := 0
-				for {
-					time1 := time.Now()
-					if successBlk, err := InsertBatchBlock(s.Chain, blockBuffer); err != nil {
-						return
-					} else {
-						insertBlkCnt += successBlk
-						fmt.Printf("Syncker Insert %d shard %d block(from %d to %d) elaspse %f \n", successBlk, s.shardID, blockBuffer[0].GetHeight(), blockBuffer[len(blockBuffer)-1].GetHeight(), time.Since(time1).Seconds())
-						if successBlk >= len(blockBuffer) || successBlk == 0 {
-							break
-						}
-						blockBuffer = blockBuffer[successBlk:]
-					}
-				}
+	request := func(ctx context.Context, peerID string, from uint64, to uint64) (chan common.BlockInterface, error) {
+		return s.Server.RequestShardBlocksViaStream(ctx, peerID, s.shardID, from, to)
+	}
+	downloader := NewRangeDownloader(DefaultDownloaderConfig(), s.peerScorer, request, s.validateSyncedBlock, s.insertSyncedBlocks)
+	if inserted, err := downloader.Download(peers, s.Chain.GetFinalViewHeight()+1, toHeight); err != nil {
+		Logger.Errorf("Syncker download shard %d blocks to height %d fail: %v", s.shardID, toHeight, err)
+		if inserted == 0 {
+			time.Sleep(time.Second)
+		}
+	}
+	return
+}
 
-				insertTime = time.Now()
-				blockBuffer = []common.BlockInterface{}
-			}
+//the signatures of a block of the current epoch are validated when it is downloaded, an invalid block bans its peer
+func (s *ShardSyncProcess) validateSyncedBlock(blk common.BlockInterface) error {
+	if blk.GetCurrentEpoch() != s.Chain.GetEpoch() {
+		return nil
+	}
+	return s.Chain.PreValidateBlockSignatures(blk, s.Chain.GetCommittee())
+}
 
-			if isNil(blk) && len(blockBuffer) == 0 {
-				return
+func (s *ShardSyncProcess) insertSyncedBlocks(blocks []common.BlockInterface) (int, error) {
+	//only insert the blocks whose beacon block is inserted
+	insertable := func() []common.BlockInterface {
+		beaconHeight := s.beaconChain.GetBestViewHeight()
+		for i, blk := range blocks {
+			if blk.(*blockchain.ShardBlock).Header.BeaconHeight > beaconHeight {
+				return blocks[:i]
 			}
 		}
+		return blocks
+	}
+	blockBuffer := insertable()
+	if len(blockBuffer) < len(blocks) {
+		time.Sleep(30 * time.Second)
+		blockBuffer = insertable()
 	}
 
+	insertBlkCnt := 0
+	for len(blockBuffer) > 0 {
+		time1 := time.Now()
+		successBlk, err := InsertBatchBlock(s.Chain, blockBuffer)
+		if err != nil {
+			return insertBlkCnt, err
+		}
+		if successBlk == 0 {
+			break
+		}
+		insertBlkCnt += successBlk
+		Logger.Infof("Syncker Insert %d shard %d block(from %d to %d) elaspse %f \n", successBlk, s.shardID, blockBuffer[0].GetHeight(), blockBuffer[successBlk-1].GetHeight(), time.Since(time1).Seconds())
+		blockBuffer = blockBuffer[successBlk:]
+	}
+	return insertBlkCnt, nil
 }
@@ -0,0 +1,277 @@
+package syncker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/dataaccessobject/rawdbv2"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/trie"
+)
+
+const (
+	stateSyncNodesPerRequest = 384 // number of trie nodes requested in one stream
+	stateSyncBloomSize       = 64  // megabytes of the bloom filter of known trie nodes
+	stateSyncMaxEmptyRequest = 10  // requests in a row without any node before a trie sync fails
+	stateSyncMaxAttempt      = 3   // failed state syncs of a chain before falling back to block sync
+)
+
+// errStateSyncNotReady is returned when the checkpoint of a peer cannot be
+// used yet, the state sync is tried again later without counting an attempt.
+var errStateSyncNotReady = errors.New("state checkpoint is not ready")
+
+// StateSyncer downloads the state tries of the final view of a peer into an
+// empty chain, so the chain continues syncing blocks from the height of the
+// view instead of inserting every block before it. Trie nodes are addressed
+// by their hash, so every downloaded node is verified before it is stored.
+// The committees restored from the tries are verified against the checkpoint
+// block header when the view is inserted. The beacon checkpoint block must
+// descend from the trusted checkpoint of the node, which is verified with the
+// committee signatures of the blocks between them: fast sync is not possible
+// without a trusted checkpoint.
+//
+// Blocks, transactions and cross shard confirmations older than the
+// checkpoint are not stored, and the BTC relaying chain is not synced.
+type StateSyncer struct {
+	server     Server
+	blockchain *blockchain.BlockChain
+}
+
+func NewStateSyncer(server Server, bc *blockchain.BlockChain) *StateSyncer {
+	return &StateSyncer{server: server, blockchain: bc}
+}
+
+// syncBeaconState downloads the beacon state once the beacon sync process is
+// started, and gives up after stateSyncMaxAttempt failures.
+func (s *StateSyncer) syncBeaconState(process *BeaconSyncProcess) {
+	for attempt := 0; attempt < stateSyncMaxAttempt; {
+		if process.status != RUNNING_SYNC {
+			time.Sleep(time.Second)
+			continue
+		}
+		err := s.syncBeacon(process)
+		if err == nil {
+			return
+		}
+		if err != errStateSyncNotReady {
+			attempt++
+		}
+		Logger.Infof("Syncker beacon state sync fail: %v", err)
+		time.Sleep(10 * time.Second)
+	}
+	Logger.Infof("Syncker beacon state sync fail %d times, sync beacon blocks instead", stateSyncMaxAttempt)
+}
+
+func (s *StateSyncer) syncBeacon(process *BeaconSyncProcess) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	cp, err := s.server.RequestStateCheckpoint(ctx, "", -1)
+	cancel()
+	if err != nil {
+		return err
+	}
+	view := &blockchain.BeaconBestState{}
+	if err := json.Unmarshal(cp.View, view); err != nil {
+		return err
+	}
+	if view.BeaconHeight <= s.blockchain.BeaconChain.GetFinalViewHeight() {
+		return errStateSyncNotReady
+	}
+	trusted := s.blockchain.GetTrustedCheckpoint()
+	if trusted == nil {
+		return errors.New("beacon state sync needs a trusted checkpoint")
+	}
+	if view.BeaconHeight < trusted.Height {
+		return errStateSyncNotReady
+	}
+	if err := s.verifyBeaconCheckpoint(process, view); err != nil {
+		return err
+	}
+	Logger.Infof("Syncker sync beacon state at height %d", view.BeaconHeight)
+	if err := s.syncStateTries(-1, cp, s.blockchain.GetBeaconChainDatabase()); err != nil {
+		return err
+	}
+	if err := s.blockchain.InsertBeaconStateCheckpoint(cp); err != nil {
+		return err
+	}
+	// cross shard blocks are confirmed from the beacon block after the checkpoint
+	return rawdbv2.StoreLastBeaconStateConfirmCrossShard(s.blockchain.GetBeaconChainDatabase(), LastCrossShardBeaconProcess{view.BeaconHeight + 1, view.LastCrossShardState})
+}
+
+// verifyBeaconCheckpoint checks that the final view of a peer is the block of
+// the trusted checkpoint or one of its descendants. The blocks from the
+// checkpoint to the view are downloaded by chunks from the beacon peers, the
+// committee signatures of a chunk are verified and its blocks discarded
+// before the next chunk is verified.
+func (s *StateSyncer) verifyBeaconCheckpoint(process *BeaconSyncProcess, view *blockchain.BeaconBestState) error {
+	trusted := s.blockchain.GetTrustedCheckpoint()
+	verifier, err := s.blockchain.NewBeaconCheckpointVerifier()
+	if err != nil {
+		return err
+	}
+	peers := make(map[string]uint64)
+	for peerID, pState := range process.getBeaconPeerStates() {
+		peers[peerID] = pState.BestViewHeight
+	}
+	verify := func(blocks []common.BlockInterface) (int, error) {
+		beaconBlocks := []*blockchain.BeaconBlock{}
+		for _, blk := range blocks {
+			beaconBlock, ok := blk.(*blockchain.BeaconBlock)
+			if !ok {
+				return 0, blockchain.NewInvalidBlockError(errors.New("not a beacon block"))
+			}
+			beaconBlocks = append(beaconBlocks, beaconBlock)
+		}
+		if err := verifier.Verify(beaconBlocks); err != nil {
+			return 0, err
+		}
+		return len(blocks), nil
+	}
+	downloader := NewRangeDownloader(DefaultDownloaderConfig(), process.peerScorer, s.server.RequestBeaconBlocksViaStream, nil, verify)
+	if _, err := downloader.Download(peers, trusted.Height, view.BeaconHeight); err != nil {
+		return err
+	}
+	last := verifier.GetLastBlock()
+	if last == nil || last.GetHeight() != view.BeaconHeight || !last.Hash().IsEqual(&view.BestBlockHash) {
+		return fmt.Errorf("beacon state checkpoint %v at height %d does not descend from the trusted checkpoint", view.BestBlockHash, view.BeaconHeight)
+	}
+	return nil
+}
+
+// syncShardState downloads the state of a shard once its sync process is
+// started, and gives up after stateSyncMaxAttempt failures. The checkpoint is
+// only used when its beacon block is finalized on the beacon chain.
+func (s *StateSyncer) syncShardState(process *ShardSyncProcess) {
+	for attempt := 0; attempt < stateSyncMaxAttempt; {
+		if process.status != RUNNING_SYNC {
+			time.Sleep(time.Second)
+			continue
+		}
+		if process.Chain.GetFinalViewHeight() > 1 {
+			return // restored from a preloaded database
+		}
+		err := s.syncShard(process.shardID)
+		if err == nil {
+			return
+		}
+		if err != errStateSyncNotReady {
+			attempt++
+		}
+		Logger.Infof("Syncker shard %d state sync fail: %v", process.shardID, err)
+		time.Sleep(10 * time.Second)
+	}
+	Logger.Infof("Syncker shard %d state sync fail %d times, sync shard blocks instead", process.shardID, stateSyncMaxAttempt)
+}
+
+func (s *StateSyncer) syncShard(shardID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	cp, err := s.server.RequestStateCheckpoint(ctx, "", shardID)
+	cancel()
+	if err != nil {
+		return err
+	}
+	view := &blockchain.ShardBestState{}
+	if err := json.Unmarshal(cp.View, view); err != nil {
+		return err
+	}
+	if view.ShardHeight <= s.blockchain.ShardChain[shardID].GetFinalViewHeight() {
+		return errStateSyncNotReady
+	}
+	if view.BeaconHeight > s.blockchain.BeaconChain.GetFinalViewHeight() {
+		return errStateSyncNotReady // wait for the beacon chain
+	}
+	if _, err := rawdbv2.GetFinalizedBeaconBlockHashByIndex(s.blockchain.GetBeaconChainDatabase(), view.BeaconHeight); err != nil {
+		return errStateSyncNotReady // older than the beacon checkpoint, wait for a newer view
+	}
+	Logger.Infof("Syncker sync shard %d state at height %d", shardID, view.ShardHeight)
+	if err := s.syncStateTries(shardID, cp, s.blockchain.GetShardChainDatabase(byte(shardID))); err != nil {
+		return err
+	}
+	return s.blockchain.InsertShardStateCheckpoint(byte(shardID), cp)
+}
+
+// syncStateTries downloads every state trie of cp into db
+func (s *StateSyncer) syncStateTries(cID int, cp *blockchain.StateCheckpoint, db incdb.Database) error {
+	roots, err := cp.GetStateRoots(cID)
+	if err != nil {
+		return err
+	}
+	bloom := trie.NewSyncBloom(stateSyncBloomSize, db)
+	defer bloom.Close()
+	for _, root := range roots {
+		if root == (common.Hash{}) {
+			continue
+		}
+		time1 := time.Now()
+		nodes, err := s.syncStateTrie(cID, root, db, bloom)
+		if err != nil {
+			return fmt.Errorf("sync state trie %v: %v", root, err)
+		}
+		Logger.Infof("Syncker sync state trie %v of chain %d, %d nodes elapse %f", root, cID, nodes, time.Since(time1).Seconds())
+	}
+	return nil
+}
+
+// syncStateTrie downloads the missing nodes of the trie at root from peers
+// and returns the number of downloaded nodes. The nodes which a peer did not
+// send are requested again.
+func (s *StateSyncer) syncStateTrie(cID int, root common.Hash, db incdb.Database, bloom *trie.SyncBloom) (int, error) {
+	sched := trie.NewSync(root, db, nil, bloom)
+	retry := []common.Hash{}
+	nodes, emptyRequests := 0, 0
+	for sched.Pending() > 0 {
+		hashes := append(retry, sched.Missing(stateSyncNodesPerRequest-len(retry))...)
+		if len(hashes) == 0 {
+			return nodes, errors.New("no missing node to request")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		results, err := s.server.RequestStateNodes(ctx, "", cID, hashes)
+		cancel()
+		if err != nil {
+			Logger.Infof("Syncker request state nodes fail: %v", err)
+		}
+
+		requested := make(map[common.Hash]bool)
+		for _, hash := range hashes {
+			requested[hash] = true
+		}
+		verified := []trie.SyncResult{}
+		for _, res := range results {
+			if !requested[res.Hash] || common.Keccak256Hash(res.Data) != res.Hash {
+				continue
+			}
+			delete(requested, res.Hash)
+			verified = append(verified, res)
+		}
+		retry = []common.Hash{}
+		for hash := range requested {
+			retry = append(retry, hash)
+		}
+		if len(verified) == 0 {
+			emptyRequests++
+			if emptyRequests >= stateSyncMaxEmptyRequest {
+				return nodes, fmt.Errorf("no state node is received in %d requests", emptyRequests)
+			}
+			time.Sleep(time.Second)
+			continue
+		}
+		emptyRequests = 0
+
+		if _, i, err := sched.Process(verified); err != nil {
+			return nodes, fmt.Errorf("process state node %v: %v", verified[i].Hash, err)
+		}
+		batch := db.NewBatch()
+		if err := sched.Commit(batch); err != nil {
+			return nodes, err
+		}
+		if err := batch.Write(); err != nil {
+			return nodes, err
+		}
+		nodes += len(verified)
+	}
+	return nodes, nil
+}
@@ -17,10 +17,12 @@ import (
 
 const MAX_S2B_BLOCK = 90
 const MAX_CROSSX_BLOCK = 10
+const MAX_PIPELINE_BLOCK = 256
 
 type SynckerManagerConfig struct {
 	Node       Server
 	Blockchain *blockchain.BlockChain
+	Pipeline   PipelineConfig
 }
 
 type SynckerManager struct {
@@ -44,7 +46,7 @@ func NewSynckerManager() *SynckerManager {
 	return s
 }
 
-func (synckerManager *SynckerManager) Init(config *SynckerManagerConfig) {
+func (synckerManager *SynckerManager) Init(config *SynckerManagerConfig) error {
 	synckerManager.config = config
 
 	//check preload beacon
@@ -55,17 +57,31 @@ func (synckerManager *SynckerManager) Init(config *SynckerManagerConfig) {
 			Logger.Infof("Preload beacon fail!")
 		} else {
 			config.Blockchain.RestoreBeaconViews()
+			//the preloaded database is not trusted beyond the checkpoint
+			if err := config.Blockchain.VerifyTrustedCheckpoint(); err != nil {
+				Logger.Errorf("Preloaded beacon database conflicts with the trusted checkpoint: %v", err)
+				return err
+			}
 		}
 	}
 
+	//fast sync state of empty chains, from the trusted checkpoint if any
+	var stateSyncer *StateSyncer
+	if config.Blockchain.GetConfig().FastSync || config.Blockchain.GetTrustedCheckpoint() != nil {
+		stateSyncer = NewStateSyncer(config.Node, config.Blockchain)
+	}
+
+	//blocks from the pools are validated in parallel before being inserted
+	pipeline := NewBlockPipeline(config.Pipeline)
+
 	//init beacon sync process
-	synckerManager.BeaconSyncProcess = NewBeaconSyncProcess(synckerManager.config.Node, synckerManager.config.Blockchain.BeaconChain)
+	synckerManager.BeaconSyncProcess = NewBeaconSyncProcess(synckerManager.config.Node, synckerManager.config.Blockchain.BeaconChain, stateSyncer, pipeline)
 	synckerManager.beaconPool = synckerManager.BeaconSyncProcess.beaconPool
 
 	//init shard sync process
 	for _, chain := range synckerManager.config.Blockchain.ShardChain {
 		sid := chain.GetShardID()
-		synckerManager.ShardSyncProcess[sid] = NewShardSyncProcess(sid, synckerManager.config.Node, synckerManager.config.Blockchain.BeaconChain, chain)
+		synckerManager.ShardSyncProcess[sid] = NewShardSyncProcess(sid, synckerManager.config.Node, synckerManager.config.Blockchain.BeaconChain, chain, stateSyncer, pipeline)
 		synckerManager.shardPool[sid] = synckerManager.ShardSyncProcess[sid].shardPool
 		synckerManager.CrossShardSyncProcess[sid] = synckerManager.ShardSyncProcess[sid].crossShardSyncProcess
 		synckerManager.crossShardPool[sid] = synckerManager.CrossShardSyncProcess[sid].crossShardPool
@@ -88,6 +104,7 @@ func (synckerManager *SynckerManager) Init(config *SynckerManagerConfig) {
 			}
 		}
 	}()
+	return nil
 }
 
 func (synckerManager *SynckerManager) Start() {
@@ -42,6 +42,33 @@ func (it *Iterator) Next() bool {
 	return false
 }
 
+// LeavesAfter returns the keys and values of at most limit leaves of it which
+// come after start. Subtries before start are skipped without being resolved.
+func LeavesAfter(it NodeIterator, start []byte, limit int) ([][]byte, [][]byte, error) {
+	var startPath []byte
+	if len(start) > 0 {
+		startPath = keybytesToHex(start)
+		startPath = startPath[:len(startPath)-1]
+	}
+	keys := [][]byte{}
+	values := [][]byte{}
+	descend := true
+	for len(keys) < limit && it.Next(descend) {
+		path := it.Path()
+		descend = startPath == nil || bytes.HasPrefix(startPath, path) || bytes.Compare(path, startPath) > 0
+		if !descend || !it.Leaf() {
+			continue
+		}
+		key := it.LeafKey()
+		if len(start) > 0 && bytes.Compare(key, start) <= 0 {
+			continue
+		}
+		keys = append(keys, common.CopyBytes(key))
+		values = append(values, common.CopyBytes(it.LeafBlob()))
+	}
+	return keys, values, it.Error()
+}
+
 // Prove generates the Merkle proof for the leaf node the iterator is currently
 // positioned on.
 func (it *Iterator) Prove() [][]byte {
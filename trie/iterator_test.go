@@ -0,0 +1,50 @@
+package trie
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestLeavesAfter(t *testing.T) {
+	diskdb, dbPath := newPrunerTestDB(t)
+	defer os.RemoveAll(dbPath)
+	defer diskdb.Close()
+
+	values := make(map[string]string)
+	for i := 0; i < 300; i++ {
+		values[fmt.Sprintf("%c-key-%064d", 'a'+i%3, i)] = fmt.Sprintf("value-%064d", i)
+	}
+	root := commitPrunerTestTrie(t, NewIntermediateWriter(diskdb), emptyRoot, values)
+	tr, err := NewPrefixTrie(root, NewIntermediateWriter(diskdb))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// read the leaves of prefix b in ranges of 7, each range continues after
+	// the last key of the previous one
+	got := 0
+	var start []byte
+	for {
+		keys, vals, err := LeavesAfter(tr.NodeIterator([]byte("b")), start, 7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, k := range keys {
+			if k[0] != 'b' || values[string(k)] != string(vals[i]) {
+				t.Fatalf("unexpected leaf %s = %s", k, vals[i])
+			}
+			if start != nil && string(k) <= string(start) {
+				t.Fatalf("leaf %s is not after %s", k, start)
+			}
+			start = k
+		}
+		got += len(keys)
+		if len(keys) < 7 {
+			break
+		}
+	}
+	if got != 100 {
+		t.Fatalf("got %+v leaves of prefix b, want 100", got)
+	}
+}
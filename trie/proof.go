@@ -2,6 +2,7 @@ package trie
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/rlp"
@@ -16,7 +17,7 @@ import (
 // If the trie does not contain a value for key, the returned proof contains all
 // nodes of the longest existing prefix of the key (at least the root node), ending
 // with the node that proves the absence of the key.
-func (t *Trie) Prove(key []byte, fromLevel uint, proofDb incdb.Database) error {
+func (t *Trie) Prove(key []byte, fromLevel uint, proofDb incdb.KeyValueWriter) error {
 	// Collect all nodes on the path to key.
 	key = keybytesToHex(key)
 	var nodes []node
@@ -79,7 +80,7 @@ func (t *Trie) Prove(key []byte, fromLevel uint, proofDb incdb.Database) error {
 // If the trie does not contain a value for key, the returned proof contains all
 // nodes of the longest existing prefix of the key (at least the root node), ending
 // with the node that proves the absence of the key.
-func (t *SecureTrie) Prove(key []byte, fromLevel uint, proofDb incdb.Database) error {
+func (t *SecureTrie) Prove(key []byte, fromLevel uint, proofDb incdb.KeyValueWriter) error {
 	return t.trie.Prove(key, fromLevel, proofDb)
 }
 
@@ -90,14 +91,14 @@ func (t *SecureTrie) Prove(key []byte, fromLevel uint, proofDb incdb.Database) e
 // If the trie does not contain a value for key, the returned proof contains all
 // nodes of the longest existing prefix of the key (at least the root node), ending
 // with the node that proves the absence of the key.
-func (t *PrefixTrie) Prove(key []byte, fromLevel uint, proofDb incdb.Database) error {
+func (t *PrefixTrie) Prove(key []byte, fromLevel uint, proofDb incdb.KeyValueWriter) error {
 	return t.trie.Prove(key, fromLevel, proofDb)
 }
 
 // VerifyProof checks merkle proofs. The given proof must contain the value for
 // key in a trie with the given root hash. VerifyProof returns an error if the
 // proof contains invalid trie nodes or the wrong value.
-func VerifyProof(rootHash common.Hash, key []byte, proofDb incdb.Database) (value []byte, nodes int, err error) {
+func VerifyProof(rootHash common.Hash, key []byte, proofDb incdb.KeyValueReader) (value []byte, nodes int, err error) {
 	key = keybytesToHex(key)
 	wantHash := rootHash
 	for i := 0; ; i++ {
@@ -123,6 +124,39 @@ func VerifyProof(rootHash common.Hash, key []byte, proofDb incdb.Database) (valu
 	}
 }
 
+// ProofList is a merkle proof as a list of encoded trie nodes. Prove appends
+// the nodes to the list, VerifyProof finds them by the hash of their encoding,
+// so a proof can be sent as a plain list of bytes and checked by a client.
+type ProofList [][]byte
+
+// Put appends a proof node, the key is its hash and is not stored.
+func (p *ProofList) Put(key []byte, value []byte) error {
+	*p = append(*p, common.CopyBytes(value))
+	return nil
+}
+
+// Delete is not supported by a proof list.
+func (p *ProofList) Delete(key []byte) error {
+	return errors.New("delete is not supported by proof list")
+}
+
+// Has reports whether the list contains a node with the hash key.
+func (p ProofList) Has(key []byte) (bool, error) {
+	value, _ := p.Get(key)
+	return value != nil, nil
+}
+
+// Get returns the node whose encoding hashes to key.
+func (p ProofList) Get(key []byte) ([]byte, error) {
+	for _, value := range p {
+		hash := common.Keccak256Hash(value)
+		if bytes.Equal(hash[:], key) {
+			return value, nil
+		}
+	}
+	return nil, fmt.Errorf("proof node %x not found", key)
+}
+
 func get(tn node, key []byte) ([]byte, node) {
 	for {
 		switch n := tn.(type) {
@@ -0,0 +1,55 @@
+package trie
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestProofListProveAndVerify(t *testing.T) {
+	diskdb, dbPath := newPrunerTestDB(t)
+	defer os.RemoveAll(dbPath)
+	defer diskdb.Close()
+	iw := NewIntermediateWriter(diskdb)
+
+	values := make(map[string]string)
+	for i := 0; i < 200; i++ {
+		values[fmt.Sprintf("key-%064d", i)] = fmt.Sprintf("value-%064d", i)
+	}
+	root := commitPrunerTestTrie(t, iw, emptyRoot, values)
+	tr, err := NewPrefixTrie(root, NewIntermediateWriter(diskdb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range values {
+		proof := ProofList{}
+		if err := tr.Prove([]byte(k), 0, &proof); err != nil {
+			t.Fatal(err)
+		}
+		got, _, err := VerifyProof(root, []byte(k), proof)
+		if err != nil || string(got) != v {
+			t.Fatalf("verify %+v = %+v %+v, want %+v", k, string(got), err, v)
+		}
+	}
+
+	// a proof of absence has no value
+	proof := ProofList{}
+	if err := tr.Prove([]byte("missing"), 0, &proof); err != nil {
+		t.Fatal(err)
+	}
+	if got, _, err := VerifyProof(root, []byte("missing"), proof); err != nil || got != nil {
+		t.Fatalf("verify missing key = %+v %+v", got, err)
+	}
+
+	// a tampered proof is rejected
+	k := fmt.Sprintf("key-%064d", 7)
+	proof = ProofList{}
+	if err := tr.Prove([]byte(k), 0, &proof); err != nil {
+		t.Fatal(err)
+	}
+	last := proof[len(proof)-1]
+	last[len(last)-1] ^= 0xff
+	if got, _, err := VerifyProof(root, []byte(k), proof); err == nil {
+		t.Fatalf("tampered proof is verified with value %+v", string(got))
+	}
+}
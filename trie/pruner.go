@@ -0,0 +1,263 @@
+package trie
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	"github.com/incognitochain/incognito-chain/metrics"
+)
+
+// pruneJournalKey is the database key of the journal of a running prune. It
+// is written before the first node is deleted and removed when the prune is
+// done, so an interrupted prune can be detected and resumed.
+var pruneJournalKey = []byte("trie-prune-journal")
+
+// pruneBatchSize is the number of nodes deleted by a sweep batch.
+const pruneBatchSize = 10000
+
+// pruneJournal records the roots a prune keeps and the last key it swept.
+// Deletions of a batch and the journal update are written in the same batch.
+type pruneJournal struct {
+	Roots   []common.Hash
+	LastKey []byte
+}
+
+// Pruner garbage collects the trie nodes of a disk database with a mark and
+// sweep: every node reachable from the kept roots is marked, then every trie
+// node of the database which is not marked is deleted.
+//
+// Only nodes which are not reachable from the kept roots are ever deleted, so
+// stopping a prune at any point leaves a consistent database. Tries can be
+// committed while a prune is running as long as every commit is done between
+// LockCommit and UnlockCommit: the nodes of the committed roots are marked
+// before the next batch of the sweep, so a node written again after the mark
+// phase is never swept.
+type Pruner struct {
+	diskdb incdb.Database
+
+	commitLock sync.Mutex    // Held by a commit of tries and by the write of a sweep batch
+	running    bool          // Whether a prune keeps the committed roots
+	committed  []common.Hash // Roots committed since the last sweep batch
+
+	markedNodes  metrics.Gauge // Nodes reachable from the kept roots
+	sweptKeys    metrics.Gauge // Keys of the database visited by the sweep
+	prunedNodes  metrics.Counter
+	prunedSize   metrics.Counter
+	pruneTimer   metrics.Timer
+	lastPruneEnd metrics.Gauge // Unix time of the last finished prune
+}
+
+// NewPruner returns a pruner of the trie nodes of diskdb, its metrics are
+// registered with name as prefix (eg. beacon, shard0).
+func NewPruner(diskdb incdb.Database, name string) *Pruner {
+	return &Pruner{
+		diskdb:       diskdb,
+		markedNodes:  metrics.GetOrRegisterGauge(name+"/prune/markednodes", nil),
+		sweptKeys:    metrics.GetOrRegisterGauge(name+"/prune/sweptkeys", nil),
+		prunedNodes:  metrics.GetOrRegisterCounter(name+"/prune/prunednodes", nil),
+		prunedSize:   metrics.GetOrRegisterCounter(name+"/prune/prunedsize", nil),
+		pruneTimer:   metrics.GetOrRegisterTimer(name+"/prune/time", nil),
+		lastPruneEnd: metrics.GetOrRegisterGauge(name+"/prune/lastend", nil),
+	}
+}
+
+// HasUnfinishedPrune reports whether a prune of this database was interrupted.
+func (pruner *Pruner) HasUnfinishedPrune() bool {
+	has, err := pruner.diskdb.Has(pruneJournalKey)
+	return err == nil && has
+}
+
+// LockCommit must be called before tries are committed to the database, no
+// batch of a running sweep is written until UnlockCommit is called.
+func (pruner *Pruner) LockCommit() {
+	pruner.commitLock.Lock()
+}
+
+// UnlockCommit ends a commit started by LockCommit, the nodes reachable from
+// roots are kept by the running prune.
+func (pruner *Pruner) UnlockCommit(roots []common.Hash) {
+	if pruner.running {
+		pruner.committed = append(pruner.committed, roots...)
+	}
+	pruner.commitLock.Unlock()
+}
+
+// Prune deletes every trie node of the database which is not reachable from
+// the roots returned by getRoots or from the roots committed during the
+// prune, it returns the number of deleted nodes. getRoots is called under
+// the commit lock so no commit is missed between the roots and the prune. If
+// a previous prune was interrupted, the sweep restarts from the last key it
+// committed.
+func (pruner *Pruner) Prune(getRoots func() ([]common.Hash, error)) (uint64, error) {
+	start := time.Now()
+	pruner.commitLock.Lock()
+	roots, err := getRoots()
+	if err == nil {
+		pruner.running = true
+		pruner.committed = nil
+	}
+	pruner.commitLock.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		pruner.commitLock.Lock()
+		pruner.running = false
+		pruner.committed = nil
+		pruner.commitLock.Unlock()
+	}()
+
+	journal := &pruneJournal{}
+	if data, err := pruner.diskdb.Get(pruneJournalKey); err == nil {
+		if err := json.Unmarshal(data, journal); err != nil {
+			return 0, err
+		}
+		Logger.log.Infof("Resume interrupted prune from key %x", journal.LastKey)
+	}
+	journal.Roots = roots
+
+	marked := make(map[common.Hash]struct{})
+	pruner.markedNodes.Update(0)
+	if err := pruner.mark(marked, roots); err != nil {
+		return 0, err
+	}
+	Logger.log.Infof("Prune mark %+v nodes reachable from %+v roots in %+v", len(marked), len(roots), time.Since(start))
+
+	pruned, err := pruner.sweep(marked, journal)
+	if err != nil {
+		return pruned, err
+	}
+	if err := pruner.diskdb.Delete(pruneJournalKey); err != nil {
+		return pruned, err
+	}
+	pruner.pruneTimer.UpdateSince(start)
+	pruner.lastPruneEnd.Update(time.Now().Unix())
+	Logger.log.Infof("Prune delete %+v nodes in %+v", pruned, time.Since(start))
+	return pruned, nil
+}
+
+// mark adds the hashes of the nodes reachable from roots to marked. Every
+// root must be fully present in the database, a missing node aborts the prune.
+func (pruner *Pruner) mark(marked map[common.Hash]struct{}, roots []common.Hash) error {
+	for _, root := range roots {
+		if root == (common.Hash{}) || root == emptyRoot {
+			continue
+		}
+		stack := []common.Hash{root}
+		for len(stack) > 0 {
+			hash := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if _, ok := marked[hash]; ok {
+				continue
+			}
+			blob, err := pruner.diskdb.Get(hash[:])
+			if err != nil || len(blob) == 0 {
+				return &MissingNodeError{NodeHash: hash}
+			}
+			n, err := decodeNode(hash[:], blob)
+			if err != nil {
+				return err
+			}
+			marked[hash] = struct{}{}
+			children := []common.Hash{}
+			gatherChildren(simplifyNode(n), &children)
+			stack = append(stack, children...)
+			if len(marked)%100000 == 0 {
+				pruner.markedNodes.Update(int64(len(marked)))
+			}
+		}
+	}
+	pruner.markedNodes.Update(int64(len(marked)))
+	return nil
+}
+
+// sweep deletes every trie node which is not marked. Keys are deleted by
+// batches, each batch also moves the journal forward. A batch is written
+// under the commit lock after the roots committed since the previous batch
+// are marked, its keys reachable from them are kept.
+func (pruner *Pruner) sweep(marked map[common.Hash]struct{}, journal *pruneJournal) (uint64, error) {
+	var pruned, swept uint64
+	keys := [][]byte{}
+	sizes := []int{}
+	batch := pruner.diskdb.NewBatch()
+	flush := func(lastKey []byte) error {
+		pruner.commitLock.Lock()
+		defer pruner.commitLock.Unlock()
+		if err := pruner.mark(marked, pruner.committed); err != nil {
+			return err
+		}
+		pruner.committed = nil
+		for i, key := range keys {
+			if _, ok := marked[common.BytesToHash(key)]; ok {
+				continue
+			}
+			if err := batch.Delete(key); err != nil {
+				return err
+			}
+			pruned++
+			pruner.prunedNodes.Inc(1)
+			pruner.prunedSize.Inc(int64(sizes[i]))
+		}
+		journal.LastKey = lastKey
+		data, err := json.Marshal(journal)
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(pruneJournalKey, data); err != nil {
+			return err
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+		keys, sizes = keys[:0], sizes[:0]
+		pruner.sweptKeys.Update(int64(swept))
+		return nil
+	}
+	// the journal is stored before the first deletion
+	if err := flush(journal.LastKey); err != nil {
+		return 0, err
+	}
+	it := pruner.diskdb.NewIteratorWithStart(journal.LastKey)
+	defer it.Release()
+	lastKey := journal.LastKey
+	for it.Next() {
+		key, value := it.Key(), it.Value()
+		swept++
+		if !isTrieNode(key, value) {
+			continue
+		}
+		if _, ok := marked[common.BytesToHash(key)]; ok {
+			continue
+		}
+		lastKey = common.CopyBytes(key)
+		keys = append(keys, lastKey)
+		sizes = append(sizes, len(key)+len(value))
+		if len(keys) >= pruneBatchSize {
+			if err := flush(lastKey); err != nil {
+				return pruned, err
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return pruned, err
+	}
+	if err := flush(lastKey); err != nil {
+		return pruned, err
+	}
+	return pruned, nil
+}
+
+// isTrieNode reports whether a database entry is a trie node, which is stored
+// with the hash of its rlp encoding as key.
+func isTrieNode(key []byte, value []byte) bool {
+	if len(key) != common.HashSize || len(value) == 0 {
+		return false
+	}
+	hash := common.Keccak256Hash(value)
+	return bytes.Equal(hash[:], key)
+}
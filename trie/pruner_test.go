@@ -0,0 +1,236 @@
+package trie
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incdb"
+	_ "github.com/incognitochain/incognito-chain/incdb/lvdb"
+)
+
+func newPrunerTestDB(t *testing.T) (incdb.Database, string) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %+v", err)
+	}
+	diskdb, err := incdb.Open("leveldb", dbPath)
+	if err != nil {
+		t.Fatalf("could not open db path: %s, %+v", dbPath, err)
+	}
+	return diskdb, dbPath
+}
+
+// commitPrunerTestTrie updates the trie at root with values and commits it to disk.
+func commitPrunerTestTrie(t *testing.T, iw *IntermediateWriter, root common.Hash, values map[string]string) common.Hash {
+	tr, err := NewPrefixTrie(root, iw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range values {
+		if err := tr.TryUpdate([]byte(k), []byte(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	newRoot, err := tr.Commit(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := iw.Commit(newRoot, false); err != nil {
+		t.Fatal(err)
+	}
+	return newRoot
+}
+
+func keepRoots(roots ...common.Hash) func() ([]common.Hash, error) {
+	return func() ([]common.Hash, error) {
+		return roots, nil
+	}
+}
+
+func countTrieNodes(t *testing.T, diskdb incdb.Database) int {
+	count := 0
+	it := diskdb.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		if isTrieNode(it.Key(), it.Value()) {
+			count++
+		}
+	}
+	return count
+}
+
+func TestPrunerKeepsReachableNodes(t *testing.T) {
+	diskdb, dbPath := newPrunerTestDB(t)
+	defer os.RemoveAll(dbPath)
+	defer diskdb.Close()
+	iw := NewIntermediateWriter(diskdb)
+
+	values := make(map[string]string)
+	for i := 0; i < 500; i++ {
+		values[fmt.Sprintf("key-%064d", i)] = fmt.Sprintf("value-%064d", i)
+	}
+	oldRoot := commitPrunerTestTrie(t, iw, emptyRoot, values)
+	updated := make(map[string]string)
+	for i := 0; i < 500; i += 2 {
+		updated[fmt.Sprintf("key-%064d", i)] = fmt.Sprintf("updated-%064d", i)
+	}
+	newRoot := commitPrunerTestTrie(t, iw, oldRoot, updated)
+	otherRoot := commitPrunerTestTrie(t, iw, emptyRoot, map[string]string{"other": "value"})
+	if err := diskdb.Put([]byte("not-a-trie-node-but-stored-with-the-trie"), []byte{1}); err != nil {
+		t.Fatal(err)
+	}
+	before := countTrieNodes(t, diskdb)
+
+	pruner := NewPruner(diskdb, "test")
+	pruned, err := pruner.Prune(keepRoots(newRoot, otherRoot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pruned == 0 || int(pruned) != before-countTrieNodes(t, diskdb) {
+		t.Fatalf("pruned %+v nodes, trie nodes before %+v after %+v", pruned, before, countTrieNodes(t, diskdb))
+	}
+	if pruner.HasUnfinishedPrune() {
+		t.Fatal("journal is not removed after prune")
+	}
+	if has, _ := diskdb.Has([]byte("not-a-trie-node-but-stored-with-the-trie")); !has {
+		t.Fatal("prune deletes a key which is not a trie node")
+	}
+
+	// every value of the kept roots is still readable from disk
+	tr, err := NewPrefixTrie(newRoot, NewIntermediateWriter(diskdb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range values {
+		if want, ok := updated[k]; ok {
+			v = want
+		}
+		got, err := tr.TryGet([]byte(k))
+		if err != nil || string(got) != v {
+			t.Fatalf("get %+v = %+v %+v, want %+v", k, string(got), err, v)
+		}
+	}
+	tr, err = NewPrefixTrie(otherRoot, NewIntermediateWriter(diskdb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := tr.TryGet([]byte("other")); err != nil || string(got) != "value" {
+		t.Fatalf("get other = %+v %+v", string(got), err)
+	}
+
+	// the old root is gone
+	if _, err := NewPrefixTrie(oldRoot, NewIntermediateWriter(diskdb)); err == nil {
+		t.Fatal("pruned root is still readable")
+	}
+
+	// pruning again with the same roots deletes nothing
+	pruned, err = pruner.Prune(keepRoots(newRoot, otherRoot))
+	if err != nil || pruned != 0 {
+		t.Fatalf("second prune deletes %+v nodes, err %+v", pruned, err)
+	}
+}
+
+func TestPrunerResumeInterruptedPrune(t *testing.T) {
+	diskdb, dbPath := newPrunerTestDB(t)
+	defer os.RemoveAll(dbPath)
+	defer diskdb.Close()
+	iw := NewIntermediateWriter(diskdb)
+
+	values := make(map[string]string)
+	for i := 0; i < 200; i++ {
+		values[fmt.Sprintf("key-%064d", i)] = fmt.Sprintf("value-%064d", i)
+	}
+	oldRoot := commitPrunerTestTrie(t, iw, emptyRoot, values)
+	newRoot := commitPrunerTestTrie(t, iw, oldRoot, map[string]string{"key-0": "updated"})
+
+	// a journal left by a crashed prune
+	data, _ := json.Marshal(&pruneJournal{Roots: []common.Hash{newRoot}, LastKey: newRoot[:]})
+	if err := diskdb.Put(pruneJournalKey, data); err != nil {
+		t.Fatal(err)
+	}
+	pruner := NewPruner(diskdb, "test")
+	if !pruner.HasUnfinishedPrune() {
+		t.Fatal("interrupted prune is not detected")
+	}
+	if _, err := pruner.Prune(keepRoots(newRoot)); err != nil {
+		t.Fatal(err)
+	}
+	if pruner.HasUnfinishedPrune() {
+		t.Fatal("journal is not removed after prune")
+	}
+	tr, err := NewPrefixTrie(newRoot, NewIntermediateWriter(diskdb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k := range values {
+		if _, err := tr.TryGet([]byte(k)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// a missing kept root aborts the prune before deleting anything
+	before := countTrieNodes(t, diskdb)
+	if _, err := pruner.Prune(keepRoots(common.HashH([]byte("missing")))); err == nil {
+		t.Fatal("prune with a missing root succeeds")
+	}
+	if before != countTrieNodes(t, diskdb) {
+		t.Fatal("aborted prune deletes nodes")
+	}
+}
+
+// commitOnSweepDB runs commit when the sweep of a prune starts iterating the
+// database, before any key is deleted.
+type commitOnSweepDB struct {
+	incdb.Database
+	commit func()
+}
+
+func (db *commitOnSweepDB) NewIteratorWithStart(start []byte) incdb.Iterator {
+	it := db.Database.NewIteratorWithStart(start)
+	db.commit()
+	return it
+}
+
+func TestPrunerKeepsRootsCommittedDuringPrune(t *testing.T) {
+	diskdb, dbPath := newPrunerTestDB(t)
+	defer os.RemoveAll(dbPath)
+	defer diskdb.Close()
+
+	values := make(map[string]string)
+	for i := 0; i < 200; i++ {
+		values[fmt.Sprintf("key-%064d", i)] = fmt.Sprintf("value-%064d", i)
+	}
+	// the nodes of oldRoot are not kept by the prune
+	oldRoot := commitPrunerTestTrie(t, NewIntermediateWriter(diskdb), emptyRoot, values)
+	keptRoot := commitPrunerTestTrie(t, NewIntermediateWriter(diskdb), emptyRoot, map[string]string{"other": "value"})
+
+	// the same trie is committed again while the sweep runs
+	var newRoot common.Hash
+	sweepDB := &commitOnSweepDB{Database: diskdb}
+	pruner := NewPruner(sweepDB, "test")
+	sweepDB.commit = func() {
+		pruner.LockCommit()
+		newRoot = commitPrunerTestTrie(t, NewIntermediateWriter(diskdb), emptyRoot, values)
+		pruner.UnlockCommit([]common.Hash{newRoot})
+	}
+	if _, err := pruner.Prune(keepRoots(keptRoot)); err != nil {
+		t.Fatal(err)
+	}
+	if newRoot != oldRoot {
+		t.Fatalf("root of the same trie %+v, want %+v", newRoot, oldRoot)
+	}
+	tr, err := NewPrefixTrie(newRoot, NewIntermediateWriter(diskdb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range values {
+		if got, err := tr.TryGet([]byte(k)); err != nil || string(got) != v {
+			t.Fatalf("get %+v = %+v %+v, want %+v", k, string(got), err, v)
+		}
+	}
+}